@@ -0,0 +1,126 @@
+// Package accounting keeps a ledger of the rewards and penalties of every validator, recording
+// their breakdown at each epoch transition into the database, so that the earnings of validators
+// can be accounted for between any two epochs.
+package accounting
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "accounting")
+
+// Service records the breakdown of the rewards and penalties of the validators at each epoch
+// transition of the head state.
+type Service struct {
+	ctx               context.Context
+	cancel            context.CancelFunc
+	beaconDB          db.NoHeadAccessDatabase
+	headFetcher       blockchain.HeadFetcher
+	stateNotifier     statefeed.Notifier
+	lastRecordedEpoch uint64
+}
+
+// Config options for the accounting service.
+type Config struct {
+	BeaconDB      db.NoHeadAccessDatabase
+	HeadFetcher   blockchain.HeadFetcher
+	StateNotifier statefeed.Notifier
+}
+
+// NewService initializes the service from configuration options.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		ctx:           ctx,
+		cancel:        cancel,
+		beaconDB:      cfg.BeaconDB,
+		headFetcher:   cfg.HeadFetcher,
+		stateNotifier: cfg.StateNotifier,
+	}
+}
+
+// Start the accounting service event loop.
+func (s *Service) Start() {
+	go s.run(s.ctx)
+}
+
+// Stop the accounting service event loop.
+func (s *Service) Stop() error {
+	defer s.cancel()
+	return nil
+}
+
+// Status reports the healthy status of the accounting service. Returning nil means service
+// is correctly running without error.
+func (s *Service) Status() error {
+	return nil
+}
+
+// recordRewards saves the breakdown of the rewards and penalties of the validators at the
+// transition to the epoch of the head state, once the head state enters a new epoch. The
+// breakdown is the one of the last epoch transition processed by the node.
+func (s *Service) recordRewards(ctx context.Context, headState *stateTrie.BeaconState) error {
+	epoch := helpers.CurrentEpoch(headState)
+	// No rewards nor penalties are applied at the transition to the first epoch.
+	if epoch <= 1 || epoch <= s.lastRecordedEpoch {
+		return nil
+	}
+	summary := state.ValidatorSummary
+	// After a restart, the head state may be in a new epoch without its transition being processed.
+	if len(summary) == 0 {
+		return nil
+	}
+	if len(summary) > headState.NumValidators() {
+		return errors.Errorf("epoch transition of %d validators does not match head state of %d validators", len(summary), headState.NumValidators())
+	}
+	rewards := make([]*precompute.Rewards, len(summary))
+	for i, v := range summary {
+		r := v.Rewards
+		rewards[i] = &r
+	}
+	if err := s.beaconDB.SaveValidatorRewards(ctx, epoch, rewards); err != nil {
+		return errors.Wrap(err, "could not save validator rewards")
+	}
+	s.lastRecordedEpoch = epoch
+	log.WithField("epoch", epoch).Debug("Recorded validator rewards of epoch transition")
+	return nil
+}
+
+func (s *Service) run(ctx context.Context) {
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := s.stateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	for {
+		select {
+		case event := <-stateChannel:
+			if event.Type != statefeed.BlockProcessed {
+				continue
+			}
+			headState, err := s.headFetcher.HeadState(ctx)
+			if err != nil {
+				log.WithError(err).Error("Head state is not available")
+				continue
+			}
+			if err := s.recordRewards(ctx, headState); err != nil {
+				log.WithError(err).Error("Could not record validator rewards")
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting goroutine")
+			return
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Subscription to state feed notifier failed")
+			return
+		}
+	}
+}
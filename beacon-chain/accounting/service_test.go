@@ -0,0 +1,62 @@
+package accounting
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestRecordRewards_OncePerEpoch(t *testing.T) {
+	beaconDB := dbutil.SetupDB(t)
+	defer dbutil.TeardownDB(t, beaconDB)
+	ctx := context.Background()
+	s := &Service{beaconDB: beaconDB}
+
+	summary := state.ValidatorSummary
+	defer func() {
+		state.ValidatorSummary = summary
+	}()
+	state.ValidatorSummary = []*precompute.Validator{
+		{Rewards: precompute.Rewards{SourceReward: 1, TargetReward: 2, HeadReward: 3}},
+		{Rewards: precompute.Rewards{SourcePenalty: 4, InactivityPenalty: 5}},
+	}
+	headState, err := stateTrie.InitializeFromProto(&pb.BeaconState{
+		Slot:       3*params.BeaconConfig().SlotsPerEpoch + 1,
+		Validators: []*ethpb.Validator{{}, {}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.recordRewards(ctx, headState); err != nil {
+		t.Fatal(err)
+	}
+	rewards, err := beaconDB.ValidatorRewards(ctx, 1, 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wanted := map[uint64]*precompute.Rewards{3: {SourcePenalty: 4, InactivityPenalty: 5}}
+	if !reflect.DeepEqual(wanted, rewards) {
+		t.Errorf("Wanted %v, received %v", wanted, rewards)
+	}
+
+	// The rewards of an epoch are only recorded once.
+	state.ValidatorSummary[1].Rewards.SourcePenalty = 6
+	if err := s.recordRewards(ctx, headState); err != nil {
+		t.Fatal(err)
+	}
+	rewards, err = beaconDB.ValidatorRewards(ctx, 1, 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(wanted, rewards) {
+		t.Errorf("Wanted %v, received %v", wanted, rewards)
+	}
+}
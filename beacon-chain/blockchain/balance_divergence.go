@@ -0,0 +1,136 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	effectiveBalanceDrops = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "effective_balance_imminent_drops",
+		Help: "The number of active validators whose effective balance drops at the next epoch transition at their current balance.",
+	})
+	effectiveBalanceIncreases = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "effective_balance_imminent_increases",
+		Help: "The number of active validators whose effective balance increases at the next epoch transition at their current balance.",
+	})
+)
+
+// balanceDivergence is an active validator whose balance crossed a hysteresis threshold of its
+// effective balance.
+type balanceDivergence struct {
+	ValidatorIndex       uint64 `json:"validator_index"`
+	Balance              uint64 `json:"balance"`
+	EffectiveBalance     uint64 `json:"effective_balance"`
+	NextEffectiveBalance uint64 `json:"next_effective_balance"`
+}
+
+// balanceDivergenceReport lists the validators whose effective balance changes at the next epoch
+// transition if their balance does not move until then.
+type balanceDivergenceReport struct {
+	Epoch      uint64               `json:"epoch"`
+	Drops      uint64               `json:"drops"`
+	Increases  uint64               `json:"increases"`
+	Validators []*balanceDivergence `json:"validators"`
+}
+
+// nextEffectiveBalance returns the effective balance set by the hysteresis of the epoch
+// transition, as in epoch.ProcessFinalUpdates, and whether it differs from the current one.
+func nextEffectiveBalance(balance uint64, effectiveBalance uint64) (uint64, bool) {
+	halfInc := params.BeaconConfig().EffectiveBalanceIncrement / 2
+	if balance >= effectiveBalance && effectiveBalance+3*halfInc >= balance {
+		return effectiveBalance, false
+	}
+	next := params.BeaconConfig().MaxEffectiveBalance
+	if next > balance-balance%params.BeaconConfig().EffectiveBalanceIncrement {
+		next = balance - balance%params.BeaconConfig().EffectiveBalanceIncrement
+	}
+	return next, next != effectiveBalance
+}
+
+// balanceDivergences compares the balances of the active validators of the state against their
+// effective balances.
+func balanceDivergences(state *stateTrie.BeaconState) (*balanceDivergenceReport, error) {
+	epoch := helpers.CurrentEpoch(state)
+	balances := state.Balances()
+	report := &balanceDivergenceReport{
+		Epoch:      epoch,
+		Validators: make([]*balanceDivergence, 0),
+	}
+	if err := state.ReadFromEveryValidator(func(idx int, val *stateTrie.ReadOnlyValidator) error {
+		if idx >= len(balances) || !helpers.IsActiveValidatorUsingTrie(val, epoch) {
+			return nil
+		}
+		next, changed := nextEffectiveBalance(balances[idx], val.EffectiveBalance())
+		if !changed {
+			return nil
+		}
+		if next < val.EffectiveBalance() {
+			report.Drops++
+		} else {
+			report.Increases++
+		}
+		report.Validators = append(report.Validators, &balanceDivergence{
+			ValidatorIndex:       uint64(idx),
+			Balance:              balances[idx],
+			EffectiveBalance:     val.EffectiveBalance(),
+			NextEffectiveBalance: next,
+		})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// checkBalanceDivergence records the validators of the epoch boundary state whose effective
+// balance changes at the next epoch transition, so operators can tell ahead of time which
+// validators are about to lose effective balance.
+func (s *Service) checkBalanceDivergence(state *stateTrie.BeaconState) {
+	report, err := balanceDivergences(state)
+	if err != nil {
+		log.WithError(err).Error("Could not compare balances against effective balances")
+		return
+	}
+	effectiveBalanceDrops.Set(float64(report.Drops))
+	effectiveBalanceIncreases.Set(float64(report.Increases))
+	if report.Drops > 0 {
+		log.WithFields(logrus.Fields{
+			"epoch":     report.Epoch,
+			"drops":     report.Drops,
+			"increases": report.Increases,
+		}).Info("Effective balances change at the next epoch transition")
+	}
+
+	s.balanceDivergenceLock.Lock()
+	defer s.balanceDivergenceLock.Unlock()
+	s.balanceDivergence = report
+}
+
+// BalanceDivergenceHandler is a handler to serve the /validators/balance_divergence page in
+// metrics. It writes the validators whose effective balance changes at the next epoch
+// transition, as of the last epoch boundary, as JSON.
+func (s *Service) BalanceDivergenceHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	s.balanceDivergenceLock.RLock()
+	report := s.balanceDivergence
+	s.balanceDivergenceLock.RUnlock()
+	if report == nil {
+		http.Error(w, "No epoch boundary was checked yet", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		log.WithError(err).Error("Failed to write balance divergences")
+	}
+}
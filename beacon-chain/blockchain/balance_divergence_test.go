@@ -0,0 +1,78 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestCheckBalanceDivergence(t *testing.T) {
+	maxBalance := params.BeaconConfig().MaxEffectiveBalance
+	inc := params.BeaconConfig().EffectiveBalanceIncrement
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	validators := []*ethpb.Validator{
+		{EffectiveBalance: maxBalance, ExitEpoch: farFuture},
+		{EffectiveBalance: maxBalance, ExitEpoch: farFuture},
+		{EffectiveBalance: maxBalance - 2*inc, ExitEpoch: farFuture},
+		{EffectiveBalance: maxBalance - 2*inc, ExitEpoch: farFuture},
+		// Exited validators are not reported.
+		{EffectiveBalance: maxBalance, ExitEpoch: 0},
+	}
+	balances := []uint64{
+		// Within the hysteresis above the effective balance.
+		maxBalance + inc,
+		// Below the effective balance, it drops at the next epoch transition.
+		maxBalance - inc - 1,
+		// Less than 1.5 increments above the effective balance.
+		maxBalance - inc,
+		// More than 1.5 increments above the effective balance, it increases.
+		maxBalance,
+		maxBalance - inc - 1,
+	}
+	state, err := stateTrie.InitializeFromProto(&pb.BeaconState{
+		Slot:       params.BeaconConfig().SlotsPerEpoch,
+		Validators: validators,
+		Balances:   balances,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := &Service{}
+	service.checkBalanceDivergence(state)
+
+	rec := httptest.NewRecorder()
+	service.BalanceDivergenceHandler(rec, httptest.NewRequest(http.MethodGet, "/validators/balance_divergence", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Wanted status %d, received %d", http.StatusOK, rec.Code)
+	}
+	report := &balanceDivergenceReport{}
+	if err := json.NewDecoder(rec.Body).Decode(report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Epoch != 1 || report.Drops != 1 || report.Increases != 1 {
+		t.Errorf("Wanted 1 drop and 1 increase at epoch 1, received %+v", report)
+	}
+	if len(report.Validators) != 2 {
+		t.Fatalf("Wanted 2 validators, received %d", len(report.Validators))
+	}
+	if v := report.Validators[0]; v.ValidatorIndex != 1 || v.NextEffectiveBalance != maxBalance-2*inc {
+		t.Errorf("Unexpected drop %+v", v)
+	}
+	if v := report.Validators[1]; v.ValidatorIndex != 3 || v.NextEffectiveBalance != maxBalance {
+		t.Errorf("Unexpected increase %+v", v)
+	}
+}
+
+func TestBalanceDivergenceHandler_NotChecked(t *testing.T) {
+	rec := httptest.NewRecorder()
+	(&Service{}).BalanceDivergenceHandler(rec, httptest.NewRequest(http.MethodGet, "/validators/balance_divergence", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Wanted status %d, received %d", http.StatusServiceUnavailable, rec.Code)
+	}
+}
@@ -38,6 +38,11 @@ type HeadFetcher interface {
 	HeadSeed(epoch uint64) ([32]byte, error)
 }
 
+// WarmUpChecker reports whether the caches of the node are warmed up after a restart.
+type WarmUpChecker interface {
+	WarmedUp() bool
+}
+
 // ForkFetcher retrieves the current fork information of the Ethereum beacon chain.
 type ForkFetcher interface {
 	CurrentFork() *pb.Fork
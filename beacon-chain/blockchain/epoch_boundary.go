@@ -0,0 +1,78 @@
+package blockchain
+
+import (
+	"context"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	"github.com/prysmaticlabs/prysm/shared/event"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/slotutil"
+	"github.com/sirupsen/logrus"
+)
+
+// precomputeEpochBoundaries advances the head state to the next epoch boundary halfway through
+// the last slot of every epoch, once the block of that slot is usually processed, so the first
+// block of the next epoch is processed on top of a state which went through the epoch transition.
+func (s *Service) precomputeEpochBoundaries(stateChannel chan *feed.Event, stateSub event.Subscription) {
+	// Wait for the chain to be initialized.
+	for initialized := false; !initialized; {
+		select {
+		case ev := <-stateChannel:
+			initialized = ev.Type == statefeed.Initialized
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Subscription to state notifier failed")
+			return
+		case <-s.ctx.Done():
+			stateSub.Unsubscribe()
+			return
+		}
+	}
+	stateSub.Unsubscribe()
+
+	delay := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second / 2
+	st := slotutil.GetSlotTicker(s.genesisTime, params.BeaconConfig().SecondsPerSlot)
+	defer st.Done()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case slot := <-st.C():
+			if (slot+1)%params.BeaconConfig().SlotsPerEpoch != 0 {
+				continue
+			}
+			select {
+			case <-time.After(delay):
+			case <-s.ctx.Done():
+				return
+			}
+			s.precomputeEpochBoundary(s.ctx, slot+1)
+		}
+	}
+}
+
+// precomputeEpochBoundary pre-computes the epoch boundary state at the boundary slot from the
+// current head state.
+func (s *Service) precomputeEpochBoundary(ctx context.Context, boundarySlot uint64) {
+	headState, err := s.HeadState(ctx)
+	if err != nil || headState == nil {
+		log.WithError(err).Debug("Could not get head state to pre-compute the epoch boundary state")
+		return
+	}
+	// The head state is too far behind for its next epoch to start at the boundary.
+	if helpers.StartSlot(helpers.NextEpoch(headState)) != boundarySlot {
+		return
+	}
+	start := time.Now()
+	if err := state.PrecomputeEpochBoundaryState(ctx, headState); err != nil {
+		log.WithError(err).Warn("Could not pre-compute the epoch boundary state")
+		return
+	}
+	log.WithFields(logrus.Fields{
+		"slot":     boundarySlot,
+		"duration": time.Since(start),
+	}).Debug("Pre-computed epoch boundary state")
+}
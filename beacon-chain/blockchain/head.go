@@ -5,9 +5,12 @@ import (
 
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"go.opencensus.io/trace"
 )
@@ -33,6 +36,11 @@ func (s *Service) updateHead(ctx context.Context, balances []uint64) error {
 		s.justifiedCheckpt = s.bestJustifiedCheckpt
 	}
 
+	// The boost of the timely block of a previous slot no longer applies.
+	if featureconfig.Get().ForkChoiceProposerBoost > 0 {
+		s.forkChoiceStore.ResetProposerBoost(s.CurrentSlot())
+	}
+
 	// Get head from the fork choice service.
 	f := s.finalizedCheckpt
 	j := s.justifiedCheckpt
@@ -40,6 +48,7 @@ func (s *Service) updateHead(ctx context.Context, balances []uint64) error {
 	if err != nil {
 		return err
 	}
+	headRoot = s.withholdLateBlock(headRoot, balances)
 
 	// Save head to the local service cache.
 	return s.saveHead(ctx, headRoot)
@@ -97,6 +106,16 @@ func (s *Service) saveHead(ctx context.Context, headRoot [32]byte) error {
 		return errors.Wrap(err, "could not save head root in DB")
 	}
 
+	// Send notification of the new head to the state feed.
+	s.stateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.HeadUpdated,
+		Data: &statefeed.HeadUpdatedData{
+			Slot:       newHeadBlock.Block.Slot,
+			BlockRoot:  headRoot,
+			ParentRoot: bytesutil.ToBytes32(newHeadBlock.Block.ParentRoot),
+		},
+	})
+
 	return nil
 }
 
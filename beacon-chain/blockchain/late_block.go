@@ -0,0 +1,113 @@
+package blockchain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+)
+
+var lateBlockHeadsWithheld = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "late_block_heads_withheld_total",
+	Help: "The number of times a block received after the attestation deadline of its slot was kept from becoming head.",
+})
+
+// lateBlock is a block received after the attestation deadline of its slot.
+type lateBlock struct {
+	slot       uint64
+	parentRoot [32]byte
+}
+
+// recordBlockArrival remembers the block if it was received after the attestation deadline of
+// its slot, a third into the slot, when the late block protection is enabled.
+func (s *Service) recordBlockArrival(root [32]byte, block *ethpb.BeaconBlock, arrival time.Time) {
+	if flags.Get().LateBlockParentWeight == 0 || s.genesisTime.IsZero() {
+		return
+	}
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	deadline := s.genesisTime.Add(time.Duration(block.Slot*secondsPerSlot)*time.Second + time.Duration(secondsPerSlot)*time.Second/3)
+	if !arrival.After(deadline) {
+		return
+	}
+
+	s.lateBlocksLock.Lock()
+	defer s.lateBlocksLock.Unlock()
+	if s.lateBlocks == nil {
+		s.lateBlocks = make(map[[32]byte]*lateBlock)
+	}
+	for r, b := range s.lateBlocks {
+		if b.slot+params.BeaconConfig().SlotsPerEpoch < block.Slot {
+			delete(s.lateBlocks, r)
+		}
+	}
+	s.lateBlocks[root] = &lateBlock{
+		slot:       block.Slot,
+		parentRoot: bytesutil.ToBytes32(block.ParentRoot),
+	}
+	log.WithFields(logrus.Fields{
+		"slot":      block.Slot,
+		"blockRoot": fmt.Sprintf("%#x", root),
+		"delay":     arrival.Sub(deadline),
+	}).Debug("Received block after the attestation deadline")
+}
+
+// boostTimelyBlock boosts the block in fork choice if it was received in its slot before the
+// attestation deadline, when the proposer boost is enabled. The validators attesting at the
+// deadline then vote for the block even if a competing block holds the votes of an earlier slot.
+func (s *Service) boostTimelyBlock(root [32]byte, block *ethpb.BeaconBlock, arrival time.Time) {
+	if featureconfig.Get().ForkChoiceProposerBoost == 0 || s.genesisTime.IsZero() {
+		return
+	}
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	slotStart := s.genesisTime.Add(time.Duration(block.Slot*secondsPerSlot) * time.Second)
+	deadline := slotStart.Add(time.Duration(secondsPerSlot) * time.Second / 3)
+	if arrival.Before(slotStart) || arrival.After(deadline) {
+		return
+	}
+	s.forkChoiceStore.ProcessProposerBoost(root)
+}
+
+// withholdLateBlock returns the parent of the fork choice head instead of the head if the head is
+// a late block which has not received any attestation weight yet, while its parent holds at least
+// the share of the justified balance set by the late block parent weight flag. This way a block
+// released late in its slot does not reorg the attestations of its slot, and the next proposer
+// builds on the block the validators voted for.
+func (s *Service) withholdLateBlock(headRoot [32]byte, balances []uint64) [32]byte {
+	threshold := flags.Get().LateBlockParentWeight
+	if threshold == 0 {
+		return headRoot
+	}
+	s.lateBlocksLock.Lock()
+	late, ok := s.lateBlocks[headRoot]
+	s.lateBlocksLock.Unlock()
+	if !ok {
+		return headRoot
+	}
+
+	node := s.forkChoiceStore.Node(headRoot)
+	parent := s.forkChoiceStore.Node(late.parentRoot)
+	if node == nil || parent == nil || node.Weight > 0 {
+		return headRoot
+	}
+	total := uint64(0)
+	for _, b := range balances {
+		total += b
+	}
+	if total == 0 || parent.Weight*100 < total*threshold {
+		return headRoot
+	}
+	lateBlockHeadsWithheld.Inc()
+	log.WithFields(logrus.Fields{
+		"slot":       late.slot,
+		"blockRoot":  fmt.Sprintf("%#x", headRoot),
+		"parentRoot": fmt.Sprintf("%#x", late.parentRoot),
+	}).Debug("Keeping the parent of a late block as head until the block receives attestations")
+	return late.parentRoot
+}
@@ -0,0 +1,114 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestWithholdLateBlock_KeepsParentWithAttestations(t *testing.T) {
+	flags.Init(&flags.GlobalFlags{LateBlockParentWeight: 50})
+	defer flags.Init(&flags.GlobalFlags{})
+	ctx := context.Background()
+
+	genesisRoot := [32]byte{'G'}
+	parentRoot := [32]byte{'A'}
+	lateRoot := [32]byte{'B'}
+	service := &Service{
+		genesisTime:     time.Unix(1000, 0),
+		forkChoiceStore: protoarray.New(0, 0, genesisRoot),
+	}
+	if err := service.forkChoiceStore.ProcessBlock(ctx, 1, parentRoot, genesisRoot, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.forkChoiceStore.ProcessBlock(ctx, 2, lateRoot, parentRoot, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	service.forkChoiceStore.ProcessAttestation(ctx, []uint64{0, 1}, parentRoot, 0)
+	balances := []uint64{10, 10}
+	headRoot, err := service.forkChoiceStore.Head(ctx, 0, genesisRoot, balances, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if headRoot != lateRoot {
+		t.Fatalf("Wanted fork choice head %#x, received %#x", lateRoot, headRoot)
+	}
+
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	onTime := service.genesisTime.Add(time.Duration(2*secondsPerSlot) * time.Second)
+	service.recordBlockArrival(lateRoot, &ethpb.BeaconBlock{Slot: 2, ParentRoot: parentRoot[:]}, onTime)
+	if root := service.withholdLateBlock(headRoot, balances); root != lateRoot {
+		t.Errorf("Block received on time was withheld, received head %#x", root)
+	}
+
+	late := onTime.Add(time.Duration(secondsPerSlot) * time.Second / 2)
+	service.recordBlockArrival(lateRoot, &ethpb.BeaconBlock{Slot: 2, ParentRoot: parentRoot[:]}, late)
+	if root := service.withholdLateBlock(headRoot, balances); root != parentRoot {
+		t.Errorf("Wanted parent %#x as head, received %#x", parentRoot, root)
+	}
+
+	// Once the late block receives attestations, it becomes head.
+	service.forkChoiceStore.ProcessAttestation(ctx, []uint64{0}, lateRoot, 1)
+	headRoot, err = service.forkChoiceStore.Head(ctx, 0, genesisRoot, balances, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root := service.withholdLateBlock(headRoot, balances); root != lateRoot {
+		t.Errorf("Wanted late block %#x as head, received %#x", lateRoot, root)
+	}
+}
+
+func TestBoostTimelyBlock_OutweighsPreviousSlotVotes(t *testing.T) {
+	featureconfig.Init(&featureconfig.Flags{ForkChoiceProposerBoost: 100})
+	defer featureconfig.Init(&featureconfig.Flags{})
+	ctx := context.Background()
+
+	genesisRoot := [32]byte{'G'}
+	previousRoot := [32]byte{'A'}
+	timelyRoot := [32]byte{'B'}
+	service := &Service{
+		genesisTime:     time.Unix(1000, 0),
+		forkChoiceStore: protoarray.NewWithWeights(0, 0, genesisRoot, protoarray.Weights{AttestationPercent: 100, ProposerBoostPercent: 100}),
+	}
+	if err := service.forkChoiceStore.ProcessBlock(ctx, 0, genesisRoot, params.BeaconConfig().ZeroHash, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.forkChoiceStore.ProcessBlock(ctx, 1, previousRoot, genesisRoot, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.forkChoiceStore.ProcessBlock(ctx, 2, timelyRoot, genesisRoot, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	balances := make([]uint64, 2*params.BeaconConfig().SlotsPerEpoch)
+	for i := range balances {
+		balances[i] = 10
+	}
+	service.forkChoiceStore.ProcessAttestation(ctx, []uint64{0}, previousRoot, 0)
+
+	// A block received after the attestation deadline is not boosted.
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	slotStart := service.genesisTime.Add(time.Duration(2*secondsPerSlot) * time.Second)
+	service.boostTimelyBlock(timelyRoot, &ethpb.BeaconBlock{Slot: 2}, slotStart.Add(time.Duration(secondsPerSlot)*time.Second/2))
+	headRoot, err := service.forkChoiceStore.Head(ctx, 0, genesisRoot, balances, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if headRoot != previousRoot {
+		t.Fatalf("Wanted head %#x, received %#x", previousRoot, headRoot)
+	}
+
+	service.boostTimelyBlock(timelyRoot, &ethpb.BeaconBlock{Slot: 2}, slotStart.Add(time.Second))
+	headRoot, err = service.forkChoiceStore.Head(ctx, 0, genesisRoot, balances, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if headRoot != timelyRoot {
+		t.Errorf("Wanted boosted head %#x, received %#x", timelyRoot, headRoot)
+	}
+}
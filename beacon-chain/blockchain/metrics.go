@@ -79,6 +79,10 @@ var (
 		Name: "total_voted_target_balances",
 		Help: "The total amount of ether, in gwei, that is eligible for voting of previous epoch",
 	})
+	participationRate = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "beacon_participation_rate",
+		Help: "The ratio of the balance which attested to the epoch boundary block over the total active balance",
+	}, []string{"epoch"})
 )
 
 // reportSlotMetrics reports slot related metrics.
@@ -176,5 +180,7 @@ func reportEpochMetrics(state *stateTrie.BeaconState) {
 	if precompute.Balances != nil {
 		totalEligibleBalances.Set(float64(precompute.Balances.PrevEpoch))
 		totalVotedTargetBalances.Set(float64(precompute.Balances.PrevEpochTargetAttesters))
+		participationRate.WithLabelValues("previous").Set(precompute.Balances.PrevEpochParticipationRate())
+		participationRate.WithLabelValues("current").Set(precompute.Balances.CurrentEpochParticipationRate())
 	}
 }
@@ -0,0 +1,144 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/emicklei/dot"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// nodeTree is the JSON representation of the fork choice store.
+type nodeTree struct {
+	HeadRoot            string          `json:"head_root"`
+	JustifiedEpoch      uint64          `json:"justified_epoch"`
+	JustifiedRoot       string          `json:"justified_root"`
+	FinalizedEpoch      uint64          `json:"finalized_epoch"`
+	FinalizedRoot       string          `json:"finalized_root"`
+	StoreJustifiedEpoch uint64          `json:"store_justified_epoch"`
+	StoreFinalizedEpoch uint64          `json:"store_finalized_epoch"`
+	StoreFinalizedRoot  string          `json:"store_finalized_root"`
+	ProposerBoostRoot   string          `json:"proposer_boost_root,omitempty"`
+	Nodes               []*nodeTreeNode `json:"nodes"`
+}
+
+// nodeTreeNode is the JSON representation of a block node of the fork choice store.
+type nodeTreeNode struct {
+	Index              int    `json:"index"`
+	Slot               uint64 `json:"slot"`
+	Root               string `json:"root"`
+	ParentRoot         string `json:"parent_root,omitempty"`
+	Weight             uint64 `json:"weight"`
+	JustifiedEpoch     uint64 `json:"justified_epoch"`
+	FinalizedEpoch     uint64 `json:"finalized_epoch"`
+	BestChildRoot      string `json:"best_child_root,omitempty"`
+	BestDescendantRoot string `json:"best_descendant_root,omitempty"`
+}
+
+// NodeTreeHandler is a handler to serve the /debug/nodetree page in metrics. It writes the
+// block nodes of the fork choice store with their weights, best child and best descendant,
+// along with the head and the justified and finalized checkpoints, as JSON, or as a DOT graph
+// with the format=dot query parameter.
+func (s *Service) NodeTreeHandler(w http.ResponseWriter, r *http.Request) {
+	if s.forkChoiceStore == nil {
+		http.Error(w, "Fork choice store is not initialized", http.StatusServiceUnavailable)
+		return
+	}
+	dump := s.forkChoiceStore.Dump()
+	headRoot := s.headRoot()
+
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "json":
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(s.nodeTree(dump, headRoot)); err != nil {
+			log.WithError(err).Error("Failed to write node tree")
+		}
+	case "dot":
+		w.Header().Set("Content-Type", "text/vnd.graphviz")
+		if _, err := fmt.Fprint(w, nodeTreeGraph(dump, headRoot).String()); err != nil {
+			log.WithError(err).Error("Failed to write node tree")
+		}
+	default:
+		http.Error(w, fmt.Sprintf("Unknown format %s, wanted json or dot", format), http.StatusBadRequest)
+	}
+}
+
+func (s *Service) nodeTree(dump *protoarray.Dump, headRoot [32]byte) *nodeTree {
+	justified := s.CurrentJustifiedCheckpt()
+	finalized := s.FinalizedCheckpt()
+	tree := &nodeTree{
+		HeadRoot:            fmt.Sprintf("%#x", headRoot),
+		JustifiedEpoch:      justified.Epoch,
+		JustifiedRoot:       fmt.Sprintf("%#x", justified.Root),
+		FinalizedEpoch:      finalized.Epoch,
+		FinalizedRoot:       fmt.Sprintf("%#x", finalized.Root),
+		StoreJustifiedEpoch: dump.JustifiedEpoch,
+		StoreFinalizedEpoch: dump.FinalizedEpoch,
+		StoreFinalizedRoot:  fmt.Sprintf("%#x", dump.FinalizedRoot),
+		Nodes:               make([]*nodeTreeNode, len(dump.Nodes)),
+	}
+	if dump.ProposerBoostRoot != params.BeaconConfig().ZeroHash {
+		tree.ProposerBoostRoot = fmt.Sprintf("%#x", dump.ProposerBoostRoot)
+	}
+	rootAt := func(index uint64) string {
+		if index >= uint64(len(dump.Nodes)) {
+			return ""
+		}
+		return fmt.Sprintf("%#x", dump.Nodes[index].Root())
+	}
+	for i, n := range dump.Nodes {
+		tree.Nodes[i] = &nodeTreeNode{
+			Index:              i,
+			Slot:               n.Slot,
+			Root:               rootAt(uint64(i)),
+			ParentRoot:         rootAt(n.Parent),
+			Weight:             n.Weight,
+			JustifiedEpoch:     n.JustifiedEpoch(),
+			FinalizedEpoch:     n.FinalizedEpoch(),
+			BestChildRoot:      rootAt(n.BestChild()),
+			BestDescendantRoot: rootAt(n.BestDescendent),
+		}
+	}
+	return tree
+}
+
+// nodeTreeGraph returns the fork choice store as a graph of the block nodes pointing to their
+// parent, with the edges of best children in bold, the head in green and the finalized node
+// of the store in blue.
+func nodeTreeGraph(dump *protoarray.Dump, headRoot [32]byte) *dot.Graph {
+	graph := dot.NewGraph(dot.Directed)
+	graph.Attr("rankdir", "RL")
+	graph.Attr("labeljust", "l")
+
+	dotNodes := make([]dot.Node, len(dump.Nodes))
+	for i, n := range dump.Nodes {
+		root := n.Root()
+		label := fmt.Sprintf(
+			"slot: %d\nroot: %#x\nweight: %d ETH\njustified epoch: %d\nfinalized epoch: %d",
+			n.Slot,
+			bytesutil.Trunc(root[:]),
+			n.Weight/params.BeaconConfig().GweiPerEth,
+			n.JustifiedEpoch(),
+			n.FinalizedEpoch(),
+		)
+		dotNodes[i] = graph.Node(fmt.Sprintf("%d", i)).Box().Attr("label", label)
+		if root == headRoot {
+			dotNodes[i].Attr("color", "green")
+		} else if root == dump.FinalizedRoot {
+			dotNodes[i].Attr("color", "blue")
+		}
+	}
+	for i, n := range dump.Nodes {
+		if n.Parent >= uint64(len(dotNodes)) {
+			continue
+		}
+		edge := graph.Edge(dotNodes[i], dotNodes[n.Parent])
+		if dump.Nodes[n.Parent].BestChild() == uint64(i) {
+			edge.Attr("style", "bold")
+		}
+	}
+	return graph
+}
@@ -0,0 +1,59 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+)
+
+func TestNodeTreeHandler(t *testing.T) {
+	ctx := context.Background()
+	genesis := [32]byte{'g'}
+	store := protoarray.New(0, 0, genesis)
+	if err := store.ProcessBlock(ctx, 0, genesis, [32]byte{}, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.ProcessBlock(ctx, 1, [32]byte{'a'}, genesis, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	s := &Service{forkChoiceStore: store}
+
+	rec := httptest.NewRecorder()
+	s.NodeTreeHandler(rec, httptest.NewRequest(http.MethodGet, "/debug/nodetree", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Wanted status %d, received %d", http.StatusOK, rec.Code)
+	}
+	tree := &nodeTree{}
+	if err := json.NewDecoder(rec.Body).Decode(tree); err != nil {
+		t.Fatal(err)
+	}
+	if len(tree.Nodes) != 2 {
+		t.Fatalf("Wanted 2 nodes, received %d", len(tree.Nodes))
+	}
+	if tree.Nodes[1].ParentRoot != tree.Nodes[0].Root {
+		t.Errorf("Wanted parent root %s, received %s", tree.Nodes[0].Root, tree.Nodes[1].ParentRoot)
+	}
+	if tree.Nodes[0].BestChildRoot != tree.Nodes[1].Root {
+		t.Errorf("Wanted best child root %s, received %s", tree.Nodes[1].Root, tree.Nodes[0].BestChildRoot)
+	}
+
+	rec = httptest.NewRecorder()
+	s.NodeTreeHandler(rec, httptest.NewRequest(http.MethodGet, "/debug/nodetree?format=dot", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Wanted status %d, received %d", http.StatusOK, rec.Code)
+	}
+	if !strings.HasPrefix(rec.Body.String(), "digraph") {
+		t.Errorf("Wanted a DOT graph, received %s", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	s.NodeTreeHandler(rec, httptest.NewRequest(http.MethodGet, "/debug/nodetree?format=xml", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Wanted status %d, received %d", http.StatusBadRequest, rec.Code)
+	}
+}
@@ -19,10 +19,34 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/params"
 )
 
-// getAttPreState retrieves the att pre state by either from the cache or the DB.
+// getAttPreState retrieves the att pre state by either from the cache or the DB. The state of a
+// target block which is not saved in the DB is regenerated by replaying the blocks since its
+// closest ancestor with a saved state, once per checkpoint, as the resulting state is cached.
+// Concurrent lookups of the same checkpoint share a single regeneration, while lookups of other
+// checkpoints proceed in parallel.
 func (s *Service) getAttPreState(ctx context.Context, c *ethpb.Checkpoint) (*stateTrie.BeaconState, error) {
-	s.checkpointStateLock.Lock()
-	defer s.checkpointStateLock.Unlock()
+	cachedState, err := s.checkpointState.StateByCheckpoint(c)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get cached checkpoint state")
+	}
+	if cachedState != nil {
+		return cachedState, nil
+	}
+	st, err, shared := s.checkpointStateGroup.Do(fmt.Sprintf("%d/%#x", c.Epoch, c.Root), func() (interface{}, error) {
+		return s.generateAttPreState(ctx, c)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if shared {
+		return st.(*stateTrie.BeaconState).Copy(), nil
+	}
+	return st.(*stateTrie.BeaconState), nil
+}
+
+// generateAttPreState generates the att pre state of the checkpoint and caches it, unless a
+// concurrent lookup of the checkpoint cached it already.
+func (s *Service) generateAttPreState(ctx context.Context, c *ethpb.Checkpoint) (*stateTrie.BeaconState, error) {
 	cachedState, err := s.checkpointState.StateByCheckpoint(c)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get cached checkpoint state")
@@ -50,9 +74,20 @@ func (s *Service) getAttPreState(ctx context.Context, c *ethpb.Checkpoint) (*sta
 		}
 	}
 
-	baseState, err := s.beaconDB.State(ctx, bytesutil.ToBytes32(c.Root))
-	if err != nil {
-		return nil, errors.Wrapf(err, "could not get pre state for slot %d", helpers.StartSlot(c.Epoch))
+	// Recently processed target blocks have their post-state cached, which avoids reading and
+	// regenerating the state from the DB for every attestation on the target.
+	baseState := s.postStateCache.StateByRoot(bytesutil.ToBytes32(c.Root))
+	if baseState == nil {
+		baseState, err = s.beaconDB.State(ctx, bytesutil.ToBytes32(c.Root))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get pre state for slot %d", helpers.StartSlot(c.Epoch))
+		}
+	}
+	if baseState == nil && s.beaconDB.HasBlock(ctx, bytesutil.ToBytes32(c.Root)) {
+		baseState, err = s.regenerateTargetState(ctx, bytesutil.ToBytes32(c.Root))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not regenerate pre state for slot %d", helpers.StartSlot(c.Epoch))
+		}
 	}
 	if baseState == nil {
 		return nil, fmt.Errorf("pre state of target block %d does not exist", helpers.StartSlot(c.Epoch))
@@ -75,6 +110,26 @@ func (s *Service) getAttPreState(ctx context.Context, c *ethpb.Checkpoint) (*sta
 	return baseState, nil
 }
 
+// regenerateTargetState replays the blocks from the closest ancestor of the target block with a
+// saved state up to the target block.
+func (s *Service) regenerateTargetState(ctx context.Context, targetRoot [32]byte) (*stateTrie.BeaconState, error) {
+	startRoot := targetRoot
+	for !s.beaconDB.HasState(ctx, startRoot) {
+		b, err := s.beaconDB.Block(ctx, startRoot)
+		if err != nil {
+			return nil, err
+		}
+		if b == nil || b.Block == nil {
+			return nil, fmt.Errorf("no saved state in the ancestors of target block %#x", bytesutil.Trunc(targetRoot[:]))
+		}
+		startRoot = bytesutil.ToBytes32(b.Block.ParentRoot)
+	}
+	if startRoot == targetRoot {
+		return s.beaconDB.State(ctx, targetRoot)
+	}
+	return s.generateState(ctx, startRoot, targetRoot)
+}
+
 // verifyAttTargetEpoch validates attestation is from the current or previous epoch.
 func (s *Service) verifyAttTargetEpoch(ctx context.Context, genesisTime uint64, nowTime uint64, c *ethpb.Checkpoint) error {
 	currentSlot := (nowTime - genesisTime) / params.BeaconConfig().SecondsPerSlot
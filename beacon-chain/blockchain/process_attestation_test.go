@@ -4,10 +4,12 @@ import (
 	"context"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
 	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
@@ -276,6 +278,57 @@ func TestStore_UpdateCheckpointState(t *testing.T) {
 	}
 }
 
+func TestStore_GetAttPreState_ConcurrentLookups(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+
+	service, err := NewService(ctx, &Config{BeaconDB: db})
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseState, _ := testutil.DeterministicGenesisState(t, 1)
+	if err := baseState.SetSlot(params.BeaconConfig().SlotsPerEpoch); err != nil {
+		t.Fatal(err)
+	}
+	checkpoints := []*ethpb.Checkpoint{{Epoch: 1, Root: []byte{'A'}}, {Epoch: 1, Root: []byte{'B'}}}
+	for _, c := range checkpoints {
+		if err := service.beaconDB.SaveState(ctx, baseState, bytesutil.ToBytes32(c.Root)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Lookups of the same checkpoint share the generated state, and each receive their own copy.
+	states := make([]*stateTrie.BeaconState, 8)
+	var wg sync.WaitGroup
+	for i := range states {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			st, err := service.getAttPreState(ctx, checkpoints[i%len(checkpoints)])
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			states[i] = st
+		}(i)
+	}
+	wg.Wait()
+	seen := make(map[*stateTrie.BeaconState]bool)
+	for _, st := range states {
+		if st == nil {
+			t.Fatal("Expected a state for every lookup")
+		}
+		if st.Slot() != baseState.Slot() {
+			t.Errorf("Wanted slot %d, received %d", baseState.Slot(), st.Slot())
+		}
+		if seen[st] {
+			t.Error("Expected every lookup to receive its own copy of the state")
+		}
+		seen[st] = true
+	}
+}
+
 func TestAttEpoch_MatchPrevEpoch(t *testing.T) {
 	ctx := context.Background()
 	db := testDB.SetupDB(t)
@@ -395,3 +448,96 @@ func TestVerifyBeaconBlock_OK(t *testing.T) {
 		t.Error("Did not receive the wanted error")
 	}
 }
+
+func TestStore_GetAttPreState_UsesPostStateCache(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+
+	service, err := NewService(ctx, &Config{BeaconDB: db})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	epoch := uint64(1)
+	baseState, _ := testutil.DeterministicGenesisState(t, 1)
+	baseState.SetSlot(epoch * params.BeaconConfig().SlotsPerEpoch)
+	root := [32]byte{'A'}
+	// The state is only in the post-state cache, not in the DB.
+	service.postStateCache.AddState(root, baseState)
+	returned, err := service.getAttPreState(ctx, &ethpb.Checkpoint{Epoch: epoch, Root: root[:]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if returned.Slot() != baseState.Slot() {
+		t.Errorf("Wanted cached post-state at slot %d, got %d", baseState.Slot(), returned.Slot())
+	}
+}
+
+func TestStore_GetAttPreState_RegeneratesMissingState(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+
+	service, err := NewService(ctx, &Config{BeaconDB: db})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	beaconState, privs := testutil.DeterministicGenesisState(t, 32)
+	stateRoot, err := beaconState.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesisBlock := blocks.NewGenesisBlock(stateRoot[:])
+	if err := db.SaveBlock(ctx, genesisBlock); err != nil {
+		t.Fatal(err)
+	}
+	genesisRoot, err := ssz.HashTreeRoot(genesisBlock.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, beaconState, genesisRoot); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the blocks are saved, not their states.
+	var targetRoot [32]byte
+	for i := uint64(1); i < 4; i++ {
+		block, err := testutil.GenerateFullBlock(beaconState, privs, testutil.DefaultBlockGenConfig(), i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		beaconState, err = state.ExecuteStateTransition(ctx, beaconState, block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := db.SaveBlock(ctx, block); err != nil {
+			t.Fatal(err)
+		}
+		targetRoot, err = ssz.HashTreeRoot(block.Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	wanted, err := state.ProcessSlots(ctx, beaconState, params.BeaconConfig().SlotsPerEpoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	target := &ethpb.Checkpoint{Epoch: 1, Root: targetRoot[:]}
+	received, err := service.AttestationTargetState(ctx, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ssz.DeepEqual(received.InnerStateUnsafe(), wanted.InnerStateUnsafe()) {
+		t.Error("Regenerated target state is different from the expected state")
+	}
+	cached, err := service.checkpointState.StateByCheckpoint(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached == nil {
+		t.Error("Expected the regenerated target state to be cached")
+	}
+}
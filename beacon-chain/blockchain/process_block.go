@@ -14,6 +14,7 @@ import (
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/shared/attestationutil"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
@@ -75,10 +76,13 @@ func (s *Service) onBlock(ctx context.Context, signed *ethpb.SignedBeaconBlock)
 		"root": fmt.Sprintf("0x%s...", hex.EncodeToString(root[:])[:8]),
 	}).Info("Executing state transition on block")
 
+	s.attachMutationFeed(preState)
+	defer preState.SetMutationFeed(nil)
 	postState, err := state.ExecuteStateTransition(ctx, preState, signed)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not execute state transition")
 	}
+	postState.SetMutationFeed(nil)
 
 	if err := s.beaconDB.SaveBlock(ctx, signed); err != nil {
 		return nil, errors.Wrapf(err, "could not save block from slot %d", b.Slot)
@@ -91,6 +95,7 @@ func (s *Service) onBlock(ctx context.Context, signed *ethpb.SignedBeaconBlock)
 	if err := s.beaconDB.SaveState(ctx, postState, root); err != nil {
 		return nil, errors.Wrap(err, "could not save state")
 	}
+	s.postStateCache.AddState(root, postState.Copy())
 
 	// Update justified check point.
 	if postState.CurrentJustifiedCheckpoint().Epoch > s.justifiedCheckpt.Epoch {
@@ -101,6 +106,12 @@ func (s *Service) onBlock(ctx context.Context, signed *ethpb.SignedBeaconBlock)
 
 	// Update finalized check point. Prune the block cache and helper caches on every new finalized epoch.
 	if postState.FinalizedCheckpointEpoch() > s.finalizedCheckpt.Epoch {
+		// Save the states of the archived points up to the new finalized checkpoint before the
+		// hot states are deleted.
+		if err := s.stateGen.MigrateToCold(ctx, helpers.StartSlot(s.finalizedCheckpt.Epoch), bytesutil.ToBytes32(postState.FinalizedCheckpoint().Root)); err != nil {
+			log.WithError(err).Error("Could not save states of archived points")
+		}
+
 		if err := s.beaconDB.SaveFinalizedCheckpoint(ctx, postState.FinalizedCheckpoint()); err != nil {
 			return nil, errors.Wrap(err, "could not save finalized checkpoint")
 		}
@@ -135,6 +146,9 @@ func (s *Service) onBlock(ctx context.Context, signed *ethpb.SignedBeaconBlock)
 	if postState.Slot() >= s.nextEpochBoundarySlot {
 		logEpochData(postState)
 		reportEpochMetrics(postState)
+		if featureconfig.Get().EnableBalanceDivergenceCheck {
+			s.checkBalanceDivergence(postState)
+		}
 
 		// Update committees cache at epoch boundary slot.
 		if err := helpers.UpdateCommitteeCache(postState, helpers.CurrentEpoch(postState)); err != nil {
@@ -143,6 +157,7 @@ func (s *Service) onBlock(ctx context.Context, signed *ethpb.SignedBeaconBlock)
 		if err := helpers.UpdateProposerIndicesInCache(postState, helpers.CurrentEpoch(postState)); err != nil {
 			return nil, err
 		}
+		helpers.PruneShufflingCaches(helpers.PrevEpoch(postState))
 
 		s.nextEpochBoundarySlot = helpers.StartSlot(helpers.NextEpoch(postState))
 	}
@@ -152,9 +167,18 @@ func (s *Service) onBlock(ctx context.Context, signed *ethpb.SignedBeaconBlock)
 		return nil, err
 	}
 
-	// Delete the processed block attester slashings from slashings pool.
+	// Delete the processed block slashings from slashings pool.
 	for i := 0; i < len(b.Body.AttesterSlashings); i++ {
 		s.slashingPool.MarkIncludedAttesterSlashing(b.Body.AttesterSlashings[i])
+		if err := s.slashingPool.TrackInclusion(b.Body.AttesterSlashings[i], b.Slot, root); err != nil {
+			log.WithError(err).Error("Could not track inclusion of attester slashing")
+		}
+	}
+	for i := 0; i < len(b.Body.ProposerSlashings); i++ {
+		s.slashingPool.MarkIncludedProposerSlashing(b.Body.ProposerSlashings[i])
+		if err := s.slashingPool.TrackInclusion(b.Body.ProposerSlashings[i], b.Slot, root); err != nil {
+			log.WithError(err).Error("Could not track inclusion of proposer slashing")
+		}
 	}
 
 	return postState, nil
@@ -183,10 +207,13 @@ func (s *Service) onBlockInitialSyncStateTransition(ctx context.Context, signed
 	}
 	preStateValidatorCount := preState.NumValidators()
 
+	s.attachMutationFeed(preState)
+	defer preState.SetMutationFeed(nil)
 	postState, err := state.ExecuteStateTransitionNoVerifyAttSigs(ctx, preState, signed)
 	if err != nil {
 		return errors.Wrap(err, "could not execute state transition")
 	}
+	postState.SetMutationFeed(nil)
 
 	if err := s.beaconDB.SaveBlock(ctx, signed); err != nil {
 		return errors.Wrapf(err, "could not save block from slot %d", b.Slot)
@@ -219,6 +246,12 @@ func (s *Service) onBlockInitialSyncStateTransition(ctx context.Context, signed
 
 	// Update finalized check point. Prune the block cache and helper caches on every new finalized epoch.
 	if postState.FinalizedCheckpointEpoch() > s.finalizedCheckpt.Epoch {
+		// Save the states of the archived points up to the new finalized checkpoint before the
+		// hot states are deleted.
+		if err := s.stateGen.MigrateToCold(ctx, helpers.StartSlot(s.finalizedCheckpt.Epoch), bytesutil.ToBytes32(postState.FinalizedCheckpoint().Root)); err != nil {
+			log.WithError(err).Error("Could not save states of archived points")
+		}
+
 		startSlot := helpers.StartSlot(s.prevFinalizedCheckpt.Epoch)
 		endSlot := helpers.StartSlot(s.finalizedCheckpt.Epoch)
 		if endSlot > startSlot {
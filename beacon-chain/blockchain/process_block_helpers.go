@@ -135,8 +135,12 @@ func (s *Service) saveNewValidators(ctx context.Context, preStateValidatorCount
 		indices := make([]uint64, 0)
 		pubKeys := make([][48]byte, 0)
 		for i := preStateValidatorCount; i < postStateValidatorCount; i++ {
+			pubKey, err := postState.PubkeyAtIndex(uint64(i))
+			if err != nil {
+				return errors.Wrapf(err, "could not get public key of validator %d", i)
+			}
 			indices = append(indices, uint64(i))
-			pubKeys = append(pubKeys, postState.PubkeyAtIndex(uint64(i)))
+			pubKeys = append(pubKeys, pubKey)
 		}
 		if err := s.beaconDB.SaveValidatorIndices(ctx, pubKeys, indices); err != nil {
 			return errors.Wrapf(err, "could not save activated validators: %v", indices)
@@ -465,3 +469,12 @@ func (s *Service) deletePoolAtts(atts []*ethpb.Attestation) error {
 
 	return nil
 }
+
+// attachMutationFeed sets the service's state mutation feed on the pre-state of a block,
+// so validator changes made by the block's state transition are sent on it. The feed
+// must be removed from the state once the transition is done.
+func (s *Service) attachMutationFeed(preState *stateTrie.BeaconState) {
+	if featureconfig.Get().EnableStateMutationFeed {
+		preState.SetMutationFeed(s.mutationFeed)
+	}
+}
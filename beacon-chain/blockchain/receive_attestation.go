@@ -10,6 +10,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/params"
@@ -22,6 +23,7 @@ import (
 type AttestationReceiver interface {
 	ReceiveAttestationNoPubsub(ctx context.Context, att *ethpb.Attestation) error
 	IsValidAttestation(ctx context.Context, att *ethpb.Attestation) bool
+	AttestationTargetState(ctx context.Context, target *ethpb.Checkpoint) (*stateTrie.BeaconState, error)
 }
 
 // ReceiveAttestationNoPubsub is a function that defines the operations that are preformed on
@@ -72,6 +74,16 @@ func (s *Service) IsValidAttestation(ctx context.Context, att *ethpb.Attestation
 	return true
 }
 
+// AttestationTargetState returns the state of the target checkpoint of attestations, advanced to
+// the start slot of the target epoch, from the checkpoint state cache. The state is regenerated
+// on a cache miss, so attestations sharing a target only regenerate it once.
+func (s *Service) AttestationTargetState(ctx context.Context, target *ethpb.Checkpoint) (*stateTrie.BeaconState, error) {
+	if target == nil {
+		return nil, errors.New("nil target checkpoint")
+	}
+	return s.getAttPreState(ctx, target)
+}
+
 // This processes attestations from the attestation pool to account for validator votes and fork choice.
 func (s *Service) processAttestation(subscribedToStateEvents chan struct{}) {
 	// Wait for state to be initialized.
@@ -33,10 +33,13 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"go.opencensus.io/trace"
+	"golang.org/x/sync/singleflight"
 )
 
 // Service represents a service that handles the internal
@@ -71,8 +74,16 @@ type Service struct {
 	boundaryRoots          [][32]byte
 	initSyncStateLock      sync.RWMutex
 	checkpointState        *cache.CheckpointStateCache
-	checkpointStateLock    sync.Mutex
+	postStateCache         *cache.PostStateCache
+	checkpointStateGroup   singleflight.Group
 	stateGen               *stategen.State
+	mutationFeed           *event.Feed
+	stateMutationFeed      *event.Feed
+	lateBlocks             map[[32]byte]*lateBlock
+	lateBlocksLock         sync.Mutex
+	warmingUp              int32
+	balanceDivergence      *balanceDivergenceReport
+	balanceDivergenceLock  sync.RWMutex
 }
 
 // Config options for the service.
@@ -111,7 +122,10 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		initSyncState:      make(map[[32]byte]*stateTrie.BeaconState),
 		boundaryRoots:      [][32]byte{},
 		checkpointState:    cache.NewCheckpointStateCache(),
+		postStateCache:     cache.NewPostStateCache(),
 		stateGen:           stategen.New(cfg.BeaconDB),
+		mutationFeed:       new(event.Feed),
+		stateMutationFeed:  new(event.Feed),
 	}, nil
 }
 
@@ -140,6 +154,18 @@ func (s *Service) Start() {
 	// Make sure that attestation processor is subscribed and ready for state initializing event.
 	attestationProcessorSubscribed := make(chan struct{}, 1)
 
+	if featureconfig.Get().EnableEpochBoundaryPrecompute {
+		stateChannel := make(chan *feed.Event, 1)
+		stateSub := s.stateNotifier.StateFeed().Subscribe(stateChannel)
+		go s.precomputeEpochBoundaries(stateChannel, stateSub)
+	}
+	if featureconfig.Get().EnableTransitionProfiling {
+		go s.reportTransitionProfile()
+	}
+	if featureconfig.Get().EnableStateMutationFeed {
+		go s.relayStateMutations(s.subscribeStateMutations())
+	}
+
 	// If the chain has already been initialized, simply start the block processing routine.
 	if beaconState != nil {
 		log.Info("Blockchain data already exists in DB, initializing...")
@@ -163,6 +189,7 @@ func (s *Service) Start() {
 		s.finalizedCheckpt = stateTrie.CopyCheckpoint(finalizedCheckpoint)
 		s.prevFinalizedCheckpt = stateTrie.CopyCheckpoint(finalizedCheckpoint)
 		s.resumeForkChoice(justifiedCheckpoint, finalizedCheckpoint)
+		s.startWarmUp(s.ctx)
 
 		if finalizedCheckpoint.Epoch > 1 {
 			if err := s.pruneGarbageState(ctx, helpers.StartSlot(finalizedCheckpoint.Epoch)-params.BeaconConfig().SlotsPerEpoch); err != nil {
@@ -170,10 +197,15 @@ func (s *Service) Start() {
 			}
 		}
 
+		genesisValidatorsRoot, err := s.genesisValidatorsRoot(ctx)
+		if err != nil {
+			log.WithError(err).Warn("Could not compute genesis validators root")
+		}
 		s.stateNotifier.StateFeed().Send(&feed.Event{
 			Type: statefeed.Initialized,
 			Data: &statefeed.InitializedData{
-				StartTime: s.genesisTime,
+				StartTime:             s.genesisTime,
+				GenesisValidatorsRoot: genesisValidatorsRoot,
 			},
 		})
 	} else {
@@ -217,14 +249,36 @@ func (s *Service) processChainStartTime(ctx context.Context, genesisTime time.Ti
 	if err := s.initializeBeaconChain(ctx, genesisTime, preGenesisState, s.chainStartFetcher.ChainStartEth1Data()); err != nil {
 		log.Fatalf("Could not initialize beacon chain: %v", err)
 	}
+	genesisValidatorsRoot, err := s.genesisValidatorsRoot(ctx)
+	if err != nil {
+		log.WithError(err).Warn("Could not compute genesis validators root")
+	}
 	s.stateNotifier.StateFeed().Send(&feed.Event{
 		Type: statefeed.Initialized,
 		Data: &statefeed.InitializedData{
-			StartTime: genesisTime,
+			StartTime:             genesisTime,
+			GenesisValidatorsRoot: genesisValidatorsRoot,
 		},
 	})
 }
 
+// genesisValidatorsRoot returns the hash tree root of the validator registry of the genesis state,
+// which identifies the chain in the fork digest of the gossip topics.
+func (s *Service) genesisValidatorsRoot(ctx context.Context) ([]byte, error) {
+	genesisState, err := s.beaconDB.GenesisState(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve genesis state")
+	}
+	if genesisState == nil {
+		return nil, errors.New("no genesis state in db")
+	}
+	root, err := stateutil.ValidatorRegistryRoot(genesisState.Validators())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not hash validator registry")
+	}
+	return root[:], nil
+}
+
 // initializes the state and genesis block of the beacon chain to persistent storage
 // based on a genesis timestamp value obtained from the ChainStart event emitted
 // by the ETH1.0 Deposit Contract and the POWChain service of the node.
@@ -278,6 +332,14 @@ func (s *Service) Status() error {
 	return nil
 }
 
+// StateMutationFeed returns the feed on which validator balance, status and exit
+// changes are sent while blocks are processed. Events are only sent when the
+// state mutation feed feature is enabled, and are dropped oldest first when the
+// subscribers fall behind.
+func (s *Service) StateMutationFeed() *event.Feed {
+	return s.stateMutationFeed
+}
+
 // ClearCachedStates removes all stored caches states. This is done after the node
 // is synced.
 func (s *Service) ClearCachedStates() {
@@ -290,7 +352,11 @@ func (s *Service) saveGenesisValidators(ctx context.Context, state *stateTrie.Be
 	indices := make([]uint64, state.NumValidators())
 
 	for i := 0; i < state.NumValidators(); i++ {
-		pubkeys[i] = state.PubkeyAtIndex(uint64(i))
+		pubkey, err := state.PubkeyAtIndex(uint64(i))
+		if err != nil {
+			return errors.Wrapf(err, "could not get public key of validator %d", i)
+		}
+		pubkeys[i] = pubkey
 		indices[i] = uint64(i)
 	}
 	return s.beaconDB.SaveValidatorIndices(ctx, pubkeys, indices)
@@ -428,7 +494,12 @@ func (s *Service) pruneGarbageState(ctx context.Context, slot uint64) error {
 // This is called when a client starts from non-genesis slot. This passes last justified and finalized
 // information to fork choice service to initializes fork choice store.
 func (s *Service) resumeForkChoice(justifiedCheckpoint *ethpb.Checkpoint, finalizedCheckpoint *ethpb.Checkpoint) {
-	store := protoarray.New(justifiedCheckpoint.Epoch, finalizedCheckpoint.Epoch, bytesutil.ToBytes32(finalizedCheckpoint.Root))
+	store := protoarray.NewWithWeights(
+		justifiedCheckpoint.Epoch,
+		finalizedCheckpoint.Epoch,
+		bytesutil.ToBytes32(finalizedCheckpoint.Root),
+		f.WeightsFromConfig(),
+	)
 	s.forkChoiceStore = store
 }
 
@@ -0,0 +1,42 @@
+package blockchain
+
+import (
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	"github.com/prysmaticlabs/prysm/shared/event"
+)
+
+// mutationBufferSize is the number of state mutation events held between block processing
+// and the subscribers of the state mutation feed. An epoch transition changes the balance of
+// every validator, so the buffer fits those of a large validator set.
+const mutationBufferSize = 1 << 18
+
+// subscribeStateMutations subscribes to the state mutation events sent while processing blocks
+// through a buffer dropping the oldest events, so a slow subscriber never holds up block processing.
+func (s *Service) subscribeStateMutations() (chan *feed.Event, event.Subscription) {
+	mutationChannel := make(chan *feed.Event, 1)
+	mutationSub := s.mutationFeed.SubscribeBuffered(mutationChannel, &event.BufferedOpts{
+		Name:   "state_mutations",
+		Size:   mutationBufferSize,
+		Policy: event.DropOldest,
+	})
+	return mutationChannel, mutationSub
+}
+
+// relayStateMutations forwards the state mutation events sent while processing blocks to the
+// subscribers of the state mutation feed, until the service is stopped.
+func (s *Service) relayStateMutations(mutationChannel chan *feed.Event, mutationSub event.Subscription) {
+	defer mutationSub.Unsubscribe()
+	for {
+		select {
+		case ev := <-mutationChannel:
+			s.stateMutationFeed.Send(ev)
+		case err := <-mutationSub.Err():
+			if err != nil {
+				log.WithError(err).Error("Subscription to state mutations failed")
+			}
+			return
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
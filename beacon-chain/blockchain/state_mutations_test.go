@@ -0,0 +1,68 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/shared/event"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestStateMutationFeed_ReceivesBlockStateChanges(t *testing.T) {
+	featureconfig.Init(&featureconfig.Flags{EnableStateMutationFeed: true})
+	defer featureconfig.Init(&featureconfig.Flags{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	service := &Service{ctx: ctx, mutationFeed: new(event.Feed), stateMutationFeed: new(event.Feed)}
+
+	ch := make(chan *feed.Event, 1)
+	sub := service.StateMutationFeed().Subscribe(ch)
+	defer sub.Unsubscribe()
+	go service.relayStateMutations(service.subscribeStateMutations())
+
+	preState, _ := testutil.DeterministicGenesisState(t, 8)
+	service.attachMutationFeed(preState)
+	if err := preState.UpdateBalancesAtIndex(3, params.BeaconConfig().MaxEffectiveBalance-1); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case e := <-ch:
+		if e.Type != statefeed.ValidatorBalanceChanged {
+			t.Fatalf("Wanted event type %d, received %d", statefeed.ValidatorBalanceChanged, e.Type)
+		}
+		if idx := e.Data.(*statefeed.ValidatorBalanceChangedData).ValidatorIndex; idx != 3 {
+			t.Errorf("Wanted validator index 3, received %d", idx)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Did not receive the balance change")
+	}
+}
+
+func TestStateMutationFeed_StalledSubscriberDoesNotBlockProcessing(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	service := &Service{ctx: ctx, mutationFeed: new(event.Feed), stateMutationFeed: new(event.Feed)}
+
+	// The subscriber never reads its channel.
+	sub := service.StateMutationFeed().Subscribe(make(chan *feed.Event))
+	defer sub.Unsubscribe()
+	go service.relayStateMutations(service.subscribeStateMutations())
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < mutationBufferSize+10; i++ {
+			service.mutationFeed.Send(&feed.Event{Type: statefeed.ValidatorBalanceChanged})
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Sending state mutations blocked on a stalled subscriber")
+	}
+}
@@ -212,6 +212,11 @@ func (ms *ChainService) HeadSeed(epoch uint64) ([32]byte, error) {
 	return helpers.Seed(ms.State, epoch, params.BeaconConfig().DomainBeaconAttester)
 }
 
+// WarmedUp mocks the same method in the chain service.
+func (ms *ChainService) WarmedUp() bool {
+	return true
+}
+
 // GenesisTime mocks the same method in the chain service.
 func (ms *ChainService) GenesisTime() time.Time {
 	return ms.Genesis
@@ -232,5 +237,13 @@ func (ms *ChainService) IsValidAttestation(ctx context.Context, att *ethpb.Attes
 	return ms.ValidAttestation
 }
 
+// AttestationTargetState mocks the same method in the chain service.
+func (ms *ChainService) AttestationTargetState(ctx context.Context, target *ethpb.Checkpoint) (*stateTrie.BeaconState, error) {
+	if ms.State == nil {
+		return nil, errors.New("nil state")
+	}
+	return ms.State, nil
+}
+
 // ClearCachedStates does nothing.
 func (ms *ChainService) ClearCachedStates() {}
@@ -0,0 +1,40 @@
+package blockchain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/runutil"
+	"github.com/sirupsen/logrus"
+)
+
+// reportTransitionProfile logs the time spent in each stage of the state transition every
+// epoch, slowest stage first, so a regression can be traced back to the operation causing it.
+func (s *Service) reportTransitionProfile() {
+	period := time.Duration(params.BeaconConfig().SlotsPerEpoch*params.BeaconConfig().SecondsPerSlot) * time.Second
+	runutil.RunEvery(s.ctx, period, func() {
+		logTransitionProfile(state.TransitionProfile())
+	})
+}
+
+func logTransitionProfile(stages map[string]*state.StageTiming) {
+	names := make([]string, 0, len(stages))
+	for name := range stages {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return stages[names[i]].Total > stages[names[j]].Total
+	})
+	for _, name := range names {
+		t := stages[name]
+		log.WithFields(logrus.Fields{
+			"stage":   name,
+			"count":   t.Count,
+			"total":   t.Total,
+			"average": t.Average(),
+			"max":     t.Max,
+		}).Info("State transition stage timing")
+	}
+}
@@ -0,0 +1,65 @@
+package blockchain
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+)
+
+// WarmedUp returns false while the service loads the finalized and head states and fills the
+// committee and proposer caches after a restart.
+func (s *Service) WarmedUp() bool {
+	return atomic.LoadInt32(&s.warmingUp) == 0
+}
+
+// startWarmUp warms up the caches in the background, so the node is only reported healthy for
+// RPC once the first duty requests of validators are served from the caches.
+func (s *Service) startWarmUp(ctx context.Context) {
+	atomic.StoreInt32(&s.warmingUp, 1)
+	go func() {
+		defer atomic.StoreInt32(&s.warmingUp, 0)
+		if err := s.warmUpCaches(ctx); err != nil {
+			log.WithError(err).Warn("Could not warm up caches")
+		}
+	}()
+}
+
+// warmUpCaches loads the finalized and head states from the database and computes the
+// shuffling, committees and proposers of their current and next epochs.
+func (s *Service) warmUpCaches(ctx context.Context) error {
+	start := time.Now()
+	finalized, err := s.beaconDB.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	finalizedState, err := s.beaconDB.State(ctx, bytesutil.ToBytes32(finalized.Root))
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized state")
+	}
+	headState, err := s.beaconDB.HeadState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get head state")
+	}
+	for _, st := range []*stateTrie.BeaconState{finalizedState, headState} {
+		if st == nil {
+			continue
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		epoch := helpers.CurrentEpoch(st)
+		if err := helpers.UpdateCommitteeCache(st, epoch); err != nil {
+			return errors.Wrapf(err, "could not update committee cache of epoch %d", epoch)
+		}
+		if err := helpers.UpdateProposerIndicesInCache(st, epoch); err != nil {
+			return errors.Wrapf(err, "could not update proposer indices cache of epoch %d", epoch)
+		}
+	}
+	log.WithField("duration", time.Since(start)).Info("Warmed up caches")
+	return nil
+}
@@ -0,0 +1,36 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestWarmUpCaches(t *testing.T) {
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+	ctx := context.Background()
+
+	st, _ := testutil.DeterministicGenesisState(t, 64)
+	root := [32]byte{'a'}
+	if err := db.SaveState(ctx, st, root); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Root: root[:]}); err != nil {
+		t.Fatal(err)
+	}
+	s := &Service{beaconDB: db}
+	if !s.WarmedUp() {
+		t.Error("Expected a service without a warm-up to be warmed up")
+	}
+	s.warmingUp = 1
+	if s.WarmedUp() {
+		t.Error("Expected the service to be warming up")
+	}
+	if err := s.warmUpCaches(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
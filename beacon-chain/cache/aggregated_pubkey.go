@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+)
+
+var (
+	// maxAggregatedPubkeysPerEpoch defines the max number of aggregated public keys cached per
+	// epoch, which covers several attesting subsets of every committee of an epoch of mainnet.
+	maxAggregatedPubkeysPerEpoch = 8192
+
+	// Metrics.
+	aggregatedPubkeyCacheMiss = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "aggregated_pubkey_cache_miss",
+		Help: "The number of aggregated public key requests that aren't present in the cache.",
+	})
+	aggregatedPubkeyCacheHit = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "aggregated_pubkey_cache_hit",
+		Help: "The number of aggregated public key requests that are present in the cache.",
+	})
+)
+
+// AggregatedPubkeyCache stores the aggregated public keys of the attesting validators of
+// committees by epoch, so repeated verifications of attestations of the same committee don't
+// deserialize and aggregate the public keys again. Aggregates are keyed by the hash of the
+// serialized public keys, which is much cheaper than aggregating them. Aggregates of epochs
+// before the previous epoch of the latest epoch seen are evicted, as attestations of those
+// epochs are no longer accepted.
+type AggregatedPubkeyCache struct {
+	pubkeys     map[uint64]map[[32]byte]*bls.PublicKey
+	latestEpoch uint64
+	lock        sync.RWMutex
+}
+
+// NewAggregatedPubkeyCache creates a new aggregated public key cache.
+func NewAggregatedPubkeyCache() *AggregatedPubkeyCache {
+	return &AggregatedPubkeyCache{
+		pubkeys: make(map[uint64]map[[32]byte]*bls.PublicKey),
+	}
+}
+
+// AggregatedPubkey returns the cached aggregate of the serialized public keys attesting in the
+// epoch, or nil if it isn't cached. The returned key is shared and must not be aggregated into.
+func (c *AggregatedPubkeyCache) AggregatedPubkey(epoch uint64, pubkeys [][48]byte) *bls.PublicKey {
+	key := pubkeysKey(pubkeys)
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	pubkey, ok := c.pubkeys[epoch][key]
+	if !ok {
+		aggregatedPubkeyCacheMiss.Inc()
+		return nil
+	}
+	aggregatedPubkeyCacheHit.Inc()
+	return pubkey
+}
+
+// AddAggregatedPubkey caches the aggregate of the serialized public keys attesting in the epoch,
+// evicting the aggregates of epochs before the previous epoch once a new epoch is seen.
+func (c *AggregatedPubkeyCache) AddAggregatedPubkey(epoch uint64, pubkeys [][48]byte, aggregate *bls.PublicKey) {
+	key := pubkeysKey(pubkeys)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if epoch > c.latestEpoch {
+		c.latestEpoch = epoch
+		for e := range c.pubkeys {
+			if e+1 < epoch {
+				delete(c.pubkeys, e)
+			}
+		}
+	}
+	if epoch+1 < c.latestEpoch {
+		return
+	}
+	aggregates, ok := c.pubkeys[epoch]
+	if !ok {
+		aggregates = make(map[[32]byte]*bls.PublicKey)
+		c.pubkeys[epoch] = aggregates
+	}
+	if len(aggregates) >= maxAggregatedPubkeysPerEpoch {
+		return
+	}
+	aggregates[key] = aggregate
+}
+
+// pubkeysKey hashes the serialized public keys into the key of their aggregate.
+func pubkeysKey(pubkeys [][48]byte) [32]byte {
+	b := make([]byte, 0, 48*len(pubkeys))
+	for _, pubkey := range pubkeys {
+		b = append(b, pubkey[:]...)
+	}
+	return hashutil.Hash(b)
+}
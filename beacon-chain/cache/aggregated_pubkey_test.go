@@ -0,0 +1,41 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/bls"
+)
+
+func TestAggregatedPubkeyCache_AddAndEvict(t *testing.T) {
+	c := NewAggregatedPubkeyCache()
+	pubkey := bls.RandKey().PublicKey()
+	indices := [][48]byte{{1}, {2}, {3}}
+
+	if c.AggregatedPubkey(1, indices) != nil {
+		t.Error("Expected an empty cache to miss")
+	}
+	c.AddAggregatedPubkey(1, indices, pubkey)
+	if c.AggregatedPubkey(1, indices) != pubkey {
+		t.Error("Expected the aggregated public key to be cached")
+	}
+	if c.AggregatedPubkey(1, [][48]byte{{1}, {2}}) != nil {
+		t.Error("Expected other public keys to miss")
+	}
+	if c.AggregatedPubkey(2, indices) != nil {
+		t.Error("Expected another epoch to miss")
+	}
+
+	// The previous epoch is kept, older epochs are evicted.
+	c.AddAggregatedPubkey(2, indices, pubkey)
+	if c.AggregatedPubkey(1, indices) == nil {
+		t.Error("Expected the previous epoch to be kept")
+	}
+	c.AddAggregatedPubkey(3, indices, pubkey)
+	if c.AggregatedPubkey(1, indices) != nil {
+		t.Error("Expected epoch 1 to be evicted")
+	}
+	c.AddAggregatedPubkey(1, indices, pubkey)
+	if c.AggregatedPubkey(1, indices) != nil {
+		t.Error("Expected an evicted epoch to not be cached again")
+	}
+}
@@ -60,10 +60,13 @@ func checkpointState(obj interface{}) (string, error) {
 }
 
 // NewCheckpointStateCache creates a new checkpoint state cache for storing/accessing processed state.
+// The cache counts towards the state cache memory budget.
 func NewCheckpointStateCache() *CheckpointStateCache {
-	return &CheckpointStateCache{
+	c := &CheckpointStateCache{
 		cache: cache.NewFIFO(checkpointState),
 	}
+	stateCacheBudget.register(checkpointStateBudgetKind, c)
+	return c
 }
 
 // StateByCheckpoint fetches state by checkpoint. Returns true with a
@@ -99,19 +102,49 @@ func (c *CheckpointStateCache) StateByCheckpoint(cp *ethpb.Checkpoint) (*stateTr
 // AddCheckpointState adds CheckpointState object to the cache. This method also trims the least
 // recently added CheckpointState object if the cache size has ready the max cache size limit.
 func (c *CheckpointStateCache) AddCheckpointState(cp *CheckpointState) error {
+	key, err := checkpointState(cp)
+	if err != nil {
+		return err
+	}
 	c.lock.Lock()
-	defer c.lock.Unlock()
 	if err := c.cache.AddIfNotPresent(&CheckpointState{
 		Checkpoint: stateTrie.CopyCheckpoint(cp.Checkpoint),
 		State:      cp.State.Copy(),
 	}); err != nil {
+		c.lock.Unlock()
 		return err
 	}
+	trimWithEviction(c.cache, maxCheckpointStateSize, c.evicted)
+	_, exists, err := c.cache.GetByKey(key)
+	if err != nil {
+		c.lock.Unlock()
+		return err
+	}
+	if exists {
+		stateCacheBudget.set(checkpointStateBudgetKind, c, key, stateSizeEstimate(cp.State))
+	}
+	c.lock.Unlock()
 
-	trim(c.cache, maxCheckpointStateSize)
+	stateCacheBudget.enforce()
 	return nil
 }
 
+func (c *CheckpointStateCache) evicted(obj interface{}) {
+	if key, err := checkpointState(obj); err == nil {
+		stateCacheBudget.remove(checkpointStateBudgetKind, c, key)
+	}
+}
+
+func (c *CheckpointStateCache) evictOldest() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(c.cache.ListKeys()) == 0 {
+		return false
+	}
+	trimWithEviction(c.cache, len(c.cache.ListKeys())-1, c.evicted)
+	return true
+}
+
 // CheckpointStateKeys returns the keys of the state in cache.
 func (c *CheckpointStateCache) CheckpointStateKeys() []string {
 	return c.cache.ListKeys()
@@ -59,10 +59,13 @@ func committeeKeyFn(obj interface{}) (string, error) {
 }
 
 // NewCommitteesCache creates a new committee cache for storing/accessing shuffled indices of a committee.
+// The cache counts towards the state cache memory budget.
 func NewCommitteesCache() *CommitteeCache {
-	return &CommitteeCache{
+	c := &CommitteeCache{
 		CommitteeCache: cache.NewFIFO(committeeKeyFn),
 	}
+	stateCacheBudget.register(committeeBudgetKind, c)
+	return c
 }
 
 // Committee fetches the shuffled indices by slot and committee index. Every list of indices
@@ -107,20 +110,40 @@ func (c *CommitteeCache) Committee(slot uint64, seed [32]byte, index uint64) ([]
 // his method also trims the least recently list if the cache size has ready the max cache size limit.
 func (c *CommitteeCache) AddCommitteeShuffledList(committees *Committees) error {
 	c.lock.Lock()
-	defer c.lock.Unlock()
-
 	if err := c.CommitteeCache.AddIfNotPresent(committees); err != nil {
+		c.lock.Unlock()
+		return err
+	}
+	trimWithEviction(c.CommitteeCache, maxCommitteesCacheSize, c.evicted)
+	err := c.setBudget(committees.Seed)
+	c.lock.Unlock()
+	if err != nil {
 		return err
 	}
-	trim(c.CommitteeCache, maxCommitteesCacheSize)
+
+	stateCacheBudget.enforce()
 	return nil
 }
 
 // AddProposerIndicesList updates the committee shuffled list with proposer indices.
 func (c *CommitteeCache) AddProposerIndicesList(seed [32]byte, indices []uint64) error {
 	c.lock.Lock()
-	defer c.lock.Unlock()
+	err := c.addProposerIndicesList(seed, indices)
+	if err == nil {
+		err = c.setBudget(seed)
+	}
+	c.lock.Unlock()
+	if err != nil {
+		return err
+	}
 
+	stateCacheBudget.enforce()
+	return nil
+}
+
+// addProposerIndicesList updates the committee shuffled list with proposer indices. The caller
+// is expected to hold the lock.
+func (c *CommitteeCache) addProposerIndicesList(seed [32]byte, indices []uint64) error {
 	obj, exists, err := c.CommitteeCache.GetByKey(key(seed))
 	if err != nil {
 		return err
@@ -141,10 +164,41 @@ func (c *CommitteeCache) AddProposerIndicesList(seed [32]byte, indices []uint64)
 		}
 	}
 
-	trim(c.CommitteeCache, maxCommitteesCacheSize)
+	trimWithEviction(c.CommitteeCache, maxCommitteesCacheSize, c.evicted)
+	return nil
+}
+
+// setBudget records the estimated size of the committees of the seed in the state cache budget,
+// if they are cached. The caller is expected to hold the lock.
+func (c *CommitteeCache) setBudget(seed [32]byte) error {
+	obj, exists, err := c.CommitteeCache.GetByKey(key(seed))
+	if err != nil || !exists {
+		return err
+	}
+	committees, ok := obj.(*Committees)
+	if !ok {
+		return ErrNotCommittee
+	}
+	stateCacheBudget.set(committeeBudgetKind, c, key(seed), committeesSizeEstimate(committees))
 	return nil
 }
 
+func (c *CommitteeCache) evicted(obj interface{}) {
+	if k, err := committeeKeyFn(obj); err == nil {
+		stateCacheBudget.remove(committeeBudgetKind, c, k)
+	}
+}
+
+func (c *CommitteeCache) evictOldest() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(c.CommitteeCache.ListKeys()) == 0 {
+		return false
+	}
+	trimWithEviction(c.CommitteeCache, len(c.CommitteeCache.ListKeys())-1, c.evicted)
+	return true
+}
+
 // ActiveIndices returns the active indices of a given seed stored in cache.
 func (c *CommitteeCache) ActiveIndices(seed [32]byte) ([]uint64, error) {
 	c.lock.RLock()
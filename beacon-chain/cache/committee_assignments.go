@@ -0,0 +1,112 @@
+package cache
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+)
+
+var (
+	// ErrNotCommitteeAssignments will be returned when a cache object is not a pointer to
+	// a CommitteeAssignments struct.
+	ErrNotCommitteeAssignments = errors.New("object is not a committee assignments struct")
+
+	// defaultCommitteeAssignmentsCacheSize is used when no cache size has been configured.
+	// Duties are requested for the current and next epoch, 4 entries cover 2 concurrent branches.
+	defaultCommitteeAssignmentsCacheSize = 4
+
+	// Metrics.
+	committeeAssignmentsCacheHit = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "committee_assignments_cache_hit",
+		Help: "The number of committee assignments requests that are present in the cache.",
+	})
+	committeeAssignmentsCacheMiss = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "committee_assignments_cache_miss",
+		Help: "The number of committee assignments requests that aren't present in the cache.",
+	})
+	committeeAssignmentsCacheSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "committee_assignments_cache_size",
+		Help: "The number of epochs of committee assignments currently held in the cache.",
+	})
+)
+
+// CommitteeAssignment represents a committee, index, and attester slot for a given epoch.
+type CommitteeAssignment struct {
+	Committee      []uint64
+	AttesterSlot   uint64
+	CommitteeIndex uint64
+}
+
+// CommitteeAssignments defines the committee assignment of every active validator and the
+// proposer slots of an epoch. The committees only depend on the seed, while the proposers also
+// depend on the effective balances of the active validators, identified by BalancesRoot.
+type CommitteeAssignments struct {
+	Seed                [32]byte
+	BalancesRoot        [32]byte
+	Epoch               uint64
+	ValidatorCommittees map[uint64]*CommitteeAssignment
+	ProposerIndexToSlot map[uint64]uint64
+}
+
+// CommitteeAssignmentsCache stores the committee assignments of an epoch keyed by (seed,
+// balances root, epoch).
+// It is shared by the RPC duties endpoints so that every connected validator client doesn't
+// recompute the full epoch assignments.
+type CommitteeAssignmentsCache struct {
+	cache *seedEpochCache
+}
+
+// NewCommitteeAssignmentsCache creates a new committee assignments cache.
+func NewCommitteeAssignmentsCache() *CommitteeAssignmentsCache {
+	return &CommitteeAssignmentsCache{
+		cache: newSeedEpochCache(),
+	}
+}
+
+// CommitteeAssignments returns the assignments of a given seed, balances root and epoch. Returns
+// nil if the assignments do not exist in the cache. The returned maps are shared and must not be
+// mutated.
+func (c *CommitteeAssignmentsCache) CommitteeAssignments(seed [32]byte, balancesRoot [32]byte, epoch uint64) (*CommitteeAssignments, error) {
+	obj, exists := c.cache.get(assignmentsKey(seed, balancesRoot), epoch)
+	if !exists {
+		committeeAssignmentsCacheMiss.Inc()
+		return nil, nil
+	}
+	committeeAssignmentsCacheHit.Inc()
+
+	item, ok := obj.(*CommitteeAssignments)
+	if !ok {
+		return nil, ErrNotCommitteeAssignments
+	}
+	return item, nil
+}
+
+// AddCommitteeAssignments adds the assignments to the cache. The entries of the oldest epochs
+// are evicted once the configured cache size is reached.
+func (c *CommitteeAssignmentsCache) AddCommitteeAssignments(assignments *CommitteeAssignments) error {
+	if assignments == nil {
+		return ErrNotCommitteeAssignments
+	}
+	size := flags.Get().CommitteeAssignmentsCacheSize
+	if size <= 0 {
+		size = defaultCommitteeAssignmentsCacheSize
+	}
+	c.cache.add(assignmentsKey(assignments.Seed, assignments.BalancesRoot), assignments.Epoch, assignments, size)
+	committeeAssignmentsCacheSize.Set(float64(c.cache.len()))
+	return nil
+}
+
+// PruneBefore removes the assignments of every epoch lower than the given epoch.
+func (c *CommitteeAssignmentsCache) PruneBefore(epoch uint64) {
+	c.cache.pruneBefore(epoch)
+	committeeAssignmentsCacheSize.Set(float64(c.cache.len()))
+}
+
+// assignmentsKey combines the seed and the balances root into the seed the assignments are
+// stored under, as assignments of the same seed differ in proposers once balances change.
+func assignmentsKey(seed [32]byte, balancesRoot [32]byte) [32]byte {
+	return hashutil.Hash(append(seed[:], balancesRoot[:]...))
+}
@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCommitteeAssignmentsCache_AddAndRetrieve(t *testing.T) {
+	c := NewCommitteeAssignmentsCache()
+	seed := [32]byte{'A'}
+
+	balancesRoot := [32]byte{'B'}
+
+	item, err := c.CommitteeAssignments(seed, balancesRoot, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item != nil {
+		t.Error("Expected committee assignments not to exist in empty cache")
+	}
+
+	wanted := &CommitteeAssignments{
+		Seed:         seed,
+		BalancesRoot: balancesRoot,
+		Epoch:        1,
+		ValidatorCommittees: map[uint64]*CommitteeAssignment{
+			0: {Committee: []uint64{0, 1}, AttesterSlot: 8, CommitteeIndex: 0},
+			1: {Committee: []uint64{0, 1}, AttesterSlot: 8, CommitteeIndex: 0},
+		},
+		ProposerIndexToSlot: map[uint64]uint64{1: 9},
+	}
+	if err := c.AddCommitteeAssignments(wanted); err != nil {
+		t.Fatal(err)
+	}
+	item, err = c.CommitteeAssignments(seed, balancesRoot, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(item, wanted) {
+		t.Errorf("Wanted %v, got %v", wanted, item)
+	}
+
+	// The proposers of the same seed differ once the balances change.
+	item, err = c.CommitteeAssignments(seed, [32]byte{'C'}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item != nil {
+		t.Error("Expected committee assignments not to exist for other balances")
+	}
+}
+
+func TestCommitteeAssignmentsCache_NilAssignments(t *testing.T) {
+	c := NewCommitteeAssignmentsCache()
+	if err := c.AddCommitteeAssignments(nil); err != ErrNotCommitteeAssignments {
+		t.Errorf("Expected error %v, got %v", ErrNotCommitteeAssignments, err)
+	}
+}
+
+func TestCommitteeAssignmentsCache_MaxSize(t *testing.T) {
+	c := NewCommitteeAssignmentsCache()
+	for i := uint64(0); i < uint64(defaultCommitteeAssignmentsCacheSize)+2; i++ {
+		if err := c.AddCommitteeAssignments(&CommitteeAssignments{Seed: [32]byte{byte(i)}, Epoch: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if c.cache.len() != defaultCommitteeAssignmentsCacheSize {
+		t.Errorf("Wanted cache size %d, got %d", defaultCommitteeAssignmentsCacheSize, c.cache.len())
+	}
+	item, err := c.CommitteeAssignments([32]byte{0}, [32]byte{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item != nil {
+		t.Error("Expected assignments of the oldest epoch to be evicted")
+	}
+}
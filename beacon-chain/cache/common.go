@@ -1,6 +1,9 @@
 package cache
 
 import (
+	"sync"
+
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"k8s.io/client-go/tools/cache"
 )
@@ -19,7 +22,103 @@ func trim(queue *cache.FIFO, maxSize int) {
 	}
 }
 
+// trimWithEviction trims the FIFO queue to the maxSize, calling evicted with each removed object.
+func trimWithEviction(queue *cache.FIFO, maxSize int, evicted func(obj interface{})) {
+	for s := len(queue.ListKeys()); s > maxSize; s-- {
+		// #nosec G104 the process func never returns an error
+		_, _ = queue.Pop(func(obj interface{}) error {
+			evicted(obj)
+			return nil
+		})
+	}
+}
+
 // popProcessNoopFunc is a no-op function that never returns an error.
 func popProcessNoopFunc(obj interface{}) error {
 	return nil
 }
+
+// seedEpochKey is the key of the caches that store data derived from a
+// shuffling, which is uniquely identified by its seed and epoch.
+type seedEpochKey string
+
+func newSeedEpochKey(seed [32]byte, epoch uint64) seedEpochKey {
+	return seedEpochKey(append(seed[:], bytesutil.Bytes8(epoch)...))
+}
+
+// seedEpochCache is a bounded map keyed by (seed, epoch). Once the number of entries exceeds
+// the size limit, the entries of the oldest epochs are evicted first so that the shufflings of
+// the current and next epoch survive reorgs which only produce new seeds for recent epochs.
+type seedEpochCache struct {
+	items  map[seedEpochKey]interface{}
+	epochs map[seedEpochKey]uint64
+	lock   sync.RWMutex
+}
+
+func newSeedEpochCache() *seedEpochCache {
+	return &seedEpochCache{
+		items:  make(map[seedEpochKey]interface{}),
+		epochs: make(map[seedEpochKey]uint64),
+	}
+}
+
+func (c *seedEpochCache) get(seed [32]byte, epoch uint64) (interface{}, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	item, ok := c.items[newSeedEpochKey(seed, epoch)]
+	return item, ok
+}
+
+func (c *seedEpochCache) add(seed [32]byte, epoch uint64, item interface{}, maxSize int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	k := newSeedEpochKey(seed, epoch)
+	c.items[k] = item
+	c.epochs[k] = epoch
+	for len(c.items) > maxSize {
+		c.evictOldestEpoch()
+	}
+}
+
+// pruneBefore removes every entry of an epoch lower than the given epoch.
+func (c *seedEpochCache) pruneBefore(epoch uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for k, e := range c.epochs {
+		if e < epoch {
+			delete(c.items, k)
+			delete(c.epochs, k)
+		}
+	}
+}
+
+// clear removes every entry of the cache.
+func (c *seedEpochCache) clear() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.items = make(map[seedEpochKey]interface{})
+	c.epochs = make(map[seedEpochKey]uint64)
+}
+
+func (c *seedEpochCache) len() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return len(c.items)
+}
+
+// evictOldestEpoch removes all the entries of the lowest epoch in the cache. The caller is
+// expected to hold the write lock.
+func (c *seedEpochCache) evictOldestEpoch() {
+	oldest := ^uint64(0)
+	for _, e := range c.epochs {
+		if e < oldest {
+			oldest = e
+		}
+	}
+	for k, e := range c.epochs {
+		if e == oldest {
+			delete(c.items, k)
+			delete(c.epochs, k)
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	dbpb "github.com/prysmaticlabs/prysm/proto/beacon/db"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
 	log "github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
@@ -28,7 +29,9 @@ var (
 type DepositFetcher interface {
 	AllDeposits(ctx context.Context, beforeBlk *big.Int) []*ethpb.Deposit
 	DepositByPubkey(ctx context.Context, pubKey []byte) (*ethpb.Deposit, *big.Int)
+	DepositsByPubkey(ctx context.Context, pubKey []byte) []*ValidatorDeposit
 	DepositsNumberAndRootAtHeight(ctx context.Context, blockHeight *big.Int) (uint64, [32]byte)
+	DepositSnapshot() *trieutil.DepositTreeSnapshot
 }
 
 // DepositCache stores all in-memory deposit objects. This
@@ -41,6 +44,13 @@ type DepositCache struct {
 	chainStartDeposits    []*ethpb.Deposit
 	chainstartPubkeys     map[string]bool
 	chainstartPubkeysLock sync.RWMutex
+	// Snapshot of the deposits made before the cached deposits, if the node was
+	// bootstrapped from a deposit tree snapshot. Guarded by depositsLock.
+	depositSnapshot *trieutil.DepositTreeSnapshot
+	// Sorted indices of the cached deposits of each public key, and eth1 transaction
+	// hashes of the deposits by index. Guarded by depositsLock.
+	pubkeyDeposits  map[[48]byte][]int64
+	depositTxHashes map[int64][]byte
 }
 
 // NewDepositCache instantiates a new deposit cache
@@ -50,6 +60,8 @@ func NewDepositCache() *DepositCache {
 		deposits:           []*dbpb.DepositContainer{},
 		chainstartPubkeys:  make(map[string]bool),
 		chainStartDeposits: make([]*ethpb.Deposit, 0),
+		pubkeyDeposits:     make(map[[48]byte][]int64),
+		depositTxHashes:    make(map[int64][]byte),
 	}
 }
 
@@ -73,6 +85,7 @@ func (dc *DepositCache) InsertDeposit(ctx context.Context, d *ethpb.Deposit, blo
 	heightIdx := sort.Search(len(dc.deposits), func(i int) bool { return dc.deposits[i].Index >= index })
 	newDeposits := append([]*dbpb.DepositContainer{{Deposit: d, Eth1BlockHeight: blockNum, DepositRoot: depositRoot[:], Index: index}}, dc.deposits[heightIdx:]...)
 	dc.deposits = append(dc.deposits[:heightIdx], newDeposits...)
+	dc.indexPubkeyDeposit(d, index)
 	historicalDepositsCount.Inc()
 }
 
@@ -85,6 +98,12 @@ func (dc *DepositCache) InsertDepositContainers(ctx context.Context, ctrs []*dbp
 
 	sort.SliceStable(ctrs, func(i int, j int) bool { return ctrs[i].Index < ctrs[j].Index })
 	dc.deposits = ctrs
+	dc.pubkeyDeposits = make(map[[48]byte][]int64)
+	for _, ctr := range ctrs {
+		if ctr.Deposit != nil {
+			dc.indexPubkeyDeposit(ctr.Deposit, ctr.Index)
+		}
+	}
 	historicalDepositsCount.Add(float64(len(ctrs)))
 }
 
@@ -145,12 +164,75 @@ func (dc *DepositCache) DepositsNumberAndRootAtHeight(ctx context.Context, block
 	dc.depositsLock.RLock()
 	defer dc.depositsLock.RUnlock()
 	heightIdx := sort.Search(len(dc.deposits), func(i int) bool { return dc.deposits[i].Eth1BlockHeight > blockHeight.Uint64() })
+	var snapshotCount uint64
+	if dc.depositSnapshot != nil {
+		snapshotCount = dc.depositSnapshot.DepositCount
+	}
 	// send the deposit root of the empty trie, if eth1follow distance is greater than the time of the earliest
 	// deposit.
 	if heightIdx == 0 {
+		if dc.depositSnapshot != nil {
+			return snapshotCount, bytesutil.ToBytes32(dc.depositSnapshot.DepositRoot)
+		}
 		return 0, [32]byte{}
 	}
-	return uint64(heightIdx), bytesutil.ToBytes32(dc.deposits[heightIdx-1].DepositRoot)
+	return snapshotCount + uint64(heightIdx), bytesutil.ToBytes32(dc.deposits[heightIdx-1].DepositRoot)
+}
+
+// RemoveDepositsFromHeight removes the deposits and pending deposits made in eth1 blocks from
+// the given height on, after these blocks were reorged out of the eth1 chain. It returns the
+// number of removed deposits.
+func (dc *DepositCache) RemoveDepositsFromHeight(ctx context.Context, height uint64) int {
+	ctx, span := trace.StartSpan(ctx, "DepositsCache.RemoveDepositsFromHeight")
+	defer span.End()
+	dc.depositsLock.Lock()
+	defer dc.depositsLock.Unlock()
+
+	// Deposits are sorted by index, and therefore by eth1 block height.
+	heightIdx := sort.Search(len(dc.deposits), func(i int) bool { return dc.deposits[i].Eth1BlockHeight >= height })
+	removed := dc.deposits[heightIdx:]
+	// Copy the remaining deposits, so insertions do not overwrite slices returned before.
+	dc.deposits = append([]*dbpb.DepositContainer{}, dc.deposits[:heightIdx]...)
+	for _, ctnr := range removed {
+		delete(dc.depositTxHashes, ctnr.Index)
+		if ctnr.Deposit == nil || ctnr.Deposit.Data == nil {
+			continue
+		}
+		key := bytesutil.ToBytes48(ctnr.Deposit.Data.PublicKey)
+		indices := dc.pubkeyDeposits[key]
+		i := sort.Search(len(indices), func(i int) bool { return indices[i] >= ctnr.Index })
+		if i == 0 {
+			delete(dc.pubkeyDeposits, key)
+		} else {
+			dc.pubkeyDeposits[key] = indices[:i]
+		}
+	}
+
+	var pendingDeposits []*dbpb.DepositContainer
+	for _, ctnr := range dc.pendingDeposits {
+		if ctnr.Eth1BlockHeight < height {
+			pendingDeposits = append(pendingDeposits, ctnr)
+		}
+	}
+	dc.pendingDeposits = pendingDeposits
+	pendingDepositsCount.Set(float64(len(dc.pendingDeposits)))
+	return len(removed)
+}
+
+// SetDepositSnapshot sets the snapshot of the deposits made before the deposits in the cache,
+// when the node is bootstrapped from a deposit tree snapshot instead of all deposit logs.
+func (dc *DepositCache) SetDepositSnapshot(snapshot *trieutil.DepositTreeSnapshot) {
+	dc.depositsLock.Lock()
+	defer dc.depositsLock.Unlock()
+	dc.depositSnapshot = snapshot
+}
+
+// DepositSnapshot returns the snapshot of the deposits made before the deposits in the cache,
+// or nil if all deposits are in the cache.
+func (dc *DepositCache) DepositSnapshot() *trieutil.DepositTreeSnapshot {
+	dc.depositsLock.RLock()
+	defer dc.depositsLock.RUnlock()
+	return dc.depositSnapshot
 }
 
 // DepositByPubkey looks through historical deposits and finds one which contains
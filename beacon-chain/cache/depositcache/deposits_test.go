@@ -9,6 +9,7 @@ import (
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	dbpb "github.com/prysmaticlabs/prysm/proto/beacon/db"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 )
 
@@ -226,6 +227,38 @@ func TestBeaconDB_DepositsNumberAndRootAtHeight_ReturnsEmptyTrieIfBlockHeightLes
 	}
 }
 
+func TestBeaconDB_DepositsNumberAndRootAtHeight_CountsSnapshotDeposits(t *testing.T) {
+	dc := DepositCache{}
+	dc.SetDepositSnapshot(&trieutil.DepositTreeSnapshot{
+		DepositCount:         100,
+		DepositRoot:          []byte("snapshot root"),
+		ExecutionBlockHeight: 9,
+	})
+	dc.deposits = []*dbpb.DepositContainer{
+		{
+			Eth1BlockHeight: 10,
+			Deposit:         &ethpb.Deposit{},
+			DepositRoot:     []byte("root"),
+		},
+	}
+
+	n, root := dc.DepositsNumberAndRootAtHeight(context.Background(), big.NewInt(10))
+	if int(n) != 101 {
+		t.Errorf("Returned unexpected deposits number %d wanted %d", n, 101)
+	}
+	if root != bytesutil.ToBytes32([]byte("root")) {
+		t.Errorf("Returned unexpected root: %v", root)
+	}
+
+	n, root = dc.DepositsNumberAndRootAtHeight(context.Background(), big.NewInt(9))
+	if int(n) != 100 {
+		t.Errorf("Returned unexpected deposits number %d wanted %d", n, 100)
+	}
+	if root != bytesutil.ToBytes32([]byte("snapshot root")) {
+		t.Errorf("Returned unexpected root: %v", root)
+	}
+}
+
 func TestBeaconDB_DepositByPubkey_ReturnsFirstMatchingDeposit(t *testing.T) {
 	dc := DepositCache{}
 
@@ -273,3 +306,38 @@ func TestBeaconDB_DepositByPubkey_ReturnsFirstMatchingDeposit(t *testing.T) {
 		t.Errorf("Returned wrong block number %v", blkNum)
 	}
 }
+
+func TestBeaconDB_RemoveDepositsFromHeight(t *testing.T) {
+	ctx := context.Background()
+	dc := NewDepositCache()
+	pubkeys := [][]byte{[]byte("pk0"), []byte("pk1"), []byte("pk0"), []byte("pk2")}
+	heights := []uint64{10, 11, 12, 12}
+	for i, pubkey := range pubkeys {
+		dep := &ethpb.Deposit{Data: &ethpb.Deposit_Data{PublicKey: pubkey}}
+		dc.InsertDeposit(ctx, dep, heights[i], int64(i), [32]byte{})
+		dc.InsertPendingDeposit(ctx, dep, heights[i], int64(i), [32]byte{})
+		dc.SetDepositTxHash(ctx, int64(i), [32]byte{byte(i)})
+	}
+
+	if removed := dc.RemoveDepositsFromHeight(ctx, 12); removed != 2 {
+		t.Errorf("Wanted 2 removed deposits, received %d", removed)
+	}
+	if deposits := dc.AllDeposits(ctx, nil); len(deposits) != 2 {
+		t.Errorf("Wanted 2 remaining deposits, received %d", len(deposits))
+	}
+	if pending := dc.PendingContainers(ctx, nil); len(pending) != 2 {
+		t.Errorf("Wanted 2 remaining pending deposits, received %d", len(pending))
+	}
+	if deposits := dc.DepositsByPubkey(ctx, []byte("pk0")); len(deposits) != 1 || deposits[0].Index != 0 {
+		t.Errorf("Wanted only the first deposit of the public key, received %v", deposits)
+	}
+	if deposits := dc.DepositsByPubkey(ctx, []byte("pk2")); len(deposits) != 0 {
+		t.Errorf("Wanted no deposits of a removed public key, received %v", deposits)
+	}
+
+	// Deposits of the new eth1 chain reuse the removed indices.
+	dc.InsertDeposit(ctx, &ethpb.Deposit{Data: &ethpb.Deposit_Data{PublicKey: []byte("pk3")}}, 13, 2, [32]byte{})
+	if deposits := dc.DepositsByPubkey(ctx, []byte("pk3")); len(deposits) != 1 || deposits[0].TxHash != nil {
+		t.Errorf("Wanted the new deposit without a transaction hash, received %v", deposits)
+	}
+}
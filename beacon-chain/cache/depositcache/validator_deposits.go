@@ -0,0 +1,111 @@
+package depositcache
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	log "github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+var topUpDepositsCount = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "beacondb_top_up_deposits",
+	Help: "The number of deposits to a public key which was deposited to before",
+})
+
+// ValidatorDeposit is a deposit made to the deposit contract for a validator public key.
+type ValidatorDeposit struct {
+	Deposit         *ethpb.Deposit
+	Index           int64
+	Eth1BlockHeight uint64
+	// TxHash is the hash of the eth1 transaction of the deposit, nil if the deposit was loaded
+	// from the database instead of an eth1 log.
+	TxHash []byte
+	// TopUp is true if the public key was deposited to by an earlier deposit, in which case the
+	// deposit only increases the balance of the validator.
+	TopUp bool
+	// IgnoredWithdrawalCredentials is true if a top-up deposit has other withdrawal credentials
+	// than the first deposit of the public key, which the beacon chain ignores.
+	IgnoredWithdrawalCredentials bool
+}
+
+// DepositsByPubkey returns all cached deposits to a public key, sorted by deposit index, the
+// deposits after the first one being top-ups of the validator.
+func (dc *DepositCache) DepositsByPubkey(ctx context.Context, pubKey []byte) []*ValidatorDeposit {
+	ctx, span := trace.StartSpan(ctx, "DepositsCache.DepositsByPubkey")
+	defer span.End()
+	dc.depositsLock.RLock()
+	defer dc.depositsLock.RUnlock()
+
+	indices := dc.pubkeyDeposits[bytesutil.ToBytes48(pubKey)]
+	deposits := make([]*ValidatorDeposit, 0, len(indices))
+	for _, index := range indices {
+		i := sort.Search(len(dc.deposits), func(i int) bool { return dc.deposits[i].Index >= index })
+		if i == len(dc.deposits) || dc.deposits[i].Index != index {
+			continue
+		}
+		ctnr := dc.deposits[i]
+		d := &ValidatorDeposit{
+			Deposit:         ctnr.Deposit,
+			Index:           ctnr.Index,
+			Eth1BlockHeight: ctnr.Eth1BlockHeight,
+			TxHash:          dc.depositTxHashes[ctnr.Index],
+		}
+		if len(deposits) > 0 {
+			d.TopUp = true
+			first := deposits[0].Deposit.Data.WithdrawalCredentials
+			d.IgnoredWithdrawalCredentials = !bytes.Equal(first, ctnr.Deposit.Data.WithdrawalCredentials)
+		}
+		deposits = append(deposits, d)
+	}
+	return deposits
+}
+
+// SetDepositTxHash records the hash of the eth1 transaction of the deposit at the given index.
+func (dc *DepositCache) SetDepositTxHash(ctx context.Context, index int64, txHash [32]byte) {
+	ctx, span := trace.StartSpan(ctx, "DepositsCache.SetDepositTxHash")
+	defer span.End()
+	dc.depositsLock.Lock()
+	defer dc.depositsLock.Unlock()
+
+	if dc.depositTxHashes == nil {
+		dc.depositTxHashes = make(map[int64][]byte)
+	}
+	dc.depositTxHashes[index] = txHash[:]
+}
+
+// indexPubkeyDeposit adds the deposit at the given index to the deposits of its public key,
+// counting it as a top-up if the public key was deposited to before. The deposits lock is
+// expected to be held by the caller.
+func (dc *DepositCache) indexPubkeyDeposit(d *ethpb.Deposit, index int64) {
+	if d.Data == nil {
+		return
+	}
+	if dc.pubkeyDeposits == nil {
+		dc.pubkeyDeposits = make(map[[48]byte][]int64)
+	}
+	key := bytesutil.ToBytes48(d.Data.PublicKey)
+	indices := dc.pubkeyDeposits[key]
+	i := sort.Search(len(indices), func(i int) bool { return indices[i] >= index })
+	if i < len(indices) && indices[i] == index {
+		return
+	}
+	if len(indices) > 0 {
+		topUpDepositsCount.Inc()
+		log.WithFields(log.Fields{
+			"pubkey":           fmt.Sprintf("%#x", bytesutil.Trunc(d.Data.PublicKey)),
+			"index":            index,
+			"previousDeposits": len(indices),
+		}).Debug("Received top-up deposit")
+	}
+	indices = append(indices, 0)
+	copy(indices[i+1:], indices[i:])
+	indices[i] = index
+	dc.pubkeyDeposits[key] = indices
+}
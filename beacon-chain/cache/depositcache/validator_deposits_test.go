@@ -0,0 +1,68 @@
+package depositcache
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	dbpb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+)
+
+func TestDepositsByPubkey_TracksTopUps(t *testing.T) {
+	ctx := context.Background()
+	dc := DepositCache{}
+	pk := bytes.Repeat([]byte{1}, 48)
+	deposit := func(pubkey []byte, creds byte) *ethpb.Deposit {
+		return &ethpb.Deposit{Data: &ethpb.Deposit_Data{PublicKey: pubkey, WithdrawalCredentials: []byte{creds}}}
+	}
+
+	// Deposits are inserted out of order, and the deposit at index 2 is inserted twice.
+	dc.InsertDeposit(ctx, deposit(pk, 1), 10, 2, [32]byte{})
+	dc.InsertDeposit(ctx, deposit(bytes.Repeat([]byte{2}, 48), 1), 9, 1, [32]byte{})
+	dc.InsertDeposit(ctx, deposit(pk, 1), 8, 0, [32]byte{})
+	dc.InsertDeposit(ctx, deposit(pk, 2), 11, 3, [32]byte{})
+	dc.InsertDeposit(ctx, deposit(pk, 1), 10, 2, [32]byte{})
+	dc.SetDepositTxHash(ctx, 3, [32]byte{'a'})
+
+	deposits := dc.DepositsByPubkey(ctx, pk)
+	if len(deposits) != 3 {
+		t.Fatalf("Expected 3 deposits, received %d", len(deposits))
+	}
+	wanted := []struct {
+		index        int64
+		topUp        bool
+		ignoredCreds bool
+	}{
+		{index: 0},
+		{index: 2, topUp: true},
+		{index: 3, topUp: true, ignoredCreds: true},
+	}
+	for i, w := range wanted {
+		d := deposits[i]
+		if d.Index != w.index || d.TopUp != w.topUp || d.IgnoredWithdrawalCredentials != w.ignoredCreds {
+			t.Errorf("Unexpected deposit %d: %+v", i, d)
+		}
+	}
+	if deposits[0].TxHash != nil {
+		t.Errorf("Expected no transaction hash, received %#x", deposits[0].TxHash)
+	}
+	if txHash := [32]byte{'a'}; !bytes.Equal(deposits[2].TxHash, txHash[:]) {
+		t.Errorf("Unexpected transaction hash %#x", deposits[2].TxHash)
+	}
+}
+
+func TestDepositsByPubkey_InsertDepositContainers(t *testing.T) {
+	ctx := context.Background()
+	dc := DepositCache{}
+	pk := bytes.Repeat([]byte{1}, 48)
+	dc.InsertDepositContainers(ctx, []*dbpb.DepositContainer{
+		{Index: 1, Deposit: &ethpb.Deposit{Data: &ethpb.Deposit_Data{PublicKey: pk}}},
+		{Index: 0, Deposit: &ethpb.Deposit{Data: &ethpb.Deposit_Data{PublicKey: pk}}},
+	})
+
+	deposits := dc.DepositsByPubkey(ctx, pk)
+	if len(deposits) != 2 || deposits[0].Index != 0 || deposits[1].Index != 1 || !deposits[1].TopUp {
+		t.Errorf("Unexpected deposits %v", deposits)
+	}
+}
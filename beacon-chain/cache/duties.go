@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+)
+
+var (
+	// maxDutiesCacheSize is the number of (epoch, public key set) entries kept in the cache.
+	// Every connected validator client requests the duties of its keys for the current and
+	// next epoch.
+	maxDutiesCacheSize = 2048
+
+	// Metrics.
+	dutiesCacheHit = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "duties_cache_hit",
+		Help: "The number of duties requests that are present in the cache.",
+	})
+	dutiesCacheMiss = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "duties_cache_miss",
+		Help: "The number of duties requests that aren't present in the cache.",
+	})
+	dutiesCacheInvalidations = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "duties_cache_invalidations",
+		Help: "The number of times the duties cache was cleared because of a reorg.",
+	})
+)
+
+// dutiesEntry holds the duties of a set of public keys, computed from the head state of the
+// block headRoot.
+type dutiesEntry struct {
+	headRoot [32]byte
+	duties   []*ethpb.DutiesResponse_Duty
+}
+
+// DutiesCache stores the duties responses of the RPC service keyed by epoch and the hash of
+// the requested public keys, so that the duties of validator clients requesting the same
+// keys are computed once per head. Entries computed from a different head are ignored, as
+// the statuses and proposer slots may have changed since, and the whole cache is cleared
+// when the head of the chain reorgs.
+type DutiesCache struct {
+	cache    *seedEpochCache
+	headRoot [32]byte
+	lock     sync.Mutex
+}
+
+// NewDutiesCache creates a new duties cache.
+func NewDutiesCache() *DutiesCache {
+	return &DutiesCache{
+		cache: newSeedEpochCache(),
+	}
+}
+
+// DutiesKey returns the cache key of a list of public keys. The order of the keys is part of
+// the key since duties are returned in the order they were requested.
+func DutiesKey(pubKeys [][]byte) [32]byte {
+	var enc []byte
+	for _, k := range pubKeys {
+		enc = append(enc, k...)
+	}
+	return hashutil.Hash(enc)
+}
+
+// Duties returns the cached duties of the given public key set and epoch, if they were
+// computed from the head state of the block headRoot. Returns nil otherwise. The returned
+// duties are shared and must not be mutated.
+func (c *DutiesCache) Duties(key [32]byte, epoch uint64, headRoot [32]byte) []*ethpb.DutiesResponse_Duty {
+	obj, exists := c.cache.get(key, epoch)
+	if !exists {
+		dutiesCacheMiss.Inc()
+		return nil
+	}
+	entry, ok := obj.(*dutiesEntry)
+	if !ok || entry.headRoot != headRoot {
+		dutiesCacheMiss.Inc()
+		return nil
+	}
+	dutiesCacheHit.Inc()
+	return entry.duties
+}
+
+// AddDuties caches the duties of the given public key set and epoch computed from the head
+// state of the block headRoot.
+func (c *DutiesCache) AddDuties(key [32]byte, epoch uint64, headRoot [32]byte, duties []*ethpb.DutiesResponse_Duty) {
+	c.cache.add(key, epoch, &dutiesEntry{headRoot: headRoot, duties: duties}, maxDutiesCacheSize)
+}
+
+// HeadUpdated records the new head of the chain. The cache is cleared if the new head does not
+// build on the previous one, dropping the entries of the abandoned branch.
+func (c *DutiesCache) HeadUpdated(parentRoot [32]byte, headRoot [32]byte) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.headRoot != [32]byte{} && c.headRoot != parentRoot && c.headRoot != headRoot {
+		c.cache.clear()
+		dutiesCacheInvalidations.Inc()
+	}
+	c.headRoot = headRoot
+}
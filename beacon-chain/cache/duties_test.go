@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"reflect"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+func TestDutiesCache_AddAndRetrieve(t *testing.T) {
+	c := NewDutiesCache()
+	key := DutiesKey([][]byte{{'A'}, {'B'}})
+	headRoot := [32]byte{'H'}
+
+	if duties := c.Duties(key, 1, headRoot); duties != nil {
+		t.Error("Expected duties not to exist in empty cache")
+	}
+
+	wanted := []*ethpb.DutiesResponse_Duty{
+		{PublicKey: []byte{'A'}, AttesterSlot: 8},
+		{PublicKey: []byte{'B'}, ProposerSlot: 9},
+	}
+	c.AddDuties(key, 1, headRoot, wanted)
+	if duties := c.Duties(key, 1, headRoot); !reflect.DeepEqual(duties, wanted) {
+		t.Errorf("Wanted %v, got %v", wanted, duties)
+	}
+	if duties := c.Duties(key, 2, headRoot); duties != nil {
+		t.Error("Expected no duties for a different epoch")
+	}
+	if duties := c.Duties(DutiesKey([][]byte{{'B'}, {'A'}}), 1, headRoot); duties != nil {
+		t.Error("Expected no duties for differently ordered keys")
+	}
+}
+
+func TestDutiesCache_HeadChanged(t *testing.T) {
+	c := NewDutiesCache()
+	key := DutiesKey([][]byte{{'A'}})
+	c.AddDuties(key, 1, [32]byte{'H'}, []*ethpb.DutiesResponse_Duty{{PublicKey: []byte{'A'}}})
+
+	if duties := c.Duties(key, 1, [32]byte{'I'}); duties != nil {
+		t.Error("Expected duties of a different head to be ignored")
+	}
+}
+
+func TestDutiesCache_HeadUpdated(t *testing.T) {
+	c := NewDutiesCache()
+	key := DutiesKey([][]byte{{'A'}})
+	headRoot := [32]byte{'H'}
+	c.AddDuties(key, 1, headRoot, []*ethpb.DutiesResponse_Duty{{PublicKey: []byte{'A'}}})
+
+	c.HeadUpdated([32]byte{}, [32]byte{1})
+	c.HeadUpdated([32]byte{1}, [32]byte{2})
+	if duties := c.Duties(key, 1, headRoot); duties == nil {
+		t.Fatal("Expected duties to survive head updates extending the chain")
+	}
+
+	// The new head does not build on head 2.
+	c.HeadUpdated([32]byte{1}, [32]byte{3})
+	if duties := c.Duties(key, 1, headRoot); duties != nil {
+		t.Error("Expected duties to be cleared on reorg")
+	}
+}
@@ -1,6 +1,8 @@
 package cache
 
 import (
+	"sync"
+
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -24,38 +26,127 @@ var (
 // HotStateCache is used to store the processed beacon state after finalized check point..
 type HotStateCache struct {
 	cache *lru.Cache
+	// lock serializes the insertions and budget evictions of the LRU cache with the accounting of
+	// the state cache budget.
+	lock       sync.Mutex
+	pinned     map[[32]byte]*pinnedState
+	pinnedLock sync.RWMutex
+}
+
+// pinnedState is a state kept by the cache until it is unpinned as many times as it was pinned.
+type pinnedState struct {
+	state *stateTrie.BeaconState
+	pins  int
 }
 
-// NewHotStateCache initializes the map and underlying cache.
+// NewHotStateCache initializes the map and underlying cache. The cache counts towards the state
+// cache memory budget.
 func NewHotStateCache() *HotStateCache {
-	cache, err := lru.New(hotStateCacheSize)
+	c := &HotStateCache{
+		pinned: make(map[[32]byte]*pinnedState),
+	}
+	cache, err := lru.NewWithEvict(hotStateCacheSize, c.onEvicted)
 	if err != nil {
 		panic(err)
 	}
-	return &HotStateCache{
-		cache: cache,
-	}
+	c.cache = cache
+	stateCacheBudget.register(hotStateBudgetKind, c)
+	return c
 }
 
 // Get returns a cached response via input block root, if any.
 // The response is copied by default.
 func (c *HotStateCache) Get(root [32]byte) *stateTrie.BeaconState {
-	item, exists := c.cache.Get(root)
-
-	if exists && item != nil {
+	if st := c.get(root); st != nil {
 		hotStateCacheHit.Inc()
-		return item.(*stateTrie.BeaconState).Copy()
+		return st.Copy()
 	}
 	hotStateCacheMiss.Inc()
 	return nil
 }
 
+// get returns the cached or pinned state of the block root without copying it.
+func (c *HotStateCache) get(root [32]byte) *stateTrie.BeaconState {
+	item, exists := c.cache.Get(root)
+	if exists && item != nil {
+		return item.(*stateTrie.BeaconState)
+	}
+	c.pinnedLock.RLock()
+	defer c.pinnedLock.RUnlock()
+	if p, ok := c.pinned[root]; ok {
+		return p.state
+	}
+	return nil
+}
+
+// Pin keeps the state of the block root in the cache until it is unpinned, even if it is evicted
+// from the LRU cache or to stay within the state cache budget, so long running queries can read
+// the state they operate on until they are done. It returns a copy of the state, or nil if the
+// state is not cached, in which case it is not pinned. Every successful Pin must be followed by
+// an Unpin.
+func (c *HotStateCache) Pin(root [32]byte) *stateTrie.BeaconState {
+	st := c.get(root)
+	if st == nil {
+		return nil
+	}
+	c.pinnedLock.Lock()
+	defer c.pinnedLock.Unlock()
+	p, ok := c.pinned[root]
+	if !ok {
+		p = &pinnedState{state: st}
+		c.pinned[root] = p
+	}
+	p.pins++
+	return st.Copy()
+}
+
+// Unpin releases a pin of the state of the block root. The state is dropped once it is no longer
+// pinned, unless it is still in the LRU cache.
+func (c *HotStateCache) Unpin(root [32]byte) {
+	c.pinnedLock.Lock()
+	defer c.pinnedLock.Unlock()
+	p, ok := c.pinned[root]
+	if !ok {
+		return
+	}
+	p.pins--
+	if p.pins <= 0 {
+		delete(c.pinned, root)
+	}
+}
+
 // Put the response in the cache.
 func (c *HotStateCache) Put(root [32]byte, state *stateTrie.BeaconState) {
+	c.lock.Lock()
 	c.cache.Add(root, state)
+	if c.cache.Contains(root) {
+		stateCacheBudget.set(hotStateBudgetKind, c, string(root[:]), stateSizeEstimate(state))
+	}
+	c.lock.Unlock()
+
+	stateCacheBudget.enforce()
+}
+
+func (c *HotStateCache) onEvicted(key interface{}, _ interface{}) {
+	if root, ok := key.([32]byte); ok {
+		stateCacheBudget.remove(hotStateBudgetKind, c, string(root[:]))
+	}
 }
 
-// Has returns true if the key exists in the cache.
+func (c *HotStateCache) evictOldest() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, _, ok := c.cache.RemoveOldest()
+	return ok
+}
+
+// Has returns true if the key exists in the cache, or is pinned.
 func (c *HotStateCache) Has(root [32]byte) bool {
-	return c.cache.Contains(root)
+	if c.cache.Contains(root) {
+		return true
+	}
+	c.pinnedLock.RLock()
+	defer c.pinnedLock.RUnlock()
+	_, ok := c.pinned[root]
+	return ok
 }
@@ -39,3 +39,41 @@ func TestHotStateCache_RoundTrip(t *testing.T) {
 		t.Error("Expected equal protos to return from cache")
 	}
 }
+
+func TestHotStateCache_PinSurvivesEviction(t *testing.T) {
+	c := cache.NewHotStateCache()
+	root := [32]byte{'A'}
+	if st := c.Pin(root); st != nil {
+		t.Errorf("Pinned a state which is not cached: %v", st)
+	}
+
+	state, err := stateTrie.InitializeFromProto(&pb.BeaconState{
+		Slot: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Put(root, state)
+	pinned := c.Pin(root)
+	if pinned == nil || pinned.Slot() != 10 {
+		t.Fatalf("Wanted pinned state of slot 10, got %v", pinned)
+	}
+	c.Pin(root)
+
+	// Fill the cache with other states to evict the pinned state from the LRU cache.
+	for i := 0; i < 32; i++ {
+		c.Put([32]byte{byte(i)}, state)
+	}
+	if !c.Has(root) || c.Get(root) == nil {
+		t.Fatal("Pinned state was evicted")
+	}
+
+	c.Unpin(root)
+	if c.Get(root) == nil {
+		t.Fatal("State was dropped while still pinned")
+	}
+	c.Unpin(root)
+	if c.Has(root) || c.Get(root) != nil {
+		t.Error("Unpinned state was not dropped after eviction")
+	}
+}
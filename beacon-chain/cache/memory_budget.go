@@ -0,0 +1,185 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// Kinds of caches sharing the state cache memory budget.
+const (
+	hotStateBudgetKind        = "hot_state"
+	postStateBudgetKind       = "post_state"
+	checkpointStateBudgetKind = "checkpoint_state"
+	committeeBudgetKind       = "committee"
+)
+
+// validatorSizeEstimate approximates the memory held for each validator of a state: its
+// record, its balance and its share of the field tries of the registry.
+const validatorSizeEstimate = 256
+
+var (
+	// budgetWeights scale the memory usage of each kind of cache when picking the cache to evict
+	// from. Hot states and post-states are replayed from the database cheaply compared to the
+	// epoch processing behind checkpoint states and the shuffling behind committees, so they are
+	// evicted first.
+	budgetWeights = map[string]uint64{
+		hotStateBudgetKind:        2,
+		postStateBudgetKind:       2,
+		checkpointStateBudgetKind: 1,
+		committeeBudgetKind:       1,
+	}
+
+	// stateCacheBudget is the memory budget shared by the state and committee caches.
+	stateCacheBudget = newMemoryBudget()
+
+	// Metrics.
+	stateCacheBudgetUsage = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "state_cache_budget_usage_bytes",
+		Help: "The estimated memory held by the caches sharing the state cache budget.",
+	}, []string{"cache"})
+	stateCacheBudgetEvictions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "state_cache_budget_evictions_total",
+		Help: "The number of cache entries evicted to keep the caches within the state cache budget.",
+	}, []string{"cache"})
+)
+
+// evictor is a cache which can give up its oldest entry to stay within the memory budget.
+type evictor interface {
+	// evictOldest removes the oldest entry of the cache, returning false if the cache is empty.
+	evictOldest() bool
+}
+
+type budgetedCache struct {
+	owner   evictor
+	entries map[string]uint64
+	usage   uint64
+}
+
+// memoryBudget tracks the estimated size of the entries of several caches, and evicts entries
+// from the cache with the highest weighted usage until the total usage is within the limit set
+// by the max state cache flag. Only the most recently registered cache of each kind is tracked.
+type memoryBudget struct {
+	caches map[string]*budgetedCache
+	lock   sync.Mutex
+}
+
+func newMemoryBudget() *memoryBudget {
+	return &memoryBudget{
+		caches: make(map[string]*budgetedCache),
+	}
+}
+
+// limit of the budget in bytes, or 0 if there is no limit.
+func (b *memoryBudget) limit() uint64 {
+	return flags.Get().MaxStateCacheMB << 20
+}
+
+// register makes the cache the tracked cache of its kind, forgetting the entries of the cache it
+// replaces.
+func (b *memoryBudget) register(kind string, owner evictor) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.caches[kind] = &budgetedCache{
+		owner:   owner,
+		entries: make(map[string]uint64),
+	}
+	stateCacheBudgetUsage.WithLabelValues(kind).Set(0)
+}
+
+// set records the size of an entry of the cache. The cache must hold the lock it inserts and
+// evicts entries under when calling set, so that an entry can't be evicted before it is recorded,
+// and must call enforce once it released that lock.
+func (b *memoryBudget) set(kind string, owner evictor, key string, size uint64) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	c, ok := b.caches[kind]
+	if !ok || c.owner != owner {
+		return
+	}
+	c.usage = c.usage - c.entries[key] + size
+	c.entries[key] = size
+	stateCacheBudgetUsage.WithLabelValues(kind).Set(float64(c.usage))
+}
+
+// remove forgets an entry which left the cache.
+func (b *memoryBudget) remove(kind string, owner evictor, key string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	c, ok := b.caches[kind]
+	if !ok || c.owner != owner {
+		return
+	}
+	c.usage -= c.entries[key]
+	delete(c.entries, key)
+	stateCacheBudgetUsage.WithLabelValues(kind).Set(float64(c.usage))
+}
+
+// usage is the estimated memory held by all the tracked caches.
+func (b *memoryBudget) usage() uint64 {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.totalUsage()
+}
+
+func (b *memoryBudget) totalUsage() uint64 {
+	total := uint64(0)
+	for _, c := range b.caches {
+		total += c.usage
+	}
+	return total
+}
+
+// enforce evicts the oldest entries of the caches with the highest weighted usage until the
+// caches are within the budget. The budget lock is released while evicting, as evicting an entry
+// removes it from the budget, so the caller must not hold the lock of any cache.
+func (b *memoryBudget) enforce() {
+	limit := b.limit()
+	if limit == 0 {
+		return
+	}
+	for {
+		b.lock.Lock()
+		if b.totalUsage() <= limit {
+			b.lock.Unlock()
+			return
+		}
+		var victim evictor
+		victimKind := ""
+		highest := uint64(0)
+		for kind, c := range b.caches {
+			if score := c.usage * budgetWeights[kind]; score > highest {
+				victim, victimKind, highest = c.owner, kind, score
+			}
+		}
+		b.lock.Unlock()
+
+		if victim == nil || !victim.evictOldest() {
+			return
+		}
+		stateCacheBudgetEvictions.WithLabelValues(victimKind).Inc()
+	}
+}
+
+// stateSizeEstimate approximates the memory held by a beacon state, from its fixed size vectors
+// of roots, randao mixes and slashings, and its number of validators.
+func stateSizeEstimate(state *stateTrie.BeaconState) uint64 {
+	if state == nil {
+		return 0
+	}
+	cfg := params.BeaconConfig()
+	fixed := 2*cfg.SlotsPerHistoricalRoot*32 + cfg.EpochsPerHistoricalVector*32 + cfg.EpochsPerSlashingsVector*8
+	return fixed + uint64(state.NumValidators())*validatorSizeEstimate
+}
+
+// committeesSizeEstimate approximates the memory held by the shuffled committees of a seed.
+func committeesSizeEstimate(committees *Committees) uint64 {
+	if committees == nil {
+		return 0
+	}
+	return uint64(len(committees.ShuffledIndices)+len(committees.SortedIndices)+len(committees.ProposerIndices)) * 8
+}
@@ -0,0 +1,123 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+func TestMemoryBudget_EvictsWeightedCacheFirst(t *testing.T) {
+	stateCacheBudget = newMemoryBudget()
+	flags.Init(&flags.GlobalFlags{MaxStateCacheMB: 6})
+	defer flags.Init(&flags.GlobalFlags{})
+
+	checkpointStates := NewCheckpointStateCache()
+	postStates := NewPostStateCache()
+	st, err := stateTrie.InitializeFromProto(&pb.BeaconState{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	size := stateSizeEstimate(st)
+	if 3*size <= 6<<20 || 2*size > 6<<20 {
+		t.Fatalf("Test expects the budget to hold 2 empty states, a state is estimated at %d bytes", size)
+	}
+
+	if err := checkpointStates.AddCheckpointState(&CheckpointState{
+		Checkpoint: &ethpb.Checkpoint{Epoch: 1, Root: []byte{'A'}},
+		State:      st,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	for i := byte(0); i < 3; i++ {
+		postStates.AddState([32]byte{i}, st)
+	}
+
+	// Post-states are weighted higher, so they are evicted before the checkpoint state.
+	if len(checkpointStates.CheckpointStateKeys()) != 1 {
+		t.Error("Expected the checkpoint state to stay cached")
+	}
+	if postStates.cache.Len() != 1 {
+		t.Errorf("Expected 1 post-state to stay cached, %d are", postStates.cache.Len())
+	}
+	if postStates.StateByRoot([32]byte{2}) == nil {
+		t.Error("Expected the most recent post-state to stay cached")
+	}
+	if usage := stateCacheBudget.usage(); usage != 2*size {
+		t.Errorf("Expected a usage of %d bytes, received %d", 2*size, usage)
+	}
+}
+
+func TestMemoryBudget_NoLimit(t *testing.T) {
+	stateCacheBudget = newMemoryBudget()
+	flags.Init(&flags.GlobalFlags{})
+
+	postStates := NewPostStateCache()
+	st, err := stateTrie.InitializeFromProto(&pb.BeaconState{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := byte(0); i < 5; i++ {
+		postStates.AddState([32]byte{i}, st)
+	}
+	if postStates.cache.Len() != 5 {
+		t.Errorf("Expected 5 post-states to be cached without a budget, %d are", postStates.cache.Len())
+	}
+	if usage := stateCacheBudget.usage(); usage != 5*stateSizeEstimate(st) {
+		t.Errorf("Expected a usage of %d bytes, received %d", 5*stateSizeEstimate(st), usage)
+	}
+}
+
+func TestMemoryBudget_ReleasesEvictedCommittees(t *testing.T) {
+	stateCacheBudget = newMemoryBudget()
+
+	c := NewCommitteesCache()
+	for i := 0; i < maxCommitteesCacheSize+2; i++ {
+		if err := c.AddCommitteeShuffledList(&Committees{
+			Seed:            [32]byte{byte(i)},
+			ShuffledIndices: []uint64{1, 2, 3},
+			SortedIndices:   []uint64{1, 2, 3},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := uint64(maxCommitteesCacheSize) * 6 * 8
+	if usage := stateCacheBudget.usage(); usage != want {
+		t.Errorf("Expected a usage of %d bytes, received %d", want, usage)
+	}
+}
+
+func TestMemoryBudget_ConcurrentAccounting(t *testing.T) {
+	stateCacheBudget = newMemoryBudget()
+	flags.Init(&flags.GlobalFlags{MaxStateCacheMB: 6})
+	defer flags.Init(&flags.GlobalFlags{})
+
+	hotStates := NewHotStateCache()
+	postStates := NewPostStateCache()
+	st, err := stateTrie.InitializeFromProto(&pb.BeaconState{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				root := [32]byte{byte(i), byte(j)}
+				hotStates.Put(root, st)
+				postStates.AddState(root, st)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// Every state still cached is accounted for, and only those.
+	want := uint64(hotStates.cache.Len()+postStates.cache.Len()) * stateSizeEstimate(st)
+	if usage := stateCacheBudget.usage(); usage != want {
+		t.Errorf("Expected a usage of %d bytes, received %d", want, usage)
+	}
+}
@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+)
+
+var (
+	// defaultPostStateCacheSize is used when no cache size has been configured. It covers the
+	// target states of attestations for the current and previous epoch on a few branches.
+	defaultPostStateCacheSize = 8
+
+	// Metrics.
+	postStateCacheHit = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "post_state_cache_hit",
+		Help: "The number of post-state requests that are present in the cache.",
+	})
+	postStateCacheMiss = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "post_state_cache_miss",
+		Help: "The number of post-state requests that aren't present in the cache.",
+	})
+)
+
+// PostStateCache is a bounded LRU cache of the post-states of processed blocks, keyed by block
+// root, so attestations targeting recent blocks are validated without regenerating the state.
+type PostStateCache struct {
+	cache *lru.Cache
+	// lock serializes the insertions and budget evictions of the LRU cache with the accounting of
+	// the state cache budget.
+	lock sync.Mutex
+}
+
+// NewPostStateCache creates a new post-state cache holding as many states as configured by the
+// post state cache size flag. The cache counts towards the state cache memory budget.
+func NewPostStateCache() *PostStateCache {
+	size := flags.Get().PostStateCacheSize
+	if size <= 0 {
+		size = defaultPostStateCacheSize
+	}
+	c := &PostStateCache{}
+	cache, err := lru.NewWithEvict(size, c.onEvicted)
+	if err != nil {
+		panic(err)
+	}
+	c.cache = cache
+	stateCacheBudget.register(postStateBudgetKind, c)
+	return c
+}
+
+// StateByRoot returns a copy of the cached post-state of the block root, or nil if the state
+// isn't cached.
+func (c *PostStateCache) StateByRoot(root [32]byte) *stateTrie.BeaconState {
+	item, exists := c.cache.Get(root)
+	if exists && item != nil {
+		postStateCacheHit.Inc()
+		return item.(*stateTrie.BeaconState).Copy()
+	}
+	postStateCacheMiss.Inc()
+	return nil
+}
+
+// AddState adds the post-state of the block root to the cache, evicting the least recently
+// used state once the cache is full. The state must not be mutated after it is added.
+func (c *PostStateCache) AddState(root [32]byte, state *stateTrie.BeaconState) {
+	c.lock.Lock()
+	c.cache.Add(root, state)
+	if c.cache.Contains(root) {
+		stateCacheBudget.set(postStateBudgetKind, c, string(root[:]), stateSizeEstimate(state))
+	}
+	c.lock.Unlock()
+
+	stateCacheBudget.enforce()
+}
+
+func (c *PostStateCache) onEvicted(key interface{}, _ interface{}) {
+	if root, ok := key.([32]byte); ok {
+		stateCacheBudget.remove(postStateBudgetKind, c, string(root[:]))
+	}
+}
+
+func (c *PostStateCache) evictOldest() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, _, ok := c.cache.RemoveOldest()
+	return ok
+}
@@ -0,0 +1,60 @@
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+func TestPostStateCache_RoundTrip(t *testing.T) {
+	c := cache.NewPostStateCache()
+	root := [32]byte{'A'}
+	if state := c.StateByRoot(root); state != nil {
+		t.Errorf("Empty cache returned an object: %v", state)
+	}
+
+	state, err := stateTrie.InitializeFromProto(&pb.BeaconState{Slot: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.AddState(root, state)
+
+	res := c.StateByRoot(root)
+	if res == nil || res.Slot() != 10 {
+		t.Fatalf("Wanted cached state at slot 10, got %v", res)
+	}
+	// The cached state is copied, mutating it does not change the cache.
+	if err := res.SetSlot(11); err != nil {
+		t.Fatal(err)
+	}
+	if c.StateByRoot(root).Slot() != 10 {
+		t.Error("Mutating a returned state changed the cached state")
+	}
+}
+
+func TestPostStateCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	flags.Init(&flags.GlobalFlags{PostStateCacheSize: 2})
+	defer flags.Init(&flags.GlobalFlags{})
+
+	c := cache.NewPostStateCache()
+	for i := byte(0); i < 3; i++ {
+		state, err := stateTrie.InitializeFromProto(&pb.BeaconState{Slot: uint64(i)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.AddState([32]byte{i}, state)
+		if i == 1 {
+			// Use the first state so the second one is evicted.
+			c.StateByRoot([32]byte{0})
+		}
+	}
+	if c.StateByRoot([32]byte{1}) != nil {
+		t.Error("Expected least recently used state to be evicted")
+	}
+	if c.StateByRoot([32]byte{0}) == nil || c.StateByRoot([32]byte{2}) == nil {
+		t.Error("Expected recently used states to be cached")
+	}
+}
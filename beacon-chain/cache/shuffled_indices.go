@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+)
+
+var (
+	// ErrNotShuffledIndices will be returned when a cache object is not a list of
+	// shuffled indices.
+	ErrNotShuffledIndices = errors.New("object is not a shuffled indices list")
+
+	// defaultShuffledIndicesCacheSize is used when no cache size has been configured. The
+	// current and next epoch shufflings of 2 concurrent branches fit with room to spare.
+	defaultShuffledIndicesCacheSize = 8
+
+	// Metrics.
+	shuffledIndicesCacheHit = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "shuffled_indices_cache_hit",
+		Help: "The number of shuffled indices requests that are present in the cache.",
+	})
+	shuffledIndicesCacheMiss = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "shuffled_indices_cache_miss",
+		Help: "The number of shuffled indices requests that aren't present in the cache.",
+	})
+	shuffledIndicesCacheSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "shuffled_indices_cache_size",
+		Help: "The number of shuffled indices lists currently held in the cache.",
+	})
+)
+
+// ShuffledIndicesCache stores the shuffled active validator indices of an epoch keyed by
+// (seed, active validator count, epoch), so committees of the epoch can be sliced out of a
+// single shuffling instead of un-shuffling the whole validator set for every committee.
+type ShuffledIndicesCache struct {
+	cache *seedEpochCache
+}
+
+// NewShuffledIndicesCache creates a new shuffled indices cache.
+func NewShuffledIndicesCache() *ShuffledIndicesCache {
+	return &ShuffledIndicesCache{
+		cache: newSeedEpochCache(),
+	}
+}
+
+// ShuffledIndices returns the shuffled indices of a given seed, active validator count and
+// epoch. Returns nil if the list does not exist in the cache. The returned list is shared and
+// must not be mutated.
+func (c *ShuffledIndicesCache) ShuffledIndices(seed [32]byte, activeCount uint64, epoch uint64) ([]uint64, error) {
+	obj, exists := c.cache.get(shuffledIndicesKey(seed, activeCount), epoch)
+	if !exists {
+		shuffledIndicesCacheMiss.Inc()
+		return nil, nil
+	}
+	shuffledIndicesCacheHit.Inc()
+
+	indices, ok := obj.([]uint64)
+	if !ok {
+		return nil, ErrNotShuffledIndices
+	}
+	return indices, nil
+}
+
+// AddShuffledIndices adds the shuffled indices of a given seed and epoch to the cache, keyed by
+// the number of indices. The entries of the oldest epochs are evicted once the configured cache
+// size is reached.
+func (c *ShuffledIndicesCache) AddShuffledIndices(seed [32]byte, epoch uint64, indices []uint64) {
+	size := flags.Get().ShuffledIndicesCacheSize
+	if size <= 0 {
+		size = defaultShuffledIndicesCacheSize
+	}
+	c.cache.add(shuffledIndicesKey(seed, uint64(len(indices))), epoch, indices, size)
+	shuffledIndicesCacheSize.Set(float64(c.cache.len()))
+}
+
+// PruneBefore removes the shuffled indices of every epoch lower than the given epoch.
+func (c *ShuffledIndicesCache) PruneBefore(epoch uint64) {
+	c.cache.pruneBefore(epoch)
+	shuffledIndicesCacheSize.Set(float64(c.cache.len()))
+}
+
+// shuffledIndicesKey combines the seed with the number of active validators, so shufflings of
+// conflicting validator sets with the same seed do not share an entry.
+func shuffledIndicesKey(seed [32]byte, activeCount uint64) [32]byte {
+	return hashutil.Hash(append(seed[:], bytesutil.Bytes8(activeCount)...))
+}
@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+)
+
+func TestShuffledIndicesCache_AddAndRetrieve(t *testing.T) {
+	c := NewShuffledIndicesCache()
+	seed := [32]byte{'A'}
+
+	indices, err := c.ShuffledIndices(seed, 5, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if indices != nil {
+		t.Error("Expected shuffled indices not to exist in empty cache")
+	}
+
+	wanted := []uint64{5, 3, 1, 2, 4}
+	c.AddShuffledIndices(seed, 1, wanted)
+	indices, err = c.ShuffledIndices(seed, 5, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(indices, wanted) {
+		t.Errorf("Wanted %v, got %v", wanted, indices)
+	}
+
+	// Same seed at a different epoch is a different shuffling.
+	indices, err = c.ShuffledIndices(seed, 5, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if indices != nil {
+		t.Error("Expected shuffled indices not to exist for a different epoch")
+	}
+
+	// Same seed with a different number of active validators is a conflicting validator set.
+	indices, err = c.ShuffledIndices(seed, 4, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if indices != nil {
+		t.Error("Expected shuffled indices not to exist for a different active validator count")
+	}
+}
+
+func TestShuffledIndicesCache_EvictsOldestEpochFirst(t *testing.T) {
+	flags.Init(&flags.GlobalFlags{ShuffledIndicesCacheSize: 2})
+	defer flags.Init(&flags.GlobalFlags{})
+
+	c := NewShuffledIndicesCache()
+	c.AddShuffledIndices([32]byte{'B'}, 5, []uint64{1})
+	c.AddShuffledIndices([32]byte{'A'}, 3, []uint64{2})
+	c.AddShuffledIndices([32]byte{'C'}, 4, []uint64{3})
+
+	if c.cache.len() != 2 {
+		t.Fatalf("Wanted cache size 2, got %d", c.cache.len())
+	}
+	indices, err := c.ShuffledIndices([32]byte{'A'}, 1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if indices != nil {
+		t.Error("Expected oldest epoch to be evicted")
+	}
+	indices, err = c.ShuffledIndices([32]byte{'C'}, 1, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if indices == nil {
+		t.Error("Expected epoch 4 to remain in cache")
+	}
+	indices, err = c.ShuffledIndices([32]byte{'B'}, 1, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if indices == nil {
+		t.Error("Expected epoch 5 to remain in cache")
+	}
+}
+
+func TestShuffledIndicesCache_PruneBefore(t *testing.T) {
+	c := NewShuffledIndicesCache()
+	for i := uint64(0); i < 4; i++ {
+		c.AddShuffledIndices([32]byte{byte(i)}, i, []uint64{i})
+	}
+	c.PruneBefore(2)
+	if c.cache.len() != 2 {
+		t.Fatalf("Wanted cache size 2, got %d", c.cache.len())
+	}
+	indices, err := c.ShuffledIndices([32]byte{1}, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if indices != nil {
+		t.Error("Expected epoch 1 to be pruned")
+	}
+}
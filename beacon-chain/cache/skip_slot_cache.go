@@ -26,10 +26,12 @@ var (
 )
 
 // SkipSlotCache is used to store the cached results of processing skip slots in state.ProcessSlots.
+// States are keyed by the root of the pre-state and the target slot, as states of different forks
+// may be advanced from the same slot.
 type SkipSlotCache struct {
 	cache      *lru.Cache
 	lock       sync.RWMutex
-	inProgress map[uint64]bool
+	inProgress map[[32]byte]bool
 }
 
 // NewSkipSlotCache initializes the map and underlying cache.
@@ -40,13 +42,13 @@ func NewSkipSlotCache() *SkipSlotCache {
 	}
 	return &SkipSlotCache{
 		cache:      cache,
-		inProgress: make(map[uint64]bool),
+		inProgress: make(map[[32]byte]bool),
 	}
 }
 
 // Get waits for any in progress calculation to complete before returning a
 // cached response, if any.
-func (c *SkipSlotCache) Get(ctx context.Context, slot uint64) (*stateTrie.BeaconState, error) {
+func (c *SkipSlotCache) Get(ctx context.Context, key [32]byte) (*stateTrie.BeaconState, error) {
 	if !featureconfig.Get().EnableSkipSlotsCache {
 		// Return a miss result if cache is not enabled.
 		skipSlotCacheMiss.Inc()
@@ -63,7 +65,7 @@ func (c *SkipSlotCache) Get(ctx context.Context, slot uint64) (*stateTrie.Beacon
 		}
 
 		c.lock.RLock()
-		if !c.inProgress[slot] {
+		if !c.inProgress[key] {
 			c.lock.RUnlock()
 			break
 		}
@@ -76,7 +78,7 @@ func (c *SkipSlotCache) Get(ctx context.Context, slot uint64) (*stateTrie.Beacon
 		delay = math.Min(delay, maxDelay)
 	}
 
-	item, exists := c.cache.Get(slot)
+	item, exists := c.cache.Get(key)
 
 	if exists && item != nil {
 		skipSlotCacheHit.Inc()
@@ -88,7 +90,7 @@ func (c *SkipSlotCache) Get(ctx context.Context, slot uint64) (*stateTrie.Beacon
 
 // MarkInProgress a request so that any other similar requests will block on
 // Get until MarkNotInProgress is called.
-func (c *SkipSlotCache) MarkInProgress(slot uint64) error {
+func (c *SkipSlotCache) MarkInProgress(key [32]byte) error {
 	if !featureconfig.Get().EnableSkipSlotsCache {
 		return nil
 	}
@@ -96,16 +98,16 @@ func (c *SkipSlotCache) MarkInProgress(slot uint64) error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	if c.inProgress[slot] {
+	if c.inProgress[key] {
 		return ErrAlreadyInProgress
 	}
-	c.inProgress[slot] = true
+	c.inProgress[key] = true
 	return nil
 }
 
 // MarkNotInProgress will release the lock on a given request. This should be
 // called after put.
-func (c *SkipSlotCache) MarkNotInProgress(slot uint64) error {
+func (c *SkipSlotCache) MarkNotInProgress(key [32]byte) error {
 	if !featureconfig.Get().EnableSkipSlotsCache {
 		return nil
 	}
@@ -113,18 +115,18 @@ func (c *SkipSlotCache) MarkNotInProgress(slot uint64) error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	delete(c.inProgress, slot)
+	delete(c.inProgress, key)
 	return nil
 }
 
 // Put the response in the cache.
-func (c *SkipSlotCache) Put(ctx context.Context, slot uint64, state *stateTrie.BeaconState) error {
+func (c *SkipSlotCache) Put(ctx context.Context, key [32]byte, state *stateTrie.BeaconState) error {
 	if !featureconfig.Get().EnableSkipSlotsCache {
 		return nil
 	}
 
 	// Copy state so cached value is not mutated.
-	c.cache.Add(slot, state.Copy())
+	c.cache.Add(key, state.Copy())
 
 	return nil
 }
@@ -17,8 +17,9 @@ func TestSkipSlotCache_RoundTrip(t *testing.T) {
 	fc := featureconfig.Get()
 	fc.EnableSkipSlotsCache = true
 	featureconfig.Init(fc)
+	key := [32]byte{'a'}
 
-	state, err := c.Get(ctx, 5)
+	state, err := c.Get(ctx, key)
 	if err != nil {
 		t.Error(err)
 	}
@@ -27,7 +28,7 @@ func TestSkipSlotCache_RoundTrip(t *testing.T) {
 		t.Errorf("Empty cache returned an object: %v", state)
 	}
 
-	if err := c.MarkInProgress(5); err != nil {
+	if err := c.MarkInProgress(key); err != nil {
 		t.Error(err)
 	}
 
@@ -38,15 +39,15 @@ func TestSkipSlotCache_RoundTrip(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if err = c.Put(ctx, 5, state); err != nil {
+	if err = c.Put(ctx, key, state); err != nil {
 		t.Error(err)
 	}
 
-	if err := c.MarkNotInProgress(5); err != nil {
+	if err := c.MarkNotInProgress(key); err != nil {
 		t.Error(err)
 	}
 
-	res, err := c.Get(ctx, 5)
+	res, err := c.Get(ctx, key)
 	if err != nil {
 		t.Error(err)
 	}
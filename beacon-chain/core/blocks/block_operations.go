@@ -34,6 +34,7 @@ import (
 var log = logrus.WithField("prefix", "blocks")
 
 var eth1DataCache = cache.NewEth1DataVoteCache()
+var aggregatedPubkeyCache = cache.NewAggregatedPubkeyCache()
 
 // ErrSigFailedToVerify returns when a signature of a block object(ie attestation, slashing, exit... etc)
 // failed to verify.
@@ -328,7 +329,10 @@ func ProcessRandao(
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get beacon proposer index")
 	}
-	proposerPub := beaconState.PubkeyAtIndex(proposerIdx)
+	proposerPub, err := beaconState.PubkeyAtIndex(proposerIdx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get proposer public key")
+	}
 
 	currentEpoch := helpers.SlotToEpoch(beaconState.Slot())
 	buf := make([]byte, 32)
@@ -366,18 +370,15 @@ func ProcessRandaoNoVerify(
 	// If block randao passed verification, we XOR the state's latest randao mix with the block's
 	// randao and update the state's corresponding latest randao mix value.
 	latestMixesLength := params.BeaconConfig().EpochsPerHistoricalVector
-	latestMixSlice, err := beaconState.RandaoMixAtIndex(currentEpoch % latestMixesLength)
+	latestMix, err := beaconState.RandaoMixAtIndex32(currentEpoch % latestMixesLength)
 	if err != nil {
 		return nil, err
 	}
 	blockRandaoReveal := hashutil.Hash(body.RandaoReveal)
-	if len(blockRandaoReveal) != len(latestMixSlice) {
-		return nil, errors.New("blockRandaoReveal length doesnt match latestMixSlice length")
-	}
 	for i, x := range blockRandaoReveal {
-		latestMixSlice[i] ^= x
+		latestMix[i] ^= x
 	}
-	if err := beaconState.UpdateRandaoMixesAtIndex(latestMixSlice, currentEpoch%latestMixesLength); err != nil {
+	if err := beaconState.UpdateRandaoMixesAtIndex(latestMix[:], currentEpoch%latestMixesLength); err != nil {
 		return nil, err
 	}
 	return beaconState, nil
@@ -814,18 +815,9 @@ func VerifyIndexedAttestation(ctx context.Context, beaconState *stateTrie.Beacon
 	}
 	var pubkey *bls.PublicKey
 	if len(indices) > 0 {
-		pubkeyAtIdx := beaconState.PubkeyAtIndex(indices[0])
-		pubkey, err = bls.PublicKeyFromBytes(pubkeyAtIdx[:])
+		pubkey, err = aggregatedPubkey(beaconState, indexedAtt.Data.Target.Epoch, indices)
 		if err != nil {
-			return errors.Wrap(err, "could not deserialize validator public key")
-		}
-		for i := 1; i < len(indices); i++ {
-			pubkeyAtIdx = beaconState.PubkeyAtIndex(indices[i])
-			pk, err := bls.PublicKeyFromBytes(pubkeyAtIdx[:])
-			if err != nil {
-				return errors.Wrap(err, "could not deserialize validator public key")
-			}
-			pubkey.Aggregate(pk)
+			return err
 		}
 	}
 
@@ -1184,6 +1176,35 @@ func VerifyExit(validator *ethpb.Validator, currentSlot uint64, fork *pb.Fork, s
 	return nil
 }
 
+// aggregatedPubkey returns the aggregated public key of the validator indices attesting in the
+// epoch, aggregating and caching it on a cache miss.
+func aggregatedPubkey(beaconState *stateTrie.BeaconState, epoch uint64, indices []uint64) (*bls.PublicKey, error) {
+	pubkeys := make([][48]byte, len(indices))
+	for i, idx := range indices {
+		pubkey, err := beaconState.PubkeyAtIndex(idx)
+		if err != nil {
+			return nil, err
+		}
+		pubkeys[i] = pubkey
+	}
+	if pubkey := aggregatedPubkeyCache.AggregatedPubkey(epoch, pubkeys); pubkey != nil {
+		return pubkey, nil
+	}
+	pubkey, err := bls.PublicKeyFromBytes(pubkeys[0][:])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not deserialize validator public key")
+	}
+	for i := 1; i < len(pubkeys); i++ {
+		pk, err := bls.PublicKeyFromBytes(pubkeys[i][:])
+		if err != nil {
+			return nil, errors.Wrap(err, "could not deserialize validator public key")
+		}
+		pubkey.Aggregate(pk)
+	}
+	aggregatedPubkeyCache.AddAggregatedPubkey(epoch, pubkeys, pubkey)
+	return pubkey, nil
+}
+
 // ClearEth1DataVoteCache clears the eth1 data vote count cache.
 func ClearEth1DataVoteCache() {
 	eth1DataCache = cache.NewEth1DataVoteCache()
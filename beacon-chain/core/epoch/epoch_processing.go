@@ -281,11 +281,11 @@ func ProcessFinalUpdates(state *stateTrie.BeaconState) (*stateTrie.BeaconState,
 			randaoMixLength,
 		)
 	}
-	mix, err := helpers.RandaoMix(state, currentEpoch)
+	mix, err := state.RandaoMixAtIndex32(currentEpoch % randaoMixLength)
 	if err != nil {
 		return nil, err
 	}
-	if err := state.UpdateRandaoMixesAtIndex(mix, nextEpoch%randaoMixLength); err != nil {
+	if err := state.UpdateRandaoMixesAtIndex(mix[:], nextEpoch%randaoMixLength); err != nil {
 		return nil, err
 	}
 
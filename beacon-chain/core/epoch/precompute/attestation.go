@@ -28,26 +28,26 @@ func ProcessAttestations(
 	ctx, span := trace.StartSpan(ctx, "precomputeEpoch.ProcessAttestations")
 	defer span.End()
 
-	v := &Validator{}
-	var err error
+	vp, bp, err := processAttestations(ctx, state, vp, bp)
+	if err != nil {
+		traceutil.AnnotateError(span, err)
+		return nil, nil, err
+	}
+	Balances = bp
 
-	for _, a := range append(state.PreviousEpochAttestations(), state.CurrentEpochAttestations()...) {
-		v.IsCurrentEpochAttester, v.IsCurrentEpochTargetAttester, err = AttestedCurrentEpoch(state, a)
-		if err != nil {
-			traceutil.AnnotateError(span, err)
-			return nil, nil, errors.Wrap(err, "could not check validator attested current epoch")
-		}
-		v.IsPrevEpochAttester, v.IsPrevEpochTargetAttester, v.IsPrevEpochHeadAttester, err = AttestedPrevEpoch(state, a)
-		if err != nil {
-			traceutil.AnnotateError(span, err)
-			return nil, nil, errors.Wrap(err, "could not check validator attested previous epoch")
-		}
+	return vp, bp, nil
+}
 
-		committee, err := helpers.BeaconCommitteeFromState(state, a.Data.Slot, a.Data.CommitteeIndex)
-		if err != nil {
-			return nil, nil, err
-		}
-		indices, err := attestationutil.AttestingIndices(a.AggregationBits, committee)
+// processAttestations updates the validator pre computes and the epoch attesting balances
+// with the attestations in state, without updating the Balances reported in metrics.
+func processAttestations(
+	ctx context.Context,
+	state *stateTrie.BeaconState,
+	vp []*Validator,
+	bp *Balance,
+) ([]*Validator, *Balance, error) {
+	for _, a := range append(state.PreviousEpochAttestations(), state.CurrentEpochAttestations()...) {
+		v, indices, err := attestationRecord(state, a)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -55,11 +55,45 @@ func ProcessAttestations(
 	}
 
 	bp = UpdateBalance(vp, bp)
-	Balances = bp
 
 	return vp, bp, nil
 }
 
+// attestationRecord returns the votes of the attestation checked against the state, and the
+// indices of its attesters.
+func attestationRecord(state *stateTrie.BeaconState, a *pb.PendingAttestation) (*Validator, []uint64, error) {
+	if a.Data == nil || a.Data.Target == nil {
+		return nil, nil, errors.New("nil attestation data")
+	}
+	v := &Validator{}
+	var err error
+	v.IsCurrentEpochAttester, v.IsCurrentEpochTargetAttester, err = AttestedCurrentEpoch(state, a)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not check validator attested current epoch")
+	}
+	// The block root of the attestation slot is only known by the state once that slot passed.
+	if v.IsCurrentEpochAttester && a.Data.Slot < state.Slot() {
+		v.IsCurrentEpochHeadAttester, err = SameHead(state, a)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "could not check same head")
+		}
+	}
+	v.IsPrevEpochAttester, v.IsPrevEpochTargetAttester, v.IsPrevEpochHeadAttester, err = AttestedPrevEpoch(state, a)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not check validator attested previous epoch")
+	}
+
+	committee, err := helpers.BeaconCommitteeFromState(state, a.Data.Slot, a.Data.CommitteeIndex)
+	if err != nil {
+		return nil, nil, err
+	}
+	indices, err := attestationutil.AttestingIndices(a.AggregationBits, committee)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, indices, nil
+}
+
 // AttestedCurrentEpoch returns true if attestation `a` attested once in current epoch and/or epoch boundary block.
 func AttestedCurrentEpoch(s *stateTrie.BeaconState, a *pb.PendingAttestation) (bool, bool, error) {
 	currentEpoch := helpers.CurrentEpoch(s)
@@ -106,11 +140,11 @@ func AttestedPrevEpoch(s *stateTrie.BeaconState, a *pb.PendingAttestation) (bool
 
 // SameTarget returns true if attestation `a` attested to the same target block in state.
 func SameTarget(state *stateTrie.BeaconState, a *pb.PendingAttestation, e uint64) (bool, error) {
-	r, err := helpers.BlockRoot(state, e)
+	r, err := helpers.BlockRootAtSlot32(state, helpers.StartSlot(e))
 	if err != nil {
 		return false, err
 	}
-	if bytes.Equal(a.Data.Target.Root, r) {
+	if bytes.Equal(a.Data.Target.Root, r[:]) {
 		return true, nil
 	}
 	return false, nil
@@ -118,11 +152,11 @@ func SameTarget(state *stateTrie.BeaconState, a *pb.PendingAttestation, e uint64
 
 // SameHead returns true if attestation `a` attested to the same block by attestation slot in state.
 func SameHead(state *stateTrie.BeaconState, a *pb.PendingAttestation) (bool, error) {
-	r, err := helpers.BlockRootAtSlot(state, a.Data.Slot)
+	r, err := helpers.BlockRootAtSlot32(state, a.Data.Slot)
 	if err != nil {
 		return false, err
 	}
-	if bytes.Equal(a.Data.BeaconBlockRoot, r) {
+	if bytes.Equal(a.Data.BeaconBlockRoot, r[:]) {
 		return true, nil
 	}
 	return false, nil
@@ -139,6 +173,9 @@ func UpdateValidator(vp []*Validator, record *Validator, indices []uint64, a *pb
 		if record.IsCurrentEpochTargetAttester {
 			vp[i].IsCurrentEpochTargetAttester = true
 		}
+		if record.IsCurrentEpochHeadAttester {
+			vp[i].IsCurrentEpochHeadAttester = true
+		}
 		if record.IsPrevEpochAttester {
 			vp[i].IsPrevEpochAttester = true
 			// Update attestation inclusion info if inclusion slot is lower than before
@@ -160,24 +197,32 @@ func UpdateValidator(vp []*Validator, record *Validator, indices []uint64, a *pb
 
 // UpdateBalance updates pre computed balance store.
 func UpdateBalance(vp []*Validator, bp *Balance) *Balance {
+	noVotes := &Validator{}
 	for _, v := range vp {
-		if !v.IsSlashed {
-			if v.IsCurrentEpochAttester {
-				bp.CurrentEpochAttesters += v.CurrentEpochEffectiveBalance
-			}
-			if v.IsCurrentEpochTargetAttester {
-				bp.CurrentEpochTargetAttesters += v.CurrentEpochEffectiveBalance
-			}
-			if v.IsPrevEpochAttester {
-				bp.PrevEpochAttesters += v.CurrentEpochEffectiveBalance
-			}
-			if v.IsPrevEpochTargetAttester {
-				bp.PrevEpochTargetAttesters += v.CurrentEpochEffectiveBalance
-			}
-			if v.IsPrevEpochHeadAttester {
-				bp.PrevEpochHeadAttesters += v.CurrentEpochEffectiveBalance
-			}
-		}
+		addAttestingBalance(bp, v, noVotes)
 	}
 	return bp
 }
+
+// addAttestingBalance adds the effective balance of an unslashed validator to the attesting
+// balances of the votes of its record which are not in its previous record.
+func addAttestingBalance(bp *Balance, v *Validator, previous *Validator) {
+	if v.IsSlashed {
+		return
+	}
+	if v.IsCurrentEpochAttester && !previous.IsCurrentEpochAttester {
+		bp.CurrentEpochAttesters += v.CurrentEpochEffectiveBalance
+	}
+	if v.IsCurrentEpochTargetAttester && !previous.IsCurrentEpochTargetAttester {
+		bp.CurrentEpochTargetAttesters += v.CurrentEpochEffectiveBalance
+	}
+	if v.IsPrevEpochAttester && !previous.IsPrevEpochAttester {
+		bp.PrevEpochAttesters += v.CurrentEpochEffectiveBalance
+	}
+	if v.IsPrevEpochTargetAttester && !previous.IsPrevEpochTargetAttester {
+		bp.PrevEpochTargetAttesters += v.CurrentEpochEffectiveBalance
+	}
+	if v.IsPrevEpochHeadAttester && !previous.IsPrevEpochHeadAttester {
+		bp.PrevEpochHeadAttesters += v.CurrentEpochEffectiveBalance
+	}
+}
@@ -0,0 +1,54 @@
+package precompute
+
+import (
+	"context"
+
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"go.opencensus.io/trace"
+)
+
+// Participation computes the total active and attesting balances of the previous and current
+// epoch of the given state. Unlike ProcessAttestations, it does not update the Balances used
+// by the epoch metrics, so it may be called on any state.
+func Participation(ctx context.Context, state *stateTrie.BeaconState) (*Balance, error) {
+	ctx, span := trace.StartSpan(ctx, "precomputeEpoch.Participation")
+	defer span.End()
+
+	r, err := RecordsFromState(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	return r.Balance, nil
+}
+
+// ValidatorParticipation computes the attesting records of the validators from the attestations
+// of the previous and current epoch included in the given state. Like Participation, it does not
+// update the Balances used by the epoch metrics.
+func ValidatorParticipation(ctx context.Context, state *stateTrie.BeaconState) ([]*Validator, error) {
+	ctx, span := trace.StartSpan(ctx, "precomputeEpoch.ValidatorParticipation")
+	defer span.End()
+
+	r, err := RecordsFromState(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	return r.Validators, nil
+}
+
+// PrevEpochParticipationRate returns the ratio of the balance which attested to the epoch
+// boundary block of the previous epoch over the total active balance of the previous epoch.
+func (b *Balance) PrevEpochParticipationRate() float64 {
+	if b.PrevEpoch == 0 {
+		return 0
+	}
+	return float64(b.PrevEpochTargetAttesters) / float64(b.PrevEpoch)
+}
+
+// CurrentEpochParticipationRate returns the ratio of the balance which attested to the epoch
+// boundary block of the current epoch over the total active balance of the current epoch.
+func (b *Balance) CurrentEpochParticipationRate() float64 {
+	if b.CurrentEpoch == 0 {
+		return 0
+	}
+	return float64(b.CurrentEpochTargetAttesters) / float64(b.CurrentEpoch)
+}
@@ -0,0 +1,112 @@
+package precompute_test
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestBalance_ParticipationRates(t *testing.T) {
+	bp := &precompute.Balance{
+		PrevEpoch:                   400,
+		PrevEpochTargetAttesters:    300,
+		CurrentEpoch:                400,
+		CurrentEpochTargetAttesters: 100,
+	}
+	if rate := bp.PrevEpochParticipationRate(); rate != 0.75 {
+		t.Errorf("Wanted previous epoch participation rate 0.75, got %f", rate)
+	}
+	if rate := bp.CurrentEpochParticipationRate(); rate != 0.25 {
+		t.Errorf("Wanted current epoch participation rate 0.25, got %f", rate)
+	}
+
+	empty := &precompute.Balance{}
+	if empty.PrevEpochParticipationRate() != 0 || empty.CurrentEpochParticipationRate() != 0 {
+		t.Error("Wanted participation rates of 0 without active balance")
+	}
+}
+
+func TestParticipation(t *testing.T) {
+	params.UseMinimalConfig()
+	defer params.UseMainnetConfig()
+
+	beaconState, _ := testutil.DeterministicGenesisState(t, 64)
+	beaconState.SetSlot(params.BeaconConfig().SlotsPerEpoch)
+
+	bf := []byte{0xff}
+	rt := [32]byte{'A'}
+	br := beaconState.BlockRoots()
+	br[0] = rt[:]
+	beaconState.SetBlockRoots(br)
+	att := &ethpb.Attestation{Data: &ethpb.AttestationData{
+		Target:          &ethpb.Checkpoint{Epoch: 0, Root: rt[:]},
+		BeaconBlockRoot: rt[:],
+	}}
+	beaconState.SetPreviousEpochAttestations([]*pb.PendingAttestation{{Data: att.Data, AggregationBits: bf}})
+
+	balances := &precompute.Balance{}
+	precompute.Balances = balances
+	bp, err := precompute.Participation(context.Background(), beaconState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if precompute.Balances != balances {
+		t.Error("Participation should not update the balances reported in metrics")
+	}
+	if bp.PrevEpoch == 0 || bp.PrevEpochTargetAttesters == 0 {
+		t.Fatalf("Wanted previous epoch attesting balance, got %+v", bp)
+	}
+	if rate := bp.PrevEpochParticipationRate(); rate <= 0 || rate >= 1 {
+		t.Errorf("Wanted a partial previous epoch participation rate, got %f", rate)
+	}
+	if rate := bp.CurrentEpochParticipationRate(); rate != 0 {
+		t.Errorf("Wanted no current epoch participation, got %f", rate)
+	}
+}
+
+func TestValidatorParticipation(t *testing.T) {
+	params.UseMinimalConfig()
+	defer params.UseMainnetConfig()
+
+	beaconState, _ := testutil.DeterministicGenesisState(t, 64)
+	beaconState.SetSlot(params.BeaconConfig().SlotsPerEpoch)
+
+	bf := []byte{0xff}
+	rt := [32]byte{'A'}
+	br := beaconState.BlockRoots()
+	br[0] = rt[:]
+	beaconState.SetBlockRoots(br)
+	att := &ethpb.Attestation{Data: &ethpb.AttestationData{
+		Target:          &ethpb.Checkpoint{Epoch: 0, Root: rt[:]},
+		BeaconBlockRoot: rt[:],
+	}}
+	beaconState.SetPreviousEpochAttestations([]*pb.PendingAttestation{{Data: att.Data, AggregationBits: bf}})
+
+	vp, err := precompute.ValidatorParticipation(context.Background(), beaconState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vp) != 64 {
+		t.Fatalf("Wanted 64 validator records, got %d", len(vp))
+	}
+	attesters := 0
+	for _, v := range vp {
+		if v.IsCurrentEpochAttester {
+			t.Error("Wanted no current epoch attester")
+		}
+		if v.IsPrevEpochAttester {
+			attesters++
+			if !v.IsPrevEpochTargetAttester || !v.IsPrevEpochHeadAttester {
+				t.Errorf("Wanted previous epoch attester to attest target and head, got %+v", v)
+			}
+		}
+	}
+	if attesters == 0 || attesters == len(vp) {
+		t.Errorf("Wanted some previous epoch attesters, got %d", attesters)
+	}
+}
@@ -0,0 +1,93 @@
+package precompute
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"go.opencensus.io/trace"
+)
+
+// Records are the pre computed attesting records of the validators of a state along with the
+// attesting balances they add up to. They are updated incrementally as attestations and blocks
+// are included, so that the RPC and metrics tracking participation share the logic of epoch
+// processing.
+type Records struct {
+	Validators []*Validator
+	Balance    *Balance
+	state      *stateTrie.BeaconState
+}
+
+// NewRecords returns the records of the validators of the state before any attestation of the
+// previous and current epoch is included.
+func NewRecords(ctx context.Context, state *stateTrie.BeaconState) *Records {
+	vp, bp := New(ctx, state)
+	return &Records{
+		Validators: vp,
+		Balance:    bp,
+		state:      state,
+	}
+}
+
+// RecordsFromState returns the records of the validators of the state with the attestations of
+// the previous and current epoch included in the state. Like Participation, it does not update
+// the Balances used by the epoch metrics.
+func RecordsFromState(ctx context.Context, state *stateTrie.BeaconState) (*Records, error) {
+	ctx, span := trace.StartSpan(ctx, "precomputeEpoch.RecordsFromState")
+	defer span.End()
+
+	r := NewRecords(ctx, state)
+	var err error
+	r.Validators, r.Balance, err = processAttestations(ctx, state, r.Validators, r.Balance)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// UpdateWithAttestation updates the records of the attesters of the pending attestation, and
+// adds their effective balance to the attesting balances of the votes they had not cast yet.
+// The votes of the attestation are checked against the block roots of the state of the records.
+func (r *Records) UpdateWithAttestation(a *pb.PendingAttestation) error {
+	record, indices, err := attestationRecord(r.state, a)
+	if err != nil {
+		return err
+	}
+	previous := make([]Validator, len(indices))
+	for j, i := range indices {
+		if i >= uint64(len(r.Validators)) {
+			return fmt.Errorf("attester %d is not one of the %d validators of the records", i, len(r.Validators))
+		}
+		previous[j] = *r.Validators[i]
+	}
+	r.Validators = UpdateValidator(r.Validators, record, indices, a, a.Data.Slot)
+	for j, i := range indices {
+		addAttestingBalance(r.Balance, r.Validators[i], &previous[j])
+	}
+	return nil
+}
+
+// UpdateWithBlock updates the records with the attestations of the block, as included by the
+// proposer of the block.
+func (r *Records) UpdateWithBlock(blk *ethpb.BeaconBlock, proposerIndex uint64) error {
+	if blk == nil || blk.Body == nil {
+		return errors.New("nil block")
+	}
+	for _, a := range blk.Body.Attestations {
+		if a.Data == nil || a.Data.Slot > blk.Slot {
+			return fmt.Errorf("invalid attestation in block of slot %d", blk.Slot)
+		}
+		if err := r.UpdateWithAttestation(&pb.PendingAttestation{
+			AggregationBits: a.AggregationBits,
+			Data:            a.Data,
+			InclusionDelay:  blk.Slot - a.Data.Slot,
+			ProposerIndex:   proposerIndex,
+		}); err != nil {
+			return errors.Wrapf(err, "could not update records with attestation of slot %d", a.Data.Slot)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package precompute_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/attestationutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestRecords_UpdateWithAttestation(t *testing.T) {
+	params.UseMinimalConfig()
+	defer params.UseMainnetConfig()
+
+	beaconState, _ := testutil.DeterministicFilledState(t, 64, 2, 0.5)
+	want, err := precompute.RecordsFromState(context.Background(), beaconState)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := precompute.NewRecords(context.Background(), beaconState)
+	atts := append(beaconState.PreviousEpochAttestations(), beaconState.CurrentEpochAttestations()...)
+	if len(atts) == 0 {
+		t.Fatal("Wanted pending attestations in the state")
+	}
+	for _, a := range atts {
+		if err := r.UpdateWithAttestation(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !reflect.DeepEqual(r.Balance, want.Balance) {
+		t.Errorf("Wanted balance %+v, got %+v", want.Balance, r.Balance)
+	}
+	if !reflect.DeepEqual(r.Validators, want.Validators) {
+		t.Error("Incremental validator records do not match the records of the state")
+	}
+
+	// Including the same attestations again does not count their attesters twice.
+	for _, a := range atts {
+		if err := r.UpdateWithAttestation(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !reflect.DeepEqual(r.Balance, want.Balance) {
+		t.Errorf("Wanted balance %+v after repeated attestations, got %+v", want.Balance, r.Balance)
+	}
+}
+
+func TestRecords_UpdateWithBlock(t *testing.T) {
+	params.UseMinimalConfig()
+	defer params.UseMainnetConfig()
+
+	beaconState, _ := testutil.DeterministicFilledState(t, 64, 2, 1)
+	a := beaconState.PreviousEpochAttestations()[0]
+	blk := &ethpb.BeaconBlock{
+		Slot: beaconState.Slot(),
+		Body: &ethpb.BeaconBlockBody{
+			Attestations: []*ethpb.Attestation{{AggregationBits: a.AggregationBits, Data: a.Data}},
+		},
+	}
+	proposerIndex := uint64(7)
+
+	r := precompute.NewRecords(context.Background(), beaconState)
+	if err := r.UpdateWithBlock(blk, proposerIndex); err != nil {
+		t.Fatal(err)
+	}
+	committee, err := helpers.BeaconCommitteeFromState(beaconState, a.Data.Slot, a.Data.CommitteeIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	indices, err := attestationutil.AttestingIndices(a.AggregationBits, committee)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var attesting uint64
+	for _, i := range indices {
+		v := r.Validators[i]
+		if !v.IsPrevEpochAttester || !v.IsPrevEpochTargetAttester || !v.IsPrevEpochHeadAttester {
+			t.Errorf("Wanted validator %d to be a previous epoch attester, got %+v", i, v)
+		}
+		if v.InclusionSlot != blk.Slot || v.InclusionDistance != blk.Slot-a.Data.Slot || v.ProposerIndex != proposerIndex {
+			t.Errorf("Wanted validator %d included at slot %d by %d, got %+v", i, blk.Slot, proposerIndex, v)
+		}
+		attesting += v.CurrentEpochEffectiveBalance
+	}
+	if r.Balance.PrevEpochTargetAttesters != attesting {
+		t.Errorf("Wanted previous epoch target attesting balance %d, got %d", attesting, r.Balance.PrevEpochTargetAttesters)
+	}
+
+	blk.Body.Attestations[0].Data.Slot = blk.Slot + 1
+	if err := r.UpdateWithBlock(blk, proposerIndex); err == nil {
+		t.Error("Wanted an error for an attestation after the block slot")
+	}
+}
@@ -36,6 +36,7 @@ func ProcessRewardsAndPenaltiesPrecompute(
 		return nil, errors.Wrap(err, "could not get attestation delta")
 	}
 	for i := 0; i < numOfVals; i++ {
+		vp[i].Rewards.ProposerReward = proposerRewards[i]
 		vp[i].BeforeEpochTransitionBalance, err = state.BalanceAtIndex(uint64(i))
 		if err != nil {
 			return nil, errors.Wrap(err, "could not get validator balance before epoch")
@@ -58,63 +59,64 @@ func ProcessRewardsAndPenaltiesPrecompute(
 }
 
 // This computes the rewards and penalties differences for individual validators based on the
-// voting records.
+// voting records, and records their breakdown in the validator records.
 func attestationDeltas(state *stateTrie.BeaconState, bp *Balance, vp []*Validator) ([]uint64, []uint64, error) {
 	numOfVals := state.NumValidators()
 	rewards := make([]uint64, numOfVals)
 	penalties := make([]uint64, numOfVals)
 
 	for i, v := range vp {
-		rewards[i], penalties[i] = attestationDelta(state, bp, v)
+		v.Rewards = attestationDelta(state, bp, v)
+		rewards[i], penalties[i] = v.Rewards.TotalReward(), v.Rewards.TotalPenalty()
 	}
 	return rewards, penalties, nil
 }
 
-func attestationDelta(state *stateTrie.BeaconState, bp *Balance, v *Validator) (uint64, uint64) {
+func attestationDelta(state *stateTrie.BeaconState, bp *Balance, v *Validator) Rewards {
+	r := Rewards{}
 	eligible := v.IsActivePrevEpoch || (v.IsSlashed && !v.IsWithdrawableCurrentEpoch)
 	if !eligible {
-		return 0, 0
+		return r
 	}
 
 	e := helpers.PrevEpoch(state)
 	vb := v.CurrentEpochEffectiveBalance
 	br := vb * params.BeaconConfig().BaseRewardFactor / mathutil.IntegerSquareRoot(bp.CurrentEpoch) / params.BeaconConfig().BaseRewardsPerEpoch
-	r, p := uint64(0), uint64(0)
 
 	// Process source reward / penalty
 	if v.IsPrevEpochAttester && !v.IsSlashed {
-		r += br * bp.PrevEpochAttesters / bp.CurrentEpoch
+		r.SourceReward = br * bp.PrevEpochAttesters / bp.CurrentEpoch
 		proposerReward := br / params.BeaconConfig().ProposerRewardQuotient
 		maxAtteserReward := br - proposerReward
-		r += maxAtteserReward / v.InclusionDistance
+		r.InclusionDelayReward = maxAtteserReward / v.InclusionDistance
 	} else {
-		p += br
+		r.SourcePenalty = br
 	}
 
 	// Process target reward / penalty
 	if v.IsPrevEpochTargetAttester && !v.IsSlashed {
-		r += br * bp.PrevEpochTargetAttesters / bp.CurrentEpoch
+		r.TargetReward = br * bp.PrevEpochTargetAttesters / bp.CurrentEpoch
 	} else {
-		p += br
+		r.TargetPenalty = br
 	}
 
 	// Process head reward / penalty
 	if v.IsPrevEpochHeadAttester && !v.IsSlashed {
-		r += br * bp.PrevEpochHeadAttesters / bp.CurrentEpoch
+		r.HeadReward = br * bp.PrevEpochHeadAttesters / bp.CurrentEpoch
 	} else {
-		p += br
+		r.HeadPenalty = br
 	}
 
 	// Process finality delay penalty
 	finalizedEpoch := state.FinalizedCheckpointEpoch()
 	finalityDelay := e - finalizedEpoch
 	if finalityDelay > params.BeaconConfig().MinEpochsToInactivityPenalty {
-		p += params.BeaconConfig().BaseRewardsPerEpoch * br
+		r.InactivityPenalty = params.BeaconConfig().BaseRewardsPerEpoch * br
 		if !v.IsPrevEpochTargetAttester {
-			p += vb * finalityDelay / params.BeaconConfig().InactivityPenaltyQuotient
+			r.InactivityPenalty += vb * finalityDelay / params.BeaconConfig().InactivityPenaltyQuotient
 		}
 	}
-	return r, p
+	return r
 }
 
 // This computes the rewards and penalties differences for individual validators based on the
@@ -126,6 +126,9 @@ func TestAttestationDeltaPrecompute(t *testing.T) {
 		if penalties[i] != 0 {
 			t.Errorf("Wanted penalty balance 0, got %d", penalties[i])
 		}
+		if r := vp[i].Rewards; r.SourceReward != base*attestedBalance/totalBalance || r.TargetReward != r.SourceReward || r.HeadReward != r.SourceReward {
+			t.Errorf("Unexpected reward breakdown %+v for validator with index %d", r, i)
+		}
 	}
 
 	nonAttestedIndices := []uint64{434, 677, 872, 791}
@@ -143,6 +146,9 @@ func TestAttestationDeltaPrecompute(t *testing.T) {
 		if penalties[i] != wanted {
 			t.Errorf("Wanted penalty balance %d, got %d", wanted, penalties[i])
 		}
+		if r := vp[i].Rewards; r.SourcePenalty != base || r.TargetPenalty != base || r.HeadPenalty != base {
+			t.Errorf("Unexpected penalty breakdown %+v for validator with index %d", r, i)
+		}
 	}
 }
 
@@ -16,6 +16,8 @@ type Validator struct {
 	IsCurrentEpochAttester bool
 	// IsCurrentEpochTargetAttester is true if the validator attested current epoch target.
 	IsCurrentEpochTargetAttester bool
+	// IsCurrentEpochHeadAttester is true if the validator attested head in the current epoch.
+	IsCurrentEpochHeadAttester bool
 	// IsPrevEpochAttester is true if the validator attested previous epoch.
 	IsPrevEpochAttester bool
 	// IsPrevEpochTargetAttester is true if the validator attested previous epoch target.
@@ -35,6 +37,41 @@ type Validator struct {
 	BeforeEpochTransitionBalance uint64
 	// AfterEpochTransitionBalance is the validator balance after epoch transition.
 	AfterEpochTransitionBalance uint64
+	// Rewards is the breakdown of the rewards and penalties applied to the validator balance at epoch transition.
+	Rewards Rewards
+}
+
+// Rewards stores the breakdown of the rewards and penalties applied to the balance of a validator
+// at an epoch transition, in Gwei.
+type Rewards struct {
+	// SourceReward is the reward for attesting the correct source during prev epoch.
+	SourceReward uint64
+	// TargetReward is the reward for attesting the correct target during prev epoch.
+	TargetReward uint64
+	// HeadReward is the reward for attesting the correct head during prev epoch.
+	HeadReward uint64
+	// InclusionDelayReward is the reward for getting the attestation of prev epoch included early.
+	InclusionDelayReward uint64
+	// ProposerReward is the reward for including attestations of prev epoch in proposed blocks.
+	ProposerReward uint64
+	// SourcePenalty is the penalty for not attesting the correct source during prev epoch.
+	SourcePenalty uint64
+	// TargetPenalty is the penalty for not attesting the correct target during prev epoch.
+	TargetPenalty uint64
+	// HeadPenalty is the penalty for not attesting the correct head during prev epoch.
+	HeadPenalty uint64
+	// InactivityPenalty is the penalty applied while the chain has not finalized for too long.
+	InactivityPenalty uint64
+}
+
+// TotalReward is the sum of the rewards.
+func (r *Rewards) TotalReward() uint64 {
+	return r.SourceReward + r.TargetReward + r.HeadReward + r.InclusionDelayReward + r.ProposerReward
+}
+
+// TotalPenalty is the sum of the penalties.
+func (r *Rewards) TotalPenalty() uint64 {
+	return r.SourcePenalty + r.TargetPenalty + r.HeadPenalty + r.InactivityPenalty
 }
 
 // Balance stores the pre computation of the total participated balances for a given epoch
@@ -1,14 +1,31 @@
 package state
 
-import "time"
+import (
+	"time"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
 
 const (
 	// BlockProcessed is sent after a block has been processed and updated the state database.
 	BlockProcessed = iota + 1
-	// ChainStarted is sent when enough validators are active to start proposing blocks.
+	// ChainStarted is sent when enough validators are active to start proposing blocks, with the
+	// genesis time and the genesis validators root of the chain.
 	ChainStarted
 	// Initialized is sent when the internal beacon node's state is ready to be accessed.
 	Initialized
+	// ValidatorBalanceChanged is sent when a validator's balance is modified in a beacon state.
+	ValidatorBalanceChanged
+	// ValidatorStatusChanged is sent when a validator is made eligible for activation, activated,
+	// slashed or has its withdrawable epoch modified in a beacon state.
+	ValidatorStatusChanged
+	// ValidatorExitInitiated is sent when a validator's exit epoch is set in a beacon state.
+	ValidatorExitInitiated
+	// HeadUpdated is sent when the canonical head of the chain changes.
+	HeadUpdated
+	// FinalityDelayed is sent at each epoch the chain has not finalized for the configured number
+	// of finality alert epochs.
+	FinalityDelayed
 )
 
 // BlockProcessedData is the data sent with BlockProcessed events.
@@ -21,14 +38,63 @@ type BlockProcessedData struct {
 	Verified bool
 }
 
+// HeadUpdatedData is the data sent with HeadUpdated events.
+type HeadUpdatedData struct {
+	// Slot is the slot of the new head block.
+	Slot uint64
+	// BlockRoot is the hash of the new head block.
+	BlockRoot [32]byte
+	// ParentRoot is the parent root of the new head block.
+	ParentRoot [32]byte
+}
+
+// FinalityDelayedData is the data sent with FinalityDelayed events.
+type FinalityDelayedData struct {
+	// Epoch is the current epoch.
+	Epoch uint64
+	// FinalizedEpoch is the finalized epoch of the head state.
+	FinalizedEpoch uint64
+	// EpochsSinceFinality is the number of epochs between the current and the finalized epochs.
+	EpochsSinceFinality uint64
+	// JustificationBits are the justification bits of the head state.
+	JustificationBits uint8
+}
+
 // ChainStartedData is the data sent with ChainStarted events.
 type ChainStartedData struct {
 	// StartTime is the time at which the chain started.
 	StartTime time.Time
+	// GenesisValidatorsRoot is the hash tree root of the validator registry of the genesis state.
+	GenesisValidatorsRoot []byte
 }
 
 // InitializedData is the data sent with Initialized events.
 type InitializedData struct {
 	// StartTime is the time at which the chain started.
 	StartTime time.Time
+	// GenesisValidatorsRoot is the hash tree root of the validator registry of the genesis state.
+	GenesisValidatorsRoot []byte
+}
+
+// ValidatorBalanceChangedData is the data sent with ValidatorBalanceChanged events.
+type ValidatorBalanceChangedData struct {
+	// Slot is the slot of the state in which the balance changed.
+	Slot uint64
+	// ValidatorIndex is the index of the validator whose balance changed.
+	ValidatorIndex uint64
+	// PreviousBalance is the balance before the change, in Gwei.
+	PreviousBalance uint64
+	// Balance is the balance after the change, in Gwei.
+	Balance uint64
+}
+
+// ValidatorChangedData is the data sent with ValidatorStatusChanged and
+// ValidatorExitInitiated events.
+type ValidatorChangedData struct {
+	// Slot is the slot of the state in which the validator changed.
+	Slot uint64
+	// ValidatorIndex is the index of the validator which changed.
+	ValidatorIndex uint64
+	// Validator is the validator record after the change.
+	Validator *ethpb.Validator
 }
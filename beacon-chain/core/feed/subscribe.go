@@ -0,0 +1,12 @@
+package feed
+
+import "github.com/prysmaticlabs/prysm/shared/event"
+
+// SubscribeBuffered subscribes to a feed of events through a buffer, and returns the channel
+// the events are delivered on. Subscribers slower than the feed, such as RPC streams, should use
+// it rather than a plain channel, so that the overflow policy decides whether they lose events or
+// hold up the feed, instead of the feed stalling on them unnoticed.
+func SubscribeBuffered(f *event.Feed, opts *event.BufferedOpts) (<-chan *Event, event.Subscription) {
+	ch := make(chan *Event)
+	return ch, f.SubscribeBuffered(ch, opts)
+}
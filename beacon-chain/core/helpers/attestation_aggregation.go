@@ -0,0 +1,164 @@
+package helpers
+
+import (
+	"fmt"
+	"sort"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+const (
+	// NaiveAggregation greedily aggregates attestations in the order they are given.
+	NaiveAggregation = "naive"
+	// MaxCoverAggregation aggregates the attestations covering the most validators first.
+	MaxCoverAggregation = "max_cover"
+)
+
+// AttestationAggregator aggregates attestations with the same data into as few
+// attestations as possible. Implementations trade CPU time for packing quality.
+type AttestationAggregator interface {
+	Aggregate(atts []*ethpb.Attestation) ([]*ethpb.Attestation, error)
+}
+
+// ValidateAggregationStrategy returns an error if the strategy name is not one of
+// the known aggregation strategies, so that a mistyped flag is rejected on startup
+// instead of falling back to the naive aggregator.
+func ValidateAggregationStrategy(strategy string) error {
+	switch strategy {
+	case NaiveAggregation, MaxCoverAggregation:
+		return nil
+	default:
+		return fmt.Errorf("unknown attestation aggregation strategy %q, wanted %s or %s", strategy, NaiveAggregation, MaxCoverAggregation)
+	}
+}
+
+// AttestationAggregatorFor returns the aggregator for the given strategy name,
+// defaulting to the naive aggregator for an empty name. Names are checked with
+// ValidateAggregationStrategy when the node starts.
+func AttestationAggregatorFor(strategy string) AttestationAggregator {
+	switch strategy {
+	case MaxCoverAggregation:
+		return maxCoverAggregator{}
+	default:
+		return naiveAggregator{}
+	}
+}
+
+// naiveAggregator merges each attestation with every later non-overlapping
+// attestation, in the order they are given.
+type naiveAggregator struct{}
+
+// Aggregate attestations in O(n^2) time. The input slice is modified.
+func (naiveAggregator) Aggregate(atts []*ethpb.Attestation) ([]*ethpb.Attestation, error) {
+	if len(atts) <= 1 {
+		return atts, nil
+	}
+
+	// Naive aggregation. O(n^2) time.
+	for i, a := range atts {
+		if i >= len(atts) {
+			break
+		}
+		for j := i + 1; j < len(atts); j++ {
+			b := atts[j]
+			if a.AggregationBits.Len() == b.AggregationBits.Len() && !a.AggregationBits.Overlaps(b.AggregationBits) {
+				var err error
+				a, err = AggregateAttestation(a, b)
+				if err != nil {
+					return nil, err
+				}
+				// Delete b
+				atts = append(atts[:j], atts[j+1:]...)
+				j--
+				atts[i] = a
+			}
+		}
+	}
+
+	// Naive deduplication of identical aggregations. O(n^2) time.
+	for i, a := range atts {
+		for j := i + 1; j < len(atts); j++ {
+			b := atts[j]
+
+			if a.AggregationBits.Len() != b.AggregationBits.Len() {
+				continue
+			}
+
+			if a.AggregationBits.Contains(b.AggregationBits) {
+				// If b is fully contained in a, then b can be removed.
+				atts = append(atts[:j], atts[j+1:]...)
+				j--
+			} else if b.AggregationBits.Contains(a.AggregationBits) {
+				// if a is fully contained in b, then a can be removed.
+				atts = append(atts[:i], atts[i+1:]...)
+				i--
+				break // Stop the inner loop, advance a.
+			}
+		}
+	}
+
+	return atts, nil
+}
+
+// maxCoverAggregator builds each aggregate from the attestation with the most
+// bits set which is not yet aggregated, then repeatedly adds the non-overlapping
+// attestation covering the most new validators. This is the greedy approximation
+// of maximum coverage: it packs more validators into the first aggregates than
+// the naive aggregator, at the cost of sorting the attestations.
+type maxCoverAggregator struct{}
+
+// Aggregate attestations in O(n^2) time plus the sort. The input slice is not modified.
+func (maxCoverAggregator) Aggregate(atts []*ethpb.Attestation) ([]*ethpb.Attestation, error) {
+	if len(atts) <= 1 {
+		return atts, nil
+	}
+
+	candidates := make([]*ethpb.Attestation, len(atts))
+	copy(candidates, atts)
+	counts := make(map[*ethpb.Attestation]uint64, len(candidates))
+	for _, att := range candidates {
+		counts[att] = att.AggregationBits.Count()
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return counts[candidates[i]] > counts[candidates[j]]
+	})
+
+	used := make([]bool, len(candidates))
+	aggregated := make([]*ethpb.Attestation, 0, len(candidates))
+	for i, agg := range candidates {
+		if used[i] {
+			continue
+		}
+		used[i] = true
+		if containedInAny(aggregated, agg) {
+			continue
+		}
+		// Candidates are sorted by bits set and a non-overlapping candidate only
+		// adds new bits, so the first one that fits covers the most new validators.
+		for j := i + 1; j < len(candidates); j++ {
+			b := candidates[j]
+			if used[j] || agg.AggregationBits.Len() != b.AggregationBits.Len() || agg.AggregationBits.Overlaps(b.AggregationBits) {
+				continue
+			}
+			var err error
+			agg, err = AggregateAttestation(agg, b)
+			if err != nil {
+				return nil, err
+			}
+			used[j] = true
+		}
+		aggregated = append(aggregated, agg)
+	}
+	return aggregated, nil
+}
+
+// containedInAny returns true if the aggregation bits of the attestation are fully
+// contained in those of one of the aggregates.
+func containedInAny(aggregates []*ethpb.Attestation, att *ethpb.Attestation) bool {
+	for _, agg := range aggregates {
+		if agg.AggregationBits.Len() == att.AggregationBits.Len() && agg.AggregationBits.Contains(att.AggregationBits) {
+			return true
+		}
+	}
+	return false
+}
@@ -95,16 +95,19 @@ func BenchmarkAggregateAttestations(b *testing.B) {
 		return atts
 	}
 
-	for _, tt := range tests {
-		b.Run(tt.name, func(b *testing.B) {
-			atts := makeAttestationsFromBitlists(tt.inputs)
-			b.ResetTimer()
-			for i := 0; i < b.N; i++ {
-				_, err := AggregateAttestations(atts)
-				if err != nil {
-					b.Fatal(err)
+	for _, strategy := range []string{NaiveAggregation, MaxCoverAggregation} {
+		aggregator := AttestationAggregatorFor(strategy)
+		for _, tt := range tests {
+			b.Run(strategy+"/"+tt.name, func(b *testing.B) {
+				atts := makeAttestationsFromBitlists(tt.inputs)
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					_, err := aggregator.Aggregate(atts)
+					if err != nil {
+						b.Fatal(err)
+					}
 				}
-			}
-		})
+			})
+		}
 	}
 }
@@ -13,6 +13,7 @@ import (
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
 )
@@ -212,31 +213,97 @@ func TestAggregateAttestations(t *testing.T) {
 		return atts
 	}
 
+	for _, strategy := range []string{helpers.NaiveAggregation, helpers.MaxCoverAggregation} {
+		for _, tt := range tests {
+			t.Run(strategy+"/"+tt.name, func(t *testing.T) {
+				featureconfig.Init(&featureconfig.Flags{AttestationAggregationStrategy: strategy})
+				defer featureconfig.Init(&featureconfig.Flags{})
+				got, err := helpers.AggregateAttestations(makeAttestationsFromBitlists(tt.inputs))
+				if err != nil {
+					t.Fatal(err)
+				}
+				assertBitlists(t, got, tt.want)
+			})
+		}
+	}
+}
+
+func TestAttestationAggregator_MaxCoverPacksBetter(t *testing.T) {
+	// The naive aggregator merges the first attestation with the second, after which
+	// the third overlaps. The max cover aggregator starts from the third, which has
+	// the most bits set, and fits all attestations into one aggregate.
+	inputs := []bitfield.Bitlist{
+		{0b00000001, 0b1},
+		{0b00001110, 0b1},
+		{0b01110001, 0b1},
+	}
+	tests := []struct {
+		strategy string
+		want     []bitfield.Bitlist
+	}{
+		{
+			strategy: helpers.NaiveAggregation,
+			want: []bitfield.Bitlist{
+				{0b00001111, 0b1},
+				{0b01110001, 0b1},
+			},
+		},
+		{
+			strategy: helpers.MaxCoverAggregation,
+			want: []bitfield.Bitlist{
+				{0b01111111, 0b1},
+			},
+		},
+	}
 	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := helpers.AggregateAttestations(makeAttestationsFromBitlists(tt.inputs))
+		t.Run(tt.strategy, func(t *testing.T) {
+			atts := make([]*ethpb.Attestation, len(inputs))
+			for i, b := range inputs {
+				atts[i] = &ethpb.Attestation{
+					AggregationBits: b,
+					Signature:       bls.RandKey().Sign([]byte("dummy_test_data"), 0 /*domain*/).Marshal(),
+				}
+			}
+			got, err := helpers.AttestationAggregatorFor(tt.strategy).Aggregate(atts)
 			if err != nil {
 				t.Fatal(err)
 			}
-			sort.Slice(got, func(i, j int) bool {
-				return got[i].AggregationBits.Bytes()[0] < got[j].AggregationBits.Bytes()[0]
-			})
-			sort.Slice(tt.want, func(i, j int) bool {
-				return tt.want[i].Bytes()[0] < tt.want[j].Bytes()[0]
-			})
-			if len(got) != len(tt.want) {
-				t.Logf("got=%v", got)
-				t.Fatalf("Wrong number of responses. Got %d, wanted %d", len(got), len(tt.want))
-			}
-			for i, w := range tt.want {
-				if !bytes.Equal(got[i].AggregationBits.Bytes(), w.Bytes()) {
-					t.Errorf("Unexpected bitlist at index %d, got %b, wanted %b", i, got[i].AggregationBits.Bytes(), w.Bytes())
-				}
-			}
+			assertBitlists(t, got, tt.want)
 		})
 	}
 }
 
+// assertBitlists checks the aggregation bits of the attestations against the wanted
+// bitlists, regardless of order.
+func assertBitlists(t *testing.T, got []*ethpb.Attestation, want []bitfield.Bitlist) {
+	sort.Slice(got, func(i, j int) bool {
+		return got[i].AggregationBits.Bytes()[0] < got[j].AggregationBits.Bytes()[0]
+	})
+	sort.Slice(want, func(i, j int) bool {
+		return want[i].Bytes()[0] < want[j].Bytes()[0]
+	})
+	if len(got) != len(want) {
+		t.Logf("got=%v", got)
+		t.Fatalf("Wrong number of responses. Got %d, wanted %d", len(got), len(want))
+	}
+	for i, w := range want {
+		if !bytes.Equal(got[i].AggregationBits.Bytes(), w.Bytes()) {
+			t.Errorf("Unexpected bitlist at index %d, got %b, wanted %b", i, got[i].AggregationBits.Bytes(), w.Bytes())
+		}
+	}
+}
+
+func TestValidateAggregationStrategy(t *testing.T) {
+	for _, strategy := range []string{helpers.NaiveAggregation, helpers.MaxCoverAggregation} {
+		if err := helpers.ValidateAggregationStrategy(strategy); err != nil {
+			t.Errorf("Unexpected error for strategy %s: %v", strategy, err)
+		}
+	}
+	if err := helpers.ValidateAggregationStrategy("max-cover"); err == nil {
+		t.Error("Expected unknown strategy to be rejected")
+	}
+}
+
 func TestSlotSignature_Verify(t *testing.T) {
 	priv := bls.RandKey()
 	pub := priv.PublicKey()
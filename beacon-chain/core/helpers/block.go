@@ -23,6 +23,15 @@ func BlockRootAtSlot(state *stateTrie.BeaconState, slot uint64) ([]byte, error)
 	return state.BlockRootAtIndex(slot % params.BeaconConfig().SlotsPerHistoricalRoot)
 }
 
+// BlockRootAtSlot32 returns the block root stored in the BeaconState for a recent slot as a
+// fixed size array, for callers which compare many roots and should not allocate each of them.
+func BlockRootAtSlot32(state *stateTrie.BeaconState, slot uint64) ([32]byte, error) {
+	if slot >= state.Slot() || state.Slot() > slot+params.BeaconConfig().SlotsPerHistoricalRoot {
+		return [32]byte{}, errors.Errorf("slot %d out of bounds", slot)
+	}
+	return state.BlockRootAtIndex32(slot % params.BeaconConfig().SlotsPerHistoricalRoot)
+}
+
 // BlockRoot returns the block root stored in the BeaconState for epoch start slot.
 //
 // Spec pseudocode definition:
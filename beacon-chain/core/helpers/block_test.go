@@ -121,3 +121,33 @@ func TestBlockRootAtSlot_OutOfBounds(t *testing.T) {
 		}
 	}
 }
+
+func TestBlockRootAtSlot32_MatchesBlockRootAtSlot(t *testing.T) {
+	var blockRoots [][]byte
+	for i := uint64(0); i < params.BeaconConfig().SlotsPerHistoricalRoot; i++ {
+		blockRoots = append(blockRoots, []byte{byte(i)})
+	}
+	s, err := beaconstate.InitializeFromProto(&pb.BeaconState{
+		Slot:       3000,
+		BlockRoots: blockRoots,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, slot := range []uint64{2873, 2999} {
+		want, err := helpers.BlockRootAtSlot(s, slot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := helpers.BlockRootAtSlot32(s, slot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got[:], want) {
+			t.Errorf("Wanted root %#x at slot %d, received %#x", want, slot, got)
+		}
+	}
+	if _, err := helpers.BlockRootAtSlot32(s, 3000); err == nil || err.Error() != "slot 3000 out of bounds" {
+		t.Errorf("Expected out of bounds error, received %v", err)
+	}
+}
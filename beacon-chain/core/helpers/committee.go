@@ -17,6 +17,8 @@ import (
 )
 
 var committeeCache = cache.NewCommitteesCache()
+var shuffledIndicesCache = cache.NewShuffledIndicesCache()
+var committeeAssignmentsCache = cache.NewCommitteeAssignmentsCache()
 
 // SlotCommitteeCount returns the number of crosslink committees of a slot. The
 // active validator count is provided as an argument rather than a direct implementation
@@ -106,7 +108,38 @@ func BeaconCommittee(validatorIndices []uint64, seed [32]byte, slot uint64, comm
 	epochOffset := committeeIndex + (slot%params.BeaconConfig().SlotsPerEpoch)*committeesPerSlot
 	count := committeesPerSlot * params.BeaconConfig().SlotsPerEpoch
 
-	return ComputeCommittee(validatorIndices, seed, epochOffset, count)
+	shuffledList, err := shuffledIndicesWithCache(validatorIndices, seed, SlotToEpoch(slot))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not shuffle indices")
+	}
+	validatorCount := uint64(len(validatorIndices))
+	start := sliceutil.SplitOffset(validatorCount, count, epochOffset)
+	end := sliceutil.SplitOffset(validatorCount, count, epochOffset+1)
+	// Cap the capacity so that appending to the committee does not overwrite the shuffled list
+	// shared with the cache.
+	return shuffledList[start:end:end], nil
+}
+
+// shuffledIndicesWithCache returns the input active validator indices un-shuffled with the seed,
+// reusing the shuffled indices cache entry of the (seed, active validator count, epoch) tuple when
+// it exists. The returned list is shared with the cache and must not be mutated.
+func shuffledIndicesWithCache(indices []uint64, seed [32]byte, epoch uint64) ([]uint64, error) {
+	shuffledList, err := shuffledIndicesCache.ShuffledIndices(seed, uint64(len(indices)), epoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not interface with shuffled indices cache")
+	}
+	if shuffledList != nil {
+		return shuffledList, nil
+	}
+
+	shuffledList = make([]uint64, len(indices))
+	copy(shuffledList, indices)
+	shuffledList, err = UnshuffleList(shuffledList, seed)
+	if err != nil {
+		return nil, err
+	}
+	shuffledIndicesCache.AddShuffledIndices(seed, epoch, shuffledList)
+	return shuffledList, nil
 }
 
 // ComputeCommittee returns the requested shuffled committee out of the total committees using
@@ -168,14 +201,11 @@ func AttestingIndices(bf bitfield.Bitfield, committee []uint64) ([]uint64, error
 }
 
 // CommitteeAssignmentContainer represents a committee, index, and attester slot for a given epoch.
-type CommitteeAssignmentContainer struct {
-	Committee      []uint64
-	AttesterSlot   uint64
-	CommitteeIndex uint64
-}
+type CommitteeAssignmentContainer = cache.CommitteeAssignment
 
 // CommitteeAssignments is a map of validator indices pointing to the appropriate committee
-// assignment for the given epoch.
+// assignment for the given epoch. The result is cached by (seed, effective balances, epoch), the
+// returned maps are shared between callers and must not be mutated.
 //
 // 1. Determine the proposer validator index for each slot.
 // 2. Compute all committees.
@@ -191,8 +221,33 @@ func CommitteeAssignments(state *stateTrie.BeaconState, epoch uint64) (map[uint6
 		)
 	}
 
-	// Track which slot has which proposer.
 	startSlot := StartSlot(epoch)
+	seed, err := Seed(state, epoch, params.BeaconConfig().DomainBeaconAttester)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get seed")
+	}
+	activeValidatorIndices, err := ActiveValidatorIndices(state, epoch)
+	if err != nil {
+		return nil, nil, err
+	}
+	balancesRoot, err := effectiveBalancesRoot(state, activeValidatorIndices)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not hash effective balances")
+	}
+	cached, err := committeeAssignmentsCache.CommitteeAssignments(seed, balancesRoot, epoch)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not interface with committee assignments cache")
+	}
+	if cached != nil {
+		// Leave the state at the same slot a full computation would, callers rely on the
+		// state being advanced to the requested epoch.
+		if err := state.SetSlot(startSlot + params.BeaconConfig().SlotsPerEpoch - 1); err != nil {
+			return nil, nil, err
+		}
+		return cached.ValidatorCommittees, cached.ProposerIndexToSlot, nil
+	}
+
+	// Track which slot has which proposer.
 	proposerIndexToSlot := make(map[uint64]uint64)
 	for slot := startSlot; slot < startSlot+params.BeaconConfig().SlotsPerEpoch; slot++ {
 		if err := state.SetSlot(slot); err != nil {
@@ -205,10 +260,6 @@ func CommitteeAssignments(state *stateTrie.BeaconState, epoch uint64) (map[uint6
 		proposerIndexToSlot[i] = slot
 	}
 
-	activeValidatorIndices, err := ActiveValidatorIndices(state, epoch)
-	if err != nil {
-		return nil, nil, err
-	}
 	// Each slot in an epoch has a different set of committees. This value is derived from the
 	// active validator set, which does not change.
 	numCommitteesPerSlot := SlotCommitteeCount(uint64(len(activeValidatorIndices)))
@@ -235,9 +286,33 @@ func CommitteeAssignments(state *stateTrie.BeaconState, epoch uint64) (map[uint6
 		}
 	}
 
+	if err := committeeAssignmentsCache.AddCommitteeAssignments(&cache.CommitteeAssignments{
+		Seed:                seed,
+		BalancesRoot:        balancesRoot,
+		Epoch:               epoch,
+		ValidatorCommittees: validatorIndexToCommittee,
+		ProposerIndexToSlot: proposerIndexToSlot,
+	}); err != nil {
+		return nil, nil, err
+	}
+
 	return validatorIndexToCommittee, proposerIndexToSlot, nil
 }
 
+// effectiveBalancesRoot hashes the effective balances of the active validators, which the
+// proposer selection depends on in addition to the seed.
+func effectiveBalancesRoot(state *stateTrie.BeaconState, activeIndices []uint64) ([32]byte, error) {
+	balances := make([]byte, 0, 8*len(activeIndices))
+	for _, idx := range activeIndices {
+		v, err := state.ValidatorAtIndexReadOnly(idx)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		balances = append(balances, bytesutil.Bytes8(v.EffectiveBalance())...)
+	}
+	return hashutil.Hash(balances), nil
+}
+
 // CommitteeAssignment is used to query committee assignment from
 // current and previous epoch.
 //
@@ -361,7 +436,25 @@ func ShuffledIndices(state *stateTrie.BeaconState, epoch uint64) ([]uint64, erro
 		return nil
 	})
 
-	return UnshuffleList(indices, seed)
+	cached, err := shuffledIndicesCache.ShuffledIndices(seed, uint64(len(indices)), epoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not interface with shuffled indices cache")
+	}
+	if cached != nil {
+		shuffled := make([]uint64, len(cached))
+		copy(shuffled, cached)
+		return shuffled, nil
+	}
+
+	shuffled, err := UnshuffleList(indices, seed)
+	if err != nil {
+		return nil, err
+	}
+	// The returned list is handed over to the committee cache, keep a separate copy.
+	cachedList := make([]uint64, len(shuffled))
+	copy(cachedList, shuffled)
+	shuffledIndicesCache.AddShuffledIndices(seed, epoch, cachedList)
+	return shuffled, nil
 }
 
 // UpdateCommitteeCache gets called at the beginning of every epoch to cache the committee shuffled indices
@@ -427,9 +520,19 @@ func UpdateProposerIndicesInCache(state *stateTrie.BeaconState, epoch uint64) er
 	return nil
 }
 
-// ClearCache clears the committee cache
+// ClearCache clears the committee, shuffled indices and committee assignments caches.
 func ClearCache() {
 	committeeCache = cache.NewCommitteesCache()
+	shuffledIndicesCache = cache.NewShuffledIndicesCache()
+	committeeAssignmentsCache = cache.NewCommitteeAssignmentsCache()
+}
+
+// PruneShufflingCaches evicts the shuffled indices and committee assignments of the epochs
+// prior to the given epoch. Attestations older than the previous epoch are no longer valid,
+// so their shufflings are not needed by either the validation path or the duties endpoints.
+func PruneShufflingCaches(epoch uint64) {
+	shuffledIndicesCache.PruneBefore(epoch)
+	committeeAssignmentsCache.PruneBefore(epoch)
 }
 
 // This computes proposer indices of the current epoch and returns a list of proposer indices,
@@ -87,6 +87,58 @@ func TestComputeCommittee_WithoutCache(t *testing.T) {
 	}
 }
 
+func TestBeaconCommittee_MatchesComputeCommitteeWithShuffledIndicesCache(t *testing.T) {
+	ClearCache()
+	validatorCount := 4 * params.BeaconConfig().TargetCommitteeSize
+	indices := make([]uint64, validatorCount)
+	for i := range indices {
+		indices[i] = uint64(i)
+	}
+	seed := [32]byte{'A'}
+	slot := params.BeaconConfig().SlotsPerEpoch + 1
+	committeesPerSlot := SlotCommitteeCount(validatorCount)
+	count := committeesPerSlot * params.BeaconConfig().SlotsPerEpoch
+	epochOffset := (slot % params.BeaconConfig().SlotsPerEpoch) * committeesPerSlot
+
+	wanted, err := ComputeCommittee(indices, seed, epochOffset, count)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The first call populates the shuffled indices cache, the second is served from it.
+	for i := 0; i < 2; i++ {
+		committee, err := BeaconCommittee(indices, seed, slot, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(wanted, committee) {
+			t.Errorf("Wanted committee %v, got %v", wanted, committee)
+		}
+	}
+	cached, err := shuffledIndicesCache.ShuffledIndices(seed, validatorCount, SlotToEpoch(slot))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uint64(len(cached)) != validatorCount {
+		t.Errorf("Wanted %d cached shuffled indices, got %d", validatorCount, len(cached))
+	}
+
+	// Appending to a committee must not overwrite the cached shuffled indices.
+	committee, err := BeaconCommittee(indices, seed, slot, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = append(committee, validatorCount)
+	next, err := BeaconCommittee(indices, seed, slot+1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, idx := range next {
+		if idx == validatorCount {
+			t.Fatal("Appending to a committee overwrote the cached shuffled indices")
+		}
+	}
+}
+
 func TestAttestationParticipants_NoCommitteeCache(t *testing.T) {
 	committeeSize := uint64(16)
 	validators := make([]*ethpb.Validator, committeeSize*params.BeaconConfig().SlotsPerEpoch)
@@ -23,12 +23,12 @@ func Seed(state *stateTrie.BeaconState, epoch uint64, domain [bls.DomainByteLeng
 	lookAheadEpoch := epoch + params.BeaconConfig().EpochsPerHistoricalVector -
 		params.BeaconConfig().MinSeedLookahead - 1
 
-	randaoMix, err := RandaoMix(state, lookAheadEpoch)
+	randaoMix, err := state.RandaoMixAtIndex32(lookAheadEpoch % params.BeaconConfig().EpochsPerHistoricalVector)
 	if err != nil {
 		return [32]byte{}, err
 	}
 	seed := append(domain[:], bytesutil.Bytes8(epoch)...)
-	seed = append(seed, randaoMix...)
+	seed = append(seed, randaoMix[:]...)
 
 	seed32 := hashutil.Hash(seed)
 
@@ -45,12 +45,9 @@ func Seed(state *stateTrie.BeaconState, epoch uint64, domain [bls.DomainByteLeng
 //    """
 //    return state.randao_mixes[epoch % EPOCHS_PER_HISTORICAL_VECTOR]
 func RandaoMix(state *stateTrie.BeaconState, epoch uint64) ([]byte, error) {
-	currMix, err := state.RandaoMixAtIndex(epoch % params.BeaconConfig().EpochsPerHistoricalVector)
+	mix, err := state.RandaoMixAtIndex32(epoch % params.BeaconConfig().EpochsPerHistoricalVector)
 	if err != nil {
 		return nil, err
 	}
-	newMixLength := len(currMix)
-	newMix := make([]byte, newMixLength)
-	copy(newMix, currMix)
-	return newMix, nil
+	return mix[:], nil
 }
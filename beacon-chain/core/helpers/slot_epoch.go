@@ -1,12 +1,12 @@
 package helpers
 
 import (
-	"fmt"
 	"time"
 
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/roughtime"
+	"github.com/prysmaticlabs/prysm/shared/slotutil"
 )
 
 // SlotToEpoch returns the epoch number of the input slot.
@@ -90,16 +90,11 @@ func SlotsSinceEpochStarts(slot uint64) uint64 {
 }
 
 // Allow for slots "from the future" within a certain tolerance.
-const timeShiftTolerance = 10 // ms
+const timeShiftTolerance = 10 * time.Second
 
 // VerifySlotTime validates the input slot is not from the future.
 func VerifySlotTime(genesisTime uint64, slot uint64) error {
-	slotTime := genesisTime + slot*params.BeaconConfig().SecondsPerSlot
-	currentTime := uint64(roughtime.Now().Unix())
-	if slotTime > currentTime+timeShiftTolerance {
-		return fmt.Errorf("could not process slot from the future, slot time %d > current time %d", slotTime, currentTime)
-	}
-	return nil
+	return slotutil.VerifySlotTime(genesisTime, slot, timeShiftTolerance)
 }
 
 // SlotsSince computes the number of time slots that have occurred since the given timestamp.
@@ -9,6 +9,7 @@ import (
 	beaconstate "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/shared/benchutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
 )
 
 var runAmount = 25
@@ -111,6 +112,19 @@ func BenchmarkProcessEpoch_2FullEpochs(b *testing.B) {
 	}
 }
 
+func BenchmarkProcessEpoch_FilledState(b *testing.B) {
+	// The generated state doesn't depend on the benchmark files, so it scales to any validator
+	// count and participation.
+	beaconState, _ := testutil.DeterministicFilledState(b, 16384, 3, 0.9)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ProcessEpochPrecompute(context.Background(), beaconState.Copy()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func BenchmarkHashTreeRoot_FullState(b *testing.B) {
 	beaconState, err := benchutil.PreGenState2FullEpochs()
 	if err != nil {
@@ -0,0 +1,88 @@
+package state
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"go.opencensus.io/trace"
+)
+
+var (
+	epochBoundaryPrecomputeHit = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "epoch_boundary_precompute_hit",
+		Help: "The number of slot processings which started from a pre-computed epoch boundary state.",
+	})
+	epochBoundaryPrecomputeMiss = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "epoch_boundary_precompute_miss",
+		Help: "The number of slot processings across an epoch boundary without a pre-computed state.",
+	})
+)
+
+// epochBoundary holds a state advanced to the start of the next epoch ahead of time, along with
+// the slot and latest block header root of the state it was advanced from. A state is uniquely
+// identified by both, as it only goes through empty slots after its latest block.
+var epochBoundary = struct {
+	lock       sync.RWMutex
+	slot       uint64
+	headerRoot [32]byte
+	state      *stateTrie.BeaconState
+}{}
+
+// PrecomputeEpochBoundaryState advances a copy of the state through the empty slots up to the
+// start slot of the next epoch, including the epoch transition. Processing slots from the same
+// state to the next epoch or later then starts from the pre-computed state, which takes the
+// epoch transition off the critical path of the first block of the epoch.
+func PrecomputeEpochBoundaryState(ctx context.Context, state *stateTrie.BeaconState) error {
+	ctx, span := trace.StartSpan(ctx, "beacon-chain.ChainService.PrecomputeEpochBoundaryState")
+	defer span.End()
+
+	headerRoot, err := ssz.HashTreeRoot(state.LatestBlockHeader())
+	if err != nil {
+		return errors.Wrap(err, "could not hash latest block header")
+	}
+	epochBoundary.lock.RLock()
+	cached := epochBoundary.state != nil && epochBoundary.slot == state.Slot() && epochBoundary.headerRoot == headerRoot
+	epochBoundary.lock.RUnlock()
+	if cached {
+		return nil
+	}
+
+	boundaryState, err := ProcessSlots(ctx, state.Copy(), helpers.StartSlot(helpers.NextEpoch(state)))
+	if err != nil {
+		return errors.Wrap(err, "could not process slots to the epoch boundary")
+	}
+	epochBoundary.lock.Lock()
+	epochBoundary.slot = state.Slot()
+	epochBoundary.headerRoot = headerRoot
+	epochBoundary.state = boundaryState
+	epochBoundary.lock.Unlock()
+	return nil
+}
+
+// precomputedEpochBoundaryState returns a copy of the state pre-computed from the given state,
+// if it does not go past the given slot. States with a mutation feed are not served, as the
+// validator changes of the epoch transition must be sent on their feed.
+func precomputedEpochBoundaryState(state *stateTrie.BeaconState, slot uint64) *stateTrie.BeaconState {
+	if state.MutationFeed() != nil || slot < helpers.StartSlot(helpers.NextEpoch(state)) {
+		return nil
+	}
+	epochBoundary.lock.RLock()
+	defer epochBoundary.lock.RUnlock()
+	if epochBoundary.state == nil || epochBoundary.slot != state.Slot() || epochBoundary.state.Slot() > slot {
+		epochBoundaryPrecomputeMiss.Inc()
+		return nil
+	}
+	headerRoot, err := ssz.HashTreeRoot(state.LatestBlockHeader())
+	if err != nil || headerRoot != epochBoundary.headerRoot {
+		epochBoundaryPrecomputeMiss.Inc()
+		return nil
+	}
+	epochBoundaryPrecomputeHit.Inc()
+	return epochBoundary.state.Copy()
+}
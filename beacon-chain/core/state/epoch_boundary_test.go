@@ -0,0 +1,64 @@
+package state_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestPrecomputeEpochBoundaryState_MatchesProcessSlots(t *testing.T) {
+	ctx := context.Background()
+	genesis, _ := testutil.DeterministicGenesisState(t, params.MinimalSpecConfig().MinGenesisActiveValidatorCount)
+	if err := genesis.SetSlot(params.BeaconConfig().SlotsPerEpoch - 2); err != nil {
+		t.Fatal(err)
+	}
+	target := params.BeaconConfig().SlotsPerEpoch + 1
+
+	wanted, err := state.ProcessSlots(ctx, genesis.Copy(), target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantedRoot, err := wanted.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := featureconfig.Get()
+	cfg.EnableEpochBoundaryPrecompute = true
+	featureconfig.Init(cfg)
+	defer func() {
+		cfg.EnableEpochBoundaryPrecompute = false
+		featureconfig.Init(cfg)
+	}()
+
+	if err := state.PrecomputeEpochBoundaryState(ctx, genesis); err != nil {
+		t.Fatal(err)
+	}
+	if genesis.Slot() != params.BeaconConfig().SlotsPerEpoch-2 {
+		t.Fatal("Pre-computing the epoch boundary state mutated the input state")
+	}
+	got, err := state.ProcessSlots(ctx, genesis.Copy(), target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotRoot, err := got.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Slot() != target || gotRoot != wantedRoot {
+		t.Errorf("Wanted state root %#x at slot %d, got %#x at slot %d", wantedRoot, target, gotRoot, got.Slot())
+	}
+
+	// Processing slots before the boundary does not use the pre-computed state.
+	got, err = state.ProcessSlots(ctx, genesis.Copy(), params.BeaconConfig().SlotsPerEpoch-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Slot() != params.BeaconConfig().SlotsPerEpoch-1 {
+		t.Errorf("Wanted slot %d, got %d", params.BeaconConfig().SlotsPerEpoch-1, got.Slot())
+	}
+}
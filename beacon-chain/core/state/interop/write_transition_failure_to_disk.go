@@ -0,0 +1,53 @@
+package interop
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+)
+
+// WriteTransitionFailureToDisk writes the ssz encoded pre-state and block of a
+// failed state transition, along with the transition error, to the given
+// directory so the transition can be replayed. Debug!
+func WriteTransitionFailureToDisk(
+	dir string, preState *stateTrie.BeaconState, block *ethpb.SignedBeaconBlock, transitionErr error,
+) {
+	if preState == nil || block == nil || block.Block == nil {
+		return
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		log.WithError(err).Error("Failed to create transition debug directory")
+		return
+	}
+	prefix := path.Join(dir, fmt.Sprintf("failed_transition_%d_%d", preState.Slot(), block.Block.Slot))
+	log.Warnf("Writing failed state transition to disk at %s_*", prefix)
+
+	enc, err := ssz.Marshal(preState.InnerStateUnsafe())
+	if err != nil {
+		log.WithError(err).Error("Failed to ssz encode state")
+		return
+	}
+	if err := ioutil.WriteFile(prefix+"_pre_state.ssz", enc, 0664); err != nil {
+		log.WithError(err).Error("Failed to write to disk")
+		return
+	}
+	enc, err = ssz.Marshal(block)
+	if err != nil {
+		log.WithError(err).Error("Failed to ssz encode block")
+		return
+	}
+	if err := ioutil.WriteFile(prefix+"_block.ssz", enc, 0664); err != nil {
+		log.WithError(err).Error("Failed to write to disk")
+		return
+	}
+	if transitionErr != nil {
+		if err := ioutil.WriteFile(prefix+"_error.txt", []byte(transitionErr.Error()+"\n"), 0664); err != nil {
+			log.WithError(err).Error("Failed to write to disk")
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package state
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+)
+
+// The stages of block and epoch processing timed by the transition profile.
+const (
+	StageBlockHeader               = "block_header"
+	StageRandao                    = "randao"
+	StageEth1Data                  = "eth1_data"
+	StageProposerSlashings         = "proposer_slashings"
+	StageAttesterSlashings         = "attester_slashings"
+	StageAttestations              = "attestations"
+	StageDeposits                  = "deposits"
+	StageVoluntaryExits            = "voluntary_exits"
+	StageEpochAttestations         = "epoch_attestations"
+	StageJustificationFinalization = "justification_finalization"
+	StageRewardsPenalties          = "rewards_penalties"
+	StageRegistryUpdates           = "registry_updates"
+	StageSlashings                 = "slashings"
+	StageFinalUpdates              = "final_updates"
+)
+
+// StageTiming aggregates the time spent in a stage of the state transition.
+type StageTiming struct {
+	Count uint64
+	Total time.Duration
+	Max   time.Duration
+}
+
+// Average time spent in the stage.
+func (s *StageTiming) Average() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+var (
+	profileLock   sync.Mutex
+	profileStages = make(map[string]*StageTiming)
+)
+
+// recordStage adds the time elapsed since start to the stage, if transition profiling is enabled.
+func recordStage(stage string, start time.Time) {
+	if !featureconfig.Get().EnableTransitionProfiling {
+		return
+	}
+	elapsed := time.Since(start)
+	profileLock.Lock()
+	defer profileLock.Unlock()
+	t, ok := profileStages[stage]
+	if !ok {
+		t = &StageTiming{}
+		profileStages[stage] = t
+	}
+	t.Count++
+	t.Total += elapsed
+	if elapsed > t.Max {
+		t.Max = elapsed
+	}
+}
+
+// TransitionProfile returns the timings of the state transition stages recorded since the
+// previous call, and resets them.
+func TransitionProfile() map[string]*StageTiming {
+	profileLock.Lock()
+	defer profileLock.Unlock()
+	stages := profileStages
+	profileStages = make(map[string]*StageTiming)
+	return stages
+}
@@ -0,0 +1,34 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+)
+
+func TestTransitionProfile(t *testing.T) {
+	TransitionProfile()
+	recordStage(StageRandao, time.Now())
+	if stages := TransitionProfile(); len(stages) != 0 {
+		t.Fatalf("Expected no stages to be recorded without the flag, received %v", stages)
+	}
+
+	featureconfig.Init(&featureconfig.Flags{EnableTransitionProfiling: true})
+	defer featureconfig.Init(&featureconfig.Flags{})
+	recordStage(StageRandao, time.Now().Add(-2*time.Second))
+	recordStage(StageRandao, time.Now().Add(-4*time.Second))
+	recordStage(StageDeposits, time.Now())
+
+	stages := TransitionProfile()
+	randao := stages[StageRandao]
+	if randao == nil || randao.Count != 2 || randao.Max < 4*time.Second || randao.Average() < 3*time.Second {
+		t.Errorf("Unexpected randao timing %+v", randao)
+	}
+	if stages[StageDeposits] == nil || stages[StageDeposits].Count != 1 {
+		t.Errorf("Unexpected deposits timing %+v", stages[StageDeposits])
+	}
+	if stages := TransitionProfile(); len(stages) != 0 {
+		t.Errorf("Expected the profile to be reset, received %v", stages)
+	}
+}
@@ -2,10 +2,29 @@ package state
 
 import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
 )
 
 // skipSlotCache exists for the unlikely scenario that is a large gap between the head state and
 // the current slot. If the beacon chain were ever to be stalled for several epochs, it may be
 // difficult or impossible to compute the appropriate beacon state for assignments within a
-// reasonable amount of time.
+// reasonable amount of time. It also saves advancing the same state again when several
+// attestations are validated against the same target, or several blocks are proposed on top of
+// the same parent after skipped slots.
 var skipSlotCache = cache.NewSkipSlotCache()
+
+// skipSlotCacheKey is the hash of the root of the pre-state and the slot it is advanced to. The
+// root is only computed when the cache is enabled.
+func skipSlotCacheKey(state *stateTrie.BeaconState, slot uint64) ([32]byte, error) {
+	if !featureconfig.Get().EnableSkipSlotsCache {
+		return [32]byte{}, nil
+	}
+	root, err := state.HashTreeRoot()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return hashutil.Hash(append(root[:], bytesutil.Bytes8(slot)...)), nil
+}
@@ -5,8 +5,10 @@ import (
 	"testing"
 
 	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
 	beaconstate "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
@@ -47,3 +49,70 @@ func TestSkipSlotCache_OK(t *testing.T) {
 		t.Fatal("Skipped slots cache leads to different states")
 	}
 }
+
+func TestSkipSlotCache_ConcurrentForks(t *testing.T) {
+	cfg := featureconfig.Get()
+	cfg.EnableSkipSlotsCache = true
+	featureconfig.Init(cfg)
+	defer func() {
+		cfg.EnableSkipSlotsCache = false
+		featureconfig.Init(cfg)
+	}()
+
+	// Two forks with different states at the same slot are advanced through the same empty slots.
+	forkA, _ := testutil.DeterministicGenesisState(t, params.MinimalSpecConfig().MinGenesisActiveValidatorCount)
+	forkB := forkA.Copy()
+	if err := forkB.UpdateBalancesAtIndex(0, 1); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	advancedA, err := state.ProcessSlots(ctx, forkA.Copy(), 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	advancedB, err := state.ProcessSlots(ctx, forkB.Copy(), 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if advancedB.Balances()[0] != 1 {
+		t.Fatal("Expected the state of the second fork not to be served from the state of the first fork")
+	}
+	cachedA, err := state.ProcessSlots(ctx, forkA.Copy(), 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ssz.DeepEqual(advancedA.CloneInnerState(), cachedA.CloneInnerState()) {
+		t.Error("Expected the cached state to equal the advanced state")
+	}
+}
+
+func TestSkipSlotCache_MutationFeedAdvancesState(t *testing.T) {
+	cfg := featureconfig.Get()
+	cfg.EnableSkipSlotsCache = true
+	featureconfig.Init(cfg)
+	defer func() {
+		cfg.EnableSkipSlotsCache = false
+		featureconfig.Init(cfg)
+	}()
+
+	genesis, _ := testutil.DeterministicGenesisState(t, params.MinimalSpecConfig().MinGenesisActiveValidatorCount)
+	ctx := context.Background()
+	// Validators are penalized for missing attestations at the end of the first epoch after genesis.
+	slot := 2*params.BeaconConfig().SlotsPerEpoch + 1
+	if _, err := state.ProcessSlots(ctx, genesis.Copy(), slot); err != nil {
+		t.Fatal(err)
+	}
+
+	st := genesis.Copy()
+	f := new(event.Feed)
+	ch := make(chan *feed.Event, 4*len(st.Validators()))
+	sub := f.Subscribe(ch)
+	defer sub.Unsubscribe()
+	st.SetMutationFeed(f)
+	if _, err := state.ProcessSlots(ctx, st, slot); err != nil {
+		t.Fatal(err)
+	}
+	if len(ch) == 0 {
+		t.Error("Expected the epoch transitions to send balance changes instead of serving the cached state")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
@@ -16,8 +17,11 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state/interop"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/mathutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
@@ -57,6 +61,7 @@ func ExecuteStateTransition(
 	ctx, span := trace.StartSpan(ctx, "beacon-chain.ChainService.ExecuteStateTransition")
 	defer span.End()
 	var err error
+	defer writeFailedTransition(state, signed, &err)()
 	// Execute per slots transition.
 	state, err = ProcessSlots(ctx, state, signed.Block.Slot)
 	if err != nil {
@@ -77,8 +82,9 @@ func ExecuteStateTransition(
 		return nil, err
 	}
 	if !bytes.Equal(postStateRoot[:], signed.Block.StateRoot) {
-		return state, fmt.Errorf("validate state root failed, wanted: %#x, received: %#x",
+		err = fmt.Errorf("validate state root failed, wanted: %#x, received: %#x",
 			postStateRoot[:], signed.Block.StateRoot)
+		return state, err
 	}
 	return state, nil
 }
@@ -114,6 +120,7 @@ func ExecuteStateTransitionNoVerifyAttSigs(
 	ctx, span := trace.StartSpan(ctx, "beacon-chain.ChainService.ExecuteStateTransitionNoVerifyAttSigs")
 	defer span.End()
 	var err error
+	defer writeFailedTransition(state, signed, &err)()
 
 	// Execute per slots transition.
 	state, err = ProcessSlots(ctx, state, signed.Block.Slot)
@@ -130,6 +137,86 @@ func ExecuteStateTransitionNoVerifyAttSigs(
 	return state, nil
 }
 
+// ExecuteStateTransitionNoVerifySig defines the procedure for a state transition function
+// replaying blocks which were already verified, such as finalized blocks regenerating historical
+// states. It skips the proposer, randao, attestation and voluntary exit signature verifications,
+// which take most of the time of a state transition, but still validates the parent root and the
+// post-state root of the block. A post-state root mismatch is returned as a
+// *StateRootMismatchError along with the post-state.
+//
+// WARNING: This method does not validate most signatures in a block. This method also modifies
+// the passed in state.
+func ExecuteStateTransitionNoVerifySig(
+	ctx context.Context,
+	state *stateTrie.BeaconState,
+	signed *ethpb.SignedBeaconBlock,
+) (*stateTrie.BeaconState, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if signed == nil || signed.Block == nil {
+		return nil, errors.New("nil block")
+	}
+
+	b.ClearEth1DataVoteCache()
+	ctx, span := trace.StartSpan(ctx, "beacon-chain.ChainService.ExecuteStateTransitionNoVerifySig")
+	defer span.End()
+
+	// Execute per slots transition.
+	state, err := ProcessSlots(ctx, state, signed.Block.Slot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process slot")
+	}
+
+	// Execute per block transition.
+	state, err = ProcessBlockForStateRoot(ctx, state, signed)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not process block in slot %d", signed.Block.Slot)
+	}
+
+	postStateRoot, err := state.HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(postStateRoot[:], signed.Block.StateRoot) {
+		return state, &StateRootMismatchError{
+			Slot:     signed.Block.Slot,
+			Expected: bytesutil.ToBytes32(signed.Block.StateRoot),
+			Computed: postStateRoot,
+		}
+	}
+	return state, nil
+}
+
+// StateRootMismatchError is returned when the post-state root of a block differs from the
+// state root the block commits to.
+type StateRootMismatchError struct {
+	Slot     uint64
+	Expected [32]byte
+	Computed [32]byte
+}
+
+func (e *StateRootMismatchError) Error() string {
+	return fmt.Sprintf("validate state root failed at slot %d, wanted: %#x, received: %#x", e.Slot, e.Expected, e.Computed)
+}
+
+// writeFailedTransition copies the pre-state of a state transition if a transition debug
+// directory is configured. The returned function writes the pre-state and block to that
+// directory if the transition failed, so it can be replayed with the beacon-chain replay
+// command, and is meant to be deferred.
+func writeFailedTransition(preState *stateTrie.BeaconState, signed *ethpb.SignedBeaconBlock, err *error) func() {
+	dir := flags.Get().TransitionDebugDir
+	if dir == "" || preState == nil {
+		return func() {}
+	}
+	preState = preState.Copy()
+	return func() {
+		if *err != nil {
+			interop.WriteTransitionFailureToDisk(dir, preState, signed, *err)
+		}
+	}
+}
+
 // CalculateStateRoot defines the procedure for a state transition function.
 // This does not validate any BLS signatures in a block, it is used for calculating the
 // state root of the state for the block proposer to use.
@@ -270,40 +357,49 @@ func ProcessSlots(ctx context.Context, state *stateTrie.BeaconState, slot uint64
 		return state, nil
 	}
 
-	highestSlot := state.Slot()
-	key := state.Slot()
+	// Start from the epoch boundary state pre-computed from this state, if one exists.
+	if featureconfig.Get().EnableEpochBoundaryPrecompute {
+		if boundaryState := precomputedEpochBoundaryState(state, slot); boundaryState != nil {
+			state = boundaryState
+			if state.Slot() == slot {
+				return state, nil
+			}
+		}
+	}
 
-	// Restart from cached value, if one exists.
-	cachedState, err := skipSlotCache.Get(ctx, key)
+	key, err := skipSlotCacheKey(state, slot)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(err, "could not compute skip slot cache key")
 	}
 
-	if cachedState != nil && cachedState.Slot() < slot {
-		highestSlot = cachedState.Slot()
-		state = cachedState
-	}
-	if err := skipSlotCache.MarkInProgress(key); err == cache.ErrAlreadyInProgress {
-		cachedState, err = skipSlotCache.Get(ctx, key)
+	// Return the cached state advanced to the slot, if one exists. States with a mutation
+	// feed are always advanced, as the validator changes of the skipped epoch transitions
+	// must be sent on their feed.
+	if state.MutationFeed() == nil {
+		cachedState, err := skipSlotCache.Get(ctx, key)
 		if err != nil {
 			return nil, err
 		}
-		if cachedState != nil && cachedState.Slot() < slot {
-			highestSlot = cachedState.Slot()
-			state = cachedState
+		if cachedState != nil {
+			return cachedState, nil
 		}
-	} else if err != nil {
-		return nil, err
+		if err := skipSlotCache.MarkInProgress(key); err == cache.ErrAlreadyInProgress {
+			cachedState, err = skipSlotCache.Get(ctx, key)
+			if err != nil {
+				return nil, err
+			}
+			if cachedState != nil {
+				return cachedState, nil
+			}
+		} else if err != nil {
+			return nil, err
+		}
+		defer skipSlotCache.MarkNotInProgress(key)
 	}
-	defer skipSlotCache.MarkNotInProgress(key)
 
 	for state.Slot() < slot {
 		if ctx.Err() != nil {
 			traceutil.AnnotateError(span, ctx.Err())
-			// Cache last best value.
-			if highestSlot < state.Slot() {
-				skipSlotCache.Put(ctx, key, state)
-			}
 			return nil, ctx.Err()
 		}
 		state, err = ProcessSlot(ctx, state)
@@ -321,8 +417,8 @@ func ProcessSlots(ctx context.Context, state *stateTrie.BeaconState, slot uint64
 		state.SetSlot(state.Slot() + 1)
 	}
 
-	if highestSlot < state.Slot() {
-		skipSlotCache.Put(ctx, key, state)
+	if err := skipSlotCache.Put(ctx, key, state); err != nil {
+		return nil, err
 	}
 
 	return state, nil
@@ -347,19 +443,25 @@ func ProcessBlock(
 	ctx, span := trace.StartSpan(ctx, "beacon-chain.ChainService.state.ProcessBlock")
 	defer span.End()
 
+	start := time.Now()
 	state, err := b.ProcessBlockHeader(state, signed)
+	recordStage(StageBlockHeader, start)
 	if err != nil {
 		traceutil.AnnotateError(span, err)
 		return nil, errors.Wrap(err, "could not process block header")
 	}
 
+	start = time.Now()
 	state, err = b.ProcessRandao(state, signed.Block.Body)
+	recordStage(StageRandao, start)
 	if err != nil {
 		traceutil.AnnotateError(span, err)
 		return nil, errors.Wrap(err, "could not verify and process randao")
 	}
 
+	start = time.Now()
 	state, err = b.ProcessEth1DataInBlock(state, signed.Block)
+	recordStage(StageEth1Data, start)
 	if err != nil {
 		traceutil.AnnotateError(span, err)
 		return nil, errors.Wrap(err, "could not process eth1 data")
@@ -393,19 +495,25 @@ func ProcessBlockNoVerifyAttSigs(
 	ctx, span := trace.StartSpan(ctx, "beacon-chain.ChainService.state.ProcessBlock")
 	defer span.End()
 
+	start := time.Now()
 	state, err := b.ProcessBlockHeader(state, signed)
+	recordStage(StageBlockHeader, start)
 	if err != nil {
 		traceutil.AnnotateError(span, err)
 		return nil, errors.Wrap(err, "could not process block header")
 	}
 
+	start = time.Now()
 	state, err = b.ProcessRandao(state, signed.Block.Body)
+	recordStage(StageRandao, start)
 	if err != nil {
 		traceutil.AnnotateError(span, err)
 		return nil, errors.Wrap(err, "could not verify and process randao")
 	}
 
+	start = time.Now()
 	state, err = b.ProcessEth1DataInBlock(state, signed.Block)
+	recordStage(StageEth1Data, start)
 	if err != nil {
 		traceutil.AnnotateError(span, err)
 		return nil, errors.Wrap(err, "could not process eth1 data")
@@ -449,27 +557,37 @@ func ProcessOperations(
 	ctx, span := trace.StartSpan(ctx, "beacon-chain.ChainService.state.ProcessOperations")
 	defer span.End()
 
-	if err := verifyOperationLengths(state, body); err != nil {
+	if err := VerifyOperationLengths(state, body); err != nil {
 		return nil, errors.Wrap(err, "could not verify operation lengths")
 	}
 
+	start := time.Now()
 	state, err := b.ProcessProposerSlashings(ctx, state, body)
+	recordStage(StageProposerSlashings, start)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process block proposer slashings")
 	}
+	start = time.Now()
 	state, err = b.ProcessAttesterSlashings(ctx, state, body)
+	recordStage(StageAttesterSlashings, start)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process block attester slashings")
 	}
+	start = time.Now()
 	state, err = b.ProcessAttestations(ctx, state, body)
+	recordStage(StageAttestations, start)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process block attestations")
 	}
+	start = time.Now()
 	state, err = b.ProcessDeposits(ctx, state, body)
+	recordStage(StageDeposits, start)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process block validator deposits")
 	}
+	start = time.Now()
 	state, err = b.ProcessVoluntaryExits(ctx, state, body)
+	recordStage(StageVoluntaryExits, start)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process validator exits")
 	}
@@ -509,27 +627,37 @@ func processOperationsNoVerify(
 	ctx, span := trace.StartSpan(ctx, "beacon-chain.ChainService.state.ProcessOperations")
 	defer span.End()
 
-	if err := verifyOperationLengths(state, body); err != nil {
+	if err := VerifyOperationLengths(state, body); err != nil {
 		return nil, errors.Wrap(err, "could not verify operation lengths")
 	}
 
+	start := time.Now()
 	state, err := b.ProcessProposerSlashings(ctx, state, body)
+	recordStage(StageProposerSlashings, start)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process block proposer slashings")
 	}
+	start = time.Now()
 	state, err = b.ProcessAttesterSlashings(ctx, state, body)
+	recordStage(StageAttesterSlashings, start)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process block attester slashings")
 	}
+	start = time.Now()
 	state, err = b.ProcessAttestationsNoVerify(ctx, state, body)
+	recordStage(StageAttestations, start)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process block attestations")
 	}
+	start = time.Now()
 	state, err = b.ProcessDeposits(ctx, state, body)
+	recordStage(StageDeposits, start)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process block validator deposits")
 	}
+	start = time.Now()
 	state, err = b.ProcessVoluntaryExitsNoVerify(state, body)
+	recordStage(StageVoluntaryExits, start)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process validator exits")
 	}
@@ -537,7 +665,10 @@ func processOperationsNoVerify(
 	return state, nil
 }
 
-func verifyOperationLengths(state *stateTrie.BeaconState, body *ethpb.BeaconBlockBody) error {
+// VerifyOperationLengths checks the number of each operation in the block body against
+// the maximum allowed per block and the number of deposits against the deposits pending
+// in the state.
+func VerifyOperationLengths(state *stateTrie.BeaconState, body *ethpb.BeaconBlockBody) error {
 	if uint64(len(body.ProposerSlashings)) > params.BeaconConfig().MaxProposerSlashings {
 		return fmt.Errorf(
 			"number of proposer slashings (%d) in block body exceeds allowed threshold of %d",
@@ -605,35 +736,47 @@ func ProcessEpochPrecompute(ctx context.Context, state *stateTrie.BeaconState) (
 	if state == nil {
 		return nil, errors.New("nil state")
 	}
+	start := time.Now()
 	vp, bp := precompute.New(ctx, state)
 	vp, bp, err := precompute.ProcessAttestations(ctx, state, vp, bp)
 	if err != nil {
 		return nil, err
 	}
+	recordStage(StageEpochAttestations, start)
 
 	ValidatorSummary = vp
 
+	start = time.Now()
 	state, err = precompute.ProcessJustificationAndFinalizationPreCompute(state, bp)
+	recordStage(StageJustificationFinalization, start)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process justification")
 	}
 
+	start = time.Now()
 	state, err = precompute.ProcessRewardsAndPenaltiesPrecompute(state, bp, vp)
+	recordStage(StageRewardsPenalties, start)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process rewards and penalties")
 	}
 
+	start = time.Now()
 	state, err = e.ProcessRegistryUpdates(state)
+	recordStage(StageRegistryUpdates, start)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process registry updates")
 	}
 
+	start = time.Now()
 	err = precompute.ProcessSlashingsPrecompute(state, bp)
 	if err != nil {
 		return nil, err
 	}
+	recordStage(StageSlashings, start)
 
+	start = time.Now()
 	state, err = e.ProcessFinalUpdates(state)
+	recordStage(StageFinalUpdates, start)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process final updates")
 	}
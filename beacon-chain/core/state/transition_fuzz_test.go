@@ -159,7 +159,7 @@ func TestFuzzverifyOperationLengths_10000(t *testing.T) {
 	for i := 0; i < 10000; i++ {
 		fuzzer.Fuzz(state)
 		fuzzer.Fuzz(bb)
-		verifyOperationLengths(state, bb)
+		VerifyOperationLengths(state, bb)
 	}
 }
 
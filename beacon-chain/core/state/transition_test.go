@@ -5,6 +5,9 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -14,6 +17,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	beaconstate "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/attestationutil"
@@ -44,6 +48,48 @@ func TestExecuteStateTransition_IncorrectSlot(t *testing.T) {
 	}
 }
 
+func TestExecuteStateTransition_WritesFailedTransition(t *testing.T) {
+	dir := filepath.Join(testutil.TempDir(), "transition-debug")
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	flags.Init(&flags.GlobalFlags{TransitionDebugDir: dir})
+	defer flags.Init(&flags.GlobalFlags{})
+
+	beaconState, _ := testutil.DeterministicGenesisState(t, 8)
+	if err := beaconState.SetSlot(5); err != nil {
+		t.Fatal(err)
+	}
+	block := &ethpb.SignedBeaconBlock{
+		Block: &ethpb.BeaconBlock{
+			Slot: 4,
+			Body: &ethpb.BeaconBlockBody{},
+		},
+	}
+	if _, err := state.ExecuteStateTransition(context.Background(), beaconState, block); err == nil {
+		t.Fatal("Expected state transition to fail")
+	}
+	for _, suffix := range []string{"pre_state.ssz", "block.ssz", "error.txt"} {
+		fp := filepath.Join(dir, "failed_transition_5_4_"+suffix)
+		if _, err := os.Stat(fp); err != nil {
+			t.Errorf("Expected %s to be written: %v", fp, err)
+		}
+	}
+	enc, err := ioutil.ReadFile(filepath.Join(dir, "failed_transition_5_4_pre_state.ssz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := &pb.BeaconState{}
+	if err := ssz.Unmarshal(enc, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Slot != 5 {
+		t.Errorf("Wanted pre-state slot 5, received %d", decoded.Slot)
+	}
+}
+
 func TestExecuteStateTransition_FullProcess(t *testing.T) {
 	beaconState, privKeys := testutil.DeterministicGenesisState(t, 100)
 
@@ -112,6 +158,61 @@ func TestExecuteStateTransition_FullProcess(t *testing.T) {
 	}
 }
 
+func TestExecuteStateTransitionNoVerifySig_SkipsSignatures(t *testing.T) {
+	beaconState, privKeys := testutil.DeterministicGenesisState(t, 100)
+	block, err := testutil.GenerateFullBlock(beaconState, privKeys, nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Corrupt the signatures, which are not verified.
+	block.Signature = make([]byte, 96)
+	block.Block.Body.RandaoReveal = bytes.Repeat([]byte{'a'}, 96)
+	_, err = state.ExecuteStateTransitionNoVerifySig(context.Background(), beaconState.Copy(), block)
+	if err == nil {
+		t.Fatal("Expected the corrupted randao reveal to change the post-state root")
+	}
+	if _, ok := err.(*state.StateRootMismatchError); !ok {
+		t.Fatalf("Expected a state root mismatch, received %v", err)
+	}
+
+	// Recompute the state root for the corrupted randao reveal.
+	postState, err := state.ProcessSlots(context.Background(), beaconState.Copy(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	postState, err = state.ProcessBlockForStateRoot(context.Background(), postState, block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := postState.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	block.Block.StateRoot = root[:]
+	postState, err = state.ExecuteStateTransitionNoVerifySig(context.Background(), beaconState.Copy(), block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if postState.Slot() != 1 {
+		t.Errorf("Wanted post-state at slot 1, received %d", postState.Slot())
+	}
+	if _, err := state.ExecuteStateTransition(context.Background(), beaconState.Copy(), block); err == nil {
+		t.Error("Expected the full state transition to reject the corrupted signatures")
+	}
+}
+
+func TestExecuteStateTransitionNoVerifySig_ValidatesParentRoot(t *testing.T) {
+	beaconState, privKeys := testutil.DeterministicGenesisState(t, 100)
+	block, err := testutil.GenerateFullBlock(beaconState, privKeys, nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block.Block.ParentRoot = bytes.Repeat([]byte{'b'}, 32)
+	if _, err := state.ExecuteStateTransitionNoVerifySig(context.Background(), beaconState, block); err == nil {
+		t.Error("Expected an error for a block with the wrong parent root")
+	}
+}
+
 func TestProcessBlock_IncorrectProposerSlashing(t *testing.T) {
 	beaconState, privKeys := testutil.DeterministicGenesisState(t, 100)
 
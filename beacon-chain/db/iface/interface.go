@@ -4,10 +4,13 @@ package iface
 import (
 	"context"
 	"io"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
 	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/proto/beacon/db"
@@ -27,6 +30,8 @@ type ReadOnlyDatabase interface {
 	HasBlock(ctx context.Context, blockRoot [32]byte) bool
 	GenesisBlock(ctx context.Context) (*ethpb.SignedBeaconBlock, error)
 	IsFinalizedBlock(ctx context.Context, blockRoot [32]byte) bool
+	IsCanonical(ctx context.Context, blockRoot [32]byte) bool
+	OrphanedBlocks(ctx context.Context, slot uint64) ([]*eth.SignedBeaconBlock, error)
 	// Validator related methods.
 	ValidatorIndex(ctx context.Context, publicKey []byte) (uint64, bool, error)
 	HasValidatorIndex(ctx context.Context, publicKey []byte) bool
@@ -55,10 +60,15 @@ type ReadOnlyDatabase interface {
 	ArchivedPointState(ctx context.Context, index uint64) (*state.BeaconState, error)
 	ArchivedPointRoot(ctx context.Context, index uint64) [32]byte
 	HasArchivedPoint(ctx context.Context, index uint64) bool
+	// Validator accounting handlers.
+	ValidatorRewards(ctx context.Context, validatorIdx uint64, startEpoch uint64, endEpoch uint64) (map[uint64]*precompute.Rewards, error)
 	// Deposit contract related handlers.
 	DepositContractAddress(ctx context.Context) ([]byte, error)
 	// Powchain operations.
 	PowchainData(ctx context.Context) (*db.ETH1ChainData, error)
+	Eth1HeaderByHash(ctx context.Context, hash common.Hash) (*gethTypes.Header, error)
+	Eth1HeaderByNumber(ctx context.Context, number *big.Int) (*gethTypes.Header, error)
+	Eth1HeaderByTimestamp(ctx context.Context, time uint64) (*gethTypes.Header, error)
 }
 
 // NoHeadAccessDatabase -- See github.com/prysmaticlabs/prysm/beacon-chain/db.NoHeadAccessDatabase
@@ -104,10 +114,14 @@ type NoHeadAccessDatabase interface {
 	SaveArchivedValidatorParticipation(ctx context.Context, epoch uint64, part *eth.ValidatorParticipation) error
 	SaveArchivedPointState(ctx context.Context, state *state.BeaconState, index uint64) error
 	SaveArchivedPointRoot(ctx context.Context, blockRoot [32]byte, index uint64) error
+	// Validator accounting handlers.
+	SaveValidatorRewards(ctx context.Context, epoch uint64, rewards []*precompute.Rewards) error
 	// Deposit contract related handlers.
 	SaveDepositContractAddress(ctx context.Context, addr common.Address) error
 	// Powchain operations.
 	SavePowchainData(ctx context.Context, data *db.ETH1ChainData) error
+	SaveEth1Header(ctx context.Context, header *gethTypes.Header) error
+	SaveEth1Headers(ctx context.Context, headers []*gethTypes.Header) error
 }
 
 // HeadAccessDatabase -- See github.com/prysmaticlabs/prysm/beacon-chain/db.HeadAccessDatabase
@@ -131,4 +145,5 @@ type Database interface {
 
 	// Backup and restore methods
 	Backup(ctx context.Context) error
+	CheckIntegrity(ctx context.Context) error
 }
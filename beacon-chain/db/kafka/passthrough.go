@@ -2,10 +2,13 @@ package kafka
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
 	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/proto/beacon/db"
@@ -28,6 +31,11 @@ func (e Exporter) Backup(ctx context.Context) error {
 	return e.db.Backup(ctx)
 }
 
+// CheckIntegrity -- passthrough.
+func (e Exporter) CheckIntegrity(ctx context.Context) error {
+	return e.db.CheckIntegrity(ctx)
+}
+
 // AttestationsByDataRoot -- passthrough.
 func (e Exporter) AttestationsByDataRoot(ctx context.Context, attDataRoot [32]byte) ([]*eth.Attestation, error) {
 	return e.db.AttestationsByDataRoot(ctx, attDataRoot)
@@ -318,6 +326,16 @@ func (e Exporter) IsFinalizedBlock(ctx context.Context, blockRoot [32]byte) bool
 	return e.db.IsFinalizedBlock(ctx, blockRoot)
 }
 
+// IsCanonical -- passthrough.
+func (e Exporter) IsCanonical(ctx context.Context, blockRoot [32]byte) bool {
+	return e.db.IsCanonical(ctx, blockRoot)
+}
+
+// OrphanedBlocks -- passthrough.
+func (e Exporter) OrphanedBlocks(ctx context.Context, slot uint64) ([]*eth.SignedBeaconBlock, error) {
+	return e.db.OrphanedBlocks(ctx, slot)
+}
+
 // PowchainData -- passthrough
 func (e Exporter) PowchainData(ctx context.Context) (*db.ETH1ChainData, error) {
 	return e.db.PowchainData(ctx)
@@ -328,6 +346,31 @@ func (e Exporter) SavePowchainData(ctx context.Context, data *db.ETH1ChainData)
 	return e.db.SavePowchainData(ctx, data)
 }
 
+// Eth1HeaderByHash -- passthrough
+func (e Exporter) Eth1HeaderByHash(ctx context.Context, hash common.Hash) (*gethTypes.Header, error) {
+	return e.db.Eth1HeaderByHash(ctx, hash)
+}
+
+// Eth1HeaderByNumber -- passthrough
+func (e Exporter) Eth1HeaderByNumber(ctx context.Context, number *big.Int) (*gethTypes.Header, error) {
+	return e.db.Eth1HeaderByNumber(ctx, number)
+}
+
+// Eth1HeaderByTimestamp -- passthrough
+func (e Exporter) Eth1HeaderByTimestamp(ctx context.Context, time uint64) (*gethTypes.Header, error) {
+	return e.db.Eth1HeaderByTimestamp(ctx, time)
+}
+
+// SaveEth1Header -- passthrough
+func (e Exporter) SaveEth1Header(ctx context.Context, header *gethTypes.Header) error {
+	return e.db.SaveEth1Header(ctx, header)
+}
+
+// SaveEth1Headers -- passthrough
+func (e Exporter) SaveEth1Headers(ctx context.Context, headers []*gethTypes.Header) error {
+	return e.db.SaveEth1Headers(ctx, headers)
+}
+
 // SaveArchivedPointState -- passthrough
 func (e Exporter) SaveArchivedPointState(ctx context.Context, state *state.BeaconState, index uint64) error {
 	return e.db.SaveArchivedPointState(ctx, state, index)
@@ -352,3 +395,13 @@ func (e Exporter) ArchivedPointRoot(ctx context.Context, index uint64) [32]byte
 func (e Exporter) HasArchivedPoint(ctx context.Context, index uint64) bool {
 	return e.db.HasArchivedPoint(ctx, index)
 }
+
+// ValidatorRewards -- passthrough
+func (e Exporter) ValidatorRewards(ctx context.Context, validatorIdx uint64, startEpoch uint64, endEpoch uint64) (map[uint64]*precompute.Rewards, error) {
+	return e.db.ValidatorRewards(ctx, validatorIdx, startEpoch, endEpoch)
+}
+
+// SaveValidatorRewards -- passthrough
+func (e Exporter) SaveValidatorRewards(ctx context.Context, epoch uint64, rewards []*precompute.Rewards) error {
+	return e.db.SaveValidatorRewards(ctx, epoch, rewards)
+}
@@ -220,6 +220,9 @@ func (k *Store) DeleteBlock(ctx context.Context, blockRoot [32]byte) error {
 		if err := deleteValueForIndices(indicesByBucket, blockRoot[:], tx); err != nil {
 			return errors.Wrap(err, "could not delete root for DB indices")
 		}
+		if err := deleteCanonicalBlockRoot(tx, blockRoot[:]); err != nil {
+			return errors.Wrap(err, "could not delete root from canonical index")
+		}
 		k.blockCache.Del(string(blockRoot[:]))
 		return bkt.Delete(blockRoot[:])
 	})
@@ -245,6 +248,9 @@ func (k *Store) DeleteBlocks(ctx context.Context, blockRoots [][32]byte) error {
 			if err := deleteValueForIndices(indicesByBucket, blockRoot[:], tx); err != nil {
 				return errors.Wrap(err, "could not delete root for DB indices")
 			}
+			if err := deleteCanonicalBlockRoot(tx, blockRoot[:]); err != nil {
+				return errors.Wrap(err, "could not delete root from canonical index")
+			}
 			k.blockCache.Del(string(blockRoot[:]))
 			if err := bkt.Delete(blockRoot[:]); err != nil {
 				return err
@@ -324,7 +330,10 @@ func (k *Store) SaveHeadBlockRoot(ctx context.Context, blockRoot [32]byte) error
 			return errors.New("no state found with head block root")
 		}
 		bucket := tx.Bucket(blocksBucket)
-		return bucket.Put(headBlockRootKey, blockRoot[:])
+		if err := bucket.Put(headBlockRootKey, blockRoot[:]); err != nil {
+			return err
+		}
+		return k.updateCanonicalBlockRoots(ctx, tx, blockRoot)
 	})
 }
 
@@ -0,0 +1,146 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+
+	"github.com/boltdb/bolt"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/traceutil"
+	"go.opencensus.io/trace"
+)
+
+// canonicalBlock is a block root of the canonical chain along with its slot.
+type canonicalBlock struct {
+	root []byte
+	slot uint64
+}
+
+// The canonical block roots index tracks the beacon blocks of the chain ending at the head block
+// root. It maps each canonical block root to its slot, and each slot to its canonical block root
+// so that the blocks of the previous chain can be de-indexed when the head switches to a fork.
+//
+// The index is updated along with the head block root by walking up the ancestry chain from the
+// new head until a block root already present in the index is found, which is the common
+// ancestor of the previous and the new canonical chains. The blocks of the previous chain after
+// the common ancestor are de-indexed, and the blocks of the new chain are indexed. Walking stops
+// at the genesis block root, or at a block missing from the database.
+func (k *Store) updateCanonicalBlockRoots(ctx context.Context, tx *bolt.Tx, headRoot [32]byte) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.updateCanonicalBlockRoots")
+	defer span.End()
+
+	rootsBkt := tx.Bucket(canonicalBlockRootsIndexBucket)
+	slotsBkt := tx.Bucket(canonicalSlotsIndexBucket)
+	blocksBkt := tx.Bucket(blocksBucket)
+	genesisRoot := blocksBkt.Get(genesisBlockRootKey)
+
+	var chain []*canonicalBlock
+	var pruneFrom uint64
+	foundAncestor := false
+	root := headRoot[:]
+	for {
+		if enc := rootsBkt.Get(root); enc != nil {
+			pruneFrom = binary.BigEndian.Uint64(enc) + 1
+			foundAncestor = true
+			break
+		}
+		enc := blocksBkt.Get(root)
+		if enc == nil {
+			break
+		}
+		signed := &ethpb.SignedBeaconBlock{}
+		if err := decode(enc, signed); err != nil {
+			traceutil.AnnotateError(span, err)
+			return err
+		}
+		if signed.Block == nil {
+			break
+		}
+		chain = append(chain, &canonicalBlock{root: root, slot: signed.Block.Slot})
+		if bytes.Equal(root, genesisRoot) {
+			break
+		}
+		root = signed.Block.ParentRoot
+	}
+	if !foundAncestor {
+		if len(chain) == 0 {
+			return nil
+		}
+		pruneFrom = chain[len(chain)-1].slot
+	}
+
+	// De-index the blocks of the previous canonical chain after the common ancestor.
+	var staleSlots, staleRoots [][]byte
+	c := slotsBkt.Cursor()
+	for k, v := c.Seek(canonicalSlotKey(pruneFrom)); k != nil; k, v = c.Next() {
+		staleSlots = append(staleSlots, append([]byte{}, k...))
+		staleRoots = append(staleRoots, append([]byte{}, v...))
+	}
+	for i := range staleSlots {
+		if err := slotsBkt.Delete(staleSlots[i]); err != nil {
+			traceutil.AnnotateError(span, err)
+			return err
+		}
+		if err := rootsBkt.Delete(staleRoots[i]); err != nil {
+			traceutil.AnnotateError(span, err)
+			return err
+		}
+	}
+
+	if err := k.updateOrphanedBlocks(ctx, tx, staleRoots, chain); err != nil {
+		traceutil.AnnotateError(span, err)
+		return err
+	}
+
+	for _, blk := range chain {
+		key := canonicalSlotKey(blk.slot)
+		if err := slotsBkt.Put(key, blk.root); err != nil {
+			traceutil.AnnotateError(span, err)
+			return err
+		}
+		if err := rootsBkt.Put(blk.root, key); err != nil {
+			traceutil.AnnotateError(span, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteCanonicalBlockRoot removes a block root from the canonical block roots index.
+func deleteCanonicalBlockRoot(tx *bolt.Tx, blockRoot []byte) error {
+	rootsBkt := tx.Bucket(canonicalBlockRootsIndexBucket)
+	key := rootsBkt.Get(blockRoot)
+	if key == nil {
+		return nil
+	}
+	if err := tx.Bucket(canonicalSlotsIndexBucket).Delete(key); err != nil {
+		return err
+	}
+	return rootsBkt.Delete(blockRoot)
+}
+
+// IsCanonical returns true if the block root is part of the canonical chain ending at the head
+// block root, as opposed to an orphaned fork, without walking the parents of the block.
+func (k *Store) IsCanonical(ctx context.Context, blockRoot [32]byte) bool {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.IsCanonical")
+	defer span.End()
+
+	var exists bool
+	err := k.db.View(func(tx *bolt.Tx) error {
+		exists = tx.Bucket(canonicalBlockRootsIndexBucket).Get(blockRoot[:]) != nil
+		return nil
+	})
+	if err != nil {
+		traceutil.AnnotateError(span, err)
+	}
+	return exists
+}
+
+// canonicalSlotKey encodes a slot as a big-endian key, so that the keys of the canonical slots
+// index are sorted by slot.
+func canonicalSlotKey(slot uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, slot)
+	return key
+}
@@ -0,0 +1,87 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+func TestStore_IsCanonical(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	if err := db.SaveGenesisBlockRoot(ctx, genesisBlockRoot); err != nil {
+		t.Fatal(err)
+	}
+	// Fork B branches off the canonical chain A after the block at slot 4.
+	chainA := makeBlocks(t, 0, 8, genesisBlockRoot)
+	forkRoot, err := ssz.HashTreeRoot(chainA[3].Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainB := makeBlocks(t, 5, 4, forkRoot)
+	if err := db.SaveBlocks(ctx, append(chainA, chainB...)); err != nil {
+		t.Fatal(err)
+	}
+	rootsA := blockRoots(t, chainA)
+	rootsB := blockRoots(t, chainB)
+
+	saveHead := func(root [32]byte) {
+		st, err := state.InitializeFromProto(&pb.BeaconState{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := db.SaveState(ctx, st, root); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.SaveHeadBlockRoot(ctx, root); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkCanonical := func(roots [][32]byte, canonical bool) {
+		for i, root := range roots {
+			if db.IsCanonical(ctx, root) != canonical {
+				t.Errorf("Wanted block %d with root %#x to be canonical: %v", i, root, canonical)
+			}
+		}
+	}
+
+	saveHead(rootsA[len(rootsA)-1])
+	checkCanonical(rootsA, true)
+	checkCanonical(rootsB, false)
+
+	// The head switches to fork B, orphaning the blocks of chain A after the fork.
+	saveHead(rootsB[len(rootsB)-1])
+	checkCanonical(rootsA[:4], true)
+	checkCanonical(rootsA[4:], false)
+	checkCanonical(rootsB, true)
+
+	// The head switches back to an ancestor of chain A.
+	saveHead(rootsA[5])
+	checkCanonical(rootsA[:6], true)
+	checkCanonical(rootsA[6:], false)
+	checkCanonical(rootsB, false)
+
+	// Deleted blocks are removed from the index.
+	if err := db.DeleteBlock(ctx, rootsA[5]); err != nil {
+		t.Fatal(err)
+	}
+	checkCanonical(rootsA[5:6], false)
+}
+
+func blockRoots(t *testing.T, blks []*ethpb.SignedBeaconBlock) [][32]byte {
+	roots := make([][32]byte, len(blks))
+	for i, b := range blks {
+		root, err := ssz.HashTreeRoot(b.Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		roots[i] = root
+	}
+	return roots
+}
@@ -0,0 +1,160 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math/big"
+
+	"github.com/boltdb/bolt"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"go.opencensus.io/trace"
+)
+
+// SaveEth1Header saves an eth1 block header, indexed by its hash, number and timestamp.
+func (k *Store) SaveEth1Header(ctx context.Context, header *gethTypes.Header) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveEth1Header")
+	defer span.End()
+
+	return k.SaveEth1Headers(ctx, []*gethTypes.Header{header})
+}
+
+// SaveEth1Headers saves a list of eth1 block headers in a single transaction. A header saved
+// at an existing number replaces the previous header in the number index.
+func (k *Store) SaveEth1Headers(ctx context.Context, headers []*gethTypes.Header) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveEth1Headers")
+	defer span.End()
+
+	return k.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(eth1HeadersBucket)
+		numberBkt := tx.Bucket(eth1HeaderNumberIndicesBucket)
+		timeBkt := tx.Bucket(eth1HeaderTimeIndicesBucket)
+		for _, header := range headers {
+			if header == nil || header.Number == nil {
+				continue
+			}
+			enc, err := rlp.EncodeToBytes(header)
+			if err != nil {
+				return err
+			}
+			hash := header.Hash()
+			number := header.Number.Uint64()
+			// Drop the timestamp index of a header being replaced at the same number.
+			if prevHash := numberBkt.Get(eth1NumberKey(number)); prevHash != nil && !bytes.Equal(prevHash, hash.Bytes()) {
+				prev, err := eth1HeaderFromBucket(bkt, prevHash)
+				if err != nil {
+					return err
+				}
+				if prev != nil {
+					if err := timeBkt.Delete(eth1TimeKey(prev.Time, number)); err != nil {
+						return err
+					}
+				}
+			}
+			if err := bkt.Put(hash.Bytes(), enc); err != nil {
+				return err
+			}
+			if err := numberBkt.Put(eth1NumberKey(number), hash.Bytes()); err != nil {
+				return err
+			}
+			if err := timeBkt.Put(eth1TimeKey(header.Time, number), hash.Bytes()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Eth1HeaderByHash retrieves an eth1 block header by its hash. Returns nil if the header
+// has not been saved.
+func (k *Store) Eth1HeaderByHash(ctx context.Context, hash common.Hash) (*gethTypes.Header, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.Eth1HeaderByHash")
+	defer span.End()
+
+	var header *gethTypes.Header
+	err := k.db.View(func(tx *bolt.Tx) error {
+		var err error
+		header, err = eth1HeaderFromBucket(tx.Bucket(eth1HeadersBucket), hash.Bytes())
+		return err
+	})
+	return header, err
+}
+
+// Eth1HeaderByNumber retrieves the eth1 block header saved at the given block number.
+// Returns nil if no header has been saved at that number.
+func (k *Store) Eth1HeaderByNumber(ctx context.Context, number *big.Int) (*gethTypes.Header, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.Eth1HeaderByNumber")
+	defer span.End()
+
+	var header *gethTypes.Header
+	err := k.db.View(func(tx *bolt.Tx) error {
+		hash := tx.Bucket(eth1HeaderNumberIndicesBucket).Get(eth1NumberKey(number.Uint64()))
+		if hash == nil {
+			return nil
+		}
+		var err error
+		header, err = eth1HeaderFromBucket(tx.Bucket(eth1HeadersBucket), hash)
+		return err
+	})
+	return header, err
+}
+
+// Eth1HeaderByTimestamp retrieves the saved eth1 block header with the highest timestamp
+// that is less than or equal to the given time. Returns nil if there is no such header.
+// Saved headers are not required to be contiguous, so callers must check that the next
+// block is after the given time before treating the result as the chain's latest block.
+func (k *Store) Eth1HeaderByTimestamp(ctx context.Context, time uint64) (*gethTypes.Header, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.Eth1HeaderByTimestamp")
+	defer span.End()
+
+	var header *gethTypes.Header
+	err := k.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(eth1HeaderTimeIndicesBucket).Cursor()
+		var hash []byte
+		if time == ^uint64(0) {
+			_, hash = c.Last()
+		} else if key, _ := c.Seek(eth1TimeKey(time+1, 0)); key == nil {
+			_, hash = c.Last()
+		} else {
+			_, hash = c.Prev()
+		}
+		if hash == nil {
+			return nil
+		}
+		var err error
+		header, err = eth1HeaderFromBucket(tx.Bucket(eth1HeadersBucket), hash)
+		return err
+	})
+	return header, err
+}
+
+func eth1HeaderFromBucket(bkt *bolt.Bucket, hash []byte) (*gethTypes.Header, error) {
+	enc := bkt.Get(hash)
+	if enc == nil {
+		return nil, nil
+	}
+	header := &gethTypes.Header{}
+	if err := rlp.DecodeBytes(enc, header); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// eth1NumberKey encodes a block number as a big-endian key, so that the number index
+// is ordered by block number.
+func eth1NumberKey(number uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, number)
+	return key
+}
+
+// eth1TimeKey encodes a block timestamp followed by its number as a big-endian key, so that
+// the timestamp index is ordered by time and blocks sharing a timestamp remain distinct.
+func eth1TimeKey(time uint64, number uint64) []byte {
+	key := make([]byte, 16)
+	binary.BigEndian.PutUint64(key[:8], time)
+	binary.BigEndian.PutUint64(key[8:], number)
+	return key
+}
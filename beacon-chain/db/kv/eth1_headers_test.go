@@ -0,0 +1,128 @@
+package kv
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestStore_Eth1Headers_CRUD(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	header := &gethTypes.Header{
+		ParentHash: common.HexToHash("0x1234"),
+		Number:     big.NewInt(10),
+		Time:       100,
+	}
+	retrieved, err := db.Eth1HeaderByHash(ctx, header.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retrieved != nil {
+		t.Errorf("Expected nil header, received %v", retrieved)
+	}
+	if err := db.SaveEth1Header(ctx, header); err != nil {
+		t.Fatal(err)
+	}
+	retrieved, err = db.Eth1HeaderByHash(ctx, header.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retrieved == nil || retrieved.Hash() != header.Hash() {
+		t.Errorf("Wanted header %#x, received %v", header.Hash(), retrieved)
+	}
+	retrieved, err = db.Eth1HeaderByNumber(ctx, big.NewInt(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retrieved == nil || retrieved.Hash() != header.Hash() {
+		t.Errorf("Wanted header %#x, received %v", header.Hash(), retrieved)
+	}
+	retrieved, err = db.Eth1HeaderByNumber(ctx, big.NewInt(11))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retrieved != nil {
+		t.Errorf("Expected nil header, received %v", retrieved)
+	}
+}
+
+func TestStore_Eth1HeaderByTimestamp(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	headers := make([]*gethTypes.Header, 5)
+	for i := range headers {
+		headers[i] = &gethTypes.Header{
+			Number: big.NewInt(int64(i)),
+			Time:   uint64(100 + 10*i),
+		}
+	}
+	if err := db.SaveEth1Headers(ctx, headers); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		time   uint64
+		number int64
+	}{
+		{time: 100, number: 0},
+		{time: 115, number: 1},
+		{time: 120, number: 2},
+		{time: 1000, number: 4},
+		{time: ^uint64(0), number: 4},
+	}
+	for _, tt := range tests {
+		retrieved, err := db.Eth1HeaderByTimestamp(ctx, tt.time)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if retrieved == nil || retrieved.Number.Int64() != tt.number {
+			t.Errorf("Wanted header %d for time %d, received %v", tt.number, tt.time, retrieved)
+		}
+	}
+
+	retrieved, err := db.Eth1HeaderByTimestamp(ctx, 99)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retrieved != nil {
+		t.Errorf("Expected nil header before the earliest timestamp, received %v", retrieved)
+	}
+}
+
+func TestStore_SaveEth1Headers_ReplacesReorgedHeader(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	original := &gethTypes.Header{Number: big.NewInt(3), Time: 130}
+	replacement := &gethTypes.Header{Number: big.NewInt(3), Time: 135, Extra: []byte("reorg")}
+	if err := db.SaveEth1Header(ctx, original); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveEth1Header(ctx, replacement); err != nil {
+		t.Fatal(err)
+	}
+
+	retrieved, err := db.Eth1HeaderByNumber(ctx, big.NewInt(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retrieved == nil || retrieved.Hash() != replacement.Hash() {
+		t.Errorf("Wanted header %#x, received %v", replacement.Hash(), retrieved)
+	}
+	retrieved, err = db.Eth1HeaderByTimestamp(ctx, 132)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retrieved != nil {
+		t.Errorf("Expected the replaced header to be removed from the timestamp index, received %v", retrieved)
+	}
+}
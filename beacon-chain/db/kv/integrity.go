@@ -0,0 +1,249 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/boltdb/bolt"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// integrityReport counts the entries checked and repaired by an integrity check.
+type integrityReport struct {
+	blocksChecked     int
+	statesChecked     int
+	quarantinedBlocks int
+	quarantinedStates int
+	missingParents    int
+	repairedIndices   int
+}
+
+// storedBlock is the part of a stored block the integrity check needs.
+type storedBlock struct {
+	slot       uint64
+	parentRoot []byte
+	stateRoot  []byte
+}
+
+// CheckIntegrity verifies the stored blocks against their roots and the linkage to their
+// parents, the stored states against the state roots of their blocks, and the block slot and
+// parent root index buckets. Corrupt blocks and states are moved to quarantine buckets, and
+// inconsistent index entries are rebuilt from the blocks, so that they are not hit later during
+// sync. An error is returned if the head or genesis state is corrupt, as the node cannot start
+// without them.
+func (k *Store) CheckIntegrity(ctx context.Context) error {
+	report, err := k.checkIntegrity(ctx)
+	if err != nil {
+		return err
+	}
+	logrus.WithField("prefix", "db").WithFields(logrus.Fields{
+		"blocksChecked":     report.blocksChecked,
+		"statesChecked":     report.statesChecked,
+		"quarantinedBlocks": report.quarantinedBlocks,
+		"quarantinedStates": report.quarantinedStates,
+		"missingParents":    report.missingParents,
+		"repairedIndices":   report.repairedIndices,
+	}).Info("Checked database integrity")
+	return nil
+}
+
+func (k *Store) checkIntegrity(ctx context.Context) (*integrityReport, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.checkIntegrity")
+	defer span.End()
+
+	report := &integrityReport{}
+	err := k.db.Update(func(tx *bolt.Tx) error {
+		blocks, err := k.checkBlocks(tx, report)
+		if err != nil {
+			return err
+		}
+		if err := checkStates(tx, blocks, report); err != nil {
+			return err
+		}
+		return repairBlockIndices(tx, blocks, report)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// checkBlocks quarantines the blocks which cannot be decoded or whose root differs from their
+// key, and returns the remaining blocks by root.
+func (k *Store) checkBlocks(tx *bolt.Tx, report *integrityReport) (map[[32]byte]*storedBlock, error) {
+	blocks := make(map[[32]byte]*storedBlock)
+	var corrupt [][]byte
+	if err := tx.Bucket(blocksBucket).ForEach(func(key, enc []byte) error {
+		report.blocksChecked++
+		signed := &ethpb.SignedBeaconBlock{}
+		if err := decode(enc, signed); err != nil || signed.Block == nil {
+			corrupt = append(corrupt, append([]byte{}, key...))
+			return nil
+		}
+		root, err := ssz.HashTreeRoot(signed.Block)
+		if err != nil || !bytes.Equal(root[:], key) {
+			corrupt = append(corrupt, append([]byte{}, key...))
+			return nil
+		}
+		blocks[root] = &storedBlock{
+			slot:       signed.Block.Slot,
+			parentRoot: signed.Block.ParentRoot,
+			stateRoot:  signed.Block.StateRoot,
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, key := range corrupt {
+		logrus.WithField("prefix", "db").WithField("blockRoot", bytesutil.Trunc(key)).Warn("Quarantining corrupt block")
+		if err := quarantine(tx, blocksBucket, quarantinedBlocksBucket, key); err != nil {
+			return nil, err
+		}
+		if err := deleteCanonicalBlockRoot(tx, key); err != nil {
+			return nil, err
+		}
+		k.blockCache.Del(string(key))
+		report.quarantinedBlocks++
+	}
+
+	for root, blk := range blocks {
+		if blk.slot == 0 {
+			continue
+		}
+		if _, ok := blocks[bytesutil.ToBytes32(blk.parentRoot)]; !ok {
+			logrus.WithField("prefix", "db").WithFields(logrus.Fields{
+				"blockRoot":  bytesutil.Trunc(root[:]),
+				"parentRoot": bytesutil.Trunc(blk.parentRoot),
+				"slot":       blk.slot,
+			}).Debug("Block parent is not in the database")
+			report.missingParents++
+		}
+	}
+	return blocks, nil
+}
+
+// checkStates quarantines the states which cannot be decoded, or whose root differs from the
+// state root of their block when they are at the slot of the block. States advanced past the
+// slot of their block, such as archived points, cannot be checked against the block.
+func checkStates(tx *bolt.Tx, blocks map[[32]byte]*storedBlock, report *integrityReport) error {
+	metadata := tx.Bucket(chainMetadataBucket)
+	headRoot := metadata.Get(headBlockRootKey)
+	genesisRoot := metadata.Get(genesisBlockRootKey)
+
+	var corrupt [][]byte
+	if err := tx.Bucket(stateBucket).ForEach(func(key, enc []byte) error {
+		report.statesChecked++
+		protoState, err := createState(enc)
+		if err != nil {
+			corrupt = append(corrupt, append([]byte{}, key...))
+			return nil
+		}
+		blk, ok := blocks[bytesutil.ToBytes32(key)]
+		if !ok || blk.slot != protoState.Slot {
+			return nil
+		}
+		st, err := state.InitializeFromProtoUnsafe(protoState)
+		if err != nil {
+			corrupt = append(corrupt, append([]byte{}, key...))
+			return nil
+		}
+		root, err := st.HashTreeRoot()
+		if err != nil || !bytes.Equal(root[:], blk.stateRoot) {
+			corrupt = append(corrupt, append([]byte{}, key...))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, key := range corrupt {
+		if bytes.Equal(key, headRoot) || bytes.Equal(key, genesisRoot) {
+			return errors.Errorf("state of block %#x is corrupt and required to start, the database must be resynced", key)
+		}
+		logrus.WithField("prefix", "db").WithField("blockRoot", bytesutil.Trunc(key)).Warn("Quarantining corrupt state")
+		if err := quarantine(tx, stateBucket, quarantinedStatesBucket, key); err != nil {
+			return err
+		}
+		report.quarantinedStates++
+	}
+	return nil
+}
+
+// repairBlockIndices rebuilds the entries of the block slot and parent root index buckets which
+// differ from the indices of the stored blocks.
+func repairBlockIndices(tx *bolt.Tx, blocks map[[32]byte]*storedBlock, report *integrityReport) error {
+	expected := map[string]map[string][]byte{
+		string(blockSlotIndicesBucket):       make(map[string][]byte),
+		string(blockParentRootIndicesBucket): make(map[string][]byte),
+	}
+	for root, blk := range blocks {
+		indices := createBlockIndicesFromBlock(&ethpb.BeaconBlock{Slot: blk.slot, ParentRoot: blk.parentRoot})
+		for bkt, idx := range indices {
+			expected[bkt][string(idx)] = append(expected[bkt][string(idx)], root[:]...)
+		}
+	}
+
+	for bktName, want := range expected {
+		bkt := tx.Bucket([]byte(bktName))
+		var stale [][]byte
+		if err := bkt.ForEach(func(idx, roots []byte) error {
+			if _, ok := want[string(idx)]; !ok {
+				stale = append(stale, append([]byte{}, idx...))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, idx := range stale {
+			if err := bkt.Delete(idx); err != nil {
+				return err
+			}
+			report.repairedIndices++
+		}
+		for idx, roots := range want {
+			if sameRoots(bkt.Get([]byte(idx)), roots) {
+				continue
+			}
+			if err := bkt.Put([]byte(idx), roots); err != nil {
+				return err
+			}
+			report.repairedIndices++
+		}
+	}
+	return nil
+}
+
+// quarantine moves the value of the key from the bucket to the quarantine bucket.
+func quarantine(tx *bolt.Tx, from []byte, to []byte, key []byte) error {
+	bkt := tx.Bucket(from)
+	if err := tx.Bucket(to).Put(key, bkt.Get(key)); err != nil {
+		return err
+	}
+	return bkt.Delete(key)
+}
+
+// sameRoots returns true if both concatenations of 32 byte roots hold the same roots,
+// regardless of their order.
+func sameRoots(a []byte, b []byte) bool {
+	if len(a) != len(b) || len(a)%32 != 0 {
+		return false
+	}
+	roots := make(map[string]int)
+	for i := 0; i < len(a); i += 32 {
+		roots[string(a[i:i+32])]++
+	}
+	for i := 0; i < len(b); i += 32 {
+		if roots[string(b[i:i+32])] == 0 {
+			return false
+		}
+		roots[string(b[i:i+32])]--
+	}
+	return true
+}
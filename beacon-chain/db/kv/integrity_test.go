@@ -0,0 +1,131 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/boltdb/bolt"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+func TestStore_CheckIntegrity(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	blks := makeBlocks(t, 0, 4, genesisBlockRoot)
+	// The state of the block at slot 3 is consistent, the state of the block at slot 4 is not.
+	goodState, err := state.InitializeFromProto(&pb.BeaconState{Slot: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	goodRoot, err := goodState.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	blks[2].Block.StateRoot = goodRoot[:]
+	badState, err := state.InitializeFromProto(&pb.BeaconState{Slot: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	blks[3].Block.StateRoot = goodRoot[:]
+	if err := db.SaveBlocks(ctx, blks); err != nil {
+		t.Fatal(err)
+	}
+	roots := blockRoots(t, blks)
+	if err := db.SaveState(ctx, goodState, roots[2]); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, badState, roots[3]); err != nil {
+		t.Fatal(err)
+	}
+
+	// Corrupt the block at slot 2, and add a stale slot index entry.
+	if err := db.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(blocksBucket).Put(roots[1][:], []byte("corrupt")); err != nil {
+			return err
+		}
+		return tx.Bucket(blockSlotIndicesBucket).Put([]byte("0000099"), roots[0][:])
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := db.checkIntegrity(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.blocksChecked != 4 || report.statesChecked != 2 {
+		t.Errorf("Wanted 4 blocks and 2 states checked, received %d and %d", report.blocksChecked, report.statesChecked)
+	}
+	if report.quarantinedBlocks != 1 || report.quarantinedStates != 1 {
+		t.Errorf("Wanted 1 block and 1 state quarantined, received %d and %d", report.quarantinedBlocks, report.quarantinedStates)
+	}
+	// The first block has no stored parent, and the block at slot 3 lost its parent.
+	if report.missingParents != 2 {
+		t.Errorf("Wanted 2 missing parents, received %d", report.missingParents)
+	}
+	// The stale slot index, the slot index and the parent root index of the corrupt block.
+	if report.repairedIndices != 3 {
+		t.Errorf("Wanted 3 repaired indices, received %d", report.repairedIndices)
+	}
+
+	if db.HasBlock(ctx, roots[1]) {
+		t.Error("Expected the corrupt block to be removed")
+	}
+	if !db.HasState(ctx, roots[2]) || db.HasState(ctx, roots[3]) {
+		t.Error("Expected only the inconsistent state to be removed")
+	}
+	if err := db.db.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(quarantinedBlocksBucket).Get(roots[1][:]) == nil {
+			t.Error("Expected the corrupt block in quarantine")
+		}
+		if tx.Bucket(quarantinedStatesBucket).Get(roots[3][:]) == nil {
+			t.Error("Expected the inconsistent state in quarantine")
+		}
+		if tx.Bucket(blockSlotIndicesBucket).Get([]byte("0000099")) != nil {
+			t.Error("Expected the stale slot index to be removed")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A second check finds nothing to repair.
+	report, err = db.checkIntegrity(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.quarantinedBlocks != 0 || report.quarantinedStates != 0 || report.repairedIndices != 0 {
+		t.Errorf("Wanted nothing repaired on the second check, received %+v", report)
+	}
+}
+
+func TestStore_CheckIntegrity_CorruptHeadState(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	blks := makeBlocks(t, 0, 1, genesisBlockRoot)
+	blks[0].Block.StateRoot = make([]byte, 32)
+	if err := db.SaveBlocks(ctx, blks); err != nil {
+		t.Fatal(err)
+	}
+	root := blockRoots(t, blks)[0]
+	st, err := state.InitializeFromProto(&pb.BeaconState{Slot: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, st, root); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveHeadBlockRoot(ctx, root); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.CheckIntegrity(ctx); err == nil {
+		t.Error("Expected an error for a corrupt head state")
+	}
+	if !db.HasState(ctx, root) {
+		t.Error("Expected the head state to be kept")
+	}
+}
@@ -1,6 +1,7 @@
 package kv
 
 import (
+	"context"
 	"os"
 	"path"
 	"time"
@@ -31,10 +32,11 @@ var BlockCacheSize = int64(1 << 21)
 // Store defines an implementation of the Prysm Database interface
 // using BoltDB as the underlying persistent kv-store for eth2.
 type Store struct {
-	db                  *bolt.DB
+	db                  *instrumentedDB
 	databasePath        string
 	blockCache          *ristretto.Cache
 	validatorIndexCache *ristretto.Cache
+	stopMetrics         context.CancelFunc
 }
 
 // NewKVStore initializes a new boltDB key-value store at the directory
@@ -72,7 +74,7 @@ func NewKVStore(dirPath string) (*Store, error) {
 	}
 
 	kv := &Store{
-		db:                  boltDB,
+		db:                  &instrumentedDB{boltDB},
 		databasePath:        dirPath,
 		blockCache:          blockCache,
 		validatorIndexCache: validatorCache,
@@ -98,6 +100,11 @@ func NewKVStore(dirPath string) (*Store, error) {
 			stateSummaryBucket,
 			archivedIndexRootBucket,
 			archivedIndexStateBucket,
+			validatorRewardsBucket,
+			eth1HeadersBucket,
+			orphanedBlocksBucket,
+			quarantinedBlocksBucket,
+			quarantinedStatesBucket,
 			// Indices buckets.
 			attestationHeadBlockRootBucket,
 			attestationSourceRootIndicesBucket,
@@ -107,6 +114,11 @@ func NewKVStore(dirPath string) (*Store, error) {
 			blockSlotIndicesBucket,
 			blockParentRootIndicesBucket,
 			finalizedBlockRootsIndexBucket,
+			canonicalBlockRootsIndexBucket,
+			canonicalSlotsIndexBucket,
+			eth1HeaderNumberIndicesBucket,
+			eth1HeaderTimeIndicesBucket,
+			orphanedBlockSlotIndicesBucket,
 			// Migration bucket.
 			migrationBucket,
 		)
@@ -114,7 +126,11 @@ func NewKVStore(dirPath string) (*Store, error) {
 		return nil, err
 	}
 
-	err = prometheus.Register(createBoltCollector(kv.db))
+	err = prometheus.Register(createBoltCollector(kv.db.DB))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	kv.stopMetrics = cancel
+	go kv.runBucketMetrics(ctx)
 
 	return kv, err
 }
@@ -124,13 +140,14 @@ func (k *Store) ClearDB() error {
 	if _, err := os.Stat(k.databasePath); os.IsNotExist(err) {
 		return nil
 	}
-	prometheus.Unregister(createBoltCollector(k.db))
+	prometheus.Unregister(createBoltCollector(k.db.DB))
 	return os.Remove(path.Join(k.databasePath, databaseFileName))
 }
 
 // Close closes the underlying BoltDB database.
 func (k *Store) Close() error {
-	prometheus.Unregister(createBoltCollector(k.db))
+	k.stopMetrics()
+	prometheus.Unregister(createBoltCollector(k.db.DB))
 	return k.db.Close()
 }
 
@@ -0,0 +1,114 @@
+package kv
+
+import (
+	"context"
+	"os"
+	"path"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+)
+
+// bucketMetricsInterval is the interval between two collections of the bucket metrics. Reading
+// the stats of a bucket walks all of its pages, so this is kept well above the scrape interval.
+const bucketMetricsInterval = 5 * time.Minute
+
+var (
+	transactionLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "beacondb_transaction_seconds",
+			Help:    "Time taken by read and write transactions of the beacon database, including the commit of writes.",
+			Buckets: []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5},
+		},
+		[]string{"type"},
+	)
+	bucketKeys = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "beacondb_bucket_keys",
+			Help: "Number of keys in a bucket of the beacon database.",
+		},
+		[]string{"bucket"},
+	)
+	bucketBytes = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "beacondb_bucket_bytes",
+			Help: "Bytes used by the keys and values of a bucket of the beacon database.",
+		},
+		[]string{"bucket"},
+	)
+	bucketAllocatedBytes = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "beacondb_bucket_allocated_bytes",
+			Help: "Bytes of the pages allocated to a bucket of the beacon database.",
+		},
+		[]string{"bucket"},
+	)
+	fileSizeBytes = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "beacondb_file_size_bytes",
+		Help: "Size of the beacon database file.",
+	})
+	freeBytes = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "beacondb_free_bytes",
+		Help: "Bytes of the free and pending pages of the beacon database file, which would be reclaimed by compacting it.",
+	})
+)
+
+// instrumentedDB records the latency of the transactions of the underlying BoltDB database.
+type instrumentedDB struct {
+	*bolt.DB
+}
+
+// View executes a read-only transaction and records its latency.
+func (db *instrumentedDB) View(fn func(*bolt.Tx) error) error {
+	start := time.Now()
+	defer func() {
+		transactionLatency.WithLabelValues("read").Observe(time.Since(start).Seconds())
+	}()
+	return db.DB.View(fn)
+}
+
+// Update executes a read-write transaction and records its latency.
+func (db *instrumentedDB) Update(fn func(*bolt.Tx) error) error {
+	start := time.Now()
+	defer func() {
+		transactionLatency.WithLabelValues("write").Observe(time.Since(start).Seconds())
+	}()
+	return db.DB.Update(fn)
+}
+
+// runBucketMetrics periodically collects the size of every bucket and of the database file,
+// so disk growth can be attributed to blocks, states or attestations.
+func (k *Store) runBucketMetrics(ctx context.Context) {
+	ticker := time.NewTicker(bucketMetricsInterval)
+	defer ticker.Stop()
+	for {
+		if err := k.collectBucketMetrics(); err != nil {
+			logrus.WithField("prefix", "db").WithError(err).Debug("Could not collect bucket metrics")
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (k *Store) collectBucketMetrics() error {
+	if info, err := os.Stat(path.Join(k.databasePath, databaseFileName)); err == nil {
+		fileSizeBytes.Set(float64(info.Size()))
+	}
+	return k.db.DB.View(func(tx *bolt.Tx) error {
+		stats := k.db.Stats()
+		freeBytes.Set(float64((stats.FreePageN + stats.PendingPageN) * tx.DB().Info().PageSize))
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			bucketStats := b.Stats()
+			bucketKeys.WithLabelValues(string(name)).Set(float64(bucketStats.KeyN))
+			bucketBytes.WithLabelValues(string(name)).Set(float64(bucketStats.BranchInuse + bucketStats.LeafInuse))
+			bucketAllocatedBytes.WithLabelValues(string(name)).Set(float64(bucketStats.BranchAlloc + bucketStats.LeafAlloc))
+			return nil
+		})
+	})
+}
@@ -0,0 +1,45 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+func TestStore_CollectBucketMetrics(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+	blocks := []*ethpb.SignedBeaconBlock{
+		{Block: &ethpb.BeaconBlock{Slot: 1}},
+		{Block: &ethpb.BeaconBlock{Slot: 2}},
+	}
+	if err := db.SaveBlocks(ctx, blocks); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.collectBucketMetrics(); err != nil {
+		t.Fatal(err)
+	}
+
+	metric := &dto.Metric{}
+	if err := bucketKeys.WithLabelValues(string(blocksBucket)).Write(metric); err != nil {
+		t.Fatal(err)
+	}
+	if metric.GetGauge().GetValue() != float64(len(blocks)) {
+		t.Errorf("Wanted %d keys in blocks bucket, received %v", len(blocks), metric.GetGauge().GetValue())
+	}
+	if err := bucketBytes.WithLabelValues(string(blocksBucket)).Write(metric); err != nil {
+		t.Fatal(err)
+	}
+	if metric.GetGauge().GetValue() == 0 {
+		t.Error("Expected bytes of blocks bucket to be collected")
+	}
+	if err := fileSizeBytes.Write(metric); err != nil {
+		t.Fatal(err)
+	}
+	if metric.GetGauge().GetValue() == 0 {
+		t.Error("Expected size of database file to be collected")
+	}
+}
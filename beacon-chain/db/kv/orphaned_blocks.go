@@ -0,0 +1,150 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+
+	"github.com/boltdb/bolt"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/traceutil"
+	"go.opencensus.io/trace"
+)
+
+// The orphaned blocks bucket keeps a copy of the blocks de-indexed from the canonical chain when
+// the head switches to a fork, so that they remain available to fork monitors and slashers after
+// they are pruned from the blocks bucket. Orphaned blocks are indexed by slot, and kept for the
+// number of epochs set by the orphaned block retention flag behind the head. A retention of 0
+// disables the bucket.
+//
+// A block which becomes canonical again after a later reorg is removed from the bucket.
+func (k *Store) updateOrphanedBlocks(
+	ctx context.Context,
+	tx *bolt.Tx,
+	orphanedRoots [][]byte,
+	canonical []*canonicalBlock,
+) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.updateOrphanedBlocks")
+	defer span.End()
+
+	retention := flags.Get().OrphanedBlockRetentionEpochs * params.BeaconConfig().SlotsPerEpoch
+	if retention == 0 {
+		return nil
+	}
+	bkt := tx.Bucket(orphanedBlocksBucket)
+	slotsBkt := tx.Bucket(orphanedBlockSlotIndicesBucket)
+	blocksBkt := tx.Bucket(blocksBucket)
+
+	for _, blk := range canonical {
+		if err := deleteOrphanedBlock(tx, blk.root); err != nil {
+			traceutil.AnnotateError(span, err)
+			return err
+		}
+	}
+	for _, root := range orphanedRoots {
+		enc := blocksBkt.Get(root)
+		if enc == nil {
+			continue
+		}
+		signed := &ethpb.SignedBeaconBlock{}
+		if err := decode(enc, signed); err != nil {
+			traceutil.AnnotateError(span, err)
+			return err
+		}
+		if signed.Block == nil {
+			continue
+		}
+		key := orphanedBlockSlotKey(signed.Block.Slot, root)
+		if err := bkt.Put(root, enc); err != nil {
+			traceutil.AnnotateError(span, err)
+			return err
+		}
+		if err := slotsBkt.Put(key, root); err != nil {
+			traceutil.AnnotateError(span, err)
+			return err
+		}
+	}
+
+	// Prune the orphaned blocks which are older than the retention period behind the new head.
+	if len(canonical) == 0 || canonical[0].slot < retention {
+		return nil
+	}
+	cutoff := orphanedBlockSlotKey(canonical[0].slot-retention, nil)
+	var staleKeys, staleRoots [][]byte
+	c := slotsBkt.Cursor()
+	for k, v := c.First(); k != nil && bytes.Compare(k, cutoff) < 0; k, v = c.Next() {
+		staleKeys = append(staleKeys, append([]byte{}, k...))
+		staleRoots = append(staleRoots, append([]byte{}, v...))
+	}
+	for i := range staleKeys {
+		if err := slotsBkt.Delete(staleKeys[i]); err != nil {
+			traceutil.AnnotateError(span, err)
+			return err
+		}
+		if err := bkt.Delete(staleRoots[i]); err != nil {
+			traceutil.AnnotateError(span, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteOrphanedBlock removes a block root from the orphaned blocks bucket.
+func deleteOrphanedBlock(tx *bolt.Tx, blockRoot []byte) error {
+	bkt := tx.Bucket(orphanedBlocksBucket)
+	enc := bkt.Get(blockRoot)
+	if enc == nil {
+		return nil
+	}
+	signed := &ethpb.SignedBeaconBlock{}
+	if err := decode(enc, signed); err != nil {
+		return err
+	}
+	if signed.Block != nil {
+		key := orphanedBlockSlotKey(signed.Block.Slot, blockRoot)
+		if err := tx.Bucket(orphanedBlockSlotIndicesBucket).Delete(key); err != nil {
+			return err
+		}
+	}
+	return bkt.Delete(blockRoot)
+}
+
+// OrphanedBlocks retrieves the blocks at the given slot which were de-indexed from the canonical
+// chain by a reorg, and are still within the orphaned block retention period.
+func (k *Store) OrphanedBlocks(ctx context.Context, slot uint64) ([]*ethpb.SignedBeaconBlock, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.OrphanedBlocks")
+	defer span.End()
+
+	var blocks []*ethpb.SignedBeaconBlock
+	err := k.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(orphanedBlocksBucket)
+		prefix := orphanedBlockSlotKey(slot, nil)
+		c := tx.Bucket(orphanedBlockSlotIndicesBucket).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			enc := bkt.Get(v)
+			if enc == nil {
+				continue
+			}
+			signed := &ethpb.SignedBeaconBlock{}
+			if err := decode(enc, signed); err != nil {
+				return err
+			}
+			blocks = append(blocks, signed)
+		}
+		return nil
+	})
+	if err != nil {
+		traceutil.AnnotateError(span, err)
+	}
+	return blocks, err
+}
+
+// orphanedBlockSlotKey encodes a slot as a big-endian key followed by the block root, so that
+// the orphaned blocks of a slot are stored next to each other and sorted by slot.
+func orphanedBlockSlotKey(slot uint64, blockRoot []byte) []byte {
+	key := make([]byte, 8, 8+len(blockRoot))
+	binary.BigEndian.PutUint64(key, slot)
+	return append(key, blockRoot...)
+}
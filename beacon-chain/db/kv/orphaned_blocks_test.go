@@ -0,0 +1,93 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestStore_OrphanedBlocks(t *testing.T) {
+	flags.Init(&flags.GlobalFlags{OrphanedBlockRetentionEpochs: 1})
+	defer flags.Init(&flags.GlobalFlags{})
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	if err := db.SaveGenesisBlockRoot(ctx, genesisBlockRoot); err != nil {
+		t.Fatal(err)
+	}
+	// Fork B branches off chain A after the block at slot 4.
+	chainA := makeBlocks(t, 0, 8, genesisBlockRoot)
+	forkRoot, err := ssz.HashTreeRoot(chainA[3].Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainB := makeBlocks(t, 5, 4, forkRoot)
+	if err := db.SaveBlocks(ctx, append(chainA, chainB...)); err != nil {
+		t.Fatal(err)
+	}
+	rootsA := blockRoots(t, chainA)
+	rootsB := blockRoots(t, chainB)
+
+	saveHead := func(root [32]byte) {
+		st, err := state.InitializeFromProto(&pb.BeaconState{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := db.SaveState(ctx, st, root); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.SaveHeadBlockRoot(ctx, root); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkOrphaned := func(slot uint64, wanted []*ethpb.SignedBeaconBlock) {
+		blocks, err := db.OrphanedBlocks(ctx, slot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(blocks) != len(wanted) {
+			t.Fatalf("Wanted %d orphaned blocks at slot %d, received %d", len(wanted), slot, len(blocks))
+		}
+		for i := range blocks {
+			if !proto.Equal(blocks[i], wanted[i]) {
+				t.Errorf("Wanted orphaned block %v at slot %d, received %v", wanted[i], slot, blocks[i])
+			}
+		}
+	}
+
+	saveHead(rootsA[len(rootsA)-1])
+	checkOrphaned(6, nil)
+
+	// The head switches to fork B, orphaning the blocks of chain A after the fork. Orphaned
+	// blocks remain available once deleted from the blocks bucket.
+	saveHead(rootsB[len(rootsB)-1])
+	checkOrphaned(5, []*ethpb.SignedBeaconBlock{chainA[4]})
+	checkOrphaned(6, []*ethpb.SignedBeaconBlock{chainA[5]})
+	if err := db.DeleteBlocks(ctx, rootsA[6:]); err != nil {
+		t.Fatal(err)
+	}
+	checkOrphaned(8, []*ethpb.SignedBeaconBlock{chainA[7]})
+
+	// Blocks of chain A which become canonical again are no longer orphaned.
+	saveHead(rootsA[5])
+	checkOrphaned(5, nil)
+	checkOrphaned(6, []*ethpb.SignedBeaconBlock{chainB[0]})
+
+	// Orphaned blocks older than the retention period behind the head are pruned.
+	chainC := makeBlocks(t, int(params.BeaconConfig().SlotsPerEpoch)+8, 1, rootsA[5])
+	if err := db.SaveBlocks(ctx, chainC); err != nil {
+		t.Fatal(err)
+	}
+	saveHead(blockRoots(t, chainC)[0])
+	checkOrphaned(6, nil)
+	checkOrphaned(8, nil)
+	checkOrphaned(9, []*ethpb.SignedBeaconBlock{chainB[3]})
+}
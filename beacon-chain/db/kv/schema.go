@@ -24,6 +24,11 @@ var (
 	powchainBucket                       = []byte("powchain")
 	archivedIndexRootBucket              = []byte("archived-index-root")
 	archivedIndexStateBucket             = []byte("archived-index-state")
+	validatorRewardsBucket               = []byte("validator-rewards")
+	eth1HeadersBucket                    = []byte("eth1-headers")
+	orphanedBlocksBucket                 = []byte("orphaned-blocks")
+	quarantinedBlocksBucket              = []byte("quarantined-blocks")
+	quarantinedStatesBucket              = []byte("quarantined-states")
 
 	// Key indices buckets.
 	blockParentRootIndicesBucket        = []byte("block-parent-root-indices")
@@ -34,6 +39,11 @@ var (
 	attestationTargetRootIndicesBucket  = []byte("attestation-target-root-indices")
 	attestationTargetEpochIndicesBucket = []byte("attestation-target-epoch-indices")
 	finalizedBlockRootsIndexBucket      = []byte("finalized-block-roots-index")
+	canonicalBlockRootsIndexBucket      = []byte("canonical-block-roots-index")
+	canonicalSlotsIndexBucket           = []byte("canonical-slots-index")
+	eth1HeaderNumberIndicesBucket       = []byte("eth1-header-number-indices")
+	eth1HeaderTimeIndicesBucket         = []byte("eth1-header-time-indices")
+	orphanedBlockSlotIndicesBucket      = []byte("orphaned-block-slot-indices")
 
 	// Specific item keys.
 	headBlockRootKey          = []byte("head-root")
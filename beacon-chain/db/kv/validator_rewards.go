@@ -0,0 +1,99 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+
+	"github.com/boltdb/bolt"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
+	"go.opencensus.io/trace"
+)
+
+// ValidatorRewards retrieves the breakdown of the rewards and penalties of a validator at the
+// transitions to the epochs between the start and end epochs, inclusive, keyed by epoch. Epochs
+// without recorded rewards are left out.
+func (k *Store) ValidatorRewards(ctx context.Context, validatorIdx uint64, startEpoch uint64, endEpoch uint64) (map[uint64]*precompute.Rewards, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.ValidatorRewards")
+	defer span.End()
+
+	rewards := make(map[uint64]*precompute.Rewards)
+	err := k.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(validatorRewardsBucket).Cursor()
+		max := validatorRewardsKey(validatorIdx, endEpoch)
+		for key, enc := c.Seek(validatorRewardsKey(validatorIdx, startEpoch)); key != nil && bytes.Compare(key, max) <= 0; key, enc = c.Next() {
+			r, err := unmarshalRewards(enc)
+			if err != nil {
+				return err
+			}
+			rewards[binary.BigEndian.Uint64(key[8:])] = r
+		}
+		return nil
+	})
+	return rewards, err
+}
+
+// SaveValidatorRewards saves the breakdown of the rewards and penalties of every validator at
+// the transition to the given epoch, the validator index being the position in the list.
+func (k *Store) SaveValidatorRewards(ctx context.Context, epoch uint64, rewards []*precompute.Rewards) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveValidatorRewards")
+	defer span.End()
+
+	return k.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(validatorRewardsBucket)
+		for i, r := range rewards {
+			if r == nil {
+				continue
+			}
+			if err := bkt.Put(validatorRewardsKey(uint64(i), epoch), marshalRewards(r)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// validatorRewardsKey encodes a validator index followed by an epoch as a big-endian key, so
+// that the rewards of a validator are sorted by epoch.
+func validatorRewardsKey(validatorIdx uint64, epoch uint64) []byte {
+	key := make([]byte, 16)
+	binary.BigEndian.PutUint64(key[:8], validatorIdx)
+	binary.BigEndian.PutUint64(key[8:], epoch)
+	return key
+}
+
+func rewardFields(r *precompute.Rewards) []*uint64 {
+	return []*uint64{
+		&r.SourceReward,
+		&r.TargetReward,
+		&r.HeadReward,
+		&r.InclusionDelayReward,
+		&r.ProposerReward,
+		&r.SourcePenalty,
+		&r.TargetPenalty,
+		&r.HeadPenalty,
+		&r.InactivityPenalty,
+	}
+}
+
+func marshalRewards(r *precompute.Rewards) []byte {
+	fields := rewardFields(r)
+	res := make([]byte, len(fields)*8)
+	for i, f := range fields {
+		binary.LittleEndian.PutUint64(res[i*8:(i+1)*8], *f)
+	}
+	return res
+}
+
+func unmarshalRewards(enc []byte) (*precompute.Rewards, error) {
+	r := &precompute.Rewards{}
+	fields := rewardFields(r)
+	if len(enc) != len(fields)*8 {
+		return nil, errors.Errorf("invalid validator rewards encoding of %d bytes", len(enc))
+	}
+	for i, f := range fields {
+		*f = binary.LittleEndian.Uint64(enc[i*8 : (i+1)*8])
+	}
+	return r, nil
+}
@@ -0,0 +1,56 @@
+package kv
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
+)
+
+func TestStore_ValidatorRewards(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	for epoch := uint64(1); epoch <= 4; epoch++ {
+		rewards := []*precompute.Rewards{
+			{SourceReward: epoch, TargetReward: 2 * epoch, ProposerReward: 3 * epoch},
+			{SourcePenalty: epoch, InactivityPenalty: 4 * epoch},
+		}
+		if err := db.SaveValidatorRewards(ctx, epoch, rewards); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	received, err := db.ValidatorRewards(ctx, 1, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wanted := map[uint64]*precompute.Rewards{
+		2: {SourcePenalty: 2, InactivityPenalty: 8},
+		3: {SourcePenalty: 3, InactivityPenalty: 12},
+	}
+	if !reflect.DeepEqual(wanted, received) {
+		t.Errorf("Wanted %v, received %v", wanted, received)
+	}
+
+	received, err = db.ValidatorRewards(ctx, 0, 4, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wanted = map[uint64]*precompute.Rewards{
+		4: {SourceReward: 4, TargetReward: 8, ProposerReward: 12},
+	}
+	if !reflect.DeepEqual(wanted, received) {
+		t.Errorf("Wanted %v, received %v", wanted, received)
+	}
+
+	received, err = db.ValidatorRewards(ctx, 2, 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(received) != 0 {
+		t.Errorf("Expected no rewards of unknown validator, received %v", received)
+	}
+}
@@ -0,0 +1,214 @@
+// Package epochsummary posts a JSON summary of every epoch transition of the head state, such
+// as its participation rate and the rewards and penalties applied, to webhook URLs, so that
+// teams can follow the chain in their chat or alerting tools without scraping metrics.
+package epochsummary
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/event"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "epochsummary")
+
+// requestTimeout bounds how long a single post to a webhook URL may take.
+const requestTimeout = 10 * time.Second
+
+// Summary is the JSON document posted to the webhook URLs at each epoch transition.
+type Summary struct {
+	Epoch uint64 `json:"epoch"`
+	Slot  uint64 `json:"slot"`
+	// ParticipationRate is the share of the active balance of the previous epoch which attested
+	// to the target of the previous epoch.
+	ParticipationRate  float64 `json:"participation_rate"`
+	JustifiedEpoch     uint64  `json:"justified_epoch"`
+	FinalizedEpoch     uint64  `json:"finalized_epoch"`
+	TotalRewards       uint64  `json:"total_rewards"`
+	TotalPenalties     uint64  `json:"total_penalties"`
+	SlashedValidators  uint64  `json:"slashed_validators"`
+	AttestingBalance   uint64  `json:"attesting_balance"`
+	ActiveBalance      uint64  `json:"active_balance"`
+	ValidatorsInSystem uint64  `json:"validators_in_system"`
+}
+
+// Service posts a summary of the epoch transitions of the head state to webhook URLs.
+type Service struct {
+	ctx             context.Context
+	cancel          context.CancelFunc
+	urls            []string
+	client          *http.Client
+	headFetcher     blockchain.HeadFetcher
+	stateNotifier   statefeed.Notifier
+	lastPostedEpoch uint64
+}
+
+// Config options for the epoch summary service.
+type Config struct {
+	// URLs the summaries are posted to as JSON.
+	URLs          []string
+	HeadFetcher   blockchain.HeadFetcher
+	StateNotifier statefeed.Notifier
+}
+
+// NewService initializes the service from configuration options.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		ctx:           ctx,
+		cancel:        cancel,
+		urls:          cfg.URLs,
+		client:        &http.Client{Timeout: requestTimeout},
+		headFetcher:   cfg.HeadFetcher,
+		stateNotifier: cfg.StateNotifier,
+	}
+}
+
+// Start the epoch summary service event loop.
+func (s *Service) Start() {
+	go s.run(s.ctx)
+}
+
+// Stop the epoch summary service event loop.
+func (s *Service) Stop() error {
+	defer s.cancel()
+	return nil
+}
+
+// Status reports the healthy status of the epoch summary service. Returning nil means service
+// is correctly running without error.
+func (s *Service) Status() error {
+	return nil
+}
+
+// summarize returns the summary of the transition to the epoch of the head state, or nil if
+// the transition was already summarized or was not processed by the node.
+func (s *Service) summarize(headState *stateTrie.BeaconState) *Summary {
+	epoch := helpers.CurrentEpoch(headState)
+	// No rewards nor penalties are applied at the transition to the first epoch.
+	if epoch <= 1 || epoch <= s.lastPostedEpoch {
+		return nil
+	}
+	// After a restart, the head state may be in a new epoch without its transition being processed.
+	if len(state.ValidatorSummary) == 0 {
+		return nil
+	}
+	summary := &Summary{
+		Epoch:              epoch,
+		Slot:               headState.Slot(),
+		ValidatorsInSystem: uint64(headState.NumValidators()),
+	}
+	if justified := headState.CurrentJustifiedCheckpoint(); justified != nil {
+		summary.JustifiedEpoch = justified.Epoch
+	}
+	summary.FinalizedEpoch = headState.FinalizedCheckpointEpoch()
+	addValidators(summary, state.ValidatorSummary)
+	return summary
+}
+
+// addValidators adds the participation, rewards, penalties and slashings of the validators of
+// the epoch transition to the summary.
+func addValidators(summary *Summary, validators []*precompute.Validator) {
+	for _, v := range validators {
+		summary.TotalRewards += v.Rewards.TotalReward()
+		summary.TotalPenalties += v.Rewards.TotalPenalty()
+		if v.IsSlashed {
+			summary.SlashedValidators++
+		}
+		if v.IsActivePrevEpoch {
+			summary.ActiveBalance += v.CurrentEpochEffectiveBalance
+			if v.IsPrevEpochTargetAttester && !v.IsSlashed {
+				summary.AttestingBalance += v.CurrentEpochEffectiveBalance
+			}
+		}
+	}
+	if summary.ActiveBalance > 0 {
+		summary.ParticipationRate = float64(summary.AttestingBalance) / float64(summary.ActiveBalance)
+	}
+}
+
+// post sends the summary to a webhook URL.
+func (s *Service) post(ctx context.Context, url string, summary *Summary) error {
+	enc, err := json.Marshal(summary)
+	if err != nil {
+		return errors.Wrap(err, "could not encode summary")
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(enc))
+	if err != nil {
+		return errors.Wrap(err, "could not create request")
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "could not post summary")
+	}
+	if err := resp.Body.Close(); err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook URL responded with status %s", resp.Status)
+	}
+	return nil
+}
+
+// postSummary posts the summary of the epoch transition of the head state to every webhook URL,
+// once the head state enters a new epoch. A failed post is logged and not retried, so that an
+// unreachable URL does not hold up the summaries of the next epochs.
+func (s *Service) postSummary(ctx context.Context, headState *stateTrie.BeaconState) {
+	summary := s.summarize(headState)
+	if summary == nil {
+		return
+	}
+	s.lastPostedEpoch = summary.Epoch
+	for _, url := range s.urls {
+		if err := s.post(ctx, url, summary); err != nil {
+			log.WithError(err).WithField("url", url).Error("Could not post epoch summary")
+		}
+	}
+	log.WithField("epoch", summary.Epoch).Debug("Posted epoch summary")
+}
+
+func (s *Service) run(ctx context.Context) {
+	// The posts may be slow, so the events are buffered rather than holding up the state feed.
+	// Only the latest head matters, older events are dropped.
+	stateChannel, stateSub := feed.SubscribeBuffered(s.stateNotifier.StateFeed(), &event.BufferedOpts{
+		Name:   "epoch_summary",
+		Size:   1,
+		Policy: event.DropOldest,
+	})
+	defer stateSub.Unsubscribe()
+	for {
+		select {
+		case ev := <-stateChannel:
+			if ev.Type != statefeed.BlockProcessed {
+				continue
+			}
+			headState, err := s.headFetcher.HeadState(ctx)
+			if err != nil {
+				log.WithError(err).Error("Head state is not available")
+				continue
+			}
+			s.postSummary(ctx, headState)
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting goroutine")
+			return
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Subscription to state feed notifier failed")
+			return
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package epochsummary
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestPostSummary_OncePerEpoch(t *testing.T) {
+	var received []*Summary
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		summary := &Summary{}
+		if err := json.NewDecoder(r.Body).Decode(summary); err != nil {
+			t.Error(err)
+		}
+		received = append(received, summary)
+	}))
+	defer srv.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	s := NewService(context.Background(), &Config{URLs: []string{failing.URL, srv.URL}})
+
+	validatorSummary := state.ValidatorSummary
+	defer func() {
+		state.ValidatorSummary = validatorSummary
+	}()
+	state.ValidatorSummary = []*precompute.Validator{
+		{
+			IsActivePrevEpoch:            true,
+			IsPrevEpochTargetAttester:    true,
+			CurrentEpochEffectiveBalance: 32,
+			Rewards:                      precompute.Rewards{SourceReward: 1, TargetReward: 2, HeadReward: 3},
+		},
+		{
+			IsActivePrevEpoch:            true,
+			CurrentEpochEffectiveBalance: 32,
+			Rewards:                      precompute.Rewards{SourcePenalty: 4, InactivityPenalty: 5},
+		},
+		{
+			IsSlashed:                    true,
+			IsActivePrevEpoch:            true,
+			IsPrevEpochTargetAttester:    true,
+			CurrentEpochEffectiveBalance: 32,
+		},
+		{},
+	}
+	slot := 3*params.BeaconConfig().SlotsPerEpoch + 1
+	headState, err := stateTrie.InitializeFromProto(&pb.BeaconState{
+		Slot:                       slot,
+		Validators:                 []*ethpb.Validator{{}, {}, {}, {}},
+		CurrentJustifiedCheckpoint: &ethpb.Checkpoint{Epoch: 2},
+		FinalizedCheckpoint:        &ethpb.Checkpoint{Epoch: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.postSummary(context.Background(), headState)
+
+	// The summary of an epoch is only posted once, even if a webhook URL failed.
+	s.postSummary(context.Background(), headState)
+
+	wanted := []*Summary{{
+		Epoch:              3,
+		Slot:               slot,
+		ParticipationRate:  1.0 / 3.0,
+		JustifiedEpoch:     2,
+		FinalizedEpoch:     1,
+		TotalRewards:       6,
+		TotalPenalties:     9,
+		SlashedValidators:  1,
+		AttestingBalance:   32,
+		ActiveBalance:      96,
+		ValidatorsInSystem: 4,
+	}}
+	if !reflect.DeepEqual(wanted, received) {
+		t.Errorf("Wanted %v, received %v", wanted, received)
+	}
+}
+
+func TestSummarize_FirstEpoch(t *testing.T) {
+	s := &Service{}
+	validatorSummary := state.ValidatorSummary
+	defer func() {
+		state.ValidatorSummary = validatorSummary
+	}()
+	state.ValidatorSummary = []*precompute.Validator{{}}
+	headState, err := stateTrie.InitializeFromProto(&pb.BeaconState{
+		Slot:       params.BeaconConfig().SlotsPerEpoch,
+		Validators: []*ethpb.Validator{{}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary := s.summarize(headState); summary != nil {
+		t.Errorf("Wanted no summary of the transition to the first epoch, received %v", summary)
+	}
+}
@@ -0,0 +1,217 @@
+// Package finality defines a service which monitors the finality of the chain, keeping a history
+// of the justification bits of the head state at each epoch and alerting when the chain has not
+// finalized for too many epochs.
+package finality
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/runutil"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "finality")
+
+// historyLength is the number of epochs the justification bits are kept for.
+const historyLength = 64
+
+var (
+	epochsSinceFinality = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "beacon_epochs_since_finality",
+		Help: "The number of epochs between the current epoch and the finalized epoch of the head state.",
+	})
+	justificationBits = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "beacon_justification_bits",
+		Help: "The justification bits of the head state, bit i being set when the epoch i+1 epochs before the head epoch is justified.",
+	})
+	finalityAlerts = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "beacon_finality_alerts_total",
+		Help: "The number of epochs at which the chain had not finalized for the alert epochs.",
+	})
+)
+
+// Record is the justification and finalization status of the head state at an epoch.
+type Record struct {
+	Epoch uint64 `json:"epoch"`
+	// JustificationBits has bit i set when the epoch i+1 epochs before Epoch is justified.
+	JustificationBits     uint8  `json:"justification_bits"`
+	CurrentJustifiedEpoch uint64 `json:"current_justified_epoch"`
+	FinalizedEpoch        uint64 `json:"finalized_epoch"`
+}
+
+// Status is the distance of the current epoch from finalization, along with the history of the
+// justification bits of the last epochs, oldest first.
+type Status struct {
+	CurrentEpoch        uint64    `json:"current_epoch"`
+	FinalizedEpoch      uint64    `json:"finalized_epoch"`
+	EpochsSinceFinality uint64    `json:"epochs_since_finality"`
+	History             []*Record `json:"history"`
+}
+
+// Service checks every slot how many epochs the chain has not finalized for.
+type Service struct {
+	ctx            context.Context
+	cancel         context.CancelFunc
+	headFetcher    blockchain.HeadFetcher
+	timeFetcher    blockchain.TimeFetcher
+	stateNotifier  statefeed.Notifier
+	alertEpochs    uint64
+	lock           sync.RWMutex
+	status         *Status
+	lastAlertEpoch uint64
+	alerting       bool
+}
+
+// Config options for the finality service.
+type Config struct {
+	HeadFetcher   blockchain.HeadFetcher
+	TimeFetcher   blockchain.TimeFetcher
+	StateNotifier statefeed.Notifier
+	// AlertEpochs is the number of epochs since finality after which the chain is alerted as not
+	// finalizing. 0 disables the alerts.
+	AlertEpochs uint64
+}
+
+// NewService initializes the service from configuration options.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		ctx:           ctx,
+		cancel:        cancel,
+		headFetcher:   cfg.HeadFetcher,
+		timeFetcher:   cfg.TimeFetcher,
+		stateNotifier: cfg.StateNotifier,
+		alertEpochs:   cfg.AlertEpochs,
+		status:        &Status{},
+	}
+}
+
+// Start the finality service event loop.
+func (s *Service) Start() {
+	runutil.RunEvery(s.ctx, time.Duration(params.BeaconConfig().SecondsPerSlot)*time.Second, s.checkFinality)
+}
+
+// Stop the finality service event loop.
+func (s *Service) Stop() error {
+	defer s.cancel()
+	return nil
+}
+
+// Status reports the healthy status of the finality service. Returning nil
+// means service is correctly running without error.
+func (s *Service) Status() error {
+	return nil
+}
+
+// FinalityStatus returns the distance from finalization and the justification bits history.
+func (s *Service) FinalityStatus() *Status {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	status := *s.status
+	status.History = make([]*Record, len(s.status.History))
+	copy(status.History, s.status.History)
+	return &status
+}
+
+// FinalityHandler is a handler to serve the /finality page in metrics. It writes the distance
+// of the current epoch from finalization and the justification bits history as JSON.
+func (s *Service) FinalityHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(s.FinalityStatus()); err != nil {
+		log.WithError(err).Error("Failed to write finality status")
+	}
+}
+
+// checkFinality records the justification bits of the head state once it enters a new epoch, and
+// updates the distance of the current epoch from finalization. The current epoch is the one of
+// the wall clock, so a chain which stopped producing blocks is still seen as not finalizing. An
+// alert is sent on the state feed at each epoch the chain has not finalized for the alert epochs.
+func (s *Service) checkFinality() {
+	headState, err := s.headFetcher.HeadState(s.ctx)
+	if err != nil || headState == nil {
+		log.WithError(err).Error("Head state is not available")
+		return
+	}
+	currentEpoch := helpers.SlotToEpoch(s.timeFetcher.CurrentSlot())
+	headEpoch := helpers.CurrentEpoch(headState)
+	if headEpoch > currentEpoch {
+		currentEpoch = headEpoch
+	}
+	finalizedEpoch := headState.FinalizedCheckpointEpoch()
+	var bits uint8
+	if b := headState.JustificationBits(); len(b) > 0 {
+		bits = b[0] & 0x0F
+	}
+
+	s.lock.Lock()
+	history := s.status.History
+	if len(history) == 0 || history[len(history)-1].Epoch < headEpoch {
+		history = append(history, &Record{
+			Epoch:                 headEpoch,
+			JustificationBits:     bits,
+			CurrentJustifiedEpoch: headState.CurrentJustifiedCheckpoint().Epoch,
+			FinalizedEpoch:        finalizedEpoch,
+		})
+		if len(history) > historyLength {
+			history = history[len(history)-historyLength:]
+		}
+	}
+	s.status = &Status{
+		CurrentEpoch:        currentEpoch,
+		FinalizedEpoch:      finalizedEpoch,
+		EpochsSinceFinality: currentEpoch - finalizedEpoch,
+		History:             history,
+	}
+	s.lock.Unlock()
+
+	epochsSinceFinality.Set(float64(currentEpoch - finalizedEpoch))
+	justificationBits.Set(float64(bits))
+
+	if s.alertEpochs == 0 {
+		return
+	}
+	if currentEpoch-finalizedEpoch < s.alertEpochs {
+		if s.alerting {
+			log.WithField("finalizedEpoch", finalizedEpoch).Info("Chain is finalizing again")
+			s.alerting = false
+		}
+		return
+	}
+	if s.alerting && s.lastAlertEpoch == currentEpoch {
+		return
+	}
+	s.alerting = true
+	s.lastAlertEpoch = currentEpoch
+	finalityAlerts.Inc()
+	log.WithFields(logrus.Fields{
+		"currentEpoch":        currentEpoch,
+		"finalizedEpoch":      finalizedEpoch,
+		"epochsSinceFinality": currentEpoch - finalizedEpoch,
+		"justificationBits":   bits,
+	}).Warn("Chain has not finalized")
+	s.stateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.FinalityDelayed,
+		Data: &statefeed.FinalityDelayedData{
+			Epoch:               currentEpoch,
+			FinalizedEpoch:      finalizedEpoch,
+			EpochsSinceFinality: currentEpoch - finalizedEpoch,
+			JustificationBits:   bits,
+		},
+	})
+}
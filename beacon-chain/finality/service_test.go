@@ -0,0 +1,104 @@
+package finality
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+type mockClock struct {
+	slot uint64
+}
+
+func (c *mockClock) GenesisTime() time.Time {
+	return time.Unix(0, 0)
+}
+
+func (c *mockClock) CurrentSlot() uint64 {
+	return c.slot
+}
+
+func TestCheckFinality_AlertsWhenNotFinalizing(t *testing.T) {
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	st, err := stateTrie.InitializeFromProto(&pb.BeaconState{
+		Slot:                       3 * slotsPerEpoch,
+		JustificationBits:          []byte{0x03},
+		CurrentJustifiedCheckpoint: &ethpb.Checkpoint{Epoch: 2},
+		FinalizedCheckpoint:        &ethpb.Checkpoint{Epoch: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain := &mock.ChainService{State: st}
+	notifier := &mock.MockStateNotifier{}
+	events := make(chan *feed.Event, 2)
+	sub := notifier.StateFeed().Subscribe(events)
+	defer sub.Unsubscribe()
+	clock := &mockClock{slot: 3 * slotsPerEpoch}
+	s := NewService(context.Background(), &Config{
+		HeadFetcher:   chain,
+		TimeFetcher:   clock,
+		StateNotifier: notifier,
+		AlertEpochs:   4,
+	})
+
+	s.checkFinality()
+	status := s.FinalityStatus()
+	if status.EpochsSinceFinality != 2 || len(status.History) != 1 {
+		t.Fatalf("Wanted 2 epochs since finality and a single record, received %+v", status)
+	}
+	if status.History[0].JustificationBits != 0x03 || status.History[0].CurrentJustifiedEpoch != 2 {
+		t.Errorf("Unexpected record %+v", status.History[0])
+	}
+	if len(events) != 0 {
+		t.Fatal("Expected no finality alert before the alert epochs")
+	}
+
+	// No block is processed for three epochs.
+	clock.slot = 5 * slotsPerEpoch
+	s.checkFinality()
+	if len(s.FinalityStatus().History) != 1 {
+		t.Error("Expected no record without a new head epoch")
+	}
+	if len(events) != 1 {
+		t.Fatalf("Wanted a finality alert, received %d", len(events))
+	}
+	event := <-events
+	data, ok := event.Data.(*statefeed.FinalityDelayedData)
+	if event.Type != statefeed.FinalityDelayed || !ok {
+		t.Fatalf("Unexpected event %+v", event)
+	}
+	if data.Epoch != 5 || data.FinalizedEpoch != 1 || data.EpochsSinceFinality != 4 {
+		t.Errorf("Unexpected alert %+v", data)
+	}
+	// The alert is sent once per epoch.
+	clock.slot++
+	s.checkFinality()
+	if len(events) != 0 {
+		t.Errorf("Wanted a single alert in the epoch, received %d more", len(events))
+	}
+
+	// The head catches up and finalizes.
+	if err := st.SetSlot(5 * slotsPerEpoch); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.SetFinalizedCheckpoint(&ethpb.Checkpoint{Epoch: 3}); err != nil {
+		t.Fatal(err)
+	}
+	s.checkFinality()
+	status = s.FinalityStatus()
+	if status.EpochsSinceFinality != 2 || len(status.History) != 2 || status.History[1].FinalizedEpoch != 3 {
+		t.Errorf("Wanted the finalization to be recorded, received %+v", status)
+	}
+	if len(events) != 0 {
+		t.Errorf("Expected no alert once the chain finalizes, received %d", len(events))
+	}
+}
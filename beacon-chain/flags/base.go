@@ -10,6 +10,12 @@ var (
 		Name:  "no-custom-config",
 		Usage: "Run the beacon chain with the real parameters from phase 0.",
 	}
+	// ChainConfigFileFlag specifies a YAML file overriding the parameters of the chain config.
+	ChainConfigFileFlag = cli.StringFlag{
+		Name: "chain-config-file",
+		Usage: "The path to a YAML file overriding chain config parameters, using the keys of the spec " +
+			"configs such as MIN_GENESIS_TIME, MIN_GENESIS_DELAY or MIN_GENESIS_ACTIVE_VALIDATOR_COUNT",
+	}
 	// HTTPWeb3ProviderFlag provides an HTTP access endpoint to an ETH 1.0 RPC.
 	HTTPWeb3ProviderFlag = cli.StringFlag{
 		Name:  "http-web3provider",
@@ -28,6 +34,12 @@ var (
 		Usage: "Deposit contract address. Beacon chain node will listen logs coming from the deposit contract to determine when validator is eligible to participate.",
 		Value: "0x4689a3C63CE249355C8a573B5974db21D2d1b8Ef",
 	}
+	// ForceEth1ConfigFlag starts the beacon node even if the eth1 endpoint does not match the chain config.
+	ForceEth1ConfigFlag = cli.BoolFlag{
+		Name: "force-eth1-config",
+		Usage: "Only warn, instead of refusing to start, when the chain ID or network ID of the eth1 endpoint, " +
+			"or the code of the deposit contract, do not match the chain config",
+	}
 	// RPCHost defines the host on which the RPC server should listen.
 	RPCHost = cli.StringFlag{
 		Name:  "rpc-host",
@@ -57,6 +69,27 @@ var (
 		Name:  "tls-key",
 		Usage: "Key for secure gRPC. Pass this and the tls-cert flag in order to use gRPC securely.",
 	}
+	// ClientCAFlag defines a flag for the certificate authority of the clients of the node.
+	ClientCAFlag = cli.StringFlag{
+		Name: "tls-client-ca",
+		Usage: "Certificate authority which the TLS certificates of gRPC clients must be signed by. " +
+			"Pass this along with the tls-cert and tls-key flags to require mutual TLS.",
+	}
+	// RPCAPIKeysFlag specifies the YAML file of API keys which requests to the RPC server must
+	// carry, along with the methods and rate quota of each key.
+	RPCAPIKeysFlag = cli.StringFlag{
+		Name: "rpc-api-keys",
+		Usage: "Path to a YAML file of API keys required to call the RPC server, passed in the x-api-key " +
+			"metadata or HTTP header. Each key can be restricted to a list of methods and a rate quota, " +
+			"and the file is reloaded when it changes. The keys also guard the JSON endpoints of the gateway " +
+			"which assemble proposals or submit operations, but not the endpoints of the monitoring port.",
+	}
+	// ReadOnlyFlag disables the RPC methods which assemble block proposals or submit operations.
+	ReadOnlyFlag = cli.BoolFlag{
+		Name: "read-only",
+		Usage: "Run the beacon node in read-only mode, rejecting block proposals, attestation, aggregate, exit " +
+			"and slashing submissions over RPC, while sync and query endpoints stay available",
+	}
 	// GRPCGatewayPort enables a gRPC gateway to be exposed for Prysm.
 	GRPCGatewayPort = cli.IntFlag{
 		Name:  "grpc-gateway-port",
@@ -75,6 +108,17 @@ var (
 		Usage: "The eth1 block in which the deposit contract was deployed.",
 		Value: 1960177,
 	}
+	// BlocksDirFlag specifies a directory of ssz encoded blocks to import before syncing from the network.
+	BlocksDirFlag = cli.StringFlag{
+		Name: "blocks-dir",
+		Usage: "Import the ssz encoded signed blocks of the .ssz files in this directory before syncing " +
+			"from the network",
+	}
+	// DepositSnapshotFlag specifies the path to a deposit tree snapshot to initialize the deposit trie from.
+	DepositSnapshotFlag = cli.StringFlag{
+		Name:  "deposit-snapshot",
+		Usage: "The path to a JSON encoded deposit tree snapshot, exported by a synced node at /deposits/snapshot, to initialize the deposit trie from instead of processing all deposit logs. Only used when the database has no eth1 data yet.",
+	}
 	// SetGCPercent is the percentage of current live allocations at which the garbage collector is to run.
 	SetGCPercent = cli.IntFlag{
 		Name:  "gc-percent",
@@ -97,4 +141,118 @@ var (
 		Usage: "A slasher provider string endpoint. Can either be an grpc server endpoint.",
 		Value: "127.0.0.1:5000",
 	}
+	// ShuffledIndicesCacheSize defines the number of shuffled indices lists the beacon node keeps in memory.
+	ShuffledIndicesCacheSize = cli.IntFlag{
+		Name:  "shuffled-indices-cache-size",
+		Usage: "The max number of shuffled validator indices lists to cache, keyed by seed, active validator count and epoch",
+		Value: 8,
+	}
+	// CommitteeAssignmentsCacheSize defines the number of epochs of committee assignments the beacon node keeps in memory.
+	CommitteeAssignmentsCacheSize = cli.IntFlag{
+		Name:  "committee-assignments-cache-size",
+		Usage: "The max number of epoch committee assignments to cache for validator duties, keyed by seed and epoch",
+		Value: 4,
+	}
+	// PostStateCacheSize defines the number of block post-states the beacon node keeps in memory for attestation validation.
+	PostStateCacheSize = cli.IntFlag{
+		Name:  "post-state-cache-size",
+		Usage: "The max number of block post-states to cache for validating attestations, keyed by block root",
+		Value: 8,
+	}
+	// MaxStateCacheMB defines the memory budget shared by the state and committee caches.
+	MaxStateCacheMB = cli.Uint64Flag{
+		Name: "max-state-cache-mb",
+		Usage: "The max memory in megabytes held by the hot state, checkpoint state and committee caches. Once " +
+			"exceeded, entries are evicted from the caches weighted by how costly they are to regenerate. 0 means no limit",
+	}
+	// LateBlockParentWeight defines the parent weight above which late blocks are kept from becoming head.
+	LateBlockParentWeight = cli.Uint64Flag{
+		Name: "late-block-parent-weight",
+		Usage: "Keep the parent of a block received after the attestation deadline of its slot as head until the " +
+			"block receives attestations, if the parent holds at least this percent of the justified balance. 0 disables",
+	}
+	// OrphanedBlockRetentionEpochs defines how long the blocks orphaned by reorgs are kept.
+	OrphanedBlockRetentionEpochs = cli.Uint64Flag{
+		Name: "orphaned-block-retention-epochs",
+		Usage: "The number of epochs behind the head for which the blocks orphaned by a reorg are kept in the " +
+			"database and served by the blocks at slot API. 0 disables keeping orphaned blocks",
+		Value: 256,
+	}
+	// DBIntegrityCheckFlag enables the database integrity check on startup.
+	DBIntegrityCheckFlag = cli.BoolFlag{
+		Name: "db-integrity-check",
+		Usage: "Check the block parent linkage, the state roots of the stored states and the block indices of " +
+			"the database on startup, quarantining corrupt blocks and states and rebuilding inconsistent indices",
+	}
+	// SlotsPerArchivedPoint defines the number of slots between the states saved as archived points.
+	SlotsPerArchivedPoint = cli.Uint64Flag{
+		Name: "slots-per-archive-point",
+		Usage: "The slot interval of the finalized states saved to the database as archived points. A lower value " +
+			"uses more disk space, a higher value makes regenerating historical states slower",
+		Value: 2048,
+	}
+	// WatchdogStuckSlotsFlag defines the number of slots without a new head after which the watchdog
+	// rotates the peers and resyncs.
+	WatchdogStuckSlotsFlag = cli.Uint64Flag{
+		Name: "watchdog-stuck-slots",
+		Usage: "The number of slots without a new head, while connected peers advertise higher head slots, " +
+			"after which the node disconnects the peers which are not ahead and resyncs. 0 disables the watchdog",
+		Value: 64,
+	}
+	// StandbyPrimaryFlag defines the gRPC endpoint of the primary beacon node a hot standby follows.
+	StandbyPrimaryFlag = cli.StringFlag{
+		Name: "standby-primary",
+		Usage: "Run as a hot standby of the beacon node at this gRPC endpoint, processing the blocks it streams " +
+			"in addition to the blocks received over p2p, so validator clients can fail over to this node without " +
+			"a sync delay",
+	}
+	// StandbyPrimaryCertFlag defines the TLS certificate of the primary beacon node.
+	StandbyPrimaryCertFlag = cli.StringFlag{
+		Name:  "standby-primary-tls-cert",
+		Usage: "Certificate for secure gRPC to the primary beacon node of --standby-primary",
+	}
+	// FinalityAlertEpochsFlag defines the number of epochs without finality after which the chain is
+	// alerted as not finalizing.
+	FinalityAlertEpochsFlag = cli.Uint64Flag{
+		Name: "finality-alert-epochs",
+		Usage: "The number of epochs between the current epoch and the finalized epoch after which a warning is " +
+			"logged and a finality alert event is sent, at each epoch until the chain finalizes again. 0 disables " +
+			"the alerts",
+		Value: 4,
+	}
+	// ValidatorAccountingFlag enables the ledger of the rewards and penalties of the validators.
+	ValidatorAccountingFlag = cli.BoolFlag{
+		Name: "validator-accounting",
+		Usage: "Record the breakdown of the rewards and penalties of every validator at each epoch transition, " +
+			"so that the earnings of validators between epochs can be queried",
+	}
+	// EpochSummaryWebhookURLFlag defines the webhook URLs the epoch summaries are posted to.
+	EpochSummaryWebhookURLFlag = cli.StringSliceFlag{
+		Name: "epoch-summary-webhook-url",
+		Usage: "Post a JSON summary of every epoch transition, with the participation rate, finalized epoch, " +
+			"rewards and penalties applied and number of slashed validators, to this URL. This flag may be used " +
+			"multiple times.",
+	}
+	// TransitionDebugDirFlag defines the directory failed state transitions are written to.
+	TransitionDebugDirFlag = cli.StringFlag{
+		Name: "transition-debug-dir",
+		Usage: "Write the ssz encoded pre-state and block of any failed state transition to this directory, " +
+			"to be re-run with the replay command",
+	}
+	// ReplayPreStateFlag defines the ssz encoded pre-state file used by the replay command.
+	ReplayPreStateFlag = cli.StringFlag{
+		Name:  "pre-state",
+		Usage: "Path to the ssz encoded pre-state of the transition to replay",
+	}
+	// ReplayBlockFlag defines the ssz encoded block file used by the replay command.
+	ReplayBlockFlag = cli.StringFlag{
+		Name:  "block",
+		Usage: "Path to the ssz encoded signed block of the transition to replay",
+	}
+	// VerifyChainStartSlotFlag defines the slot from which the verify-chain command replays stored blocks.
+	VerifyChainStartSlotFlag = cli.Uint64Flag{
+		Name: "start-slot",
+		Usage: "Replay the chain from the latest stored state at or before this slot instead of from genesis. " +
+			"Only used by the verify-chain command",
+	}
 )
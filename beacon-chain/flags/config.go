@@ -17,6 +17,13 @@ type GlobalFlags struct {
 	MaxPageSize                       int
 	DeploymentBlock                   int
 	UnsafeSync                        bool
+	ShuffledIndicesCacheSize          int
+	CommitteeAssignmentsCacheSize     int
+	PostStateCacheSize                int
+	MaxStateCacheMB                   uint64
+	LateBlockParentWeight             uint64
+	OrphanedBlockRetentionEpochs      uint64
+	TransitionDebugDir                string
 }
 
 var globalConfig *GlobalFlags
@@ -55,6 +62,13 @@ func ConfigureGlobalFlags(ctx *cli.Context) {
 	}
 	cfg.MaxPageSize = ctx.GlobalInt(RPCMaxPageSize.Name)
 	cfg.DeploymentBlock = ctx.GlobalInt(ContractDeploymentBlock.Name)
+	cfg.ShuffledIndicesCacheSize = ctx.GlobalInt(ShuffledIndicesCacheSize.Name)
+	cfg.CommitteeAssignmentsCacheSize = ctx.GlobalInt(CommitteeAssignmentsCacheSize.Name)
+	cfg.PostStateCacheSize = ctx.GlobalInt(PostStateCacheSize.Name)
+	cfg.MaxStateCacheMB = ctx.GlobalUint64(MaxStateCacheMB.Name)
+	cfg.LateBlockParentWeight = ctx.GlobalUint64(LateBlockParentWeight.Name)
+	cfg.OrphanedBlockRetentionEpochs = ctx.GlobalUint64(OrphanedBlockRetentionEpochs.Name)
+	cfg.TransitionDebugDir = ctx.GlobalString(TransitionDebugDirFlag.Name)
 	configureMinimumPeers(ctx, cfg)
 
 	Init(cfg)
@@ -11,6 +11,7 @@ type ForkChoicer interface {
 	HeadRetriever        // to compute head.
 	BlockProcessor       // to track new block for fork choice.
 	AttestationProcessor // to track new attestation for fork choice.
+	ProposerBooster      // to boost timely blocks for fork choice.
 	Pruner               // to clean old data for fork choice.
 	Getter               // to retrieve fork choice information.
 }
@@ -30,6 +31,12 @@ type AttestationProcessor interface {
 	ProcessAttestation(context.Context, []uint64, [32]byte, uint64)
 }
 
+// ProposerBooster boosts the block of the current slot received before the attestation deadline.
+type ProposerBooster interface {
+	ProcessProposerBoost([32]byte)
+	ResetProposerBoost(uint64)
+}
+
 // Pruner prunes the fork choice upon new finalization. This is used to keep fork choice sane.
 type Pruner interface {
 	Prune(context.Context, [32]byte) error
@@ -40,4 +47,5 @@ type Getter interface {
 	Nodes() []*protoarray.Node
 	Node([32]byte) *protoarray.Node
 	HasNode([32]byte) bool
+	Dump() *protoarray.Dump
 }
@@ -0,0 +1,50 @@
+package protoarray
+
+// Dump is a copy of the contents of the fork choice store, used to inspect why a head was chosen.
+type Dump struct {
+	JustifiedEpoch    uint64
+	FinalizedEpoch    uint64
+	FinalizedRoot     [32]byte
+	ProposerBoostRoot [32]byte
+	Nodes             []*Node
+}
+
+// Dump returns a copy of the block nodes and checkpoint information of the fork choice store.
+func (f *ForkChoice) Dump() *Dump {
+	f.store.nodeIndicesLock.RLock()
+	defer f.store.nodeIndicesLock.RUnlock()
+	f.store.proposerBoostLock.Lock()
+	defer f.store.proposerBoostLock.Unlock()
+
+	nodes := make([]*Node, len(f.store.nodes))
+	for i, n := range f.store.nodes {
+		nodes[i] = copyNode(n)
+	}
+	return &Dump{
+		JustifiedEpoch:    f.store.justifiedEpoch,
+		FinalizedEpoch:    f.store.finalizedEpoch,
+		FinalizedRoot:     f.store.finalizedRoot,
+		ProposerBoostRoot: f.store.proposerBoostRoot,
+		Nodes:             nodes,
+	}
+}
+
+// Root returns the root of the block of the node.
+func (n *Node) Root() [32]byte {
+	return n.root
+}
+
+// JustifiedEpoch returns the justified epoch of the state of the node.
+func (n *Node) JustifiedEpoch() uint64 {
+	return n.justifiedEpoch
+}
+
+// FinalizedEpoch returns the finalized epoch of the state of the node.
+func (n *Node) FinalizedEpoch() uint64 {
+	return n.finalizedEpoch
+}
+
+// BestChild returns the index of the best child of the node, or the max uint64 if it has none.
+func (n *Node) BestChild() uint64 {
+	return n.bestChild
+}
@@ -0,0 +1,42 @@
+package protoarray
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestForkChoice_Dump(t *testing.T) {
+	f := setup(1, 1)
+	if err := f.ProcessBlock(context.Background(), 1, indexToHash(1), params.BeaconConfig().ZeroHash, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Head(context.Background(), 1, params.BeaconConfig().ZeroHash, make([]uint64, 16), 1); err != nil {
+		t.Fatal(err)
+	}
+
+	dump := f.Dump()
+	if dump.JustifiedEpoch != 1 || dump.FinalizedEpoch != 1 {
+		t.Errorf("Wanted justified and finalized epochs 1, received %d and %d", dump.JustifiedEpoch, dump.FinalizedEpoch)
+	}
+	if dump.FinalizedRoot != params.BeaconConfig().ZeroHash {
+		t.Errorf("Wanted finalized root %#x, received %#x", params.BeaconConfig().ZeroHash, dump.FinalizedRoot)
+	}
+	if len(dump.Nodes) != 2 {
+		t.Fatalf("Wanted 2 nodes, received %d", len(dump.Nodes))
+	}
+	node := dump.Nodes[1]
+	if node.Root() != indexToHash(1) || node.Parent != 0 || node.JustifiedEpoch() != 1 || node.FinalizedEpoch() != 1 {
+		t.Errorf("Unexpected dumped node %+v", node)
+	}
+	if dump.Nodes[0].BestChild() != 1 || dump.Nodes[0].BestDescendent != 1 {
+		t.Errorf("Wanted best child and descendant 1 of the finalized node, received %d and %d", dump.Nodes[0].BestChild(), dump.Nodes[0].BestDescendent)
+	}
+
+	// The dump is a copy of the store.
+	dump.Nodes[1].Weight = 100
+	if f.store.nodes[1].Weight == 100 {
+		t.Error("Expected dumped nodes to be copied")
+	}
+}
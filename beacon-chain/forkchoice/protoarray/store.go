@@ -15,8 +15,20 @@ const defaultPruneThreshold = 256
 // This tracks the last reported head root. Used for metrics.
 var lastHeadRoot [32]byte
 
-// New initializes a new fork choice store.
+// DefaultWeights counts the full balance of validators for their attestations, without
+// proposer boost.
+func DefaultWeights() Weights {
+	return Weights{AttestationPercent: 100}
+}
+
+// New initializes a new fork choice store with the default weights.
 func New(justifiedEpoch uint64, finalizedEpoch uint64, finalizedRoot [32]byte) *ForkChoice {
+	return NewWithWeights(justifiedEpoch, finalizedEpoch, finalizedRoot, DefaultWeights())
+}
+
+// NewWithWeights initializes a new fork choice store weighing attestations and timely blocks
+// with the given weights.
+func NewWithWeights(justifiedEpoch uint64, finalizedEpoch uint64, finalizedRoot [32]byte, weights Weights) *ForkChoice {
 	s := &Store{
 		justifiedEpoch: justifiedEpoch,
 		finalizedEpoch: finalizedEpoch,
@@ -24,6 +36,7 @@ func New(justifiedEpoch uint64, finalizedEpoch uint64, finalizedRoot [32]byte) *
 		nodes:          make([]*Node, 0),
 		nodeIndices:    make(map[[32]byte]uint64),
 		pruneThreshold: defaultPruneThreshold,
+		weights:        weights,
 	}
 
 	b := make([]uint64, 0)
@@ -39,7 +52,7 @@ func (f *ForkChoice) Head(ctx context.Context, justifiedEpoch uint64, justifiedR
 	defer span.End()
 	calledHeadCount.Inc()
 
-	newBalances := justifiedStateBalances
+	newBalances := f.store.weighBalances(justifiedStateBalances)
 
 	// Using the read lock is ok here, rest of the operations below is read only.
 	// The only time it writes to node indices is inserting and pruning blocks from the store.
@@ -50,6 +63,7 @@ func (f *ForkChoice) Head(ctx context.Context, justifiedEpoch uint64, justifiedR
 		return [32]byte{}, errors.Wrap(err, "Could not compute deltas")
 	}
 	f.votes = newVotes
+	f.store.applyProposerBoost(deltas, newBalances)
 
 	if err := f.store.applyWeightChanges(ctx, justifiedEpoch, finalizedEpoch, deltas); err != nil {
 		return [32]byte{}, errors.Wrap(err, "Could not apply score changes")
@@ -93,6 +107,33 @@ func (f *ForkChoice) ProcessBlock(ctx context.Context, slot uint64, blockRoot [3
 	return f.store.insert(ctx, slot, blockRoot, parentRoot, justifiedEpoch, finalizedEpoch)
 }
 
+// ProcessProposerBoost boosts the block of the current slot received before the attestation
+// deadline, which replaces the boost of any previous block at the next head computation.
+func (f *ForkChoice) ProcessProposerBoost(blockRoot [32]byte) {
+	if f.store.weights.ProposerBoostPercent == 0 {
+		return
+	}
+	f.store.proposerBoostLock.Lock()
+	defer f.store.proposerBoostLock.Unlock()
+	f.store.proposerBoostRoot = blockRoot
+}
+
+// ResetProposerBoost stops boosting the block once its slot has passed, at the next head computation.
+func (f *ForkChoice) ResetProposerBoost(currentSlot uint64) {
+	// Same lock order as the head computation.
+	f.store.nodeIndicesLock.RLock()
+	defer f.store.nodeIndicesLock.RUnlock()
+	f.store.proposerBoostLock.Lock()
+	defer f.store.proposerBoostLock.Unlock()
+	if f.store.proposerBoostRoot == params.BeaconConfig().ZeroHash {
+		return
+	}
+	index, ok := f.store.nodeIndices[f.store.proposerBoostRoot]
+	if !ok || f.store.nodes[index].Slot < currentSlot {
+		f.store.proposerBoostRoot = params.BeaconConfig().ZeroHash
+	}
+}
+
 // Prune prunes the fork choice store with the new finalized root. The store is only pruned if the input
 // root is different than the current store finalized root, and the number of the store has met prune threshold.
 func (f *ForkChoice) Prune(ctx context.Context, finalizedRoot [32]byte) error {
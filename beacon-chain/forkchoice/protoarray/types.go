@@ -11,13 +11,29 @@ type ForkChoice struct {
 
 // Store defines the fork choice store which includes block nodes and the last view of checkpoint information.
 type Store struct {
-	pruneThreshold  uint64              // do not prune tree unless threshold is reached.
-	justifiedEpoch  uint64              // latest justified epoch in store.
-	finalizedEpoch  uint64              // latest finalized epoch in store.
-	finalizedRoot   [32]byte            // latest finalized root in store.
-	nodes           []*Node             // list of block nodes, each node is a representation of one block.
-	nodeIndices     map[[32]byte]uint64 // the root of block node and the nodes index in the list.
-	nodeIndicesLock sync.RWMutex
+	pruneThreshold    uint64              // do not prune tree unless threshold is reached.
+	justifiedEpoch    uint64              // latest justified epoch in store.
+	finalizedEpoch    uint64              // latest finalized epoch in store.
+	finalizedRoot     [32]byte            // latest finalized root in store.
+	nodes             []*Node             // list of block nodes, each node is a representation of one block.
+	nodeIndices       map[[32]byte]uint64 // the root of block node and the nodes index in the list.
+	nodeIndicesLock   sync.RWMutex
+	weights           Weights  // weighting of attestations and timely proposals.
+	proposerBoostRoot [32]byte // root of the timely block of the current slot to boost.
+	appliedBoostRoot  [32]byte // root of the block the last applied boost was added to.
+	appliedBoostScore uint64   // weight of the last applied boost.
+	proposerBoostLock sync.Mutex
+}
+
+// Weights defines how the fork choice store weighs the latest attestations of validators and
+// the blocks received in time in their slot.
+type Weights struct {
+	// AttestationPercent is the percentage of the balance of a validator counted for its
+	// latest attestation.
+	AttestationPercent uint64
+	// ProposerBoostPercent is the percentage of the average committee weight added to the
+	// block received before the attestation deadline of the current slot, 0 disables it.
+	ProposerBoostPercent uint64
 }
 
 // Node defines the individual block which includes its block parent, ancestor and how much weight accounted for it.
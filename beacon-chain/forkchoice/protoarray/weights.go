@@ -0,0 +1,51 @@
+package protoarray
+
+import (
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// weighBalances scales the justified balances of validators by the attestation weight, which
+// are then counted for their latest votes.
+func (s *Store) weighBalances(balances []uint64) []uint64 {
+	if s.weights.AttestationPercent == 100 {
+		return balances
+	}
+	weighted := make([]uint64, len(balances))
+	for i, b := range balances {
+		weighted[i] = b * s.weights.AttestationPercent / 100
+	}
+	return weighted
+}
+
+// applyProposerBoost moves the proposer boost in the node deltas: the boost applied at the
+// previous head computation is removed, and the boost of the current timely block, a share of
+// the average committee weight, is added. The node indices lock is expected to be held by the
+// caller.
+func (s *Store) applyProposerBoost(deltas []int, balances []uint64) {
+	s.proposerBoostLock.Lock()
+	defer s.proposerBoostLock.Unlock()
+
+	if s.appliedBoostScore > 0 {
+		// The boosted node may have been pruned along with its weight.
+		if index, ok := s.nodeIndices[s.appliedBoostRoot]; ok && int(index) < len(deltas) {
+			deltas[index] -= int(s.appliedBoostScore)
+		}
+		s.appliedBoostRoot = params.BeaconConfig().ZeroHash
+		s.appliedBoostScore = 0
+	}
+	if s.weights.ProposerBoostPercent == 0 || s.proposerBoostRoot == params.BeaconConfig().ZeroHash {
+		return
+	}
+	index, ok := s.nodeIndices[s.proposerBoostRoot]
+	if !ok || int(index) >= len(deltas) {
+		return
+	}
+	total := uint64(0)
+	for _, b := range balances {
+		total += b
+	}
+	score := total / params.BeaconConfig().SlotsPerEpoch * s.weights.ProposerBoostPercent / 100
+	deltas[index] += int(score)
+	s.appliedBoostRoot = s.proposerBoostRoot
+	s.appliedBoostScore = score
+}
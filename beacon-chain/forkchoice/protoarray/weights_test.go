@@ -0,0 +1,88 @@
+package protoarray
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestProposerBoost_OutweighsVotesUntilSlotPasses(t *testing.T) {
+	ctx := context.Background()
+	balances := make([]uint64, 64)
+	for i := range balances {
+		balances[i] = 10
+	}
+	f := setup(1, 1)
+	f.store.weights = Weights{AttestationPercent: 100, ProposerBoostPercent: 200}
+	zeroHash := params.BeaconConfig().ZeroHash
+	if err := f.ProcessBlock(ctx, 1, indexToHash(1), zeroHash, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.ProcessBlock(ctx, 2, indexToHash(2), zeroHash, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	f.ProcessAttestation(ctx, []uint64{0}, indexToHash(1), 2)
+	r, err := f.Head(ctx, 1, zeroHash, balances, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r != indexToHash(1) {
+		t.Error("Expected the attested block to be head")
+	}
+
+	// The boost is twice the average committee weight, more than the single vote.
+	f.ProcessProposerBoost(indexToHash(2))
+	r, err = f.Head(ctx, 1, zeroHash, balances, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r != indexToHash(2) {
+		t.Error("Expected the boosted block to be head")
+	}
+	wanted := 640 / params.BeaconConfig().SlotsPerEpoch * 2
+	if w := f.Node(indexToHash(2)).Weight; w != wanted {
+		t.Errorf("Wanted boosted weight %d, received %d", wanted, w)
+	}
+	// The boost is applied once across head computations.
+	f.ResetProposerBoost(2)
+	if _, err := f.Head(ctx, 1, zeroHash, balances, 1); err != nil {
+		t.Fatal(err)
+	}
+	if w := f.Node(indexToHash(2)).Weight; w != wanted {
+		t.Errorf("Wanted boosted weight %d, received %d", wanted, w)
+	}
+
+	f.ResetProposerBoost(3)
+	r, err = f.Head(ctx, 1, zeroHash, balances, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r != indexToHash(1) {
+		t.Error("Expected the attested block to be head once the boost expired")
+	}
+	if w := f.Node(indexToHash(2)).Weight; w != 0 {
+		t.Errorf("Wanted the boost to be removed, received weight %d", w)
+	}
+}
+
+func TestWeighBalances_ScalesVotes(t *testing.T) {
+	ctx := context.Background()
+	f := setup(1, 1)
+	f.store.weights = Weights{AttestationPercent: 50}
+	if err := f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	f.ProcessAttestation(ctx, []uint64{0, 1}, indexToHash(1), 2)
+	if _, err := f.Head(ctx, 1, params.BeaconConfig().ZeroHash, []uint64{10, 20}, 1); err != nil {
+		t.Fatal(err)
+	}
+	if w := f.Node(indexToHash(1)).Weight; w != 15 {
+		t.Errorf("Wanted half of the voting balance 15, received %d", w)
+	}
+	// Without the proposer boost, boosting a block does nothing.
+	f.ProcessProposerBoost(indexToHash(1))
+	if f.store.proposerBoostRoot != params.BeaconConfig().ZeroHash {
+		t.Error("Expected no proposer boost when disabled")
+	}
+}
@@ -0,0 +1,18 @@
+package forkchoice
+
+import (
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+)
+
+// WeightsFromConfig returns the fork choice weights set by the feature config. An unset
+// attestation weight keeps the full balance of validators.
+func WeightsFromConfig() protoarray.Weights {
+	weights := protoarray.DefaultWeights()
+	cfg := featureconfig.Get()
+	if cfg.ForkChoiceAttestationWeight > 0 {
+		weights.AttestationPercent = cfg.ForkChoiceAttestationWeight
+	}
+	weights.ProposerBoostPercent = cfg.ForkChoiceProposerBoost
+	return weights
+}
@@ -0,0 +1,39 @@
+// Package fuzz defines go-fuzz compatible entry points for the state transition
+// and the p2p request/response decoders. Each entry point returns 1 when the input
+// was decoded into a well formed object, so the fuzzer prioritizes it, and 0
+// otherwise. Any panic is a bug.
+package fuzz
+
+import (
+	"sync"
+
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/interop"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// fuzzValidatorCount is the number of validators in the genesis state fuzzed
+// inputs are processed against.
+const fuzzValidatorCount = 64
+
+var (
+	genesisOnce  sync.Once
+	genesisState *stateTrie.BeaconState
+)
+
+// baseState returns a copy of a deterministic genesis state under the minimal
+// config. The genesis state is generated once and reused across inputs.
+func baseState() *stateTrie.BeaconState {
+	genesisOnce.Do(func() {
+		params.OverrideBeaconConfig(params.MinimalSpecConfig())
+		st, _, err := interop.GenerateGenesisState(1 /*genesisTime*/, fuzzValidatorCount)
+		if err != nil {
+			panic(err)
+		}
+		genesisState, err = stateTrie.InitializeFromProto(st)
+		if err != nil {
+			panic(err)
+		}
+	})
+	return genesisState.Copy()
+}
@@ -0,0 +1,113 @@
+package fuzz
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+func TestBeaconFuzzBlock_MalformedInputs(t *testing.T) {
+	for _, input := range [][]byte{nil, {}, {0x01}, bytes.Repeat([]byte{0xff}, 512)} {
+		if BeaconFuzzBlock(input) != 0 {
+			t.Errorf("Expected malformed input %#x to be rejected", input)
+		}
+	}
+}
+
+func TestBeaconFuzzBlock_EmptyBlockDoesNotPanic(t *testing.T) {
+	blk := &ethpb.SignedBeaconBlock{
+		Block: &ethpb.BeaconBlock{
+			Slot: 1,
+			Body: &ethpb.BeaconBlockBody{
+				Eth1Data: &ethpb.Eth1Data{},
+			},
+		},
+	}
+	enc, err := ssz.Marshal(blk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The block has no valid signature or randao reveal, so it is rejected by
+	// the state transition, but processing it must not panic.
+	BeaconFuzzBlock(enc)
+}
+
+func TestBeaconFuzzAttestation_EmptyAttestationDoesNotPanic(t *testing.T) {
+	att := &ethpb.Attestation{
+		Data: &ethpb.AttestationData{
+			Source: &ethpb.Checkpoint{},
+			Target: &ethpb.Checkpoint{},
+		},
+	}
+	enc, err := ssz.Marshal(att)
+	if err != nil {
+		t.Fatal(err)
+	}
+	BeaconFuzzAttestation(enc)
+	BeaconFuzzAttestation(enc[:len(enc)/2])
+}
+
+func TestBeaconFuzzRPCDecoders_AcceptsStatus(t *testing.T) {
+	buf := new(bytes.Buffer)
+	e := encoder.SszNetworkEncoder{UseSnappyCompression: true}
+	if _, err := e.EncodeWithLength(buf, &pb.Status{HeadSlot: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if BeaconFuzzRPCDecoders(buf.Bytes()) != 1 {
+		t.Error("Expected encoded status message to be decoded")
+	}
+	BeaconFuzzRPCDecoders(buf.Bytes()[:buf.Len()-1])
+}
+
+func TestBeaconFuzzGossipDecoders_AcceptsExit(t *testing.T) {
+	exit := &ethpb.SignedVoluntaryExit{Exit: &ethpb.VoluntaryExit{Epoch: 1}, Signature: make([]byte, 96)}
+	enc, err := ssz.Marshal(exit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if BeaconFuzzGossipDecoders(enc) != 1 {
+		t.Error("Expected encoded voluntary exit to be decoded")
+	}
+}
+
+func TestCorpusSeeds_Decode(t *testing.T) {
+	decoders := map[string]func([]byte) bool{
+		"block": func(b []byte) bool {
+			return ssz.Unmarshal(b, &ethpb.SignedBeaconBlock{}) == nil
+		},
+		"attestation": func(b []byte) bool {
+			return ssz.Unmarshal(b, &ethpb.Attestation{}) == nil
+		},
+		"rpc": func(b []byte) bool {
+			return BeaconFuzzRPCDecoders(b) == 1
+		},
+		"gossip": func(b []byte) bool {
+			return BeaconFuzzGossipDecoders(b) == 1
+		},
+	}
+	for target, decode := range decoders {
+		dir := filepath.Join("testdata", target, "corpus")
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(files) == 0 {
+			t.Errorf("No corpus seeds for %s", target)
+		}
+		for _, f := range files {
+			b, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !decode(b) {
+				t.Errorf("Corpus seed %s of %s does not decode", f.Name(), target)
+			}
+		}
+	}
+}
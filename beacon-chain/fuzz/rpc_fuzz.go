@@ -0,0 +1,63 @@
+package fuzz
+
+import (
+	"bytes"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+var encoders = []encoder.NetworkEncoding{
+	encoder.SszNetworkEncoder{UseSnappyCompression: false},
+	encoder.SszNetworkEncoder{UseSnappyCompression: true},
+}
+
+// BeaconFuzzRPCDecoders decodes the input as each length prefixed p2p
+// request and response message type, with and without snappy compression.
+func BeaconFuzzRPCDecoders(b []byte) int {
+	result := 0
+	for _, e := range encoders {
+		for _, msg := range rpcMessages() {
+			if err := e.DecodeWithLength(bytes.NewReader(b), msg); err == nil {
+				result = 1
+			}
+		}
+	}
+	return result
+}
+
+// BeaconFuzzGossipDecoders decodes the input as each gossip message type,
+// with and without snappy compression.
+func BeaconFuzzGossipDecoders(b []byte) int {
+	result := 0
+	for _, e := range encoders {
+		for _, msg := range gossipMessages() {
+			if err := e.Decode(b, msg); err == nil {
+				result = 1
+			}
+		}
+	}
+	return result
+}
+
+func rpcMessages() []interface{} {
+	return []interface{}{
+		&pb.Status{},
+		new(uint64),
+		&pb.BeaconBlocksByRangeRequest{},
+		&[][32]byte{},
+		&ethpb.SignedBeaconBlock{},
+	}
+}
+
+func gossipMessages() []interface{} {
+	return []interface{}{
+		&ethpb.SignedBeaconBlock{},
+		&ethpb.Attestation{},
+		&ethpb.AggregateAttestationAndProof{},
+		&ethpb.SignedVoluntaryExit{},
+		&ethpb.ProposerSlashing{},
+		&ethpb.AttesterSlashing{},
+	}
+}
@@ -0,0 +1,53 @@
+package fuzz
+
+import (
+	"context"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// maxSlotsToProcess bounds the number of empty slots processed before a fuzzed
+// block, so that an input with a far future slot does not stall the fuzzer.
+const maxSlotsToProcess = 2
+
+// BeaconFuzzBlock processes an SSZ encoded signed beacon block against the
+// genesis state.
+func BeaconFuzzBlock(b []byte) int {
+	blk := &ethpb.SignedBeaconBlock{}
+	if err := ssz.Unmarshal(b, blk); err != nil {
+		return 0
+	}
+	if blk.Block == nil || blk.Block.Body == nil {
+		return 0
+	}
+	ctx := context.Background()
+	st := baseState()
+	if blk.Block.Slot > st.Slot() && blk.Block.Slot-st.Slot() <= maxSlotsToProcess*params.BeaconConfig().SlotsPerEpoch {
+		var err error
+		st, err = state.ProcessSlots(ctx, st, blk.Block.Slot)
+		if err != nil {
+			return 0
+		}
+	}
+	if _, err := state.ProcessBlock(ctx, st, blk); err != nil {
+		return 0
+	}
+	return 1
+}
+
+// BeaconFuzzAttestation processes an SSZ encoded attestation against the
+// genesis state, without verifying its signature.
+func BeaconFuzzAttestation(b []byte) int {
+	att := &ethpb.Attestation{}
+	if err := ssz.Unmarshal(b, att); err != nil {
+		return 0
+	}
+	if _, err := blocks.ProcessAttestationNoVerify(context.Background(), baseState(), att); err != nil {
+		return 0
+	}
+	return 1
+}
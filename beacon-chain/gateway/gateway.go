@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	gwruntime "github.com/grpc-ecosystem/grpc-gateway/runtime"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1_gateway"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/apikey"
 	"github.com/prysmaticlabs/prysm/shared"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
@@ -47,7 +49,11 @@ func (g *Gateway) Start() {
 
 	g.conn = conn
 
-	gwmux := gwruntime.NewServeMux(gwruntime.WithMarshalerOption(gwruntime.MIMEWildcard, &gwruntime.JSONPb{OrigName: false, EmitDefaults: true}))
+	gwmux := gwruntime.NewServeMux(
+		gwruntime.WithMarshalerOption(gwruntime.MIMEWildcard, &gwruntime.JSONPb{OrigName: false, EmitDefaults: true}),
+		gwruntime.WithMarshalerOption(MIMESSZ, &sszMarshaler{&gwruntime.JSONPb{OrigName: false, EmitDefaults: true}}),
+		gwruntime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+	)
 	for _, f := range []func(context.Context, *gwruntime.ServeMux, *grpc.ClientConn) error{
 		ethpb.RegisterNodeHandler,
 		ethpb.RegisterBeaconChainHandler,
@@ -103,6 +109,15 @@ func (g *Gateway) Stop() error {
 	return nil
 }
 
+// incomingHeaderMatcher forwards the API key header of HTTP requests to the gRPC server, along
+// with the headers forwarded by default.
+func incomingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, apikey.MetadataKey) {
+		return apikey.MetadataKey, true
+	}
+	return gwruntime.DefaultHeaderMatcher(key)
+}
+
 // New returns a new gateway server which translates HTTP into gRPC.
 // Accepts a context and optional http.ServeMux.
 func New(ctx context.Context, remoteAddress, gatewayAddress string, mux *http.ServeMux) *Gateway {
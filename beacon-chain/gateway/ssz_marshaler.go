@@ -0,0 +1,89 @@
+package gateway
+
+import (
+	"errors"
+	"io"
+	"reflect"
+
+	gogoproto "github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/proto"
+	gwruntime "github.com/grpc-ecosystem/grpc-gateway/runtime"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+)
+
+// MIMESSZ is the content type requested with the Accept header to receive SSZ encoded
+// responses from the gateway instead of JSON.
+const MIMESSZ = "application/octet-stream"
+
+var errNoSSZEncoding = errors.New("message has no SSZ encoding")
+
+// sszMarshaler encodes the blocks of gateway responses as SSZ, the encoding other eth2 tooling
+// consumes. ListBlocks responses are encoded as an SSZ list of their signed blocks, dropping the
+// block roots and pagination fields, and responses without an SSZ encoding, such as errors,
+// fall back to JSON. Request bodies are always decoded as JSON.
+type sszMarshaler struct {
+	*gwruntime.JSONPb
+}
+
+// ContentType of the SSZ responses.
+func (m *sszMarshaler) ContentType() string {
+	return MIMESSZ
+}
+
+// Marshal a response as SSZ, or as JSON if it has no SSZ encoding.
+func (m *sszMarshaler) Marshal(v interface{}) ([]byte, error) {
+	enc, err := marshalSSZ(v)
+	if err == errNoSSZEncoding {
+		return m.JSONPb.Marshal(v)
+	}
+	return enc, err
+}
+
+// NewEncoder returns an encoder writing SSZ responses to w.
+func (m *sszMarshaler) NewEncoder(w io.Writer) gwruntime.Encoder {
+	return gwruntime.EncoderFunc(func(v interface{}) error {
+		enc, err := m.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(enc)
+		return err
+	})
+}
+
+func marshalSSZ(v interface{}) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, errNoSSZEncoding
+	}
+	// The gateway messages don't carry the SSZ struct tags, so they are converted to the
+	// equivalent beacon chain types through their shared protobuf encoding.
+	t := gogoproto.MessageType(proto.MessageName(msg))
+	if t == nil || t.Kind() != reflect.Ptr {
+		return nil, errNoSSZEncoding
+	}
+	enc, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	converted, ok := reflect.New(t.Elem()).Interface().(gogoproto.Message)
+	if !ok {
+		return nil, errNoSSZEncoding
+	}
+	if err := gogoproto.Unmarshal(enc, converted); err != nil {
+		return nil, err
+	}
+	switch m := converted.(type) {
+	case *ethpb.ListBlocksResponse:
+		blocks := make([]*ethpb.SignedBeaconBlock, len(m.BlockContainers))
+		for i, c := range m.BlockContainers {
+			blocks[i] = c.Block
+		}
+		return ssz.Marshal(blocks)
+	case *ethpb.SignedBeaconBlock, *ethpb.BeaconBlock:
+		return ssz.Marshal(m)
+	default:
+		return nil, errNoSSZEncoding
+	}
+}
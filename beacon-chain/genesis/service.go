@@ -0,0 +1,127 @@
+// Package genesis defines a service which tracks the deposits made to the deposit
+// contract before the chain starts and counts down to the genesis time.
+package genesis
+
+import (
+	"context"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
+	"github.com/prysmaticlabs/prysm/shared"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+	"github.com/sirupsen/logrus"
+)
+
+var _ = shared.Service(&Service{})
+
+// depositsLogInterval is how often the deposit progress is logged before chain start.
+const depositsLogInterval = 5 * time.Minute
+
+// Service logs the progress of the deposit contract towards the genesis conditions and,
+// once they are met, counts down to the genesis time.
+type Service struct {
+	ctx           context.Context
+	cancel        context.CancelFunc
+	statusFetcher powchain.GenesisStatusFetcher
+}
+
+// Config options for the genesis countdown service.
+type Config struct {
+	GenesisStatusFetcher powchain.GenesisStatusFetcher
+}
+
+// NewService creates a new genesis countdown service.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		ctx:           ctx,
+		cancel:        cancel,
+		statusFetcher: cfg.GenesisStatusFetcher,
+	}
+}
+
+// Start the countdown.
+func (s *Service) Start() {
+	cfg := params.BeaconConfig()
+	log.WithFields(logrus.Fields{
+		"minGenesisTime":                 time.Unix(int64(cfg.MinGenesisTime), 0),
+		"genesisDelay":                   time.Duration(featureconfig.Get().CustomGenesisDelay) * time.Second,
+		"minGenesisActiveValidatorCount": cfg.MinGenesisActiveValidatorCount,
+	}).Info("Genesis parameters")
+	go s.run()
+}
+
+// Stop the countdown.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status always returns nil.
+func (s *Service) Status() error {
+	return nil
+}
+
+func (s *Service) run() {
+	for {
+		next, done := s.logStatus(roughtime.Now())
+		if done {
+			return
+		}
+		select {
+		case <-time.After(next):
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// logStatus logs the deposit progress or the time left until genesis. It returns the delay
+// until the next update, or true once the genesis time was reached.
+func (s *Service) logStatus(now time.Time) (time.Duration, bool) {
+	status := s.statusFetcher.GenesisStatus()
+	genesis := time.Unix(int64(status.GenesisTime), 0)
+	if !status.Chainstarted {
+		log.WithFields(logrus.Fields{
+			"deposits":            status.DepositCount,
+			"totalDepositsETH":    status.TotalDeposits / params.BeaconConfig().GweiPerEth,
+			"activeValidators":    status.ActiveValidatorCount,
+			"requiredValidators":  params.BeaconConfig().MinGenesisActiveValidatorCount,
+			"earliestGenesisTime": genesis,
+		}).Info("Waiting for genesis conditions to be met")
+		return depositsLogInterval, false
+	}
+	remaining := genesis.Sub(now)
+	if remaining <= 0 {
+		// Only announce genesis if the countdown is ending, not on restarts of a running chain.
+		if -remaining < time.Duration(params.BeaconConfig().SecondsPerSlot)*time.Second {
+			log.WithField("genesisTime", genesis).Info("Chain genesis time reached")
+		}
+		return 0, true
+	}
+	log.WithFields(logrus.Fields{
+		"genesisTime":      genesis,
+		"activeValidators": status.ActiveValidatorCount,
+	}).Infof("%s until chain genesis", remaining.Round(time.Second))
+	next := countdownInterval(remaining)
+	if next > remaining {
+		next = remaining
+	}
+	return next, false
+}
+
+// countdownInterval logs the countdown more often as the genesis time approaches.
+func countdownInterval(remaining time.Duration) time.Duration {
+	switch {
+	case remaining > 24*time.Hour:
+		return time.Hour
+	case remaining > time.Hour:
+		return 10 * time.Minute
+	case remaining > 10*time.Minute:
+		return time.Minute
+	default:
+		return 10 * time.Second
+	}
+}
@@ -0,0 +1,66 @@
+package genesis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+type mockStatusFetcher struct {
+	status *powchain.GenesisStatus
+}
+
+func (m *mockStatusFetcher) GenesisStatus() *powchain.GenesisStatus {
+	return m.status
+}
+
+func TestLogStatus_WaitingForDeposits(t *testing.T) {
+	hook := logTest.NewGlobal()
+	s := NewService(context.Background(), &Config{
+		GenesisStatusFetcher: &mockStatusFetcher{status: &powchain.GenesisStatus{DepositCount: 10}},
+	})
+	next, done := s.logStatus(time.Now())
+	if done {
+		t.Error("Expected countdown not to be done before chain start")
+	}
+	if next != depositsLogInterval {
+		t.Errorf("Wanted next update in %v, got %v", depositsLogInterval, next)
+	}
+	testutil.AssertLogsContain(t, hook, "Waiting for genesis conditions to be met")
+}
+
+func TestLogStatus_Countdown(t *testing.T) {
+	hook := logTest.NewGlobal()
+	now := time.Now()
+	fetcher := &mockStatusFetcher{status: &powchain.GenesisStatus{
+		Chainstarted: true,
+		GenesisTime:  uint64(now.Add(2 * time.Hour).Unix()),
+	}}
+	s := NewService(context.Background(), &Config{GenesisStatusFetcher: fetcher})
+
+	next, done := s.logStatus(now)
+	if done {
+		t.Fatal("Expected countdown not to be done before genesis")
+	}
+	if next != 10*time.Minute {
+		t.Errorf("Wanted next update in 10m, got %v", next)
+	}
+	testutil.AssertLogsContain(t, hook, "until chain genesis")
+
+	next, done = s.logStatus(now.Add(2*time.Hour - 5*time.Second))
+	if done {
+		t.Fatal("Expected countdown not to be done before genesis")
+	}
+	if next > 5*time.Second {
+		t.Errorf("Wanted next update at genesis, got %v", next)
+	}
+
+	if _, done := s.logStatus(now.Add(2*time.Hour + time.Second)); !done {
+		t.Error("Expected countdown to be done after genesis")
+	}
+	testutil.AssertLogsContain(t, hook, "Chain genesis time reached")
+}
@@ -16,6 +16,7 @@ import (
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared"
 	"github.com/prysmaticlabs/prysm/shared/interop"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
 )
 
 var _ = shared.Service(&Service{})
@@ -146,11 +147,21 @@ func (s *Service) DepositByPubkey(ctx context.Context, pubKey []byte) (*ethpb.De
 	return &ethpb.Deposit{}, big.NewInt(1)
 }
 
+// DepositsByPubkey mocks out the deposit cache functionality for interop.
+func (s *Service) DepositsByPubkey(ctx context.Context, pubKey []byte) []*depositcache.ValidatorDeposit {
+	return nil
+}
+
 // DepositsNumberAndRootAtHeight mocks out the deposit cache functionality for interop.
 func (s *Service) DepositsNumberAndRootAtHeight(ctx context.Context, blockHeight *big.Int) (uint64, [32]byte) {
 	return 0, [32]byte{}
 }
 
+// DepositSnapshot mocks out the deposit cache functionality for interop.
+func (s *Service) DepositSnapshot() *trieutil.DepositTreeSnapshot {
+	return nil
+}
+
 func (s *Service) saveGenesisState(ctx context.Context, genesisState *stateTrie.BeaconState) error {
 	s.chainStartDeposits = make([]*ethpb.Deposit, genesisState.NumValidators())
 	stateRoot, err := genesisState.HashTreeRoot()
@@ -184,7 +195,10 @@ func (s *Service) saveGenesisState(ctx context.Context, genesisState *stateTrie.
 	}
 
 	for i := uint64(0); i < uint64(genesisState.NumValidators()); i++ {
-		pk := genesisState.PubkeyAtIndex(i)
+		pk, err := genesisState.PubkeyAtIndex(i)
+		if err != nil {
+			return errors.Wrapf(err, "could not get validator public key: %d", i)
+		}
 		if err := s.beaconDB.SaveValidatorIndex(ctx, pk[:], i); err != nil {
 			return errors.Wrapf(err, "could not save validator index: %d", i)
 		}
@@ -0,0 +1,222 @@
+package lightclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "lightclient")
+
+// maxStoredUpdates is the number of finalized epochs for which updates are kept.
+const maxStoredUpdates = 1024
+
+// UpdateFetcher retrieves light client updates.
+type UpdateFetcher interface {
+	LatestUpdate() *Update
+	UpdateAtFinalizedEpoch(epoch uint64) *Update
+}
+
+// Service builds a light client update every time the finalized checkpoint of
+// the head state advances, and keeps the most recent ones in memory to serve
+// them to light clients.
+type Service struct {
+	ctx                 context.Context
+	cancel              context.CancelFunc
+	beaconDB            db.ReadOnlyDatabase
+	headFetcher         blockchain.HeadFetcher
+	finalizationFetcher blockchain.FinalizationFetcher
+	stateNotifier       statefeed.Notifier
+	lock                sync.RWMutex
+	updates             map[uint64]*Update
+	latest              *Update
+}
+
+// Config options for the light client service.
+type Config struct {
+	BeaconDB            db.ReadOnlyDatabase
+	HeadFetcher         blockchain.HeadFetcher
+	FinalizationFetcher blockchain.FinalizationFetcher
+	StateNotifier       statefeed.Notifier
+}
+
+// NewService initializes the service from configuration options.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		ctx:                 ctx,
+		cancel:              cancel,
+		beaconDB:            cfg.BeaconDB,
+		headFetcher:         cfg.HeadFetcher,
+		finalizationFetcher: cfg.FinalizationFetcher,
+		stateNotifier:       cfg.StateNotifier,
+		updates:             make(map[uint64]*Update),
+	}
+}
+
+// Start the light client service event loop.
+func (s *Service) Start() {
+	go s.run(s.ctx)
+}
+
+// Stop the light client service event loop.
+func (s *Service) Stop() error {
+	defer s.cancel()
+	return nil
+}
+
+// Status reports the healthy status of the light client service. Returning nil
+// means service is correctly running without error.
+func (s *Service) Status() error {
+	return nil
+}
+
+// LatestUpdate returns the update with the highest finalized epoch, or nil if
+// no update has been built yet.
+func (s *Service) LatestUpdate() *Update {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.latest
+}
+
+// UpdateAtFinalizedEpoch returns the first update built for the finalized
+// epoch, or nil if there is none.
+func (s *Service) UpdateAtFinalizedEpoch(epoch uint64) *Update {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.updates[epoch]
+}
+
+// UpdateHandler is a handler to serve the /light_client/update page in metrics.
+// The update at the finalized_epoch query parameter is returned, or the latest
+// update if the parameter is not set.
+func (s *Service) UpdateHandler(w http.ResponseWriter, r *http.Request) {
+	var update *Update
+	if q := r.URL.Query().Get("finalized_epoch"); q != "" {
+		epoch, err := strconv.ParseUint(q, 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid finalized_epoch parameter", http.StatusBadRequest)
+			return
+		}
+		update = s.UpdateAtFinalizedEpoch(epoch)
+	} else {
+		update = s.LatestUpdate()
+	}
+	if update == nil {
+		http.Error(w, "No light client update available", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(update); err != nil {
+		log.WithError(err).Error("Failed to write light client update")
+	}
+}
+
+// processHead builds an update from the current head if its finalized
+// checkpoint is newer than the one of the latest update.
+func (s *Service) processHead(ctx context.Context) error {
+	if !s.finalityAdvanced(s.finalizationFetcher.FinalizedCheckpt()) {
+		return nil
+	}
+	// The state is loaded by the root of the head block, rather than fetched as the head state,
+	// so the header of the update matches the state even if the head changes in between.
+	headBlock, err := s.headFetcher.HeadBlock(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get head block")
+	}
+	if headBlock == nil || headBlock.Block == nil {
+		return errors.New("nil head block")
+	}
+	headRoot, err := ssz.HashTreeRoot(headBlock.Block)
+	if err != nil {
+		return errors.Wrap(err, "could not get head block root")
+	}
+	headState, err := s.beaconDB.State(ctx, headRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not get head state")
+	}
+	if headState == nil {
+		return errors.New("head state not found")
+	}
+	finalized := headState.FinalizedCheckpoint()
+	if !s.finalityAdvanced(finalized) {
+		return nil
+	}
+	finalizedBlock, err := s.beaconDB.Block(ctx, bytesutil.ToBytes32(finalized.Root))
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized block")
+	}
+	if finalizedBlock == nil || finalizedBlock.Block == nil {
+		return errors.New("finalized block not found")
+	}
+	update, err := NewUpdate(headBlock.Block, headState.InnerStateUnsafe(), finalizedBlock.Block)
+	if err != nil {
+		return err
+	}
+	s.saveUpdate(update)
+	log.WithField("finalizedEpoch", update.FinalizedEpoch).Debug("Saved light client update")
+	return nil
+}
+
+// finalityAdvanced returns whether the finalized checkpoint is newer than the one of the latest
+// update. There is no finalized block to prove before the first finalized epoch.
+func (s *Service) finalityAdvanced(finalized *ethpb.Checkpoint) bool {
+	if finalized == nil || finalized.Epoch == 0 {
+		return false
+	}
+	latest := s.LatestUpdate()
+	return latest == nil || latest.FinalizedEpoch < finalized.Epoch
+}
+
+// saveUpdate stores the update as the latest one, pruning the oldest update
+// once more than maxStoredUpdates are kept.
+func (s *Service) saveUpdate(update *Update) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.updates[update.FinalizedEpoch] = update
+	s.latest = update
+	if len(s.updates) > maxStoredUpdates {
+		oldest := update.FinalizedEpoch
+		for epoch := range s.updates {
+			if epoch < oldest {
+				oldest = epoch
+			}
+		}
+		delete(s.updates, oldest)
+	}
+}
+
+func (s *Service) run(ctx context.Context) {
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := s.stateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	for {
+		select {
+		case event := <-stateChannel:
+			if event.Type == statefeed.BlockProcessed {
+				if err := s.processHead(ctx); err != nil {
+					log.WithError(err).Error("Could not build light client update")
+				}
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting goroutine")
+			return
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Subscription to state feed notifier failed")
+			return
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package lightclient
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+)
+
+func TestService_ProcessHead(t *testing.T) {
+	db := dbutil.SetupDB(t)
+	defer dbutil.TeardownDB(t, db)
+	ctx := context.Background()
+
+	block, state, finalizedBlock := testChain(t)
+	if err := db.SaveBlock(ctx, &ethpb.SignedBeaconBlock{Block: finalizedBlock}); err != nil {
+		t.Fatal(err)
+	}
+	headState, err := stateTrie.InitializeFromProto(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	headRoot, err := ssz.HashTreeRoot(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, headState, headRoot); err != nil {
+		t.Fatal(err)
+	}
+	chain := &mock.ChainService{
+		Block:               &ethpb.SignedBeaconBlock{Block: block},
+		FinalizedCheckPoint: state.FinalizedCheckpoint,
+	}
+	s := NewService(ctx, &Config{
+		BeaconDB:            db,
+		HeadFetcher:         chain,
+		FinalizationFetcher: chain,
+	})
+	if err := s.processHead(ctx); err != nil {
+		t.Fatal(err)
+	}
+	latest := s.LatestUpdate()
+	if latest == nil {
+		t.Fatal("Expected an update to be saved")
+	}
+	if s.UpdateAtFinalizedEpoch(1) != latest {
+		t.Error("Expected update to be stored at finalized epoch 1")
+	}
+	if ok, err := VerifyUpdate(latest); err != nil || !ok {
+		t.Errorf("Expected saved update to verify, received %v, %v", ok, err)
+	}
+}
+
+func TestService_ProcessHeadWithoutNewFinality(t *testing.T) {
+	ctx := context.Background()
+	// The head is not read while the finalized checkpoint has not advanced.
+	chain := &mock.ChainService{FinalizedCheckPoint: &ethpb.Checkpoint{Epoch: 2}}
+	s := NewService(ctx, &Config{HeadFetcher: chain, FinalizationFetcher: chain})
+	s.saveUpdate(&Update{FinalizedEpoch: 2})
+	if err := s.processHead(ctx); err != nil {
+		t.Fatal(err)
+	}
+	chain.FinalizedCheckPoint = &ethpb.Checkpoint{Epoch: 0}
+	if err := s.processHead(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestService_SaveUpdatePrunesOldest(t *testing.T) {
+	s := NewService(context.Background(), &Config{})
+	for i := uint64(1); i <= maxStoredUpdates+1; i++ {
+		s.saveUpdate(&Update{FinalizedEpoch: i})
+	}
+	if len(s.updates) != maxStoredUpdates {
+		t.Errorf("Wanted %d stored updates, received %d", maxStoredUpdates, len(s.updates))
+	}
+	if s.UpdateAtFinalizedEpoch(1) != nil {
+		t.Error("Expected oldest update to be pruned")
+	}
+	if s.LatestUpdate().FinalizedEpoch != maxStoredUpdates+1 {
+		t.Errorf("Wanted latest finalized epoch %d, received %d", maxStoredUpdates+1, s.LatestUpdate().FinalizedEpoch)
+	}
+}
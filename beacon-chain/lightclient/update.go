@@ -0,0 +1,121 @@
+package lightclient
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
+)
+
+// finalityBranchDepth is the length of the merkle branch from the finalized
+// checkpoint root to the state root.
+const finalityBranchDepth = 6
+
+// Update is the minimal data a light client needs to follow the finalized
+// chain: the header of a block, the header of the block finalized in that
+// block's post-state, and the merkle branch proving the finalized header root
+// is the finalized checkpoint root committed to by the first header's state root.
+//
+// Sync committee data will be added once it is defined by the spec.
+type Update struct {
+	Header          *ethpb.BeaconBlockHeader `json:"header"`
+	FinalizedHeader *ethpb.BeaconBlockHeader `json:"finalized_header"`
+	FinalizedEpoch  uint64                   `json:"finalized_epoch"`
+	FinalityBranch  [][]byte                 `json:"finality_branch" ssz-size:"6,32"`
+	// FinalityIndex is the merkle index of the finalized root in the state,
+	// to verify FinalityBranch with.
+	FinalityIndex uint64 `json:"finality_index"`
+}
+
+// UpdateRequest is the req/resp request for a light client update. A zero
+// FinalizedEpoch requests the latest update.
+type UpdateRequest struct {
+	FinalizedEpoch uint64
+}
+
+// NewUpdate builds the light client update for a block, its post-state and
+// the block of the state's finalized checkpoint.
+func NewUpdate(
+	block *ethpb.BeaconBlock, postState *pb.BeaconState, finalizedBlock *ethpb.BeaconBlock,
+) (*Update, error) {
+	if block == nil || postState == nil || finalizedBlock == nil || postState.FinalizedCheckpoint == nil {
+		return nil, errors.New("nil block, state or finalized block")
+	}
+	header, err := BlockHeader(block)
+	if err != nil {
+		return nil, err
+	}
+	finalizedHeader, err := BlockHeader(finalizedBlock)
+	if err != nil {
+		return nil, err
+	}
+	proof, index, err := stateutil.FinalizedRootProof(postState)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute finality branch")
+	}
+	branch := make([][]byte, len(proof))
+	for i := range proof {
+		branch[i] = make([]byte, 32)
+		copy(branch[i], proof[i][:])
+	}
+	return &Update{
+		Header:          header,
+		FinalizedHeader: finalizedHeader,
+		FinalizedEpoch:  postState.FinalizedCheckpoint.Epoch,
+		FinalityBranch:  branch,
+		FinalityIndex:   index,
+	}, nil
+}
+
+// BlockHeader returns the header of a block, which has the same hash tree root
+// as the block itself.
+func BlockHeader(block *ethpb.BeaconBlock) (*ethpb.BeaconBlockHeader, error) {
+	if block == nil || block.Body == nil {
+		return nil, errors.New("nil block")
+	}
+	bodyRoot, err := ssz.HashTreeRoot(block.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not hash block body")
+	}
+	return &ethpb.BeaconBlockHeader{
+		Slot:       block.Slot,
+		ParentRoot: block.ParentRoot,
+		StateRoot:  block.StateRoot,
+		BodyRoot:   bodyRoot[:],
+	}, nil
+}
+
+// VerifyUpdate checks that the finalized header of the update is committed to
+// by the state root of its header at the finalized epoch of the update. The
+// finality index of the update must be the one of the finalized root, else the
+// branch could prove any other leaf of the state.
+func VerifyUpdate(update *Update) (bool, error) {
+	if update == nil || update.Header == nil || update.FinalizedHeader == nil {
+		return false, errors.New("nil update")
+	}
+	if update.FinalityIndex != stateutil.FinalizedRootIndex {
+		return false, errors.Errorf("wanted finality index %d, received %d", stateutil.FinalizedRootIndex, update.FinalityIndex)
+	}
+	if len(update.FinalityBranch) != finalityBranchDepth {
+		return false, errors.Errorf("wanted finality branch of length %d, received %d", finalityBranchDepth, len(update.FinalityBranch))
+	}
+	// The first sibling of the finalized root is the finalized checkpoint epoch.
+	epochRoot := stateutil.Uint64Root(update.FinalizedEpoch)
+	if !bytes.Equal(update.FinalityBranch[0], epochRoot[:]) {
+		return false, nil
+	}
+	finalizedRoot, err := stateutil.BlockHeaderRoot(update.FinalizedHeader)
+	if err != nil {
+		return false, errors.Wrap(err, "could not hash finalized header")
+	}
+	return trieutil.VerifyMerkleBranch(
+		update.Header.StateRoot,
+		finalizedRoot[:],
+		stateutil.FinalizedRootIndex,
+		update.FinalityBranch,
+	), nil
+}
@@ -0,0 +1,113 @@
+package lightclient
+
+import (
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/interop"
+)
+
+// testChain returns a finalized block, and a block with its post-state finalizing it.
+func testChain(t *testing.T) (*ethpb.BeaconBlock, *pb.BeaconState, *ethpb.BeaconBlock) {
+	finalizedBlock := &ethpb.BeaconBlock{
+		Slot:       8,
+		ParentRoot: make([]byte, 32),
+		StateRoot:  make([]byte, 32),
+		Body:       &ethpb.BeaconBlockBody{RandaoReveal: make([]byte, 96), Graffiti: []byte{'A'}},
+	}
+	finalizedRoot, err := ssz.HashTreeRoot(finalizedBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, _, err := interop.GenerateGenesisState(0, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.Slot = 100
+	state.FinalizedCheckpoint = &ethpb.Checkpoint{Epoch: 1, Root: finalizedRoot[:]}
+	stateRoot, err := stateutil.HashTreeRootState(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := &ethpb.BeaconBlock{
+		Slot:       100,
+		ParentRoot: make([]byte, 32),
+		StateRoot:  stateRoot[:],
+		Body:       &ethpb.BeaconBlockBody{RandaoReveal: make([]byte, 96), Graffiti: []byte{'B'}},
+	}
+	return block, state, finalizedBlock
+}
+
+func TestBlockHeader_SameRootAsBlock(t *testing.T) {
+	block, _, _ := testChain(t)
+	header, err := BlockHeader(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	headerRoot, err := ssz.HashTreeRoot(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blockRoot, err := ssz.HashTreeRoot(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if headerRoot != blockRoot {
+		t.Errorf("Wanted header root %#x, received %#x", blockRoot, headerRoot)
+	}
+}
+
+func TestNewUpdate_Verifies(t *testing.T) {
+	block, state, finalizedBlock := testChain(t)
+	update, err := NewUpdate(block, state, finalizedBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update.FinalizedEpoch != 1 {
+		t.Errorf("Wanted finalized epoch 1, received %d", update.FinalizedEpoch)
+	}
+	ok, err := VerifyUpdate(update)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Error("Expected update to verify")
+	}
+
+	update.FinalizedEpoch = 2
+	if ok, err := VerifyUpdate(update); err != nil || ok {
+		t.Errorf("Expected update with wrong finalized epoch to fail verification, received %v, %v", ok, err)
+	}
+	update.FinalizedEpoch = 1
+	update.FinalizedHeader.Slot++
+	if ok, err := VerifyUpdate(update); err != nil || ok {
+		t.Errorf("Expected update with wrong finalized header to fail verification, received %v, %v", ok, err)
+	}
+	update.FinalizedHeader.Slot--
+	update.FinalityIndex++
+	if ok, err := VerifyUpdate(update); err == nil || ok {
+		t.Errorf("Expected update with another finality index to be rejected, received %v, %v", ok, err)
+	}
+}
+
+func TestUpdate_SSZRoundTrip(t *testing.T) {
+	block, state, finalizedBlock := testChain(t)
+	update, err := NewUpdate(block, state, finalizedBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc, err := ssz.Marshal(update)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := &Update{}
+	if err := ssz.Unmarshal(enc, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := VerifyUpdate(decoded); err != nil || !ok {
+		t.Errorf("Expected decoded update to verify, received %v, %v", ok, err)
+	}
+}
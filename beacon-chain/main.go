@@ -15,6 +15,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/debug"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/logutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/version"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
@@ -24,20 +25,43 @@ import (
 )
 
 var appFlags = []cli.Flag{
+	cmd.ConfigFileFlag,
 	flags.NoCustomConfigFlag,
+	flags.ChainConfigFileFlag,
 	flags.DepositContractFlag,
+	flags.ForceEth1ConfigFlag,
 	flags.Web3ProviderFlag,
 	flags.HTTPWeb3ProviderFlag,
 	flags.RPCHost,
 	flags.RPCPort,
 	flags.CertFlag,
 	flags.KeyFlag,
+	flags.ClientCAFlag,
+	flags.RPCAPIKeysFlag,
+	flags.ReadOnlyFlag,
 	flags.GRPCGatewayPort,
 	flags.MinSyncPeers,
 	flags.RPCMaxPageSize,
 	flags.ContractDeploymentBlock,
+	flags.DepositSnapshotFlag,
+	flags.BlocksDirFlag,
 	flags.SetGCPercent,
 	flags.UnsafeSync,
+	flags.ShuffledIndicesCacheSize,
+	flags.CommitteeAssignmentsCacheSize,
+	flags.PostStateCacheSize,
+	flags.MaxStateCacheMB,
+	flags.LateBlockParentWeight,
+	flags.OrphanedBlockRetentionEpochs,
+	flags.DBIntegrityCheckFlag,
+	flags.SlotsPerArchivedPoint,
+	flags.WatchdogStuckSlotsFlag,
+	flags.FinalityAlertEpochsFlag,
+	flags.StandbyPrimaryFlag,
+	flags.StandbyPrimaryCertFlag,
+	flags.ValidatorAccountingFlag,
+	flags.EpochSummaryWebhookURLFlag,
+	flags.TransitionDebugDirFlag,
 	flags.InteropMockEth1DataVotesFlag,
 	flags.InteropGenesisStateFlag,
 	flags.InteropNumValidatorsFlag,
@@ -53,11 +77,20 @@ var appFlags = []cli.Flag{
 	cmd.P2PUDPPort,
 	cmd.P2PTCPPort,
 	cmd.P2PIP,
+	cmd.P2PIP6,
 	cmd.P2PHost,
+	cmd.P2PHostIP6,
 	cmd.P2PHostDNS,
 	cmd.P2PMaxPeers,
 	cmd.P2PPrivKey,
 	cmd.P2PWhitelist,
+	cmd.P2PAllowList,
+	cmd.P2PDenyList,
+	cmd.P2PGossipD,
+	cmd.P2PGossipDlo,
+	cmd.P2PGossipDhi,
+	cmd.P2PGossipHeartbeat,
+	cmd.P2POutboundBandwidthBudget,
 	cmd.P2PEncoding,
 	cmd.DataDirFlag,
 	cmd.VerbosityFlag,
@@ -79,6 +112,7 @@ var appFlags = []cli.Flag{
 	debug.TraceFlag,
 	cmd.LogFileName,
 	cmd.EnableUPnPFlag,
+	cmd.MaxClockDisparityFlag,
 }
 
 func init() {
@@ -94,8 +128,31 @@ func main() {
 	app.Version = version.GetVersion()
 
 	app.Flags = appFlags
+	app.Commands = []cli.Command{
+		{
+			Name:  "verify-chain",
+			Usage: "replays the stored canonical chain and checks every computed state root against the state roots of the stored blocks",
+			Flags: []cli.Flag{
+				flags.VerifyChainStartSlotFlag,
+			},
+			Action: verifyChain,
+		},
+		{
+			Name:  "replay",
+			Usage: "re-runs a state transition written by --transition-debug-dir, logging every slot and block operation processed",
+			Flags: []cli.Flag{
+				flags.ReplayPreStateFlag,
+				flags.ReplayBlockFlag,
+			},
+			Action: replayTransition,
+		},
+		cmd.ConfigCommand(appFlags),
+	}
 
 	app.Before = func(ctx *cli.Context) error {
+		if err := cmd.LoadFlagsFromConfigFile(ctx, appFlags); err != nil {
+			return err
+		}
 		format := ctx.GlobalString(cmd.LogFormat.Name)
 		switch format {
 		case "text":
@@ -166,3 +223,23 @@ func startNode(ctx *cli.Context) error {
 	beacon.Start()
 	return nil
 }
+
+// configureChainParams applies the feature flags and chain parameters of the node, for
+// commands which process the chain outside of a running node.
+func configureChainParams(ctx *cli.Context) error {
+	featureconfig.ConfigureBeaconChain(ctx)
+	if !ctx.GlobalBool(flags.NoCustomConfigFlag.Name) {
+		if featureconfig.Get().MinimalConfig {
+			params.UseMinimalConfig()
+		} else {
+			params.UseDemoBeaconConfig()
+		}
+	}
+	if chainConfigFile := ctx.GlobalString(flags.ChainConfigFileFlag.Name); chainConfigFile != "" {
+		if err := params.LoadChainConfigFile(chainConfigFile); err != nil {
+			return err
+		}
+	}
+	featureconfig.ConfigureGenesisDelay(ctx)
+	return nil
+}
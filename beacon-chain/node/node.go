@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"os/signal"
 	"path"
@@ -16,23 +17,31 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/accounting"
 	"github.com/prysmaticlabs/prysm/beacon-chain/archiver"
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/epochsummary"
+	"github.com/prysmaticlabs/prysm/beacon-chain/finality"
 	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
 	"github.com/prysmaticlabs/prysm/beacon-chain/gateway"
+	"github.com/prysmaticlabs/prysm/beacon-chain/genesis"
 	interopcoldstart "github.com/prysmaticlabs/prysm/beacon-chain/interop-cold-start"
+	"github.com/prysmaticlabs/prysm/beacon-chain/lightclient"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc"
+	"github.com/prysmaticlabs/prysm/beacon-chain/standby"
 	prysmsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
 	initialsync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync"
+	"github.com/prysmaticlabs/prysm/beacon-chain/watchdog"
 	"github.com/prysmaticlabs/prysm/shared"
 	"github.com/prysmaticlabs/prysm/shared/cmd"
 	"github.com/prysmaticlabs/prysm/shared/debug"
@@ -41,6 +50,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/prometheus"
 	"github.com/prysmaticlabs/prysm/shared/sliceutil"
+	"github.com/prysmaticlabs/prysm/shared/slotutil"
 	"github.com/prysmaticlabs/prysm/shared/tracing"
 	"github.com/prysmaticlabs/prysm/shared/version"
 	"github.com/sirupsen/logrus"
@@ -49,7 +59,9 @@ import (
 
 var log = logrus.WithField("prefix", "node")
 
-const beaconChainDBName = "beaconchaindata"
+// BeaconChainDBName is the name of the beacon chain database directory in the data directory.
+const BeaconChainDBName = "beaconchaindata"
+
 const testSkipPowFlag = "test-skip-pow"
 
 // BeaconNode defines a struct that handles the services running a random beacon chain
@@ -84,6 +96,9 @@ func NewBeaconNode(ctx *cli.Context) (*BeaconNode, error) {
 		return nil, err
 	}
 	featureconfig.ConfigureBeaconChain(ctx)
+	if err := helpers.ValidateAggregationStrategy(featureconfig.Get().AttestationAggregationStrategy); err != nil {
+		return nil, err
+	}
 	flags.ConfigureGlobalFlags(ctx)
 	registry := shared.NewServiceRegistry()
 
@@ -101,6 +116,16 @@ func NewBeaconNode(ctx *cli.Context) (*BeaconNode, error) {
 			params.UseDemoBeaconConfig()
 		}
 	}
+	if chainConfigFile := ctx.GlobalString(flags.ChainConfigFileFlag.Name); chainConfigFile != "" {
+		if err := params.LoadChainConfigFile(chainConfigFile); err != nil {
+			return nil, err
+		}
+		log.WithField("file", chainConfigFile).Info("Loaded chain config overrides")
+	}
+	featureconfig.ConfigureGenesisDelay(ctx)
+	if err := configureSlotsPerArchivedPoint(ctx); err != nil {
+		return nil, err
+	}
 
 	beacon := &BeaconNode{
 		ctx:             ctx,
@@ -118,6 +143,10 @@ func NewBeaconNode(ctx *cli.Context) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	if err := beacon.registerClockService(ctx); err != nil {
+		return nil, err
+	}
+
 	if err := beacon.registerP2P(ctx); err != nil {
 		return nil, err
 	}
@@ -134,6 +163,10 @@ func NewBeaconNode(ctx *cli.Context) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	if err := beacon.registerGenesisService(ctx); err != nil {
+		return nil, err
+	}
+
 	beacon.startForkChoice()
 
 	if err := beacon.registerBlockchainService(ctx); err != nil {
@@ -144,10 +177,26 @@ func NewBeaconNode(ctx *cli.Context) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	if err := beacon.registerLightClientService(ctx); err != nil {
+		return nil, err
+	}
+
 	if err := beacon.registerSyncService(ctx); err != nil {
 		return nil, err
 	}
 
+	if err := beacon.registerWatchdogService(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := beacon.registerFinalityService(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := beacon.registerStandbyService(ctx); err != nil {
+		return nil, err
+	}
+
 	if err := beacon.registerRPCService(ctx); err != nil {
 		return nil, err
 	}
@@ -160,6 +209,14 @@ func NewBeaconNode(ctx *cli.Context) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	if err := beacon.registerAccountingService(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := beacon.registerEpochSummaryService(ctx); err != nil {
+		return nil, err
+	}
+
 	if !ctx.GlobalBool(cmd.DisableMonitoringFlag.Name) {
 		if err := beacon.registerPrometheusService(ctx); err != nil {
 			return nil, err
@@ -232,13 +289,13 @@ func (b *BeaconNode) Close() {
 }
 
 func (b *BeaconNode) startForkChoice() {
-	f := protoarray.New(0, 0, params.BeaconConfig().ZeroHash)
+	f := protoarray.NewWithWeights(0, 0, params.BeaconConfig().ZeroHash, forkchoice.WeightsFromConfig())
 	b.forkChoiceStore = f
 }
 
 func (b *BeaconNode) startDB(ctx *cli.Context) error {
 	baseDir := ctx.GlobalString(cmd.DataDirFlag.Name)
-	dbPath := path.Join(baseDir, beaconChainDBName)
+	dbPath := path.Join(baseDir, BeaconChainDBName)
 	clearDB := ctx.GlobalBool(cmd.ClearDB.Name)
 	forceClearDB := ctx.GlobalBool(cmd.ForceClearDB.Name)
 
@@ -267,11 +324,21 @@ func (b *BeaconNode) startDB(ctx *cli.Context) error {
 		}
 	}
 	log.WithField("database-path", dbPath).Info("Checking DB")
+	if ctx.GlobalBool(flags.DBIntegrityCheckFlag.Name) {
+		if err := d.CheckIntegrity(context.Background()); err != nil {
+			return errors.Wrap(err, "database integrity check failed")
+		}
+	}
 	b.db = d
 	b.depositCache = depositcache.NewDepositCache()
 	return nil
 }
 
+func (b *BeaconNode) registerClockService(ctx *cli.Context) error {
+	svc := slotutil.NewClockService(context.Background(), ctx.GlobalDuration(cmd.MaxClockDisparityFlag.Name))
+	return b.services.RegisterService(svc)
+}
+
 func (b *BeaconNode) registerP2P(ctx *cli.Context) error {
 	// Bootnode ENR may be a filepath to an ENR file.
 	bootnodeAddrs := strings.Split(ctx.GlobalString(cmd.BootstrapNode.Name), ",")
@@ -286,21 +353,31 @@ func (b *BeaconNode) registerP2P(ctx *cli.Context) error {
 	}
 
 	svc, err := p2p.NewService(&p2p.Config{
-		NoDiscovery:       ctx.GlobalBool(cmd.NoDiscovery.Name),
-		StaticPeers:       sliceutil.SplitCommaSeparated(ctx.GlobalStringSlice(cmd.StaticPeers.Name)),
-		BootstrapNodeAddr: bootnodeAddrs,
-		RelayNodeAddr:     ctx.GlobalString(cmd.RelayNode.Name),
-		DataDir:           ctx.GlobalString(cmd.DataDirFlag.Name),
-		LocalIP:           ctx.GlobalString(cmd.P2PIP.Name),
-		HostAddress:       ctx.GlobalString(cmd.P2PHost.Name),
-		HostDNS:           ctx.GlobalString(cmd.P2PHostDNS.Name),
-		PrivateKey:        ctx.GlobalString(cmd.P2PPrivKey.Name),
-		TCPPort:           ctx.GlobalUint(cmd.P2PTCPPort.Name),
-		UDPPort:           ctx.GlobalUint(cmd.P2PUDPPort.Name),
-		MaxPeers:          ctx.GlobalUint(cmd.P2PMaxPeers.Name),
-		WhitelistCIDR:     ctx.GlobalString(cmd.P2PWhitelist.Name),
-		EnableUPnP:        ctx.GlobalBool(cmd.EnableUPnPFlag.Name),
-		Encoding:          ctx.GlobalString(cmd.P2PEncoding.Name),
+		NoDiscovery:             ctx.GlobalBool(cmd.NoDiscovery.Name),
+		StaticPeers:             sliceutil.SplitCommaSeparated(ctx.GlobalStringSlice(cmd.StaticPeers.Name)),
+		BootstrapNodeAddr:       bootnodeAddrs,
+		RelayNodeAddr:           ctx.GlobalString(cmd.RelayNode.Name),
+		DataDir:                 ctx.GlobalString(cmd.DataDirFlag.Name),
+		LocalIP:                 ctx.GlobalString(cmd.P2PIP.Name),
+		LocalIP6:                ctx.GlobalString(cmd.P2PIP6.Name),
+		HostAddress:             ctx.GlobalString(cmd.P2PHost.Name),
+		HostAddress6:            ctx.GlobalString(cmd.P2PHostIP6.Name),
+		HostDNS:                 ctx.GlobalString(cmd.P2PHostDNS.Name),
+		PrivateKey:              ctx.GlobalString(cmd.P2PPrivKey.Name),
+		TCPPort:                 ctx.GlobalUint(cmd.P2PTCPPort.Name),
+		UDPPort:                 ctx.GlobalUint(cmd.P2PUDPPort.Name),
+		MaxPeers:                ctx.GlobalUint(cmd.P2PMaxPeers.Name),
+		WhitelistCIDR:           ctx.GlobalString(cmd.P2PWhitelist.Name),
+		AllowListCIDR:           sliceutil.SplitCommaSeparated(ctx.GlobalStringSlice(cmd.P2PAllowList.Name)),
+		DenyListCIDR:            sliceutil.SplitCommaSeparated(ctx.GlobalStringSlice(cmd.P2PDenyList.Name)),
+		EnableUPnP:              ctx.GlobalBool(cmd.EnableUPnPFlag.Name),
+		Encoding:                ctx.GlobalString(cmd.P2PEncoding.Name),
+		StateNotifier:           b,
+		GossipD:                 ctx.GlobalInt(cmd.P2PGossipD.Name),
+		GossipDlo:               ctx.GlobalInt(cmd.P2PGossipDlo.Name),
+		GossipDhi:               ctx.GlobalInt(cmd.P2PGossipDhi.Name),
+		GossipHeartbeatInterval: ctx.GlobalDuration(cmd.P2PGossipHeartbeat.Name),
+		OutboundBandwidthBudget: ctx.GlobalUint64(cmd.P2POutboundBandwidthBudget.Name),
 	})
 	if err != nil {
 		return err
@@ -343,7 +420,8 @@ func (b *BeaconNode) registerBlockchainService(ctx *cli.Context) error {
 
 func (b *BeaconNode) registerAttestationPool(ctx *cli.Context) error {
 	attPoolService, err := attestations.NewService(context.Background(), &attestations.Config{
-		Pool: b.attestationPool,
+		Pool:          b.attestationPool,
+		StateNotifier: b,
 	})
 	if err != nil {
 		return err
@@ -356,6 +434,15 @@ func (b *BeaconNode) registerPOWChainService(cliCtx *cli.Context) error {
 		return b.services.RegisterService(&powchain.Service{})
 	}
 	depAddress := cliCtx.GlobalString(flags.DepositContractFlag.Name)
+	placeholderContract := false
+	if depAddress == "" && isInteropMode(cliCtx) {
+		// Interop genesis states are not backed by a deposit contract, so the
+		// zero address is used as a placeholder. It is never saved to the database
+		// so a later run with a real deposit contract is not rejected.
+		log.Warn("No deposit contract specified in interop mode, using the zero address")
+		depAddress = common.Address{}.Hex()
+		placeholderContract = true
+	}
 	if depAddress == "" {
 		log.Fatal(fmt.Sprintf("%s is required", flags.DepositContractFlag.Name))
 	}
@@ -366,17 +453,28 @@ func (b *BeaconNode) registerPOWChainService(cliCtx *cli.Context) error {
 
 	ctx := context.Background()
 	cfg := &powchain.Web3ServiceConfig{
-		ETH1Endpoint:    cliCtx.GlobalString(flags.Web3ProviderFlag.Name),
-		HTTPEndPoint:    cliCtx.GlobalString(flags.HTTPWeb3ProviderFlag.Name),
-		DepositContract: common.HexToAddress(depAddress),
-		BeaconDB:        b.db,
-		DepositCache:    b.depositCache,
-		StateNotifier:   b,
+		ETH1Endpoint:       cliCtx.GlobalString(flags.Web3ProviderFlag.Name),
+		HTTPEndPoint:       cliCtx.GlobalString(flags.HTTPWeb3ProviderFlag.Name),
+		DepositContract:    common.HexToAddress(depAddress),
+		BeaconDB:           b.db,
+		DepositCache:       b.depositCache,
+		StateNotifier:      b,
+		ForceDepositConfig: cliCtx.GlobalBool(flags.ForceEth1ConfigFlag.Name),
+	}
+	if path := cliCtx.GlobalString(flags.DepositSnapshotFlag.Name); path != "" {
+		snapshot, err := powchain.ReadDepositSnapshot(path)
+		if err != nil {
+			return err
+		}
+		cfg.DepositSnapshot = snapshot
 	}
 	web3Service, err := powchain.NewService(ctx, cfg)
 	if err != nil {
 		return errors.Wrap(err, "could not register proof-of-work chain web3Service")
 	}
+	if placeholderContract {
+		return b.services.RegisterService(web3Service)
+	}
 	knownContract, err := b.db.DepositContractAddress(ctx)
 	if err != nil {
 		return err
@@ -408,7 +506,7 @@ func (b *BeaconNode) registerSyncService(ctx *cli.Context) error {
 		return err
 	}
 
-	rs := prysmsync.NewRegularSync(&prysmsync.Config{
+	cfg := &prysmsync.Config{
 		DB:                  b.db,
 		P2P:                 b.fetchP2P(ctx),
 		Chain:               chainService,
@@ -418,11 +516,91 @@ func (b *BeaconNode) registerSyncService(ctx *cli.Context) error {
 		AttestationNotifier: b,
 		AttPool:             b.attestationPool,
 		ExitPool:            b.exitPool,
-	})
+	}
+	if featureconfig.Get().EnableLightClientServer {
+		var lightClient *lightclient.Service
+		if err := b.services.FetchService(&lightClient); err != nil {
+			return err
+		}
+		cfg.LightClient = lightClient
+	}
+	rs := prysmsync.NewRegularSync(cfg)
 
 	return b.services.RegisterService(rs)
 }
 
+func (b *BeaconNode) registerWatchdogService(ctx *cli.Context) error {
+	stuckSlots := ctx.GlobalUint64(flags.WatchdogStuckSlotsFlag.Name)
+	if stuckSlots == 0 {
+		return nil
+	}
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+	var initSync *initialsync.Service
+	if err := b.services.FetchService(&initSync); err != nil {
+		return err
+	}
+	svc := watchdog.NewService(context.Background(), &watchdog.Config{
+		HeadFetcher: chainService,
+		TimeFetcher: chainService,
+		P2P:         b.fetchP2P(ctx),
+		InitialSync: initSync,
+		StuckSlots:  stuckSlots,
+	})
+	return b.services.RegisterService(svc)
+}
+
+func (b *BeaconNode) registerFinalityService(ctx *cli.Context) error {
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+	svc := finality.NewService(context.Background(), &finality.Config{
+		HeadFetcher:   chainService,
+		TimeFetcher:   chainService,
+		StateNotifier: b,
+		AlertEpochs:   ctx.GlobalUint64(flags.FinalityAlertEpochsFlag.Name),
+	})
+	return b.services.RegisterService(svc)
+}
+
+func (b *BeaconNode) registerStandbyService(ctx *cli.Context) error {
+	primary := ctx.GlobalString(flags.StandbyPrimaryFlag.Name)
+	if primary == "" {
+		return nil
+	}
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+	svc := standby.NewService(context.Background(), &standby.Config{
+		Primary: primary,
+		Cert:    ctx.GlobalString(flags.StandbyPrimaryCertFlag.Name),
+		DB:      b.db,
+		Chain:   chainService,
+	})
+	return b.services.RegisterService(svc)
+}
+
+func (b *BeaconNode) registerLightClientService(ctx *cli.Context) error {
+	if !featureconfig.Get().EnableLightClientServer {
+		return nil
+	}
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+	svc := lightclient.NewService(context.Background(), &lightclient.Config{
+		BeaconDB:            b.db,
+		HeadFetcher:         chainService,
+		FinalizationFetcher: chainService,
+		StateNotifier:       b,
+	})
+	return b.services.RegisterService(svc)
+}
+
 func (b *BeaconNode) registerInitialSyncService(ctx *cli.Context) error {
 	var chainService *blockchain.Service
 	if err := b.services.FetchService(&chainService); err != nil {
@@ -435,6 +613,7 @@ func (b *BeaconNode) registerInitialSyncService(ctx *cli.Context) error {
 		P2P:           b.fetchP2P(ctx),
 		StateNotifier: b,
 		BlockNotifier: b,
+		BlocksDir:     ctx.GlobalString(flags.BlocksDirFlag.Name),
 	})
 
 	return b.services.RegisterService(is)
@@ -457,11 +636,9 @@ func (b *BeaconNode) registerRPCService(ctx *cli.Context) error {
 		return err
 	}
 
-	genesisValidators := ctx.GlobalUint64(flags.InteropNumValidatorsFlag.Name)
-	genesisStatePath := ctx.GlobalString(flags.InteropGenesisStateFlag.Name)
 	var depositFetcher depositcache.DepositFetcher
 	var chainStartFetcher powchain.ChainStartFetcher
-	if genesisValidators > 0 || genesisStatePath != "" {
+	if isInteropMode(ctx) {
 		var interopService *interopcoldstart.Service
 		if err := b.services.FetchService(&interopService); err != nil {
 			return err
@@ -477,39 +654,54 @@ func (b *BeaconNode) registerRPCService(ctx *cli.Context) error {
 	port := ctx.GlobalString(flags.RPCPort.Name)
 	cert := ctx.GlobalString(flags.CertFlag.Name)
 	key := ctx.GlobalString(flags.KeyFlag.Name)
+	clientCA := ctx.GlobalString(flags.ClientCAFlag.Name)
+	apiKeysFile := ctx.GlobalString(flags.RPCAPIKeysFlag.Name)
+	readOnly := ctx.GlobalBool(flags.ReadOnlyFlag.Name)
 	slasherCert := ctx.GlobalString(flags.SlasherCertFlag.Name)
 	slasherProvider := ctx.GlobalString(flags.SlasherProviderFlag.Name)
 
 	mockEth1DataVotes := ctx.GlobalBool(flags.InteropMockEth1DataVotesFlag.Name)
+	if isInteropMode(ctx) && !mockEth1DataVotes {
+		log.Info("Mocking eth1 data votes as the node is running in interop mode")
+		mockEth1DataVotes = true
+	}
 	rpcService := rpc.NewService(context.Background(), &rpc.Config{
-		Host:                  host,
-		Port:                  port,
-		CertFlag:              cert,
-		KeyFlag:               key,
-		BeaconDB:              b.db,
-		Broadcaster:           b.fetchP2P(ctx),
-		PeersFetcher:          b.fetchP2P(ctx),
-		HeadFetcher:           chainService,
-		ForkFetcher:           chainService,
-		FinalizationFetcher:   chainService,
-		ParticipationFetcher:  chainService,
-		BlockReceiver:         chainService,
-		AttestationReceiver:   chainService,
-		GenesisTimeFetcher:    chainService,
-		AttestationsPool:      b.attestationPool,
-		ExitPool:              b.exitPool,
-		SlashingsPool:         b.slashingsPool,
-		POWChainService:       web3Service,
-		ChainStartFetcher:     chainStartFetcher,
-		MockEth1Votes:         mockEth1DataVotes,
-		SyncService:           syncService,
-		DepositFetcher:        depositFetcher,
-		PendingDepositFetcher: b.depositCache,
-		BlockNotifier:         b,
-		StateNotifier:         b,
-		OperationNotifier:     b,
-		SlasherCert:           slasherCert,
-		SlasherProvider:       slasherProvider,
+		Host:                   host,
+		Port:                   port,
+		CertFlag:               cert,
+		KeyFlag:                key,
+		ClientCAFlag:           clientCA,
+		BeaconDB:               b.db,
+		Broadcaster:            b.fetchP2P(ctx),
+		PeersFetcher:           b.fetchP2P(ctx),
+		IdentityFetcher:        b.fetchP2P(ctx),
+		HeadFetcher:            chainService,
+		ForkFetcher:            chainService,
+		FinalizationFetcher:    chainService,
+		ParticipationFetcher:   chainService,
+		WarmUpChecker:          chainService,
+		BlockReceiver:          chainService,
+		AttestationReceiver:    chainService,
+		GenesisTimeFetcher:     chainService,
+		AttestationsPool:       b.attestationPool,
+		ExitPool:               b.exitPool,
+		SlashingsPool:          b.slashingsPool,
+		POWChainService:        web3Service,
+		ChainStartFetcher:      chainStartFetcher,
+		MockEth1Votes:          mockEth1DataVotes,
+		SyncService:            syncService,
+		SyncProgressFetcher:    syncService,
+		DepositSnapshotFetcher: web3Service,
+		DepositFetcher:         depositFetcher,
+		PendingDepositFetcher:  b.depositCache,
+		BlockNotifier:          b,
+		StateNotifier:          b,
+		OperationNotifier:      b,
+		SlasherCert:            slasherCert,
+		SlasherProvider:        slasherProvider,
+		APIKeysFile:            apiKeysFile,
+		ReadOnly:               readOnly,
+		InteropNumValidators:   ctx.GlobalUint64(flags.InteropNumValidatorsFlag.Name),
 	})
 
 	return b.services.RegisterService(rpcService)
@@ -522,6 +714,7 @@ func (b *BeaconNode) registerPrometheusService(ctx *cli.Context) error {
 		panic(err)
 	}
 	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/p2p", Handler: p.InfoHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/p2p/gater", Handler: p.PeerGaterHandler})
 
 	var c *blockchain.Service
 	if err := b.services.FetchService(&c); err != nil {
@@ -533,6 +726,52 @@ func (b *BeaconNode) registerPrometheusService(ctx *cli.Context) error {
 	}
 
 	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/tree", Handler: c.TreeHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/debug/nodetree", Handler: c.NodeTreeHandler})
+	if featureconfig.Get().EnableBalanceDivergenceCheck {
+		additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/validators/balance_divergence", Handler: c.BalanceDivergenceHandler})
+	}
+
+	var f *finality.Service
+	if err := b.services.FetchService(&f); err != nil {
+		panic(err)
+	}
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/finality", Handler: f.FinalityHandler})
+
+	var r *rpc.Service
+	if err := b.services.FetchService(&r); err != nil {
+		panic(err)
+	}
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/attestations/proof", Handler: r.AttestationInclusionProofHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/attestations/pool", Handler: r.PoolAttestationsHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/attestations/pool/stats", Handler: r.PoolStatsHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/validators/balances/history", Handler: r.BalanceHistoryHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/validators/earnings", Handler: r.ValidatorEarningsHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/validators/attestations/bitmap", Handler: r.AttestationBitmapsHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/validators/deposits", Handler: r.ValidatorDepositsHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/validators/exit_queue", Handler: r.ExitQueueHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/validators/proposers", Handler: r.ProposerLookaheadHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/validators/committee_proof", Handler: r.CommitteeProofHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/blocks/roots", Handler: r.BlocksByRootsHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/blocks/slot", Handler: r.BlocksAtSlotHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/deposits/snapshot", Handler: r.DepositSnapshotHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/deposits/proof", Handler: r.DepositInclusionProofHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/slashings/pending", Handler: r.PendingSlashingsHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/slashings/inclusion", Handler: r.SlashingInclusionHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/participation", Handler: r.ParticipationHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/validator/duties", Handler: r.DutiesLookaheadHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/validator/activation_estimate", Handler: r.ActivationEstimateHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/sync/status", Handler: r.SyncStatusHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/p2p/scores", Handler: r.PeerScoresHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/p2p/identity", Handler: r.IdentityHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/interop/keys", Handler: r.InteropKeysHandler})
+
+	if featureconfig.Get().EnableLightClientServer {
+		var lightClient *lightclient.Service
+		if err := b.services.FetchService(&lightClient); err != nil {
+			panic(err)
+		}
+		additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/light_client/update", Handler: lightClient.UpdateHandler})
+	}
 
 	service := prometheus.NewPrometheusService(
 		fmt.Sprintf(":%d", ctx.GlobalInt64(cmd.MonitoringPortFlag.Name)),
@@ -547,9 +786,24 @@ func (b *BeaconNode) registerPrometheusService(ctx *cli.Context) error {
 func (b *BeaconNode) registerGRPCGateway(ctx *cli.Context) error {
 	gatewayPort := ctx.GlobalInt(flags.GRPCGatewayPort.Name)
 	if gatewayPort > 0 {
+		var r *rpc.Service
+		if err := b.services.FetchService(&r); err != nil {
+			return err
+		}
+		// The JSON endpoints which assemble proposals, submit operations or regenerate historical
+		// states are served next to the gRPC methods, behind the same API keys.
+		mux := http.NewServeMux()
+		mux.HandleFunc("/validator/block/dry_run", r.Authenticated(r.BlockProposalDryRunHandler))
+		mux.HandleFunc("/validator/block/propose", r.Authenticated(r.ValidatedProposalHandler))
+		mux.HandleFunc("/validator/attestations", r.Authenticated(r.SubmitAttestationsHandler))
+		mux.HandleFunc("/validators/export", r.Authenticated(r.ValidatorRegistryExportHandler))
+		mux.HandleFunc("/validators/epochs", r.Authenticated(r.ValidatorEpochsHandler))
+		mux.HandleFunc("/blocks/rewards", r.Authenticated(r.BlockRewardsHandler))
+		mux.HandleFunc("/debug/state/field", r.Authenticated(r.StateFieldHandler))
+
 		selfAddress := fmt.Sprintf("127.0.0.1:%d", ctx.GlobalInt(flags.RPCPort.Name))
 		gatewayAddress := fmt.Sprintf("0.0.0.0:%d", gatewayPort)
-		return b.services.RegisterService(gateway.New(context.Background(), selfAddress, gatewayAddress, nil /*optional mux*/))
+		return b.services.RegisterService(gateway.New(context.Background(), selfAddress, gatewayAddress, mux))
 	}
 	return nil
 }
@@ -559,7 +813,7 @@ func (b *BeaconNode) registerInteropServices(ctx *cli.Context) error {
 	genesisValidators := ctx.GlobalUint64(flags.InteropNumValidatorsFlag.Name)
 	genesisStatePath := ctx.GlobalString(flags.InteropGenesisStateFlag.Name)
 
-	if genesisValidators > 0 || genesisStatePath != "" {
+	if isInteropMode(ctx) {
 		svc := interopcoldstart.NewColdStartService(context.Background(), &interopcoldstart.Config{
 			GenesisTime:   genesisTime,
 			NumValidators: genesisValidators,
@@ -573,6 +827,28 @@ func (b *BeaconNode) registerInteropServices(ctx *cli.Context) error {
 	return nil
 }
 
+func (b *BeaconNode) registerGenesisService(ctx *cli.Context) error {
+	if isInteropMode(ctx) {
+		return nil
+	}
+	var web3Service *powchain.Service
+	if err := b.services.FetchService(&web3Service); err != nil {
+		return err
+	}
+	svc := genesis.NewService(context.Background(), &genesis.Config{
+		GenesisStatusFetcher: web3Service,
+	})
+	return b.services.RegisterService(svc)
+}
+
+// isInteropMode returns true if the node starts from an interop genesis state,
+// either generated from deterministic keys or loaded from a file, instead of
+// from the deposit contract.
+func isInteropMode(ctx *cli.Context) bool {
+	return ctx.GlobalUint64(flags.InteropNumValidatorsFlag.Name) > 0 ||
+		ctx.GlobalString(flags.InteropGenesisStateFlag.Name) != ""
+}
+
 func (b *BeaconNode) registerArchiverService(ctx *cli.Context) error {
 	if !flags.Get().EnableArchive {
 		return nil
@@ -589,3 +865,49 @@ func (b *BeaconNode) registerArchiverService(ctx *cli.Context) error {
 	})
 	return b.services.RegisterService(svc)
 }
+
+func (b *BeaconNode) registerAccountingService(ctx *cli.Context) error {
+	if !ctx.GlobalBool(flags.ValidatorAccountingFlag.Name) {
+		return nil
+	}
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+	svc := accounting.NewService(context.Background(), &accounting.Config{
+		BeaconDB:      b.db,
+		HeadFetcher:   chainService,
+		StateNotifier: b,
+	})
+	return b.services.RegisterService(svc)
+}
+
+func (b *BeaconNode) registerEpochSummaryService(ctx *cli.Context) error {
+	urls := ctx.GlobalStringSlice(flags.EpochSummaryWebhookURLFlag.Name)
+	if len(urls) == 0 {
+		return nil
+	}
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+	svc := epochsummary.NewService(context.Background(), &epochsummary.Config{
+		URLs:          urls,
+		HeadFetcher:   chainService,
+		StateNotifier: b,
+	})
+	return b.services.RegisterService(svc)
+}
+
+// configureSlotsPerArchivedPoint overrides the slot interval of the archived point states with the
+// --slots-per-archive-point flag.
+func configureSlotsPerArchivedPoint(ctx *cli.Context) error {
+	slots := ctx.GlobalUint64(flags.SlotsPerArchivedPoint.Name)
+	if slots == 0 {
+		return errors.Errorf("--%s must be greater than 0", flags.SlotsPerArchivedPoint.Name)
+	}
+	c := params.BeaconConfig()
+	c.SlotsPerArchivedPoint = slots
+	params.OverrideBeaconConfig(c)
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/urfave/cli"
@@ -44,3 +45,27 @@ func TestNodeClose_OK(t *testing.T) {
 
 	os.RemoveAll(tmp)
 }
+
+func TestIsInteropMode(t *testing.T) {
+	tests := []struct {
+		name          string
+		numValidators uint64
+		genesisState  string
+		want          bool
+	}{
+		{name: "no interop flags", want: false},
+		{name: "interop validators", numValidators: 64, want: true},
+		{name: "interop genesis state", genesisState: "genesis.ssz", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := cli.NewApp()
+			set := flag.NewFlagSet("test", 0)
+			set.Uint64(flags.InteropNumValidatorsFlag.Name, tt.numValidators, "")
+			set.String(flags.InteropGenesisStateFlag.Name, tt.genesisState, "")
+			if got := isInteropMode(cli.NewContext(app, set, nil)); got != tt.want {
+				t.Errorf("isInteropMode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
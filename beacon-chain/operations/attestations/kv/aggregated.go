@@ -26,6 +26,7 @@ func (p *AttCaches) SaveAggregatedAttestation(att *ethpb.Attestation) error {
 	if !ok {
 		atts := []*ethpb.Attestation{att}
 		p.aggregatedAtt.Set(string(r[:]), atts, cache.DefaultExpiration)
+		p.indexSlot(p.aggregatedAtt, att.Data.Slot, string(r[:]))
 		return nil
 	}
 
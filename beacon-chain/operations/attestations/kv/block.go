@@ -36,6 +36,7 @@ func (p *AttCaches) SaveBlockAttestation(att *ethpb.Attestation) error {
 	// DefaultExpiration is set to what was given to New(). In this case
 	// it's one epoch.
 	p.blockAtt.Set(string(r[:]), atts, cache.DefaultExpiration)
+	p.indexSlot(p.blockAtt, att.Data.Slot, string(r[:]))
 
 	return nil
 }
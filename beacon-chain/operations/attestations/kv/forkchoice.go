@@ -17,6 +17,7 @@ func (p *AttCaches) SaveForkchoiceAttestation(att *ethpb.Attestation) error {
 	// DefaultExpiration is set to what was given to New(). In this case
 	// it's one epoch.
 	p.forkchoiceAtt.Set(string(r[:]), att, cache.DefaultExpiration)
+	p.indexSlot(p.forkchoiceAtt, att.Data.Slot, string(r[:]))
 
 	return nil
 }
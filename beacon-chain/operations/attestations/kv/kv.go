@@ -1,6 +1,7 @@
 package kv
 
 import (
+	"sync"
 	"time"
 
 	"github.com/patrickmn/go-cache"
@@ -15,6 +16,16 @@ type AttCaches struct {
 	unAggregatedAtt *cache.Cache
 	forkchoiceAtt   *cache.Cache
 	blockAtt        *cache.Cache
+	// slotIndex holds the cache keys of the attestations by the slot of their data, so that the
+	// attestations past the inclusion window are deleted without scanning the caches.
+	slotIndex     map[uint64]map[slotIndexKey]bool
+	slotIndexLock sync.Mutex
+}
+
+// slotIndexKey is the key of an attestation in one of the caches.
+type slotIndexKey struct {
+	cache *cache.Cache
+	key   string
 }
 
 // NewAttCaches initializes a new attestation pool consists of multiple KV store in cache for
@@ -29,6 +40,7 @@ func NewAttCaches() *AttCaches {
 		aggregatedAtt:   cache.New(secsInEpoch*time.Second, secsInEpoch*time.Second),
 		forkchoiceAtt:   cache.New(secsInEpoch*time.Second, secsInEpoch*time.Second),
 		blockAtt:        cache.New(secsInEpoch*time.Second, secsInEpoch*time.Second),
+		slotIndex:       make(map[uint64]map[slotIndexKey]bool),
 	}
 
 	return pool
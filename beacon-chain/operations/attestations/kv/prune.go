@@ -0,0 +1,46 @@
+package kv
+
+import (
+	"github.com/patrickmn/go-cache"
+)
+
+// indexSlot adds the cache key of an attestation to the slot index.
+func (p *AttCaches) indexSlot(c *cache.Cache, slot uint64, key string) {
+	p.slotIndexLock.Lock()
+	defer p.slotIndexLock.Unlock()
+	keys, ok := p.slotIndex[slot]
+	if !ok {
+		keys = make(map[slotIndexKey]bool)
+		p.slotIndex[slot] = keys
+	}
+	keys[slotIndexKey{cache: c, key: key}] = true
+}
+
+// DeleteAttestationsBeforeSlot deletes the attestations of all kinds whose data is older than
+// the slot, and returns the number of cache entries deleted. Entries already deleted or expired
+// are not counted.
+func (p *AttCaches) DeleteAttestationsBeforeSlot(slot uint64) int {
+	p.slotIndexLock.Lock()
+	defer p.slotIndexLock.Unlock()
+	deleted := 0
+	for s, keys := range p.slotIndex {
+		if s >= slot {
+			continue
+		}
+		for k := range keys {
+			if _, ok := k.cache.Get(k.key); ok {
+				k.cache.Delete(k.key)
+				deleted++
+			}
+		}
+		delete(p.slotIndex, s)
+	}
+	return deleted
+}
+
+// IndexedSlotCount returns the number of slots with attestations in the slot index.
+func (p *AttCaches) IndexedSlotCount() int {
+	p.slotIndexLock.Lock()
+	defer p.slotIndexLock.Unlock()
+	return len(p.slotIndex)
+}
@@ -0,0 +1,52 @@
+package kv
+
+import (
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+func TestKV_DeleteAttestationsBeforeSlot(t *testing.T) {
+	cache := NewAttCaches()
+
+	for slot := uint64(1); slot <= 3; slot++ {
+		data := &ethpb.AttestationData{Slot: slot}
+		if err := cache.SaveUnaggregatedAttestation(&ethpb.Attestation{Data: data, AggregationBits: bitfield.Bitlist{0b101}}); err != nil {
+			t.Fatal(err)
+		}
+		if err := cache.SaveAggregatedAttestation(&ethpb.Attestation{Data: data, AggregationBits: bitfield.Bitlist{0b111}}); err != nil {
+			t.Fatal(err)
+		}
+		if err := cache.SaveBlockAttestation(&ethpb.Attestation{Data: data, AggregationBits: bitfield.Bitlist{0b111}}); err != nil {
+			t.Fatal(err)
+		}
+		if err := cache.SaveForkchoiceAttestation(&ethpb.Attestation{Data: data, AggregationBits: bitfield.Bitlist{0b111}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// An attestation deleted before pruning is not counted.
+	if err := cache.DeleteUnaggregatedAttestation(&ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 1}, AggregationBits: bitfield.Bitlist{0b101}}); err != nil {
+		t.Fatal(err)
+	}
+	if cache.IndexedSlotCount() != 3 {
+		t.Errorf("Wanted 3 indexed slots, received %d", cache.IndexedSlotCount())
+	}
+
+	if deleted := cache.DeleteAttestationsBeforeSlot(3); deleted != 7 {
+		t.Errorf("Wanted 7 deleted attestations, received %d", deleted)
+	}
+	if cache.IndexedSlotCount() != 1 {
+		t.Errorf("Wanted 1 indexed slot, received %d", cache.IndexedSlotCount())
+	}
+	for name, atts := range map[string][]*ethpb.Attestation{
+		"unaggregated": cache.UnaggregatedAttestations(),
+		"aggregated":   cache.AggregatedAttestations(),
+		"block":        cache.BlockAttestations(),
+		"forkchoice":   cache.ForkchoiceAttestations(),
+	} {
+		if len(atts) != 1 || atts[0].Data.Slot != 3 {
+			t.Errorf("Wanted only the %s attestation of slot 3 to be kept, received %v", name, atts)
+		}
+	}
+}
@@ -22,6 +22,7 @@ func (p *AttCaches) SaveUnaggregatedAttestation(att *ethpb.Attestation) error {
 	// DefaultExpiration is set to what was given to New(). In this case
 	// it's one epoch.
 	p.unAggregatedAtt.Set(string(r[:]), att, cache.DefaultExpiration)
+	p.indexSlot(p.unAggregatedAtt, att.Data.Slot, string(r[:]))
 
 	return nil
 }
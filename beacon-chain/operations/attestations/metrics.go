@@ -18,9 +18,29 @@ var (
 			Help: "The number of unaggregated attestations in the pool.",
 		},
 	)
+	indexedSlotsCount = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "attestation_pool_indexed_slots_count",
+			Help: "The number of slots with attestations in the pool.",
+		},
+	)
+	prunedAttsCount = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "attestation_pool_pruned_total",
+			Help: "The number of attestations pruned from the pool for being past the inclusion window.",
+		},
+	)
+	pruneDuration = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "attestation_pool_prune_duration_seconds",
+			Help:    "The time taken to prune the attestations past the inclusion window from the pool.",
+			Buckets: prometheus.ExponentialBuckets(0.0001, 4, 8),
+		},
+	)
 )
 
 func (s *Service) updateMetrics() {
 	aggregatedAttsCount.Set(float64(s.pool.AggregatedAttestationCount()))
 	unaggregatedAttsCount.Set(float64(s.pool.UnaggregatedAttestationCount()))
+	indexedSlotsCount.Set(float64(s.pool.IndexedSlotCount()))
 }
@@ -34,6 +34,9 @@ type Pool interface {
 	SaveForkchoiceAttestations(atts []*ethpb.Attestation) error
 	ForkchoiceAttestations() []*ethpb.Attestation
 	DeleteForkchoiceAttestation(att *ethpb.Attestation) error
+	// For garbage collection of the attestations past the inclusion window.
+	DeleteAttestationsBeforeSlot(slot uint64) int
+	IndexedSlotCount() int
 }
 
 // NewPool initializes a new attestation pool.
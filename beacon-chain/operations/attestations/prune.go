@@ -0,0 +1,78 @@
+package attestations
+
+import (
+	"time"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/slotutil"
+	"github.com/sirupsen/logrus"
+)
+
+// This kicks off a routine to prune, at every slot, the attestations which can no longer be
+// included in a block. Without it the pool grows with the attestations of every slot during
+// non-finality, as only the attestations included in blocks are deleted.
+func (s *Service) pruneRoutine() {
+	genesisTime, ok := s.waitForGenesisTime()
+	if !ok {
+		return
+	}
+	ticker := slotutil.GetSlotTicker(genesisTime, params.BeaconConfig().SecondsPerSlot)
+	defer ticker.Done()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case slot := <-ticker.C():
+			s.pruneExpiredAtts(slot)
+		}
+	}
+}
+
+// pruneExpiredAtts deletes the attestations of the pool which are past the inclusion window of
+// the current slot.
+func (s *Service) pruneExpiredAtts(currentSlot uint64) {
+	window := params.BeaconConfig().SlotsPerEpoch
+	if currentSlot <= window {
+		return
+	}
+	start := time.Now()
+	pruned := s.pool.DeleteAttestationsBeforeSlot(currentSlot - window)
+	pruneDuration.Observe(time.Since(start).Seconds())
+	prunedAttsCount.Add(float64(pruned))
+	s.updateMetrics()
+	if pruned > 0 {
+		log.WithFields(logrus.Fields{
+			"pruned":      pruned,
+			"currentSlot": currentSlot,
+		}).Debug("Pruned attestations past the inclusion window")
+	}
+}
+
+// waitForGenesisTime blocks until the chain has started, and returns false if the service is
+// stopped before.
+func (s *Service) waitForGenesisTime() (time.Time, bool) {
+	if s.stateNotifier == nil {
+		return time.Time{}, false
+	}
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := s.stateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	for {
+		select {
+		case event := <-stateChannel:
+			switch event.Type {
+			case statefeed.ChainStarted:
+				return event.Data.(*statefeed.ChainStartedData).StartTime, true
+			case statefeed.Initialized:
+				return event.Data.(*statefeed.InitializedData).StartTime, true
+			}
+		case <-s.ctx.Done():
+			return time.Time{}, false
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Subscription to state feed notifier failed")
+			return time.Time{}, false
+		}
+	}
+}
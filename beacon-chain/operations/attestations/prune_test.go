@@ -0,0 +1,35 @@
+package attestations
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestPruneExpiredAtts_DeletesAttsPastInclusionWindow(t *testing.T) {
+	s, err := NewService(context.Background(), &Config{Pool: NewPool()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	window := params.BeaconConfig().SlotsPerEpoch
+	oldAtt := &ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 1}, AggregationBits: bitfield.Bitlist{0b101}}
+	newAtt := &ethpb.Attestation{Data: &ethpb.AttestationData{Slot: window + 1}, AggregationBits: bitfield.Bitlist{0b101}}
+	if err := s.pool.SaveUnaggregatedAttestations([]*ethpb.Attestation{oldAtt, newAtt}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The attestation of slot 1 can still be included at slot 1 + SLOTS_PER_EPOCH.
+	s.pruneExpiredAtts(window + 1)
+	if s.pool.UnaggregatedAttestationCount() != 2 {
+		t.Fatalf("Wanted 2 attestations in the pool, received %d", s.pool.UnaggregatedAttestationCount())
+	}
+
+	s.pruneExpiredAtts(window + 2)
+	atts := s.pool.UnaggregatedAttestations()
+	if len(atts) != 1 || atts[0].Data.Slot != window+1 {
+		t.Errorf("Wanted only the attestation of slot %d to be kept, received %v", window+1, atts)
+	}
+}
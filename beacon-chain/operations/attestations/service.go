@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/dgraph-io/ristretto"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 )
 
 var forkChoiceProcessedRootsSize = int64(1 << 16)
@@ -13,13 +14,15 @@ type Service struct {
 	ctx                      context.Context
 	cancel                   context.CancelFunc
 	pool                     Pool
+	stateNotifier            statefeed.Notifier
 	err                      error
 	forkChoiceProcessedRoots *ristretto.Cache
 }
 
 // Config options for the service.
 type Config struct {
-	Pool Pool
+	Pool          Pool
+	StateNotifier statefeed.Notifier
 }
 
 // NewService instantiates a new attestation pool service instance that will
@@ -39,6 +42,7 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		ctx:                      ctx,
 		cancel:                   cancel,
 		pool:                     cfg.Pool,
+		stateNotifier:            cfg.StateNotifier,
 		forkChoiceProcessedRoots: cache,
 	}, nil
 }
@@ -47,6 +51,7 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 func (s *Service) Start() {
 	go s.prepareForkChoiceAtts()
 	go s.aggregateRoutine()
+	go s.pruneRoutine()
 }
 
 // Stop the beacon block attestation pool service's main event loop
@@ -6,31 +6,46 @@ import (
 	"sort"
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	beaconstate "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/sliceutil"
 )
 
+// maxTrackedInclusions is the number of included slashings whose inclusion is remembered.
+const maxTrackedInclusions = 4096
+
 // NewPool returns an initialized attester slashing and proposer slashing pool.
 func NewPool() *Pool {
 	return &Pool{
 		pendingProposerSlashing: make([]*ethpb.ProposerSlashing, 0),
 		pendingAttesterSlashing: make([]*PendingAttesterSlashing, 0),
 		included:                make(map[uint64]bool),
+		inclusions:              make(map[[32]byte]*Inclusion),
 	}
 }
 
 // PendingAttesterSlashings returns attester slashings that are able to be included into a block.
 // This method will not return more than the block enforced MaxAttesterSlashings.
 func (p *Pool) PendingAttesterSlashings() []*ethpb.AttesterSlashing {
+	return p.pendingAttesterSlashings(int(params.BeaconConfig().MaxAttesterSlashings))
+}
+
+// AllPendingAttesterSlashings returns every attester slashing of the pool, without the limit
+// of a block.
+func (p *Pool) AllPendingAttesterSlashings() []*ethpb.AttesterSlashing {
+	return p.pendingAttesterSlashings(-1)
+}
+
+func (p *Pool) pendingAttesterSlashings(limit int) []*ethpb.AttesterSlashing {
 	p.lock.RLock()
 	defer p.lock.RUnlock()
 
 	included := make(map[uint64]bool)
-	pending := make([]*ethpb.AttesterSlashing, 0, params.BeaconConfig().MaxAttesterSlashings)
+	pending := make([]*ethpb.AttesterSlashing, 0, len(p.pendingAttesterSlashing))
 	for i, slashing := range p.pendingAttesterSlashing {
-		if i >= int(params.BeaconConfig().MaxAttesterSlashings) {
+		if limit >= 0 && i >= limit {
 			break
 		}
 		if included[slashing.validatorToSlash] {
@@ -50,11 +65,21 @@ func (p *Pool) PendingAttesterSlashings() []*ethpb.AttesterSlashing {
 // PendingProposerSlashings returns proposer slashings that are able to be included into a block.
 // This method will not return more than the block enforced MaxProposerSlashings.
 func (p *Pool) PendingProposerSlashings() []*ethpb.ProposerSlashing {
+	return p.pendingProposerSlashings(int(params.BeaconConfig().MaxProposerSlashings))
+}
+
+// AllPendingProposerSlashings returns every proposer slashing of the pool, without the limit
+// of a block.
+func (p *Pool) AllPendingProposerSlashings() []*ethpb.ProposerSlashing {
+	return p.pendingProposerSlashings(-1)
+}
+
+func (p *Pool) pendingProposerSlashings(limit int) []*ethpb.ProposerSlashing {
 	p.lock.RLock()
 	defer p.lock.RUnlock()
-	pending := make([]*ethpb.ProposerSlashing, 0, params.BeaconConfig().MaxProposerSlashings)
+	pending := make([]*ethpb.ProposerSlashing, 0, len(p.pendingProposerSlashing))
 	for i, slashing := range p.pendingProposerSlashing {
-		if i >= int(params.BeaconConfig().MaxProposerSlashings) {
+		if limit >= 0 && i >= limit {
 			break
 		}
 		pending = append(pending, slashing)
@@ -172,6 +197,36 @@ func (p *Pool) MarkIncludedProposerSlashing(ps *ethpb.ProposerSlashing) {
 	p.included[ps.ProposerIndex] = true
 }
 
+// TrackInclusion records the block a slashing, identified by its hash tree root, was included
+// in. Only the most recent inclusions are remembered.
+func (p *Pool) TrackInclusion(slashing interface{}, slot uint64, blockRoot [32]byte) error {
+	root, err := ssz.HashTreeRoot(slashing)
+	if err != nil {
+		return err
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if _, ok := p.inclusions[root]; ok {
+		return nil
+	}
+	if len(p.inclusionRoots) >= maxTrackedInclusions {
+		delete(p.inclusions, p.inclusionRoots[0])
+		p.inclusionRoots = p.inclusionRoots[1:]
+	}
+	p.inclusions[root] = &Inclusion{Slot: slot, BlockRoot: blockRoot}
+	p.inclusionRoots = append(p.inclusionRoots, root)
+	return nil
+}
+
+// Inclusion returns the block the slashing with the given hash tree root was included in, if
+// it was recently included.
+func (p *Pool) Inclusion(root [32]byte) (*Inclusion, bool) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	inclusion, ok := p.inclusions[root]
+	return inclusion, ok
+}
+
 // this function checks a few items about a validator before proceeding with inserting
 // a proposer/attester slashing into the pool. First, it checks if the validator
 // has been recently included in the pool, then it checks if the validator has exited,
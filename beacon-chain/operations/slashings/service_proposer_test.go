@@ -7,6 +7,7 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
 	beaconstate "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	p2ppb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/params"
@@ -377,3 +378,48 @@ func TestPool_PendingProposerSlashings(t *testing.T) {
 		})
 	}
 }
+
+func TestPool_AllPendingProposerSlashings(t *testing.T) {
+	p := &Pool{
+		pendingProposerSlashing: generateNProposerSlashings(24),
+	}
+	if got := p.AllPendingProposerSlashings(); !reflect.DeepEqual(got, generateNProposerSlashings(24)) {
+		t.Errorf("AllPendingProposerSlashings() = %v, want every pending slashing", got)
+	}
+}
+
+func TestPool_TrackInclusion(t *testing.T) {
+	p := NewPool()
+	slashings := make([]*ethpb.ProposerSlashing, maxTrackedInclusions+1)
+	for i := range slashings {
+		slashings[i] = &ethpb.ProposerSlashing{
+			ProposerIndex: uint64(i),
+			Header_1:      &ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{}, Signature: make([]byte, 96)},
+			Header_2:      &ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{}, Signature: make([]byte, 96)},
+		}
+		if err := p.TrackInclusion(slashings[i], uint64(i), [32]byte{byte(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	root, err := ssz.HashTreeRoot(slashings[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	inclusion, ok := p.Inclusion(root)
+	if !ok {
+		t.Fatal("Expected inclusion of slashing to be tracked")
+	}
+	if inclusion.Slot != 1 || inclusion.BlockRoot != [32]byte{1} {
+		t.Errorf("Wanted inclusion at slot 1 in block %#x, received %+v", [32]byte{1}, inclusion)
+	}
+
+	// The oldest inclusion is forgotten once too many are tracked.
+	root, err = ssz.HashTreeRoot(slashings[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := p.Inclusion(root); ok {
+		t.Error("Expected the oldest inclusion to be evicted")
+	}
+}
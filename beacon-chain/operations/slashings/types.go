@@ -13,6 +13,8 @@ type Pool struct {
 	pendingProposerSlashing []*ethpb.ProposerSlashing
 	pendingAttesterSlashing []*PendingAttesterSlashing
 	included                map[uint64]bool
+	inclusions              map[[32]byte]*Inclusion
+	inclusionRoots          [][32]byte
 }
 
 // PendingAttesterSlashing represents an attester slashing in the operation pool.
@@ -21,3 +23,9 @@ type PendingAttesterSlashing struct {
 	attesterSlashing *ethpb.AttesterSlashing
 	validatorToSlash uint64
 }
+
+// Inclusion records the block a slashing was included in.
+type Inclusion struct {
+	Slot      uint64
+	BlockRoot [32]byte
+}
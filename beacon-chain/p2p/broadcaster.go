@@ -36,6 +36,13 @@ func (s *Service) Broadcast(ctx context.Context, msg proto.Message) error {
 		}
 	}
 
+	digest, err := s.ForkDigest()
+	if err != nil {
+		err := errors.Wrap(err, "could not compute fork digest")
+		traceutil.AnnotateError(span, err)
+		return err
+	}
+	topic = ForkDigestTopic(topic, digest)
 	span.AddAttributes(trace.StringAttribute("topic", topic))
 
 	buf := new(bytes.Buffer)
@@ -51,6 +58,11 @@ func (s *Service) Broadcast(ctx context.Context, msg proto.Message) error {
 		span.AddMessageSendEvent(int64(id), messageLen /*uncompressed*/, messageLen /*compressed*/)
 	}
 
+	// Search for peers of the subnet of an attestation, instead of publishing it to no one.
+	if att, ok := msg.(*eth.Attestation); ok && att.Data != nil && !s.hasPeerWithTopic(topic+s.Encoding().ProtocolSuffix()) {
+		s.FindPeersWithSubnet(ctx, att.Data.CommitteeIndex)
+	}
+
 	if err := s.pubsub.Publish(topic+s.Encoding().ProtocolSuffix(), buf.Bytes()); err != nil {
 		err := errors.Wrap(err, "could not publish message")
 		traceutil.AnnotateError(span, err)
@@ -29,6 +29,12 @@ func TestService_Broadcast(t *testing.T) {
 			Encoding: "ssz",
 		},
 	}
+	p.genesis.time = time.Now()
+	p.genesis.validatorsRoot = make([]byte, 32)
+	digest, err := p.ForkDigest()
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	msg := &testpb.TestSimpleMessage{
 		Bar: 55,
@@ -38,7 +44,7 @@ func TestService_Broadcast(t *testing.T) {
 	GossipTypeMapping[reflect.TypeOf(msg)] = "/testing"
 
 	// External peer subscribes to the topic.
-	topic := "/testing" + p.Encoding().ProtocolSuffix()
+	topic := ForkDigestTopic("/testing", digest) + p.Encoding().ProtocolSuffix()
 	sub, err := p2.PubSub().Subscribe(topic)
 	if err != nil {
 		t.Fatal(err)
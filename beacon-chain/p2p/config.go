@@ -1,23 +1,39 @@
 package p2p
 
+import (
+	"time"
+
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+)
+
 // Config for the p2p service. These parameters are set from application level flags
 // to initialize the p2p service.
 type Config struct {
-	NoDiscovery           bool
-	StaticPeers           []string
-	BootstrapNodeAddr     []string
-	KademliaBootStrapAddr []string
-	Discv5BootStrapAddr   []string
-	RelayNodeAddr         string
-	LocalIP               string
-	HostAddress           string
-	HostDNS               string
-	PrivateKey            string
-	DataDir               string
-	TCPPort               uint
-	UDPPort               uint
-	MaxPeers              uint
-	WhitelistCIDR         string
-	EnableUPnP            bool
-	Encoding              string
+	NoDiscovery             bool
+	StaticPeers             []string
+	BootstrapNodeAddr       []string
+	KademliaBootStrapAddr   []string
+	Discv5BootStrapAddr     []string
+	RelayNodeAddr           string
+	LocalIP                 string
+	LocalIP6                string
+	HostAddress             string
+	HostAddress6            string
+	HostDNS                 string
+	PrivateKey              string
+	DataDir                 string
+	TCPPort                 uint
+	UDPPort                 uint
+	MaxPeers                uint
+	WhitelistCIDR           string
+	AllowListCIDR           []string
+	DenyListCIDR            []string
+	EnableUPnP              bool
+	GossipD                 int
+	GossipDlo               int
+	GossipDhi               int
+	GossipHeartbeatInterval time.Duration
+	OutboundBandwidthBudget uint64
+	Encoding                string
+	StateNotifier           statefeed.Notifier
 }
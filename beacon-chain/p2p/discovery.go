@@ -3,8 +3,8 @@ package p2p
 import (
 	"context"
 	"crypto/ecdsa"
-	"fmt"
 	"net"
+	"path"
 
 	"github.com/ethereum/go-ethereum/p2p/discover"
 	"github.com/ethereum/go-ethereum/p2p/enode"
@@ -27,18 +27,24 @@ type Listener interface {
 	LookupRandom() []*enode.Node
 	Ping(*enode.Node) error
 	RequestENR(*enode.Node) (*enode.Node, error)
+	LocalNode() *enode.LocalNode
 }
 
 func createListener(ipAddr net.IP, privKey *ecdsa.PrivateKey, cfg *Config) *discover.UDPv5 {
+	network, bindIP := discoveryNetwork(ipAddr, listenIP6(cfg))
 	udpAddr := &net.UDPAddr{
-		IP:   ipAddr,
+		IP:   bindIP,
 		Port: int(cfg.UDPPort),
 	}
-	conn, err := net.ListenUDP("udp4", udpAddr)
+	conn, err := net.ListenUDP(network, udpAddr)
 	if err != nil {
 		log.Fatal(err)
 	}
-	localNode, err := createLocalNode(privKey, ipAddr, int(cfg.UDPPort), int(cfg.TCPPort))
+	var dbPath string
+	if cfg.DataDir != "" {
+		dbPath = path.Join(cfg.DataDir, nodeDBPath)
+	}
+	localNode, err := createLocalNode(privKey, ipAddr, int(cfg.UDPPort), int(cfg.TCPPort), dbPath)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -50,6 +56,9 @@ func createListener(ipAddr net.IP, privKey *ecdsa.PrivateKey, cfg *Config) *disc
 			localNode.SetFallbackIP(hostIP)
 		}
 	}
+	if ip6 := advertisedIP6(cfg); ip6 != nil {
+		setIP6Entries(localNode, ip6, int(cfg.UDPPort), int(cfg.TCPPort))
+	}
 	dv5Cfg := discover.Config{
 		PrivateKey: privKey,
 	}
@@ -62,19 +71,26 @@ func createListener(ipAddr net.IP, privKey *ecdsa.PrivateKey, cfg *Config) *disc
 		dv5Cfg.Bootnodes = append(dv5Cfg.Bootnodes, bootNode)
 	}
 
-	network, err := discover.ListenV5(conn, localNode, dv5Cfg)
+	listener, err := discover.ListenV5(conn, localNode, dv5Cfg)
 	if err != nil {
 		log.Fatal(err)
 	}
-	return network
+	return listener
 }
 
-func createLocalNode(privKey *ecdsa.PrivateKey, ipAddr net.IP, udpPort int, tcpPort int) (*enode.LocalNode, error) {
-	db, err := enode.OpenDB("")
+// createLocalNode creates the node record of the discovery listener. The node database is kept in
+// memory if the path is empty. The record has no IPv4 entries if the address is nil, as for an
+// IPv6-only node.
+func createLocalNode(privKey *ecdsa.PrivateKey, ipAddr net.IP, udpPort int, tcpPort int, dbPath string) (*enode.LocalNode, error) {
+	db, err := enode.OpenDB(dbPath)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not open node's peer database")
 	}
 	localNode := enode.NewLocalNode(db, privKey)
+	localNode.Set(enr.WithEntry(attSubnetEnrKey, make([]byte, attSubnetCount/8)))
+	if ipAddr == nil {
+		return localNode, nil
+	}
 	ipEntry := enr.IP(ipAddr)
 	udpEntry := enr.UDP(udpPort)
 	tcpEntry := enr.TCP(tcpPort)
@@ -137,14 +153,15 @@ func parseGenericAddrs(addrs []string) (enodeString []string, multiAddrString []
 	return enodeString, multiAddrString
 }
 
-func convertToMultiAddr(nodes []*enode.Node) []ma.Multiaddr {
+// convertToMultiAddr returns the multiaddrs of the nodes reachable over the ip families. Nodes
+// with no reachable address, such as IPv6-only nodes for an IPv4-only node, are skipped.
+func convertToMultiAddr(nodes []*enode.Node, families ipFamilies) []ma.Multiaddr {
 	var multiAddrs []ma.Multiaddr
 	for _, node := range nodes {
-		// ignore nodes with no ip address stored
-		if node.IP() == nil {
+		multiAddr, err := convertToSingleMultiAddr(node, families)
+		if err == errNoReachableAddr {
 			continue
 		}
-		multiAddr, err := convertToSingleMultiAddr(node)
 		if err != nil {
 			log.WithError(err).Error("Could not convert to multiAddr")
 			continue
@@ -154,26 +171,24 @@ func convertToMultiAddr(nodes []*enode.Node) []ma.Multiaddr {
 	return multiAddrs
 }
 
-func convertToSingleMultiAddr(node *enode.Node) (ma.Multiaddr, error) {
-	ip4 := node.IP().To4()
-	if ip4 == nil {
-		return nil, errors.Errorf("node doesn't have an ip4 address, it's stated IP is %s", node.IP().String())
-	}
+func convertToSingleMultiAddr(node *enode.Node, families ipFamilies) (ma.Multiaddr, error) {
 	pubkey := node.Pubkey()
 	assertedKey := convertToInterfacePubkey(pubkey)
 	id, err := peer.IDFromPublicKey(assertedKey)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get peer id")
 	}
-	multiAddrString := fmt.Sprintf("/ip4/%s/tcp/%d/p2p/%s", ip4.String(), node.TCP(), id)
-	multiAddr, err := ma.NewMultiaddr(multiAddrString)
+	multiAddr, err := reachableMultiAddr(node, families, id)
+	if err == errNoReachableAddr {
+		return nil, err
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get multiaddr")
 	}
 	return multiAddr, nil
 }
 
-func peersFromStringAddrs(addrs []string) ([]ma.Multiaddr, error) {
+func peersFromStringAddrs(addrs []string, families ipFamilies) ([]ma.Multiaddr, error) {
 	var allAddrs []ma.Multiaddr
 	enodeString, multiAddrString := parseGenericAddrs(addrs)
 	for _, stringAddr := range multiAddrString {
@@ -188,7 +203,7 @@ func peersFromStringAddrs(addrs []string) ([]ma.Multiaddr, error) {
 		if err != nil {
 			return nil, errors.Wrapf(err, "Could not get enode from string")
 		}
-		addr, err := convertToSingleMultiAddr(enodeAddr)
+		addr, err := convertToSingleMultiAddr(enodeAddr, families)
 		if err != nil {
 			return nil, errors.Wrapf(err, "Could not get multiaddr")
 		}
@@ -110,11 +110,11 @@ func TestStartDiscV5_DiscoverAllPeers(t *testing.T) {
 func TestMultiAddrsConversion_InvalidIPAddr(t *testing.T) {
 	addr := net.ParseIP("invalidIP")
 	_, pkey := createAddrAndPrivKey(t)
-	node, err := createLocalNode(pkey, addr, 0, 0)
+	node, err := createLocalNode(pkey, addr, 0, 0, "")
 	if err != nil {
 		t.Fatal(err)
 	}
-	multiAddr := convertToMultiAddr([]*enode.Node{node.Node()})
+	multiAddr := convertToMultiAddr([]*enode.Node{node.Node()}, ipv4Only)
 	if len(multiAddr) != 0 {
 		t.Error("Invalid ip address converted successfully")
 	}
@@ -125,7 +125,7 @@ func TestMultiAddrConversion_OK(t *testing.T) {
 	ipAddr, pkey := createAddrAndPrivKey(t)
 	listener := createListener(ipAddr, pkey, &Config{})
 
-	_ = convertToMultiAddr([]*enode.Node{listener.Self()})
+	_ = convertToMultiAddr([]*enode.Node{listener.Self()}, ipv4Only)
 	testutil.AssertLogsDoNotContain(t, hook, "Node doesn't have an ip4 address")
 	testutil.AssertLogsDoNotContain(t, hook, "Invalid port, the tcp port of the node is a reserved port")
 	testutil.AssertLogsDoNotContain(t, hook, "Could not get multiaddr")
@@ -14,6 +14,9 @@ const (
 type NetworkEncoding interface {
 	// Decodes to the provided message. The interface must be a pointer to the decoding destination.
 	Decode([]byte, interface{}) error
+	// DecodeGossip decodes to the provided message. The interface must be a pointer to the decoding
+	// destination. The size of the message should not be more than the provided limit.
+	DecodeGossip([]byte, interface{}, uint64) error
 	// DecodeWithLength a bytes from a reader with a varint length prefix. The interface must be a pointer to the
 	// decoding destination.
 	DecodeWithLength(io.Reader, interface{}) error
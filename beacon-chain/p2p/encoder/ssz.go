@@ -6,11 +6,17 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/shared/params"
 )
 
 var _ = NetworkEncoding(&SszNetworkEncoder{})
 
+// ErrExceedsMaxSize is returned when a message is larger than the allowed max size. The size is
+// checked before the message is decompressed or decoded.
+var ErrExceedsMaxSize = errors.New("message exceeds max size")
+
 // SszNetworkEncoder supports p2p networking encoding using SimpleSerialize
 // with snappy compression (if enabled).
 type SszNetworkEncoder struct {
@@ -72,10 +78,28 @@ func (e SszNetworkEncoder) EncodeWithMaxLength(w io.Writer, msg interface{}, max
 	return w.Write(b)
 }
 
-// Decode the bytes to the protobuf message provided.
+// Decode the bytes to the protobuf message provided. The message should not be larger than the
+// gossip max size.
 func (e SszNetworkEncoder) Decode(b []byte, to interface{}) error {
+	return e.DecodeGossip(b, to, params.BeaconConfig().GossipMaxSize)
+}
+
+// DecodeGossip the bytes to the protobuf message provided. This checks that the message, once
+// decompressed, isn't larger than the provided max limit before decoding it.
+func (e SszNetworkEncoder) DecodeGossip(b []byte, to interface{}, maxSize uint64) error {
+	if uint64(len(b)) > maxSize {
+		return errors.Wrapf(ErrExceedsMaxSize, "size of message is %d, max limit is %d", len(b), maxSize)
+	}
 	if e.UseSnappyCompression {
-		var err error
+		// The decompressed length is read from the snappy header, so that a small message can't
+		// make the decoder allocate an arbitrary large buffer.
+		decodedLen, err := snappy.DecodedLen(b)
+		if err != nil {
+			return err
+		}
+		if uint64(decodedLen) > maxSize {
+			return errors.Wrapf(ErrExceedsMaxSize, "size of decompressed message is %d, max limit is %d", decodedLen, maxSize)
+		}
 		b, err = snappy.Decode(nil /*dst*/, b)
 		if err != nil {
 			return err
@@ -85,18 +109,10 @@ func (e SszNetworkEncoder) Decode(b []byte, to interface{}) error {
 	return ssz.Unmarshal(b, to)
 }
 
-// DecodeWithLength the bytes from io.Reader to the protobuf message provided.
+// DecodeWithLength the bytes from io.Reader to the protobuf message provided. The message
+// should not be larger than the max chunk size.
 func (e SszNetworkEncoder) DecodeWithLength(r io.Reader, to interface{}) error {
-	msgLen, err := readVarint(r)
-	if err != nil {
-		return err
-	}
-	b := make([]byte, msgLen)
-	_, err = r.Read(b)
-	if err != nil {
-		return err
-	}
-	return e.Decode(b, to)
+	return e.DecodeWithMaxLength(r, to, params.BeaconConfig().MaxChunkSize)
 }
 
 // DecodeWithMaxLength the bytes from io.Reader to the protobuf message provided.
@@ -107,14 +123,14 @@ func (e SszNetworkEncoder) DecodeWithMaxLength(r io.Reader, to interface{}, maxS
 		return err
 	}
 	if msgLen > maxSize {
-		return fmt.Errorf("size of decoded message is %d which is larger than the provided max limit of %d", msgLen, maxSize)
+		return errors.Wrapf(ErrExceedsMaxSize, "size of decoded message is %d which is larger than the provided max limit of %d", msgLen, maxSize)
 	}
 	b := make([]byte, msgLen)
 	_, err = r.Read(b)
 	if err != nil {
 		return err
 	}
-	return e.Decode(b, to)
+	return e.DecodeGossip(b, to, maxSize)
 }
 
 // ProtocolSuffix returns the appropriate suffix for protocol IDs.
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
 	testpb "github.com/prysmaticlabs/prysm/proto/testing"
 )
@@ -103,3 +104,31 @@ func TestSszNetworkEncoder_DecodeWithMaxLength(t *testing.T) {
 		t.Errorf("error did not contain wanted message. Wanted: %s but Got: %s", wanted, err.Error())
 	}
 }
+
+func TestSszNetworkEncoder_DecodeGossip_MaxSize(t *testing.T) {
+	msg := &testpb.TestSimpleMessage{
+		Foo: make([]byte, 1000),
+		Bar: 4242,
+	}
+	maxSize := uint64(200)
+	for _, e := range []*encoder.SszNetworkEncoder{{UseSnappyCompression: false}, {UseSnappyCompression: true}} {
+		buf := new(bytes.Buffer)
+		if _, err := e.Encode(buf, msg); err != nil {
+			t.Fatal(err)
+		}
+		if e.UseSnappyCompression && uint64(buf.Len()) > maxSize {
+			t.Fatalf("Wanted compressed message smaller than %d, got %d", maxSize, buf.Len())
+		}
+		decoded := &testpb.TestSimpleMessage{}
+		err := e.DecodeGossip(buf.Bytes(), decoded, maxSize)
+		if errors.Cause(err) != encoder.ErrExceedsMaxSize {
+			t.Errorf("Wanted error %v with snappy compression %v, got %v", encoder.ErrExceedsMaxSize, e.UseSnappyCompression, err)
+		}
+		if err := e.DecodeGossip(buf.Bytes(), decoded, 2000); err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(decoded, msg) {
+			t.Error("Decoded message is not the same as original")
+		}
+	}
+}
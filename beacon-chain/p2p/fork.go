@@ -0,0 +1,176 @@
+package p2p
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/shared/event"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+)
+
+const gossipTopicPrefix = "/eth2"
+
+// ErrGenesisUnknown occurs when a fork digest is requested before the genesis of the chain is known.
+var ErrGenesisUnknown = errors.New("genesis of the chain is not known yet")
+
+// genesisInfo holds the genesis time and validators root of the chain, which identify the chain in
+// the fork digests of the gossip topics.
+type genesisInfo struct {
+	lock           sync.RWMutex
+	time           time.Time
+	validatorsRoot []byte
+}
+
+// ComputeForkDigest returns the first 4 bytes of the hash tree root of the fork data built from the
+// fork version and the genesis validators root.
+func ComputeForkDigest(version []byte, genesisValidatorsRoot []byte) ([4]byte, error) {
+	if len(version) != 4 {
+		return [4]byte{}, fmt.Errorf("wanted a fork version of 4 bytes, got %d", len(version))
+	}
+	if len(genesisValidatorsRoot) != 32 {
+		return [4]byte{}, fmt.Errorf("wanted a genesis validators root of 32 bytes, got %d", len(genesisValidatorsRoot))
+	}
+	// The fork data container has 2 fields, its root is the hash of their 32 byte chunks.
+	chunks := make([]byte, 64)
+	copy(chunks[:32], version)
+	copy(chunks[32:], genesisValidatorsRoot)
+	root := hashutil.Hash(chunks)
+	var digest [4]byte
+	copy(digest[:], root[:4])
+	return digest, nil
+}
+
+// ForkVersion returns the version of the fork active at the epoch according to the fork version
+// schedule.
+func ForkVersion(epoch uint64) []byte {
+	version := params.BeaconConfig().GenesisForkVersion
+	for _, forkEpoch := range scheduledForkEpochs() {
+		if forkEpoch > epoch {
+			break
+		}
+		version = params.BeaconConfig().ForkVersionSchedule[forkEpoch]
+	}
+	return version
+}
+
+// activeForkVersions returns the fork versions of the gossip topics to handle at the epoch. Within
+// the transition window around a fork epoch, the topics of the forks on both sides are handled.
+func activeForkVersions(epoch uint64) [][]byte {
+	window := params.BeaconConfig().ForkDigestTransitionEpochs
+	versions := [][]byte{ForkVersion(epoch)}
+	for _, forkEpoch := range scheduledForkEpochs() {
+		switch {
+		case forkEpoch > epoch && forkEpoch-epoch <= window:
+			versions = append(versions, ForkVersion(forkEpoch))
+		case forkEpoch <= epoch && epoch-forkEpoch < window && forkEpoch > 0:
+			versions = append(versions, ForkVersion(forkEpoch-1))
+		}
+	}
+	return versions
+}
+
+// scheduledForkEpochs returns the epochs of the fork version schedule in ascending order.
+func scheduledForkEpochs() []uint64 {
+	epochs := make([]uint64, 0, len(params.BeaconConfig().ForkVersionSchedule))
+	for epoch := range params.BeaconConfig().ForkVersionSchedule {
+		epochs = append(epochs, epoch)
+	}
+	sort.Slice(epochs, func(i, j int) bool {
+		return epochs[i] < epochs[j]
+	})
+	return epochs
+}
+
+// ForkDigestTopic returns the gossip topic with the fork digest, such as
+// /eth2/beacon_block becoming /eth2/{fork digest}/beacon_block.
+func ForkDigestTopic(topic string, digest [4]byte) string {
+	return fmt.Sprintf("%s/%x%s", gossipTopicPrefix, digest, strings.TrimPrefix(topic, gossipTopicPrefix))
+}
+
+// TopicWithoutForkDigest returns the gossip topic without its fork digest, if it has one.
+func TopicWithoutForkDigest(topic string) string {
+	rest := strings.TrimPrefix(topic, gossipTopicPrefix+"/")
+	if len(rest) == len(topic) || len(rest) < 9 || rest[8] != '/' {
+		return topic
+	}
+	if _, err := hex.DecodeString(rest[:8]); err != nil {
+		return topic
+	}
+	return gossipTopicPrefix + rest[8:]
+}
+
+// ForkDigest returns the fork digest of the gossip topics at the current epoch.
+func (s *Service) ForkDigest() ([4]byte, error) {
+	s.genesis.lock.RLock()
+	defer s.genesis.lock.RUnlock()
+	if s.genesis.validatorsRoot == nil {
+		return [4]byte{}, ErrGenesisUnknown
+	}
+	return ComputeForkDigest(ForkVersion(s.currentEpoch()), s.genesis.validatorsRoot)
+}
+
+// ActiveForkDigests returns the fork digests of the gossip topics to subscribe to at the current
+// epoch, the first being the current fork digest.
+func (s *Service) ActiveForkDigests() ([][4]byte, error) {
+	s.genesis.lock.RLock()
+	defer s.genesis.lock.RUnlock()
+	if s.genesis.validatorsRoot == nil {
+		return nil, ErrGenesisUnknown
+	}
+	versions := activeForkVersions(s.currentEpoch())
+	digests := make([][4]byte, len(versions))
+	for i, version := range versions {
+		digest, err := ComputeForkDigest(version, s.genesis.validatorsRoot)
+		if err != nil {
+			return nil, err
+		}
+		digests[i] = digest
+	}
+	return digests, nil
+}
+
+// currentEpoch returns the current epoch according to the genesis time. The genesis lock must be held.
+func (s *Service) currentEpoch() uint64 {
+	if roughtime.Now().Before(s.genesis.time) {
+		return 0
+	}
+	slot := uint64(roughtime.Since(s.genesis.time).Seconds()) / params.BeaconConfig().SecondsPerSlot
+	return slot / params.BeaconConfig().SlotsPerEpoch
+}
+
+// awaitStateInitialized sets the genesis info of the chain once the beacon state is initialized.
+func (s *Service) awaitStateInitialized(stateChannel chan *feed.Event, stateSub event.Subscription) {
+	defer stateSub.Unsubscribe()
+	for {
+		select {
+		case ev := <-stateChannel:
+			if ev.Type == statefeed.Initialized {
+				data := ev.Data.(*statefeed.InitializedData)
+				if data.GenesisValidatorsRoot == nil {
+					log.Error("Received state initialized event without a genesis validators root")
+					return
+				}
+				s.genesis.lock.Lock()
+				s.genesis.time = data.StartTime
+				s.genesis.validatorsRoot = data.GenesisValidatorsRoot
+				s.genesis.lock.Unlock()
+				return
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting goroutine")
+			return
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Subscription to state notifier failed")
+			return
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package p2p
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestComputeForkDigest(t *testing.T) {
+	root := bytes.Repeat([]byte{1}, 32)
+	digest, err := ComputeForkDigest([]byte{0, 0, 0, 0}, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherVersion, err := ComputeForkDigest([]byte{0, 0, 0, 1}, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if digest == otherVersion {
+		t.Error("Expected different fork digests for different fork versions")
+	}
+	otherRoot, err := ComputeForkDigest([]byte{0, 0, 0, 0}, make([]byte, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if digest == otherRoot {
+		t.Error("Expected different fork digests for different genesis validators roots")
+	}
+	if _, err := ComputeForkDigest([]byte{0}, root); err == nil {
+		t.Error("Expected error with a short fork version")
+	}
+}
+
+func TestForkDigestTopic(t *testing.T) {
+	topic := ForkDigestTopic("/eth2/beacon_block", [4]byte{0xab, 0xcd, 0, 1})
+	if topic != "/eth2/abcd0001/beacon_block" {
+		t.Errorf("Unexpected topic %s", topic)
+	}
+	if stripped := TopicWithoutForkDigest(topic); stripped != "/eth2/beacon_block" {
+		t.Errorf("Wanted /eth2/beacon_block, got %s", stripped)
+	}
+	if stripped := TopicWithoutForkDigest("/eth2/beacon_block"); stripped != "/eth2/beacon_block" {
+		t.Errorf("Wanted topic without digest unchanged, got %s", stripped)
+	}
+}
+
+func TestActiveForkVersions_TransitionWindow(t *testing.T) {
+	cfg := params.BeaconConfig()
+	defer params.OverrideBeaconConfig(cfg)
+	newCfg := *cfg
+	newCfg.GenesisForkVersion = []byte{0, 0, 0, 0}
+	newCfg.ForkVersionSchedule = map[uint64][]byte{10: {0, 0, 0, 1}}
+	newCfg.ForkDigestTransitionEpochs = 2
+	params.OverrideBeaconConfig(&newCfg)
+
+	tests := []struct {
+		epoch    uint64
+		versions [][]byte
+	}{
+		{epoch: 7, versions: [][]byte{{0, 0, 0, 0}}},
+		{epoch: 8, versions: [][]byte{{0, 0, 0, 0}, {0, 0, 0, 1}}},
+		{epoch: 10, versions: [][]byte{{0, 0, 0, 1}, {0, 0, 0, 0}}},
+		{epoch: 11, versions: [][]byte{{0, 0, 0, 1}, {0, 0, 0, 0}}},
+		{epoch: 12, versions: [][]byte{{0, 0, 0, 1}}},
+	}
+	for _, tt := range tests {
+		versions := activeForkVersions(tt.epoch)
+		if len(versions) != len(tt.versions) {
+			t.Fatalf("Epoch %d: wanted %d fork versions, got %d", tt.epoch, len(tt.versions), len(versions))
+		}
+		for i := range versions {
+			if !bytes.Equal(versions[i], tt.versions[i]) {
+				t.Errorf("Epoch %d: wanted fork version %#x, got %#x", tt.epoch, tt.versions[i], versions[i])
+			}
+		}
+	}
+}
+
+func TestService_ForkDigest_GenesisUnknown(t *testing.T) {
+	s := &Service{}
+	if _, err := s.ForkDigest(); err != ErrGenesisUnknown {
+		t.Errorf("Wanted %v, got %v", ErrGenesisUnknown, err)
+	}
+	s.genesis.time = time.Now()
+	s.genesis.validatorsRoot = make([]byte, 32)
+	digests, err := s.ActiveForkDigests()
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest, err := s.ForkDigest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(digests) == 0 || digests[0] != digest {
+		t.Errorf("Wanted the current fork digest first, got %v", digests)
+	}
+}
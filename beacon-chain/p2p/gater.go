@@ -0,0 +1,125 @@
+package p2p
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/libp2p/go-libp2p"
+	filter "github.com/libp2p/go-maddr-filter"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+)
+
+// peerGater enforces the allow and deny lists of CIDR subnets on the multiaddress filters of the
+// host, which are checked when dialing and accepting connections. With an allow list, only peers
+// within its subnets are connected. Peers within the subnets of the deny list are never
+// connected, even when within the allow list.
+type peerGater struct {
+	filters *filter.Filters
+	allow   []*net.IPNet
+	deny    []*net.IPNet
+	lock    sync.Mutex
+}
+
+// PeerGaterLists are the allow and deny lists of CIDR subnets of the peer connections.
+type PeerGaterLists struct {
+	AllowList []string `json:"allowlist"`
+	DenyList  []string `json:"denylist"`
+}
+
+func newPeerGater(allowList []string, denyList []string) (*peerGater, error) {
+	allow, err := parseCIDRs(allowList)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid p2p allow list")
+	}
+	deny, err := parseCIDRs(denyList)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid p2p deny list")
+	}
+	return &peerGater{allow: allow, deny: deny}, nil
+}
+
+func parseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	ipNets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+	return ipNets, nil
+}
+
+// option installs the lists of the gater on the multiaddress filters of the host.
+func (g *peerGater) option() libp2p.Option {
+	return func(cfg *libp2p.Config) error {
+		if cfg.Filters == nil {
+			cfg.Filters = filter.NewFilters()
+		}
+		g.lock.Lock()
+		defer g.lock.Unlock()
+		g.filters = cfg.Filters
+		g.apply()
+		return nil
+	}
+}
+
+// apply adds the filters of the lists, the deny filters last so they take precedence over the
+// allow filters.
+func (g *peerGater) apply() {
+	g.filters.DefaultAction = filter.ActionNone
+	if len(g.allow) > 0 {
+		g.filters.DefaultAction = filter.ActionDeny
+	}
+	for _, ipNet := range g.allow {
+		g.filters.AddFilter(*ipNet, filter.ActionAccept)
+	}
+	for _, ipNet := range g.deny {
+		g.filters.AddFilter(*ipNet, filter.ActionDeny)
+	}
+}
+
+// blocked returns true if connections to the multiaddress are not allowed.
+func (g *peerGater) blocked(addr ma.Multiaddr) bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.filters != nil && g.filters.AddrBlocked(addr)
+}
+
+func (g *peerGater) lists() *PeerGaterLists {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	lists := &PeerGaterLists{
+		AllowList: make([]string, len(g.allow)),
+		DenyList:  make([]string, len(g.deny)),
+	}
+	for i, ipNet := range g.allow {
+		lists.AllowList[i] = ipNet.String()
+	}
+	for i, ipNet := range g.deny {
+		lists.DenyList[i] = ipNet.String()
+	}
+	return lists
+}
+
+// PeerGaterLists returns the allow and deny lists of CIDR subnets of the peer connections.
+func (s *Service) PeerGaterLists() *PeerGaterLists {
+	return s.gater.lists()
+}
+
+// PeerGaterHandler is a handler to serve the /p2p/gater page in metrics, which writes the allow
+// and deny lists of CIDR subnets of the peer connections as JSON. The lists are read only, as the
+// monitoring port is not authenticated, and are only set by flag.
+func (s *Service) PeerGaterHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.PeerGaterLists()); err != nil {
+		log.WithError(err).Error("Failed to write peer connection lists")
+	}
+}
@@ -0,0 +1,94 @@
+package p2p
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/libp2p/go-libp2p"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestPeerGater_AllowAndDenyLists(t *testing.T) {
+	g, err := newPeerGater([]string{"192.168.0.0/16"}, []string{"192.168.1.0/24"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.option()(&libp2p.Config{}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		addr    string
+		blocked bool
+	}{
+		{addr: "/ip4/192.168.0.1/tcp/13000", blocked: false},
+		{addr: "/ip4/192.168.1.1/tcp/13000", blocked: true},
+		{addr: "/ip4/10.0.0.1/tcp/13000", blocked: true},
+	}
+	for _, tt := range tests {
+		if blocked := g.blocked(ma.StringCast(tt.addr)); blocked != tt.blocked {
+			t.Errorf("Wanted %s blocked %v, got %v", tt.addr, tt.blocked, blocked)
+		}
+	}
+
+	// Without an allow list, only the denied subnets are blocked.
+	g, err = newPeerGater(nil, []string{"10.0.0.0/8"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.option()(&libp2p.Config{}); err != nil {
+		t.Fatal(err)
+	}
+	tests = []struct {
+		addr    string
+		blocked bool
+	}{
+		{addr: "/ip4/192.168.1.1/tcp/13000", blocked: false},
+		{addr: "/ip4/172.16.0.1/tcp/13000", blocked: false},
+		{addr: "/ip4/10.0.0.1/tcp/13000", blocked: true},
+	}
+	for _, tt := range tests {
+		if blocked := g.blocked(ma.StringCast(tt.addr)); blocked != tt.blocked {
+			t.Errorf("Wanted %s blocked %v without an allow list, got %v", tt.addr, tt.blocked, blocked)
+		}
+	}
+	if lists := g.lists(); len(lists.AllowList) != 0 || len(lists.DenyList) != 1 || lists.DenyList[0] != "10.0.0.0/8" {
+		t.Errorf("Unexpected lists: %+v", lists)
+	}
+}
+
+func TestPeerGater_InvalidCIDR(t *testing.T) {
+	if _, err := newPeerGater([]string{"192.168.0.0"}, nil); err == nil {
+		t.Error("Expected error for an invalid allow list")
+	}
+	if _, err := newPeerGater(nil, []string{"not a subnet"}); err == nil {
+		t.Error("Expected error for an invalid deny list")
+	}
+}
+
+func TestPeerGaterHandler_ReadOnly(t *testing.T) {
+	g, err := newPeerGater(nil, []string{"10.0.0.0/8"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Service{gater: g}
+
+	rec := httptest.NewRecorder()
+	s.PeerGaterHandler(rec, httptest.NewRequest(http.MethodPost, "/p2p/gater", strings.NewReader(`{"allowlist":[],"denylist":[]}`)))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Wanted status %d, received %d", http.StatusMethodNotAllowed, rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	s.PeerGaterHandler(rec, httptest.NewRequest(http.MethodGet, "/p2p/gater", nil))
+	lists := &PeerGaterLists{}
+	if err := json.NewDecoder(rec.Body).Decode(lists); err != nil {
+		t.Fatal(err)
+	}
+	if len(lists.DenyList) != 1 || lists.DenyList[0] != "10.0.0.0/8" {
+		t.Errorf("Wanted the deny list to be unchanged, received %+v", lists)
+	}
+}
@@ -0,0 +1,101 @@
+package p2p
+
+import (
+	"sync"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsub_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/pkg/errors"
+)
+
+// validateGossipParams checks the gossip mesh parameters of the config, where zero values keep
+// the defaults of gossipsub.
+func validateGossipParams(cfg *Config) error {
+	d, dlo, dhi := pubsub.GossipSubD, pubsub.GossipSubDlo, pubsub.GossipSubDhi
+	if cfg.GossipD != 0 {
+		d = cfg.GossipD
+	}
+	if cfg.GossipDlo != 0 {
+		dlo = cfg.GossipDlo
+	}
+	if cfg.GossipDhi != 0 {
+		dhi = cfg.GossipDhi
+	}
+	if d < 0 || dlo < 0 || dhi < 0 || cfg.GossipHeartbeatInterval < 0 {
+		return errors.New("gossip mesh parameters must not be negative")
+	}
+	if dlo > d || d > dhi {
+		return errors.Errorf("gossip mesh degree must be within its low and high watermarks, received D=%d Dlo=%d Dhi=%d", d, dlo, dhi)
+	}
+	return nil
+}
+
+// setGossipParams applies the gossip mesh parameters of the config to gossipsub. These are
+// global to the gossipsub package, so they are set before the router is created.
+func setGossipParams(cfg *Config) {
+	if cfg.GossipD != 0 {
+		pubsub.GossipSubD = cfg.GossipD
+	}
+	if cfg.GossipDlo != 0 {
+		pubsub.GossipSubDlo = cfg.GossipDlo
+	}
+	if cfg.GossipDhi != 0 {
+		pubsub.GossipSubDhi = cfg.GossipDhi
+	}
+	if cfg.GossipHeartbeatInterval != 0 {
+		pubsub.GossipSubHeartbeatInterval = cfg.GossipHeartbeatInterval
+	}
+}
+
+// meshTracer follows the graft and prune events of gossipsub to keep the peers in the mesh of
+// each topic.
+type meshTracer struct {
+	lock   sync.RWMutex
+	meshes map[string]map[string]bool
+}
+
+func newMeshTracer() *meshTracer {
+	return &meshTracer{meshes: make(map[string]map[string]bool)}
+}
+
+// Trace implements pubsub.EventTracer.
+func (m *meshTracer) Trace(evt *pubsub_pb.TraceEvent) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	switch evt.GetType() {
+	case pubsub_pb.TraceEvent_GRAFT:
+		topic := evt.GetGraft().GetTopic()
+		if m.meshes[topic] == nil {
+			m.meshes[topic] = make(map[string]bool)
+		}
+		m.meshes[topic][string(evt.GetGraft().GetPeerID())] = true
+	case pubsub_pb.TraceEvent_PRUNE:
+		delete(m.meshes[evt.GetPrune().GetTopic()], string(evt.GetPrune().GetPeerID()))
+	case pubsub_pb.TraceEvent_LEAVE:
+		delete(m.meshes, evt.GetLeave().GetTopic())
+	case pubsub_pb.TraceEvent_REMOVE_PEER:
+		for _, mesh := range m.meshes {
+			delete(mesh, string(evt.GetRemovePeer().GetPeerID()))
+		}
+	}
+}
+
+// meshSizes returns the number of peers in the mesh of each topic.
+func (m *meshTracer) meshSizes() map[string]int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	sizes := make(map[string]int, len(m.meshes))
+	for topic, mesh := range m.meshes {
+		sizes[topic] = len(mesh)
+	}
+	return sizes
+}
+
+// overBandwidthBudget returns true when the outbound rate of the node exceeds the configured
+// budget, in which case no new peers are accepted.
+func (s *Service) overBandwidthBudget() bool {
+	if s.cfg.OutboundBandwidthBudget == 0 || s.bandwidth == nil {
+		return false
+	}
+	return s.bandwidth.GetBandwidthTotals().RateOut > float64(s.cfg.OutboundBandwidthBudget)
+}
@@ -0,0 +1,67 @@
+package p2p
+
+import (
+	"testing"
+
+	pubsub_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+)
+
+func TestValidateGossipParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *Config
+		wantErr bool
+	}{
+		{name: "defaults", cfg: &Config{}},
+		{name: "smaller mesh", cfg: &Config{GossipD: 3, GossipDlo: 2, GossipDhi: 5}},
+		{name: "degree only within defaults", cfg: &Config{GossipD: 8}},
+		{name: "degree over high watermark", cfg: &Config{GossipD: 6, GossipDhi: 5}, wantErr: true},
+		{name: "low watermark over degree", cfg: &Config{GossipD: 3}, wantErr: true},
+		{name: "negative degree", cfg: &Config{GossipD: -1, GossipDlo: -2}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateGossipParams(tt.cfg); (err != nil) != tt.wantErr {
+				t.Errorf("validateGossipParams() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMeshTracer(t *testing.T) {
+	tracer := newMeshTracer()
+	event := func(typ pubsub_pb.TraceEvent_Type, topic string, peerID string) *pubsub_pb.TraceEvent {
+		evt := &pubsub_pb.TraceEvent{Type: &typ}
+		switch typ {
+		case pubsub_pb.TraceEvent_GRAFT:
+			evt.Graft = &pubsub_pb.TraceEvent_Graft{Topic: &topic, PeerID: []byte(peerID)}
+		case pubsub_pb.TraceEvent_PRUNE:
+			evt.Prune = &pubsub_pb.TraceEvent_Prune{Topic: &topic, PeerID: []byte(peerID)}
+		case pubsub_pb.TraceEvent_LEAVE:
+			evt.Leave = &pubsub_pb.TraceEvent_Leave{Topic: &topic}
+		case pubsub_pb.TraceEvent_REMOVE_PEER:
+			evt.RemovePeer = &pubsub_pb.TraceEvent_RemovePeer{PeerID: []byte(peerID)}
+		}
+		return evt
+	}
+
+	tracer.Trace(event(pubsub_pb.TraceEvent_GRAFT, "blocks", "a"))
+	tracer.Trace(event(pubsub_pb.TraceEvent_GRAFT, "blocks", "b"))
+	tracer.Trace(event(pubsub_pb.TraceEvent_GRAFT, "blocks", "b"))
+	tracer.Trace(event(pubsub_pb.TraceEvent_GRAFT, "exits", "a"))
+	tracer.Trace(event(pubsub_pb.TraceEvent_GRAFT, "slashings", "c"))
+	if sizes := tracer.meshSizes(); sizes["blocks"] != 2 || sizes["exits"] != 1 || sizes["slashings"] != 1 {
+		t.Errorf("Unexpected mesh sizes after grafts: %v", sizes)
+	}
+
+	tracer.Trace(event(pubsub_pb.TraceEvent_PRUNE, "blocks", "b"))
+	tracer.Trace(event(pubsub_pb.TraceEvent_REMOVE_PEER, "", "a"))
+	tracer.Trace(event(pubsub_pb.TraceEvent_LEAVE, "slashings", ""))
+	sizes := tracer.meshSizes()
+	if sizes["blocks"] != 0 || sizes["exits"] != 0 {
+		t.Errorf("Unexpected mesh sizes after prunes: %v", sizes)
+	}
+	if _, ok := sizes["slashings"]; ok {
+		t.Error("Expected no mesh for a topic which was left")
+	}
+}
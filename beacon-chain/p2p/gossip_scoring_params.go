@@ -0,0 +1,84 @@
+package p2p
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+)
+
+// gossipScoreDecayInterval is the interval at which the gossip scores of the peers decay.
+const gossipScoreDecayInterval = 12 * time.Second
+
+// gossipGraylistThreshold is the gossip score under which a peer is disconnected.
+const gossipGraylistThreshold = -400
+
+// gossipScoreParams returns the parameters of the gossip score of the peers. Blocks weigh
+// more than attestations, as a peer relaying invalid blocks harms the node the most, and the
+// message counters decay to a tenth of their value in about four minutes. As messages which are
+// only ignored also fail validation, a peer has to deliver several of them in a short time to be
+// disconnected.
+func gossipScoreParams() *peers.ScoreParams {
+	topics := map[string]*peers.TopicScoreParams{
+		"/eth2/beacon_block": {
+			TopicWeight:                    1,
+			FirstMessageDeliveriesWeight:   1,
+			FirstMessageDeliveriesCap:      20,
+			InvalidMessageDeliveriesWeight: -10,
+		},
+		"/eth2/beacon_aggregate_and_proof": {
+			TopicWeight:                    0.5,
+			FirstMessageDeliveriesWeight:   0.1,
+			FirstMessageDeliveriesCap:      100,
+			InvalidMessageDeliveriesWeight: -2,
+		},
+		"/eth2/voluntary_exit": {
+			TopicWeight:                    0.05,
+			FirstMessageDeliveriesWeight:   1,
+			FirstMessageDeliveriesCap:      10,
+			InvalidMessageDeliveriesWeight: -100,
+		},
+		"/eth2/proposer_slashing": {
+			TopicWeight:                    0.05,
+			FirstMessageDeliveriesWeight:   1,
+			FirstMessageDeliveriesCap:      10,
+			InvalidMessageDeliveriesWeight: -100,
+		},
+		"/eth2/attester_slashing": {
+			TopicWeight:                    0.05,
+			FirstMessageDeliveriesWeight:   1,
+			FirstMessageDeliveriesCap:      10,
+			InvalidMessageDeliveriesWeight: -100,
+		},
+	}
+	for i := uint64(0); i < attSubnetCount; i++ {
+		topics[fmt.Sprintf(attestationSubnetTopicFormat, i)] = &peers.TopicScoreParams{
+			TopicWeight:                    1.0 / attSubnetCount,
+			FirstMessageDeliveriesWeight:   0.5,
+			FirstMessageDeliveriesCap:      50,
+			InvalidMessageDeliveriesWeight: -20,
+		}
+	}
+	return &peers.ScoreParams{
+		Topics:            topics,
+		DecayFactor:       0.9,
+		DecayToZero:       0.01,
+		GraylistThreshold: gossipGraylistThreshold,
+	}
+}
+
+// decayGossipScores decays the gossip scores of the peers, and disconnects the connected peers
+// whose score is under the graylist threshold.
+func (s *Service) decayGossipScores() {
+	s.peers.DecayGossipScores()
+	for _, pid := range s.peers.Connected() {
+		score, err := s.peers.GossipScore(pid)
+		if err != nil || score >= gossipGraylistThreshold {
+			continue
+		}
+		log.WithField("peer", pid).WithField("score", score).Debug("Disconnecting peer with low gossip score")
+		if err := s.Disconnect(pid); err != nil {
+			log.WithError(err).Error("Unable to disconnect from peer")
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/params"
 )
 
 // GossipTopicMappings represent the protocol ID to protobuf message type map for easy
@@ -18,6 +19,30 @@ var GossipTopicMappings = map[string]proto.Message{
 	"/eth2/beacon_aggregate_and_proof":           &pb.AggregateAttestationAndProof{},
 }
 
+// gossipTopicMaxSizes bounds the uncompressed size of messages on topics whose messages are
+// much smaller than the gossip max size. Each bound is the largest valid SSZ encoding of the
+// topic message rounded up to the next power of 2.
+var gossipTopicMaxSizes = map[string]uint64{
+	// 485 bytes with a full committee of 2048 validators.
+	"/eth2/committee_index%d_beacon_attestation": 1 << 10,
+	// 112 bytes.
+	"/eth2/voluntary_exit": 1 << 7,
+	// 424 bytes.
+	"/eth2/proposer_slashing": 1 << 9,
+	// 33232 bytes with 2 attestations of full committees.
+	"/eth2/attester_slashing": 1 << 16,
+	// 593 bytes with a full committee.
+	"/eth2/beacon_aggregate_and_proof": 1 << 10,
+}
+
+// GossipTopicMaxSize returns the max allowed size of uncompressed messages on the topic.
+func GossipTopicMaxSize(topic string) uint64 {
+	if size, ok := gossipTopicMaxSizes[topic]; ok && size < params.BeaconConfig().GossipMaxSize {
+		return size
+	}
+	return params.BeaconConfig().GossipMaxSize
+}
+
 // GossipTypeMapping is the inverse of GossipTopicMappings so that an arbitrary protobuf message
 // can be mapped to a protocol ID string.
 var GossipTypeMapping = make(map[reflect.Type]string)
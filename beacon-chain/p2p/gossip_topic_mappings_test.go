@@ -3,6 +3,8 @@ package p2p
 import (
 	"reflect"
 	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
 )
 
 func TestMappingHasNoDuplicates(t *testing.T) {
@@ -14,3 +16,17 @@ func TestMappingHasNoDuplicates(t *testing.T) {
 		m[reflect.TypeOf(v)] = true
 	}
 }
+
+func TestGossipTopicMaxSize(t *testing.T) {
+	for topic := range gossipTopicMaxSizes {
+		if _, ok := GossipTopicMappings[topic]; !ok {
+			t.Errorf("Max size set for unknown topic %s", topic)
+		}
+	}
+	if size := GossipTopicMaxSize("/eth2/voluntary_exit"); size != 1<<7 {
+		t.Errorf("Wanted max size %d for voluntary exits, got %d", 1<<7, size)
+	}
+	if size := GossipTopicMaxSize("/eth2/beacon_block"); size != params.BeaconConfig().GossipMaxSize {
+		t.Errorf("Wanted gossip max size %d for blocks, got %d", params.BeaconConfig().GossipMaxSize, size)
+	}
+}
@@ -34,6 +34,13 @@ func (s *Service) AddConnectionHandler(reqFunc func(ctx context.Context, id peer
 				}
 				return
 			}
+			if s.overBandwidthBudget() {
+				log.WithField("reason", "over bandwidth budget").Trace("Ignoring connection request")
+				if err := s.Disconnect(conn.RemotePeer()); err != nil {
+					log.WithError(err).Error("Unable to disconnect from peer")
+				}
+				return
+			}
 			if s.peers.IsBad(conn.RemotePeer()) {
 				log.WithField("reason", "bad peer").Trace("Ignoring connection request")
 				if err := s.Disconnect(conn.RemotePeer()); err != nil {
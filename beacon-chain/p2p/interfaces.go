@@ -3,10 +3,12 @@ package p2p
 import (
 	"context"
 
+	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/gogo/protobuf/proto"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	ma "github.com/multiformats/go-multiaddr"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 )
@@ -21,6 +23,8 @@ type P2P interface {
 	Sender
 	ConnectionHandler
 	PeersProvider
+	ForkDigestProvider
+	IdentityProvider
 }
 
 // Broadcaster broadcasts messages to peers over the p2p pubsub protocol.
@@ -60,6 +64,20 @@ type Sender interface {
 	Send(context.Context, interface{}, peer.ID) (network.Stream, error)
 }
 
+// ForkDigestProvider provides the fork digests of the gossip topics.
+type ForkDigestProvider interface {
+	ForkDigest() ([4]byte, error)
+	ActiveForkDigests() ([][4]byte, error)
+}
+
+// IdentityProvider provides the identity the node advertises to the network.
+type IdentityProvider interface {
+	PeerID() peer.ID
+	ENR() *enr.Record
+	HostAddresses() []ma.Multiaddr
+	AttestationSubnets() []byte
+}
+
 // PeersProvider abstracts obtaining our current list of known peers status.
 type PeersProvider interface {
 	Peers() *peers.Status
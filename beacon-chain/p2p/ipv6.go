@@ -0,0 +1,153 @@
+package p2p
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/iputils"
+)
+
+var errNoReachableAddr = errors.New("node has no address reachable over the enabled ip families")
+
+// ipFamilies are the IP families peers are dialed over.
+type ipFamilies struct {
+	ip4 bool
+	ip6 bool
+}
+
+// ipv4Only dials peers over IPv4, as done when no local IPv6 address is configured.
+var ipv4Only = ipFamilies{ip4: true}
+
+// ip6Entry is the "ip6" entry of a node record, which holds the IPv6 address of a dual-stack or
+// IPv6-only node next to the "ip" entry holding its IPv4 address.
+type ip6Entry net.IP
+
+// ENRKey implements enr.Entry.
+func (ip6Entry) ENRKey() string { return "ip6" }
+
+// validateIP6Config checks that the configured IPv6 addresses are IPv6 addresses, and that an
+// advertised IPv6 address comes with a local IPv6 address to listen on.
+func validateIP6Config(cfg *Config) error {
+	for name, addr := range map[string]string{"local": cfg.LocalIP6, "host": cfg.HostAddress6} {
+		if addr == "" {
+			continue
+		}
+		if ip := net.ParseIP(addr); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("invalid %s ipv6 address %s", name, addr)
+		}
+	}
+	if cfg.HostAddress6 != "" && cfg.LocalIP6 == "" {
+		return errors.New("a local ipv6 address is required to advertise a host ipv6 address")
+	}
+	return nil
+}
+
+// listenIP6 returns the local IPv6 address the p2p host and discovery listen on, or nil if
+// IPv6 is not enabled.
+func listenIP6(cfg *Config) net.IP {
+	if cfg.LocalIP6 == "" {
+		return nil
+	}
+	return net.ParseIP(cfg.LocalIP6)
+}
+
+// advertisedIP6 returns the IPv6 address advertised in the node record: the host IPv6 address
+// if any, else the local IPv6 address if it is not the unspecified address, else the first
+// global IPv6 address of the interfaces. Returns nil if there is no IPv6 address to advertise.
+func advertisedIP6(cfg *Config) net.IP {
+	if cfg.HostAddress6 != "" {
+		return net.ParseIP(cfg.HostAddress6)
+	}
+	local := listenIP6(cfg)
+	if local == nil {
+		return nil
+	}
+	if !local.IsUnspecified() {
+		return local
+	}
+	external, err := iputils.ExternalIPv6()
+	if err != nil {
+		log.WithError(err).Error("Could not get IPv6 address")
+		return nil
+	}
+	ip := net.ParseIP(external)
+	if ip == nil || ip.IsLoopback() {
+		log.Warn("No global IPv6 address to advertise, set the host IPv6 address to advertise one")
+		return nil
+	}
+	return ip
+}
+
+// localIPv4 returns the IPv4 address of the node, or nil for an IPv6-only node, which has IPv6
+// enabled and no IPv4 address but the loopback one.
+func localIPv4(cfg *Config) net.IP {
+	ip := ipAddr()
+	if cfg.LocalIP6 != "" && cfg.LocalIP == "" && cfg.HostAddress == "" && ip.IsLoopback() {
+		return nil
+	}
+	return ip
+}
+
+// enabledFamilies returns the IP families the node dials peers over.
+func enabledFamilies(cfg *Config, ip4 net.IP) ipFamilies {
+	return ipFamilies{
+		ip4: ip4 != nil,
+		ip6: listenIP6(cfg) != nil,
+	}
+}
+
+// discoveryNetwork returns the network and address the discovery UDP socket is bound to. With
+// both IP families enabled, the socket is bound to the unspecified IPv6 address, which accepts
+// IPv4 packets as well on dual-stack hosts.
+func discoveryNetwork(ip4 net.IP, ip6 net.IP) (string, net.IP) {
+	switch {
+	case ip6 == nil:
+		return "udp4", ip4
+	case ip4 == nil:
+		return "udp6", ip6
+	default:
+		return "udp", net.IPv6unspecified
+	}
+}
+
+// setIP6Entries adds the IPv6 address and ports of the node to its record.
+func setIP6Entries(localNode *enode.LocalNode, ip6 net.IP, udpPort int, tcpPort int) {
+	localNode.Set(ip6Entry(ip6))
+	localNode.Set(enr.UDP6(udpPort))
+	localNode.Set(enr.TCP6(tcpPort))
+}
+
+// nodeIP6 returns the IPv6 address of the node record, or nil if it has none.
+func nodeIP6(node *enode.Node) net.IP {
+	var ip ip6Entry
+	if err := node.Load(&ip); err != nil || len(ip) != net.IPv6len {
+		return nil
+	}
+	return net.IP(ip)
+}
+
+// nodeTCP6 returns the IPv6 TCP port of the node record, which defaults to its TCP port.
+func nodeTCP6(node *enode.Node) int {
+	var port enr.TCP6
+	if err := node.Load(&port); err != nil {
+		return node.TCP()
+	}
+	return int(port)
+}
+
+// reachableMultiAddr returns the multiaddr the node is dialed on, over IPv4 if both the node and
+// this node have an IPv4 address, and over IPv6 otherwise.
+func reachableMultiAddr(node *enode.Node, families ipFamilies, id peer.ID) (ma.Multiaddr, error) {
+	if ip4 := node.IP().To4(); families.ip4 && ip4 != nil {
+		return ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d/p2p/%s", ip4.String(), node.TCP(), id))
+	}
+	if ip6 := nodeIP6(node); families.ip6 && ip6 != nil {
+		return ma.NewMultiaddr(fmt.Sprintf("/ip6/%s/tcp/%d/p2p/%s", ip6.String(), nodeTCP6(node), id))
+	}
+	return nil, errNoReachableAddr
+}
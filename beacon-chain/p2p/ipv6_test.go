@@ -0,0 +1,94 @@
+package p2p
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+func TestValidateIP6Config(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *Config
+		wantErr string
+	}{
+		{name: "disabled", cfg: &Config{}},
+		{name: "local and host", cfg: &Config{LocalIP6: "::", HostAddress6: "2001:db8::1"}},
+		{name: "ipv4 local address", cfg: &Config{LocalIP6: "10.0.0.1"}, wantErr: "invalid local ipv6 address"},
+		{name: "invalid host address", cfg: &Config{LocalIP6: "::", HostAddress6: "host"}, wantErr: "invalid host ipv6 address"},
+		{name: "host without local", cfg: &Config{HostAddress6: "2001:db8::1"}, wantErr: "a local ipv6 address is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateIP6Config(tt.cfg)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("Wanted error %q, received %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDiscoveryNetwork(t *testing.T) {
+	ip4 := net.ParseIP("10.0.0.1")
+	ip6 := net.ParseIP("2001:db8::1")
+	if network, ip := discoveryNetwork(ip4, nil); network != "udp4" || !ip.Equal(ip4) {
+		t.Errorf("Wanted udp4 on %s, received %s on %s", ip4, network, ip)
+	}
+	if network, ip := discoveryNetwork(nil, ip6); network != "udp6" || !ip.Equal(ip6) {
+		t.Errorf("Wanted udp6 on %s, received %s on %s", ip6, network, ip)
+	}
+	if network, ip := discoveryNetwork(ip4, ip6); network != "udp" || !ip.IsUnspecified() {
+		t.Errorf("Wanted udp on the unspecified address, received %s on %s", network, ip)
+	}
+}
+
+func TestConvertToSingleMultiAddr_PrefersReachableFamily(t *testing.T) {
+	_, pkey := createAddrAndPrivKey(t)
+	localNode, err := createLocalNode(pkey, net.ParseIP("10.0.0.1"), 3000, 4000, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	setIP6Entries(localNode, net.ParseIP("2001:db8::1"), 3001, 4001)
+	dualStack := localNode.Node()
+
+	ip6OnlyNode, err := createLocalNode(pkey, nil, 0, 0, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	setIP6Entries(ip6OnlyNode, net.ParseIP("2001:db8::2"), 3001, 4001)
+	ip6Only := ip6OnlyNode.Node()
+
+	tests := []struct {
+		name     string
+		families ipFamilies
+		wantAddr string
+	}{
+		{name: "ipv4 only", families: ipv4Only, wantAddr: "/ip4/10.0.0.1/tcp/4000/"},
+		{name: "ipv6 only", families: ipFamilies{ip6: true}, wantAddr: "/ip6/2001:db8::1/tcp/4001/"},
+		{name: "dual stack", families: ipFamilies{ip4: true, ip6: true}, wantAddr: "/ip4/10.0.0.1/tcp/4000/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := convertToSingleMultiAddr(dualStack, tt.families)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(addr.String(), tt.wantAddr) {
+				t.Errorf("Wanted address %s, received %s", tt.wantAddr, addr)
+			}
+		})
+	}
+
+	if _, err := convertToSingleMultiAddr(ip6Only, ipv4Only); err != errNoReachableAddr {
+		t.Errorf("Wanted %v, received %v", errNoReachableAddr, err)
+	}
+	addrs := convertToMultiAddr([]*enode.Node{dualStack, ip6Only}, ipFamilies{ip4: true, ip6: true})
+	if len(addrs) != 2 || !strings.HasPrefix(addrs[1].String(), "/ip6/2001:db8::2/tcp/4001/") {
+		t.Errorf("Wanted the ipv6 address of the ipv6 only node, received %v", addrs)
+	}
+}
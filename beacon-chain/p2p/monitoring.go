@@ -16,6 +16,16 @@ var (
 		Help: "The number of peers in a given state.",
 	},
 		[]string{"state"})
+	p2pMeshPeerCount = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "p2p_mesh_peer_count",
+		Help: "The number of peers in the gossip mesh of a given topic.",
+	},
+		[]string{"topic"})
+	p2pBandwidthRate = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "p2p_bandwidth_rate",
+		Help: "The rate of p2p traffic in bytes per second in a given direction.",
+	},
+		[]string{"direction"})
 )
 
 func (s *Service) updateMetrics() {
@@ -28,4 +38,10 @@ func (s *Service) updateMetrics() {
 	p2pPeerCount.WithLabelValues("Connecting").Set(float64(len(s.peers.Connecting())))
 	p2pPeerCount.WithLabelValues("Disconnecting").Set(float64(len(s.peers.Disconnecting())))
 	p2pPeerCount.WithLabelValues("Bad").Set(float64(len(s.peers.Bad())))
+	for topic, size := range s.meshTracer.meshSizes() {
+		p2pMeshPeerCount.WithLabelValues(topic).Set(float64(size))
+	}
+	totals := s.bandwidth.GetBandwidthTotals()
+	p2pBandwidthRate.WithLabelValues("inbound").Set(totals.RateIn)
+	p2pBandwidthRate.WithLabelValues("outbound").Set(totals.RateOut)
 }
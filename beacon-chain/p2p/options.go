@@ -15,16 +15,28 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 )
 
-// buildOptions for the libp2p host.
+// buildOptions for the libp2p host. The host listens on the IPv4 address, unless nil for an
+// IPv6-only node, and on the local IPv6 address if configured.
 func buildOptions(cfg *Config, ip net.IP, priKey *ecdsa.PrivateKey) []libp2p.Option {
-	listen, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", ip, cfg.TCPPort))
-	if err != nil {
-		log.Fatalf("Failed to p2p listen: %v", err)
+	var listenAddrs []ma.Multiaddr
+	if ip != nil {
+		listen, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", ip, cfg.TCPPort))
+		if err != nil {
+			log.Fatalf("Failed to p2p listen: %v", err)
+		}
+		listenAddrs = append(listenAddrs, listen)
+	}
+	if ip6 := listenIP6(cfg); ip6 != nil {
+		listen, err := ma.NewMultiaddr(fmt.Sprintf("/ip6/%s/tcp/%d", ip6, cfg.TCPPort))
+		if err != nil {
+			log.Fatalf("Failed to p2p listen: %v", err)
+		}
+		listenAddrs = append(listenAddrs, listen)
 	}
 	options := []libp2p.Option{
 		privKeyOption(priKey),
 		libp2p.EnableRelay(),
-		libp2p.ListenAddrs(listen),
+		libp2p.ListenAddrs(listenAddrs...),
 		whitelistSubnet(cfg.WhitelistCIDR),
 		// Add one for the boot node and another for the relay, otherwise when we are close to maxPeers we will be above the high
 		// water mark and continually trigger pruning.
@@ -40,26 +52,10 @@ func buildOptions(cfg *Config, ip net.IP, priKey *ecdsa.PrivateKey) []libp2p.Opt
 	if cfg.RelayNodeAddr != "" {
 		options = append(options, libp2p.AddrsFactory(withRelayAddrs(cfg.RelayNodeAddr)))
 	}
-	if cfg.HostAddress != "" {
-		options = append(options, libp2p.AddrsFactory(func(addrs []multiaddr.Multiaddr) []multiaddr.Multiaddr {
-			external, err := multiaddr.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", cfg.HostAddress, cfg.TCPPort))
-			if err != nil {
-				log.WithError(err).Error("Unable to create external multiaddress")
-			} else {
-				addrs = append(addrs, external)
-			}
-			return addrs
-		}))
-	}
-	if cfg.HostDNS != "" {
+	if external := externalAddrs(cfg); len(external) > 0 {
+		// The external addresses share a single factory, as libp2p accepts only one.
 		options = append(options, libp2p.AddrsFactory(func(addrs []multiaddr.Multiaddr) []multiaddr.Multiaddr {
-			external, err := multiaddr.NewMultiaddr(fmt.Sprintf("/dns4/%s/tcp/%d", cfg.HostDNS, cfg.TCPPort))
-			if err != nil {
-				log.WithError(err).Error("Unable to create external multiaddress")
-			} else {
-				addrs = append(addrs, external)
-			}
-			return addrs
+			return append(addrs, external...)
 		}))
 	}
 	if cfg.LocalIP != "" {
@@ -67,7 +63,7 @@ func buildOptions(cfg *Config, ip net.IP, priKey *ecdsa.PrivateKey) []libp2p.Opt
 			log.Errorf("Invalid local ip provided: %s", cfg.LocalIP)
 			return options
 		}
-		listen, err = ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", cfg.LocalIP, cfg.TCPPort))
+		listen, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", cfg.LocalIP, cfg.TCPPort))
 		if err != nil {
 			log.Fatalf("Failed to p2p listen: %v", err)
 		}
@@ -76,6 +72,30 @@ func buildOptions(cfg *Config, ip net.IP, priKey *ecdsa.PrivateKey) []libp2p.Opt
 	return options
 }
 
+// externalAddrs returns the external IPv4, IPv6 and DNS addresses advertised by the host.
+func externalAddrs(cfg *Config) []multiaddr.Multiaddr {
+	var formats []string
+	if cfg.HostAddress != "" {
+		formats = append(formats, fmt.Sprintf("/ip4/%s/tcp/%d", cfg.HostAddress, cfg.TCPPort))
+	}
+	if cfg.HostAddress6 != "" {
+		formats = append(formats, fmt.Sprintf("/ip6/%s/tcp/%d", cfg.HostAddress6, cfg.TCPPort))
+	}
+	if cfg.HostDNS != "" {
+		formats = append(formats, fmt.Sprintf("/dns4/%s/tcp/%d", cfg.HostDNS, cfg.TCPPort))
+	}
+	addrs := make([]multiaddr.Multiaddr, 0, len(formats))
+	for _, f := range formats {
+		external, err := multiaddr.NewMultiaddr(f)
+		if err != nil {
+			log.WithError(err).Error("Unable to create external multiaddress")
+			continue
+		}
+		addrs = append(addrs, external)
+	}
+	return addrs
+}
+
 // Adds a private key to the libp2p option if the option was provided.
 // If the private key file is missing or cannot be read, or if the
 // private key contents cannot be marshaled, an exception is thrown.
@@ -0,0 +1,141 @@
+package peers
+
+import (
+	"math"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+// TopicScoreParams are the parameters of the gossip score a peer gets from the messages it
+// delivers on a topic.
+type TopicScoreParams struct {
+	// TopicWeight is the weight of the topic score in the gossip score of the peer.
+	TopicWeight float64
+	// FirstMessageDeliveriesWeight is the score of each valid message first delivered by the peer.
+	FirstMessageDeliveriesWeight float64
+	// FirstMessageDeliveriesCap is the maximum counter of valid messages first delivered by the peer.
+	FirstMessageDeliveriesCap float64
+	// InvalidMessageDeliveriesWeight is the score, usually negative, of the square of the counter of
+	// invalid messages delivered by the peer.
+	InvalidMessageDeliveriesWeight float64
+}
+
+// ScoreParams are the parameters of the gossip score of the peers.
+type ScoreParams struct {
+	// Topics are the score parameters of the topics, keyed by topic without fork digest and
+	// encoding suffix. Messages of other topics do not change the gossip score.
+	Topics map[string]*TopicScoreParams
+	// DecayFactor is the factor the message counters are multiplied by on every decay.
+	DecayFactor float64
+	// DecayToZero is the value under which a decayed message counter is reset to zero.
+	DecayToZero float64
+	// GraylistThreshold is the gossip score under which a peer is considered bad.
+	GraylistThreshold float64
+}
+
+// topicScore holds the message counters of a peer on a topic.
+type topicScore struct {
+	firstMessageDeliveries   float64
+	invalidMessageDeliveries float64
+}
+
+// SetScoreParams sets the parameters of the gossip score of the peers.
+func (p *Status) SetScoreParams(params *ScoreParams) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.scoreParams = params
+}
+
+// RecordGossipValidation records the result of the validation of a message delivered by the given
+// remote peer on a topic, the topic being without fork digest and encoding suffix.
+func (p *Status) RecordGossipValidation(pid peer.ID, topic string, valid bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.scoreParams == nil {
+		return
+	}
+	params, ok := p.scoreParams.Topics[topic]
+	if !ok {
+		return
+	}
+	status := p.fetch(pid)
+	if status.topicScores == nil {
+		status.topicScores = make(map[string]*topicScore)
+	}
+	ts, ok := status.topicScores[topic]
+	if !ok {
+		ts = &topicScore{}
+		status.topicScores[topic] = ts
+	}
+	if valid {
+		ts.firstMessageDeliveries = math.Min(ts.firstMessageDeliveries+1, params.FirstMessageDeliveriesCap)
+	} else {
+		ts.invalidMessageDeliveries++
+	}
+}
+
+// GossipScore obtains the gossip score of the given remote peer.
+// This will error if the peer does not exist.
+func (p *Status) GossipScore(pid peer.ID) (float64, error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if status, ok := p.status[pid]; ok {
+		return p.gossipScore(status), nil
+	}
+	return 0, ErrPeerUnknown
+}
+
+// DecayGossipScores decays the message counters of the gossip score of all peers, so that the
+// score of a peer reflects its recent behaviour.
+func (p *Status) DecayGossipScores() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.scoreParams == nil {
+		return
+	}
+	for _, status := range p.status {
+		for topic, ts := range status.topicScores {
+			ts.firstMessageDeliveries = p.decay(ts.firstMessageDeliveries)
+			ts.invalidMessageDeliveries = p.decay(ts.invalidMessageDeliveries)
+			if ts.firstMessageDeliveries == 0 && ts.invalidMessageDeliveries == 0 {
+				delete(status.topicScores, topic)
+			}
+		}
+	}
+}
+
+// This returns the gossip score of a peer, the lock is expected to be held by the caller.
+func (p *Status) gossipScore(status *peerStatus) float64 {
+	if p.scoreParams == nil {
+		return 0
+	}
+	score := 0.0
+	for topic, ts := range status.topicScores {
+		params, ok := p.scoreParams.Topics[topic]
+		if !ok {
+			continue
+		}
+		topicScore := ts.firstMessageDeliveries * params.FirstMessageDeliveriesWeight
+		topicScore += ts.invalidMessageDeliveries * ts.invalidMessageDeliveries * params.InvalidMessageDeliveriesWeight
+		score += topicScore * params.TopicWeight
+	}
+	return score
+}
+
+// This states if the gossip score of a peer is under the graylist threshold, the lock is expected
+// to be held by the caller.
+func (p *Status) isGraylisted(status *peerStatus) bool {
+	return p.scoreParams != nil && p.gossipScore(status) < p.scoreParams.GraylistThreshold
+}
+
+func (p *Status) decay(counter float64) float64 {
+	counter *= p.scoreParams.DecayFactor
+	if counter < p.scoreParams.DecayToZero {
+		return 0
+	}
+	return counter
+}
@@ -0,0 +1,88 @@
+package peers_test
+
+import (
+	"testing"
+
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+)
+
+func TestGossipScore(t *testing.T) {
+	maxBadResponses := 2
+	p := peers.NewStatus(maxBadResponses)
+	p.SetScoreParams(&peers.ScoreParams{
+		Topics: map[string]*peers.TopicScoreParams{
+			"/eth2/beacon_block": {
+				TopicWeight:                    1,
+				FirstMessageDeliveriesWeight:   1,
+				FirstMessageDeliveriesCap:      2,
+				InvalidMessageDeliveriesWeight: -1,
+			},
+		},
+		DecayFactor:       0.5,
+		DecayToZero:       0.1,
+		GraylistThreshold: -10,
+	})
+
+	id, err := peer.IDB58Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.GossipScore(id); err != peers.ErrPeerUnknown {
+		t.Errorf("Unexpected error: expected %v, received %v", peers.ErrPeerUnknown, err)
+	}
+
+	// Valid messages are rewarded up to the cap, messages of topics without parameters are ignored.
+	for i := 0; i < 3; i++ {
+		p.RecordGossipValidation(id, "/eth2/beacon_block", true)
+	}
+	p.RecordGossipValidation(id, "/eth2/voluntary_exit", false)
+	score, err := p.GossipScore(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score != 2 {
+		t.Errorf("Unexpected score: expected 2, received %v", score)
+	}
+
+	// Invalid messages are penalized by the square of their counter.
+	for i := 0; i < 4; i++ {
+		p.RecordGossipValidation(id, "/eth2/beacon_block", false)
+	}
+	score, err = p.GossipScore(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score != -14 {
+		t.Errorf("Unexpected score: expected -14, received %v", score)
+	}
+	if !p.IsBad(id) {
+		t.Error("Expected peer under the graylist threshold to be bad")
+	}
+	if len(p.Bad()) != 1 {
+		t.Errorf("Unexpected number of bad peers: expected 1, received %d", len(p.Bad()))
+	}
+
+	// The counters decay.
+	p.DecayGossipScores()
+	score, err = p.GossipScore(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score != -3 {
+		t.Errorf("Unexpected score: expected -3, received %v", score)
+	}
+	if p.IsBad(id) {
+		t.Error("Expected peer above the graylist threshold not to be bad")
+	}
+	for i := 0; i < 5; i++ {
+		p.DecayGossipScores()
+	}
+	score, err = p.GossipScore(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score != 0 {
+		t.Errorf("Unexpected score: expected 0, received %v", score)
+	}
+}
@@ -57,6 +57,7 @@ var (
 type Status struct {
 	lock            sync.RWMutex
 	maxBadResponses int
+	scoreParams     *ScoreParams
 	status          map[peer.ID]*peerStatus
 }
 
@@ -68,6 +69,7 @@ type peerStatus struct {
 	chainState            *pb.Status
 	chainStateLastUpdated time.Time
 	badResponses          int
+	topicScores           map[string]*topicScore
 }
 
 // NewStatus creates a new status entity.
@@ -205,14 +207,15 @@ func (p *Status) BadResponses(pid peer.ID) (int, error) {
 	return -1, ErrPeerUnknown
 }
 
-// IsBad states if the peer is to be considered bad.
+// IsBad states if the peer is to be considered bad, having either too many bad responses or a gossip score under the graylist
+// threshold.
 // If the peer is unknown this will return `false`, which makes using this function easier than returning an error.
 func (p *Status) IsBad(pid peer.ID) bool {
 	p.lock.RLock()
 	defer p.lock.RUnlock()
 
 	if status, ok := p.status[pid]; ok {
-		return status.badResponses >= p.maxBadResponses
+		return status.badResponses >= p.maxBadResponses || p.isGraylisted(status)
 	}
 	return false
 }
@@ -301,7 +304,7 @@ func (p *Status) Bad() []peer.ID {
 	defer p.lock.RUnlock()
 	peers := make([]peer.ID, 0)
 	for pid, status := range p.status {
-		if status.badResponses >= p.maxBadResponses {
+		if status.badResponses >= p.maxBadResponses || p.isGraylisted(status) {
 			peers = append(peers, pid)
 		}
 	}
@@ -396,6 +399,11 @@ func (p *Status) fetch(pid peer.ID) *peerStatus {
 
 // CurrentEpoch returns the highest reported epoch amongst peers.
 func (p *Status) CurrentEpoch() uint64 {
+	return helpers.SlotToEpoch(p.HighestHeadSlot())
+}
+
+// HighestHeadSlot returns the highest reported head slot amongst peers.
+func (p *Status) HighestHeadSlot() uint64 {
 	p.lock.RLock()
 	defer p.lock.RUnlock()
 	var highestSlot uint64
@@ -404,5 +412,5 @@ func (p *Status) CurrentEpoch() uint64 {
 			highestSlot = ps.chainState.HeadSlot
 		}
 	}
-	return helpers.SlotToEpoch(highestSlot)
+	return highestSlot
 }
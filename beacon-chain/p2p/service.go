@@ -9,10 +9,12 @@ import (
 
 	"github.com/dgraph-io/ristretto"
 	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
 	ds "github.com/ipfs/go-datastore"
 	dsync "github.com/ipfs/go-datastore/sync"
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/metrics"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/protocol"
@@ -22,6 +24,7 @@ import (
 	rhost "github.com/libp2p/go-libp2p/p2p/host/routed"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/shared"
@@ -51,6 +54,11 @@ type Service struct {
 	privKey       *ecdsa.PrivateKey
 	dht           *kaddht.IpfsDHT
 	peers         *peers.Status
+	genesis       genesisInfo
+	gater         *peerGater
+	meshTracer    *meshTracer
+	bandwidth     *metrics.BandwidthCounter
+	families      ipFamilies
 }
 
 // NewService initializes a new p2p service compatible with shared.Service interface. No
@@ -69,6 +77,8 @@ func NewService(cfg *Config) (*Service, error) {
 		cancel:        cancel,
 		cfg:           cfg,
 		exclusionList: cache,
+		meshTracer:    newMeshTracer(),
+		bandwidth:     metrics.NewBandwidthCounter(),
 	}
 
 	dv5Nodes, kadDHTNodes := parseBootStrapAddrs(s.cfg.BootstrapNodeAddr)
@@ -76,14 +86,26 @@ func NewService(cfg *Config) (*Service, error) {
 	cfg.Discv5BootStrapAddr = dv5Nodes
 	cfg.KademliaBootStrapAddr = kadDHTNodes
 
-	ipAddr := ipAddr()
+	if err := validateIP6Config(s.cfg); err != nil {
+		return nil, err
+	}
+	ipAddr := localIPv4(s.cfg)
+	s.families = enabledFamilies(s.cfg, ipAddr)
 	s.privKey, err = privKey(s.cfg)
 	if err != nil {
 		log.WithError(err).Error("Failed to generate p2p private key")
 		return nil, err
 	}
 
+	s.gater, err = newPeerGater(s.cfg.AllowListCIDR, s.cfg.DenyListCIDR)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateGossipParams(s.cfg); err != nil {
+		return nil, err
+	}
 	opts := buildOptions(s.cfg, ipAddr, s.privKey)
+	opts = append(opts, s.gater.option(), libp2p.BandwidthReporter(s.bandwidth))
 	h, err := libp2p.New(s.ctx, opts...)
 	if err != nil {
 		log.WithError(err).Error("Failed to create p2p host")
@@ -108,7 +130,6 @@ func NewService(cfg *Config) (*Service, error) {
 	}
 	s.host = h
 
-	// TODO(3147): Add gossip sub options
 	// Gossipsub registration is done before we add in any new peers
 	// due to libp2p's gossipsub implementation not taking into
 	// account previously added peers when creating the gossipsub
@@ -117,7 +138,9 @@ func NewService(cfg *Config) (*Service, error) {
 		pubsub.WithMessageSigning(false),
 		pubsub.WithStrictSignatureVerification(false),
 		pubsub.WithMessageIdFn(msgIDFunction),
+		pubsub.WithEventTracer(s.meshTracer),
 	}
+	setGossipParams(s.cfg)
 	gs, err := pubsub.NewGossipSub(s.ctx, s.host, psOpts...)
 	if err != nil {
 		log.WithError(err).Error("Failed to start pubsub")
@@ -126,6 +149,7 @@ func NewService(cfg *Config) (*Service, error) {
 	s.pubsub = gs
 
 	s.peers = peers.NewStatus(maxBadResponses)
+	s.peers.SetScoreParams(gossipScoreParams())
 
 	return s, nil
 }
@@ -137,6 +161,13 @@ func (s *Service) Start() {
 		return
 	}
 
+	// Subscribe before the blockchain service starts to not miss the state initialized event.
+	if s.cfg.StateNotifier != nil {
+		stateChannel := make(chan *feed.Event, 1)
+		stateSub := s.cfg.StateNotifier.StateFeed().Subscribe(stateChannel)
+		go s.awaitStateInitialized(stateChannel, stateSub)
+	}
+
 	var peersToWatch []string
 	if s.cfg.RelayNodeAddr != "" {
 		peersToWatch = append(peersToWatch, s.cfg.RelayNodeAddr)
@@ -151,7 +182,7 @@ func (s *Service) Start() {
 	}
 
 	if len(s.cfg.Discv5BootStrapAddr) != 0 && !s.cfg.NoDiscovery {
-		ipAddr := ipAddr()
+		ipAddr := localIPv4(s.cfg)
 		listener, err := startDiscoveryV5(ipAddr, s.privKey, s.cfg)
 		if err != nil {
 			log.WithError(err).Error("Failed to start discovery")
@@ -167,6 +198,7 @@ func (s *Service) Start() {
 		s.dv5Listener = listener
 
 		go s.listenForNewNodes()
+		runutil.RunEvery(s.ctx, time.Minute, s.updateSubnetRecord)
 	}
 
 	if len(s.cfg.KademliaBootStrapAddr) != 0 && !s.cfg.NoDiscovery {
@@ -198,7 +230,7 @@ func (s *Service) Start() {
 	s.started = true
 
 	if len(s.cfg.StaticPeers) > 0 {
-		addrs, err := peersFromStringAddrs(s.cfg.StaticPeers)
+		addrs, err := peersFromStringAddrs(s.cfg.StaticPeers, s.families)
 		if err != nil {
 			log.Errorf("Could not connect to static peer: %v", err)
 		}
@@ -210,10 +242,14 @@ func (s *Service) Start() {
 		ensurePeerConnections(s.ctx, s.host, peersToWatch...)
 	})
 	runutil.RunEvery(s.ctx, time.Hour, s.Peers().Decay)
+	runutil.RunEvery(s.ctx, gossipScoreDecayInterval, s.decayGossipScores)
 	runutil.RunEvery(s.ctx, 10*time.Second, s.updateMetrics)
 
 	multiAddrs := s.host.Network().ListenAddresses()
 	logIP4Addr(s.host.ID(), multiAddrs...)
+	if s.families.ip6 {
+		logIP6Addr(s.host.ID(), multiAddrs...)
+	}
 
 	p2pHostAddress := s.cfg.HostAddress
 	p2pTCPPort := s.cfg.TCPPort
@@ -221,6 +257,9 @@ func (s *Service) Start() {
 	if p2pHostAddress != "" {
 		logExternalIP4Addr(s.host.ID(), p2pHostAddress, p2pTCPPort)
 	}
+	if s.cfg.HostAddress6 != "" {
+		logExternalIP6Addr(s.host.ID(), s.cfg.HostAddress6, p2pTCPPort)
+	}
 
 	p2pHostDNS := s.cfg.HostDNS
 	if p2pHostDNS != "" {
@@ -281,6 +320,19 @@ func (s *Service) PeerID() peer.ID {
 	return s.host.ID()
 }
 
+// ENR returns the node record advertised over discovery, or nil if discovery is disabled.
+func (s *Service) ENR() *enr.Record {
+	if s.dv5Listener == nil {
+		return nil
+	}
+	return s.dv5Listener.Self().Record()
+}
+
+// HostAddresses returns the multiaddrs the p2p host is listening on.
+func (s *Service) HostAddresses() []ma.Multiaddr {
+	return s.host.Addrs()
+}
+
 // Disconnect from a peer.
 func (s *Service) Disconnect(pid peer.ID) error {
 	return s.host.Network().ClosePeer(pid)
@@ -299,7 +351,7 @@ func (s *Service) listenForNewNodes() {
 	}
 	runutil.RunEvery(s.ctx, pollingPeriod, func() {
 		nodes := s.dv5Listener.Lookup(bootNode.ID())
-		multiAddresses := convertToMultiAddr(nodes)
+		multiAddresses := convertToMultiAddr(nodes, s.families)
 		s.connectWithAllPeers(multiAddresses)
 	})
 }
@@ -333,7 +385,7 @@ func (s *Service) addBootNodesToExclusionList() error {
 		if err != nil {
 			return err
 		}
-		multAddr, err := convertToSingleMultiAddr(bootNode)
+		multAddr, err := convertToSingleMultiAddr(bootNode, s.families)
 		if err != nil {
 			return err
 		}
@@ -378,6 +430,18 @@ func logIP4Addr(id peer.ID, addrs ...ma.Multiaddr) {
 	}
 }
 
+func logIP6Addr(id peer.ID, addrs ...ma.Multiaddr) {
+	for _, addr := range addrs {
+		if strings.Contains(addr.String(), "/ip6/") {
+			log.WithField(
+				"multiAddr",
+				addr.String()+"/p2p/"+id.String(),
+			).Info("Node started p2p server")
+			return
+		}
+	}
+}
+
 func logExternalIP4Addr(id peer.ID, addr string, port uint) {
 	if addr != "" {
 		p := strconv.FormatUint(uint64(port), 10)
@@ -389,6 +453,17 @@ func logExternalIP4Addr(id peer.ID, addr string, port uint) {
 	}
 }
 
+func logExternalIP6Addr(id peer.ID, addr string, port uint) {
+	if addr != "" {
+		p := strconv.FormatUint(uint64(port), 10)
+
+		log.WithField(
+			"multiAddr",
+			"/ip6/"+addr+"/tcp/"+p+"/p2p/"+id.String(),
+		).Info("Node started external p2p server")
+	}
+}
+
 func logExternalDNSAddr(id peer.ID, addr string, port uint) {
 	if addr != "" {
 		p := strconv.FormatUint(uint64(port), 10)
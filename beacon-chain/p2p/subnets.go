@@ -0,0 +1,142 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opencensus.io/trace"
+)
+
+// attSubnetEnrKey is the key of the node record entry holding the bitvector of the attestation
+// subnets the node is subscribed to.
+const attSubnetEnrKey = "attnets"
+
+// attSubnetCount is the number of attestation subnets of the node record bitvector.
+const attSubnetCount = 64
+
+// subnetSearchTimeout is how long a search for peers of an attestation subnet lasts at most.
+const subnetSearchTimeout = 6 * time.Second
+
+var subnetPeerSearches = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "p2p_subnet_peer_searches_total",
+	Help: "The number of searches for peers of attestation subnets without connected peers, by result.",
+},
+	[]string{"result"})
+
+// FindPeersWithSubnet searches the discovery network for nodes whose record advertises the
+// attestation subnet, and dials them until a peer of the subnet is connected or the search times
+// out. It returns whether a peer of the subnet is connected.
+func (s *Service) FindPeersWithSubnet(ctx context.Context, index uint64) bool {
+	ctx, span := trace.StartSpan(ctx, "p2p.FindPeersWithSubnet")
+	defer span.End()
+
+	topic := s.attestationSubnetTopic(index)
+	if s.hasPeerWithTopic(topic) {
+		return true
+	}
+	if s.dv5Listener == nil || topic == "" || index >= attSubnetCount {
+		subnetPeerSearches.WithLabelValues("unavailable").Inc()
+		return false
+	}
+	ctx, cancel := context.WithTimeout(ctx, subnetSearchTimeout)
+	defer cancel()
+
+	for {
+		nodes := s.dv5Listener.LookupRandom()
+		var matching []*enode.Node
+		for _, node := range nodes {
+			subnets, err := attSubnetsFromRecord(node.Record())
+			if err != nil {
+				continue
+			}
+			if subnets[index/8]&(1<<(index%8)) != 0 {
+				matching = append(matching, node)
+			}
+		}
+		s.connectWithAllPeers(convertToMultiAddr(matching, s.families))
+
+		select {
+		case <-ctx.Done():
+			found := s.hasPeerWithTopic(topic)
+			if found {
+				subnetPeerSearches.WithLabelValues("found").Inc()
+			} else {
+				subnetPeerSearches.WithLabelValues("timeout").Inc()
+				log.WithField("subnet", index).Warn("Could not find peers of attestation subnet")
+			}
+			return found
+		case <-time.After(time.Second):
+			// Dials are not blocking, the peers of the subnet are only seen once they joined it.
+			if s.hasPeerWithTopic(topic) {
+				subnetPeerSearches.WithLabelValues("found").Inc()
+				return true
+			}
+		}
+	}
+}
+
+// updateSubnetRecord sets the attestation subnets of the node record to the subnets of the
+// committee index topics the node is subscribed to, so that other nodes can find it.
+func (s *Service) updateSubnetRecord() {
+	if s.dv5Listener == nil {
+		return
+	}
+	subnets := s.AttestationSubnets()
+	localNode := s.dv5Listener.LocalNode()
+	current, err := attSubnetsFromRecord(localNode.Node().Record())
+	if err == nil && string(current) == string(subnets) {
+		return
+	}
+	localNode.Set(enr.WithEntry(attSubnetEnrKey, subnets))
+}
+
+// AttestationSubnets returns the bitvector of the attestation subnets of the committee index
+// topics the node is subscribed to, as advertised in the attnets entry of its node record.
+func (s *Service) AttestationSubnets() []byte {
+	subnets := make([]byte, attSubnetCount/8)
+	if s.pubsub == nil {
+		return subnets
+	}
+	for _, topic := range s.pubsub.GetTopics() {
+		var index uint64
+		topic = strings.TrimSuffix(TopicWithoutForkDigest(topic), s.Encoding().ProtocolSuffix())
+		if _, err := fmt.Sscanf(topic, attestationSubnetTopicFormat, &index); err != nil || index >= attSubnetCount {
+			continue
+		}
+		subnets[index/8] |= 1 << (index % 8)
+	}
+	return subnets
+}
+
+// attestationSubnetTopic returns the full topic of an attestation subnet, as published to.
+func (s *Service) attestationSubnetTopic(index uint64) string {
+	digest, err := s.ForkDigest()
+	if err != nil {
+		log.WithError(err).Error("Could not compute fork digest")
+		return ""
+	}
+	return ForkDigestTopic(fmt.Sprintf(attestationSubnetTopicFormat, index), digest) + s.Encoding().ProtocolSuffix()
+}
+
+func (s *Service) hasPeerWithTopic(topic string) bool {
+	return topic != "" && len(s.pubsub.ListPeers(topic)) > 0
+}
+
+// attSubnetsFromRecord returns the attestation subnets bitvector of a node record.
+func attSubnetsFromRecord(record *enr.Record) ([]byte, error) {
+	var subnets []byte
+	if err := record.Load(enr.WithEntry(attSubnetEnrKey, &subnets)); err != nil {
+		return nil, err
+	}
+	if len(subnets) != attSubnetCount/8 {
+		return nil, errors.Errorf("invalid attestation subnets bitvector length %d", len(subnets))
+	}
+	return subnets, nil
+}
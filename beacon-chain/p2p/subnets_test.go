@@ -0,0 +1,43 @@
+package p2p
+
+import (
+	"net"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+)
+
+func TestAttSubnetsFromRecord(t *testing.T) {
+	_, pkey := createAddrAndPrivKey(t)
+	localNode, err := createLocalNode(pkey, net.ParseIP("127.0.0.1"), 0, 0, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The node record advertises no subnets by default.
+	subnets, err := attSubnetsFromRecord(localNode.Node().Record())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, b := range subnets {
+		if b != 0 {
+			t.Errorf("Wanted no subnets in byte %d, received %08b", i, b)
+		}
+	}
+
+	wanted := make([]byte, attSubnetCount/8)
+	wanted[1] = 1 << 2 // Subnet 10.
+	localNode.Set(enr.WithEntry(attSubnetEnrKey, wanted))
+	subnets, err = attSubnetsFromRecord(localNode.Node().Record())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subnets[10/8]&(1<<(10%8)) == 0 {
+		t.Error("Wanted subnet 10 to be advertised")
+	}
+
+	localNode.Set(enr.WithEntry(attSubnetEnrKey, []byte{1}))
+	if _, err := attSubnetsFromRecord(localNode.Node().Record()); err == nil {
+		t.Error("Expected bitvector of invalid length to be rejected")
+	}
+}
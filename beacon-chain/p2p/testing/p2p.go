@@ -5,9 +5,11 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/gogo/protobuf/proto"
 	bhost "github.com/libp2p/go-libp2p-blankhost"
 	core "github.com/libp2p/go-libp2p-core"
@@ -17,6 +19,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/protocol"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	swarmt "github.com/libp2p/go-libp2p-swarm/testing"
+	ma "github.com/multiformats/go-multiaddr"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
 	peers "github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
@@ -38,6 +41,7 @@ type TestP2P struct {
 	pubsub          *pubsub.PubSub
 	BroadcastCalled bool
 	DelaySend       bool
+	Digest          [4]byte
 	peers           *peers.Status
 }
 
@@ -116,6 +120,8 @@ func (p *TestP2P) ReceivePubSub(topic string, msg proto.Message) {
 		p.t.Fatalf("Failed to encode message: %v", err)
 	}
 
+	// Gossip topics carry the fork digest after the /eth2 prefix.
+	topic = fmt.Sprintf("/eth2/%x%s", p.Digest, strings.TrimPrefix(topic, "/eth2"))
 	if err := ps.Publish(topic+p.Encoding().ProtocolSuffix(), buf.Bytes()); err != nil {
 		p.t.Fatalf("Failed to publish message; %v", err)
 	}
@@ -153,6 +159,21 @@ func (p *TestP2P) PeerID() peer.ID {
 	return p.Host.ID()
 }
 
+// ENR returns nil, the test service does not run discovery.
+func (p *TestP2P) ENR() *enr.Record {
+	return nil
+}
+
+// HostAddresses returns the multiaddrs of the test host.
+func (p *TestP2P) HostAddresses() []ma.Multiaddr {
+	return p.Host.Addrs()
+}
+
+// AttestationSubnets returns an empty attestation subnets bitvector.
+func (p *TestP2P) AttestationSubnets() []byte {
+	return make([]byte, 8)
+}
+
 // AddConnectionHandler handles the connection with a newly connected peer.
 func (p *TestP2P) AddConnectionHandler(f func(ctx context.Context, id peer.ID) error) {
 	p.Host.Network().Notify(&network.NotifyBundle{
@@ -227,3 +248,13 @@ func (p *TestP2P) Started() bool {
 func (p *TestP2P) Peers() *peers.Status {
 	return p.peers
 }
+
+// ForkDigest returns the fork digest of the test service.
+func (p *TestP2P) ForkDigest() ([4]byte, error) {
+	return p.Digest, nil
+}
+
+// ActiveForkDigests returns the fork digest of the test service.
+func (p *TestP2P) ActiveForkDigests() ([][4]byte, error) {
+	return [][4]byte{p.Digest}, nil
+}
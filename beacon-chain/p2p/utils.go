@@ -17,6 +17,10 @@ import (
 
 const keyPath = "network-keys"
 
+// nodeDBPath is the directory of the discovery node database, which persists the sequence number of
+// the node's record so that it keeps increasing across restarts.
+const nodeDBPath = "discovery-node-db"
+
 func convertFromInterfacePrivKey(privkey crypto.PrivKey) *ecdsa.PrivateKey {
 	typeAssertedKey := (*ecdsa.PrivateKey)((*btcec.PrivateKey)(privkey.(*crypto.Secp256k1PrivateKey)))
 	return typeAssertedKey
@@ -32,6 +36,9 @@ func convertToInterfacePubkey(pubkey *ecdsa.PublicKey) crypto.PubKey {
 	return typeAssertedKey
 }
 
+// privKey returns the private key of the node's peer identity. The key of the --p2p-priv-key file is
+// used if set, otherwise the key persisted in the data directory, which is generated on the first
+// start so that the peer ID and record of the node are kept across restarts.
 func privKey(cfg *Config) (*ecdsa.PrivateKey, error) {
 	defaultKeyPath := path.Join(cfg.DataDir, keyPath)
 	privateKeyPath := cfg.PrivateKey
@@ -53,9 +60,13 @@ func privKey(cfg *Config) (*ecdsa.PrivateKey, error) {
 		}
 		dst := make([]byte, hex.EncodedLen(len(rawbytes)))
 		hex.Encode(dst, rawbytes)
+		if err := os.MkdirAll(cfg.DataDir, 0700); err != nil {
+			return nil, errors.Wrap(err, "could not create data directory")
+		}
 		if err = ioutil.WriteFile(defaultKeyPath, dst, 0600); err != nil {
 			return nil, err
 		}
+		log.WithField("path", defaultKeyPath).Info("Generated and saved new network key")
 		convertedKey := convertFromInterfacePrivKey(priv)
 		return convertedKey, nil
 	}
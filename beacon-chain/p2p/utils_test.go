@@ -0,0 +1,59 @@
+package p2p
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestPrivKey_PersistedInDataDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "p2p")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	// The data directory is created along with the key if it does not exist yet.
+	cfg := &Config{DataDir: path.Join(dir, "datadir")}
+
+	key, err := privKey(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restarted, err := privKey(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(key.D, restarted.D) {
+		t.Error("Wanted the same key to be used after a restart")
+	}
+}
+
+func TestPrivKey_FromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "p2p")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	key, err := privKey(&Config{DataDir: path.Join(dir, "first")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The key of the file is used instead of the key of the data directory.
+	cfg := &Config{
+		DataDir:    path.Join(dir, "second"),
+		PrivateKey: path.Join(dir, "first", keyPath),
+	}
+	if _, err := privKey(&Config{DataDir: cfg.DataDir}); err != nil {
+		t.Fatal(err)
+	}
+	fromFile, err := privKey(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(key.D, fromFile.D) {
+		t.Error("Wanted the key of the file to be used")
+	}
+}
@@ -7,10 +7,10 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prysmaticlabs/prysm/shared/params"
-	"k8s.io/client-go/tools/cache"
 )
 
 var (
@@ -36,6 +36,18 @@ var (
 		Name: "powchain_block_cache_size",
 		Help: "The number of blocks in the block cache",
 	})
+	blockDBHit = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "powchain_block_db_hit",
+		Help: "The number of block requests missing from the cache that were served from the database.",
+	})
+	blockDBMiss = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "powchain_block_db_miss",
+		Help: "The number of block requests missing from both the cache and the database.",
+	})
+	providerCalls = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "powchain_provider_calls",
+		Help: "The number of block requests made to the eth1 provider, by method.",
+	}, []string{"method"})
 )
 
 // blockInfo specifies the block information in the ETH 1.0 chain.
@@ -74,19 +86,27 @@ func heightKeyFn(obj interface{}) (string, error) {
 	return bInfo.Number.String(), nil
 }
 
-// blockCache struct with two queues for looking up by hash or by block height.
+// blockCache struct with two LRU caches for looking up by hash or by block height.
 type blockCache struct {
-	hashCache   *cache.FIFO
-	heightCache *cache.FIFO
+	hashCache   *lru.Cache
+	heightCache *lru.Cache
 	lock        sync.RWMutex
 }
 
 // newBlockCache creates a new block cache for storing/accessing blockInfo from
 // memory.
 func newBlockCache() *blockCache {
+	hashCache, err := lru.New(maxCacheSize)
+	if err != nil {
+		panic(err)
+	}
+	heightCache, err := lru.New(maxCacheSize)
+	if err != nil {
+		panic(err)
+	}
 	return &blockCache{
-		hashCache:   cache.NewFIFO(hashKeyFn),
-		heightCache: cache.NewFIFO(heightKeyFn),
+		hashCache:   hashCache,
+		heightCache: heightCache,
 	}
 }
 
@@ -96,11 +116,7 @@ func (b *blockCache) BlockInfoByHash(hash common.Hash) (bool, *blockInfo, error)
 	b.lock.RLock()
 	defer b.lock.RUnlock()
 
-	obj, exists, err := b.hashCache.GetByKey(hash.Hex())
-	if err != nil {
-		return false, nil, err
-	}
-
+	obj, exists := b.hashCache.Get(hash.Hex())
 	if exists {
 		blockCacheHit.Inc()
 	} else {
@@ -122,11 +138,7 @@ func (b *blockCache) BlockInfoByHeight(height *big.Int) (bool, *blockInfo, error
 	b.lock.RLock()
 	defer b.lock.RUnlock()
 
-	obj, exists, err := b.heightCache.GetByKey(height.String())
-	if err != nil {
-		return false, nil, err
-	}
-
+	obj, exists := b.heightCache.Get(height.String())
 	if exists {
 		blockCacheHit.Inc()
 	} else {
@@ -142,41 +154,27 @@ func (b *blockCache) BlockInfoByHeight(height *big.Int) (bool, *blockInfo, error
 	return exists, bInfo, nil
 }
 
-// AddBlock adds a blockInfo object to the cache. This method also trims the
-// least recently added block info if the cache size has reached the max cache
-// size limit. This method should be called in sequential block number order if
-// the desired behavior is that the blocks with the highest block number should
-// be present in the cache.
+// AddBlock adds a blockInfo object to the cache. The least recently used block
+// info is evicted once the cache size has reached the max cache size limit. A
+// block added at an existing height replaces the previous block at that height.
 func (b *blockCache) AddBlock(blk *gethTypes.Block) error {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
 	bInfo := blockToBlockInfo(blk)
 
-	if err := b.hashCache.AddIfNotPresent(bInfo); err != nil {
+	hashKey, err := hashKeyFn(bInfo)
+	if err != nil {
 		return err
 	}
-	if err := b.heightCache.AddIfNotPresent(bInfo); err != nil {
+	heightKey, err := heightKeyFn(bInfo)
+	if err != nil {
 		return err
 	}
+	b.hashCache.Add(hashKey, bInfo)
+	b.heightCache.Add(heightKey, bInfo)
 
-	trim(b.hashCache, maxCacheSize)
-	trim(b.heightCache, maxCacheSize)
-
-	blockCacheSize.Set(float64(len(b.hashCache.ListKeys())))
-
-	return nil
-}
-
-// trim the FIFO queue to the maxSize.
-func trim(queue *cache.FIFO, maxSize int) {
-	for s := len(queue.ListKeys()); s > maxSize; s-- {
-		// #nosec G104 popProcessNoopFunc never returns an error
-		_, _ = queue.Pop(popProcessNoopFunc)
-	}
-}
+	blockCacheSize.Set(float64(b.hashCache.Len()))
 
-// popProcessNoopFunc is a no-op function that never returns an error.
-func popProcessNoopFunc(obj interface{}) error {
 	return nil
 }
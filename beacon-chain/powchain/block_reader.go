@@ -5,7 +5,9 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/params"
 	"go.opencensus.io/trace"
 )
 
@@ -14,24 +16,11 @@ func (s *Service) BlockExists(ctx context.Context, hash common.Hash) (bool, *big
 	ctx, span := trace.StartSpan(ctx, "beacon-chain.web3service.BlockExists")
 	defer span.End()
 
-	if exists, blkInfo, err := s.blockCache.BlockInfoByHash(hash); exists || err != nil {
-		if err != nil {
-			return false, nil, err
-		}
-		span.AddAttributes(trace.BoolAttribute("blockCacheHit", true))
-		return true, blkInfo.Number, nil
-	}
-	span.AddAttributes(trace.BoolAttribute("blockCacheHit", false))
-	block, err := s.blockFetcher.BlockByHash(ctx, hash)
+	info, err := s.blockInfoByHash(ctx, hash)
 	if err != nil {
-		return false, big.NewInt(0), errors.Wrap(err, "could not query block with given hash")
-	}
-
-	if err := s.blockCache.AddBlock(block); err != nil {
 		return false, big.NewInt(0), err
 	}
-
-	return true, block.Number(), nil
+	return true, info.Number, nil
 }
 
 // BlockHashByHeight returns the block hash of the block at the given height.
@@ -39,71 +28,162 @@ func (s *Service) BlockHashByHeight(ctx context.Context, height *big.Int) (commo
 	ctx, span := trace.StartSpan(ctx, "beacon-chain.web3service.BlockHashByHeight")
 	defer span.End()
 
-	if exists, blkInfo, err := s.blockCache.BlockInfoByHeight(height); exists || err != nil {
-		if err != nil {
-			return [32]byte{}, err
-		}
-		span.AddAttributes(trace.BoolAttribute("blockCacheHit", true))
-		return blkInfo.Hash, nil
-	}
-	span.AddAttributes(trace.BoolAttribute("blockCacheHit", false))
-	block, err := s.blockFetcher.BlockByNumber(ctx, height)
+	info, err := s.blockInfoByHeight(ctx, height)
 	if err != nil {
-		return [32]byte{}, errors.Wrap(err, "could not query block with given height")
-	}
-	if err := s.blockCache.AddBlock(block); err != nil {
 		return [32]byte{}, err
 	}
-	return block.Hash(), nil
+	return info.Hash, nil
 }
 
 // BlockTimeByHeight fetches an eth1.0 block timestamp by its height.
 func (s *Service) BlockTimeByHeight(ctx context.Context, height *big.Int) (uint64, error) {
 	ctx, span := trace.StartSpan(ctx, "beacon-chain.web3service.BlockTimeByHeight")
 	defer span.End()
-	block, err := s.blockFetcher.BlockByNumber(ctx, height)
+
+	info, err := s.blockInfoByHeight(ctx, height)
 	if err != nil {
-		return 0, errors.Wrap(err, "could not query block with given height")
+		return 0, err
 	}
-	return block.Time(), nil
+	return info.Time, nil
 }
 
 // BlockNumberByTimestamp returns the most recent block number up to a given timestamp.
-// This is a naive implementation that will use O(ETH1_FOLLOW_DISTANCE) calls to cache
-// or ETH1. This is called for multiple times but only changes every
-// SlotsPerEth1VotingPeriod (1024 slots) so the whole method should be cached.
+// Headers persisted in the database are consulted first, falling back to a naive walk
+// down from the head that uses O(ETH1_FOLLOW_DISTANCE) calls to cache or ETH1.
 func (s *Service) BlockNumberByTimestamp(ctx context.Context, time uint64) (*big.Int, error) {
 	ctx, span := trace.StartSpan(ctx, "beacon-chain.web3service.BlockByTimestamp")
 	defer span.End()
 
+	providerCalls.WithLabelValues("BlockByNumber").Inc()
 	head, err := s.blockFetcher.BlockByNumber(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
+	if head.Time() <= time {
+		return head.Number(), nil
+	}
+
+	// The saved headers need not be contiguous, so the stored header is only the answer
+	// if the block following it is after the requested time.
+	header, err := s.beaconDB.Eth1HeaderByTimestamp(ctx, time)
+	if err != nil {
+		return nil, err
+	}
+	if header != nil && header.Number.Cmp(head.Number()) < 0 {
+		next, err := s.blockInfoByHeight(ctx, big.NewInt(0).Add(header.Number, big.NewInt(1)))
+		if err != nil {
+			return nil, err
+		}
+		if next.Time > time {
+			span.AddAttributes(trace.BoolAttribute("blockDBHit", true))
+			return header.Number, nil
+		}
+	}
+	span.AddAttributes(trace.BoolAttribute("blockDBHit", false))
 
 	for bn := head.Number(); ; bn = big.NewInt(0).Sub(bn, big.NewInt(1)) {
 		if ctx.Err() != nil {
 			return nil, ctx.Err()
 		}
 
-		exists, info, err := s.blockCache.BlockInfoByHeight(bn)
+		info, err := s.blockInfoByHeight(ctx, bn)
 		if err != nil {
 			return nil, err
 		}
 
-		if !exists {
-			blk, err := s.blockFetcher.BlockByNumber(ctx, bn)
-			if err != nil {
-				return nil, err
-			}
-			if err := s.blockCache.AddBlock(blk); err != nil {
-				return nil, err
-			}
-			info = blockToBlockInfo(blk)
-		}
-
 		if info.Time <= time {
 			return info.Number, nil
 		}
 	}
 }
+
+// blockInfoByHash looks up a block by its hash in the block cache, then in the database,
+// and finally requests it from the eth1 provider.
+func (s *Service) blockInfoByHash(ctx context.Context, hash common.Hash) (*blockInfo, error) {
+	ctx, span := trace.StartSpan(ctx, "beacon-chain.web3service.blockInfoByHash")
+	defer span.End()
+
+	if exists, info, err := s.blockCache.BlockInfoByHash(hash); exists || err != nil {
+		span.AddAttributes(trace.BoolAttribute("blockCacheHit", exists))
+		return info, err
+	}
+	span.AddAttributes(trace.BoolAttribute("blockCacheHit", false))
+
+	header, err := s.beaconDB.Eth1HeaderByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if header != nil {
+		blockDBHit.Inc()
+		return s.cacheHeader(ctx, header)
+	}
+	blockDBMiss.Inc()
+
+	providerCalls.WithLabelValues("BlockByHash").Inc()
+	block, err := s.blockFetcher.BlockByHash(ctx, hash)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not query block with given hash")
+	}
+	return s.cacheHeader(ctx, block.Header())
+}
+
+// blockInfoByHeight looks up a block by its number in the block cache, then in the
+// database, and finally requests it from the eth1 provider.
+func (s *Service) blockInfoByHeight(ctx context.Context, height *big.Int) (*blockInfo, error) {
+	ctx, span := trace.StartSpan(ctx, "beacon-chain.web3service.blockInfoByHeight")
+	defer span.End()
+
+	if exists, info, err := s.blockCache.BlockInfoByHeight(height); exists || err != nil {
+		span.AddAttributes(trace.BoolAttribute("blockCacheHit", exists))
+		return info, err
+	}
+	span.AddAttributes(trace.BoolAttribute("blockCacheHit", false))
+
+	header, err := s.beaconDB.Eth1HeaderByNumber(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	if header != nil {
+		blockDBHit.Inc()
+		return s.cacheHeader(ctx, header)
+	}
+	blockDBMiss.Inc()
+
+	providerCalls.WithLabelValues("BlockByNumber").Inc()
+	block, err := s.blockFetcher.BlockByNumber(ctx, height)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not query block with given height")
+	}
+	return s.cacheHeader(ctx, block.Header())
+}
+
+// cacheHeader adds a header to the block cache and persists it if it is final enough.
+func (s *Service) cacheHeader(ctx context.Context, header *gethTypes.Header) (*blockInfo, error) {
+	if err := s.cacheHeaders(ctx, []*gethTypes.Header{header}); err != nil {
+		return nil, err
+	}
+	return blockToBlockInfo(gethTypes.NewBlockWithHeader(header)), nil
+}
+
+// cacheHeaders adds headers to the block cache. Headers at least ETH1_FOLLOW_DISTANCE
+// behind the latest known eth1 block are also saved to the database, as they are what
+// eth1 data voting and deposit proofs ask for and are unlikely to be reorged out.
+func (s *Service) cacheHeaders(ctx context.Context, headers []*gethTypes.Header) error {
+	followDistance := params.BeaconConfig().Eth1FollowDistance
+	final := make([]*gethTypes.Header, 0, len(headers))
+	for _, h := range headers {
+		if h == nil || h.Number == nil {
+			continue
+		}
+		if err := s.blockCache.AddBlock(gethTypes.NewBlockWithHeader(h)); err != nil {
+			return err
+		}
+		if s.latestEth1Data != nil && h.Number.Uint64()+followDistance <= s.latestEth1Data.BlockHeight {
+			final = append(final, h)
+		}
+	}
+	if len(final) == 0 {
+		return nil
+	}
+	return s.beaconDB.SaveEth1Headers(ctx, final)
+}
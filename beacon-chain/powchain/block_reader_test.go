@@ -14,6 +14,7 @@ import (
 	mockPOW "github.com/prysmaticlabs/prysm/beacon-chain/powchain/testing"
 	contracts "github.com/prysmaticlabs/prysm/contracts/deposit-contract"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
 )
 
 var endpoint = "ws://127.0.0.1"
@@ -259,3 +260,86 @@ func TestBlockNumberByTimestamp(t *testing.T) {
 		t.Error("Returned a block with zero number, expected to be non zero")
 	}
 }
+
+func TestBlockHashByHeight_UsesSavedHeader(t *testing.T) {
+	beaconDB := dbutil.SetupDB(t)
+	defer dbutil.TeardownDB(t, beaconDB)
+	web3Service, err := NewService(context.Background(), &Web3ServiceConfig{
+		ETH1Endpoint: endpoint,
+		BeaconDB:     beaconDB,
+	})
+	if err != nil {
+		t.Fatalf("unable to setup web3 ETH1.0 chain service: %v", err)
+	}
+	// nil blockFetcher would panic if the saved header was not used
+	web3Service.blockFetcher = nil
+
+	ctx := context.Background()
+	header := &gethTypes.Header{
+		Number: big.NewInt(42),
+		Time:   420,
+	}
+	if err := beaconDB.SaveEth1Header(ctx, header); err != nil {
+		t.Fatal(err)
+	}
+
+	hash, err := web3Service.BlockHashByHeight(ctx, header.Number)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != header.Hash() {
+		t.Errorf("Wanted hash %#x, got %#x", header.Hash(), hash)
+	}
+	exists, _, err := web3Service.blockCache.BlockInfoByHash(header.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !exists {
+		t.Error("Expected the saved header to be added to the block cache")
+	}
+}
+
+func TestCacheHeaders_SavesFinalHeaders(t *testing.T) {
+	beaconDB := dbutil.SetupDB(t)
+	defer dbutil.TeardownDB(t, beaconDB)
+	web3Service, err := NewService(context.Background(), &Web3ServiceConfig{
+		ETH1Endpoint: endpoint,
+		BeaconDB:     beaconDB,
+	})
+	if err != nil {
+		t.Fatalf("unable to setup web3 ETH1.0 chain service: %v", err)
+	}
+
+	ctx := context.Background()
+	followDistance := params.BeaconConfig().Eth1FollowDistance
+	web3Service.latestEth1Data.BlockHeight = followDistance + 10
+	final := &gethTypes.Header{Number: big.NewInt(10)}
+	recent := &gethTypes.Header{Number: big.NewInt(11)}
+	if err := web3Service.cacheHeaders(ctx, []*gethTypes.Header{final, recent}); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := beaconDB.Eth1HeaderByHash(ctx, final.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved == nil {
+		t.Error("Expected header at the follow distance to be saved")
+	}
+	saved, err = beaconDB.Eth1HeaderByHash(ctx, recent.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved != nil {
+		t.Error("Expected header within the follow distance not to be saved")
+	}
+	for _, h := range []*gethTypes.Header{final, recent} {
+		exists, _, err := web3Service.blockCache.BlockInfoByHash(h.Hash())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !exists {
+			t.Errorf("Expected header %d to be in the block cache", h.Number)
+		}
+	}
+}
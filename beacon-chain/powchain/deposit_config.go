@@ -0,0 +1,64 @@
+package powchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+var errDepositConfigMismatch = errors.New("eth1 endpoint does not match the chain config")
+
+// NetworkReader reads the network of an eth1 node and the code of its contracts.
+type NetworkReader interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	NetworkID(ctx context.Context) (*big.Int, error)
+	CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error)
+}
+
+// checkDepositConfig verifies the chain ID and network ID of the eth1 node, and the code hash of
+// the deposit contract, against the chain config, so a node connected to the wrong network or
+// contract does not silently track it. Values left at zero in the chain config are not checked.
+// Mismatches are reported as errDepositConfigMismatch, other errors come from the eth1 node.
+func checkDepositConfig(ctx context.Context, reader NetworkReader, contract common.Address) error {
+	cfg := params.BeaconConfig()
+	var mismatches []string
+	if cfg.DepositChainID != 0 {
+		chainID, err := reader.ChainID(ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not get chain ID")
+		}
+		if !chainID.IsUint64() || chainID.Uint64() != cfg.DepositChainID {
+			mismatches = append(mismatches, fmt.Sprintf("chain ID is %d, wanted %d", chainID, cfg.DepositChainID))
+		}
+	}
+	if cfg.DepositNetworkID != 0 {
+		networkID, err := reader.NetworkID(ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not get network ID")
+		}
+		if !networkID.IsUint64() || networkID.Uint64() != cfg.DepositNetworkID {
+			mismatches = append(mismatches, fmt.Sprintf("network ID is %d, wanted %d", networkID, cfg.DepositNetworkID))
+		}
+	}
+	if cfg.DepositContractCodeHash != cfg.ZeroHash {
+		code, err := reader.CodeAt(ctx, contract, nil)
+		if err != nil {
+			return errors.Wrap(err, "could not get deposit contract code")
+		}
+		if len(code) == 0 {
+			mismatches = append(mismatches, fmt.Sprintf("no contract is deployed at %#x", contract))
+		} else if codeHash := crypto.Keccak256Hash(code); codeHash != cfg.DepositContractCodeHash {
+			mismatches = append(mismatches, fmt.Sprintf("deposit contract code hash is %#x, wanted %#x", codeHash, cfg.DepositContractCodeHash))
+		}
+	}
+	if len(mismatches) > 0 {
+		return errors.Wrap(errDepositConfigMismatch, strings.Join(mismatches, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package powchain
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+type mockNetworkReader struct {
+	chainID   int64
+	networkID int64
+	code      []byte
+}
+
+func (n *mockNetworkReader) ChainID(_ context.Context) (*big.Int, error) {
+	return big.NewInt(n.chainID), nil
+}
+
+func (n *mockNetworkReader) NetworkID(_ context.Context) (*big.Int, error) {
+	return big.NewInt(n.networkID), nil
+}
+
+func (n *mockNetworkReader) CodeAt(_ context.Context, _ common.Address, _ *big.Int) ([]byte, error) {
+	return n.code, nil
+}
+
+func TestCheckDepositConfig(t *testing.T) {
+	cfg := params.BeaconConfig()
+	defer params.OverrideBeaconConfig(cfg)
+	newCfg := *cfg
+	newCfg.DepositChainID = 5
+	newCfg.DepositNetworkID = 5
+	code := []byte("deposit contract")
+	newCfg.DepositContractCodeHash = crypto.Keccak256Hash(code)
+	params.OverrideBeaconConfig(&newCfg)
+	ctx := context.Background()
+
+	if err := checkDepositConfig(ctx, &mockNetworkReader{chainID: 5, networkID: 5, code: code}, common.Address{'a'}); err != nil {
+		t.Fatalf("Expected a matching eth1 endpoint, received %v", err)
+	}
+
+	err := checkDepositConfig(ctx, &mockNetworkReader{chainID: 1, networkID: 5}, common.Address{'a'})
+	if errors.Cause(err) != errDepositConfigMismatch {
+		t.Fatalf("Expected a mismatch, received %v", err)
+	}
+	for _, want := range []string{"chain ID is 1, wanted 5", "no contract is deployed"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in %v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "network ID") {
+		t.Errorf("Did not expect a network ID mismatch in %v", err)
+	}
+
+	err = checkDepositConfig(ctx, &mockNetworkReader{chainID: 5, networkID: 5, code: []byte("other contract")}, common.Address{'a'})
+	if err == nil || !strings.Contains(err.Error(), "deposit contract code hash") {
+		t.Errorf("Expected a code hash mismatch, received %v", err)
+	}
+
+	newCfg.DepositChainID = 0
+	newCfg.DepositNetworkID = 0
+	newCfg.DepositContractCodeHash = [32]byte{}
+	if err := checkDepositConfig(ctx, &mockNetworkReader{chainID: 1, networkID: 1}, common.Address{'a'}); err != nil {
+		t.Errorf("Expected unset values not to be checked, received %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package powchain
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
+	"github.com/sirupsen/logrus"
+)
+
+// DepositSnapshotFetcher retrieves snapshots of the deposit trie, which nodes can be
+// bootstrapped from instead of processing every deposit log.
+type DepositSnapshotFetcher interface {
+	FinalizedDepositSnapshot(ctx context.Context) (*trieutil.DepositTreeSnapshot, error)
+}
+
+// FinalizedDepositSnapshot returns a snapshot of the deposit trie up to the deposits included
+// in the finalized state, along with the eth1 block of the last of these deposits.
+func (s *Service) FinalizedDepositSnapshot(ctx context.Context) (*trieutil.DepositTreeSnapshot, error) {
+	cp, err := s.beaconDB.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	finalizedState, err := s.beaconDB.State(ctx, bytesutil.ToBytes32(cp.Root))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get finalized state")
+	}
+	if finalizedState == nil {
+		return nil, errors.New("finalized state does not exist")
+	}
+	count := finalizedState.Eth1DepositIndex()
+
+	s.processingLock.RLock()
+	snapshot, err := s.depositTrie.Snapshot(count)
+	s.processingLock.RUnlock()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not take snapshot of deposit trie")
+	}
+	if count == 0 {
+		return snapshot, nil
+	}
+
+	var found bool
+	for _, ctr := range s.depositCache.AllDepositContainers(ctx) {
+		if uint64(ctr.Index) == count-1 {
+			snapshot.ExecutionBlockHeight = ctr.Eth1BlockHeight
+			found = true
+			break
+		}
+	}
+	if !found {
+		// The last deposit is only known from the snapshot the node was bootstrapped from,
+		// whose eth1 block is not kept once the node restarts.
+		previous := s.depositCache.DepositSnapshot()
+		if previous == nil || previous.DepositCount != count || len(previous.ExecutionBlockHash) == 0 {
+			return nil, errors.Errorf("could not find eth1 block of deposit %d", count-1)
+		}
+		snapshot.ExecutionBlockHeight = previous.ExecutionBlockHeight
+		snapshot.ExecutionBlockHash = previous.ExecutionBlockHash
+		return snapshot, nil
+	}
+	hash, err := s.BlockHashByHeight(ctx, new(big.Int).SetUint64(snapshot.ExecutionBlockHeight))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get eth1 block hash")
+	}
+	snapshot.ExecutionBlockHash = hash.Bytes()
+	return snapshot, nil
+}
+
+// ReadDepositSnapshot reads a JSON encoded deposit trie snapshot from a file.
+func ReadDepositSnapshot(path string) (*trieutil.DepositTreeSnapshot, error) {
+	enc, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read deposit snapshot file")
+	}
+	snapshot := &trieutil.DepositTreeSnapshot{}
+	if err := json.Unmarshal(enc, snapshot); err != nil {
+		return nil, errors.Wrap(err, "could not decode deposit snapshot")
+	}
+	return snapshot, nil
+}
+
+// initFromDepositSnapshot initializes the deposit trie from a snapshot, so that deposit logs
+// are only processed after the eth1 block of the snapshot.
+func (s *Service) initFromDepositSnapshot(snapshot *trieutil.DepositTreeSnapshot) error {
+	depositTrie, err := trieutil.TrieFromSnapshot(snapshot, int(params.BeaconConfig().DepositContractTreeDepth))
+	if err != nil {
+		return errors.Wrap(err, "could not create deposit trie from snapshot")
+	}
+	s.depositTrie = depositTrie
+	s.lastReceivedMerkleIndex = int64(snapshot.DepositCount) - 1
+	s.latestEth1Data.LastRequestedBlock = snapshot.ExecutionBlockHeight
+	// Snapshots are taken from finalized states, so the chain has already started.
+	s.chainStartData.Chainstarted = true
+	s.depositCache.SetDepositSnapshot(snapshot)
+	log.WithFields(logrus.Fields{
+		"depositCount": snapshot.DepositCount,
+		"eth1Block":    snapshot.ExecutionBlockHeight,
+	}).Info("Initialized deposit trie from snapshot")
+	return nil
+}
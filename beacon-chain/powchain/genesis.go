@@ -0,0 +1,128 @@
+package powchain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+	"github.com/sirupsen/logrus"
+)
+
+// GenesisStatus summarizes the progress of the deposit contract towards chain start.
+type GenesisStatus struct {
+	// Chainstarted is true once the genesis conditions were met.
+	Chainstarted bool
+	// DepositCount is the number of deposits processed from the deposit contract.
+	DepositCount uint64
+	// TotalDeposits is the sum of the amounts of the processed deposits, in Gwei.
+	TotalDeposits uint64
+	// ActiveValidatorCount is the number of validators which would be active at genesis.
+	ActiveValidatorCount uint64
+	// GenesisTime is the genesis time once the chain started. Before chain start it is the
+	// earliest genesis time if the genesis conditions were met at the latest eth1 block.
+	GenesisTime uint64
+}
+
+// GenesisStatusFetcher retrieves the progress of the deposit contract towards chain start.
+type GenesisStatusFetcher interface {
+	GenesisStatus() *GenesisStatus
+}
+
+// GenesisStatus returns the progress of the deposit contract towards chain start.
+func (s *Service) GenesisStatus() *GenesisStatus {
+	s.processingLock.RLock()
+	defer s.processingLock.RUnlock()
+
+	status := &GenesisStatus{
+		Chainstarted: s.chainStartData.Chainstarted,
+		DepositCount: uint64(len(s.chainStartData.ChainstartDeposits)),
+	}
+	for _, d := range s.chainStartData.ChainstartDeposits {
+		if d.Data != nil {
+			status.TotalDeposits += d.Data.Amount
+		}
+	}
+	if status.Chainstarted {
+		status.GenesisTime = s.chainStartData.GenesisTime
+	} else {
+		status.GenesisTime = GenesisTime(s.latestEth1Data.BlockTime)
+		if status.GenesisTime < params.BeaconConfig().MinGenesisTime {
+			status.GenesisTime = params.BeaconConfig().MinGenesisTime
+		}
+	}
+	if s.preGenesisState != nil {
+		count, err := helpers.ActiveValidatorCount(s.preGenesisState, 0)
+		if err == nil {
+			status.ActiveValidatorCount = count
+		}
+	}
+	return status
+}
+
+// GenesisTime computes the genesis time of a chain triggered by the eth1 block with the given
+// timestamp, following the spec:
+//
+//	genesis_time = eth1_timestamp - eth1_timestamp % MIN_GENESIS_DELAY + 2 * MIN_GENESIS_DELAY
+//
+// The genesis delay is the MIN_GENESIS_DELAY of the chain config unless overridden with the custom
+// genesis delay flag, a delay of 0 starts the chain at the eth1 block timestamp.
+func GenesisTime(eth1Timestamp uint64) uint64 {
+	delay := featureconfig.Get().CustomGenesisDelay
+	if delay == 0 {
+		return eth1Timestamp
+	}
+	return eth1Timestamp - eth1Timestamp%delay + 2*delay
+}
+
+// notifyChainStart starts the beacon chain once the genesis time is reached, by sending the
+// chain started event. Until then, the genesis service counts down to the genesis time.
+func (s *Service) notifyChainStart(genesisTime time.Time) {
+	// The deposits of the pre-genesis state activate the genesis validators, so its registry is
+	// the registry of the genesis state.
+	validatorsRoot, err := stateutil.ValidatorRegistryRoot(s.preGenesisState.Validators())
+	if err != nil {
+		log.WithError(err).Error("Unable to compute genesis validators root")
+	}
+	select {
+	case <-time.After(roughtime.Until(genesisTime)):
+	case <-s.ctx.Done():
+		return
+	}
+	log.WithFields(logrus.Fields{
+		"ChainStartTime":        genesisTime,
+		"GenesisValidatorsRoot": fmt.Sprintf("%#x", validatorsRoot),
+	}).Info("Genesis time reached, starting the beacon chain")
+	s.stateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.ChainStarted,
+		Data: &statefeed.ChainStartedData{
+			StartTime:             genesisTime,
+			GenesisValidatorsRoot: validatorsRoot[:],
+		},
+	})
+}
+
+// resumeChainStart waits for the genesis time again if the node was restarted after the genesis
+// conditions were met, but before the beacon chain started with the genesis state.
+func (s *Service) resumeChainStart(ctx context.Context) error {
+	// Chain start data restored from a deposit snapshot has no genesis time, the chain is
+	// already running.
+	if !s.chainStartData.Chainstarted || s.chainStartData.GenesisTime == 0 {
+		return nil
+	}
+	genesisState, err := s.beaconDB.GenesisState(ctx)
+	if err != nil {
+		return err
+	}
+	if genesisState != nil {
+		return nil
+	}
+	go s.notifyChainStart(time.Unix(int64(s.chainStartData.GenesisTime), 0))
+	return nil
+}
@@ -0,0 +1,93 @@
+package powchain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	protodb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+)
+
+func TestGenesisTime(t *testing.T) {
+	defer featureconfig.Init(&featureconfig.Flags{})
+
+	featureconfig.Init(&featureconfig.Flags{CustomGenesisDelay: 0})
+	if got := GenesisTime(1000); got != 1000 {
+		t.Errorf("Wanted genesis time 1000 without delay, got %d", got)
+	}
+
+	featureconfig.Init(&featureconfig.Flags{CustomGenesisDelay: 300})
+	// 1000 - 1000 % 300 + 2 * 300
+	if got := GenesisTime(1000); got != 1500 {
+		t.Errorf("Wanted genesis time 1500, got %d", got)
+	}
+}
+
+func TestGenesisStatus(t *testing.T) {
+	defer featureconfig.Init(&featureconfig.Flags{})
+	featureconfig.Init(&featureconfig.Flags{CustomGenesisDelay: 300})
+
+	s := &Service{
+		chainStartData: &protodb.ChainStartData{
+			ChainstartDeposits: []*ethpb.Deposit{
+				{Data: &ethpb.Deposit_Data{Amount: 32e9}},
+				{Data: &ethpb.Deposit_Data{Amount: 16e9}},
+			},
+		},
+		latestEth1Data: &protodb.LatestETH1Data{BlockTime: 1000},
+	}
+	status := s.GenesisStatus()
+	if status.Chainstarted {
+		t.Error("Expected chain not to be started")
+	}
+	if status.DepositCount != 2 || status.TotalDeposits != 48e9 {
+		t.Errorf("Wanted 2 deposits totaling 48e9, got %d totaling %d", status.DepositCount, status.TotalDeposits)
+	}
+	if status.GenesisTime != 1500 {
+		t.Errorf("Wanted expected genesis time 1500, got %d", status.GenesisTime)
+	}
+
+	s.chainStartData.Chainstarted = true
+	s.chainStartData.GenesisTime = 1200
+	if status := s.GenesisStatus(); status.GenesisTime != 1200 {
+		t.Errorf("Wanted genesis time of the started chain 1200, got %d", status.GenesisTime)
+	}
+}
+
+func TestNotifyChainStart_WaitsForGenesisTime(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	preGenesisState, err := stateTrie.InitializeFromProto(&pb.BeaconState{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Service{
+		ctx:             ctx,
+		stateNotifier:   &goodNotifier{},
+		preGenesisState: preGenesisState,
+	}
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := s.stateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+
+	genesisTime := time.Now().Add(time.Second)
+	go s.notifyChainStart(genesisTime)
+
+	select {
+	case ev := <-stateChannel:
+		if ev.Type != statefeed.ChainStarted {
+			t.Fatalf("Wanted chain started event, got %v", ev.Type)
+		}
+		if time.Now().Before(genesisTime) {
+			t.Error("Expected the chain not to start before the genesis time")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Chain did not start at the genesis time")
+	}
+}
@@ -14,15 +14,12 @@ import (
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
-	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
-	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	contracts "github.com/prysmaticlabs/prysm/contracts/deposit-contract"
 	protodb "github.com/prysmaticlabs/prysm/proto/beacon/db"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
-	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/sirupsen/logrus"
@@ -84,14 +81,7 @@ func (s *Service) ProcessLog(ctx context.Context, depositLog gethTypes.Log) erro
 			return errors.Wrap(err, "Could not process deposit log")
 		}
 		if s.lastReceivedMerkleIndex%eth1DataSavingInterval == 0 {
-			eth1Data := &protodb.ETH1ChainData{
-				CurrentEth1Data:   s.latestEth1Data,
-				ChainstartData:    s.chainStartData,
-				BeaconState:       s.preGenesisState.InnerStateUnsafe(), // I promise not to mutate it!
-				Trie:              s.depositTrie.ToProto(),
-				DepositContainers: s.depositCache.AllDepositContainers(ctx),
-			}
-			return s.beaconDB.SavePowchainData(ctx, eth1Data)
+			return s.savePowchainData(ctx)
 		}
 		return nil
 	}
@@ -99,6 +89,18 @@ func (s *Service) ProcessLog(ctx context.Context, depositLog gethTypes.Log) erro
 	return nil
 }
 
+// savePowchainData saves the deposit trie and deposits processed so far in the database.
+func (s *Service) savePowchainData(ctx context.Context) error {
+	eth1Data := &protodb.ETH1ChainData{
+		CurrentEth1Data:   s.latestEth1Data,
+		ChainstartData:    s.chainStartData,
+		BeaconState:       s.preGenesisState.InnerStateUnsafe(), // I promise not to mutate it!
+		Trie:              s.depositTrie.ToProto(),
+		DepositContainers: s.depositCache.AllDepositContainers(ctx),
+	}
+	return s.beaconDB.SavePowchainData(ctx, eth1Data)
+}
+
 // ProcessDepositLog processes the log which had been received from
 // the ETH1.0 chain by trying to ascertain which participant deposited
 // in the contract.
@@ -167,6 +169,8 @@ func (s *Service) ProcessDepositLog(ctx context.Context, depositLog gethTypes.Lo
 
 	// We always store all historical deposits in the DB.
 	s.depositCache.InsertDeposit(ctx, deposit, depositLog.BlockNumber, int64(index), s.depositTrie.Root())
+	s.depositCache.SetDepositTxHash(ctx, int64(index), depositLog.TxHash)
+	s.recordDepositBlock(depositLog)
 	validData := true
 	if !s.chainStartData.Chainstarted {
 		s.chainStartData.ChainstartDeposits = append(s.chainStartData.ChainstartDeposits, deposit)
@@ -228,24 +232,8 @@ func (s *Service) ProcessChainStart(genesisTime uint64, eth1BlockHash [32]byte,
 		BlockHash:    eth1BlockHash[:],
 	}
 
-	log.WithFields(logrus.Fields{
-		"ChainStartTime": chainStartTime,
-	}).Info("Minimum number of validators reached for beacon-chain to start")
-	s.stateNotifier.StateFeed().Send(&feed.Event{
-		Type: statefeed.ChainStarted,
-		Data: &statefeed.ChainStartedData{
-			StartTime: chainStartTime,
-		},
-	})
-}
-
-func (s *Service) createGenesisTime(timeStamp uint64) uint64 {
-	if featureconfig.Get().CustomGenesisDelay == 0 {
-		return timeStamp
-	}
-	timeStampRdDown := timeStamp - timeStamp%featureconfig.Get().CustomGenesisDelay
-	// genesisTime will be set to the first second of the day, two days after it was triggered.
-	return timeStampRdDown + 2*featureconfig.Get().CustomGenesisDelay
+	log.WithField("ChainStartTime", chainStartTime).Info("Minimum number of validators reached for beacon-chain to start")
+	go s.notifyChainStart(chainStartTime)
 }
 
 // processPastLogs processes all the past logs from the deposit contract and
@@ -347,7 +335,11 @@ func (s *Service) processPastLogs(ctx context.Context) error {
 // last polled to now.
 func (s *Service) requestBatchedLogs(ctx context.Context) error {
 	// We request for the nth block behind the current head, in order to have
-	// stabilized logs when we retrieve it from the 1.0 chain.
+	// stabilized logs when we retrieve it from the 1.0 chain. Logs of blocks reorged
+	// out of the chain after being requested are requested again.
+	if err := s.checkDepositReorg(ctx); err != nil {
+		return errors.Wrap(err, "could not check eth1 reorg of deposit logs")
+	}
 
 	requestedBlock := s.latestEth1Data.BlockHeight - uint64(params.BeaconConfig().LogBlockDelay)
 	for i := s.latestEth1Data.LastRequestedBlock + 1; i <= requestedBlock; i++ {
@@ -449,9 +441,10 @@ func (s *Service) checkHeaderRange(start uint64, end uint64,
 
 func (s *Service) checkForChainstart(blockHash [32]byte, blockNumber *big.Int, blockTime uint64) {
 	valCount, _ := helpers.ActiveValidatorCount(s.preGenesisState, 0)
-	triggered := state.IsValidGenesisState(valCount, s.createGenesisTime(blockTime))
+	genesisTime := GenesisTime(blockTime)
+	triggered := state.IsValidGenesisState(valCount, genesisTime)
 	if triggered {
-		s.chainStartData.GenesisTime = s.createGenesisTime(blockTime)
+		s.chainStartData.GenesisTime = genesisTime
 		s.ProcessChainStart(s.chainStartData.GenesisTime, blockHash, blockNumber)
 	}
 }
@@ -0,0 +1,153 @@
+package powchain
+
+import (
+	"context"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
+	"github.com/sirupsen/logrus"
+)
+
+// depositReorgDepth is the number of eth1 blocks behind the last requested block whose
+// deposit logs are checked for reorgs.
+const depositReorgDepth = 100
+
+var (
+	depositReorgsCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "powchain_deposit_reorgs",
+		Help: "The number of eth1 reorgs beyond the log block delay which removed processed deposit logs",
+	})
+	reorgedDepositsCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "powchain_reorged_deposits",
+		Help: "The number of processed deposits removed by eth1 reorgs",
+	})
+)
+
+// recordDepositBlock keeps the hash of the eth1 block a deposit log was processed from, to
+// detect the block being reorged out of the eth1 chain.
+func (s *Service) recordDepositBlock(depositLog gethTypes.Log) {
+	if s.depositBlockHashes == nil {
+		s.depositBlockHashes = make(map[uint64]common.Hash)
+	}
+	s.depositBlockHashes[depositLog.BlockNumber] = depositLog.BlockHash
+}
+
+// checkDepositReorg checks whether the eth1 blocks of the recently processed deposit logs are
+// still canonical. Deposit logs are only processed after the log block delay, so this only
+// happens on eth1 reorgs deeper than the delay. The deposits of removed blocks are then
+// reverted so their logs are processed again from the new eth1 chain. Deposits processed
+// before the chain started are part of the pre-genesis state and are not reverted.
+func (s *Service) checkDepositReorg(ctx context.Context) error {
+	if !s.chainStartData.Chainstarted || len(s.depositBlockHashes) == 0 {
+		return nil
+	}
+	var minHeight uint64
+	if s.latestEth1Data.LastRequestedBlock > depositReorgDepth {
+		minHeight = s.latestEth1Data.LastRequestedBlock - depositReorgDepth
+	}
+	heights := make([]uint64, 0, len(s.depositBlockHashes))
+	for height := range s.depositBlockHashes {
+		if height < minHeight {
+			delete(s.depositBlockHashes, height)
+			continue
+		}
+		heights = append(heights, height)
+	}
+	if len(heights) == 0 {
+		return nil
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+
+	// Blocks before a canonical block are canonical, so only the latest deposit block is
+	// requested unless it was reorged.
+	canonical, err := s.isCanonicalDepositBlock(ctx, heights[len(heights)-1])
+	if err != nil || canonical {
+		return err
+	}
+	forkHeight := heights[len(heights)-1]
+	for i := len(heights) - 2; i >= 0; i-- {
+		canonical, err := s.isCanonicalDepositBlock(ctx, heights[i])
+		if err != nil {
+			return err
+		}
+		if canonical {
+			break
+		}
+		forkHeight = heights[i]
+	}
+	return s.revertDepositsFromHeight(ctx, forkHeight)
+}
+
+// isCanonicalDepositBlock requests the eth1 block at the height of a deposit block from the
+// eth1 node, bypassing the block cache, and compares it with the deposit block.
+func (s *Service) isCanonicalDepositBlock(ctx context.Context, height uint64) (bool, error) {
+	providerCalls.WithLabelValues("HeaderByNumber").Inc()
+	header, err := s.blockFetcher.HeaderByNumber(ctx, new(big.Int).SetUint64(height))
+	if err != nil {
+		return false, errors.Wrapf(err, "could not get eth1 header at height %d", height)
+	}
+	if header == nil {
+		return false, errors.Errorf("no eth1 header at height %d", height)
+	}
+	if header.Hash() == s.depositBlockHashes[height] {
+		return true, nil
+	}
+	// Eth1 data votes read the block hashes by height from the block cache.
+	if err := s.blockCache.AddBlock(gethTypes.NewBlockWithHeader(header)); err != nil {
+		return false, errors.Wrap(err, "could not cache eth1 header")
+	}
+	return false, nil
+}
+
+// revertDepositsFromHeight removes the deposits processed from eth1 blocks from the given
+// height on and recomputes the deposit trie from the remaining deposits, starting from the
+// deposit snapshot of the node if any. Logs are then requested again from the height.
+func (s *Service) revertDepositsFromHeight(ctx context.Context, height uint64) error {
+	s.processingLock.Lock()
+	defer s.processingLock.Unlock()
+
+	removed := s.depositCache.RemoveDepositsFromHeight(ctx, height)
+	snapshot := s.depositCache.DepositSnapshot()
+	deposits := s.depositCache.AllDeposits(ctx, nil)
+	items := make([][]byte, len(deposits))
+	for i, dep := range deposits {
+		root, err := ssz.HashTreeRoot(dep.Data)
+		if err != nil {
+			return errors.Wrap(err, "could not hash deposit data")
+		}
+		items[i] = root[:]
+	}
+	depositTrie, err := trieutil.TrieFromSnapshotAndItems(snapshot, items, int(params.BeaconConfig().DepositContractTreeDepth))
+	if err != nil {
+		return errors.Wrap(err, "could not recompute deposit trie")
+	}
+	var snapshotCount uint64
+	if snapshot != nil {
+		snapshotCount = snapshot.DepositCount
+	}
+	s.depositTrie = depositTrie
+	s.lastReceivedMerkleIndex = int64(snapshotCount) + int64(len(deposits)) - 1
+	s.latestEth1Data.LastRequestedBlock = height - 1
+	for h := range s.depositBlockHashes {
+		if h >= height {
+			delete(s.depositBlockHashes, h)
+		}
+	}
+
+	depositReorgsCount.Inc()
+	reorgedDepositsCount.Add(float64(removed))
+	log.WithFields(logrus.Fields{
+		"forkHeight":      height,
+		"removedDeposits": removed,
+		"depositCount":    s.lastReceivedMerkleIndex + 1,
+	}).Warn("Eth1 reorg removed processed deposits, requesting deposit logs again")
+	return s.savePowchainData(ctx)
+}
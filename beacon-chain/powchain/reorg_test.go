@@ -0,0 +1,116 @@
+package powchain
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	protodb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
+)
+
+type headerFetcher struct {
+	headers map[uint64]*gethTypes.Header
+}
+
+func (h *headerFetcher) BlockByHash(ctx context.Context, hash common.Hash) (*gethTypes.Block, error) {
+	return nil, nil
+}
+
+func (h *headerFetcher) BlockByNumber(ctx context.Context, number *big.Int) (*gethTypes.Block, error) {
+	return gethTypes.NewBlockWithHeader(h.headers[number.Uint64()]), nil
+}
+
+func (h *headerFetcher) HeaderByNumber(ctx context.Context, number *big.Int) (*gethTypes.Header, error) {
+	return h.headers[number.Uint64()], nil
+}
+
+func TestCheckDepositReorg_RevertsDepositsOfRemovedBlocks(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, beaconDB)
+	depositTrie, err := trieutil.NewTrie(int(params.BeaconConfig().DepositContractTreeDepth))
+	if err != nil {
+		t.Fatal(err)
+	}
+	genState, err := state.EmptyGenesisState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fetcher := &headerFetcher{headers: make(map[uint64]*gethTypes.Header)}
+	s := &Service{
+		beaconDB:                beaconDB,
+		depositCache:            depositcache.NewDepositCache(),
+		depositTrie:             depositTrie,
+		chainStartData:          &protodb.ChainStartData{Chainstarted: true},
+		latestEth1Data:          &protodb.LatestETH1Data{LastRequestedBlock: 12},
+		blockCache:              newBlockCache(),
+		blockFetcher:            fetcher,
+		preGenesisState:         genState,
+		lastReceivedMerkleIndex: -1,
+	}
+
+	var firstRoot [32]byte
+	for i, height := range []uint64{10, 11, 12} {
+		fetcher.headers[height] = &gethTypes.Header{Number: new(big.Int).SetUint64(height)}
+		data := &ethpb.Deposit_Data{PublicKey: bytes.Repeat([]byte{byte(i)}, 48), Amount: 32e9}
+		root, err := ssz.HashTreeRoot(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.depositTrie.Insert(root[:], i)
+		s.depositCache.InsertDeposit(ctx, &ethpb.Deposit{Data: data}, height, int64(i), s.depositTrie.Root())
+		s.lastReceivedMerkleIndex = int64(i)
+		s.recordDepositBlock(gethTypes.Log{BlockNumber: height, BlockHash: fetcher.headers[height].Hash()})
+		if i == 0 {
+			firstRoot = s.depositTrie.Root()
+		}
+	}
+
+	if err := s.checkDepositReorg(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if s.lastReceivedMerkleIndex != 2 {
+		t.Fatalf("Expected no deposits to be reverted without a reorg, last index %d", s.lastReceivedMerkleIndex)
+	}
+
+	// The blocks of the last two deposits are reorged out of the eth1 chain.
+	for _, height := range []uint64{11, 12} {
+		fetcher.headers[height] = &gethTypes.Header{Number: new(big.Int).SetUint64(height), Extra: []byte("fork")}
+	}
+	if err := s.checkDepositReorg(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if s.lastReceivedMerkleIndex != 0 || s.latestEth1Data.LastRequestedBlock != 10 {
+		t.Errorf(
+			"Wanted last index 0 and last requested block 10, received %d and %d",
+			s.lastReceivedMerkleIndex,
+			s.latestEth1Data.LastRequestedBlock,
+		)
+	}
+	if deposits := s.depositCache.AllDeposits(ctx, nil); len(deposits) != 1 {
+		t.Errorf("Wanted 1 remaining deposit, received %d", len(deposits))
+	}
+	if s.depositTrie.Root() != firstRoot {
+		t.Error("Expected the deposit trie to only contain the first deposit")
+	}
+	if _, ok := s.depositBlockHashes[11]; ok {
+		t.Error("Expected the removed deposit blocks to be forgotten")
+	}
+	hash, err := s.BlockHashByHeight(ctx, big.NewInt(12))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != fetcher.headers[12].Hash() {
+		t.Error("Expected the block cache to hold the block of the new eth1 chain")
+	}
+}
@@ -26,6 +26,7 @@ import (
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	contracts "github.com/prysmaticlabs/prysm/contracts/deposit-contract"
 	protodb "github.com/prysmaticlabs/prysm/proto/beacon/db"
@@ -147,6 +148,8 @@ type Service struct {
 	processingLock          sync.RWMutex
 	requestingOldLogs       bool
 	connectedETH1           bool
+	depositBlockHashes      map[uint64]common.Hash // Hashes of the eth1 blocks of recent deposit logs, by height.
+	forceDepositConfig      bool
 }
 
 // Web3ServiceConfig defines a config struct for web3 service to use through its life cycle.
@@ -157,6 +160,12 @@ type Web3ServiceConfig struct {
 	BeaconDB        db.HeadAccessDatabase
 	DepositCache    *depositcache.DepositCache
 	StateNotifier   statefeed.Notifier
+	// DepositSnapshot initializes the deposit trie of a node without eth1 data in its
+	// database, instead of processing the deposit logs before the snapshot.
+	DepositSnapshot *trieutil.DepositTreeSnapshot
+	// ForceDepositConfig only warns, instead of stopping the node, when the eth1 endpoint or
+	// the deposit contract do not match the chain config.
+	ForceDepositConfig bool
 }
 
 // NewService sets up a new instance with an ethclient when
@@ -203,6 +212,8 @@ func NewService(ctx context.Context, config *Web3ServiceConfig) (*Service, error
 		depositCache:            config.DepositCache,
 		lastReceivedMerkleIndex: -1,
 		preGenesisState:         genState,
+		depositBlockHashes:      make(map[uint64]common.Hash),
+		forceDepositConfig:      config.ForceDepositConfig,
 	}
 
 	eth1Data, err := config.BeaconDB.PowchainData(ctx)
@@ -223,12 +234,27 @@ func NewService(ctx context.Context, config *Web3ServiceConfig) (*Service, error
 		if err := s.initDepositCaches(ctx, eth1Data.DepositContainers); err != nil {
 			return nil, errors.Wrap(err, "could not initialize caches")
 		}
+		// A trie initialized from a snapshot was saved without the deposits of the snapshot.
+		if pruned := s.depositTrie.PrunedCount(); pruned > 0 {
+			snapshot, err := s.depositTrie.Snapshot(pruned)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not take snapshot of pruned deposits")
+			}
+			s.depositCache.SetDepositSnapshot(snapshot)
+		}
+	} else if config.DepositSnapshot != nil {
+		if err := s.initFromDepositSnapshot(config.DepositSnapshot); err != nil {
+			return nil, err
+		}
 	}
 	return s, nil
 }
 
 // Start a web3 service's main event loop.
 func (s *Service) Start() {
+	if err := s.resumeChainStart(s.ctx); err != nil {
+		log.WithError(err).Error("Could not resume chain start")
+	}
 	go func() {
 		s.waitForConnection()
 		s.run(s.ctx.Done())
@@ -324,8 +350,11 @@ func (s *Service) AreAllDepositsProcessed() (bool, error) {
 		return false, errors.Wrap(err, "could not get deposit count")
 	}
 	count := bytesutil.FromBytes8(countByte)
-	deposits := s.depositCache.AllDeposits(context.TODO(), nil)
-	if count != uint64(len(deposits)) {
+	deposits := uint64(len(s.depositCache.AllDeposits(context.TODO(), nil)))
+	if snapshot := s.depositCache.DepositSnapshot(); snapshot != nil {
+		deposits += snapshot.DepositCount
+	}
+	if count != deposits {
 		return false, nil
 	}
 	return true, nil
@@ -337,6 +366,19 @@ func (s *Service) connectToPowChain() error {
 		return errors.Wrap(err, "could not dial eth1 nodes")
 	}
 
+	// Interop genesis states are not backed by a deposit contract.
+	if s.depositContractAddress != (common.Address{}) {
+		if err := checkDepositConfig(s.ctx, httpClient, s.depositContractAddress); err != nil {
+			if errors.Cause(err) != errDepositConfigMismatch {
+				return errors.Wrap(err, "could not check eth1 network")
+			}
+			if !s.forceDepositConfig {
+				log.WithError(err).Fatalf("Refusing to track the wrong deposit contract, run with --%s to start anyway", flags.ForceEth1ConfigFlag.Name)
+			}
+			log.WithError(err).Warn("Eth1 endpoint does not match the chain config, tracking the deposit contract anyway")
+		}
+	}
+
 	depositContractCaller, err := contracts.NewDepositContractCaller(s.depositContractAddress, httpClient)
 	if err != nil {
 		return errors.Wrap(err, "could not create deposit contract caller")
@@ -487,6 +529,7 @@ func (s *Service) batchRequestHeaders(startBlock uint64, endBlock uint64) ([]*ge
 		headers = append(headers, header)
 		errors = append(errors, err)
 	}
+	providerCalls.WithLabelValues("BatchCall").Inc()
 	ioErr := s.rpcClient.BatchCall(elems)
 	if ioErr != nil {
 		return nil, ioErr
@@ -496,10 +539,8 @@ func (s *Service) batchRequestHeaders(startBlock uint64, endBlock uint64) ([]*ge
 			return nil, e
 		}
 	}
-	for _, h := range headers {
-		if h != nil {
-			s.blockCache.AddBlock(gethTypes.NewBlockWithHeader(h))
-		}
+	if err := s.cacheHeaders(s.ctx, headers); err != nil {
+		return nil, err
 	}
 	return headers, nil
 }
@@ -566,6 +607,7 @@ func (s *Service) run(done <-chan struct{}) {
 		return
 	}
 
+	providerCalls.WithLabelValues("HeaderByNumber").Inc()
 	header, err := s.blockFetcher.HeaderByNumber(context.Background(), nil)
 	if err != nil {
 		log.Errorf("Unable to retrieve latest ETH1.0 chain header: %v", err)
@@ -0,0 +1,160 @@
+// Package replay re-runs a single state transition step by step, logging
+// the outcome and resulting state root of every slot, epoch and block
+// operation processed, to reproduce failed transitions from bug reports.
+package replay
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	b "github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "replay")
+
+// DecodeTransition decodes the ssz encoded pre-state and signed block of a
+// state transition, as written by interop.WriteTransitionFailureToDisk.
+func DecodeTransition(encState []byte, encBlock []byte) (*stateTrie.BeaconState, *ethpb.SignedBeaconBlock, error) {
+	pbState := &pb.BeaconState{}
+	if err := ssz.Unmarshal(encState, pbState); err != nil {
+		return nil, nil, errors.Wrap(err, "could not decode pre-state")
+	}
+	preState, err := stateTrie.InitializeFromProto(pbState)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not initialize pre-state")
+	}
+	blk := &ethpb.SignedBeaconBlock{}
+	if err := ssz.Unmarshal(encBlock, blk); err != nil {
+		return nil, nil, errors.Wrap(err, "could not decode block")
+	}
+	if blk.Block == nil || blk.Block.Body == nil {
+		return nil, nil, errors.New("nil block")
+	}
+	return preState, blk, nil
+}
+
+// Transition applies the block to the pre-state like state.ExecuteStateTransition,
+// one slot and one block operation at a time, logging each step. It returns the
+// post-state, or the error of the first failing step.
+func Transition(
+	ctx context.Context, preState *stateTrie.BeaconState, signed *ethpb.SignedBeaconBlock,
+) (*stateTrie.BeaconState, error) {
+	if preState == nil || signed == nil || signed.Block == nil || signed.Block.Body == nil {
+		return nil, errors.New("nil state or block")
+	}
+	st := preState.Copy()
+	blk := signed.Block
+	logStep(st, "Loaded pre-state", nil)
+
+	var err error
+	for st.Slot() < blk.Slot {
+		slot := st.Slot()
+		epochTransition := state.CanProcessEpoch(st)
+		st, err = state.ProcessSlots(ctx, st, slot+1)
+		if err != nil {
+			return nil, stepError(fmt.Sprintf("process slot %d", slot), err)
+		}
+		msg := "Processed slot"
+		if epochTransition {
+			msg = fmt.Sprintf("Processed slot and transition to epoch %d", helpers.SlotToEpoch(st.Slot()))
+		}
+		logStep(st, msg, nil)
+	}
+
+	st, err = b.ProcessBlockHeader(st, signed)
+	if err != nil {
+		return nil, stepError("process block header", err)
+	}
+	logStep(st, "Processed block header", nil)
+	st, err = b.ProcessRandao(st, blk.Body)
+	if err != nil {
+		return nil, stepError("process randao", err)
+	}
+	logStep(st, "Processed randao", nil)
+	st, err = b.ProcessEth1DataInBlock(st, blk)
+	if err != nil {
+		return nil, stepError("process eth1 data", err)
+	}
+	logStep(st, "Processed eth1 data", nil)
+	if err := state.VerifyOperationLengths(st, blk.Body); err != nil {
+		return nil, stepError("verify operation lengths", err)
+	}
+
+	for i, slashing := range blk.Body.ProposerSlashings {
+		body := &ethpb.BeaconBlockBody{ProposerSlashings: []*ethpb.ProposerSlashing{slashing}}
+		if st, err = b.ProcessProposerSlashings(ctx, st, body); err != nil {
+			return nil, stepError(fmt.Sprintf("process proposer slashing %d", i), err)
+		}
+		logStep(st, "Processed proposer slashing", logrus.Fields{"index": i, "proposerIndex": slashing.ProposerIndex})
+	}
+	for i, slashing := range blk.Body.AttesterSlashings {
+		body := &ethpb.BeaconBlockBody{AttesterSlashings: []*ethpb.AttesterSlashing{slashing}}
+		if st, err = b.ProcessAttesterSlashings(ctx, st, body); err != nil {
+			return nil, stepError(fmt.Sprintf("process attester slashing %d", i), err)
+		}
+		logStep(st, "Processed attester slashing", logrus.Fields{"index": i})
+	}
+	for i, att := range blk.Body.Attestations {
+		body := &ethpb.BeaconBlockBody{Attestations: []*ethpb.Attestation{att}}
+		if st, err = b.ProcessAttestations(ctx, st, body); err != nil {
+			return nil, stepError(fmt.Sprintf("process attestation %d", i), err)
+		}
+		fields := logrus.Fields{"index": i}
+		if att.Data != nil {
+			fields["slot"] = att.Data.Slot
+			fields["committeeIndex"] = att.Data.CommitteeIndex
+		}
+		logStep(st, "Processed attestation", fields)
+	}
+	for i, deposit := range blk.Body.Deposits {
+		body := &ethpb.BeaconBlockBody{Deposits: []*ethpb.Deposit{deposit}}
+		if st, err = b.ProcessDeposits(ctx, st, body); err != nil {
+			return nil, stepError(fmt.Sprintf("process deposit %d", i), err)
+		}
+		logStep(st, "Processed deposit", logrus.Fields{"index": i})
+	}
+	for i, exit := range blk.Body.VoluntaryExits {
+		body := &ethpb.BeaconBlockBody{VoluntaryExits: []*ethpb.SignedVoluntaryExit{exit}}
+		if st, err = b.ProcessVoluntaryExits(ctx, st, body); err != nil {
+			return nil, stepError(fmt.Sprintf("process voluntary exit %d", i), err)
+		}
+		fields := logrus.Fields{"index": i}
+		if exit.Exit != nil {
+			fields["validatorIndex"] = exit.Exit.ValidatorIndex
+		}
+		logStep(st, "Processed voluntary exit", fields)
+	}
+
+	postRoot, err := st.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not hash post-state")
+	}
+	if postRoot != bytesutil.ToBytes32(blk.StateRoot) {
+		return st, fmt.Errorf("validate state root failed, wanted: %#x, received: %#x", postRoot, blk.StateRoot)
+	}
+	log.WithField("stateRoot", fmt.Sprintf("%#x", postRoot)).Info("State root matches block")
+	return st, nil
+}
+
+func stepError(step string, err error) error {
+	log.WithError(err).Errorf("Failed to %s", step)
+	return errors.Wrapf(err, "could not %s", step)
+}
+
+// logStep logs a processed step with the slot and root of the resulting state.
+func logStep(st *stateTrie.BeaconState, msg string, fields logrus.Fields) {
+	entry := log.WithField("slot", st.Slot())
+	if root, err := st.HashTreeRoot(); err == nil {
+		entry = entry.WithField("stateRoot", fmt.Sprintf("%#x", root))
+	}
+	entry.WithFields(fields).Info(msg)
+}
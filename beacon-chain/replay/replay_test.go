@@ -0,0 +1,107 @@
+package replay
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestTransition_MatchesStateTransition(t *testing.T) {
+	beaconState, privs := testutil.DeterministicGenesisState(t, 64)
+	conf := &testutil.BlockGenConfig{
+		NumAttestations: 1,
+	}
+	blk, err := testutil.GenerateFullBlock(beaconState, privs, conf, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wanted, err := state.ExecuteStateTransition(context.Background(), beaconState.Copy(), blk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed, err := Transition(context.Background(), beaconState, blk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantedRoot, err := wanted.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayedRoot, err := replayed.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wantedRoot != replayedRoot {
+		t.Errorf("Wanted post-state root %#x, received %#x", wantedRoot, replayedRoot)
+	}
+	if beaconState.Slot() != 0 {
+		t.Error("Expected pre-state to be left unmodified")
+	}
+}
+
+func TestTransition_StateRootMismatch(t *testing.T) {
+	beaconState, privs := testutil.DeterministicGenesisState(t, 64)
+	blk, err := testutil.GenerateFullBlock(beaconState, privs, nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blk.Block.StateRoot = make([]byte, 32)
+
+	if _, err := Transition(context.Background(), beaconState, blk); err == nil || !strings.Contains(err.Error(), "validate state root failed") {
+		t.Errorf("Expected state root error, received %v", err)
+	}
+}
+
+func TestTransition_ReportsFailingStep(t *testing.T) {
+	beaconState, privs := testutil.DeterministicGenesisState(t, 64)
+	blk, err := testutil.GenerateFullBlock(beaconState, privs, nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blk.Block.ParentRoot = make([]byte, 32)
+
+	if _, err := Transition(context.Background(), beaconState, blk); err == nil || !strings.Contains(err.Error(), "could not process block header") {
+		t.Errorf("Expected block header error, received %v", err)
+	}
+}
+
+func TestDecodeTransition(t *testing.T) {
+	beaconState, privs := testutil.DeterministicGenesisState(t, 64)
+	blk, err := testutil.GenerateFullBlock(beaconState, privs, nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encState, err := ssz.Marshal(beaconState.InnerStateUnsafe())
+	if err != nil {
+		t.Fatal(err)
+	}
+	encBlock, err := ssz.Marshal(blk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decodedState, decodedBlock, err := DecodeTransition(encState, encBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantedRoot, err := beaconState.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decodedRoot, err := decodedState.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wantedRoot != decodedRoot {
+		t.Errorf("Wanted state root %#x, received %#x", wantedRoot, decodedRoot)
+	}
+	if decodedBlock.Block.Slot != blk.Block.Slot {
+		t.Errorf("Wanted block slot %d, received %d", blk.Block.Slot, decodedBlock.Block.Slot)
+	}
+}
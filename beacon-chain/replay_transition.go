@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/beacon-chain/replay"
+	"github.com/urfave/cli"
+)
+
+// replayTransition re-runs a state transition from an ssz encoded pre-state and
+// block, such as those written on failure to the --transition-debug-dir directory.
+func replayTransition(ctx *cli.Context) error {
+	if err := configureChainParams(ctx); err != nil {
+		return err
+	}
+	statePath := ctx.String(flags.ReplayPreStateFlag.Name)
+	blockPath := ctx.String(flags.ReplayBlockFlag.Name)
+	if statePath == "" || blockPath == "" {
+		return errors.New("both --pre-state and --block are required")
+	}
+	encState, err := ioutil.ReadFile(statePath)
+	if err != nil {
+		return errors.Wrap(err, "could not read pre-state")
+	}
+	encBlock, err := ioutil.ReadFile(blockPath)
+	if err != nil {
+		return errors.Wrap(err, "could not read block")
+	}
+	preState, blk, err := replay.DecodeTransition(encState, encBlock)
+	if err != nil {
+		return err
+	}
+	_, err = replay.Transition(context.Background(), preState, blk)
+	return err
+}
@@ -0,0 +1,233 @@
+// Package apikey authenticates the requests of the RPC server by API key, restricting each
+// key to a whitelist of methods and a rate quota. Keys are configured in a YAML file which
+// is reloaded when it changes, so that keys can be added or revoked without a restart.
+package apikey
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kevinms/leakybucket-go"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/runutil"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v2"
+)
+
+// MetadataKey is the gRPC metadata key, and the HTTP header of the gateway, carrying the
+// API key of a request.
+const MetadataKey = "x-api-key"
+
+// reloadPeriod is how often the keys file is checked for changes.
+const reloadPeriod = 10 * time.Second
+
+// Config is the YAML configuration of the API keys.
+//
+//	keys:
+//	  - name: customer-a
+//	    key: 3f0c...
+//	    methods: ["/ethereum.eth.v1alpha1.BeaconNodeValidator/*"]
+//	    requests_per_second: 50
+//	    burst: 100
+type Config struct {
+	Keys []*Key `yaml:"keys"`
+}
+
+// Key is an API key along with the methods it may call and its rate quota.
+type Key struct {
+	Name string `yaml:"name"`
+	Key  string `yaml:"key"`
+	// Methods are the full gRPC method names the key may call, or the paths of the HTTP endpoints
+	// of the gateway which have no gRPC method. A name ending with * matches every method with
+	// that prefix. All methods are allowed if empty.
+	Methods []string `yaml:"methods"`
+	// RequestsPerSecond is the sustained rate of requests of the key, unlimited if zero.
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	// Burst is the number of requests the key may make at once, RequestsPerSecond by default.
+	Burst int64 `yaml:"burst"`
+}
+
+// tenant is a configured key with the state of its quota.
+type tenant struct {
+	key     *Key
+	limiter *leakybucket.Collector
+}
+
+// Authenticator authenticates gRPC requests against the API keys of a YAML file.
+type Authenticator struct {
+	path    string
+	lock    sync.RWMutex
+	tenants map[string]*tenant
+	modTime time.Time
+}
+
+// NewAuthenticator loads the API keys of the YAML file at path.
+func NewAuthenticator(path string) (*Authenticator, error) {
+	a := &Authenticator{path: path}
+	if err := a.reload(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Start reloads the API keys whenever the file changes, until the context is canceled.
+func (a *Authenticator) Start(ctx context.Context) {
+	runutil.RunEvery(ctx, reloadPeriod, func() {
+		if err := a.reload(); err != nil {
+			log.WithError(err).Error("Could not reload API keys, keeping the previous keys")
+		}
+	})
+}
+
+// reload loads the API keys from the file if it changed since it was last loaded. The
+// quotas of the keys which did not change are kept.
+func (a *Authenticator) reload() error {
+	info, err := os.Stat(a.path)
+	if err != nil {
+		return errors.Wrap(err, "could not stat API keys file")
+	}
+	a.lock.RLock()
+	unchanged := info.ModTime().Equal(a.modTime)
+	a.lock.RUnlock()
+	if unchanged {
+		return nil
+	}
+	enc, err := ioutil.ReadFile(a.path)
+	if err != nil {
+		return errors.Wrap(err, "could not read API keys file")
+	}
+	cfg := &Config{}
+	if err := yaml.UnmarshalStrict(enc, cfg); err != nil {
+		return errors.Wrap(err, "could not decode API keys file")
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	tenants := make(map[string]*tenant, len(cfg.Keys))
+	for _, k := range cfg.Keys {
+		if k.Key == "" {
+			return errors.Errorf("API key %q is empty", k.Name)
+		}
+		if _, ok := tenants[k.Key]; ok {
+			return errors.Errorf("API key %q is duplicated", k.Name)
+		}
+		t := &tenant{key: k}
+		if previous, ok := a.tenants[k.Key]; ok && sameQuota(previous.key, k) {
+			t.limiter = previous.limiter
+		} else if k.RequestsPerSecond > 0 {
+			burst := k.Burst
+			if burst <= 0 {
+				burst = int64(k.RequestsPerSecond)
+			}
+			if burst < 1 {
+				burst = 1
+			}
+			t.limiter = leakybucket.NewCollector(k.RequestsPerSecond, burst, false /* deleteEmptyBuckets */)
+		}
+		tenants[k.Key] = t
+	}
+	a.tenants = tenants
+	a.modTime = info.ModTime()
+	log.WithFields(logrus.Fields{
+		"path": a.path,
+		"keys": len(tenants),
+	}).Info("Loaded API keys")
+	return nil
+}
+
+// authorize checks the API key of the request is allowed to call the method now.
+func (a *Authenticator) authorize(ctx context.Context, method string) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(MetadataKey)
+	if len(values) == 0 {
+		return status.Errorf(codes.Unauthenticated, "Missing API key in %s metadata", MetadataKey)
+	}
+	a.lock.RLock()
+	t, ok := a.tenants[values[0]]
+	a.lock.RUnlock()
+	if !ok {
+		return status.Error(codes.Unauthenticated, "Invalid API key")
+	}
+	if !allowsMethod(t.key.Methods, method) {
+		return status.Errorf(codes.PermissionDenied, "API key %q is not allowed to call %s", t.key.Name, method)
+	}
+	if t.limiter != nil && t.limiter.Add(t.key.Key, 1) == 0 {
+		return status.Errorf(codes.ResourceExhausted, "API key %q exceeded its quota of %v requests per second", t.key.Name, t.key.RequestsPerSecond)
+	}
+	return nil
+}
+
+// UnaryServerInterceptor rejects the unary requests which are not authorized by an API key.
+func (a *Authenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := a.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rejects the streams which are not authorized by an API key. The
+// quota is only charged when the stream is opened.
+func (a *Authenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// HTTPHandler rejects the HTTP requests which are not authorized by an API key in the x-api-key
+// header. The path of the request is the method checked against the whitelist of the key.
+func (a *Authenticator) HTTPHandler(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if key := r.Header.Get(MetadataKey); key != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(MetadataKey, key))
+		}
+		if err := a.authorize(ctx, r.URL.Path); err != nil {
+			http.Error(w, status.Convert(err).Message(), httpStatus(status.Code(err)))
+			return
+		}
+		handler(w, r)
+	}
+}
+
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func allowsMethod(methods []string, method string) bool {
+	if len(methods) == 0 {
+		return true
+	}
+	for _, m := range methods {
+		if m == method || (strings.HasSuffix(m, "*") && strings.HasPrefix(method, strings.TrimSuffix(m, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameQuota(a *Key, b *Key) bool {
+	return a.RequestsPerSecond == b.RequestsPerSecond && a.Burst == b.Burst
+}
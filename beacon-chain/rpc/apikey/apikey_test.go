@@ -0,0 +1,176 @@
+package apikey
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testKeys = `
+keys:
+  - name: validators
+    key: key-a
+    methods: ["/ethereum.eth.v1alpha1.BeaconNodeValidator/*"]
+  - name: explorer
+    key: key-b
+    methods: ["/ethereum.eth.v1alpha1.BeaconChain/ListBlocks"]
+    requests_per_second: 1
+    burst: 1
+`
+
+func writeKeys(t *testing.T, path string, keys string, modTime time.Time) {
+	if err := ioutil.WriteFile(path, []byte(keys), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func withKey(key string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, key))
+}
+
+func TestAuthenticator_Authorize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apikey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "keys.yaml")
+	writeKeys(t, path, testKeys, time.Now().Add(-time.Hour))
+
+	a, err := NewAuthenticator(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		code   codes.Code
+	}{
+		{name: "missing key", ctx: context.Background(), method: "/ethereum.eth.v1alpha1.BeaconChain/ListBlocks", code: codes.Unauthenticated},
+		{name: "unknown key", ctx: withKey("key-c"), method: "/ethereum.eth.v1alpha1.BeaconChain/ListBlocks", code: codes.Unauthenticated},
+		{name: "wildcard method", ctx: withKey("key-a"), method: "/ethereum.eth.v1alpha1.BeaconNodeValidator/GetDuties", code: codes.OK},
+		{name: "method not allowed", ctx: withKey("key-a"), method: "/ethereum.eth.v1alpha1.BeaconChain/ListBlocks", code: codes.PermissionDenied},
+		{name: "within quota", ctx: withKey("key-b"), method: "/ethereum.eth.v1alpha1.BeaconChain/ListBlocks", code: codes.OK},
+		{name: "quota exceeded", ctx: withKey("key-b"), method: "/ethereum.eth.v1alpha1.BeaconChain/ListBlocks", code: codes.ResourceExhausted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := status.Code(a.authorize(tt.ctx, tt.method)); code != tt.code {
+				t.Errorf("Wanted code %v, received %v", tt.code, code)
+			}
+		})
+	}
+}
+
+func TestAuthenticator_Reload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apikey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "keys.yaml")
+	writeKeys(t, path, testKeys, time.Now().Add(-time.Hour))
+
+	a, err := NewAuthenticator(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	method := "/ethereum.eth.v1alpha1.BeaconChain/ListBlocks"
+	if err := a.authorize(withKey("key-b"), method); err != nil {
+		t.Fatal(err)
+	}
+
+	// The explorer key is revoked and replaced, while the quota of an unchanged key is kept.
+	writeKeys(t, path, `
+keys:
+  - name: explorer
+    key: key-c
+  - name: explorer-limited
+    key: key-b
+    requests_per_second: 1
+    burst: 1
+`, time.Now())
+	if err := a.reload(); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.authorize(withKey("key-c"), method); err != nil {
+		t.Errorf("Expected new key to be authorized, received %v", err)
+	}
+	if code := status.Code(a.authorize(withKey("key-b"), method)); code != codes.ResourceExhausted {
+		t.Errorf("Expected the quota of the unchanged key to be kept, received code %v", code)
+	}
+	if code := status.Code(a.authorize(withKey("key-a"), method)); code != codes.Unauthenticated {
+		t.Errorf("Expected removed key to be rejected, received code %v", code)
+	}
+
+	// An invalid file keeps the previous keys.
+	writeKeys(t, path, "keys: [", time.Now().Add(time.Minute))
+	if err := a.reload(); err == nil {
+		t.Error("Expected invalid keys file to fail to load")
+	}
+	if err := a.authorize(withKey("key-c"), method); err != nil {
+		t.Errorf("Expected previous keys to be kept, received %v", err)
+	}
+}
+
+func TestAuthenticator_HTTPHandler(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apikey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "keys.yaml")
+	writeKeys(t, path, `
+keys:
+  - name: proposer
+    key: key-a
+    methods: ["/validator/block/*"]
+  - name: explorer
+    key: key-b
+    methods: ["/ethereum.eth.v1alpha1.BeaconChain/*"]
+`, time.Now().Add(-time.Hour))
+
+	a, err := NewAuthenticator(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := a.HTTPHandler(func(w http.ResponseWriter, r *http.Request) {})
+
+	tests := []struct {
+		name string
+		key  string
+		code int
+	}{
+		{name: "missing key", code: http.StatusUnauthorized},
+		{name: "unknown key", key: "key-z", code: http.StatusUnauthorized},
+		{name: "path not whitelisted", key: "key-b", code: http.StatusForbidden},
+		{name: "allowed", key: "key-a", code: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/validator/block/propose", nil)
+			if tt.key != "" {
+				req.Header.Set(MetadataKey, tt.key)
+			}
+			rec := httptest.NewRecorder()
+			handler(rec, req)
+			if rec.Code != tt.code {
+				t.Errorf("Expected status %d, received %d", tt.code, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package apikey
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "rpc-apikey")
@@ -13,6 +13,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
 	"github.com/prysmaticlabs/prysm/shared/pagination"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/statusutil"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -50,12 +51,7 @@ func (bs *Server) ListValidatorAssignments(
 	}
 
 	if requestedEpoch > helpers.CurrentEpoch(headState) {
-		return nil, status.Errorf(
-			codes.InvalidArgument,
-			"Cannot retrieve information about an epoch in the future, current epoch %d, requesting %d",
-			helpers.CurrentEpoch(headState),
-			requestedEpoch,
-		)
+		return nil, statusutil.FutureEpoch(helpers.CurrentEpoch(headState), requestedEpoch)
 	}
 
 	// Filter out assignments by public keys.
@@ -139,13 +135,19 @@ func (bs *Server) ListValidatorAssignments(
 			if !ok {
 				return nil, status.Errorf(codes.Internal, "Could not get archived committee assignment for index %d", index)
 			}
-			pubkey := headState.PubkeyAtIndex(index)
+			pubkey, err := headState.PubkeyAtIndex(index)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "Could not get public key of validator %d: %v", index, err)
+			}
 			assignment.PublicKey = pubkey[:]
 			res = append(res, assignment)
 			continue
 		}
 		comAssignment := committeeAssignments[index]
-		pubkey := headState.PubkeyAtIndex(index)
+		pubkey, err := headState.PubkeyAtIndex(index)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get public key of validator %d: %v", index, err)
+		}
 		assign := &ethpb.ValidatorAssignments_CommitteeAssignment{
 			BeaconCommittees: comAssignment.Committee,
 			CommitteeIndex:   comAssignment.CommitteeIndex,
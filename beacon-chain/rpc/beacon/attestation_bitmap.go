@@ -0,0 +1,101 @@
+package beacon
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxAttestationBitmapEpochs bounds the epoch range of attestation bitmaps, about 2 months of
+// epochs in 2 KiB per bitmap.
+const maxAttestationBitmapEpochs = 1 << 14
+
+// AttestationBitmapRequest selects the validators and the epoch range of their attestation
+// bitmaps.
+type AttestationBitmapRequest struct {
+	ValidatorIndices []uint64 `json:"validator_indices"`
+	StartEpoch       uint64   `json:"start_epoch"`
+	// EndEpoch is inclusive.
+	EndEpoch uint64 `json:"end_epoch"`
+}
+
+// AttestationBitmap has a bit per epoch of the range, the bit of the start epoch being the
+// lowest bit of the first byte. The bitmaps are base64 encoded in JSON.
+type AttestationBitmap struct {
+	ValidatorIndex uint64 `json:"validator_index"`
+	// Included has the bits of the epochs whose attestation of the validator was included.
+	Included []byte `json:"included"`
+	// Recorded has the bits of the epochs with recorded rewards or penalties of the validator,
+	// the epochs it was expected to attest. Epochs not recorded are neither included nor
+	// missed.
+	Recorded []byte `json:"recorded"`
+}
+
+// AttestationBitmapResponse contains the attestation bitmaps of the requested validators.
+type AttestationBitmapResponse struct {
+	StartEpoch uint64               `json:"start_epoch"`
+	EndEpoch   uint64               `json:"end_epoch"`
+	Bitmaps    []*AttestationBitmap `json:"bitmaps"`
+}
+
+// GetAttestationBitmaps returns for every validator a bitmap of the epochs between the start and
+// end epochs whose attestation was included in the chain, so dashboards can draw the attestation
+// history of validators without replaying states. Inclusion is read from the ledger of
+// validator rewards recorded with --validator-accounting, the attestations of an epoch being
+// rewarded at the transition to the epoch after the next.
+func (bs *Server) GetAttestationBitmaps(ctx context.Context, req *AttestationBitmapRequest) (*AttestationBitmapResponse, error) {
+	if len(req.ValidatorIndices) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Must request at least one validator index")
+	}
+	if len(req.ValidatorIndices) > flags.Get().MaxPageSize {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Requested %d validators is more than the max allowed of %d",
+			len(req.ValidatorIndices),
+			flags.Get().MaxPageSize,
+		)
+	}
+	if req.StartEpoch > req.EndEpoch {
+		return nil, status.Errorf(codes.InvalidArgument, "Start epoch %d is after end epoch %d", req.StartEpoch, req.EndEpoch)
+	}
+	epochs := req.EndEpoch - req.StartEpoch + 1
+	if epochs > maxAttestationBitmapEpochs {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Requested %d epochs is more than the max allowed of %d",
+			epochs,
+			maxAttestationBitmapEpochs,
+		)
+	}
+
+	res := &AttestationBitmapResponse{
+		StartEpoch: req.StartEpoch,
+		EndEpoch:   req.EndEpoch,
+		Bitmaps:    make([]*AttestationBitmap, 0, len(req.ValidatorIndices)),
+	}
+	for _, idx := range req.ValidatorIndices {
+		rewards, err := bs.BeaconDB.ValidatorRewards(ctx, idx, req.StartEpoch+2, req.EndEpoch+2)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not retrieve rewards of validator %d: %v", idx, err)
+		}
+		bitmap := &AttestationBitmap{
+			ValidatorIndex: idx,
+			Included:       make([]byte, (epochs+7)/8),
+			Recorded:       make([]byte, (epochs+7)/8),
+		}
+		for epoch, r := range rewards {
+			if r.TotalReward() == 0 && r.TotalPenalty() == 0 {
+				continue
+			}
+			i := epoch - 2 - req.StartEpoch
+			bitmap.Recorded[i/8] |= 1 << (i % 8)
+			if r.SourceReward > 0 || r.InclusionDelayReward > 0 {
+				bitmap.Included[i/8] |= 1 << (i % 8)
+			}
+		}
+		res.Bitmaps = append(res.Bitmaps, bitmap)
+	}
+	return res, nil
+}
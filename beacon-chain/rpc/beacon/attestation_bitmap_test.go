@@ -0,0 +1,74 @@
+package beacon
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+)
+
+func TestServer_GetAttestationBitmaps(t *testing.T) {
+	previous := flags.Get()
+	flags.Init(&flags.GlobalFlags{MaxPageSize: 250})
+	defer flags.Init(previous)
+
+	db := dbTest.SetupDB(t)
+	defer dbTest.TeardownDB(t, db)
+	ctx := context.Background()
+
+	// Validator 0 attests every epoch but the attestations of epoch 5, validator 1 attests
+	// from epoch 10.
+	for epoch := uint64(2); epoch <= 13; epoch++ {
+		attested := &precompute.Rewards{SourceReward: 10, TargetReward: 10, InclusionDelayReward: 5}
+		missed := &precompute.Rewards{SourcePenalty: 10, TargetPenalty: 10, HeadPenalty: 10}
+		rewards := []*precompute.Rewards{attested, {}}
+		if epoch == 7 {
+			rewards[0] = missed
+		}
+		if epoch >= 12 {
+			rewards[1] = attested
+		}
+		if err := db.SaveValidatorRewards(ctx, epoch, rewards); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bs := &Server{BeaconDB: db}
+
+	res, err := bs.GetAttestationBitmaps(ctx, &AttestationBitmapRequest{
+		ValidatorIndices: []uint64{0, 1, 2},
+		StartEpoch:       1,
+		EndEpoch:         12,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wanted := []*AttestationBitmap{
+		// Epochs 1 to 11 are recorded, epoch 12 is not rewarded yet.
+		{ValidatorIndex: 0, Included: []byte{0xef, 0x07}, Recorded: []byte{0xff, 0x07}},
+		{ValidatorIndex: 1, Included: []byte{0x00, 0x06}, Recorded: []byte{0x00, 0x06}},
+		{ValidatorIndex: 2, Included: []byte{0x00, 0x00}, Recorded: []byte{0x00, 0x00}},
+	}
+	if !reflect.DeepEqual(wanted, res.Bitmaps) {
+		t.Errorf("Wanted %v, received %v", wanted, res.Bitmaps)
+	}
+
+	if _, err := bs.GetAttestationBitmaps(ctx, &AttestationBitmapRequest{
+		ValidatorIndices: []uint64{0},
+		StartEpoch:       5,
+		EndEpoch:         4,
+	}); err == nil {
+		t.Error("Expected error for start epoch after end epoch")
+	}
+	if _, err := bs.GetAttestationBitmaps(ctx, &AttestationBitmapRequest{
+		ValidatorIndices: []uint64{0},
+		EndEpoch:         maxAttestationBitmapEpochs,
+	}); err == nil {
+		t.Error("Expected error for too many epochs")
+	}
+	if _, err := bs.GetAttestationBitmaps(ctx, &AttestationBitmapRequest{EndEpoch: 4}); err == nil {
+		t.Error("Expected error without validator indices")
+	}
+}
@@ -0,0 +1,143 @@
+package beacon
+
+import (
+	"context"
+	"sort"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Kinds of pending attestations which can be listed from the pool.
+const (
+	PoolAttestationsAggregated   = "aggregated"
+	PoolAttestationsUnaggregated = "unaggregated"
+)
+
+// PoolAttestationsRequest lists the pending attestations of a kind, aggregated by default,
+// optionally filtered by slot and committee index.
+type PoolAttestationsRequest struct {
+	Kind           string  `json:"kind,omitempty"`
+	Slot           *uint64 `json:"slot,omitempty"`
+	CommitteeIndex *uint64 `json:"committee_index,omitempty"`
+}
+
+// PoolAttestationsResponse contains the pending attestations matching the request, ordered by
+// slot and committee index.
+type PoolAttestationsResponse struct {
+	Attestations []*ethpb.Attestation `json:"attestations"`
+}
+
+// PoolSlotStats counts the pending attestations of a slot.
+type PoolSlotStats struct {
+	Slot         uint64 `json:"slot"`
+	Aggregated   int    `json:"aggregated"`
+	Unaggregated int    `json:"unaggregated"`
+	Committees   int    `json:"committees"`
+	Attesters    uint64 `json:"attesters"`
+}
+
+// AttestationPoolStats summarizes the attestation pool, with the pending attestations counted
+// per slot from the most recent slot.
+type AttestationPoolStats struct {
+	Aggregated   int              `json:"aggregated"`
+	Unaggregated int              `json:"unaggregated"`
+	Block        int              `json:"block"`
+	Forkchoice   int              `json:"forkchoice"`
+	Slots        []*PoolSlotStats `json:"slots"`
+}
+
+// ListPoolAttestations retrieves the pending attestations of the pool which can be included in a
+// block, if aggregated, or are awaiting aggregation, if unaggregated.
+func (bs *Server) ListPoolAttestations(
+	ctx context.Context, req *PoolAttestationsRequest,
+) (*PoolAttestationsResponse, error) {
+	var atts []*ethpb.Attestation
+	switch req.Kind {
+	case "", PoolAttestationsAggregated:
+		atts = bs.AttestationsPool.AggregatedAttestations()
+	case PoolAttestationsUnaggregated:
+		atts = bs.AttestationsPool.UnaggregatedAttestations()
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "Unknown attestation kind %q, wanted %s or %s",
+			req.Kind, PoolAttestationsAggregated, PoolAttestationsUnaggregated)
+	}
+	filtered := make([]*ethpb.Attestation, 0, len(atts))
+	for _, att := range atts {
+		if att == nil || att.Data == nil {
+			continue
+		}
+		if req.Slot != nil && att.Data.Slot != *req.Slot {
+			continue
+		}
+		if req.CommitteeIndex != nil && att.Data.CommitteeIndex != *req.CommitteeIndex {
+			continue
+		}
+		filtered = append(filtered, att)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		if filtered[i].Data.Slot != filtered[j].Data.Slot {
+			return filtered[i].Data.Slot < filtered[j].Data.Slot
+		}
+		return filtered[i].Data.CommitteeIndex < filtered[j].Data.CommitteeIndex
+	})
+	return &PoolAttestationsResponse{
+		Attestations: filtered,
+	}, nil
+}
+
+// GetAttestationPoolStats retrieves the number of attestations of each part of the pool, and the
+// number of pending attestations, committees and attesters of each slot. A slot with few
+// attesters in the pool explains a proposal of that slot's successors containing few attestations.
+func (bs *Server) GetAttestationPoolStats(ctx context.Context) (*AttestationPoolStats, error) {
+	aggregated := bs.AttestationsPool.AggregatedAttestations()
+	unaggregated := bs.AttestationsPool.UnaggregatedAttestations()
+
+	slots := make(map[uint64]*PoolSlotStats)
+	committees := make(map[[2]uint64]bool)
+	record := func(att *ethpb.Attestation, isAggregated bool) {
+		if att == nil || att.Data == nil {
+			return
+		}
+		s, ok := slots[att.Data.Slot]
+		if !ok {
+			s = &PoolSlotStats{Slot: att.Data.Slot}
+			slots[att.Data.Slot] = s
+		}
+		if isAggregated {
+			s.Aggregated++
+		} else {
+			s.Unaggregated++
+		}
+		if att.AggregationBits != nil {
+			s.Attesters += att.AggregationBits.Count()
+		}
+		committee := [2]uint64{att.Data.Slot, att.Data.CommitteeIndex}
+		if !committees[committee] {
+			committees[committee] = true
+			s.Committees++
+		}
+	}
+	for _, att := range aggregated {
+		record(att, true)
+	}
+	for _, att := range unaggregated {
+		record(att, false)
+	}
+
+	res := &AttestationPoolStats{
+		Aggregated:   len(aggregated),
+		Unaggregated: len(unaggregated),
+		Block:        len(bs.AttestationsPool.BlockAttestations()),
+		Forkchoice:   len(bs.AttestationsPool.ForkchoiceAttestations()),
+		Slots:        make([]*PoolSlotStats, 0, len(slots)),
+	}
+	for _, s := range slots {
+		res.Slots = append(res.Slots, s)
+	}
+	sort.Slice(res.Slots, func(i, j int) bool {
+		return res.Slots[i].Slot > res.Slots[j].Slot
+	})
+	return res, nil
+}
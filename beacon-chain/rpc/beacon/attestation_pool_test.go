@@ -0,0 +1,118 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
+)
+
+func poolAttestation(slot uint64, committeeIndex uint64, bits bitfield.Bitlist) *ethpb.Attestation {
+	return &ethpb.Attestation{
+		AggregationBits: bits,
+		Data: &ethpb.AttestationData{
+			Slot:            slot,
+			CommitteeIndex:  committeeIndex,
+			BeaconBlockRoot: make([]byte, 32),
+			Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+		},
+		Signature: make([]byte, 96),
+	}
+}
+
+func TestServer_ListPoolAttestations(t *testing.T) {
+	pool := attestations.NewPool()
+	bs := &Server{
+		AttestationsPool: pool,
+	}
+	aggregated := []*ethpb.Attestation{
+		poolAttestation(2, 1, bitfield.Bitlist{0b1011}),
+		poolAttestation(1, 0, bitfield.Bitlist{0b1011}),
+		poolAttestation(2, 0, bitfield.Bitlist{0b1011}),
+	}
+	if err := pool.SaveAggregatedAttestations(aggregated); err != nil {
+		t.Fatal(err)
+	}
+	if err := pool.SaveUnaggregatedAttestation(poolAttestation(2, 1, bitfield.Bitlist{0b1001})); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := bs.ListPoolAttestations(context.Background(), &PoolAttestationsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Attestations) != 3 {
+		t.Fatalf("Expected 3 aggregated attestations, received %d", len(res.Attestations))
+	}
+	for i, want := range [][2]uint64{{1, 0}, {2, 0}, {2, 1}} {
+		data := res.Attestations[i].Data
+		if data.Slot != want[0] || data.CommitteeIndex != want[1] {
+			t.Errorf("Expected attestation %d of slot %d and committee %d, received slot %d and committee %d",
+				i, want[0], want[1], data.Slot, data.CommitteeIndex)
+		}
+	}
+
+	slot := uint64(2)
+	committeeIndex := uint64(1)
+	res, err = bs.ListPoolAttestations(context.Background(), &PoolAttestationsRequest{
+		Slot:           &slot,
+		CommitteeIndex: &committeeIndex,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Attestations) != 1 {
+		t.Errorf("Expected 1 aggregated attestation of slot 2 and committee 1, received %d", len(res.Attestations))
+	}
+
+	res, err = bs.ListPoolAttestations(context.Background(), &PoolAttestationsRequest{
+		Kind: PoolAttestationsUnaggregated,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Attestations) != 1 {
+		t.Errorf("Expected 1 unaggregated attestation, received %d", len(res.Attestations))
+	}
+
+	if _, err := bs.ListPoolAttestations(context.Background(), &PoolAttestationsRequest{Kind: "block"}); err == nil {
+		t.Error("Expected an error for an unknown attestation kind")
+	}
+}
+
+func TestServer_GetAttestationPoolStats(t *testing.T) {
+	pool := attestations.NewPool()
+	bs := &Server{
+		AttestationsPool: pool,
+	}
+	if err := pool.SaveAggregatedAttestations([]*ethpb.Attestation{
+		poolAttestation(1, 0, bitfield.Bitlist{0b1011}),
+		poolAttestation(2, 0, bitfield.Bitlist{0b1111}),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := pool.SaveUnaggregatedAttestation(poolAttestation(2, 1, bitfield.Bitlist{0b1001})); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := bs.GetAttestationPoolStats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Aggregated != 2 || res.Unaggregated != 1 {
+		t.Errorf("Expected 2 aggregated and 1 unaggregated attestations, received %d and %d", res.Aggregated, res.Unaggregated)
+	}
+	if len(res.Slots) != 2 {
+		t.Fatalf("Expected stats of 2 slots, received %d", len(res.Slots))
+	}
+	want := &PoolSlotStats{Slot: 2, Aggregated: 1, Unaggregated: 1, Committees: 2, Attesters: 4}
+	if *res.Slots[0] != *want {
+		t.Errorf("Expected stats %+v of the latest slot, received %+v", want, res.Slots[0])
+	}
+	if res.Slots[1].Slot != 1 || res.Slots[1].Attesters != 2 {
+		t.Errorf("Expected 2 attesters in slot 1, received %+v", res.Slots[1])
+	}
+}
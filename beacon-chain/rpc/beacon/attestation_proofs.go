@@ -0,0 +1,182 @@
+package beacon
+
+import (
+	"context"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AttestationInclusionProofRequest identifies the attestation to prove inclusion for,
+// either by the attestation itself or by the validator index and target epoch of one
+// of its attesters.
+type AttestationInclusionProofRequest struct {
+	// Attestation to look up. The included attestation must have the same data and
+	// cover all of its aggregation bits. If nil, ValidatorIndex and Epoch are used.
+	Attestation    *ethpb.Attestation `json:"attestation,omitempty"`
+	ValidatorIndex uint64             `json:"validator_index"`
+	Epoch          uint64             `json:"epoch"`
+}
+
+// AttestationInclusionProofResponse contains the canonical block which included the
+// requested attestation and a merkle proof of its inclusion in the block root.
+type AttestationInclusionProofResponse struct {
+	BlockRoot []byte `json:"block_root"`
+	BlockSlot uint64 `json:"block_slot"`
+	// AttestationIndex is the index of the attestation in the block body.
+	AttestationIndex uint64             `json:"attestation_index"`
+	Attestation      *ethpb.Attestation `json:"attestation"`
+	AttestationRoot  []byte             `json:"attestation_root"`
+	// Proof is the merkle branch from the attestation root up to the block root,
+	// verified by trieutil.VerifyMerkleBranch with MerkleIndex.
+	Proof       [][]byte `json:"proof"`
+	MerkleIndex uint64   `json:"merkle_index"`
+}
+
+// AttestationInclusionProof finds the earliest canonical block including the requested
+// attestation and returns a merkle proof of the attestation's inclusion in that block.
+// Only blocks in the inclusion window of the attestation, within the block roots
+// history of the head state, are searched.
+func (bs *Server) AttestationInclusionProof(
+	ctx context.Context, req *AttestationInclusionProofRequest,
+) (*AttestationInclusionProofResponse, error) {
+	var attSlot uint64
+	var matches func(att *ethpb.Attestation) bool
+	if req.Attestation != nil {
+		if req.Attestation.Data == nil {
+			return nil, status.Error(codes.InvalidArgument, "Attestation data is nil")
+		}
+		dataRoot, err := ssz.HashTreeRoot(req.Attestation.Data)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Could not hash attestation data: %v", err)
+		}
+		attSlot = req.Attestation.Data.Slot
+		matches = func(att *ethpb.Attestation) bool {
+			if att.Data.Slot != attSlot || att.AggregationBits.Len() != req.Attestation.AggregationBits.Len() {
+				return false
+			}
+			root, err := ssz.HashTreeRoot(att.Data)
+			return err == nil && root == dataRoot && att.AggregationBits.Contains(req.Attestation.AggregationBits)
+		}
+	} else {
+		committeesBySlot, _, err := bs.retrieveCommitteesForEpoch(ctx, req.Epoch)
+		if err != nil {
+			return nil, err
+		}
+		committeeIndex, position, slot, found := committeePositionOf(committeesBySlot, req.ValidatorIndex)
+		if !found {
+			return nil, status.Errorf(
+				codes.NotFound,
+				"Validator %d is not in any committee for epoch %d",
+				req.ValidatorIndex,
+				req.Epoch,
+			)
+		}
+		attSlot = slot
+		matches = func(att *ethpb.Attestation) bool {
+			return att.Data.Slot == attSlot &&
+				att.Data.CommitteeIndex == committeeIndex &&
+				position < att.AggregationBits.Len() &&
+				att.AggregationBits.BitAt(position)
+		}
+	}
+
+	headState, err := bs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
+	}
+	headRoot, err := bs.HeadFetcher.HeadRoot(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get head root: %v", err)
+	}
+
+	var prevRoot []byte
+	lastSlot := attSlot + params.BeaconConfig().SlotsPerEpoch
+	for slot := attSlot + params.BeaconConfig().MinAttestationInclusionDelay; slot <= lastSlot && slot <= headState.Slot(); slot++ {
+		root, err := canonicalBlockRootAtSlot(headState, headRoot, slot)
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "Could not get canonical block root at slot %d: %v", slot, err)
+		}
+		// Skipped slots repeat the root of the previous block.
+		if bytesutil.ToBytes32(root) == bytesutil.ToBytes32(prevRoot) {
+			continue
+		}
+		prevRoot = root
+		blk, err := bs.BeaconDB.Block(ctx, bytesutil.ToBytes32(root))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get block at slot %d: %v", slot, err)
+		}
+		if blk == nil || blk.Block == nil || blk.Block.Body == nil {
+			continue
+		}
+		for i, att := range blk.Block.Body.Attestations {
+			if att == nil || att.Data == nil || !matches(att) {
+				continue
+			}
+			return attestationInclusionProof(blk.Block, root, uint64(i))
+		}
+	}
+	return nil, status.Error(codes.NotFound, "Attestation is not included in any canonical block")
+}
+
+// attestationInclusionProof builds the response for the attestation at the given index
+// of the block body.
+func attestationInclusionProof(
+	blk *ethpb.BeaconBlock, blockRoot []byte, index uint64,
+) (*AttestationInclusionProofResponse, error) {
+	att := blk.Body.Attestations[index]
+	attRoot, err := ssz.HashTreeRoot(att)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not hash attestation: %v", err)
+	}
+	proof, merkleIndex, err := stateutil.AttestationInclusionProof(blk, index)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not compute inclusion proof: %v", err)
+	}
+	branch := make([][]byte, len(proof))
+	for i := range proof {
+		branch[i] = bytesutil.SafeCopyBytes(proof[i][:])
+	}
+	return &AttestationInclusionProofResponse{
+		BlockRoot:        blockRoot,
+		BlockSlot:        blk.Slot,
+		AttestationIndex: index,
+		Attestation:      att,
+		AttestationRoot:  attRoot[:],
+		Proof:            branch,
+		MerkleIndex:      merkleIndex,
+	}, nil
+}
+
+// committeePositionOf returns the committee index, position in the committee and slot
+// of the committee containing the validator.
+func committeePositionOf(
+	committeesBySlot map[uint64]*ethpb.BeaconCommittees_CommitteesList, validatorIndex uint64,
+) (uint64, uint64, uint64, bool) {
+	for slot, list := range committeesBySlot {
+		for committeeIndex, committee := range list.Committees {
+			for position, index := range committee.ValidatorIndices {
+				if index == validatorIndex {
+					return uint64(committeeIndex), uint64(position), slot, true
+				}
+			}
+		}
+	}
+	return 0, 0, 0, false
+}
+
+// canonicalBlockRootAtSlot returns the root of the latest block at or before the slot in
+// the chain of the head state.
+func canonicalBlockRootAtSlot(headState *stateTrie.BeaconState, headRoot []byte, slot uint64) ([]byte, error) {
+	if slot == headState.Slot() {
+		return headRoot, nil
+	}
+	return helpers.BlockRootAtSlot(headState, slot)
+}
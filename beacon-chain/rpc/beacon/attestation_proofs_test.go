@@ -0,0 +1,110 @@
+package beacon
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/go-ssz"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
+)
+
+func TestServer_AttestationInclusionProof(t *testing.T) {
+	db := dbTest.SetupDB(t)
+	defer dbTest.TeardownDB(t, db)
+	ctx := context.Background()
+
+	att := &ethpb.Attestation{
+		AggregationBits: bitfield.Bitlist{0b1101},
+		Data: &ethpb.AttestationData{
+			Slot:            1,
+			CommitteeIndex:  0,
+			BeaconBlockRoot: make([]byte, 32),
+			Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+		},
+		Signature: make([]byte, 96),
+	}
+	otherAtt := proto.Clone(att).(*ethpb.Attestation)
+	otherAtt.Data.CommitteeIndex = 1
+	blk := &ethpb.SignedBeaconBlock{
+		Block: &ethpb.BeaconBlock{
+			Slot:       2,
+			ParentRoot: make([]byte, 32),
+			StateRoot:  make([]byte, 32),
+			Body: &ethpb.BeaconBlockBody{
+				RandaoReveal: make([]byte, 96),
+				Eth1Data:     &ethpb.Eth1Data{DepositRoot: make([]byte, 32), BlockHash: make([]byte, 32)},
+				Graffiti:     make([]byte, 32),
+				Attestations: []*ethpb.Attestation{otherAtt, att},
+			},
+		},
+	}
+	if err := db.SaveBlock(ctx, blk); err != nil {
+		t.Fatal(err)
+	}
+	blkRoot, err := ssz.HashTreeRoot(blk.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blockRoots := make([][]byte, params.BeaconConfig().SlotsPerHistoricalRoot)
+	for i := range blockRoots {
+		blockRoots[i] = make([]byte, 32)
+	}
+	headState, err := stateTrie.InitializeFromProto(&pbp2p.BeaconState{
+		Slot:       2,
+		BlockRoots: blockRoots,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs := &Server{
+		BeaconDB:    db,
+		HeadFetcher: &mock.ChainService{State: headState, Root: blkRoot[:]},
+	}
+
+	// Request a subset of the aggregation bits of the included attestation.
+	req := proto.Clone(att).(*ethpb.Attestation)
+	req.AggregationBits = bitfield.Bitlist{0b1001}
+	res, err := bs.AttestationInclusionProof(ctx, &AttestationInclusionProofRequest{Attestation: req})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.BlockSlot != 2 || res.AttestationIndex != 1 {
+		t.Errorf("Wanted attestation 1 of block at slot 2, received attestation %d of block at slot %d", res.AttestationIndex, res.BlockSlot)
+	}
+	if !trieutil.VerifyMerkleBranch(blkRoot[:], res.AttestationRoot, int(res.MerkleIndex), res.Proof) {
+		t.Error("Inclusion proof did not verify against the block root")
+	}
+
+	req.Data.Slot = 0
+	if _, err := bs.AttestationInclusionProof(ctx, &AttestationInclusionProofRequest{Attestation: req}); err == nil ||
+		!strings.Contains(err.Error(), "not included") {
+		t.Errorf("Expected not included error, received %v", err)
+	}
+}
+
+func TestCommitteePositionOf(t *testing.T) {
+	committees := map[uint64]*ethpb.BeaconCommittees_CommitteesList{
+		4: {Committees: []*ethpb.BeaconCommittees_CommitteeItem{
+			{ValidatorIndices: []uint64{1, 2}},
+			{ValidatorIndices: []uint64{3, 4, 5}},
+		}},
+	}
+	committeeIndex, position, slot, found := committeePositionOf(committees, 5)
+	if !found || committeeIndex != 1 || position != 2 || slot != 4 {
+		t.Errorf("Wanted committee 1 position 2 at slot 4, received committee %d position %d at slot %d (found %v)",
+			committeeIndex, position, slot, found)
+	}
+	if _, _, _, found := committeePositionOf(committees, 6); found {
+		t.Error("Expected validator 6 to not be found")
+	}
+}
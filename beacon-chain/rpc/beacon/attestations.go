@@ -56,7 +56,7 @@ func (bs *Server) ListAttestations(
 		}
 		genesisRoot, err := ssz.HashTreeRoot(genBlk.Block)
 		if err != nil {
-			return nil, err
+			return nil, status.Errorf(codes.Internal, "Could not hash genesis block: %v", err)
 		}
 		atts, err = bs.BeaconDB.Attestations(ctx, filters.NewFilter().SetHeadBlockRoot(genesisRoot[:]))
 		if err != nil {
@@ -0,0 +1,169 @@
+package beacon
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/shared/statusutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Supported downsampling methods of a balance history. Without downsampling, each point is the
+// balance at the first epoch of its step.
+const (
+	BalanceDownsampleNone = ""
+	BalanceDownsampleMin  = "min"
+	BalanceDownsampleMax  = "max"
+	BalanceDownsampleAvg  = "avg"
+)
+
+// maxDownsampledEpochs bounds the number of epochs of archived balances read by a downsampled
+// balance history, about 2 months of epochs.
+const maxDownsampledEpochs = 1 << 14
+
+// BalanceHistoryRequest selects the validator, the epoch range and the resolution of a
+// balance history.
+type BalanceHistoryRequest struct {
+	ValidatorIndex uint64 `json:"validator_index"`
+	StartEpoch     uint64 `json:"start_epoch"`
+	// EndEpoch is inclusive.
+	EndEpoch uint64 `json:"end_epoch"`
+	// Step is the number of epochs between points, 1 by default.
+	Step uint64 `json:"step"`
+	// Downsample aggregates the balances of every epoch within a step into its point.
+	Downsample string `json:"downsample,omitempty"`
+}
+
+// BalancePoint is the balance of a validator at an epoch, in Gwei.
+type BalancePoint struct {
+	Epoch   uint64 `json:"epoch"`
+	Balance uint64 `json:"balance"`
+}
+
+// BalanceHistoryResponse contains the balance history of a validator.
+type BalanceHistoryResponse struct {
+	ValidatorIndex uint64          `json:"validator_index"`
+	Points         []*BalancePoint `json:"points"`
+}
+
+// GetBalanceHistory returns the balance of a validator at every step epochs between the start
+// and end epochs, read from the archived balances, for charting balances over long ranges. With
+// downsampling, the balances of every archived epoch within a step are aggregated into its point
+// instead. Epochs without archived balances, or at which the validator was not deposited yet,
+// are left out.
+func (bs *Server) GetBalanceHistory(ctx context.Context, req *BalanceHistoryRequest) (*BalanceHistoryResponse, error) {
+	step := req.Step
+	if step == 0 {
+		step = 1
+	}
+	switch req.Downsample {
+	case BalanceDownsampleNone, BalanceDownsampleMin, BalanceDownsampleMax, BalanceDownsampleAvg:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "Unknown downsampling method %q", req.Downsample)
+	}
+	if req.StartEpoch > req.EndEpoch {
+		return nil, status.Errorf(codes.InvalidArgument, "Start epoch %d is after end epoch %d", req.StartEpoch, req.EndEpoch)
+	}
+	if points := (req.EndEpoch-req.StartEpoch)/step + 1; points > uint64(flags.Get().MaxPageSize) {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Requested %d points is more than the max allowed of %d, use a larger step",
+			points,
+			flags.Get().MaxPageSize,
+		)
+	}
+	if epochs := req.EndEpoch - req.StartEpoch + 1; req.Downsample != BalanceDownsampleNone && epochs > maxDownsampledEpochs {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Requested %d epochs to downsample is more than the max allowed of %d",
+			epochs,
+			maxDownsampledEpochs,
+		)
+	}
+
+	headState, err := bs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
+	}
+	currentEpoch := helpers.CurrentEpoch(headState)
+	if req.EndEpoch > currentEpoch {
+		return nil, statusutil.FutureEpoch(currentEpoch, req.EndEpoch)
+	}
+
+	// The balance at the current epoch is read once, rather than copying the balances of the
+	// head state for every point.
+	var headBalance uint64
+	headBalanceKnown := req.ValidatorIndex < uint64(headState.BalancesLength())
+	if headBalanceKnown {
+		headBalance, err = headState.BalanceAtIndex(req.ValidatorIndex)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get balance of validator %d: %v", req.ValidatorIndex, err)
+		}
+	}
+
+	// balanceAt returns the balance of the validator at the epoch, if it is known.
+	balanceAt := func(epoch uint64) (uint64, bool, error) {
+		if epoch >= currentEpoch {
+			return headBalance, headBalanceKnown, nil
+		}
+		balances, err := bs.BeaconDB.ArchivedBalances(ctx, epoch)
+		if err != nil {
+			return 0, false, status.Errorf(codes.Internal, "Could not retrieve balances for epoch %d: %v", epoch, err)
+		}
+		if req.ValidatorIndex >= uint64(len(balances)) {
+			return 0, false, nil
+		}
+		return balances[req.ValidatorIndex], true, nil
+	}
+
+	res := &BalanceHistoryResponse{
+		ValidatorIndex: req.ValidatorIndex,
+		Points:         make([]*BalancePoint, 0),
+	}
+	for epoch := req.StartEpoch; ; epoch += step {
+		if ctx.Err() != nil {
+			return nil, status.Errorf(codes.Canceled, "Balance history canceled: %v", ctx.Err())
+		}
+		// Without downsampling, only the first epoch of the step is read.
+		last := epoch
+		if req.Downsample != BalanceDownsampleNone {
+			last = req.EndEpoch
+			if req.EndEpoch-epoch >= step {
+				last = epoch + step - 1
+			}
+		}
+		var aggregate, sum, count uint64
+		for e := epoch; e <= last; e++ {
+			balance, ok, err := balanceAt(e)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			switch {
+			case count == 0:
+				aggregate = balance
+			case req.Downsample == BalanceDownsampleMin && balance < aggregate:
+				aggregate = balance
+			case req.Downsample == BalanceDownsampleMax && balance > aggregate:
+				aggregate = balance
+			}
+			sum += balance
+			count++
+		}
+		if count > 0 {
+			if req.Downsample == BalanceDownsampleAvg {
+				aggregate = sum / count
+			}
+			res.Points = append(res.Points, &BalancePoint{Epoch: epoch, Balance: aggregate})
+		}
+		// Checked before incrementing the epoch so large steps can not overflow it.
+		if req.EndEpoch-epoch < step {
+			break
+		}
+	}
+	return res, nil
+}
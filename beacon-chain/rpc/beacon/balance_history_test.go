@@ -0,0 +1,132 @@
+package beacon
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func balanceHistoryServer(t *testing.T) (*Server, func()) {
+	db := dbTest.SetupDB(t)
+	ctx := context.Background()
+	// Validator 1 is deposited at epoch 2, epoch 5 is not archived.
+	archived := map[uint64][]uint64{
+		0: {32e9},
+		1: {31e9},
+		2: {33e9, 32e9},
+		3: {30e9, 32e9},
+		4: {34e9, 32e9},
+	}
+	for epoch, balances := range archived {
+		if err := db.SaveArchivedBalances(ctx, epoch, balances); err != nil {
+			t.Fatal(err)
+		}
+	}
+	headState, err := stateTrie.InitializeFromProto(&pbp2p.BeaconState{
+		Slot:     6 * params.BeaconConfig().SlotsPerEpoch,
+		Balances: []uint64{35e9, 32e9},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs := &Server{
+		BeaconDB:    db,
+		HeadFetcher: &mock.ChainService{State: headState},
+	}
+	return bs, func() { dbTest.TeardownDB(t, db) }
+}
+
+func TestServer_GetBalanceHistory(t *testing.T) {
+	bs, teardown := balanceHistoryServer(t)
+	defer teardown()
+
+	tests := []struct {
+		name   string
+		req    *BalanceHistoryRequest
+		epochs []uint64
+		wanted []uint64
+	}{
+		{
+			name:   "every epoch",
+			req:    &BalanceHistoryRequest{StartEpoch: 0, EndEpoch: 6},
+			epochs: []uint64{0, 1, 2, 3, 4, 6},
+			wanted: []uint64{32e9, 31e9, 33e9, 30e9, 34e9, 35e9},
+		},
+		{
+			name:   "every other epoch",
+			req:    &BalanceHistoryRequest{StartEpoch: 0, EndEpoch: 6, Step: 2},
+			epochs: []uint64{0, 2, 4, 6},
+			wanted: []uint64{32e9, 33e9, 34e9, 35e9},
+		},
+		{
+			name:   "min",
+			req:    &BalanceHistoryRequest{StartEpoch: 0, EndEpoch: 6, Step: 3, Downsample: BalanceDownsampleMin},
+			epochs: []uint64{0, 3, 6},
+			wanted: []uint64{31e9, 30e9, 35e9},
+		},
+		{
+			name:   "max",
+			req:    &BalanceHistoryRequest{StartEpoch: 0, EndEpoch: 6, Step: 3, Downsample: BalanceDownsampleMax},
+			epochs: []uint64{0, 3, 6},
+			wanted: []uint64{33e9, 34e9, 35e9},
+		},
+		{
+			name:   "avg",
+			req:    &BalanceHistoryRequest{StartEpoch: 0, EndEpoch: 6, Step: 3, Downsample: BalanceDownsampleAvg},
+			epochs: []uint64{0, 3, 6},
+			wanted: []uint64{32e9, 32e9, 35e9},
+		},
+		{
+			name:   "not yet deposited",
+			req:    &BalanceHistoryRequest{ValidatorIndex: 1, StartEpoch: 0, EndEpoch: 3},
+			epochs: []uint64{2, 3},
+			wanted: []uint64{32e9, 32e9},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := bs.GetBalanceHistory(context.Background(), tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(res.Points) != len(tt.wanted) {
+				t.Fatalf("Wanted %d points, got %d", len(tt.wanted), len(res.Points))
+			}
+			for i, point := range res.Points {
+				if point.Epoch != tt.epochs[i] || point.Balance != tt.wanted[i] {
+					t.Errorf("Wanted balance %d at epoch %d, got %d at epoch %d", tt.wanted[i], tt.epochs[i], point.Balance, point.Epoch)
+				}
+			}
+		})
+	}
+}
+
+func TestServer_GetBalanceHistory_Errors(t *testing.T) {
+	bs, teardown := balanceHistoryServer(t)
+	defer teardown()
+
+	tests := []struct {
+		name    string
+		req     *BalanceHistoryRequest
+		wantErr string
+	}{
+		{name: "future epoch", req: &BalanceHistoryRequest{EndEpoch: 7}, wantErr: "epoch in the future"},
+		{name: "reversed range", req: &BalanceHistoryRequest{StartEpoch: 3, EndEpoch: 2}, wantErr: "is after end epoch"},
+		{name: "too many points", req: &BalanceHistoryRequest{EndEpoch: 1000}, wantErr: "more than the max allowed"},
+		{name: "unknown downsampling", req: &BalanceHistoryRequest{EndEpoch: 6, Downsample: "median"}, wantErr: "Unknown downsampling method"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := bs.GetBalanceHistory(context.Background(), tt.req)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, received %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package beacon
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	cache "github.com/patrickmn/go-cache"
+	"github.com/pkg/errors"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// snapshotTokenSeparator separates the page from the snapshot root in a snapshot page token.
+const snapshotTokenSeparator = ":"
+
+// ValidatorSnapshots pins the head state a paginated listing of validator balances started
+// from, by its block root, so the following pages are read from the same state even if the
+// head moves in between. A snapshot expires after two epochs without a page being requested.
+type ValidatorSnapshots struct {
+	states *cache.Cache
+}
+
+// NewValidatorSnapshots initializes the snapshots of validator balance listings.
+func NewValidatorSnapshots() *ValidatorSnapshots {
+	epochDuration := time.Duration(params.BeaconConfig().SecondsPerSlot*params.BeaconConfig().SlotsPerEpoch) * time.Second
+	return &ValidatorSnapshots{
+		states: cache.New(2*epochDuration, epochDuration),
+	}
+}
+
+// get returns the pinned state of the root and extends its expiry, or nil if it expired.
+func (v *ValidatorSnapshots) get(root [32]byte) *stateTrie.BeaconState {
+	key := string(root[:])
+	item, ok := v.states.Get(key)
+	if !ok {
+		return nil
+	}
+	st := item.(*stateTrie.BeaconState)
+	v.states.Set(key, st, cache.DefaultExpiration)
+	return st
+}
+
+// put pins the state of the root.
+func (v *ValidatorSnapshots) put(root [32]byte, st *stateTrie.BeaconState) {
+	v.states.Set(string(root[:]), st, cache.DefaultExpiration)
+}
+
+// snapshotNextPageToken returns the next page token of a listing read from the state, carrying
+// the root of the snapshot the next page is read from. The state of the first page is pinned by
+// its head block root, unless snapshots are disabled and the next page is read from the head.
+func (bs *Server) snapshotNextPageToken(
+	ctx context.Context,
+	st *stateTrie.BeaconState,
+	nextPageToken string,
+	root [32]byte,
+	pinned bool,
+) (string, error) {
+	if nextPageToken == "" || (!pinned && bs.ValidatorSnapshots == nil) {
+		return nextPageToken, nil
+	}
+	if !pinned {
+		headRoot, err := bs.HeadFetcher.HeadRoot(ctx)
+		if err != nil {
+			return "", errors.Wrap(err, "could not get head root")
+		}
+		root = bytesutil.ToBytes32(headRoot)
+		bs.ValidatorSnapshots.put(root, st)
+	}
+	return snapshotPageToken(nextPageToken, root), nil
+}
+
+// snapshotPageToken appends the snapshot root to a page token, leaving the empty token of the
+// last page as is.
+func snapshotPageToken(pageToken string, root [32]byte) string {
+	if pageToken == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s%s%x", pageToken, snapshotTokenSeparator, root)
+}
+
+// parseSnapshotPageToken splits a page token into the page and the snapshot root, if any.
+func parseSnapshotPageToken(pageToken string) (string, [32]byte, bool, error) {
+	parts := strings.Split(pageToken, snapshotTokenSeparator)
+	if len(parts) == 1 {
+		return pageToken, [32]byte{}, false, nil
+	}
+	if len(parts) != 2 {
+		return "", [32]byte{}, false, errors.Errorf("invalid page token %s", pageToken)
+	}
+	rootBytes, err := hex.DecodeString(parts[1])
+	if err != nil || len(rootBytes) != 32 {
+		return "", [32]byte{}, false, errors.Errorf("invalid snapshot root in page token %s", pageToken)
+	}
+	var root [32]byte
+	copy(root[:], rootBytes)
+	return parts[0], root, true, nil
+}
@@ -0,0 +1,94 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServer_ListValidatorBalances_SnapshotAcrossPages(t *testing.T) {
+	db := dbTest.SetupDB(t)
+	defer dbTest.TeardownDB(t, db)
+	setupValidators(t, db, 100)
+	ctx := context.Background()
+	headState, err := db.HeadState(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	headRoot := [32]byte{'a'}
+	chain := &mock.ChainService{State: headState, Root: headRoot[:]}
+	bs := &Server{
+		HeadFetcher:        chain,
+		ValidatorSnapshots: NewValidatorSnapshots(),
+	}
+
+	res, err := bs.ListValidatorBalances(ctx, &ethpb.ListValidatorBalancesRequest{PageSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantToken := fmt.Sprintf("1:%x", headRoot)
+	if res.NextPageToken != wantToken {
+		t.Fatalf("Wanted next page token %s, received %s", wantToken, res.NextPageToken)
+	}
+
+	// The head moves and the balances change before the next page is requested.
+	newState := headState.Copy()
+	if err := newState.UpdateBalancesAtIndex(10, 1e9); err != nil {
+		t.Fatal(err)
+	}
+	chain.State = newState
+	chain.Root = []byte{'b'}
+
+	res, err = bs.ListValidatorBalances(ctx, &ethpb.ListValidatorBalancesRequest{
+		PageToken: res.NextPageToken,
+		PageSize:  10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Balances[0].Index != 10 || res.Balances[0].Balance != 10 {
+		t.Errorf("Wanted the balance of the snapshot, received %v", res.Balances[0])
+	}
+	if wantToken = fmt.Sprintf("2:%x", headRoot); res.NextPageToken != wantToken {
+		t.Errorf("Wanted next page token %s, received %s", wantToken, res.NextPageToken)
+	}
+
+	// The last page has no next page token.
+	res, err = bs.ListValidatorBalances(ctx, &ethpb.ListValidatorBalancesRequest{
+		PageToken: fmt.Sprintf("9:%x", headRoot),
+		PageSize:  10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.NextPageToken != "" {
+		t.Errorf("Wanted no next page token, received %s", res.NextPageToken)
+	}
+
+	// A page without a snapshot is read from the new head.
+	res, err = bs.ListValidatorBalances(ctx, &ethpb.ListValidatorBalancesRequest{PageToken: "1", PageSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Balances[0].Balance != 1e9 {
+		t.Errorf("Wanted the balance of the head, received %v", res.Balances[0])
+	}
+
+	_, err = bs.ListValidatorBalances(ctx, &ethpb.ListValidatorBalancesRequest{
+		PageToken: fmt.Sprintf("1:%x", [32]byte{'c'}),
+		PageSize:  10,
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected an unknown snapshot to be rejected, received %v", err)
+	}
+	_, err = bs.ListValidatorBalances(ctx, &ethpb.ListValidatorBalancesRequest{PageToken: "1:zz", PageSize: 10})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected an invalid page token to be rejected, received %v", err)
+	}
+}
@@ -0,0 +1,190 @@
+package beacon
+
+import (
+	"context"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/attestationutil"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/mathutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BlockRewardsRequest selects the block by its root.
+type BlockRewardsRequest struct {
+	BlockRoot []byte `json:"block_root"`
+}
+
+// BlockRewards is the reward in Gwei a block earned its proposer. Attestation rewards are paid
+// at the transition to the epoch after the target epoch of the attestations, and are computed
+// with the balances of the pre-state of the block.
+type BlockRewards struct {
+	BlockRoot     []byte `json:"block_root"`
+	Slot          uint64 `json:"slot"`
+	ProposerIndex uint64 `json:"proposer_index"`
+	// Attestations is the reward for the attesters the block is the first to include.
+	Attestations uint64 `json:"attestations"`
+	// NewAttesters is the number of attesters the block is the first to include.
+	NewAttesters      uint64 `json:"new_attesters"`
+	ProposerSlashings uint64 `json:"proposer_slashings"`
+	AttesterSlashings uint64 `json:"attester_slashings"`
+	Total             uint64 `json:"total"`
+}
+
+// GetBlockRewards returns the rewards a block earned its proposer for including attestations
+// and slashings, for explorers displaying the income of proposers per block. The slashings of
+// the block are processed again against its pre-state, and its attestations are compared with
+// the attestations already included by its ancestors.
+func (bs *Server) GetBlockRewards(ctx context.Context, req *BlockRewardsRequest) (*BlockRewards, error) {
+	if len(req.BlockRoot) != 32 {
+		return nil, status.Errorf(codes.InvalidArgument, "Block root must be 32 bytes, received %d", len(req.BlockRoot))
+	}
+	blk, err := bs.BeaconDB.Block(ctx, bytesutil.ToBytes32(req.BlockRoot))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not retrieve block: %v", err)
+	}
+	if blk == nil || blk.Block == nil {
+		return nil, status.Errorf(codes.NotFound, "Block %#x not found", req.BlockRoot)
+	}
+	if blk.Block.Slot == 0 {
+		return nil, status.Error(codes.InvalidArgument, "The genesis block has no rewards")
+	}
+	preState, err := bs.BeaconDB.State(ctx, bytesutil.ToBytes32(blk.Block.ParentRoot))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not retrieve pre-state: %v", err)
+	}
+	if preState == nil {
+		return nil, status.Errorf(codes.NotFound, "Pre-state of block %#x is not in the database", req.BlockRoot)
+	}
+	preState, err = state.ProcessSlots(ctx, preState, blk.Block.Slot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not process slots up to %d: %v", blk.Block.Slot, err)
+	}
+	proposerIndex, err := helpers.BeaconProposerIndex(preState)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get proposer index: %v", err)
+	}
+
+	res := &BlockRewards{
+		BlockRoot:     req.BlockRoot,
+		Slot:          blk.Block.Slot,
+		ProposerIndex: proposerIndex,
+	}
+	res.Attestations, res.NewAttesters, err = attestationInclusionRewards(preState, blk.Block.Body.Attestations)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not compute attestation rewards: %v", err)
+	}
+	res.ProposerSlashings, err = slashingRewards(ctx, preState, proposerIndex, &ethpb.BeaconBlockBody{
+		ProposerSlashings: blk.Block.Body.ProposerSlashings,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not compute proposer slashing rewards: %v", err)
+	}
+	res.AttesterSlashings, err = slashingRewards(ctx, preState, proposerIndex, &ethpb.BeaconBlockBody{
+		AttesterSlashings: blk.Block.Body.AttesterSlashings,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not compute attester slashing rewards: %v", err)
+	}
+	res.Total = res.Attestations + res.ProposerSlashings + res.AttesterSlashings
+	return res, nil
+}
+
+// attestationInclusionRewards returns the proposer reward of the attestations for the unslashed
+// attesters not included in the pending attestations of the state yet, and their number. The
+// earliest inclusion of an attester rewards its proposer.
+func attestationInclusionRewards(st *stateTrie.BeaconState, atts []*ethpb.Attestation) (uint64, uint64, error) {
+	included := make(map[uint64]map[uint64]bool)
+	markIncluded := func(data *ethpb.AttestationData, bits bitfield.Bitlist) ([]uint64, error) {
+		committee, err := helpers.BeaconCommitteeFromState(st, data.Slot, data.CommitteeIndex)
+		if err != nil {
+			return nil, err
+		}
+		indices, err := attestationutil.AttestingIndices(bits, committee)
+		if err != nil {
+			return nil, err
+		}
+		if included[data.Target.Epoch] == nil {
+			included[data.Target.Epoch] = make(map[uint64]bool)
+		}
+		var newIndices []uint64
+		for _, idx := range indices {
+			if !included[data.Target.Epoch][idx] {
+				included[data.Target.Epoch][idx] = true
+				newIndices = append(newIndices, idx)
+			}
+		}
+		return newIndices, nil
+	}
+	pending := append(st.PreviousEpochAttestations(), st.CurrentEpochAttestations()...)
+	for _, pa := range pending {
+		if _, err := markIncluded(pa.Data, pa.AggregationBits); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	totalBalance, err := helpers.TotalActiveBalance(st)
+	if err != nil {
+		return 0, 0, err
+	}
+	sqrtBalance := mathutil.IntegerSquareRoot(totalBalance)
+	var reward, attesters uint64
+	for _, att := range atts {
+		if att == nil || att.Data == nil || att.Data.Target == nil {
+			continue
+		}
+		indices, err := markIncluded(att.Data, att.AggregationBits)
+		if err != nil {
+			return 0, 0, err
+		}
+		for _, idx := range indices {
+			val, err := st.ValidatorAtIndexReadOnly(idx)
+			if err != nil {
+				return 0, 0, err
+			}
+			if val.Slashed() {
+				continue
+			}
+			baseReward := val.EffectiveBalance() * params.BeaconConfig().BaseRewardFactor /
+				sqrtBalance / params.BeaconConfig().BaseRewardsPerEpoch
+			reward += baseReward / params.BeaconConfig().ProposerRewardQuotient
+			attesters++
+		}
+	}
+	return reward, attesters, nil
+}
+
+// slashingRewards processes the slashings of the body against a copy of the state, and returns
+// the balance the proposer earned.
+func slashingRewards(ctx context.Context, st *stateTrie.BeaconState, proposerIndex uint64, body *ethpb.BeaconBlockBody) (uint64, error) {
+	if len(body.ProposerSlashings) == 0 && len(body.AttesterSlashings) == 0 {
+		return 0, nil
+	}
+	before, err := st.BalanceAtIndex(proposerIndex)
+	if err != nil {
+		return 0, err
+	}
+	post, err := blocks.ProcessProposerSlashings(ctx, st.Copy(), body)
+	if err != nil {
+		return 0, err
+	}
+	post, err = blocks.ProcessAttesterSlashings(ctx, post, body)
+	if err != nil {
+		return 0, err
+	}
+	after, err := post.BalanceAtIndex(proposerIndex)
+	if err != nil {
+		return 0, err
+	}
+	if after < before {
+		return 0, nil
+	}
+	return after - before, nil
+}
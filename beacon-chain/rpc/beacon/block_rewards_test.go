@@ -0,0 +1,97 @@
+package beacon
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/shared/mathutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestServer_GetBlockRewards(t *testing.T) {
+	db := dbTest.SetupDB(t)
+	defer dbTest.TeardownDB(t, db)
+	ctx := context.Background()
+
+	numValidators := uint64(64)
+	genesis, _ := testutil.DeterministicGenesisState(t, numValidators)
+	parentRoot := [32]byte{'p'}
+	if err := db.SaveState(ctx, genesis, parentRoot); err != nil {
+		t.Fatal(err)
+	}
+	committee, err := helpers.BeaconCommitteeFromState(genesis, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bits := bitfield.NewBitlist(uint64(len(committee)))
+	for i := range committee {
+		bits.SetBitAt(uint64(i), true)
+	}
+	att := &ethpb.Attestation{
+		Data: &ethpb.AttestationData{
+			BeaconBlockRoot: make([]byte, 32),
+			Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+		},
+		AggregationBits: bits,
+	}
+	blk := &ethpb.SignedBeaconBlock{
+		Block: &ethpb.BeaconBlock{
+			Slot:       1,
+			ParentRoot: parentRoot[:],
+			Body:       &ethpb.BeaconBlockBody{Attestations: []*ethpb.Attestation{att, att}},
+		},
+	}
+	if err := db.SaveBlock(ctx, blk); err != nil {
+		t.Fatal(err)
+	}
+	blkRoot, err := ssz.HashTreeRoot(blk.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bs := &Server{BeaconDB: db}
+	res, err := bs.GetBlockRewards(ctx, &BlockRewardsRequest{BlockRoot: blkRoot[:]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := params.BeaconConfig()
+	baseReward := cfg.MaxEffectiveBalance * cfg.BaseRewardFactor /
+		mathutil.IntegerSquareRoot(numValidators*cfg.MaxEffectiveBalance) / cfg.BaseRewardsPerEpoch
+	// The duplicate attestation includes no new attesters.
+	wanted := uint64(len(committee)) * (baseReward / cfg.ProposerRewardQuotient)
+	if res.NewAttesters != uint64(len(committee)) {
+		t.Errorf("Wanted %d new attesters, received %d", len(committee), res.NewAttesters)
+	}
+	if res.Attestations != wanted || res.Total != wanted {
+		t.Errorf("Wanted attestation reward and total %d, received %d and %d", wanted, res.Attestations, res.Total)
+	}
+	if res.ProposerSlashings != 0 || res.AttesterSlashings != 0 {
+		t.Errorf("Wanted no slashing rewards, received %d and %d", res.ProposerSlashings, res.AttesterSlashings)
+	}
+	if res.Slot != 1 {
+		t.Errorf("Wanted slot 1, received %d", res.Slot)
+	}
+}
+
+func TestServer_GetBlockRewards_Errors(t *testing.T) {
+	db := dbTest.SetupDB(t)
+	defer dbTest.TeardownDB(t, db)
+	ctx := context.Background()
+	bs := &Server{BeaconDB: db}
+
+	if _, err := bs.GetBlockRewards(ctx, &BlockRewardsRequest{BlockRoot: []byte{'a'}}); err == nil || !strings.Contains(err.Error(), "must be 32 bytes") {
+		t.Errorf("Wanted error for short block root, received %v", err)
+	}
+	root := [32]byte{'a'}
+	if _, err := bs.GetBlockRewards(ctx, &BlockRewardsRequest{BlockRoot: root[:]}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Wanted error for unknown block, received %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package beacon
 
 import (
+	"bytes"
 	"context"
 	"strconv"
 
@@ -14,11 +15,20 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
 	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/shared/pagination"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+const (
+	// streamBlocksBufferSize is the number of blocks buffered for a client of the blocks stream.
+	streamBlocksBufferSize = 128
+	// streamChainHeadBufferSize is the number of state updates buffered for a client of the chain
+	// head stream.
+	streamChainHeadBufferSize = 4
+)
+
 // ListBlocks retrieves blocks by root, slot, or epoch.
 //
 // The server may return multiple blocks in the case that a slot or epoch is
@@ -59,7 +69,7 @@ func (bs *Server) ListBlocks(
 		for i, b := range returnedBlks {
 			root, err := ssz.HashTreeRoot(b.Block)
 			if err != nil {
-				return nil, err
+				return nil, status.Errorf(codes.Internal, "Could not hash block: %v", err)
 			}
 			containers[i] = &ethpb.BeaconBlockContainer{
 				Block:     b,
@@ -86,7 +96,7 @@ func (bs *Server) ListBlocks(
 		}
 		root, err := ssz.HashTreeRoot(blk.Block)
 		if err != nil {
-			return nil, err
+			return nil, status.Errorf(codes.Internal, "Could not hash block: %v", err)
 		}
 
 		return &ethpb.ListBlocksResponse{
@@ -122,7 +132,7 @@ func (bs *Server) ListBlocks(
 		for i, b := range returnedBlks {
 			root, err := ssz.HashTreeRoot(b.Block)
 			if err != nil {
-				return nil, err
+				return nil, status.Errorf(codes.Internal, "Could not hash block: %v", err)
 			}
 			containers[i] = &ethpb.BeaconBlockContainer{
 				Block:     b,
@@ -145,7 +155,7 @@ func (bs *Server) ListBlocks(
 		}
 		root, err := ssz.HashTreeRoot(genBlk.Block)
 		if err != nil {
-			return nil, err
+			return nil, status.Errorf(codes.Internal, "Could not hash block: %v", err)
 		}
 		containers := []*ethpb.BeaconBlockContainer{
 			{
@@ -164,6 +174,106 @@ func (bs *Server) ListBlocks(
 	return nil, status.Error(codes.InvalidArgument, "Must specify a filter criteria for fetching blocks")
 }
 
+// ListBlocksByRootsRequest contains the roots of the blocks to retrieve.
+type ListBlocksByRootsRequest struct {
+	BlockRoots [][]byte `json:"block_roots"`
+}
+
+// ListBlocksByRootsResponse contains the blocks found for the requested roots.
+type ListBlocksByRootsResponse struct {
+	BlockContainers []*ethpb.BeaconBlockContainer `json:"block_containers"`
+}
+
+// ListBlocksByRoots retrieves the blocks of up to the max page size of roots in a single
+// request, in the order of the requested roots. Roots which are duplicated are only returned
+// once, and roots without a block in the database are left out of the response.
+func (bs *Server) ListBlocksByRoots(
+	ctx context.Context, req *ListBlocksByRootsRequest,
+) (*ListBlocksByRootsResponse, error) {
+	if len(req.BlockRoots) > flags.Get().MaxPageSize {
+		return nil, status.Errorf(codes.InvalidArgument, "Requested %d roots can not be greater than max size %d",
+			len(req.BlockRoots), flags.Get().MaxPageSize)
+	}
+
+	containers := make([]*ethpb.BeaconBlockContainer, 0, len(req.BlockRoots))
+	seen := make(map[[32]byte]bool, len(req.BlockRoots))
+	for _, r := range req.BlockRoots {
+		if len(r) != 32 {
+			return nil, status.Errorf(codes.InvalidArgument, "Block root %#x is not 32 bytes long", r)
+		}
+		root := bytesutil.ToBytes32(r)
+		if seen[root] {
+			continue
+		}
+		seen[root] = true
+		blk, err := bs.BeaconDB.Block(ctx, root)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not retrieve block %#x: %v", root, err)
+		}
+		if blk == nil {
+			continue
+		}
+		containers = append(containers, &ethpb.BeaconBlockContainer{
+			Block:     blk,
+			BlockRoot: root[:],
+		})
+	}
+
+	return &ListBlocksByRootsResponse{
+		BlockContainers: containers,
+	}, nil
+}
+
+// SlotBlockContainer is a block at a slot along with whether it is part of the canonical chain.
+type SlotBlockContainer struct {
+	Block     *ethpb.SignedBeaconBlock `json:"block"`
+	BlockRoot []byte                   `json:"block_root"`
+	Canonical bool                     `json:"canonical"`
+}
+
+// ListBlocksAtSlotResponse contains the blocks known at a slot.
+type ListBlocksAtSlotResponse struct {
+	Slot   uint64                `json:"slot"`
+	Blocks []*SlotBlockContainer `json:"blocks"`
+}
+
+// ListBlocksAtSlot retrieves all the blocks known at a slot, including the blocks of forks which
+// are not part of the canonical chain and the blocks orphaned by reorgs which are kept for the
+// orphaned block retention period.
+func (bs *Server) ListBlocksAtSlot(ctx context.Context, slot uint64) (*ListBlocksAtSlotResponse, error) {
+	blks, err := bs.BeaconDB.Blocks(ctx, filters.NewFilter().SetStartSlot(slot).SetEndSlot(slot))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not retrieve blocks for slot %d: %v", slot, err)
+	}
+	orphaned, err := bs.BeaconDB.OrphanedBlocks(ctx, slot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not retrieve orphaned blocks for slot %d: %v", slot, err)
+	}
+
+	containers := make([]*SlotBlockContainer, 0, len(blks)+len(orphaned))
+	seen := make(map[[32]byte]bool, len(blks)+len(orphaned))
+	for _, b := range append(blks, orphaned...) {
+		root, err := ssz.HashTreeRoot(b.Block)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not compute block root: %v", err)
+		}
+		if seen[root] {
+			continue
+		}
+		seen[root] = true
+		containers = append(containers, &SlotBlockContainer{
+			Block:     b,
+			BlockRoot: root[:],
+			Canonical: bs.BeaconDB.IsCanonical(ctx, root),
+		})
+	}
+
+	return &ListBlocksAtSlotResponse{
+		Slot:   slot,
+		Blocks: containers,
+	}, nil
+}
+
 // GetChainHead retrieves information about the head of the beacon chain from
 // the view of the beacon chain node.
 //
@@ -173,16 +283,21 @@ func (bs *Server) GetChainHead(ctx context.Context, _ *ptypes.Empty) (*ethpb.Cha
 	return bs.chainHeadRetrieval(ctx)
 }
 
-// StreamBlocks to clients every single time a block is received by the beacon node.
+// StreamBlocks to clients every single time a block is received by the beacon node. Blocks are
+// buffered for slow clients, and the block feed waits for a client falling behind the buffer so
+// that no block is skipped.
 func (bs *Server) StreamBlocks(_ *ptypes.Empty, stream ethpb.BeaconChain_StreamBlocksServer) error {
-	blocksChannel := make(chan *feed.Event, 1)
-	blockSub := bs.BlockNotifier.BlockFeed().Subscribe(blocksChannel)
+	blocksChannel, blockSub := feed.SubscribeBuffered(bs.BlockNotifier.BlockFeed(), &event.BufferedOpts{
+		Name:   "rpc_stream_blocks",
+		Size:   streamBlocksBufferSize,
+		Policy: event.Block,
+	})
 	defer blockSub.Unsubscribe()
 	for {
 		select {
-		case event := <-blocksChannel:
-			if event.Type == blockfeed.ReceivedBlock {
-				data, ok := event.Data.(*blockfeed.ReceivedBlockData)
+		case ev := <-blocksChannel:
+			if ev.Type == blockfeed.ReceivedBlock {
+				data, ok := ev.Data.(*blockfeed.ReceivedBlockData)
 				if !ok {
 					// Got bad data over the stream.
 					continue
@@ -205,22 +320,33 @@ func (bs *Server) StreamBlocks(_ *ptypes.Empty, stream ethpb.BeaconChain_StreamB
 	}
 }
 
-// StreamChainHead to clients every single time the head block and state of the chain change.
+// StreamChainHead to clients every single time the head block of the chain or its
+// justified and finalized checkpoints change. Updates which leave the head, justified and
+// finalized checkpoints untouched are not sent over the stream. As only the latest chain head
+// matters, the oldest updates are dropped for slow clients rather than holding up the state feed.
 func (bs *Server) StreamChainHead(_ *ptypes.Empty, stream ethpb.BeaconChain_StreamChainHeadServer) error {
-	stateChannel := make(chan *feed.Event, 1)
-	stateSub := bs.StateNotifier.StateFeed().Subscribe(stateChannel)
+	stateChannel, stateSub := feed.SubscribeBuffered(bs.StateNotifier.StateFeed(), &event.BufferedOpts{
+		Name:   "rpc_stream_chain_head",
+		Size:   streamChainHeadBufferSize,
+		Policy: event.DropOldest,
+	})
 	defer stateSub.Unsubscribe()
+	var lastSent *ethpb.ChainHead
 	for {
 		select {
-		case event := <-stateChannel:
-			if event.Type == statefeed.BlockProcessed {
+		case ev := <-stateChannel:
+			if ev.Type == statefeed.BlockProcessed || ev.Type == statefeed.HeadUpdated {
 				res, err := bs.chainHeadRetrieval(bs.Ctx)
 				if err != nil {
 					return status.Errorf(codes.Internal, "Could not retrieve chain head: %v", err)
 				}
+				if !chainHeadChanged(lastSent, res) {
+					continue
+				}
 				if err := stream.Send(res); err != nil {
 					return status.Errorf(codes.Unavailable, "Could not send over stream: %v", err)
 				}
+				lastSent = res
 			}
 		case <-stateSub.Err():
 			return status.Error(codes.Aborted, "Subscriber closed, exiting goroutine")
@@ -232,6 +358,19 @@ func (bs *Server) StreamChainHead(_ *ptypes.Empty, stream ethpb.BeaconChain_Stre
 	}
 }
 
+// chainHeadChanged returns true if the head block root or the justified or finalized
+// checkpoints of the current chain head differ from the previous one.
+func chainHeadChanged(previous *ethpb.ChainHead, current *ethpb.ChainHead) bool {
+	if previous == nil {
+		return true
+	}
+	return !bytes.Equal(previous.HeadBlockRoot, current.HeadBlockRoot) ||
+		previous.JustifiedEpoch != current.JustifiedEpoch ||
+		!bytes.Equal(previous.JustifiedBlockRoot, current.JustifiedBlockRoot) ||
+		previous.FinalizedEpoch != current.FinalizedEpoch ||
+		!bytes.Equal(previous.FinalizedBlockRoot, current.FinalizedBlockRoot)
+}
+
 // Retrieve chain head information from the DB and the current beacon state.
 func (bs *Server) chainHeadRetrieval(ctx context.Context) (*ethpb.ChainHead, error) {
 	headBlock, err := bs.HeadFetcher.HeadBlock(ctx)
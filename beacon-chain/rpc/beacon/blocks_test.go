@@ -366,6 +366,142 @@ func TestServer_ListBlocks_Errors(t *testing.T) {
 	}
 }
 
+func TestServer_ListBlocksByRoots(t *testing.T) {
+	db := dbTest.SetupDB(t)
+	defer dbTest.TeardownDB(t, db)
+	ctx := context.Background()
+
+	count := uint64(4)
+	blks := make([]*ethpb.SignedBeaconBlock, count)
+	roots := make([][]byte, count)
+	for i := uint64(0); i < count; i++ {
+		blks[i] = &ethpb.SignedBeaconBlock{
+			Block: &ethpb.BeaconBlock{
+				Slot: i,
+			},
+		}
+		root, err := ssz.HashTreeRoot(blks[i].Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		roots[i] = root[:]
+	}
+	// The last block is not saved.
+	if err := db.SaveBlocks(ctx, blks[:count-1]); err != nil {
+		t.Fatal(err)
+	}
+
+	bs := &Server{
+		BeaconDB: db,
+	}
+	res, err := bs.ListBlocksByRoots(ctx, &ListBlocksByRootsRequest{
+		BlockRoots: [][]byte{roots[2], roots[3], roots[0], roots[2]},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wanted := []*ethpb.BeaconBlockContainer{
+		{Block: blks[2], BlockRoot: roots[2]},
+		{Block: blks[0], BlockRoot: roots[0]},
+	}
+	if len(res.BlockContainers) != len(wanted) {
+		t.Fatalf("Wanted %d blocks, received %d", len(wanted), len(res.BlockContainers))
+	}
+	for i, container := range res.BlockContainers {
+		if !proto.Equal(container, wanted[i]) {
+			t.Errorf("Wanted %v, received %v", wanted[i], container)
+		}
+	}
+}
+
+func TestServer_ListBlocksAtSlot_IncludesOrphanedBlocks(t *testing.T) {
+	flags.Init(&flags.GlobalFlags{OrphanedBlockRetentionEpochs: 1})
+	defer flags.Init(&flags.GlobalFlags{})
+	db := dbTest.SetupDB(t)
+	defer dbTest.TeardownDB(t, db)
+	ctx := context.Background()
+
+	parent := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 1}}
+	parentRoot, err := ssz.HashTreeRoot(parent.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orphaned := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 2, ParentRoot: parentRoot[:]}}
+	canonical := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 2, ParentRoot: parentRoot[:], Graffiti: []byte("fork")}}
+	if err := db.SaveBlocks(ctx, []*ethpb.SignedBeaconBlock{parent, orphaned, canonical}); err != nil {
+		t.Fatal(err)
+	}
+	orphanedRoot, err := ssz.HashTreeRoot(orphaned.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	canonicalRoot, err := ssz.HashTreeRoot(canonical.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, root := range [][32]byte{orphanedRoot, canonicalRoot} {
+		st, err := stateTrie.InitializeFromProto(&pbp2p.BeaconState{Slot: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := db.SaveState(ctx, st, root); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.SaveHeadBlockRoot(ctx, root); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The orphaned block is still served once pruned from the blocks bucket.
+	if err := db.DeleteBlock(ctx, orphanedRoot); err != nil {
+		t.Fatal(err)
+	}
+
+	bs := &Server{
+		BeaconDB: db,
+	}
+	res, err := bs.ListBlocksAtSlot(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wanted := []*SlotBlockContainer{
+		{Block: canonical, BlockRoot: canonicalRoot[:], Canonical: true},
+		{Block: orphaned, BlockRoot: orphanedRoot[:], Canonical: false},
+	}
+	if len(res.Blocks) != len(wanted) {
+		t.Fatalf("Wanted %d blocks, received %d", len(wanted), len(res.Blocks))
+	}
+	for i, container := range res.Blocks {
+		if !proto.Equal(container.Block, wanted[i].Block) ||
+			!bytes.Equal(container.BlockRoot, wanted[i].BlockRoot) ||
+			container.Canonical != wanted[i].Canonical {
+			t.Errorf("Wanted %v, received %v", wanted[i], container)
+		}
+	}
+}
+
+func TestServer_ListBlocksByRoots_Errors(t *testing.T) {
+	db := dbTest.SetupDB(t)
+	defer dbTest.TeardownDB(t, db)
+	ctx := context.Background()
+
+	bs := &Server{
+		BeaconDB: db,
+	}
+	roots := make([][]byte, flags.Get().MaxPageSize+1)
+	for i := range roots {
+		roots[i] = make([]byte, 32)
+	}
+	wanted := fmt.Sprintf("Requested %d roots can not be greater than max size %d", len(roots), flags.Get().MaxPageSize)
+	if _, err := bs.ListBlocksByRoots(ctx, &ListBlocksByRootsRequest{BlockRoots: roots}); err == nil || !strings.Contains(err.Error(), wanted) {
+		t.Errorf("Expected error %v, received %v", wanted, err)
+	}
+
+	wanted = "is not 32 bytes long"
+	if _, err := bs.ListBlocksByRoots(ctx, &ListBlocksByRootsRequest{BlockRoots: [][]byte{{1, 2, 3}}}); err == nil || !strings.Contains(err.Error(), wanted) {
+		t.Errorf("Expected error %v, received %v", wanted, err)
+	}
+}
+
 func TestServer_GetChainHead_NoFinalizedBlock(t *testing.T) {
 	db := dbTest.SetupDB(t)
 	defer dbTest.TeardownDB(t, db)
@@ -591,6 +727,39 @@ func TestServer_StreamChainHead_OnHeadUpdated(t *testing.T) {
 	<-exitRoutine
 }
 
+func TestChainHeadChanged(t *testing.T) {
+	head := &ethpb.ChainHead{
+		HeadBlockRoot:      []byte{'A'},
+		JustifiedEpoch:     2,
+		JustifiedBlockRoot: []byte{'B'},
+		FinalizedEpoch:     1,
+		FinalizedBlockRoot: []byte{'C'},
+	}
+	if !chainHeadChanged(nil, head) {
+		t.Error("Expected first chain head to be reported as changed")
+	}
+	same := proto.Clone(head).(*ethpb.ChainHead)
+	same.HeadSlot = 100
+	if chainHeadChanged(head, same) {
+		t.Error("Expected chain head with same head and checkpoints to be unchanged")
+	}
+	newHead := proto.Clone(head).(*ethpb.ChainHead)
+	newHead.HeadBlockRoot = []byte{'D'}
+	if !chainHeadChanged(head, newHead) {
+		t.Error("Expected new head block root to be reported as changed")
+	}
+	newJustified := proto.Clone(head).(*ethpb.ChainHead)
+	newJustified.JustifiedEpoch = 3
+	if !chainHeadChanged(head, newJustified) {
+		t.Error("Expected new justified checkpoint to be reported as changed")
+	}
+	newFinalized := proto.Clone(head).(*ethpb.ChainHead)
+	newFinalized.FinalizedBlockRoot = []byte{'E'}
+	if !chainHeadChanged(head, newFinalized) {
+		t.Error("Expected new finalized checkpoint to be reported as changed")
+	}
+}
+
 func TestServer_StreamBlocks_ContextCanceled(t *testing.T) {
 	db := dbTest.SetupDB(t)
 	defer dbTest.TeardownDB(t, db)
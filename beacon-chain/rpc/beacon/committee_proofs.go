@@ -0,0 +1,109 @@
+package beacon
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CommitteeProofRequest selects the validator and epoch to return the committee of.
+type CommitteeProofRequest struct {
+	ValidatorIndex uint64 `json:"validator_index"`
+	Epoch          uint64 `json:"epoch"`
+}
+
+// CommitteeProofResponse is the committee a validator served in during an epoch, along with
+// the randao mix the committee shuffling is seeded with and a merkle proof of the mix in the
+// head state root. Given the active validator indices of the epoch, the committee can be
+// recomputed from the seed to verify the duty.
+type CommitteeProofResponse struct {
+	Slot           uint64   `json:"slot"`
+	CommitteeIndex uint64   `json:"committee_index"`
+	Committee      []uint64 `json:"committee"`
+	// Position is the position of the validator in the committee, and of its bit in the
+	// aggregation bits of the attestations of the committee.
+	Position             uint64 `json:"position"`
+	ActiveValidatorCount uint64 `json:"active_validator_count"`
+	// Seed is the hash of the attester domain, the epoch and the randao mix.
+	Seed      []byte `json:"seed"`
+	RandaoMix []byte `json:"randao_mix"`
+	// RandaoMixIndex is the index of the randao mix in the randao mixes of the state.
+	RandaoMixIndex uint64 `json:"randao_mix_index"`
+	StateSlot      uint64 `json:"state_slot"`
+	StateRoot      []byte `json:"state_root"`
+	// Proof is the merkle branch from the randao mix up to the state root, verified by
+	// trieutil.VerifyMerkleBranch with MerkleIndex.
+	Proof       [][]byte `json:"proof"`
+	MerkleIndex uint64   `json:"merkle_index"`
+}
+
+// GetCommitteeProof returns the committee the validator was assigned to in the epoch, with a
+// proof of the randao mix seeding the committee shuffling in the head state, so auditors can
+// verify the duty without trusting the node. The randao mix of the epoch must still be in the
+// randao mixes history of the head state.
+func (bs *Server) GetCommitteeProof(ctx context.Context, req *CommitteeProofRequest) (*CommitteeProofResponse, error) {
+	committeesBySlot, activeIndices, err := bs.retrieveCommitteesForEpoch(ctx, req.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	committeeIndex, position, slot, found := committeePositionOf(committeesBySlot, req.ValidatorIndex)
+	if !found {
+		return nil, status.Errorf(
+			codes.NotFound,
+			"Validator %d is not in any committee for epoch %d",
+			req.ValidatorIndex,
+			req.Epoch,
+		)
+	}
+
+	headState, err := bs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
+	}
+	// The shuffling of an epoch is seeded with the randao mix of the epoch before its lookahead,
+	// offset by the length of the mixes history to stay positive. The mix is overwritten once
+	// the head state reaches that offset epoch.
+	vectorLength := params.BeaconConfig().EpochsPerHistoricalVector
+	mixEpoch := req.Epoch + vectorLength - params.BeaconConfig().MinSeedLookahead - 1
+	if mixEpoch <= helpers.CurrentEpoch(headState) {
+		return nil, status.Errorf(codes.NotFound, "Randao mix of epoch %d is no longer in the head state", req.Epoch)
+	}
+	mixIndex := mixEpoch % vectorLength
+	inner := headState.InnerStateUnsafe()
+	proof, merkleIndex, err := stateutil.RandaoMixProof(inner, mixIndex)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not compute randao mix proof: %v", err)
+	}
+	stateRoot, err := headState.HashTreeRoot()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not compute state root: %v", err)
+	}
+	mix := bytesutil.SafeCopyBytes(inner.RandaoMixes[mixIndex])
+	domain := params.BeaconConfig().DomainBeaconAttester
+	seed := hashutil.Hash(append(append(domain[:], bytesutil.Bytes8(req.Epoch)...), mix...))
+
+	branch := make([][]byte, len(proof))
+	for i := range proof {
+		branch[i] = bytesutil.SafeCopyBytes(proof[i][:])
+	}
+	return &CommitteeProofResponse{
+		Slot:                 slot,
+		CommitteeIndex:       committeeIndex,
+		Committee:            committeesBySlot[slot].Committees[committeeIndex].ValidatorIndices,
+		Position:             position,
+		ActiveValidatorCount: uint64(len(activeIndices)),
+		Seed:                 seed[:],
+		RandaoMix:            mix,
+		RandaoMixIndex:       mixIndex,
+		StateSlot:            headState.Slot(),
+		StateRoot:            stateRoot[:],
+		Proof:                branch,
+		MerkleIndex:          merkleIndex,
+	}, nil
+}
@@ -0,0 +1,48 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
+)
+
+func TestServer_GetCommitteeProof(t *testing.T) {
+	db := dbTest.SetupDB(t)
+	defer dbTest.TeardownDB(t, db)
+	helpers.ClearCache()
+
+	headState := setupActiveValidators(t, db, 128)
+	randaoMixes := make([][]byte, params.BeaconConfig().EpochsPerHistoricalVector)
+	for i := 0; i < len(randaoMixes); i++ {
+		randaoMixes[i] = bytesutil.Bytes32(uint64(i))
+	}
+	if err := headState.SetRandaoMixes(randaoMixes); err != nil {
+		t.Fatal(err)
+	}
+	bs := &Server{HeadFetcher: &mock.ChainService{State: headState}}
+
+	res, err := bs.GetCommitteeProof(context.Background(), &CommitteeProofRequest{ValidatorIndex: 5, Epoch: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Committee[res.Position] != 5 {
+		t.Errorf("Expected validator 5 at position %d of committee %v", res.Position, res.Committee)
+	}
+	seed, err := helpers.Seed(headState, 0, params.BeaconConfig().DomainBeaconAttester)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(res.Seed, seed[:]) {
+		t.Errorf("Wanted seed %#x, received %#x", seed, res.Seed)
+	}
+	if !trieutil.VerifyMerkleBranch(res.StateRoot, res.RandaoMix, int(res.MerkleIndex), res.Proof) {
+		t.Error("Randao mix proof did not verify against the state root")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/statusutil"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -109,12 +110,7 @@ func (bs *Server) retrieveCommitteesForEpoch(
 		}
 	} else {
 		// Otherwise, we are requesting data from the future and we return an error.
-		return nil, nil, status.Errorf(
-			codes.InvalidArgument,
-			"Cannot retrieve information about an epoch in the future, current epoch %d, requesting %d",
-			headEpoch,
-			helpers.SlotToEpoch(startSlot),
-		)
+		return nil, nil, statusutil.FutureEpoch(headEpoch, helpers.SlotToEpoch(startSlot))
 	}
 
 	committeesListsBySlot, err := computeCommittees(startSlot, activeIndices, attesterSeed)
@@ -0,0 +1,113 @@
+package beacon
+
+import (
+	"context"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DepositInclusionProofRequest identifies the deposit to prove inclusion for, and the slot of
+// the state whose eth1 data deposit root the proof is against.
+type DepositInclusionProofRequest struct {
+	DepositIndex uint64 `json:"deposit_index"`
+	Slot         uint64 `json:"slot"`
+}
+
+// DepositInclusionProofResponse contains the deposit data and a merkle proof of its inclusion
+// in the deposit root of the eth1 data of the state.
+type DepositInclusionProofResponse struct {
+	DepositIndex uint64              `json:"deposit_index"`
+	Data         *ethpb.Deposit_Data `json:"data"`
+	DataRoot     []byte              `json:"data_root"`
+	// Proof is the merkle branch from the deposit data root up to the deposit root, ending with
+	// the deposit count, verified by trieutil.VerifyMerkleBranch with DepositIndex.
+	Proof        [][]byte `json:"proof"`
+	DepositRoot  []byte   `json:"deposit_root"`
+	DepositCount uint64   `json:"deposit_count"`
+	StateSlot    uint64   `json:"state_slot"`
+	// Verified is true if the proof verified against the deposit root of the state.
+	Verified bool `json:"verified"`
+	// Processed is true if the deposit was processed by a block up to the state.
+	Processed bool `json:"processed"`
+}
+
+// DepositInclusionProof returns the deposit data of the deposit index and a merkle proof of its
+// inclusion in the deposit root the beacon chain voted for in the state at the slot, which is
+// verified before being returned, so auditors can confirm the deposit was included without
+// running eth1 tooling. The proof is computed from the deposits known to the node, which must
+// cover all the deposits of the eth1 data of the state.
+func (bs *Server) DepositInclusionProof(
+	ctx context.Context, req *DepositInclusionProofRequest,
+) (*DepositInclusionProofResponse, error) {
+	st, err := bs.stateAtSlot(ctx, req.Slot)
+	if err != nil {
+		return nil, err
+	}
+	eth1Data := st.Eth1Data()
+	if eth1Data == nil {
+		return nil, status.Errorf(codes.Internal, "State at slot %d has no eth1 data", req.Slot)
+	}
+	if req.DepositIndex >= eth1Data.DepositCount {
+		return nil, status.Errorf(
+			codes.NotFound,
+			"Deposit %d is not in the %d deposits of the eth1 data of the state at slot %d",
+			req.DepositIndex,
+			eth1Data.DepositCount,
+			req.Slot,
+		)
+	}
+
+	// With a deposit tree snapshot, the deposit cache starts after the deposits of the snapshot.
+	snapshot := bs.DepositFetcher.DepositSnapshot()
+	var firstCached uint64
+	if snapshot != nil {
+		firstCached = snapshot.DepositCount
+	}
+	if req.DepositIndex < firstCached || eth1Data.DepositCount < firstCached {
+		return nil, status.Errorf(codes.NotFound, "Deposit %d is pruned by the deposit tree snapshot", req.DepositIndex)
+	}
+	deposits := bs.DepositFetcher.AllDeposits(ctx, nil)
+	if uint64(len(deposits)) < eth1Data.DepositCount-firstCached {
+		return nil, status.Errorf(
+			codes.Unavailable,
+			"Node only knows %d of the %d deposits of the eth1 data",
+			uint64(len(deposits))+firstCached,
+			eth1Data.DepositCount,
+		)
+	}
+	deposits = deposits[:eth1Data.DepositCount-firstCached]
+	items := make([][]byte, len(deposits))
+	for i, dep := range deposits {
+		root, err := ssz.HashTreeRoot(dep.Data)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not hash deposit data: %v", err)
+		}
+		items[i] = root[:]
+	}
+	depth := int(params.BeaconConfig().DepositContractTreeDepth)
+	depositTrie, err := trieutil.TrieFromSnapshotAndItems(snapshot, items, depth)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not generate deposit trie: %v", err)
+	}
+	proof, err := depositTrie.MerkleProof(int(req.DepositIndex))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not generate merkle proof of deposit %d: %v", req.DepositIndex, err)
+	}
+	leaf := items[req.DepositIndex-firstCached]
+	return &DepositInclusionProofResponse{
+		DepositIndex: req.DepositIndex,
+		Data:         deposits[req.DepositIndex-firstCached].Data,
+		DataRoot:     leaf,
+		Proof:        proof,
+		DepositRoot:  eth1Data.DepositRoot,
+		DepositCount: eth1Data.DepositCount,
+		StateSlot:    st.Slot(),
+		Verified:     trieutil.VerifyMerkleBranch(eth1Data.DepositRoot, leaf, int(req.DepositIndex), proof),
+		Processed:    req.DepositIndex < st.Eth1DepositIndex(),
+	}, nil
+}
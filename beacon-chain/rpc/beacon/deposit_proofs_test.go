@@ -0,0 +1,81 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServer_DepositInclusionProof(t *testing.T) {
+	ctx := context.Background()
+	dc := depositcache.NewDepositCache()
+	var items [][]byte
+	for i := 0; i < 5; i++ {
+		dep := &ethpb.Deposit{Data: &ethpb.Deposit_Data{
+			PublicKey: bytes.Repeat([]byte{byte(i)}, 48),
+			Amount:    32e9,
+		}}
+		dc.InsertDeposit(ctx, dep, 10, int64(i), [32]byte{})
+		root, err := ssz.HashTreeRoot(dep.Data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		items = append(items, root[:])
+	}
+	// The eth1 data of the state only covers the first four deposits.
+	depositTrie, err := trieutil.GenerateTrieFromItems(items[:4], int(params.BeaconConfig().DepositContractTreeDepth))
+	if err != nil {
+		t.Fatal(err)
+	}
+	depositRoot := depositTrie.HashTreeRoot()
+	headState, err := stateTrie.InitializeFromProto(&pbp2p.BeaconState{
+		Slot:             5,
+		Eth1Data:         &ethpb.Eth1Data{DepositRoot: depositRoot[:], DepositCount: 4},
+		Eth1DepositIndex: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs := &Server{
+		DepositFetcher: dc,
+		HeadFetcher:    &mock.ChainService{State: headState},
+	}
+
+	res, err := bs.DepositInclusionProof(ctx, &DepositInclusionProofRequest{DepositIndex: 3, Slot: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Verified || res.Processed {
+		t.Errorf("Wanted a verified proof of an unprocessed deposit, received %+v", res)
+	}
+	if !bytes.Equal(res.DataRoot, items[3]) || res.Data.PublicKey[0] != 3 {
+		t.Errorf("Unexpected deposit data %v", res.Data)
+	}
+	if !trieutil.VerifyMerkleBranch(depositRoot[:], res.DataRoot, 3, res.Proof) {
+		t.Error("Expected the returned proof to verify")
+	}
+
+	res, err = bs.DepositInclusionProof(ctx, &DepositInclusionProofRequest{DepositIndex: 1, Slot: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Verified || !res.Processed {
+		t.Errorf("Wanted a verified proof of a processed deposit, received %+v", res)
+	}
+
+	_, err = bs.DepositInclusionProof(ctx, &DepositInclusionProofRequest{DepositIndex: 4, Slot: 5})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected a deposit after the eth1 data to not be found, received %v", err)
+	}
+}
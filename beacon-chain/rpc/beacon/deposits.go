@@ -0,0 +1,68 @@
+package beacon
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ValidatorDepositsRequest selects the validator public key to list the deposits of.
+type ValidatorDepositsRequest struct {
+	PublicKey []byte `json:"public_key"`
+}
+
+// DepositInfo is a deposit made to the deposit contract for a validator public key.
+type DepositInfo struct {
+	Index                 int64  `json:"index"`
+	Eth1BlockHeight       uint64 `json:"eth1_block_height"`
+	Amount                uint64 `json:"amount"`
+	WithdrawalCredentials []byte `json:"withdrawal_credentials"`
+	// TxHash is the hash of the eth1 transaction of the deposit, only known for deposits
+	// received from eth1 logs since the node started.
+	TxHash []byte `json:"tx_hash,omitempty"`
+	// TopUp is true for every deposit after the first one of the public key.
+	TopUp bool `json:"top_up"`
+	// IgnoredWithdrawalCredentials is true if a top-up deposit has other withdrawal credentials
+	// than the first deposit, which the beacon chain ignores.
+	IgnoredWithdrawalCredentials bool `json:"ignored_withdrawal_credentials"`
+}
+
+// ValidatorDepositsResponse lists the deposits of a validator public key.
+type ValidatorDepositsResponse struct {
+	PublicKey []byte         `json:"public_key"`
+	Deposits  []*DepositInfo `json:"deposits"`
+	// TotalAmount is the sum of the amounts of the deposits, in Gwei.
+	TotalAmount uint64 `json:"total_amount"`
+}
+
+// ListValidatorDeposits returns all deposits to a validator public key, in deposit index
+// order, along with the hashes of their eth1 transactions, so that top-ups and re-deposits can
+// be reconciled with eth1 transfers.
+func (bs *Server) ListValidatorDeposits(ctx context.Context, req *ValidatorDepositsRequest) (*ValidatorDepositsResponse, error) {
+	if len(req.PublicKey) != params.BeaconConfig().BLSPubkeyLength {
+		return nil, status.Errorf(codes.InvalidArgument, "Public key must be %d bytes long", params.BeaconConfig().BLSPubkeyLength)
+	}
+	deposits := bs.DepositFetcher.DepositsByPubkey(ctx, req.PublicKey)
+	if len(deposits) == 0 {
+		return nil, status.Errorf(codes.NotFound, "No deposits found for public key %#x", req.PublicKey)
+	}
+	res := &ValidatorDepositsResponse{
+		PublicKey: req.PublicKey,
+		Deposits:  make([]*DepositInfo, len(deposits)),
+	}
+	for i, d := range deposits {
+		res.Deposits[i] = &DepositInfo{
+			Index:                        d.Index,
+			Eth1BlockHeight:              d.Eth1BlockHeight,
+			Amount:                       d.Deposit.Data.Amount,
+			WithdrawalCredentials:        d.Deposit.Data.WithdrawalCredentials,
+			TxHash:                       d.TxHash,
+			TopUp:                        d.TopUp,
+			IgnoredWithdrawalCredentials: d.IgnoredWithdrawalCredentials,
+		}
+		res.TotalAmount += d.Deposit.Data.Amount
+	}
+	return res, nil
+}
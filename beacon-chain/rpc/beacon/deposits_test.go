@@ -0,0 +1,46 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServer_ListValidatorDeposits(t *testing.T) {
+	ctx := context.Background()
+	dc := depositcache.NewDepositCache()
+	pk := bytes.Repeat([]byte{1}, 48)
+	for i, amount := range []uint64{32e9, 1e9} {
+		dc.InsertDeposit(ctx, &ethpb.Deposit{Data: &ethpb.Deposit_Data{PublicKey: pk, Amount: amount}}, 10, int64(i), [32]byte{})
+	}
+	dc.SetDepositTxHash(ctx, 1, [32]byte{'a'})
+	bs := &Server{DepositFetcher: dc}
+
+	res, err := bs.ListValidatorDeposits(ctx, &ValidatorDepositsRequest{PublicKey: pk})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.TotalAmount != 33e9 {
+		t.Errorf("Expected total amount of 33e9, received %d", res.TotalAmount)
+	}
+	if len(res.Deposits) != 2 || res.Deposits[0].TopUp || !res.Deposits[1].TopUp {
+		t.Fatalf("Unexpected deposits %v", res.Deposits)
+	}
+	if txHash := [32]byte{'a'}; !bytes.Equal(res.Deposits[1].TxHash, txHash[:]) {
+		t.Errorf("Unexpected transaction hash %#x", res.Deposits[1].TxHash)
+	}
+
+	_, err = bs.ListValidatorDeposits(ctx, &ValidatorDepositsRequest{PublicKey: bytes.Repeat([]byte{2}, 48)})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected not found error, received %v", err)
+	}
+	_, err = bs.ListValidatorDeposits(ctx, &ValidatorDepositsRequest{PublicKey: []byte{1}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected invalid argument error, received %v", err)
+	}
+}
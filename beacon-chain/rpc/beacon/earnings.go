@@ -0,0 +1,94 @@
+package beacon
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ValidatorEarningsRequest selects the validators and the epoch range of their earnings.
+type ValidatorEarningsRequest struct {
+	ValidatorIndices []uint64 `json:"validator_indices"`
+	StartEpoch       uint64   `json:"start_epoch"`
+	// EndEpoch is inclusive.
+	EndEpoch uint64 `json:"end_epoch"`
+}
+
+// ValidatorEarnings are the cumulative rewards and penalties of a validator over the epoch
+// transitions of a range, in Gwei.
+type ValidatorEarnings struct {
+	ValidatorIndex uint64 `json:"validator_index"`
+	// Epochs is the number of epoch transitions of the range with recorded rewards.
+	Epochs               uint64 `json:"epochs"`
+	SourceReward         uint64 `json:"source_reward"`
+	TargetReward         uint64 `json:"target_reward"`
+	HeadReward           uint64 `json:"head_reward"`
+	InclusionDelayReward uint64 `json:"inclusion_delay_reward"`
+	ProposerReward       uint64 `json:"proposer_reward"`
+	SourcePenalty        uint64 `json:"source_penalty"`
+	TargetPenalty        uint64 `json:"target_penalty"`
+	HeadPenalty          uint64 `json:"head_penalty"`
+	InactivityPenalty    uint64 `json:"inactivity_penalty"`
+	// NetEarnings is the sum of the rewards minus the sum of the penalties.
+	NetEarnings int64 `json:"net_earnings"`
+}
+
+// ValidatorEarningsResponse contains the earnings of the requested validators.
+type ValidatorEarningsResponse struct {
+	StartEpoch uint64               `json:"start_epoch"`
+	EndEpoch   uint64               `json:"end_epoch"`
+	Earnings   []*ValidatorEarnings `json:"earnings"`
+}
+
+// GetValidatorEarnings returns the cumulative rewards and penalties of validators at the epoch
+// transitions between the start and end epochs, read from the ledger of validator rewards
+// recorded with --validator-accounting. Slashings and deposits are not earnings, and are left
+// out.
+func (bs *Server) GetValidatorEarnings(ctx context.Context, req *ValidatorEarningsRequest) (*ValidatorEarningsResponse, error) {
+	if len(req.ValidatorIndices) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Must request at least one validator index")
+	}
+	if len(req.ValidatorIndices) > flags.Get().MaxPageSize {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Requested %d validators is more than the max allowed of %d",
+			len(req.ValidatorIndices),
+			flags.Get().MaxPageSize,
+		)
+	}
+	if req.StartEpoch > req.EndEpoch {
+		return nil, status.Errorf(codes.InvalidArgument, "Start epoch %d is after end epoch %d", req.StartEpoch, req.EndEpoch)
+	}
+
+	res := &ValidatorEarningsResponse{
+		StartEpoch: req.StartEpoch,
+		EndEpoch:   req.EndEpoch,
+		Earnings:   make([]*ValidatorEarnings, 0, len(req.ValidatorIndices)),
+	}
+	for _, idx := range req.ValidatorIndices {
+		rewards, err := bs.BeaconDB.ValidatorRewards(ctx, idx, req.StartEpoch, req.EndEpoch)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not retrieve rewards of validator %d: %v", idx, err)
+		}
+		e := &ValidatorEarnings{ValidatorIndex: idx, Epochs: uint64(len(rewards))}
+		var totalReward, totalPenalty uint64
+		for _, r := range rewards {
+			e.SourceReward += r.SourceReward
+			e.TargetReward += r.TargetReward
+			e.HeadReward += r.HeadReward
+			e.InclusionDelayReward += r.InclusionDelayReward
+			e.ProposerReward += r.ProposerReward
+			e.SourcePenalty += r.SourcePenalty
+			e.TargetPenalty += r.TargetPenalty
+			e.HeadPenalty += r.HeadPenalty
+			e.InactivityPenalty += r.InactivityPenalty
+			totalReward += r.TotalReward()
+			totalPenalty += r.TotalPenalty()
+		}
+		e.NetEarnings = int64(totalReward) - int64(totalPenalty)
+		res.Earnings = append(res.Earnings, e)
+	}
+	return res, nil
+}
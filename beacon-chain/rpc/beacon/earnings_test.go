@@ -0,0 +1,71 @@
+package beacon
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+)
+
+func TestServer_GetValidatorEarnings(t *testing.T) {
+	db := dbTest.SetupDB(t)
+	defer dbTest.TeardownDB(t, db)
+	ctx := context.Background()
+
+	for epoch := uint64(2); epoch <= 5; epoch++ {
+		rewards := []*precompute.Rewards{
+			{SourceReward: 10, TargetReward: 10, HeadReward: 10, InclusionDelayReward: 5},
+			{SourcePenalty: 10, TargetPenalty: 10, HeadPenalty: 10, ProposerReward: 4},
+		}
+		if err := db.SaveValidatorRewards(ctx, epoch, rewards); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bs := &Server{BeaconDB: db}
+
+	res, err := bs.GetValidatorEarnings(ctx, &ValidatorEarningsRequest{
+		ValidatorIndices: []uint64{0, 1, 2},
+		StartEpoch:       4,
+		EndEpoch:         10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wanted := []*ValidatorEarnings{
+		{
+			ValidatorIndex:       0,
+			Epochs:               2,
+			SourceReward:         20,
+			TargetReward:         20,
+			HeadReward:           20,
+			InclusionDelayReward: 10,
+			NetEarnings:          70,
+		},
+		{
+			ValidatorIndex: 1,
+			Epochs:         2,
+			ProposerReward: 8,
+			SourcePenalty:  20,
+			TargetPenalty:  20,
+			HeadPenalty:    20,
+			NetEarnings:    -52,
+		},
+		{ValidatorIndex: 2},
+	}
+	if !reflect.DeepEqual(wanted, res.Earnings) {
+		t.Errorf("Wanted %v, received %v", wanted, res.Earnings)
+	}
+
+	if _, err := bs.GetValidatorEarnings(ctx, &ValidatorEarningsRequest{
+		ValidatorIndices: []uint64{0},
+		StartEpoch:       5,
+		EndEpoch:         4,
+	}); err == nil {
+		t.Error("Expected error for start epoch after end epoch")
+	}
+	if _, err := bs.GetValidatorEarnings(ctx, &ValidatorEarningsRequest{EndEpoch: 4}); err == nil {
+		t.Error("Expected error without validator indices")
+	}
+}
@@ -0,0 +1,71 @@
+package beacon
+
+import (
+	"context"
+
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ExitQueueEstimate is the exit queue of the head state, and the epochs at which a validator
+// initiating a voluntary exit now would exit and become withdrawable.
+type ExitQueueEstimate struct {
+	Epoch uint64 `json:"epoch"`
+	// ExitQueueLength is the number of validators which initiated an exit and have not exited yet.
+	ExitQueueLength uint64 `json:"exit_queue_length"`
+	ChurnLimit      uint64 `json:"churn_limit"`
+	ExitEpoch       uint64 `json:"exit_epoch"`
+	// WithdrawableEpoch is the estimated epoch the balance of the validator becomes withdrawable.
+	WithdrawableEpoch uint64 `json:"withdrawable_epoch"`
+}
+
+// GetExitQueueEstimate computes the exit queue and churn limit of the head state, and the exit
+// epoch a validator would be assigned by initiating an exit now, following the spec's
+// initiate_validator_exit. The estimate holds as long as no other exit is processed first.
+func (bs *Server) GetExitQueueEstimate(ctx context.Context, _ *ptypes.Empty) (*ExitQueueEstimate, error) {
+	headState, err := bs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Could not get head state")
+	}
+	currentEpoch := helpers.CurrentEpoch(headState)
+	activeValidatorCount, err := helpers.ActiveValidatorCount(headState, currentEpoch)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get active validator count: %v", err)
+	}
+	churnLimit, err := helpers.ValidatorChurnLimit(activeValidatorCount)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not compute churn limit: %v", err)
+	}
+
+	res := &ExitQueueEstimate{
+		Epoch:      currentEpoch,
+		ChurnLimit: churnLimit,
+		ExitEpoch:  helpers.ActivationExitEpoch(currentEpoch),
+	}
+	vals := headState.Validators()
+	for _, val := range vals {
+		if val.ExitEpoch == params.BeaconConfig().FarFutureEpoch {
+			continue
+		}
+		if val.ExitEpoch > currentEpoch {
+			res.ExitQueueLength++
+		}
+		if val.ExitEpoch > res.ExitEpoch {
+			res.ExitEpoch = val.ExitEpoch
+		}
+	}
+	exitQueueChurn := uint64(0)
+	for _, val := range vals {
+		if val.ExitEpoch == res.ExitEpoch {
+			exitQueueChurn++
+		}
+	}
+	if exitQueueChurn >= churnLimit {
+		res.ExitEpoch++
+	}
+	res.WithdrawableEpoch = res.ExitEpoch + params.BeaconConfig().MinValidatorWithdrawabilityDelay
+	return res, nil
+}
@@ -0,0 +1,49 @@
+package beacon
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	ptypes "github.com/gogo/protobuf/types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestServer_GetExitQueueEstimate(t *testing.T) {
+	// Four validators exit at epoch 6, filling the churn limit of that epoch, and one validator
+	// already exited.
+	validators := []*ethpb.Validator{{ExitEpoch: 0}}
+	for i := 0; i < 4; i++ {
+		validators = append(validators, &ethpb.Validator{ExitEpoch: 6})
+	}
+	for i := 0; i < 4; i++ {
+		validators = append(validators, &ethpb.Validator{ExitEpoch: params.BeaconConfig().FarFutureEpoch})
+	}
+	headState, err := stateTrie.InitializeFromProto(&pbp2p.BeaconState{
+		Slot:       params.BeaconConfig().SlotsPerEpoch,
+		Validators: validators,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs := &Server{HeadFetcher: &mock.ChainService{State: headState}}
+
+	res, err := bs.GetExitQueueEstimate(context.Background(), &ptypes.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wanted := &ExitQueueEstimate{
+		Epoch:             1,
+		ExitQueueLength:   4,
+		ChurnLimit:        params.BeaconConfig().MinPerEpochChurnLimit,
+		ExitEpoch:         7,
+		WithdrawableEpoch: 7 + params.BeaconConfig().MinValidatorWithdrawabilityDelay,
+	}
+	if !reflect.DeepEqual(wanted, res) {
+		t.Errorf("Wanted %+v, received %+v", wanted, res)
+	}
+}
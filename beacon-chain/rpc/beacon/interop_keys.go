@@ -0,0 +1,86 @@
+package beacon
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/shared/interop"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// InteropKeysRequest selects a range of the deterministic interop validators.
+type InteropKeysRequest struct {
+	StartIndex uint64 `json:"start_index"`
+	// Count defaults to the max page size when 0.
+	Count uint64 `json:"count"`
+}
+
+// InteropKey is the deterministic keypair of an interop genesis validator. The keys are base64
+// encoded in JSON.
+type InteropKey struct {
+	ValidatorIndex uint64 `json:"validator_index"`
+	PublicKey      []byte `json:"public_key"`
+	PrivateKey     []byte `json:"private_key"`
+}
+
+// InteropKeysResponse contains the keypairs of the requested interop validators.
+type InteropKeysResponse struct {
+	Keys []*InteropKey `json:"keys"`
+	// NumValidators is the number of validators in the interop genesis state.
+	NumValidators uint64 `json:"num_validators"`
+}
+
+// GetInteropKeys returns the deterministic keypairs of the genesis validators when the node was
+// started with --interop-num-validators, so test harnesses and devnet orchestrators can program
+// validator clients without deriving the keys themselves. The validator index of a key is its
+// index in the interop key sequence. The private keys are not secret in interop mode, which
+// must never be used with real funds.
+func (bs *Server) GetInteropKeys(ctx context.Context, req *InteropKeysRequest) (*InteropKeysResponse, error) {
+	if bs.InteropNumValidators == 0 {
+		return nil, status.Error(
+			codes.FailedPrecondition,
+			"Node was not started from a deterministic interop genesis state",
+		)
+	}
+	if req.StartIndex >= bs.InteropNumValidators {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Start index %d is beyond the %d interop validators",
+			req.StartIndex,
+			bs.InteropNumValidators,
+		)
+	}
+	count := req.Count
+	if count == 0 {
+		count = uint64(flags.Get().MaxPageSize)
+	}
+	if count > uint64(flags.Get().MaxPageSize) {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Requested %d keys is more than the max allowed of %d",
+			count,
+			flags.Get().MaxPageSize,
+		)
+	}
+	if remaining := bs.InteropNumValidators - req.StartIndex; count > remaining {
+		count = remaining
+	}
+
+	secretKeys, publicKeys, err := interop.DeterministicallyGenerateKeys(req.StartIndex, count)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not generate interop keys: %v", err)
+	}
+	keys := make([]*InteropKey, len(secretKeys))
+	for i := range secretKeys {
+		keys[i] = &InteropKey{
+			ValidatorIndex: req.StartIndex + uint64(i),
+			PublicKey:      publicKeys[i].Marshal(),
+			PrivateKey:     secretKeys[i].Marshal(),
+		}
+	}
+	return &InteropKeysResponse{
+		Keys:          keys,
+		NumValidators: bs.InteropNumValidators,
+	}, nil
+}
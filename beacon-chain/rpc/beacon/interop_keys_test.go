@@ -0,0 +1,68 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/shared/interop"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServer_GetInteropKeys(t *testing.T) {
+	previous := flags.Get()
+	flags.Init(&flags.GlobalFlags{MaxPageSize: 4})
+	defer flags.Init(previous)
+	ctx := context.Background()
+	bs := &Server{InteropNumValidators: 10}
+
+	res, err := bs.GetInteropKeys(ctx, &InteropKeysRequest{StartIndex: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.NumValidators != 10 {
+		t.Errorf("Wanted %d validators, received %d", 10, res.NumValidators)
+	}
+	if len(res.Keys) != 2 {
+		t.Fatalf("Wanted %d keys, received %d", 2, len(res.Keys))
+	}
+	secretKeys, publicKeys, err := interop.DeterministicallyGenerateKeys(8, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, key := range res.Keys {
+		if key.ValidatorIndex != uint64(8+i) {
+			t.Errorf("Wanted validator index %d, received %d", 8+i, key.ValidatorIndex)
+		}
+		if !bytes.Equal(key.PublicKey, publicKeys[i].Marshal()) {
+			t.Errorf("Wrong public key for validator %d", key.ValidatorIndex)
+		}
+		if !bytes.Equal(key.PrivateKey, secretKeys[i].Marshal()) {
+			t.Errorf("Wrong private key for validator %d", key.ValidatorIndex)
+		}
+	}
+
+	res, err = bs.GetInteropKeys(ctx, &InteropKeysRequest{StartIndex: 1, Count: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Keys) != 3 || res.Keys[0].ValidatorIndex != 1 {
+		t.Errorf("Wanted 3 keys from index 1, received %d", len(res.Keys))
+	}
+
+	if _, err := bs.GetInteropKeys(ctx, &InteropKeysRequest{Count: 5}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Wanted invalid argument for a count above the page size, received %v", err)
+	}
+	if _, err := bs.GetInteropKeys(ctx, &InteropKeysRequest{StartIndex: 10}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Wanted invalid argument for a start index beyond the validators, received %v", err)
+	}
+}
+
+func TestServer_GetInteropKeys_NotInteropMode(t *testing.T) {
+	bs := &Server{}
+	if _, err := bs.GetInteropKeys(context.Background(), &InteropKeysRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Wanted failed precondition, received %v", err)
+	}
+}
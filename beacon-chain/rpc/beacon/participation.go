@@ -0,0 +1,60 @@
+package beacon
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EpochParticipation is the participation of the active validators in an epoch.
+type EpochParticipation struct {
+	Epoch uint64 `json:"epoch"`
+	// ActiveBalance is the total effective balance of the active validators, in Gwei.
+	ActiveBalance uint64 `json:"active_balance"`
+	// AttestingBalance is the total effective balance of the validators which attested to
+	// the epoch boundary block, in Gwei.
+	AttestingBalance uint64 `json:"attesting_balance"`
+	// Rate is the ratio of the attesting balance over the active balance.
+	Rate float64 `json:"rate"`
+}
+
+// ParticipationRatesResponse contains the participation of the previous and current epoch
+// of the head state.
+type ParticipationRatesResponse struct {
+	PreviousEpoch *EpochParticipation `json:"previous_epoch"`
+	CurrentEpoch  *EpochParticipation `json:"current_epoch"`
+}
+
+// GetParticipationRates computes the global participation rate of the previous and current
+// epoch from the attestations included in the head state. The current epoch rate is partial
+// until the epoch ends.
+func (bs *Server) GetParticipationRates(ctx context.Context) (*ParticipationRatesResponse, error) {
+	headState, err := bs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
+	}
+	if headState == nil {
+		return nil, status.Error(codes.Unavailable, "Head state is not available")
+	}
+	bp, err := precompute.Participation(ctx, headState)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not compute participation: %v", err)
+	}
+	return &ParticipationRatesResponse{
+		PreviousEpoch: &EpochParticipation{
+			Epoch:            helpers.PrevEpoch(headState),
+			ActiveBalance:    bp.PrevEpoch,
+			AttestingBalance: bp.PrevEpochTargetAttesters,
+			Rate:             bp.PrevEpochParticipationRate(),
+		},
+		CurrentEpoch: &EpochParticipation{
+			Epoch:            helpers.CurrentEpoch(headState),
+			ActiveBalance:    bp.CurrentEpoch,
+			AttestingBalance: bp.CurrentEpochTargetAttesters,
+			Rate:             bp.CurrentEpochParticipationRate(),
+		},
+	}, nil
+}
@@ -0,0 +1,57 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestServer_GetParticipationRates(t *testing.T) {
+	params.UseMinimalConfig()
+	defer params.UseMainnetConfig()
+
+	headState, _ := testutil.DeterministicGenesisState(t, 64)
+	if err := headState.SetSlot(params.BeaconConfig().SlotsPerEpoch + 1); err != nil {
+		t.Fatal(err)
+	}
+	root := [32]byte{'A'}
+	blockRoots := headState.BlockRoots()
+	blockRoots[0] = root[:]
+	if err := headState.SetBlockRoots(blockRoots); err != nil {
+		t.Fatal(err)
+	}
+	atts := []*pbp2p.PendingAttestation{{
+		Data: &ethpb.AttestationData{
+			Target:          &ethpb.Checkpoint{Epoch: 0, Root: root[:]},
+			BeaconBlockRoot: root[:],
+		},
+		AggregationBits: []byte{0xff},
+	}}
+	if err := headState.SetPreviousEpochAttestations(atts); err != nil {
+		t.Fatal(err)
+	}
+
+	bs := &Server{HeadFetcher: &mock.ChainService{State: headState}}
+	res, err := bs.GetParticipationRates(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.PreviousEpoch.Epoch != 0 || res.CurrentEpoch.Epoch != 1 {
+		t.Errorf("Wanted epochs 0 and 1, got %d and %d", res.PreviousEpoch.Epoch, res.CurrentEpoch.Epoch)
+	}
+	wantedActive := 64 * params.BeaconConfig().MaxEffectiveBalance
+	if res.PreviousEpoch.ActiveBalance != wantedActive || res.CurrentEpoch.ActiveBalance != wantedActive {
+		t.Errorf("Wanted active balance %d, got %+v", wantedActive, res)
+	}
+	if res.PreviousEpoch.AttestingBalance == 0 || res.PreviousEpoch.Rate <= 0 || res.PreviousEpoch.Rate >= 1 {
+		t.Errorf("Wanted partial previous epoch participation, got %+v", res.PreviousEpoch)
+	}
+	if res.CurrentEpoch.AttestingBalance != 0 || res.CurrentEpoch.Rate != 0 {
+		t.Errorf("Wanted no current epoch participation, got %+v", res.CurrentEpoch)
+	}
+}
@@ -0,0 +1,117 @@
+package beacon
+
+import (
+	"context"
+
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SlotProposer is the validator selected to propose the block of a slot.
+type SlotProposer struct {
+	Slot          uint64 `json:"slot"`
+	ProposerIndex uint64 `json:"proposer_index"`
+}
+
+// EpochProposers are the proposers of the slots of an epoch, and the randao mix and seed they
+// were sampled with.
+type EpochProposers struct {
+	Epoch     uint64          `json:"epoch"`
+	Proposers []*SlotProposer `json:"proposers"`
+	Seed      []byte          `json:"seed"`
+	RandaoMix []byte          `json:"randao_mix"`
+	// RandaoMixIndex is the index of the randao mix in the randao mixes of the state.
+	RandaoMixIndex uint64 `json:"randao_mix_index"`
+}
+
+// ProposerLookaheadResponse holds the proposers of the current epoch, and the proposers of the
+// next epoch once they can no longer change.
+type ProposerLookaheadResponse struct {
+	HeadSlot     uint64          `json:"head_slot"`
+	CurrentEpoch *EpochProposers `json:"current_epoch"`
+	NextEpoch    *EpochProposers `json:"next_epoch,omitempty"`
+}
+
+// GetProposerLookahead returns the proposers of the slots of the current epoch, so block relays
+// can prepare for upcoming proposers. The proposers of the next epoch are sampled from the
+// effective balances after the epoch transition, which blocks of the current epoch may still
+// change, so they are only returned once the head block is at the last slot of the current epoch.
+func (bs *Server) GetProposerLookahead(ctx context.Context, _ *ptypes.Empty) (*ProposerLookaheadResponse, error) {
+	headState, err := bs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
+	}
+	epoch := helpers.CurrentEpoch(headState)
+	if clockEpoch := helpers.SlotToEpoch(bs.GenesisTimeFetcher.CurrentSlot()); clockEpoch > epoch {
+		epoch = clockEpoch
+	}
+	st := headState
+	if helpers.CurrentEpoch(st) < epoch {
+		st, err = state.ProcessSlots(ctx, st.Copy(), helpers.StartSlot(epoch))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not process slots up to epoch %d: %v", epoch, err)
+		}
+	}
+	res := &ProposerLookaheadResponse{HeadSlot: headState.Slot()}
+	res.CurrentEpoch, err = epochProposers(st, epoch)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not compute proposers of epoch %d: %v", epoch, err)
+	}
+
+	nextEpochStart := helpers.StartSlot(epoch + 1)
+	if headState.Slot()+1 != nextEpochStart {
+		return res, nil
+	}
+	st, err = state.ProcessSlots(ctx, st.Copy(), nextEpochStart)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not process slots up to epoch %d: %v", epoch+1, err)
+	}
+	res.NextEpoch, err = epochProposers(st, epoch+1)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not compute proposers of epoch %d: %v", epoch+1, err)
+	}
+	return res, nil
+}
+
+// epochProposers computes the proposer of each slot of the epoch the same way as
+// helpers.BeaconProposerIndex, from a state in that epoch.
+func epochProposers(st *stateTrie.BeaconState, epoch uint64) (*EpochProposers, error) {
+	vectorLength := params.BeaconConfig().EpochsPerHistoricalVector
+	mixIndex := (epoch + vectorLength - params.BeaconConfig().MinSeedLookahead - 1) % vectorLength
+	mix, err := helpers.RandaoMix(st, mixIndex)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := helpers.Seed(st, epoch, params.BeaconConfig().DomainBeaconProposer)
+	if err != nil {
+		return nil, err
+	}
+	indices, err := helpers.ActiveValidatorIndices(st, epoch)
+	if err != nil {
+		return nil, err
+	}
+	validators := st.Validators()
+	res := &EpochProposers{
+		Epoch:          epoch,
+		Seed:           seed[:],
+		RandaoMix:      mix,
+		RandaoMixIndex: mixIndex,
+	}
+	start := helpers.StartSlot(epoch)
+	for slot := start; slot < start+params.BeaconConfig().SlotsPerEpoch; slot++ {
+		seedWithSlot := hashutil.Hash(append(seed[:], bytesutil.Bytes8(slot)...))
+		proposerIndex, err := helpers.ComputeProposerIndex(validators, indices, seedWithSlot)
+		if err != nil {
+			return nil, err
+		}
+		res.Proposers = append(res.Proposers, &SlotProposer{Slot: slot, ProposerIndex: proposerIndex})
+	}
+	return res, nil
+}
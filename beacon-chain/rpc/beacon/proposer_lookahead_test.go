@@ -0,0 +1,76 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+
+	ptypes "github.com/gogo/protobuf/types"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestServer_GetProposerLookahead(t *testing.T) {
+	ctx := context.Background()
+	helpers.ClearCache()
+	headState, _ := testutil.DeterministicGenesisState(t, 64)
+	if err := headState.SetSlot(params.BeaconConfig().SlotsPerEpoch); err != nil {
+		t.Fatal(err)
+	}
+	bs := &Server{
+		HeadFetcher:        &mock.ChainService{State: headState},
+		GenesisTimeFetcher: &mock.ChainService{},
+	}
+
+	res, err := bs.GetProposerLookahead(ctx, &ptypes.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.NextEpoch != nil {
+		t.Error("Expected the proposers of the next epoch to be unknown before the last slot of the epoch")
+	}
+	assertProposers(t, headState, res.CurrentEpoch, 1)
+
+	// Once the head block is at the last slot of the epoch, the next epoch is known.
+	if err := headState.SetSlot(2*params.BeaconConfig().SlotsPerEpoch - 1); err != nil {
+		t.Fatal(err)
+	}
+	res, err = bs.GetProposerLookahead(ctx, &ptypes.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertProposers(t, headState, res.CurrentEpoch, 1)
+	if res.NextEpoch == nil {
+		t.Fatal("Expected the proposers of the next epoch to be known at the last slot of the epoch")
+	}
+	nextState, err := state.ProcessSlots(ctx, headState.Copy(), 2*params.BeaconConfig().SlotsPerEpoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertProposers(t, nextState, res.NextEpoch, 2)
+}
+
+func assertProposers(t *testing.T, st *stateTrie.BeaconState, proposers *EpochProposers, epoch uint64) {
+	if proposers.Epoch != epoch {
+		t.Fatalf("Wanted proposers of epoch %d, received epoch %d", epoch, proposers.Epoch)
+	}
+	if uint64(len(proposers.Proposers)) != params.BeaconConfig().SlotsPerEpoch {
+		t.Fatalf("Wanted a proposer per slot, received %d", len(proposers.Proposers))
+	}
+	for _, p := range proposers.Proposers {
+		slotState := st.Copy()
+		if err := slotState.SetSlot(p.Slot); err != nil {
+			t.Fatal(err)
+		}
+		wanted, err := helpers.BeaconProposerIndex(slotState)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.ProposerIndex != wanted {
+			t.Errorf("Wanted proposer %d at slot %d, received %d", wanted, p.Slot, p.ProposerIndex)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package beacon
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/statusutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RegistryExportCSV is the only supported format of a validator registry export.
+const RegistryExportCSV = "csv"
+
+// registryExportHeader lists the columns of a validator registry export.
+var registryExportHeader = []string{
+	"index",
+	"public_key",
+	"status",
+	"activation_eligibility_epoch",
+	"activation_epoch",
+	"exit_epoch",
+	"withdrawable_epoch",
+	"effective_balance",
+	"slashed",
+	"balance",
+}
+
+// RegistryExportRequest selects the epoch and the format of a validator registry export.
+type RegistryExportRequest struct {
+	Epoch  uint64 `json:"epoch"`
+	Format string `json:"format"`
+}
+
+// ExportValidatorRegistry writes every validator of the registry at the requested epoch along
+// with its balance, one row per validator. Past epochs are exported from the state at the start
+// of the epoch, so that the registry and the balances are consistent.
+func (bs *Server) ExportValidatorRegistry(ctx context.Context, req *RegistryExportRequest, w io.Writer) error {
+	if req.Format != "" && req.Format != RegistryExportCSV {
+		return status.Errorf(codes.InvalidArgument, "Unknown export format %q", req.Format)
+	}
+
+	headState, err := bs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Could not get head state: %v", err)
+	}
+	st := headState
+	currentEpoch := helpers.CurrentEpoch(headState)
+	switch {
+	case req.Epoch == currentEpoch:
+	case req.Epoch < currentEpoch:
+		st, err = bs.stateAtEpoch(ctx, req.Epoch)
+		if err != nil {
+			return err
+		}
+	default:
+		return statusutil.FutureEpoch(currentEpoch, req.Epoch)
+	}
+
+	validators := st.Validators()
+	balances := st.Balances()
+	cw := csv.NewWriter(w)
+	if err := cw.Write(registryExportHeader); err != nil {
+		return status.Errorf(codes.Internal, "Could not write export: %v", err)
+	}
+	for i, v := range validators {
+		if ctx.Err() != nil {
+			return status.Errorf(codes.Canceled, "Export canceled: %v", ctx.Err())
+		}
+		row := []string{
+			strconv.Itoa(i),
+			fmt.Sprintf("%#x", v.PublicKey),
+			registryStatus(v, req.Epoch),
+			strconv.FormatUint(v.ActivationEligibilityEpoch, 10),
+			strconv.FormatUint(v.ActivationEpoch, 10),
+			strconv.FormatUint(v.ExitEpoch, 10),
+			strconv.FormatUint(v.WithdrawableEpoch, 10),
+			strconv.FormatUint(v.EffectiveBalance, 10),
+			strconv.FormatBool(v.Slashed),
+			strconv.FormatUint(balances[i], 10),
+		}
+		if err := cw.Write(row); err != nil {
+			return status.Errorf(codes.Internal, "Could not write export: %v", err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return status.Errorf(codes.Internal, "Could not write export: %v", err)
+	}
+	return nil
+}
+
+// registryStatus returns the status of the validator at the given epoch.
+func registryStatus(v *ethpb.Validator, epoch uint64) string {
+	switch {
+	case epoch >= v.WithdrawableEpoch:
+		return "withdrawable"
+	case epoch >= v.ExitEpoch:
+		if v.Slashed {
+			return "slashed"
+		}
+		return "exited"
+	case epoch >= v.ActivationEpoch:
+		if v.Slashed {
+			return "slashing"
+		}
+		if v.ExitEpoch != params.BeaconConfig().FarFutureEpoch {
+			return "exiting"
+		}
+		return "active"
+	default:
+		return "pending"
+	}
+}
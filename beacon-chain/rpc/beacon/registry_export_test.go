@@ -0,0 +1,148 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func registryExportState(t *testing.T) *stateTrie.BeaconState {
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	validators := []*ethpb.Validator{
+		{PublicKey: pubKey(0), ActivationEpoch: 0, ExitEpoch: farFuture, WithdrawableEpoch: farFuture, EffectiveBalance: 32e9},
+		{PublicKey: pubKey(1), ActivationEpoch: 0, ExitEpoch: 10, WithdrawableEpoch: farFuture, EffectiveBalance: 31e9},
+		{PublicKey: pubKey(2), ActivationEpoch: 0, ExitEpoch: 10, WithdrawableEpoch: 20, Slashed: true, EffectiveBalance: 16e9},
+		{PublicKey: pubKey(3), ActivationEpoch: farFuture, ExitEpoch: farFuture, WithdrawableEpoch: farFuture, EffectiveBalance: 32e9},
+	}
+	st, err := stateTrie.InitializeFromProto(&pbp2p.BeaconState{
+		Slot:       5 * params.BeaconConfig().SlotsPerEpoch,
+		Validators: validators,
+		Balances:   []uint64{32e9, 31e9, 16e9, 32e9},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return st
+}
+
+func TestServer_ExportValidatorRegistry_CSV(t *testing.T) {
+	bs := &Server{
+		HeadFetcher: &mock.ChainService{State: registryExportState(t)},
+	}
+	buf := new(bytes.Buffer)
+	if err := bs.ExportValidatorRegistry(context.Background(), &RegistryExportRequest{Epoch: 5}, buf); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 5 {
+		t.Fatalf("Wanted a header and 4 rows, got %d rows", len(rows))
+	}
+	if strings.Join(rows[0], ",") != strings.Join(registryExportHeader, ",") {
+		t.Errorf("Unexpected header %v", rows[0])
+	}
+	wantedStatuses := []string{"active", "exiting", "slashing", "pending"}
+	for i, status := range wantedStatuses {
+		if rows[i+1][2] != status {
+			t.Errorf("Wanted status %s for validator %d, got %s", status, i, rows[i+1][2])
+		}
+	}
+	if rows[1][1] != fmt.Sprintf("%#x", pubKey(0)) {
+		t.Errorf("Wanted public key %#x, got %s", pubKey(0), rows[1][1])
+	}
+	if rows[3][8] != "true" || rows[3][9] != "16000000000" {
+		t.Errorf("Wanted slashed validator with balance 16000000000, got %v", rows[3])
+	}
+}
+
+func TestServer_ExportValidatorRegistry_ArchivedEpoch(t *testing.T) {
+	db := dbTest.SetupDB(t)
+	defer dbTest.TeardownDB(t, db)
+	ctx := context.Background()
+
+	headState := registryExportState(t)
+	blockRoots := make([][]byte, params.BeaconConfig().SlotsPerHistoricalRoot)
+	for i := range blockRoots {
+		blockRoots[i] = make([]byte, 32)
+	}
+	if err := headState.SetBlockRoots(blockRoots); err != nil {
+		t.Fatal(err)
+	}
+	// Only the first 2 validators were deposited at epoch 2, and validator 1 had not exited yet.
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	oldState, err := stateTrie.InitializeFromProto(&pbp2p.BeaconState{
+		Slot: 2 * params.BeaconConfig().SlotsPerEpoch,
+		Validators: []*ethpb.Validator{
+			{PublicKey: pubKey(0), ActivationEpoch: 0, ExitEpoch: farFuture, WithdrawableEpoch: farFuture, EffectiveBalance: 32e9},
+			{PublicKey: pubKey(1), ActivationEpoch: 0, ExitEpoch: farFuture, WithdrawableEpoch: farFuture, EffectiveBalance: 32e9},
+		},
+		Balances: []uint64{30e9, 29e9},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := [32]byte{'a'}
+	if err := headState.UpdateBlockRootAtIndex(oldState.Slot(), root); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, oldState, root); err != nil {
+		t.Fatal(err)
+	}
+	bs := &Server{
+		BeaconDB:    db,
+		HeadFetcher: &mock.ChainService{State: headState},
+	}
+	buf := new(bytes.Buffer)
+	if err := bs.ExportValidatorRegistry(ctx, &RegistryExportRequest{Epoch: 2, Format: RegistryExportCSV}, buf); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("Wanted a header and 2 rows, got %d rows", len(rows))
+	}
+	// The registry fields and the balance are read from the same state.
+	if rows[2][2] != "active" || rows[2][5] != fmt.Sprint(farFuture) || rows[2][7] != "32000000000" {
+		t.Errorf("Wanted validator 1 from the state of epoch 2, got %v", rows[2])
+	}
+	if rows[2][9] != "29000000000" {
+		t.Errorf("Wanted balance 29000000000 at epoch 2, got %s", rows[2][9])
+	}
+}
+
+func TestServer_ExportValidatorRegistry_Errors(t *testing.T) {
+	bs := &Server{
+		HeadFetcher: &mock.ChainService{State: registryExportState(t)},
+	}
+	tests := []struct {
+		name    string
+		req     *RegistryExportRequest
+		wantErr string
+	}{
+		{name: "future epoch", req: &RegistryExportRequest{Epoch: 6}, wantErr: "epoch in the future"},
+		{name: "parquet", req: &RegistryExportRequest{Epoch: 5, Format: "parquet"}, wantErr: "Unknown export format"},
+		{name: "unknown format", req: &RegistryExportRequest{Epoch: 5, Format: "xml"}, wantErr: "Unknown export format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := bs.ExportValidatorRegistry(context.Background(), tt.req, new(bytes.Buffer))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, received %v", tt.wantErr, err)
+			}
+		})
+	}
+}
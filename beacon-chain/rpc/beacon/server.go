@@ -12,7 +12,9 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 )
 
@@ -34,6 +36,10 @@ type Server struct {
 	AttestationNotifier  operation.Notifier
 	AttestationsPool     attestations.Pool
 	SlashingsPool        *slashings.Pool
+	Broadcaster          p2p.Broadcaster
 	CanonicalStateChan   chan *pbp2p.BeaconState
 	ChainStartChan       chan time.Time
+	ValidatorSnapshots   *ValidatorSnapshots
+	StateGen             *stategen.State
+	InteropNumValidators uint64
 }
@@ -3,12 +3,36 @@ package beacon
 import (
 	"context"
 
+	ptypes "github.com/gogo/protobuf/types"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/sliceutil"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// PendingSlashingsResponse lists the slashings of the pool awaiting inclusion in a block.
+type PendingSlashingsResponse struct {
+	AttesterSlashings []*ethpb.AttesterSlashing `json:"attester_slashings"`
+	ProposerSlashings []*ethpb.ProposerSlashing `json:"proposer_slashings"`
+}
+
+// SlashingInclusionRequest identifies a slashing by its hash tree root.
+type SlashingInclusionRequest struct {
+	SlashingRoot []byte `json:"slashing_root"`
+}
+
+// SlashingInclusionResponse reports whether a slashing is pending in the pool or was
+// included in a block, and in which one.
+type SlashingInclusionResponse struct {
+	SlashingRoot []byte `json:"slashing_root"`
+	Pending      bool   `json:"pending"`
+	Included     bool   `json:"included"`
+	Slot         uint64 `json:"slot,omitempty"`
+	BlockRoot    []byte `json:"block_root,omitempty"`
+}
+
 // SubmitProposerSlashing receives a proposer slashing object via
 // RPC and injects it into the beacon node's operations pool.
 // Submission into this pool does not guarantee inclusion into a beacon block.
@@ -23,6 +47,9 @@ func (bs *Server) SubmitProposerSlashing(
 	if err := bs.SlashingsPool.InsertProposerSlashing(beaconState, req); err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not insert proposer slashing into pool: %v", err)
 	}
+	if err := bs.Broadcaster.Broadcast(ctx, req); err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not broadcast proposer slashing: %v", err)
+	}
 	return &ethpb.SubmitSlashingResponse{
 		SlashedIndices: []uint64{req.ProposerIndex},
 	}, nil
@@ -42,8 +69,62 @@ func (bs *Server) SubmitAttesterSlashing(
 	if err := bs.SlashingsPool.InsertAttesterSlashing(beaconState, req); err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not insert attester slashing into pool: %v", err)
 	}
+	if err := bs.Broadcaster.Broadcast(ctx, req); err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not broadcast attester slashing: %v", err)
+	}
 	slashedIndices := sliceutil.IntersectionUint64(req.Attestation_1.AttestingIndices, req.Attestation_2.AttestingIndices)
 	return &ethpb.SubmitSlashingResponse{
 		SlashedIndices: slashedIndices,
 	}, nil
 }
+
+// ListPendingSlashings returns every slashing of the pool awaiting inclusion in a block.
+func (bs *Server) ListPendingSlashings(ctx context.Context, _ *ptypes.Empty) (*PendingSlashingsResponse, error) {
+	return &PendingSlashingsResponse{
+		AttesterSlashings: bs.SlashingsPool.AllPendingAttesterSlashings(),
+		ProposerSlashings: bs.SlashingsPool.AllPendingProposerSlashings(),
+	}, nil
+}
+
+// GetSlashingInclusion reports whether the slashing with the requested hash tree root is
+// pending in the pool, or was included in a block. Only recent inclusions are known.
+func (bs *Server) GetSlashingInclusion(ctx context.Context, req *SlashingInclusionRequest) (*SlashingInclusionResponse, error) {
+	if len(req.SlashingRoot) != 32 {
+		return nil, status.Errorf(codes.InvalidArgument, "Slashing root must be 32 bytes, received %d", len(req.SlashingRoot))
+	}
+	root := bytesutil.ToBytes32(req.SlashingRoot)
+	res := &SlashingInclusionResponse{SlashingRoot: req.SlashingRoot}
+	if inclusion, ok := bs.SlashingsPool.Inclusion(root); ok {
+		res.Included = true
+		res.Slot = inclusion.Slot
+		res.BlockRoot = inclusion.BlockRoot[:]
+		return res, nil
+	}
+
+	pending, err := bs.isPendingSlashing(root)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not compute root of pending slashing: %v", err)
+	}
+	res.Pending = pending
+	return res, nil
+}
+
+func (bs *Server) isPendingSlashing(root [32]byte) (bool, error) {
+	var pending []interface{}
+	for _, s := range bs.SlashingsPool.AllPendingAttesterSlashings() {
+		pending = append(pending, s)
+	}
+	for _, s := range bs.SlashingsPool.AllPendingProposerSlashings() {
+		pending = append(pending, s)
+	}
+	for _, s := range pending {
+		r, err := ssz.HashTreeRoot(s)
+		if err != nil {
+			return false, err
+		}
+		if r == root {
+			return true, nil
+		}
+	}
+	return false, nil
+}
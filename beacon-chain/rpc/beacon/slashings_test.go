@@ -1,14 +1,18 @@
 package beacon
 
 import (
+	"bytes"
 	"context"
 	"strconv"
 	"testing"
 
 	"github.com/gogo/protobuf/proto"
+	ptypes "github.com/gogo/protobuf/types"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	mockp2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/params"
@@ -43,6 +47,7 @@ func TestServer_SubmitProposerSlashing(t *testing.T) {
 			State: st,
 		},
 		SlashingsPool: slashings.NewPool(),
+		Broadcaster:   &mockp2p.MockBroadcaster{},
 	}
 
 	// We want a proposer slashing for validator with index 2 to
@@ -78,6 +83,9 @@ func TestServer_SubmitProposerSlashing(t *testing.T) {
 	if !proto.Equal(wanted, res) {
 		t.Errorf("Wanted %v, received %v", wanted, res)
 	}
+	if !bs.Broadcaster.(*mockp2p.MockBroadcaster).BroadcastCalled {
+		t.Error("Expected proposer slashing to be broadcast")
+	}
 
 	// We do not want a proposer slashing for an already slashed validator
 	// (the validator at index 5) to be included in the pool.
@@ -117,6 +125,7 @@ func TestServer_SubmitAttesterSlashing(t *testing.T) {
 			State: st,
 		},
 		SlashingsPool: slashings.NewPool(),
+		Broadcaster:   &mockp2p.MockBroadcaster{},
 	}
 
 	slashing := &ethpb.AttesterSlashing{
@@ -155,3 +164,92 @@ func TestServer_SubmitAttesterSlashing(t *testing.T) {
 		t.Error("Expected including a attester slashing for an already slashed validator to fail")
 	}
 }
+
+func slashingAttestationData(targetEpoch uint64) *ethpb.AttestationData {
+	return &ethpb.AttestationData{
+		BeaconBlockRoot: make([]byte, 32),
+		Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+		Target:          &ethpb.Checkpoint{Epoch: targetEpoch, Root: make([]byte, 32)},
+	}
+}
+
+func TestServer_GetSlashingInclusion(t *testing.T) {
+	ctx := context.Background()
+	vals := make([]*ethpb.Validator, 10)
+	for i := 0; i < len(vals); i++ {
+		vals[i] = &ethpb.Validator{
+			PublicKey:             make([]byte, 48),
+			WithdrawalCredentials: make([]byte, 32),
+			EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
+			ExitEpoch:             params.BeaconConfig().FarFutureEpoch,
+		}
+	}
+	st, err := stateTrie.InitializeFromProto(&pbp2p.BeaconState{Validators: vals})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs := &Server{
+		HeadFetcher:   &mock.ChainService{State: st},
+		SlashingsPool: slashings.NewPool(),
+		Broadcaster:   &mockp2p.MockBroadcaster{},
+	}
+	slashing := &ethpb.AttesterSlashing{
+		Attestation_1: &ethpb.IndexedAttestation{
+			AttestingIndices: []uint64{1, 2},
+			Data:             slashingAttestationData(0),
+			Signature:        make([]byte, 96),
+		},
+		Attestation_2: &ethpb.IndexedAttestation{
+			AttestingIndices: []uint64{2, 3},
+			Data:             slashingAttestationData(1),
+			Signature:        make([]byte, 96),
+		},
+	}
+	root, err := ssz.HashTreeRoot(slashing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := bs.GetSlashingInclusion(ctx, &SlashingInclusionRequest{SlashingRoot: root[:]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Pending || res.Included {
+		t.Errorf("Expected unknown slashing to be neither pending nor included, received %+v", res)
+	}
+
+	if _, err := bs.SubmitAttesterSlashing(ctx, slashing); err != nil {
+		t.Fatal(err)
+	}
+	pending, err := bs.ListPendingSlashings(ctx, &ptypes.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending.AttesterSlashings) != 1 || !proto.Equal(pending.AttesterSlashings[0], slashing) {
+		t.Errorf("Expected submitted slashing to be pending, received %v", pending.AttesterSlashings)
+	}
+	res, err = bs.GetSlashingInclusion(ctx, &SlashingInclusionRequest{SlashingRoot: root[:]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Pending || res.Included {
+		t.Errorf("Expected submitted slashing to be pending, received %+v", res)
+	}
+
+	blockRoot := [32]byte{'a'}
+	bs.SlashingsPool.MarkIncludedAttesterSlashing(slashing)
+	if err := bs.SlashingsPool.TrackInclusion(slashing, 5, blockRoot); err != nil {
+		t.Fatal(err)
+	}
+	res, err = bs.GetSlashingInclusion(ctx, &SlashingInclusionRequest{SlashingRoot: root[:]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Pending || !res.Included || res.Slot != 5 || !bytes.Equal(res.BlockRoot, blockRoot[:]) {
+		t.Errorf("Expected slashing to be included at slot 5 in block %#x, received %+v", blockRoot, res)
+	}
+
+	if _, err := bs.GetSlashingInclusion(ctx, &SlashingInclusionRequest{SlashingRoot: []byte{1}}); err == nil {
+		t.Error("Expected invalid slashing root to be rejected")
+	}
+}
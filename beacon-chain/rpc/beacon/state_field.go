@@ -0,0 +1,164 @@
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/statusutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Supported encodings of a state field.
+const (
+	StateFieldEncodingJSON = "json"
+	StateFieldEncodingSSZ  = "ssz"
+)
+
+// bytesPerLengthOffset is the size of the offset of a variable size field in an SSZ container.
+const bytesPerLengthOffset = 4
+
+// StateFieldRequest selects a single field of the state at a slot.
+type StateFieldRequest struct {
+	Slot uint64 `json:"slot"`
+	// Field is the name of the field in the spec, such as randao_mixes.
+	Field string `json:"field"`
+	// Encoding is either json, the default, or ssz.
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// StateFieldResponse contains a single field of the state at a slot, in the requested
+// encoding.
+type StateFieldResponse struct {
+	Slot  uint64          `json:"slot"`
+	Field string          `json:"field"`
+	JSON  json.RawMessage `json:"json,omitempty"`
+	SSZ   []byte          `json:"ssz,omitempty"`
+}
+
+// GetStateField returns a single field of the canonical state at the requested slot, for
+// debugging a field without transferring the full state. The state is retrieved from the
+// database by the canonical block root at the slot, which must be within the block roots
+// history of the head state, and is advanced through any skipped slots up to the slot.
+func (bs *Server) GetStateField(ctx context.Context, req *StateFieldRequest) (*StateFieldResponse, error) {
+	encoding := req.Encoding
+	if encoding == "" {
+		encoding = StateFieldEncodingJSON
+	}
+	if encoding != StateFieldEncodingJSON && encoding != StateFieldEncodingSSZ {
+		return nil, status.Errorf(codes.InvalidArgument, "Unknown encoding %q", req.Encoding)
+	}
+	field, ok := stateField(req.Field)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "Unknown state field %q", req.Field)
+	}
+
+	st, err := bs.stateAtSlot(ctx, req.Slot)
+	if err != nil {
+		return nil, err
+	}
+	value := reflect.ValueOf(st.CloneInnerState()).Elem().FieldByIndex(field.Index)
+
+	res := &StateFieldResponse{
+		Slot:  req.Slot,
+		Field: req.Field,
+	}
+	switch encoding {
+	case StateFieldEncodingJSON:
+		res.JSON, err = json.Marshal(value.Interface())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not marshal field %s: %v", req.Field, err)
+		}
+	case StateFieldEncodingSSZ:
+		res.SSZ, err = marshalStateField(field, value)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not marshal field %s: %v", req.Field, err)
+		}
+	}
+	return res, nil
+}
+
+// stateAtSlot returns the canonical state at the slot from the view of the head state.
+func (bs *Server) stateAtSlot(ctx context.Context, slot uint64) (*stateTrie.BeaconState, error) {
+	headState, err := bs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
+	}
+	if slot > headState.Slot() {
+		return nil, statusutil.FutureSlot(headState.Slot(), slot)
+	}
+	if slot == headState.Slot() {
+		return headState, nil
+	}
+	if slot+params.BeaconConfig().SlotsPerHistoricalRoot < headState.Slot() {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Slot %d is older than the block roots history of the head state at slot %d",
+			slot,
+			headState.Slot(),
+		)
+	}
+	root, err := helpers.BlockRootAtSlot(headState, slot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get block root at slot %d: %v", slot, err)
+	}
+	st, err := bs.BeaconDB.State(ctx, bytesutil.ToBytes32(root))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not retrieve state of block %#x: %v", root, err)
+	}
+	if st == nil {
+		return nil, status.Errorf(codes.NotFound, "State of block %#x at slot %d is not in the database", root, slot)
+	}
+	if st.Slot() < slot {
+		st, err = state.ProcessSlots(ctx, st, slot)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not process slots up to %d: %v", slot, err)
+		}
+	}
+	return st, nil
+}
+
+// stateField returns the field of the state with the spec name, which is the name of its
+// JSON tag.
+func stateField(name string) (reflect.StructField, bool) {
+	if name == "" || name == "-" {
+		return reflect.StructField{}, false
+	}
+	t := reflect.TypeOf(pbp2p.BeaconState{})
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if strings.Split(f.Tag.Get("json"), ",")[0] == name {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// marshalStateField SSZ encodes a state field on its own. The field is encoded as the only
+// field of a container with the same SSZ tags, without the offset prepended to variable size
+// fields such as lists.
+func marshalStateField(field reflect.StructField, value reflect.Value) ([]byte, error) {
+	container := reflect.New(reflect.StructOf([]reflect.StructField{{
+		Name: field.Name,
+		Type: field.Type,
+		Tag:  field.Tag,
+	}})).Elem()
+	container.Field(0).Set(value)
+	enc, err := ssz.Marshal(container.Interface())
+	if err != nil {
+		return nil, err
+	}
+	if strings.Contains(field.Tag.Get("ssz-size"), "?") || field.Tag.Get("ssz-max") != "" {
+		return enc[bytesPerLengthOffset:], nil
+	}
+	return enc, nil
+}
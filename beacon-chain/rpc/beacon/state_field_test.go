@@ -0,0 +1,107 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestServer_GetStateField(t *testing.T) {
+	db := dbTest.SetupDB(t)
+	defer dbTest.TeardownDB(t, db)
+	ctx := context.Background()
+
+	headState, _ := testutil.DeterministicGenesisState(t, 4)
+	if err := headState.SetSlot(4); err != nil {
+		t.Fatal(err)
+	}
+	// The state at slot 2 is stored by the canonical block root at slot 2.
+	oldState := headState.Copy()
+	if err := oldState.SetSlot(2); err != nil {
+		t.Fatal(err)
+	}
+	if err := oldState.UpdateBalancesAtIndex(0, 31e9); err != nil {
+		t.Fatal(err)
+	}
+	root := [32]byte{'a'}
+	if err := headState.UpdateBlockRootAtIndex(2, root); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, oldState, root); err != nil {
+		t.Fatal(err)
+	}
+
+	bs := &Server{
+		BeaconDB:    db,
+		HeadFetcher: &mock.ChainService{State: headState},
+	}
+
+	res, err := bs.GetStateField(ctx, &StateFieldRequest{Slot: 4, Field: "slot"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(res.JSON) != "4" {
+		t.Errorf("Wanted slot 4 as JSON, received %s", res.JSON)
+	}
+
+	res, err = bs.GetStateField(ctx, &StateFieldRequest{Slot: 2, Field: "balances", Encoding: StateFieldEncodingSSZ})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wanted := make([]byte, 0, 8*len(oldState.Balances()))
+	for _, balance := range oldState.Balances() {
+		b := make([]byte, 8)
+		binary.LittleEndian.PutUint64(b, balance)
+		wanted = append(wanted, b...)
+	}
+	if !bytes.Equal(res.SSZ, wanted) {
+		t.Errorf("Wanted balances %#x as SSZ, received %#x", wanted, res.SSZ)
+	}
+
+	res, err = bs.GetStateField(ctx, &StateFieldRequest{Slot: 2, Field: "justification_bits", Encoding: StateFieldEncodingSSZ})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(res.SSZ, oldState.JustificationBits()) {
+		t.Errorf("Wanted justification bits %#x as SSZ, received %#x", oldState.JustificationBits(), res.SSZ)
+	}
+}
+
+func TestServer_GetStateField_Errors(t *testing.T) {
+	db := dbTest.SetupDB(t)
+	defer dbTest.TeardownDB(t, db)
+
+	headState, _ := testutil.DeterministicGenesisState(t, 4)
+	if err := headState.SetSlot(4); err != nil {
+		t.Fatal(err)
+	}
+	bs := &Server{
+		BeaconDB:    db,
+		HeadFetcher: &mock.ChainService{State: headState},
+	}
+
+	tests := []struct {
+		name    string
+		req     *StateFieldRequest
+		wantErr string
+	}{
+		{name: "unknown field", req: &StateFieldRequest{Field: "foo"}, wantErr: "Unknown state field"},
+		{name: "unknown encoding", req: &StateFieldRequest{Field: "slot", Encoding: "xml"}, wantErr: "Unknown encoding"},
+		{name: "future slot", req: &StateFieldRequest{Slot: 5, Field: "slot"}, wantErr: "slot in the future"},
+		{name: "missing state", req: &StateFieldRequest{Slot: 3, Field: "slot"}, wantErr: "is not in the database"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := bs.GetStateField(context.Background(), tt.req)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, received %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package beacon
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ValidatorEpochsRequest lists the public keys of the validators whose lifecycle epochs are
+// requested at an epoch.
+type ValidatorEpochsRequest struct {
+	Epoch      uint64   `json:"epoch"`
+	PublicKeys [][]byte `json:"public_keys"`
+}
+
+// ValidatorEpochs are the lifecycle epochs and slashed status of a validator at an epoch.
+type ValidatorEpochs struct {
+	PublicKey                  []byte `json:"public_key"`
+	Index                      uint64 `json:"index"`
+	ActivationEligibilityEpoch uint64 `json:"activation_eligibility_epoch"`
+	ActivationEpoch            uint64 `json:"activation_epoch"`
+	ExitEpoch                  uint64 `json:"exit_epoch"`
+	WithdrawableEpoch          uint64 `json:"withdrawable_epoch"`
+	Slashed                    bool   `json:"slashed"`
+}
+
+// ValidatorEpochsResponse contains the lifecycle epochs of the requested validators, in the
+// order of the request.
+type ValidatorEpochsResponse struct {
+	Epoch      uint64             `json:"epoch"`
+	Validators []*ValidatorEpochs `json:"validators"`
+	// MissingPublicKeys lists the requested public keys of validators which were not deposited
+	// at the epoch.
+	MissingPublicKeys [][]byte `json:"missing_public_keys,omitempty"`
+}
+
+// GetValidatorEpochs returns the activation, exit and withdrawable epochs and the slashed status
+// of a batch of validators in the state at the start of the requested epoch, so accounting
+// systems can compute the reward accrual window of many validators at once. States older than
+// the saved states are regenerated from the closest archived point.
+func (bs *Server) GetValidatorEpochs(ctx context.Context, req *ValidatorEpochsRequest) (*ValidatorEpochsResponse, error) {
+	if len(req.PublicKeys) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "No public keys requested")
+	}
+	if len(req.PublicKeys) > flags.Get().MaxPageSize {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Requested %d public keys, more than the max allowed of %d",
+			len(req.PublicKeys),
+			flags.Get().MaxPageSize,
+		)
+	}
+	st, err := bs.stateAtEpoch(ctx, req.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ValidatorEpochsResponse{
+		Epoch:      req.Epoch,
+		Validators: make([]*ValidatorEpochs, 0, len(req.PublicKeys)),
+	}
+	for _, pubKey := range req.PublicKeys {
+		idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubKey))
+		if !ok {
+			res.MissingPublicKeys = append(res.MissingPublicKeys, pubKey)
+			continue
+		}
+		v, err := st.ValidatorAtIndexReadOnly(idx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get validator %d: %v", idx, err)
+		}
+		res.Validators = append(res.Validators, &ValidatorEpochs{
+			PublicKey:                  pubKey,
+			Index:                      idx,
+			ActivationEligibilityEpoch: v.ActivationEligibilityEpoch(),
+			ActivationEpoch:            v.ActivationEpoch(),
+			ExitEpoch:                  v.ExitEpoch(),
+			WithdrawableEpoch:          v.WithdrawableEpoch(),
+			Slashed:                    v.Slashed(),
+		})
+	}
+	return res, nil
+}
+
+// stateAtEpoch returns the canonical state at the start slot of the epoch. Finalized states
+// which are not saved, or older than the block roots history of the head state, are
+// regenerated by replaying blocks from the closest archived point.
+func (bs *Server) stateAtEpoch(ctx context.Context, epoch uint64) (*stateTrie.BeaconState, error) {
+	slot := helpers.StartSlot(epoch)
+	st, err := bs.stateAtSlot(ctx, slot)
+	if err == nil {
+		return st, nil
+	}
+	if code := status.Code(err); (code != codes.NotFound && code != codes.InvalidArgument) || bs.StateGen == nil {
+		return nil, err
+	}
+	if slot > helpers.StartSlot(bs.FinalizationFetcher.FinalizedCheckpt().Epoch) {
+		return nil, err
+	}
+	st, err = bs.StateGen.StateBySlot(ctx, slot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not regenerate state at slot %d: %v", slot, err)
+	}
+	return st, nil
+}
@@ -0,0 +1,74 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServer_GetValidatorEpochs(t *testing.T) {
+	bs := &Server{
+		HeadFetcher: &mock.ChainService{State: registryExportState(t)},
+	}
+	unknownKey := pubKey(10)
+	res, err := bs.GetValidatorEpochs(context.Background(), &ValidatorEpochsRequest{
+		Epoch:      5,
+		PublicKeys: [][]byte{pubKey(2), unknownKey, pubKey(1)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Validators) != 2 {
+		t.Fatalf("Wanted 2 validators, received %d", len(res.Validators))
+	}
+	slashed := res.Validators[0]
+	if slashed.Index != 2 || !bytes.Equal(slashed.PublicKey, pubKey(2)) {
+		t.Errorf("Wanted validator 2 first, received validator %d", slashed.Index)
+	}
+	if slashed.ExitEpoch != 10 || slashed.WithdrawableEpoch != 20 || !slashed.Slashed {
+		t.Errorf("Unexpected epochs of slashed validator: %+v", slashed)
+	}
+	exiting := res.Validators[1]
+	if exiting.Index != 1 || exiting.ExitEpoch != 10 || exiting.WithdrawableEpoch != params.BeaconConfig().FarFutureEpoch || exiting.Slashed {
+		t.Errorf("Unexpected epochs of exiting validator: %+v", exiting)
+	}
+	if len(res.MissingPublicKeys) != 1 || !bytes.Equal(res.MissingPublicKeys[0], unknownKey) {
+		t.Errorf("Wanted missing public key %#x, received %#x", unknownKey, res.MissingPublicKeys)
+	}
+}
+
+func TestServer_GetValidatorEpochs_FutureEpoch(t *testing.T) {
+	bs := &Server{
+		HeadFetcher: &mock.ChainService{State: registryExportState(t)},
+	}
+	_, err := bs.GetValidatorEpochs(context.Background(), &ValidatorEpochsRequest{
+		Epoch:      6,
+		PublicKeys: [][]byte{pubKey(0)},
+	})
+	if status.Code(err) != codes.OutOfRange {
+		t.Errorf("Wanted out of range error for a future epoch, received %v", err)
+	}
+}
+
+func TestServer_GetValidatorEpochs_TooManyKeys(t *testing.T) {
+	previous := flags.Get()
+	flags.Init(&flags.GlobalFlags{MaxPageSize: 2})
+	defer flags.Init(previous)
+
+	bs := &Server{
+		HeadFetcher: &mock.ChainService{State: registryExportState(t)},
+	}
+	_, err := bs.GetValidatorEpochs(context.Background(), &ValidatorEpochsRequest{
+		Epoch:      5,
+		PublicKeys: [][]byte{pubKey(0), pubKey(1), pubKey(2)},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Wanted invalid argument error for too many keys, received %v", err)
+	}
+}
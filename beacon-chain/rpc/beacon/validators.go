@@ -12,16 +12,19 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/validators"
 	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/pagination"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/statusutil"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 // ListValidatorBalances retrieves the validator balances for a given set of public keys.
 // An optional Epoch parameter is provided to request historical validator balances from
-// archived, persistent data.
+// archived, persistent data. The pages of a listing of the current epoch are read from a
+// snapshot of the head state the first page was read from, carried by the next page token.
 func (bs *Server) ListValidatorBalances(
 	ctx context.Context,
 	req *ethpb.ListValidatorBalancesRequest) (*ethpb.ValidatorBalances, error) {
@@ -30,12 +33,31 @@ func (bs *Server) ListValidatorBalances(
 			req.PageSize, flags.Get().MaxPageSize)
 	}
 
+	pageToken, snapshotRoot, fromSnapshot, err := parseSnapshotPageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Could not parse page token: %v", err)
+	}
+
 	res := make([]*ethpb.ValidatorBalances_Balance, 0)
 	filtered := map[uint64]bool{} // Track filtered validators to prevent duplication in the response.
 
-	headState, err := bs.HeadFetcher.HeadState(ctx)
-	if err != nil {
-		return nil, status.Error(codes.Internal, "Could not get head state")
+	var headState *stateTrie.BeaconState
+	if fromSnapshot {
+		if bs.ValidatorSnapshots != nil {
+			headState = bs.ValidatorSnapshots.get(snapshotRoot)
+		}
+		if headState == nil {
+			return nil, status.Errorf(
+				codes.FailedPrecondition,
+				"Snapshot %#x of the listing expired, restart from the first page",
+				snapshotRoot,
+			)
+		}
+	} else {
+		headState, err = bs.HeadFetcher.HeadState(ctx)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "Could not get head state")
+		}
 	}
 
 	var requestingGenesis bool
@@ -67,12 +89,7 @@ func (bs *Server) ListValidatorBalances(
 		balances = headState.Balances()
 	} else {
 		// Otherwise, we are requesting data from the future and we return an error.
-		return nil, status.Errorf(
-			codes.InvalidArgument,
-			"Cannot retrieve information about an epoch in the future, current epoch %d, requesting %d",
-			helpers.CurrentEpoch(headState),
-			epoch,
-		)
+		return nil, statusutil.FutureEpoch(helpers.CurrentEpoch(headState), epoch)
 	}
 
 	balancesCount := len(balances)
@@ -136,7 +153,7 @@ func (bs *Server) ListValidatorBalances(
 		}, nil
 	}
 
-	start, end, nextPageToken, err := pagination.StartAndEndPage(req.PageToken, int(req.PageSize), balancesCount)
+	start, end, nextPageToken, err := pagination.StartAndEndPage(pageToken, int(req.PageSize), balancesCount)
 	if err != nil {
 		return nil, status.Errorf(
 			codes.Internal,
@@ -144,11 +161,21 @@ func (bs *Server) ListValidatorBalances(
 			err,
 		)
 	}
+	// Archived balances do not change, only the listings of the head state are pinned.
+	if !requestingGenesis && epoch == helpers.CurrentEpoch(headState) {
+		nextPageToken, err = bs.snapshotNextPageToken(ctx, headState, nextPageToken, snapshotRoot, fromSnapshot)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not snapshot state of the listing: %v", err)
+		}
+	}
 
 	if len(req.Indices) == 0 && len(req.PublicKeys) == 0 {
 		// Return everything.
 		for i := start; i < end; i++ {
-			pubkey := headState.PubkeyAtIndex(uint64(i))
+			pubkey, err := headState.PubkeyAtIndex(uint64(i))
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "Could not get public key of validator %d: %v", i, err)
+			}
 			res = append(res, &ethpb.ValidatorBalances_Balance{
 				PublicKey: pubkey[:],
 				Index:     uint64(i),
@@ -222,12 +249,7 @@ func (bs *Server) ListValidators(
 		validatorList = validatorList[:stopIdx]
 	} else if requestedEpoch > currentEpoch {
 		// Otherwise, we are requesting data from the future and we return an error.
-		return nil, status.Errorf(
-			codes.InvalidArgument,
-			"Cannot retrieve information about an epoch in the future, current epoch %d, requesting %d",
-			currentEpoch,
-			requestedEpoch,
-		)
+		return nil, statusutil.FutureEpoch(currentEpoch, requestedEpoch)
 	}
 
 	// Filter active validators if the request specifies it.
@@ -305,7 +327,10 @@ func (bs *Server) GetValidator(
 	}
 	pk48 := bytesutil.ToBytes48(pubKey)
 	for i := 0; i < headState.NumValidators(); i++ {
-		keyFromState := headState.PubkeyAtIndex(uint64(i))
+		keyFromState, err := headState.PubkeyAtIndex(uint64(i))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get public key of validator %d: %v", i, err)
+		}
 		if keyFromState == pk48 {
 			return headState.ValidatorAtIndex(uint64(i))
 		}
@@ -368,12 +393,7 @@ func (bs *Server) GetValidatorActiveSetChanges(
 		}
 	} else {
 		// We are requesting data from the future and we return an error.
-		return nil, status.Errorf(
-			codes.InvalidArgument,
-			"Cannot retrieve information about an epoch in the future, current epoch %d, requesting %d",
-			currentEpoch,
-			requestedEpoch,
-		)
+		return nil, statusutil.FutureEpoch(currentEpoch, requestedEpoch)
 	}
 
 	// We retrieve the public keys for the indices.
@@ -381,15 +401,24 @@ func (bs *Server) GetValidatorActiveSetChanges(
 	slashedKeys := make([][]byte, len(slashedIndices))
 	exitedKeys := make([][]byte, len(exitedIndices))
 	for i, idx := range activatedIndices {
-		pubkey := headState.PubkeyAtIndex(idx)
+		pubkey, err := headState.PubkeyAtIndex(idx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get public key of validator %d: %v", idx, err)
+		}
 		activatedKeys[i] = pubkey[:]
 	}
 	for i, idx := range slashedIndices {
-		pubkey := headState.PubkeyAtIndex(idx)
+		pubkey, err := headState.PubkeyAtIndex(idx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get public key of validator %d: %v", idx, err)
+		}
 		slashedKeys[i] = pubkey[:]
 	}
 	for i, idx := range exitedIndices {
-		pubkey := headState.PubkeyAtIndex(idx)
+		pubkey, err := headState.PubkeyAtIndex(idx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get public key of validator %d: %v", idx, err)
+		}
 		exitedKeys[i] = pubkey[:]
 	}
 	return &ethpb.ActiveSetChanges{
@@ -448,19 +477,14 @@ func (bs *Server) GetValidatorParticipation(
 	} else if requestedEpoch == currentEpoch {
 		// We cannot retrieve participation for an epoch currently in progress.
 		return nil, status.Errorf(
-			codes.InvalidArgument,
+			codes.OutOfRange,
 			"Cannot retrieve information about an epoch currently in progress, current epoch %d, requesting %d",
 			currentEpoch,
 			requestedEpoch,
 		)
 	} else if requestedEpoch > currentEpoch {
 		// We are requesting data from the future and we return an error.
-		return nil, status.Errorf(
-			codes.InvalidArgument,
-			"Cannot retrieve information about an epoch in the future, current epoch %d, requesting %d",
-			currentEpoch,
-			requestedEpoch,
-		)
+		return nil, statusutil.FutureEpoch(currentEpoch, requestedEpoch)
 	}
 
 	p := bs.ParticipationFetcher.Participation(requestedEpoch)
@@ -24,6 +24,9 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/statusutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func init() {
@@ -53,15 +56,22 @@ func TestServer_ListValidatorBalances_CannotRequestFutureEpoch(t *testing.T) {
 	}
 
 	wanted := "Cannot retrieve information about an epoch in the future"
-	if _, err := bs.ListValidatorBalances(
+	_, err = bs.ListValidatorBalances(
 		ctx,
 		&ethpb.ListValidatorBalancesRequest{
 			QueryFilter: &ethpb.ListValidatorBalancesRequest_Epoch{
 				Epoch: 1,
 			},
 		},
-	); err != nil && !strings.Contains(err.Error(), wanted) {
-		t.Errorf("Expected error %v, received %v", wanted, err)
+	)
+	if err == nil || !strings.Contains(err.Error(), wanted) {
+		t.Fatalf("Expected error %v, received %v", wanted, err)
+	}
+	if status.Code(err) != codes.OutOfRange {
+		t.Errorf("Expected out of range error, received %v", status.Code(err))
+	}
+	if epoch, ok := statusutil.CurrentEpoch(err); !ok || epoch != 0 {
+		t.Errorf("Expected the current epoch in the error details, received %d", epoch)
 	}
 }
 
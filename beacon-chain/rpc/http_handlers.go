@@ -0,0 +1,981 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	ptypes "github.com/gogo/protobuf/types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/beacon"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/validator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AttestationInclusionProofHandler is a handler to serve the /attestations/proof page in
+// metrics. The attestation is looked up by the validator_index and epoch query parameters
+// of a GET request, or by the JSON encoded beacon.AttestationInclusionProofRequest in the
+// body of a POST request.
+func (s *Service) AttestationInclusionProofHandler(w http.ResponseWriter, r *http.Request) {
+	if s.beaconChainServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	req := &beacon.AttestationInclusionProofRequest{}
+	switch r.Method {
+	case http.MethodGet:
+		var err error
+		req.ValidatorIndex, err = strconv.ParseUint(r.URL.Query().Get("validator_index"), 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid validator_index parameter", http.StatusBadRequest)
+			return
+		}
+		req.Epoch, err = strconv.ParseUint(r.URL.Query().Get("epoch"), 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid epoch parameter", http.StatusBadRequest)
+			return
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			http.Error(w, "Could not decode request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	res, err := s.beaconChainServer.AttestationInclusionProof(r.Context(), req)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// DepositInclusionProofHandler is a handler to serve the /deposits/proof page in metrics. It
+// writes the deposit data of the deposit_index query parameter and its verified merkle proof
+// against the deposit root of the state at the slot query parameter as JSON.
+func (s *Service) DepositInclusionProofHandler(w http.ResponseWriter, r *http.Request) {
+	if s.beaconChainServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	req := &beacon.DepositInclusionProofRequest{}
+	var err error
+	if req.DepositIndex, err = strconv.ParseUint(r.URL.Query().Get("deposit_index"), 10, 64); err != nil {
+		http.Error(w, "Invalid deposit_index parameter", http.StatusBadRequest)
+		return
+	}
+	if req.Slot, err = strconv.ParseUint(r.URL.Query().Get("slot"), 10, 64); err != nil {
+		http.Error(w, "Invalid slot parameter", http.StatusBadRequest)
+		return
+	}
+	res, err := s.beaconChainServer.DepositInclusionProof(r.Context(), req)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// BlockProposalDryRunHandler is a handler to serve the /validator/block/dry_run page of the
+// gateway. It assembles an unsigned block proposal for the slot query parameter of a GET
+// request, or for the JSON encoded validator.ProposalDryRunRequest in the body of a POST
+// request, and returns it along with the time spent assembling it.
+func (s *Service) BlockProposalDryRunHandler(w http.ResponseWriter, r *http.Request) {
+	if s.validatorServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if s.readOnly {
+		http.Error(w, readOnlyMessage, http.StatusForbidden)
+		return
+	}
+	req := &validator.ProposalDryRunRequest{}
+	switch r.Method {
+	case http.MethodGet:
+		var err error
+		req.Slot, err = strconv.ParseUint(r.URL.Query().Get("slot"), 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid slot parameter", http.StatusBadRequest)
+			return
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			http.Error(w, "Could not decode request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	res, err := s.validatorServer.GetBlockDryRun(r.Context(), req)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// BlocksByRootsHandler is a handler to serve the /blocks/roots page in metrics. It writes
+// the blocks of the hex encoded root query parameters of a GET request, or of the JSON
+// encoded beacon.ListBlocksByRootsRequest in the body of a POST request, as JSON. The
+// blocks are written as an SSZ encoded list of signed blocks instead if the encoding query
+// parameter is set to ssz.
+func (s *Service) BlocksByRootsHandler(w http.ResponseWriter, r *http.Request) {
+	if s.beaconChainServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	req := &beacon.ListBlocksByRootsRequest{}
+	switch r.Method {
+	case http.MethodGet:
+		for _, root := range r.URL.Query()["root"] {
+			blockRoot, err := hex.DecodeString(strings.TrimPrefix(root, "0x"))
+			if err != nil {
+				http.Error(w, "Invalid root parameter", http.StatusBadRequest)
+				return
+			}
+			req.BlockRoots = append(req.BlockRoots, blockRoot)
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			http.Error(w, "Could not decode request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	res, err := s.beaconChainServer.ListBlocksByRoots(r.Context(), req)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	if r.URL.Query().Get("encoding") != beacon.StateFieldEncodingSSZ {
+		writeJSON(w, res)
+		return
+	}
+	blocks := make([]*ethpb.SignedBeaconBlock, len(res.BlockContainers))
+	for i, c := range res.BlockContainers {
+		blocks[i] = c.Block
+	}
+	enc, err := ssz.Marshal(blocks)
+	if err != nil {
+		http.Error(w, "Could not encode blocks: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(enc); err != nil {
+		log.WithError(err).Error("Failed to write blocks")
+	}
+}
+
+// BlocksAtSlotHandler is a handler to serve the /blocks/slot page in metrics. It writes all the
+// blocks known at the slot query parameter as JSON, including the non-canonical blocks of forks
+// and the blocks orphaned by reorgs, along with whether each block is canonical.
+func (s *Service) BlocksAtSlotHandler(w http.ResponseWriter, r *http.Request) {
+	if s.beaconChainServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	slot, err := strconv.ParseUint(r.URL.Query().Get("slot"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid slot parameter", http.StatusBadRequest)
+		return
+	}
+	res, err := s.beaconChainServer.ListBlocksAtSlot(r.Context(), slot)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// ValidatedProposalHandler is a handler to serve the /validator/block/propose page of the
+// gateway. It validates the JSON encoded signed block in the body of a POST request
+// against the state of its parent and only broadcasts it if it is valid, returning the
+// reason it was rejected otherwise.
+func (s *Service) ValidatedProposalHandler(w http.ResponseWriter, r *http.Request) {
+	if s.validatorServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if s.readOnly {
+		http.Error(w, readOnlyMessage, http.StatusForbidden)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	blk := &ethpb.SignedBeaconBlock{}
+	if err := json.NewDecoder(r.Body).Decode(blk); err != nil {
+		http.Error(w, "Could not decode block: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	res, err := s.validatorServer.ProposeBlockValidated(r.Context(), blk)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// SubmitAttestationsHandler is a handler to serve the /validator/attestations page of the
+// gateway. It broadcasts the signed attestations of the JSON encoded validator.SubmitAttestationsRequest
+// in the body of a POST request, and writes the status of each attestation as JSON.
+func (s *Service) SubmitAttestationsHandler(w http.ResponseWriter, r *http.Request) {
+	if s.validatorServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if s.readOnly {
+		http.Error(w, readOnlyMessage, http.StatusForbidden)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	req := &validator.SubmitAttestationsRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		http.Error(w, "Could not decode attestations: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	res, err := s.validatorServer.SubmitAttestations(r.Context(), req)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// ValidatorRegistryExportHandler is a handler to serve the /validators/export page of the
+// gateway. It writes the validator registry and balances at the epoch query parameter, the
+// current epoch by default, in the format query parameter.
+func (s *Service) ValidatorRegistryExportHandler(w http.ResponseWriter, r *http.Request) {
+	if s.beaconChainServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	req := &beacon.RegistryExportRequest{Format: r.URL.Query().Get("format")}
+	if epoch := r.URL.Query().Get("epoch"); epoch != "" {
+		var err error
+		req.Epoch, err = strconv.ParseUint(epoch, 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid epoch parameter", http.StatusBadRequest)
+			return
+		}
+	} else {
+		headState, err := s.headFetcher.HeadState(r.Context())
+		if err != nil {
+			http.Error(w, "Could not get head state", http.StatusInternalServerError)
+			return
+		}
+		req.Epoch = helpers.CurrentEpoch(headState)
+	}
+	// The export is buffered so that errors can still be reported with an error status.
+	buf := new(bytes.Buffer)
+	if err := s.beaconChainServer.ExportValidatorRegistry(r.Context(), req, buf); err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=validators_%d.csv", req.Epoch))
+	w.WriteHeader(http.StatusOK)
+	if _, err := buf.WriteTo(w); err != nil {
+		log.WithError(err).Error("Failed to write validator registry export")
+	}
+}
+
+// ParticipationHandler is a handler to serve the /participation page in metrics. It writes
+// the participation rate of the previous and current epoch of the head state as JSON.
+func (s *Service) ParticipationHandler(w http.ResponseWriter, r *http.Request) {
+	if s.beaconChainServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	res, err := s.beaconChainServer.GetParticipationRates(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// DutiesLookaheadHandler is a handler to serve the /validator/duties page in metrics. It
+// returns the duties at the epoch and the next epoch of the validators with the hex encoded
+// public_key query parameters of a GET request, or of the JSON encoded duties request in the
+// body of a POST request.
+func (s *Service) DutiesLookaheadHandler(w http.ResponseWriter, r *http.Request) {
+	if s.validatorServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	req := &ethpb.DutiesRequest{}
+	switch r.Method {
+	case http.MethodGet:
+		var err error
+		req.Epoch, err = strconv.ParseUint(r.URL.Query().Get("epoch"), 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid epoch parameter", http.StatusBadRequest)
+			return
+		}
+		for _, key := range r.URL.Query()["public_key"] {
+			pubKey, err := hex.DecodeString(strings.TrimPrefix(key, "0x"))
+			if err != nil {
+				http.Error(w, "Invalid public_key parameter", http.StatusBadRequest)
+				return
+			}
+			req.PublicKeys = append(req.PublicKeys, pubKey)
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			http.Error(w, "Could not decode request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	res, err := s.validatorServer.GetDutiesWithLookahead(r.Context(), req)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// ActivationEstimateHandler is a handler to serve the /validator/activation_estimate page in
+// metrics. It writes the activation epoch of the validator of the public_key query parameter,
+// and the epoch it is expected to be activated at while in the activation queue, as JSON.
+func (s *Service) ActivationEstimateHandler(w http.ResponseWriter, r *http.Request) {
+	if s.validatorServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	pubKey, err := hex.DecodeString(strings.TrimPrefix(r.URL.Query().Get("public_key"), "0x"))
+	if err != nil || len(pubKey) == 0 {
+		http.Error(w, "Invalid public_key parameter", http.StatusBadRequest)
+		return
+	}
+	res, err := s.validatorServer.GetActivationEstimate(r.Context(), pubKey)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// SyncStatusHandler is a handler to serve the /sync/status page in metrics. It writes the
+// sync progress of the node as JSON, with the estimated time remaining in nanoseconds.
+func (s *Service) SyncStatusHandler(w http.ResponseWriter, r *http.Request) {
+	if s.nodeServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	res, err := s.nodeServer.GetSyncProgress(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// PeerScoresHandler is a handler to serve the /p2p/scores page in metrics. It writes the gossip
+// scores of the connected peers as JSON, from the lowest score to the highest.
+func (s *Service) PeerScoresHandler(w http.ResponseWriter, r *http.Request) {
+	if s.nodeServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	res, err := s.nodeServer.ListPeerScores(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// IdentityHandler is a handler to serve the /p2p/identity page in metrics. It writes the peer ID,
+// ENR, listening multiaddrs and attestation subnets of the node as JSON.
+func (s *Service) IdentityHandler(w http.ResponseWriter, r *http.Request) {
+	if s.nodeServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	res, err := s.nodeServer.GetIdentity(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// BalanceHistoryHandler is a handler to serve the /validators/balances/history page in
+// metrics. It writes the balance history of the validator_index query parameter between the
+// start_epoch and end_epoch query parameters as JSON, with the optional step and downsample
+// query parameters setting its resolution.
+func (s *Service) BalanceHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	if s.beaconChainServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	req := &beacon.BalanceHistoryRequest{Downsample: query.Get("downsample")}
+	params := []struct {
+		name  string
+		value *uint64
+	}{
+		{name: "validator_index", value: &req.ValidatorIndex},
+		{name: "start_epoch", value: &req.StartEpoch},
+		{name: "end_epoch", value: &req.EndEpoch},
+		{name: "step", value: &req.Step},
+	}
+	for _, param := range params {
+		if query.Get(param.name) == "" && param.name == "step" {
+			continue
+		}
+		v, err := strconv.ParseUint(query.Get(param.name), 10, 64)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid %s parameter", param.name), http.StatusBadRequest)
+			return
+		}
+		*param.value = v
+	}
+	res, err := s.beaconChainServer.GetBalanceHistory(r.Context(), req)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// ValidatorDepositsHandler is a handler to serve the /validators/deposits page in metrics. It
+// writes all deposits, top-ups included, of the hex encoded public_key query parameter as JSON.
+func (s *Service) ValidatorDepositsHandler(w http.ResponseWriter, r *http.Request) {
+	if s.beaconChainServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	pubKey, err := hex.DecodeString(strings.TrimPrefix(r.URL.Query().Get("public_key"), "0x"))
+	if err != nil {
+		http.Error(w, "Invalid public_key parameter", http.StatusBadRequest)
+		return
+	}
+	res, err := s.beaconChainServer.ListValidatorDeposits(r.Context(), &beacon.ValidatorDepositsRequest{PublicKey: pubKey})
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// ValidatorEpochsHandler is a handler to serve the /validators/epochs page of the gateway. It writes
+// the activation, exit and withdrawable epochs and slashed status at the epoch query parameter
+// of the validators with the hex encoded public_key query parameters of a GET request, or of the
+// JSON encoded beacon.ValidatorEpochsRequest in the body of a POST request.
+func (s *Service) ValidatorEpochsHandler(w http.ResponseWriter, r *http.Request) {
+	if s.beaconChainServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	req := &beacon.ValidatorEpochsRequest{}
+	switch r.Method {
+	case http.MethodGet:
+		var err error
+		req.Epoch, err = strconv.ParseUint(r.URL.Query().Get("epoch"), 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid epoch parameter", http.StatusBadRequest)
+			return
+		}
+		for _, key := range r.URL.Query()["public_key"] {
+			pubKey, err := hex.DecodeString(strings.TrimPrefix(key, "0x"))
+			if err != nil {
+				http.Error(w, "Invalid public_key parameter", http.StatusBadRequest)
+				return
+			}
+			req.PublicKeys = append(req.PublicKeys, pubKey)
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			http.Error(w, "Could not decode request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	res, err := s.beaconChainServer.GetValidatorEpochs(r.Context(), req)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// CommitteeProofHandler is a handler to serve the /validators/committee_proof page in metrics.
+// It writes the committee of the validator_index query parameter in the epoch query parameter,
+// with a proof of the randao mix seeding the committee in the head state root, as JSON.
+func (s *Service) CommitteeProofHandler(w http.ResponseWriter, r *http.Request) {
+	if s.beaconChainServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	req := &beacon.CommitteeProofRequest{}
+	var err error
+	if req.ValidatorIndex, err = strconv.ParseUint(query.Get("validator_index"), 10, 64); err != nil {
+		http.Error(w, "Invalid validator_index parameter", http.StatusBadRequest)
+		return
+	}
+	if req.Epoch, err = strconv.ParseUint(query.Get("epoch"), 10, 64); err != nil {
+		http.Error(w, "Invalid epoch parameter", http.StatusBadRequest)
+		return
+	}
+	res, err := s.beaconChainServer.GetCommitteeProof(r.Context(), req)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// ExitQueueHandler is a handler to serve the /validators/exit_queue page in metrics. It writes
+// the exit queue and churn limit of the head state, and the estimated exit and withdrawable
+// epochs of a validator exiting now as JSON.
+func (s *Service) ExitQueueHandler(w http.ResponseWriter, r *http.Request) {
+	if s.beaconChainServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	res, err := s.beaconChainServer.GetExitQueueEstimate(r.Context(), &ptypes.Empty{})
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// ProposerLookaheadHandler is a handler to serve the /validators/proposers page in metrics. It
+// writes the proposers of the current epoch, and of the next epoch once they are final, along
+// with the randao mixes they were sampled with as JSON.
+func (s *Service) ProposerLookaheadHandler(w http.ResponseWriter, r *http.Request) {
+	if s.beaconChainServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	res, err := s.beaconChainServer.GetProposerLookahead(r.Context(), &ptypes.Empty{})
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// ValidatorEarningsHandler is a handler to serve the /validators/earnings page in metrics. It
+// writes the cumulative rewards and penalties of the validator_index query parameters at the
+// epoch transitions between the start_epoch and end_epoch query parameters as JSON.
+func (s *Service) ValidatorEarningsHandler(w http.ResponseWriter, r *http.Request) {
+	if s.beaconChainServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	req := &beacon.ValidatorEarningsRequest{}
+	for _, index := range query["validator_index"] {
+		idx, err := strconv.ParseUint(index, 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid validator_index parameter", http.StatusBadRequest)
+			return
+		}
+		req.ValidatorIndices = append(req.ValidatorIndices, idx)
+	}
+	var err error
+	if req.StartEpoch, err = strconv.ParseUint(query.Get("start_epoch"), 10, 64); err != nil {
+		http.Error(w, "Invalid start_epoch parameter", http.StatusBadRequest)
+		return
+	}
+	if req.EndEpoch, err = strconv.ParseUint(query.Get("end_epoch"), 10, 64); err != nil {
+		http.Error(w, "Invalid end_epoch parameter", http.StatusBadRequest)
+		return
+	}
+	res, err := s.beaconChainServer.GetValidatorEarnings(r.Context(), req)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// AttestationBitmapsHandler is a handler to serve the /validators/attestations/bitmap page in
+// metrics. It writes the attestation inclusion bitmaps of the validator_index query parameters
+// between the start_epoch and end_epoch query parameters as JSON.
+func (s *Service) AttestationBitmapsHandler(w http.ResponseWriter, r *http.Request) {
+	if s.beaconChainServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	req := &beacon.AttestationBitmapRequest{}
+	for _, index := range query["validator_index"] {
+		idx, err := strconv.ParseUint(index, 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid validator_index parameter", http.StatusBadRequest)
+			return
+		}
+		req.ValidatorIndices = append(req.ValidatorIndices, idx)
+	}
+	var err error
+	if req.StartEpoch, err = strconv.ParseUint(query.Get("start_epoch"), 10, 64); err != nil {
+		http.Error(w, "Invalid start_epoch parameter", http.StatusBadRequest)
+		return
+	}
+	if req.EndEpoch, err = strconv.ParseUint(query.Get("end_epoch"), 10, 64); err != nil {
+		http.Error(w, "Invalid end_epoch parameter", http.StatusBadRequest)
+		return
+	}
+	res, err := s.beaconChainServer.GetAttestationBitmaps(r.Context(), req)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// StateFieldHandler is a handler to serve the /debug/state/field page of the gateway. It writes
+// the field query parameter of the state at the slot query parameter, as JSON or as raw SSZ
+// bytes when the encoding query parameter is ssz.
+func (s *Service) StateFieldHandler(w http.ResponseWriter, r *http.Request) {
+	if s.beaconChainServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	req := &beacon.StateFieldRequest{
+		Field:    r.URL.Query().Get("field"),
+		Encoding: r.URL.Query().Get("encoding"),
+	}
+	var err error
+	req.Slot, err = strconv.ParseUint(r.URL.Query().Get("slot"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid slot parameter", http.StatusBadRequest)
+		return
+	}
+	res, err := s.beaconChainServer.GetStateField(r.Context(), req)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	if req.Encoding != beacon.StateFieldEncodingSSZ {
+		writeJSON(w, res)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(res.SSZ); err != nil {
+		log.WithError(err).Error("Failed to write state field")
+	}
+}
+
+// DepositSnapshotHandler is a handler to serve the /deposits/snapshot page in metrics. It
+// writes a snapshot of the deposit trie up to the deposits of the finalized state as JSON,
+// which other nodes can be started from with the --deposit-snapshot flag.
+func (s *Service) DepositSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	if s.depositSnapshotFetcher == nil {
+		http.Error(w, "Deposit snapshots are not available", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	snapshot, err := s.depositSnapshotFetcher.FinalizedDepositSnapshot(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, snapshot)
+}
+
+// PoolAttestationsHandler is a handler to serve the /attestations/pool page in metrics. It
+// lists the pending attestations of the pool of the kind query parameter, aggregated or
+// unaggregated, filtered by the optional slot and committee_index query parameters.
+func (s *Service) PoolAttestationsHandler(w http.ResponseWriter, r *http.Request) {
+	if s.beaconChainServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	req := &beacon.PoolAttestationsRequest{
+		Kind: query.Get("kind"),
+	}
+	if query.Get("slot") != "" {
+		slot, err := strconv.ParseUint(query.Get("slot"), 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid slot parameter", http.StatusBadRequest)
+			return
+		}
+		req.Slot = &slot
+	}
+	if query.Get("committee_index") != "" {
+		committeeIndex, err := strconv.ParseUint(query.Get("committee_index"), 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid committee_index parameter", http.StatusBadRequest)
+			return
+		}
+		req.CommitteeIndex = &committeeIndex
+	}
+	res, err := s.beaconChainServer.ListPoolAttestations(r.Context(), req)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// PoolStatsHandler is a handler to serve the /attestations/pool/stats page in metrics. It
+// writes the number of attestations in the pool, and the pending attestations of each slot.
+func (s *Service) PoolStatsHandler(w http.ResponseWriter, r *http.Request) {
+	if s.beaconChainServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	res, err := s.beaconChainServer.GetAttestationPoolStats(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// PendingSlashingsHandler is a handler to serve the /slashings/pending page in metrics. It
+// lists the attester and proposer slashings of the pool awaiting inclusion in a block.
+func (s *Service) PendingSlashingsHandler(w http.ResponseWriter, r *http.Request) {
+	if s.beaconChainServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	res, err := s.beaconChainServer.ListPendingSlashings(r.Context(), &ptypes.Empty{})
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// SlashingInclusionHandler is a handler to serve the /slashings/inclusion page in metrics.
+// It reports whether the slashing with the hash tree root of the root query parameter is
+// pending in the pool or was included in a block.
+func (s *Service) SlashingInclusionHandler(w http.ResponseWriter, r *http.Request) {
+	if s.beaconChainServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	root, err := hex.DecodeString(strings.TrimPrefix(r.URL.Query().Get("root"), "0x"))
+	if err != nil {
+		http.Error(w, "Invalid root parameter", http.StatusBadRequest)
+		return
+	}
+	res, err := s.beaconChainServer.GetSlashingInclusion(r.Context(), &beacon.SlashingInclusionRequest{SlashingRoot: root})
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// InteropKeysHandler is a handler to serve the /interop/keys page in metrics. It writes the
+// deterministic keypairs of the interop genesis validators from the start_index query
+// parameter, at most count of them, as JSON.
+func (s *Service) InteropKeysHandler(w http.ResponseWriter, r *http.Request) {
+	if s.beaconChainServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	req := &beacon.InteropKeysRequest{}
+	var err error
+	if start := query.Get("start_index"); start != "" {
+		if req.StartIndex, err = strconv.ParseUint(start, 10, 64); err != nil {
+			http.Error(w, "Invalid start_index parameter", http.StatusBadRequest)
+			return
+		}
+	}
+	if count := query.Get("count"); count != "" {
+		if req.Count, err = strconv.ParseUint(count, 10, 64); err != nil {
+			http.Error(w, "Invalid count parameter", http.StatusBadRequest)
+			return
+		}
+	}
+	res, err := s.beaconChainServer.GetInteropKeys(r.Context(), req)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// BlockRewardsHandler is a handler to serve the /blocks/rewards page of the gateway. It writes
+// the rewards the block with the root query parameter earned its proposer as JSON.
+func (s *Service) BlockRewardsHandler(w http.ResponseWriter, r *http.Request) {
+	if s.beaconChainServer == nil {
+		http.Error(w, "RPC server is not started", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	root, err := hex.DecodeString(strings.TrimPrefix(r.URL.Query().Get("root"), "0x"))
+	if err != nil {
+		http.Error(w, "Invalid root parameter", http.StatusBadRequest)
+		return
+	}
+	res, err := s.beaconChainServer.GetBlockRewards(r.Context(), &beacon.BlockRewardsRequest{BlockRoot: root})
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	writeJSON(w, res)
+}
+
+// writeJSON writes the value as a JSON response.
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.WithError(err).Error("Failed to write JSON response")
+	}
+}
+
+// Authenticated requires the API key of an HTTP request to the handler to be authorized, when
+// the RPC server is configured with API keys, as the interceptors do for the gRPC methods. The
+// handlers which assemble proposals, submit operations or regenerate historical states are served
+// on the gateway behind it, rather than on the unauthenticated monitoring port.
+func (s *Service) Authenticated(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.apiKeysFile == "" {
+			handler(w, r)
+			return
+		}
+		// The API keys failed to load, so the request can not be authorized.
+		if s.authenticator == nil {
+			http.Error(w, "API keys are not loaded", http.StatusServiceUnavailable)
+			return
+		}
+		s.authenticator.HTTPHandler(handler)(w, r)
+	}
+}
+
+// httpStatusFromError maps the gRPC status code of an RPC error to an HTTP status code.
+func httpStatusFromError(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -2,9 +2,11 @@ package node
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"sort"
 
+	"github.com/ethereum/go-ethereum/rlp"
 	ptypes "github.com/gogo/protobuf/types"
 	"github.com/libp2p/go-libp2p-core/network"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
@@ -23,12 +25,31 @@ import (
 // version information, and services the node implements and runs.
 type Server struct {
 	SyncChecker        sync.Checker
+	SyncProgress       sync.ProgressFetcher
 	Server             *grpc.Server
 	BeaconDB           db.ReadOnlyDatabase
 	PeersFetcher       p2p.PeersProvider
+	IdentityFetcher    p2p.IdentityProvider
 	GenesisTimeFetcher blockchain.TimeFetcher
 }
 
+// Identity is the identity the node advertises to the network: its peer ID, its discovery node
+// record, the dialable multiaddrs it listens on and the attestation subnets of its metadata.
+type Identity struct {
+	PeerID             string   `json:"peer_id"`
+	ENR                string   `json:"enr"`
+	Addresses          []string `json:"p2p_addresses"`
+	AttestationSubnets []byte   `json:"attnets"`
+}
+
+// PeerScore is the gossip score of a connected peer, along with the number of bad responses it
+// gave to requests.
+type PeerScore struct {
+	Peer         string  `json:"peer"`
+	Score        float64 `json:"score"`
+	BadResponses int     `json:"bad_responses"`
+}
+
 // GetSyncStatus checks the current network sync status of the node.
 func (ns *Server) GetSyncStatus(ctx context.Context, _ *ptypes.Empty) (*ethpb.SyncStatus, error) {
 	return &ethpb.SyncStatus{
@@ -36,6 +57,16 @@ func (ns *Server) GetSyncStatus(ctx context.Context, _ *ptypes.Empty) (*ethpb.Sy
 	}, nil
 }
 
+// GetSyncProgress reports how far along the node is in syncing the chain, with the head slot
+// it started syncing from, its current head slot, the highest head slot reported by its peers,
+// the rate at which blocks are processed and the estimated time remaining.
+func (ns *Server) GetSyncProgress(ctx context.Context) (*sync.Progress, error) {
+	if ns.SyncProgress == nil {
+		return nil, status.Error(codes.Unimplemented, "Sync progress is not available")
+	}
+	return ns.SyncProgress.SyncProgress(), nil
+}
+
 // GetGenesis fetches genesis chain information of Ethereum 2.0.
 func (ns *Server) GetGenesis(ctx context.Context, _ *ptypes.Empty) (*ethpb.Genesis, error) {
 	contractAddr, err := ns.BeaconDB.DepositContractAddress(ctx)
@@ -108,3 +139,53 @@ func (ns *Server) ListPeers(ctx context.Context, _ *ptypes.Empty) (*ethpb.Peers,
 		Peers: res,
 	}, nil
 }
+
+// GetIdentity retrieves the identity of the node, from which static peer lists can be written.
+// The ENR is left empty if discovery is disabled.
+func (ns *Server) GetIdentity(ctx context.Context) (*Identity, error) {
+	if ns.IdentityFetcher == nil {
+		return nil, status.Error(codes.Unavailable, "P2P service is not available")
+	}
+	pid := ns.IdentityFetcher.PeerID()
+	res := &Identity{
+		PeerID:             pid.Pretty(),
+		Addresses:          make([]string, 0),
+		AttestationSubnets: ns.IdentityFetcher.AttestationSubnets(),
+	}
+	if record := ns.IdentityFetcher.ENR(); record != nil {
+		enc, err := rlp.EncodeToBytes(record)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not encode node record: %v", err)
+		}
+		res.ENR = "enr:" + base64.RawURLEncoding.EncodeToString(enc)
+	}
+	for _, addr := range ns.IdentityFetcher.HostAddresses() {
+		res.Addresses = append(res.Addresses, fmt.Sprintf("%s/p2p/%s", addr.String(), pid.Pretty()))
+	}
+	return res, nil
+}
+
+// ListPeerScores lists the gossip scores of the peers connected to this node, from the lowest
+// score to the highest.
+func (ns *Server) ListPeerScores(ctx context.Context) ([]*PeerScore, error) {
+	res := make([]*PeerScore, 0)
+	for _, pid := range ns.PeersFetcher.Peers().Connected() {
+		score, err := ns.PeersFetcher.Peers().GossipScore(pid)
+		if err != nil {
+			continue
+		}
+		badResponses, err := ns.PeersFetcher.Peers().BadResponses(pid)
+		if err != nil {
+			continue
+		}
+		res = append(res, &PeerScore{
+			Peer:         pid.Pretty(),
+			Score:        score,
+			BadResponses: badResponses,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Score < res[j].Score
+	})
+	return res, nil
+}
@@ -11,6 +11,7 @@ import (
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	mockP2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
 	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
 	"github.com/prysmaticlabs/prysm/shared/version"
@@ -122,3 +123,67 @@ func TestNodeServer_ListPeers(t *testing.T) {
 		t.Errorf("Expected 2st peer to be an outbound (%d) connection, received %d", ethpb.PeerDirection_OUTBOUND, res.Peers[0].Direction)
 	}
 }
+
+func TestNodeServer_ListPeerScores(t *testing.T) {
+	peersProvider := &mockP2p.MockPeersProvider{}
+	ns := &Server{
+		PeersFetcher: peersProvider,
+	}
+	peersProvider.Peers().SetScoreParams(&peers.ScoreParams{
+		Topics: map[string]*peers.TopicScoreParams{
+			"/eth2/beacon_block": {
+				TopicWeight:                    1,
+				FirstMessageDeliveriesWeight:   1,
+				FirstMessageDeliveriesCap:      10,
+				InvalidMessageDeliveriesWeight: -1,
+			},
+		},
+	})
+	connected := peersProvider.Peers().Connected()
+	peersProvider.Peers().RecordGossipValidation(connected[0], "/eth2/beacon_block", true)
+	peersProvider.Peers().RecordGossipValidation(connected[1], "/eth2/beacon_block", false)
+
+	res, err := ns.ListPeerScores(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 2 {
+		t.Fatalf("Expected 2 peers, received %d", len(res))
+	}
+	if res[0].Peer != connected[1].Pretty() || res[0].Score != -1 {
+		t.Errorf("Expected lowest score -1 of peer %s, received %v of peer %s", connected[1].Pretty(), res[0].Score, res[0].Peer)
+	}
+	if res[1].Peer != connected[0].Pretty() || res[1].Score != 1 {
+		t.Errorf("Expected highest score 1 of peer %s, received %v of peer %s", connected[0].Pretty(), res[1].Score, res[1].Peer)
+	}
+}
+
+func TestNodeServer_GetIdentity(t *testing.T) {
+	p2p := mockP2p.NewTestP2P(t)
+	ns := &Server{
+		IdentityFetcher: p2p,
+	}
+
+	res, err := ns.GetIdentity(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.PeerID != p2p.PeerID().Pretty() {
+		t.Errorf("Expected peer ID %s, received %s", p2p.PeerID().Pretty(), res.PeerID)
+	}
+	if res.ENR != "" {
+		t.Errorf("Expected no ENR without discovery, received %s", res.ENR)
+	}
+	if len(res.Addresses) != len(p2p.Host.Addrs()) {
+		t.Fatalf("Expected %d addresses, received %v", len(p2p.Host.Addrs()), res.Addresses)
+	}
+	for i, addr := range p2p.Host.Addrs() {
+		want := addr.String() + "/p2p/" + p2p.PeerID().Pretty()
+		if res.Addresses[i] != want {
+			t.Errorf("Expected address %s, received %s", want, res.Addresses[i])
+		}
+	}
+	if !bytes.Equal(res.AttestationSubnets, make([]byte, 8)) {
+		t.Errorf("Expected no attestation subnets, received %#x", res.AttestationSubnets)
+	}
+}
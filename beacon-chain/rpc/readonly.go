@@ -0,0 +1,36 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// readOnlyMessage is the reason write-path requests are rejected by a read-only node.
+const readOnlyMessage = "Beacon node is in read-only mode"
+
+// writeMethods are the gRPC methods which assemble block proposals, or broadcast and insert
+// operations into the pools of the node. They are rejected when the node is read-only, while
+// every other method, including the streams, stays available.
+var writeMethods = map[string]bool{
+	"/ethereum.eth.v1alpha1.BeaconNodeValidator/GetBlock":                true,
+	"/ethereum.eth.v1alpha1.BeaconNodeValidator/ProposeBlock":            true,
+	"/ethereum.eth.v1alpha1.BeaconNodeValidator/ProposeAttestation":      true,
+	"/ethereum.eth.v1alpha1.BeaconNodeValidator/ProposeExit":             true,
+	"/ethereum.eth.v1alpha1.BeaconNodeValidator/SubmitAggregateAndProof": true,
+	"/ethereum.eth.v1alpha1.BeaconChain/SubmitProposerSlashing":          true,
+	"/ethereum.eth.v1alpha1.BeaconChain/SubmitAttesterSlashing":          true,
+	"/ethereum.beacon.rpc.v1.AggregatorService/SubmitAggregateAndProof":  true,
+}
+
+// readOnlyUnaryServerInterceptor rejects the requests to the write-path methods.
+func readOnlyUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if writeMethods[info.FullMethod] {
+			return nil, status.Errorf(codes.PermissionDenied, "%s: %s is disabled", readOnlyMessage, info.FullMethod)
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,43 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/validator"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestReadOnlyUnaryServerInterceptor(t *testing.T) {
+	interceptor := readOnlyUnaryServerInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/ethereum.eth.v1alpha1.BeaconNodeValidator/ProposeAttestation"}
+	if _, err := interceptor(context.Background(), nil, info, handler); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected write-path method to be denied, received %v", err)
+	}
+
+	info = &grpc.UnaryServerInfo{FullMethod: "/ethereum.eth.v1alpha1.BeaconChain/GetChainHead"}
+	res, err := interceptor(context.Background(), nil, info, handler)
+	if err != nil {
+		t.Fatalf("Expected query method to be served, received %v", err)
+	}
+	if res != "ok" {
+		t.Errorf("Unexpected response %v", res)
+	}
+}
+
+func TestSubmitAttestationsHandler_ReadOnly(t *testing.T) {
+	s := &Service{validatorServer: &validator.Server{}, readOnly: true}
+	rec := httptest.NewRecorder()
+	s.SubmitAttestationsHandler(rec, httptest.NewRequest(http.MethodPost, "/validator/attestations", nil))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, received %d", http.StatusForbidden, rec.Code)
+	}
+}
@@ -3,6 +3,8 @@ package rpc
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"math/rand"
 	"net"
@@ -26,15 +28,18 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/aggregator"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/apikey"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/beacon"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/node"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/validator"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/beacon-chain/sync"
 	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
 	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/tlsutil"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/plugin/ocgrpc"
@@ -59,6 +64,7 @@ type Service struct {
 	forkFetcher            blockchain.ForkFetcher
 	finalizationFetcher    blockchain.FinalizationFetcher
 	participationFetcher   blockchain.ParticipationFetcher
+	warmUpChecker          blockchain.WarmUpChecker
 	genesisTimeFetcher     blockchain.TimeFetcher
 	attestationReceiver    blockchain.AttestationReceiver
 	blockReceiver          blockchain.BlockReceiver
@@ -69,17 +75,24 @@ type Service struct {
 	exitPool               *voluntaryexits.Pool
 	slashingsPool          *slashings.Pool
 	syncService            sync.Checker
+	syncProgressFetcher    sync.ProgressFetcher
+	depositSnapshotFetcher powchain.DepositSnapshotFetcher
 	host                   string
 	port                   string
 	listener               net.Listener
 	withCert               string
 	withKey                string
+	withClientCA           string
 	grpcServer             *grpc.Server
 	canonicalStateChan     chan *pbp2p.BeaconState
 	incomingAttestation    chan *ethpb.Attestation
 	credentialError        error
+	beaconChainServer      *beacon.Server
+	validatorServer        *validator.Server
+	nodeServer             *node.Server
 	p2p                    p2p.Broadcaster
 	peersFetcher           p2p.PeersProvider
+	identityFetcher        p2p.IdentityProvider
 	depositFetcher         depositcache.DepositFetcher
 	pendingDepositFetcher  depositcache.PendingDepositsFetcher
 	stateNotifier          statefeed.Notifier
@@ -90,38 +103,50 @@ type Service struct {
 	slasherCert            string
 	slasherCredentialError error
 	slasherClient          slashpb.SlasherClient
+	apiKeysFile            string
+	authenticator          *apikey.Authenticator
+	readOnly               bool
+	interopNumValidators   uint64
 }
 
 // Config options for the beacon node RPC server.
 type Config struct {
-	Host                  string
-	Port                  string
-	CertFlag              string
-	KeyFlag               string
-	BeaconDB              db.HeadAccessDatabase
-	HeadFetcher           blockchain.HeadFetcher
-	ForkFetcher           blockchain.ForkFetcher
-	FinalizationFetcher   blockchain.FinalizationFetcher
-	ParticipationFetcher  blockchain.ParticipationFetcher
-	AttestationReceiver   blockchain.AttestationReceiver
-	BlockReceiver         blockchain.BlockReceiver
-	POWChainService       powchain.Chain
-	ChainStartFetcher     powchain.ChainStartFetcher
-	GenesisTimeFetcher    blockchain.TimeFetcher
-	MockEth1Votes         bool
-	AttestationsPool      attestations.Pool
-	ExitPool              *voluntaryexits.Pool
-	SlashingsPool         *slashings.Pool
-	SyncService           sync.Checker
-	Broadcaster           p2p.Broadcaster
-	PeersFetcher          p2p.PeersProvider
-	DepositFetcher        depositcache.DepositFetcher
-	PendingDepositFetcher depositcache.PendingDepositsFetcher
-	SlasherProvider       string
-	SlasherCert           string
-	StateNotifier         statefeed.Notifier
-	BlockNotifier         blockfeed.Notifier
-	OperationNotifier     opfeed.Notifier
+	Host                   string
+	Port                   string
+	CertFlag               string
+	KeyFlag                string
+	ClientCAFlag           string
+	BeaconDB               db.HeadAccessDatabase
+	HeadFetcher            blockchain.HeadFetcher
+	ForkFetcher            blockchain.ForkFetcher
+	FinalizationFetcher    blockchain.FinalizationFetcher
+	ParticipationFetcher   blockchain.ParticipationFetcher
+	WarmUpChecker          blockchain.WarmUpChecker
+	AttestationReceiver    blockchain.AttestationReceiver
+	BlockReceiver          blockchain.BlockReceiver
+	POWChainService        powchain.Chain
+	ChainStartFetcher      powchain.ChainStartFetcher
+	GenesisTimeFetcher     blockchain.TimeFetcher
+	MockEth1Votes          bool
+	AttestationsPool       attestations.Pool
+	ExitPool               *voluntaryexits.Pool
+	SlashingsPool          *slashings.Pool
+	SyncService            sync.Checker
+	SyncProgressFetcher    sync.ProgressFetcher
+	DepositSnapshotFetcher powchain.DepositSnapshotFetcher
+	Broadcaster            p2p.Broadcaster
+	PeersFetcher           p2p.PeersProvider
+	IdentityFetcher        p2p.IdentityProvider
+	DepositFetcher         depositcache.DepositFetcher
+	PendingDepositFetcher  depositcache.PendingDepositsFetcher
+	SlasherProvider        string
+	SlasherCert            string
+	StateNotifier          statefeed.Notifier
+	BlockNotifier          blockfeed.Notifier
+	OperationNotifier      opfeed.Notifier
+	APIKeysFile            string
+	ReadOnly               bool
+	InteropNumValidators   uint64
 }
 
 // NewService instantiates a new RPC service instance that will
@@ -129,43 +154,75 @@ type Config struct {
 func NewService(ctx context.Context, cfg *Config) *Service {
 	ctx, cancel := context.WithCancel(ctx)
 	return &Service{
-		ctx:                   ctx,
-		cancel:                cancel,
-		beaconDB:              cfg.BeaconDB,
-		headFetcher:           cfg.HeadFetcher,
-		forkFetcher:           cfg.ForkFetcher,
-		finalizationFetcher:   cfg.FinalizationFetcher,
-		participationFetcher:  cfg.ParticipationFetcher,
-		genesisTimeFetcher:    cfg.GenesisTimeFetcher,
-		attestationReceiver:   cfg.AttestationReceiver,
-		blockReceiver:         cfg.BlockReceiver,
-		p2p:                   cfg.Broadcaster,
-		peersFetcher:          cfg.PeersFetcher,
-		powChainService:       cfg.POWChainService,
-		chainStartFetcher:     cfg.ChainStartFetcher,
-		mockEth1Votes:         cfg.MockEth1Votes,
-		attestationsPool:      cfg.AttestationsPool,
-		exitPool:              cfg.ExitPool,
-		slashingsPool:         cfg.SlashingsPool,
-		syncService:           cfg.SyncService,
-		host:                  cfg.Host,
-		port:                  cfg.Port,
-		withCert:              cfg.CertFlag,
-		withKey:               cfg.KeyFlag,
-		depositFetcher:        cfg.DepositFetcher,
-		pendingDepositFetcher: cfg.PendingDepositFetcher,
-		canonicalStateChan:    make(chan *pbp2p.BeaconState, params.BeaconConfig().DefaultBufferSize),
-		incomingAttestation:   make(chan *ethpb.Attestation, params.BeaconConfig().DefaultBufferSize),
-		stateNotifier:         cfg.StateNotifier,
-		blockNotifier:         cfg.BlockNotifier,
-		operationNotifier:     cfg.OperationNotifier,
-		slasherProvider:       cfg.SlasherProvider,
-		slasherCert:           cfg.SlasherCert,
+		ctx:                    ctx,
+		cancel:                 cancel,
+		beaconDB:               cfg.BeaconDB,
+		headFetcher:            cfg.HeadFetcher,
+		forkFetcher:            cfg.ForkFetcher,
+		finalizationFetcher:    cfg.FinalizationFetcher,
+		participationFetcher:   cfg.ParticipationFetcher,
+		warmUpChecker:          cfg.WarmUpChecker,
+		genesisTimeFetcher:     cfg.GenesisTimeFetcher,
+		attestationReceiver:    cfg.AttestationReceiver,
+		blockReceiver:          cfg.BlockReceiver,
+		p2p:                    cfg.Broadcaster,
+		peersFetcher:           cfg.PeersFetcher,
+		identityFetcher:        cfg.IdentityFetcher,
+		powChainService:        cfg.POWChainService,
+		chainStartFetcher:      cfg.ChainStartFetcher,
+		mockEth1Votes:          cfg.MockEth1Votes,
+		attestationsPool:       cfg.AttestationsPool,
+		exitPool:               cfg.ExitPool,
+		slashingsPool:          cfg.SlashingsPool,
+		syncService:            cfg.SyncService,
+		syncProgressFetcher:    cfg.SyncProgressFetcher,
+		depositSnapshotFetcher: cfg.DepositSnapshotFetcher,
+		host:                   cfg.Host,
+		port:                   cfg.Port,
+		withCert:               cfg.CertFlag,
+		withKey:                cfg.KeyFlag,
+		withClientCA:           cfg.ClientCAFlag,
+		depositFetcher:         cfg.DepositFetcher,
+		pendingDepositFetcher:  cfg.PendingDepositFetcher,
+		canonicalStateChan:     make(chan *pbp2p.BeaconState, params.BeaconConfig().DefaultBufferSize),
+		incomingAttestation:    make(chan *ethpb.Attestation, params.BeaconConfig().DefaultBufferSize),
+		stateNotifier:          cfg.StateNotifier,
+		blockNotifier:          cfg.BlockNotifier,
+		operationNotifier:      cfg.OperationNotifier,
+		slasherProvider:        cfg.SlasherProvider,
+		slasherCert:            cfg.SlasherCert,
+		apiKeysFile:            cfg.APIKeysFile,
+		readOnly:               cfg.ReadOnly,
+		interopNumValidators:   cfg.InteropNumValidators,
 	}
 }
 
 // Start the gRPC server.
 func (s *Service) Start() {
+	// The credentials and API keys are loaded before listening, so that the server is never
+	// exposed without authentication when they are misconfigured.
+	var creds credentials.TransportCredentials
+	if s.withCert != "" && s.withKey != "" {
+		c, err := s.transportCredentials()
+		if err != nil {
+			log.Errorf("Could not load TLS keys: %s", err)
+			s.credentialError = err
+			return
+		}
+		creds = c
+	} else {
+		log.Warn("You are using an insecure gRPC connection! Provide a certificate and key to connect securely")
+	}
+	if s.apiKeysFile != "" {
+		a, err := apikey.NewAuthenticator(s.apiKeysFile)
+		if err != nil {
+			log.Errorf("Could not load API keys: %v", err)
+			s.credentialError = err
+			return
+		}
+		s.authenticator = a
+	}
+
 	address := fmt.Sprintf("%s:%s", s.host, s.port)
 	lis, err := net.Listen("tcp", address)
 	if err != nil {
@@ -174,43 +231,47 @@ func (s *Service) Start() {
 	s.listener = lis
 	log.WithField("address", address).Info("RPC-API listening on port")
 
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		recovery.StreamServerInterceptor(
+			recovery.WithRecoveryHandlerContext(traceutil.RecoveryHandlerFunc),
+		),
+		grpc_prometheus.StreamServerInterceptor,
+		grpc_opentracing.StreamServerInterceptor(),
+	}
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		recovery.UnaryServerInterceptor(
+			recovery.WithRecoveryHandlerContext(traceutil.RecoveryHandlerFunc),
+		),
+		grpc_prometheus.UnaryServerInterceptor,
+		grpc_opentracing.UnaryServerInterceptor(),
+	}
+	if s.authenticator != nil {
+		s.authenticator.Start(s.ctx)
+		streamInterceptors = append(streamInterceptors, s.authenticator.StreamServerInterceptor())
+		unaryInterceptors = append(unaryInterceptors, s.authenticator.UnaryServerInterceptor())
+	}
+	if s.readOnly {
+		log.Warn("Beacon node is in read-only mode, block proposals and operation submissions are disabled")
+		unaryInterceptors = append(unaryInterceptors, readOnlyUnaryServerInterceptor())
+	}
 	opts := []grpc.ServerOption{
 		grpc.StatsHandler(&ocgrpc.ServerHandler{}),
-		grpc.StreamInterceptor(middleware.ChainStreamServer(
-			recovery.StreamServerInterceptor(
-				recovery.WithRecoveryHandlerContext(traceutil.RecoveryHandlerFunc),
-			),
-			grpc_prometheus.StreamServerInterceptor,
-			grpc_opentracing.StreamServerInterceptor(),
-		)),
-		grpc.UnaryInterceptor(middleware.ChainUnaryServer(
-			recovery.UnaryServerInterceptor(
-				recovery.WithRecoveryHandlerContext(traceutil.RecoveryHandlerFunc),
-			),
-			grpc_prometheus.UnaryServerInterceptor,
-			grpc_opentracing.UnaryServerInterceptor(),
-		)),
+		grpc.StreamInterceptor(middleware.ChainStreamServer(streamInterceptors...)),
+		grpc.UnaryInterceptor(middleware.ChainUnaryServer(unaryInterceptors...)),
 	}
 	grpc_prometheus.EnableHandlingTimeHistogram()
-	// TODO(#791): Utilize a certificate for secure connections
-	// between beacon nodes and validator clients.
-	if s.withCert != "" && s.withKey != "" {
-		creds, err := credentials.NewServerTLSFromFile(s.withCert, s.withKey)
-		if err != nil {
-			log.Errorf("Could not load TLS keys: %s", err)
-			s.credentialError = err
-		}
+	if creds != nil {
 		opts = append(opts, grpc.Creds(creds))
-	} else {
-		log.Warn("You are using an insecure gRPC connection! Provide a certificate and key to connect securely")
 	}
 	s.grpcServer = grpc.NewServer(opts...)
 
 	genesisTime := s.genesisTimeFetcher.GenesisTime()
+	stateGen := stategen.New(s.beaconDB)
 	validatorServer := &validator.Server{
 		Ctx:                    s.ctx,
 		BeaconDB:               s.beaconDB,
 		AttestationCache:       cache.NewAttestationCache(),
+		DutiesCache:            cache.NewDutiesCache(),
 		AttPool:                s.attestationsPool,
 		ExitPool:               s.exitPool,
 		HeadFetcher:            s.headFetcher,
@@ -233,19 +294,23 @@ func (s *Service) Start() {
 		PendingDepositsFetcher: s.pendingDepositFetcher,
 		GenesisTime:            genesisTime,
 		SlashingsPool:          s.slashingsPool,
+		StateGen:               stateGen,
 	}
 	nodeServer := &node.Server{
 		BeaconDB:           s.beaconDB,
 		Server:             s.grpcServer,
 		SyncChecker:        s.syncService,
+		SyncProgress:       s.syncProgressFetcher,
 		GenesisTimeFetcher: s.genesisTimeFetcher,
 		PeersFetcher:       s.peersFetcher,
+		IdentityFetcher:    s.identityFetcher,
 	}
 	beaconChainServer := &beacon.Server{
 		Ctx:                  s.ctx,
 		BeaconDB:             s.beaconDB,
 		AttestationsPool:     s.attestationsPool,
 		SlashingsPool:        s.slashingsPool,
+		Broadcaster:          s.p2p,
 		HeadFetcher:          s.headFetcher,
 		FinalizationFetcher:  s.finalizationFetcher,
 		ParticipationFetcher: s.participationFetcher,
@@ -257,12 +322,19 @@ func (s *Service) Start() {
 		StateNotifier:        s.stateNotifier,
 		BlockNotifier:        s.blockNotifier,
 		AttestationNotifier:  s.operationNotifier,
+		ValidatorSnapshots:   beacon.NewValidatorSnapshots(),
+		StateGen:             stateGen,
+		InteropNumValidators: s.interopNumValidators,
 	}
+	s.beaconChainServer = beaconChainServer
+	s.validatorServer = validatorServer
+	s.nodeServer = nodeServer
 	aggregatorServer := &aggregator.Server{ValidatorServer: validatorServer}
 	pb.RegisterAggregatorServiceServer(s.grpcServer, aggregatorServer)
 	ethpb.RegisterNodeServer(s.grpcServer, nodeServer)
 	ethpb.RegisterBeaconChainServer(s.grpcServer, beaconChainServer)
 	ethpb.RegisterBeaconNodeValidatorServer(s.grpcServer, validatorServer)
+	go validatorServer.UpdateDutiesCacheOnHead()
 
 	// Register reflection service on gRPC server.
 	reflection.Register(s.grpcServer)
@@ -327,7 +399,32 @@ func (s *Service) Stop() error {
 	return nil
 }
 
-// Status returns nil or credentialError
+// transportCredentials returns the TLS credentials of the server. The certificate is reloaded
+// when its files change, so that it can be rotated without a restart. Clients must present a
+// certificate signed by the client certificate authority, if one is configured.
+func (s *Service) transportCredentials() (credentials.TransportCredentials, error) {
+	reloader, err := tlsutil.NewCertReloader(s.withCert, s.withKey)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &tls.Config{
+		GetCertificate: reloader.GetCertificate,
+		MinVersion:     tls.VersionTLS12,
+	}
+	if s.withClientCA != "" {
+		pool, err := tlsutil.LoadCertPool(s.withClientCA)
+		if err != nil {
+			return nil, err
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+		log.Info("Requiring clients to authenticate with a TLS certificate")
+	}
+	return credentials.NewTLS(cfg), nil
+}
+
+// Status returns nil or credentialError, or an error while the caches of the chain service are
+// warming up after a restart, so the node is not reported healthy before it can serve duties.
 func (s *Service) Status() error {
 	if s.credentialError != nil {
 		return s.credentialError
@@ -335,5 +432,8 @@ func (s *Service) Status() error {
 	if s.slasherCredentialError != nil {
 		return s.slasherCredentialError
 	}
+	if s.warmUpChecker != nil && !s.warmUpChecker.WarmedUp() {
+		return errors.New("caches are warming up")
+	}
 	return nil
 }
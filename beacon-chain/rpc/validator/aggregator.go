@@ -19,7 +19,7 @@ func (as *Server) SubmitAggregateAndProof(ctx context.Context, req *ethpb.Aggreg
 	span.AddAttributes(trace.Int64Attribute("slot", int64(req.Slot)))
 
 	if as.SyncChecker.Syncing() {
-		return nil, status.Errorf(codes.Unavailable, "Syncing to latest head, not ready to respond")
+		return nil, as.syncingError()
 	}
 
 	validatorIndex, exists, err := as.BeaconDB.ValidatorIndex(ctx, req.PublicKey)
@@ -41,7 +41,7 @@ func (as *Server) SubmitAggregateAndProof(ctx context.Context, req *ethpb.Aggreg
 	}
 	committee, err := helpers.BeaconCommittee(activeValidatorIndices, seed, req.Slot, req.CommitteeIndex)
 	if err != nil {
-		return nil, err
+		return nil, status.Errorf(codes.Internal, "Could not get committee: %v", err)
 	}
 
 	// Check if the validator is an aggregator
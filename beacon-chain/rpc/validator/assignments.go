@@ -4,8 +4,12 @@ import (
 	"context"
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -18,7 +22,18 @@ import (
 //	4.) The bool signaling if the validator is expected to propose a block at the assigned slot.
 func (vs *Server) GetDuties(ctx context.Context, req *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error) {
 	if vs.SyncChecker.Syncing() {
-		return nil, status.Error(codes.Unavailable, "Syncing to latest head, not ready to respond")
+		return nil, vs.syncingError()
+	}
+
+	// The head root is fetched before the head state, so that duties are never cached under a
+	// head newer than the state they were computed from.
+	var headRoot [32]byte
+	if vs.DutiesCache != nil {
+		r, err := vs.HeadFetcher.HeadRoot(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get head root: %v", err)
+		}
+		headRoot = bytesutil.ToBytes32(r)
 	}
 
 	s, err := vs.HeadFetcher.HeadState(ctx)
@@ -26,6 +41,19 @@ func (vs *Server) GetDuties(ctx context.Context, req *ethpb.DutiesRequest) (*eth
 		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
 	}
 
+	// The duties are computed from the head state, which determines the shuffling up to the next
+	// epoch, along with the statuses and proposer slots, so they are cached per head.
+	var dutiesKey [32]byte
+	useCache := vs.DutiesCache != nil && req.Epoch <= helpers.NextEpoch(s)
+	if useCache {
+		dutiesKey = cache.DutiesKey(req.PublicKeys)
+		if duties := vs.DutiesCache.Duties(dutiesKey, req.Epoch, headRoot); duties != nil {
+			return &ethpb.DutiesResponse{
+				Duties: duties,
+			}, nil
+		}
+	}
+
 	// Advance state with empty transitions up to the requested epoch start slot.
 	if epochStartSlot := helpers.StartSlot(req.Epoch); s.Slot() < epochStartSlot {
 		s, err = state.ProcessSlots(ctx, s, epochStartSlot)
@@ -69,7 +97,39 @@ func (vs *Server) GetDuties(ctx context.Context, req *ethpb.DutiesRequest) (*eth
 		validatorAssignments = append(validatorAssignments, assignment)
 	}
 
+	if useCache {
+		vs.DutiesCache.AddDuties(dutiesKey, req.Epoch, headRoot, validatorAssignments)
+	}
+
 	return &ethpb.DutiesResponse{
 		Duties: validatorAssignments,
 	}, nil
 }
+
+// UpdateDutiesCacheOnHead keeps the duties cache in sync with the head of the chain, clearing
+// it when the chain reorgs. It runs until the server context is canceled.
+func (vs *Server) UpdateDutiesCacheOnHead() {
+	if vs.DutiesCache == nil {
+		return
+	}
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := vs.StateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	for {
+		select {
+		case event := <-stateChannel:
+			if event.Type != statefeed.HeadUpdated {
+				continue
+			}
+			data, ok := event.Data.(*statefeed.HeadUpdatedData)
+			if !ok {
+				continue
+			}
+			vs.DutiesCache.HeadUpdated(data.ParentRoot, data.BlockRoot)
+		case <-stateSub.Err():
+			return
+		case <-vs.Ctx.Done():
+			return
+		}
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
 	mockChain "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	blk "github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
 	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
@@ -285,6 +287,69 @@ func TestGetDuties_MultipleKeys_OK(t *testing.T) {
 	}
 }
 
+func TestGetDuties_UsesDutiesCache(t *testing.T) {
+	db := dbutil.SetupDB(t)
+	defer dbutil.TeardownDB(t, db)
+	ctx := context.Background()
+
+	bState, _ := testutil.DeterministicGenesisState(t, 64)
+	genesis := blk.NewGenesisBlock([]byte{})
+	genesisRoot, err := ssz.HashTreeRoot(genesis.Block)
+	if err != nil {
+		t.Fatalf("Could not get signing root %v", err)
+	}
+	pubKeys := make([][48]byte, len(bState.Validators()))
+	indices := make([]uint64, len(bState.Validators()))
+	for i, v := range bState.Validators() {
+		pubKeys[i] = bytesutil.ToBytes48(v.PublicKey)
+		indices[i] = uint64(i)
+	}
+	if err := db.SaveValidatorIndices(ctx, pubKeys, indices); err != nil {
+		t.Fatal(err)
+	}
+
+	vs := &Server{
+		BeaconDB:    db,
+		HeadFetcher: &mockChain.ChainService{State: bState, Root: genesisRoot[:]},
+		SyncChecker: &mockSync.Sync{IsSyncing: false},
+		DutiesCache: cache.NewDutiesCache(),
+	}
+	req := &ethpb.DutiesRequest{
+		PublicKeys: [][]byte{pubKeys[0][:], pubKeys[1][:]},
+		Epoch:      0,
+	}
+	res, err := vs.GetDuties(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cached := vs.DutiesCache.Duties(cache.DutiesKey(req.PublicKeys), 0, genesisRoot)
+	if !reflect.DeepEqual(cached, res.Duties) {
+		t.Fatalf("Wanted cached duties %v, got %v", res.Duties, cached)
+	}
+
+	// Validator indices are looked up on a cache miss only.
+	emptyDB := dbutil.SetupDB(t)
+	defer dbutil.TeardownDB(t, emptyDB)
+	vs.BeaconDB = emptyDB
+	res2, err := vs.GetDuties(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(res2.Duties, res.Duties) {
+		t.Errorf("Wanted duties %v from the cache, got %v", res.Duties, res2.Duties)
+	}
+
+	// The duties are recomputed once the head changes.
+	vs.HeadFetcher = &mockChain.ChainService{State: bState, Root: []byte{'A'}}
+	res3, err := vs.GetDuties(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res3.Duties[0].Committee != nil {
+		t.Errorf("Wanted duties to be recomputed for the new head, got %v", res3.Duties[0])
+	}
+}
+
 func TestGetDuties_SyncNotReady(t *testing.T) {
 	vs := &Server{
 		SyncChecker: &mockSync.Sync{IsSyncing: true},
@@ -34,7 +34,7 @@ func (vs *Server) GetAttestationData(ctx context.Context, req *ethpb.Attestation
 	)
 
 	if vs.SyncChecker.Syncing() {
-		return nil, status.Errorf(codes.Unavailable, "Syncing to latest head, not ready to respond")
+		return nil, vs.syncingError()
 	}
 
 	// Attester will either wait until there's a valid block from the expected block proposer of for the assigned input slot
@@ -156,6 +156,53 @@ func (vs *Server) ProposeAttestation(ctx context.Context, att *ethpb.Attestation
 	}, nil
 }
 
+// SubmitAttestationsRequest is a batch of signed attestations, of any committees and slots,
+// to broadcast at once.
+type SubmitAttestationsRequest struct {
+	Attestations []*ethpb.Attestation `json:"attestations"`
+}
+
+// AttestationSubmissionStatus is the result of the submission of an attestation of a batch,
+// with either the root of the attestation data or the reason the attestation was rejected.
+type AttestationSubmissionStatus struct {
+	AttestationDataRoot []byte `json:"attestation_data_root,omitempty"`
+	Error               string `json:"error,omitempty"`
+}
+
+// SubmitAttestationsResponse holds the statuses of the attestations of a batch, in the order
+// of the request.
+type SubmitAttestationsResponse struct {
+	Statuses []*AttestationSubmissionStatus `json:"statuses"`
+}
+
+// SubmitAttestations broadcasts a batch of signed attestations, so that a client attesting with
+// many keys in the same slot sends a single request. An attestation being rejected does not
+// prevent the others from being broadcast, the status of each attestation is in the response.
+func (vs *Server) SubmitAttestations(ctx context.Context, req *SubmitAttestationsRequest) (*SubmitAttestationsResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "AttesterServer.SubmitAttestations")
+	defer span.End()
+
+	if req == nil || len(req.Attestations) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "No attestations to submit")
+	}
+	span.AddAttributes(trace.Int64Attribute("attestations", int64(len(req.Attestations))))
+
+	statuses := make([]*AttestationSubmissionStatus, len(req.Attestations))
+	for i, att := range req.Attestations {
+		if att == nil || att.Data == nil {
+			statuses[i] = &AttestationSubmissionStatus{Error: "Attestation has no data"}
+			continue
+		}
+		res, err := vs.ProposeAttestation(ctx, att)
+		if err != nil {
+			statuses[i] = &AttestationSubmissionStatus{Error: err.Error()}
+			continue
+		}
+		statuses[i] = &AttestationSubmissionStatus{AttestationDataRoot: res.AttestationDataRoot}
+	}
+	return &SubmitAttestationsResponse{Statuses: statuses}, nil
+}
+
 // waitToOneThird waits until one-third of the way through the slot
 // or the head slot equals to the input slot.
 func (vs *Server) waitToOneThird(ctx context.Context, slot uint64) {
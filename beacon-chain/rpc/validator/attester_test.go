@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"bytes"
 	"context"
 	"strings"
 	"sync"
@@ -91,6 +92,67 @@ func TestProposeAttestation_OK(t *testing.T) {
 	}
 }
 
+func TestSubmitAttestations_StatusPerAttestation(t *testing.T) {
+	db := dbutil.SetupDB(t)
+	defer dbutil.TeardownDB(t, db)
+
+	attesterServer := &Server{
+		HeadFetcher:       &mock.ChainService{},
+		P2P:               &mockp2p.MockBroadcaster{},
+		BeaconDB:          db,
+		AttestationCache:  cache.NewAttestationCache(),
+		AttPool:           attestations.NewPool(),
+		OperationNotifier: (&mock.ChainService{}).OperationNotifier(),
+	}
+
+	sk := bls.RandKey()
+	sig := sk.Sign([]byte("dummy_test_data"), 0 /*domain*/)
+	valid := func(index uint64) *ethpb.Attestation {
+		return &ethpb.Attestation{
+			Signature: sig.Marshal(),
+			Data: &ethpb.AttestationData{
+				CommitteeIndex:  index,
+				BeaconBlockRoot: make([]byte, 32),
+				Source:          &ethpb.Checkpoint{},
+				Target:          &ethpb.Checkpoint{},
+			},
+		}
+	}
+	req := &SubmitAttestationsRequest{
+		Attestations: []*ethpb.Attestation{
+			valid(0),
+			{Data: &ethpb.AttestationData{Source: &ethpb.Checkpoint{}, Target: &ethpb.Checkpoint{}}},
+			{Signature: sig.Marshal()},
+			valid(1),
+		},
+	}
+	res, err := attesterServer.SubmitAttestations(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Statuses) != len(req.Attestations) {
+		t.Fatalf("Wanted %d statuses, received %d", len(req.Attestations), len(res.Statuses))
+	}
+	for _, i := range []int{0, 3} {
+		root, err := ssz.HashTreeRoot(req.Attestations[i].Data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Statuses[i].Error != "" || !bytes.Equal(res.Statuses[i].AttestationDataRoot, root[:]) {
+			t.Errorf("Wanted attestation %d to be submitted, received status %v", i, res.Statuses[i])
+		}
+	}
+	for _, i := range []int{1, 2} {
+		if res.Statuses[i].Error == "" {
+			t.Errorf("Wanted attestation %d to be rejected", i)
+		}
+	}
+
+	if _, err := attesterServer.SubmitAttestations(context.Background(), &SubmitAttestationsRequest{}); err == nil {
+		t.Error("Expected an empty batch to be rejected")
+	}
+}
+
 func TestProposeAttestation_IncorrectSignature(t *testing.T) {
 	db := dbutil.SetupDB(t)
 	defer dbutil.TeardownDB(t, db)
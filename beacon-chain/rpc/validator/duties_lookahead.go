@@ -0,0 +1,67 @@
+package validator
+
+import (
+	"context"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DutiesLookaheadResponse contains the duties of validators at the requested epoch and at the
+// epoch after it.
+type DutiesLookaheadResponse struct {
+	Epoch              uint64                       `json:"epoch"`
+	CurrentEpochDuties []*ethpb.DutiesResponse_Duty `json:"current_epoch_duties"`
+	NextEpochDuties    []*ethpb.DutiesResponse_Duty `json:"next_epoch_duties"`
+	// NextEpochProposerSlotsKnown is false when the proposer slots of the next epoch duties are
+	// not final yet and were left out, as the blocks until the end of the requested epoch may
+	// change the effective balances the proposers are sampled from.
+	NextEpochProposerSlotsKnown bool `json:"next_epoch_proposer_slots_known"`
+}
+
+// GetDutiesWithLookahead returns the duties of the validators at the requested epoch along with
+// their duties at the next epoch, so a validator client can subscribe to the attestation subnets
+// of the next epoch and schedule its duties ahead of time with a single request per epoch.
+// Attester slots and committee indices of the next epoch are final, as the shuffling is known an
+// epoch ahead, but proposer slots are only returned once they can no longer change.
+func (vs *Server) GetDutiesWithLookahead(ctx context.Context, req *ethpb.DutiesRequest) (*DutiesLookaheadResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "ValidatorServer.GetDutiesWithLookahead")
+	defer span.End()
+	span.AddAttributes(trace.Int64Attribute("epoch", int64(req.Epoch)))
+
+	current, err := vs.GetDuties(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	next, err := vs.GetDuties(ctx, &ethpb.DutiesRequest{
+		Epoch:      req.Epoch + 1,
+		PublicKeys: req.PublicKeys,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	headState, err := vs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
+	}
+	res := &DutiesLookaheadResponse{
+		Epoch:                       req.Epoch,
+		CurrentEpochDuties:          current.Duties,
+		NextEpochDuties:             next.Duties,
+		NextEpochProposerSlotsKnown: helpers.CurrentEpoch(headState) > req.Epoch,
+	}
+	if !res.NextEpochProposerSlotsKnown {
+		// The duties may be shared with the duties cache, they are copied before being modified.
+		res.NextEpochDuties = make([]*ethpb.DutiesResponse_Duty, len(next.Duties))
+		for i, duty := range next.Duties {
+			d := *duty
+			d.ProposerSlot = 0
+			res.NextEpochDuties[i] = &d
+		}
+	}
+	return res, nil
+}
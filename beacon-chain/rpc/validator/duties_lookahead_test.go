@@ -0,0 +1,97 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	mockChain "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestGetDutiesWithLookahead_OK(t *testing.T) {
+	db := dbutil.SetupDB(t)
+	defer dbutil.TeardownDB(t, db)
+	ctx := context.Background()
+
+	numValidators := uint64(64)
+	beaconState, _ := testutil.DeterministicGenesisState(t, numValidators)
+	pubKeys := make([][]byte, numValidators)
+	pubKeys48 := make([][48]byte, numValidators)
+	indices := make([]uint64, numValidators)
+	for i := uint64(0); i < numValidators; i++ {
+		pubKeys[i] = beaconState.Validators()[i].PublicKey
+		pubKeys48[i] = bytesutil.ToBytes48(pubKeys[i])
+		indices[i] = i
+	}
+	if err := db.SaveValidatorIndices(ctx, pubKeys48, indices); err != nil {
+		t.Fatal(err)
+	}
+
+	vs := &Server{
+		BeaconDB:    db,
+		HeadFetcher: &mockChain.ChainService{State: beaconState},
+		SyncChecker: &mockSync.Sync{IsSyncing: false},
+		DutiesCache: cache.NewDutiesCache(),
+	}
+	res, err := vs.GetDutiesWithLookahead(ctx, &ethpb.DutiesRequest{PublicKeys: pubKeys, Epoch: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.CurrentEpochDuties) != len(pubKeys) || len(res.NextEpochDuties) != len(pubKeys) {
+		t.Fatalf("Wanted %d duties for both epochs, got %d and %d", len(pubKeys), len(res.CurrentEpochDuties), len(res.NextEpochDuties))
+	}
+	if res.NextEpochProposerSlotsKnown {
+		t.Error("Proposer slots of the next epoch can not be known during the requested epoch")
+	}
+	proposers := 0
+	for i, duty := range res.NextEpochDuties {
+		if helpers.SlotToEpoch(duty.AttesterSlot) != 1 {
+			t.Errorf("Wanted attester slot of validator %d in epoch 1, got slot %d", i, duty.AttesterSlot)
+		}
+		if duty.ProposerSlot != 0 {
+			t.Errorf("Wanted proposer slot of validator %d left out, got slot %d", i, duty.ProposerSlot)
+		}
+	}
+	for _, duty := range res.CurrentEpochDuties {
+		if duty.ProposerSlot != 0 {
+			proposers++
+		}
+	}
+	if proposers == 0 {
+		t.Error("Wanted proposer slots in the current epoch duties")
+	}
+
+	// Cached duties of the next epoch keep their proposer slots.
+	next, err := vs.GetDuties(ctx, &ethpb.DutiesRequest{PublicKeys: pubKeys, Epoch: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	proposers = 0
+	for _, duty := range next.Duties {
+		if duty.ProposerSlot != 0 {
+			proposers++
+		}
+	}
+	if proposers == 0 {
+		t.Error("Wanted proposer slots in the cached next epoch duties")
+	}
+
+	// Once the head state reaches the next epoch, its proposer slots are final.
+	if err := beaconState.SetSlot(params.BeaconConfig().SlotsPerEpoch); err != nil {
+		t.Fatal(err)
+	}
+	res, err = vs.GetDutiesWithLookahead(ctx, &ethpb.DutiesRequest{PublicKeys: pubKeys, Epoch: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.NextEpochProposerSlotsKnown {
+		t.Error("Wanted proposer slots of the next epoch to be known")
+	}
+}
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"math/big"
 	"math/rand"
+	"time"
 
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	blockfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/block"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
@@ -17,6 +19,8 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state/interop"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	dbpb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/attestationutil"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
@@ -34,41 +38,129 @@ func (vs *Server) GetBlock(ctx context.Context, req *ethpb.BlockRequest) (*ethpb
 	span.AddAttributes(trace.Int64Attribute("slot", int64(req.Slot)))
 
 	if vs.SyncChecker.Syncing() {
-		return nil, status.Errorf(codes.Unavailable, "Syncing to latest head, not ready to respond")
+		return nil, vs.syncingError()
+	}
+
+	blk, _, err := vs.assembleBlock(ctx, req, vs.proposalDeadline(req.Slot), vs.filterAttestationsForBlockInclusion)
+	return blk, err
+}
+
+// ProposalDryRunRequest is the request for a block proposal dry-run. The randao reveal is
+// optional, a zero reveal is used if it is empty.
+type ProposalDryRunRequest struct {
+	Slot         uint64 `json:"slot"`
+	RandaoReveal []byte `json:"randao_reveal,omitempty"`
+	Graffiti     []byte `json:"graffiti,omitempty"`
+}
+
+// ProposalTimings records how long each step of assembling a block proposal took, in
+// nanoseconds when encoded to JSON.
+type ProposalTimings struct {
+	Eth1Data     time.Duration `json:"eth1_data"`
+	Deposits     time.Duration `json:"deposits"`
+	Attestations time.Duration `json:"attestations"`
+	Operations   time.Duration `json:"operations"`
+	StateRoot    time.Duration `json:"state_root"`
+	Total        time.Duration `json:"total"`
+}
+
+// ProposalDryRunResponse contains the unsigned block assembled by a proposal dry-run
+// and the time spent assembling it.
+type ProposalDryRunResponse struct {
+	Block   *ethpb.BeaconBlock `json:"block"`
+	Timings *ProposalTimings   `json:"timings"`
+}
+
+// GetBlockDryRun assembles a full block proposal for the slot as GetBlock does, without
+// requiring a randao reveal signed by the proposer, so operators can check the node is ready
+// to propose and measure how long block assembly takes. The block is not signed nor
+// broadcast, and nothing is removed from the operation pools. The slot may be at most the
+// slot after the current slot, so that a request can't make the node process slots up to
+// any slot.
+func (vs *Server) GetBlockDryRun(ctx context.Context, req *ProposalDryRunRequest) (*ProposalDryRunResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "ProposerServer.GetBlockDryRun")
+	defer span.End()
+	span.AddAttributes(trace.Int64Attribute("slot", int64(req.Slot)))
+
+	if vs.SyncChecker.Syncing() {
+		return nil, vs.syncingError()
+	}
+	if currentSlot := vs.GenesisTimeFetcher.CurrentSlot(); req.Slot > currentSlot+1 {
+		return nil, status.Errorf(codes.InvalidArgument, "Slot %d is beyond the next slot %d", req.Slot, currentSlot+1)
+	}
+	randaoReveal := req.RandaoReveal
+	if len(randaoReveal) == 0 {
+		randaoReveal = make([]byte, 96)
+	}
+	blk, timings, err := vs.assembleBlock(ctx, &ethpb.BlockRequest{
+		Slot:         req.Slot,
+		RandaoReveal: randaoReveal,
+		Graffiti:     req.Graffiti,
+	}, time.Time{}, vs.selectAttestationsForBlockInclusion)
+	if err != nil {
+		return nil, err
+	}
+	return &ProposalDryRunResponse{
+		Block:   blk,
+		Timings: timings,
+	}, nil
+}
+
+// assembleBlock packs the eth1 data, deposits, attestations and other operations for a block
+// proposal on top of the current head and computes its state root, timing each step. If the
+// deadline is not zero, last-known eth1 data and deposits are used when determining them
+// again does not complete by the deadline. The pool attestations are filtered with filterAtts.
+func (vs *Server) assembleBlock(
+	ctx context.Context,
+	req *ethpb.BlockRequest,
+	deadline time.Time,
+	filterAtts func(ctx context.Context, slot uint64, atts []*ethpb.Attestation) ([]*ethpb.Attestation, error),
+) (*ethpb.BeaconBlock, *ProposalTimings, error) {
+	timings := &ProposalTimings{}
+	start := time.Now()
+	step := start
+	since := func() time.Duration {
+		now := time.Now()
+		d := now.Sub(step)
+		step = now
+		return d
 	}
 
 	// Retrieve the parent block as the current head of the canonical chain.
 	parentRoot, err := vs.HeadFetcher.HeadRoot(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not retrieve head root: %v", err)
+		return nil, nil, status.Errorf(codes.Internal, "Could not retrieve head root: %v", err)
 	}
-	eth1Data, err := vs.eth1Data(ctx, req.Slot)
+	eth1Data, err := vs.eth1DataWithDeadline(ctx, req.Slot, deadline)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not get ETH1 data: %v", err)
+		return nil, nil, status.Errorf(codes.Internal, "Could not get ETH1 data: %v", err)
 	}
+	timings.Eth1Data = since()
 
 	// Pack ETH1 deposits which have not been included in the beacon chain.
-	deposits, err := vs.deposits(ctx, eth1Data)
+	deposits, err := vs.depositsWithDeadline(ctx, eth1Data, deadline)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not get ETH1 deposits: %v", err)
+		return nil, nil, status.Errorf(codes.Internal, "Could not get ETH1 deposits: %v", err)
 	}
+	timings.Deposits = since()
 
 	// Pack aggregated attestations which have not been included in the beacon chain.
 	atts := vs.AttPool.AggregatedAttestations()
-	atts, err = vs.filterAttestationsForBlockInclusion(ctx, req.Slot, atts)
+	atts, err = filterAtts(ctx, req.Slot, atts)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not filter attestations: %v", err)
+		return nil, nil, status.Errorf(codes.Internal, "Could not filter attestations: %v", err)
 	}
 
 	// If there is any room left in the block, consider unaggregated attestations as well.
 	if len(atts) < int(params.BeaconConfig().MaxAttestations) {
 		uAtts := vs.AttPool.UnaggregatedAttestations()
-		uAtts, err = vs.filterAttestationsForBlockInclusion(ctx, req.Slot, uAtts)
+		uAtts, err = filterAtts(ctx, req.Slot, uAtts)
 		if len(uAtts)+len(atts) > int(params.BeaconConfig().MaxAttestations) {
 			uAtts = uAtts[:int(params.BeaconConfig().MaxAttestations)-len(atts)]
 		}
 		atts = append(atts, uAtts...)
 	}
+	timings.Attestations = since()
 
 	// Use zero hash as stub for state root to compute later.
 	stateRoot := params.BeaconConfig().ZeroHash[:]
@@ -77,7 +169,7 @@ func (vs *Server) GetBlock(ctx context.Context, req *ethpb.BlockRequest) (*ethpb
 
 	head, err := vs.HeadFetcher.HeadState(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not get head state %v", err)
+		return nil, nil, status.Errorf(codes.Internal, "Could not get head state %v", err)
 	}
 
 	blk := &ethpb.BeaconBlock{
@@ -95,16 +187,19 @@ func (vs *Server) GetBlock(ctx context.Context, req *ethpb.BlockRequest) (*ethpb
 			Graffiti:          graffiti[:],
 		},
 	}
+	timings.Operations = since()
 
 	// Compute state root with the newly constructed block.
 	stateRoot, err = vs.computeStateRoot(ctx, &ethpb.SignedBeaconBlock{Block: blk, Signature: make([]byte, 96)})
 	if err != nil {
 		interop.WriteBlockToDisk(&ethpb.SignedBeaconBlock{Block: blk}, true /*failed*/)
-		return nil, status.Errorf(codes.Internal, "Could not compute state root: %v", err)
+		return nil, nil, status.Errorf(codes.Internal, "Could not compute state root: %v", err)
 	}
 	blk.StateRoot = stateRoot
+	timings.StateRoot = since()
+	timings.Total = time.Since(start)
 
-	return blk, nil
+	return blk, timings, nil
 }
 
 // ProposeBlock is called by a proposer during its assigned slot to create a block in an attempt
@@ -116,6 +211,9 @@ func (vs *Server) ProposeBlock(ctx context.Context, blk *ethpb.SignedBeaconBlock
 	}
 	log.WithField("blockRoot", fmt.Sprintf("%#x", bytesutil.Trunc(root[:]))).Debugf(
 		"Block proposal received via RPC")
+	if err := vs.checkProposerEquivocation(ctx, blk, root); err != nil {
+		return nil, err
+	}
 	vs.BlockNotifier.BlockFeed().Send(&feed.Event{
 		Type: blockfeed.ReceivedBlock,
 		Data: &blockfeed.ReceivedBlockData{SignedBlock: blk},
@@ -264,7 +362,7 @@ func (vs *Server) deposits(ctx context.Context, currentVote *ethpb.Eth1Data) ([]
 		depositData = append(depositData, depHash[:])
 	}
 
-	depositTrie, err := trieutil.GenerateTrieFromItems(depositData, int(params.BeaconConfig().DepositContractTreeDepth))
+	depositTrie, err := vs.historicalDepositTrie(depositData)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not generate historical deposit trie from deposits")
 	}
@@ -298,6 +396,14 @@ func (vs *Server) deposits(ctx context.Context, currentVote *ethpb.Eth1Data) ([]
 	return pendingDeposits, nil
 }
 
+// historicalDepositTrie generates the deposit trie of the deposits in the deposit cache. If the
+// node was bootstrapped from a deposit tree snapshot, the cached deposits are inserted after
+// the deposits of the snapshot.
+func (vs *Server) historicalDepositTrie(depositData [][]byte) (*trieutil.SparseMerkleTrie, error) {
+	depth := int(params.BeaconConfig().DepositContractTreeDepth)
+	return trieutil.TrieFromSnapshotAndItems(vs.DepositFetcher.DepositSnapshot(), depositData, depth)
+}
+
 // canonicalEth1Data determines the canonical eth1data and eth1 block height to use for determining deposits.
 func (vs *Server) canonicalEth1Data(ctx context.Context, beaconState *stateTrie.BeaconState, currentVote *ethpb.Eth1Data) (*ethpb.Eth1Data, *big.Int, error) {
 	var eth1BlockHash [32]byte
@@ -347,44 +453,130 @@ func (vs *Server) defaultEth1DataResponse(ctx context.Context, currentHeight *bi
 }
 
 // This filters the input attestations to return a list of valid attestations to be packaged inside a beacon block.
+// The invalid attestations are deleted from the pool.
 func (vs *Server) filterAttestationsForBlockInclusion(ctx context.Context, slot uint64, atts []*ethpb.Attestation) ([]*ethpb.Attestation, error) {
 	ctx, span := trace.StartSpan(ctx, "ProposerServer.filterAttestationsForBlockInclusion")
 	defer span.End()
 
+	validAtts, inValidAtts, err := vs.partitionAttestationsForBlockInclusion(ctx, slot, atts)
+	if err != nil {
+		return nil, err
+	}
+	if err := vs.deleteAttsInPool(inValidAtts); err != nil {
+		return nil, err
+	}
+	return validAtts, nil
+}
+
+// selectAttestationsForBlockInclusion returns the valid attestations to be packaged inside a beacon
+// block, as filterAttestationsForBlockInclusion does, but leaves the pool untouched. It is used by
+// proposals which are never broadcast, such as dry runs.
+func (vs *Server) selectAttestationsForBlockInclusion(ctx context.Context, slot uint64, atts []*ethpb.Attestation) ([]*ethpb.Attestation, error) {
+	validAtts, _, err := vs.partitionAttestationsForBlockInclusion(ctx, slot, atts)
+	return validAtts, err
+}
+
+// partitionAttestationsForBlockInclusion splits the input attestations into the valid attestations
+// to be packaged inside a beacon block, and the invalid or redundant attestations.
+func (vs *Server) partitionAttestationsForBlockInclusion(ctx context.Context, slot uint64, atts []*ethpb.Attestation) ([]*ethpb.Attestation, []*ethpb.Attestation, error) {
 	validAtts := make([]*ethpb.Attestation, 0, len(atts))
 	inValidAtts := make([]*ethpb.Attestation, 0, len(atts))
 
 	bState, err := vs.HeadFetcher.HeadState(ctx)
 	if err != nil {
-		return nil, errors.New("could not head state from DB")
+		return nil, nil, errors.New("could not head state from DB")
 	}
 
 	if bState.Slot() < slot {
 		bState, err = state.ProcessSlots(ctx, bState, slot)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not process slots up to %d", slot)
+			return nil, nil, errors.Wrapf(err, "could not process slots up to %d", slot)
 		}
 	}
 
+	records, err := precompute.RecordsFromState(ctx, bState)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not compute validator participation")
+	}
+	proposerIndex, err := helpers.BeaconProposerIndex(bState)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get proposer index")
+	}
+
 	// TODO(3916): Insert optimizations to sort out the most profitable attestations
-	for i, att := range atts {
-		if i == int(params.BeaconConfig().MaxAttestations) {
+	redundant := 0
+	for _, att := range atts {
+		if len(validAtts) == int(params.BeaconConfig().MaxAttestations) {
 			break
 		}
 
+		isRedundant, err := isRedundantAttestation(bState, records.Validators, att)
+		if err != nil {
+			inValidAtts = append(inValidAtts, att)
+			continue
+		}
+		if isRedundant {
+			inValidAtts = append(inValidAtts, att)
+			redundant++
+			continue
+		}
 		if _, err := blocks.ProcessAttestation(ctx, bState, att); err != nil {
 			inValidAtts = append(inValidAtts, att)
 			continue
 
 		}
 		validAtts = append(validAtts, att)
+		// Later attestations of the same attesters are redundant once this one is included.
+		if err := records.UpdateWithAttestation(&pb.PendingAttestation{
+			AggregationBits: att.AggregationBits,
+			Data:            att.Data,
+			InclusionDelay:  slot - att.Data.Slot,
+			ProposerIndex:   proposerIndex,
+		}); err != nil {
+			log.WithError(err).Debug("Could not update validator participation with attestation")
+		}
 	}
 
-	if err := vs.deleteAttsInPool(inValidAtts); err != nil {
-		return nil, err
+	if redundant > 0 {
+		log.WithField("redundant", redundant).Debug("Found attestations already covered on chain")
 	}
 
-	return validAtts, nil
+	return validAtts, inValidAtts, nil
+}
+
+// This states if including the attestation in a block rewards no one, as all of its attesters
+// already have an attestation of the same target epoch included in the chain of the state, which
+// attested to the target and to the head. The attesting records are expected to be computed from
+// the state.
+func isRedundantAttestation(st *stateTrie.BeaconState, vp []*precompute.Validator, att *ethpb.Attestation) (bool, error) {
+	if att.Data == nil || att.Data.Target == nil {
+		return false, errors.New("nil attestation data")
+	}
+	committee, err := helpers.BeaconCommitteeFromState(st, att.Data.Slot, att.Data.CommitteeIndex)
+	if err != nil {
+		return false, err
+	}
+	indices, err := attestationutil.AttestingIndices(att.AggregationBits, committee)
+	if err != nil {
+		return false, err
+	}
+	if len(indices) == 0 {
+		return false, nil
+	}
+	isCurrentEpoch := att.Data.Target.Epoch == helpers.CurrentEpoch(st)
+	for _, i := range indices {
+		if i >= uint64(len(vp)) {
+			return false, nil
+		}
+		v := vp[i]
+		if isCurrentEpoch && !(v.IsCurrentEpochTargetAttester && v.IsCurrentEpochHeadAttester) {
+			return false, nil
+		}
+		if !isCurrentEpoch && !(v.IsPrevEpochTargetAttester && v.IsPrevEpochHeadAttester) {
+			return false, nil
+		}
+	}
+	return true, nil
 }
 
 // The input attestations are processed and seen by the node, this deletes them from pool
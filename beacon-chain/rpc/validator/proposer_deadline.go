@@ -0,0 +1,171 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/slotutil"
+)
+
+var proposalFallbacks = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "proposal_assembly_fallbacks_total",
+	Help: "The number of block proposals which used last-known values because a step exceeded its time budget",
+}, []string{"step"})
+
+// proposalFallbackCache holds the last eth1 data vote and deposits which were computed in
+// time for a block proposal, to be reused when computing them again takes too long.
+type proposalFallbackCache struct {
+	lock         sync.RWMutex
+	eth1Data     *ethpb.Eth1Data
+	depositIndex uint64
+	depositRoot  []byte
+	deposits     []*ethpb.Deposit
+}
+
+// proposalDeadline returns the time by which the eth1 data and deposits of a block proposal
+// for the slot should be ready, a third of the way through the slot, when attesters vote on
+// the head of the chain.
+func (vs *Server) proposalDeadline(slot uint64) time.Time {
+	if vs.GenesisTimeFetcher == nil {
+		return time.Time{}
+	}
+	genesis := uint64(vs.GenesisTimeFetcher.GenesisTime().Unix())
+	budget := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second / 3
+	return slotutil.SlotStartTime(genesis, slot).Add(budget)
+}
+
+// eth1DataWithDeadline determines the eth1 data vote of a block proposal. If it is not ready by
+// the deadline, the last eth1 data vote computed in time is used instead, or the eth1 data of
+// the head state, which is always a valid vote.
+func (vs *Server) eth1DataWithDeadline(ctx context.Context, slot uint64, deadline time.Time) (*ethpb.Eth1Data, error) {
+	if deadline.IsZero() {
+		return vs.eth1Data(ctx, slot)
+	}
+	type result struct {
+		eth1Data *ethpb.Eth1Data
+		err      error
+	}
+	done := make(chan result, 1)
+	go func() {
+		eth1Data, err := vs.eth1Data(ctx, slot)
+		done <- result{eth1Data: eth1Data, err: err}
+	}()
+
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	select {
+	case res := <-done:
+		if res.err == nil {
+			vs.proposalFallback.lock.Lock()
+			vs.proposalFallback.eth1Data = res.eth1Data
+			vs.proposalFallback.lock.Unlock()
+		}
+		return res.eth1Data, res.err
+	case <-timer.C:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	proposalFallbacks.WithLabelValues("eth1_data").Inc()
+	vs.proposalFallback.lock.RLock()
+	eth1Data := vs.proposalFallback.eth1Data
+	vs.proposalFallback.lock.RUnlock()
+	if eth1Data != nil {
+		log.WithField("slot", slot).Warn("ETH1 data took too long to determine, using the last known vote")
+		return eth1Data, nil
+	}
+	headState, err := vs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head state")
+	}
+	log.WithField("slot", slot).Warn("ETH1 data took too long to determine, voting for the head state ETH1 data")
+	return headState.Eth1Data(), nil
+}
+
+// depositsWithDeadline packs the pending deposits of a block proposal. If they are not ready by
+// the deadline, the deposits last packed in time are used when they were packed for the same
+// deposit index and canonical deposit root, or no deposits when none are pending. As a block
+// missing required deposits is invalid, it keeps waiting for the deposits otherwise.
+func (vs *Server) depositsWithDeadline(ctx context.Context, currentVote *ethpb.Eth1Data, deadline time.Time) ([]*ethpb.Deposit, error) {
+	if deadline.IsZero() || vs.MockEth1Votes || !vs.Eth1InfoFetcher.IsConnectedToETH1() {
+		return vs.deposits(ctx, currentVote)
+	}
+	headState, err := vs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head state")
+	}
+	depositIndex := headState.Eth1DepositIndex()
+	headState.AppendEth1DataVotes(currentVote)
+	hasSupport, err := blocks.Eth1DataHasEnoughSupport(headState, currentVote)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not determine if current eth1data vote has enough support")
+	}
+	canonicalEth1Data := headState.Eth1Data()
+	if hasSupport {
+		canonicalEth1Data = currentVote
+	}
+	depositRoot := canonicalEth1Data.DepositRoot
+
+	type result struct {
+		deposits []*ethpb.Deposit
+		err      error
+	}
+	done := make(chan result, 1)
+	go func() {
+		deposits, err := vs.deposits(ctx, currentVote)
+		done <- result{deposits: deposits, err: err}
+	}()
+	save := func(res result) ([]*ethpb.Deposit, error) {
+		if res.err == nil {
+			vs.proposalFallback.lock.Lock()
+			vs.proposalFallback.depositIndex = depositIndex
+			vs.proposalFallback.depositRoot = depositRoot
+			vs.proposalFallback.deposits = res.deposits
+			vs.proposalFallback.lock.Unlock()
+		}
+		return res.deposits, res.err
+	}
+
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	select {
+	case res := <-done:
+		return save(res)
+	case <-timer.C:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	// No deposit proofs are needed if all the deposits of the canonical eth1 data were processed.
+	if canonicalEth1Data.DepositCount <= depositIndex {
+		proposalFallbacks.WithLabelValues("deposits").Inc()
+		log.WithField("depositIndex", depositIndex).Warn("Deposits took too long to pack, proposing without deposits")
+		return []*ethpb.Deposit{}, nil
+	}
+	vs.proposalFallback.lock.RLock()
+	cached := vs.proposalFallback.deposits != nil &&
+		vs.proposalFallback.depositIndex == depositIndex &&
+		bytes.Equal(vs.proposalFallback.depositRoot, depositRoot)
+	deposits := vs.proposalFallback.deposits
+	vs.proposalFallback.lock.RUnlock()
+	if cached {
+		proposalFallbacks.WithLabelValues("deposits").Inc()
+		log.WithField("depositIndex", depositIndex).Warn("Deposits took too long to pack, using the last packed deposits")
+		return deposits, nil
+	}
+	log.WithField("depositIndex", depositIndex).Warn("Deposits took too long to pack and no packed deposits can be reused, waiting")
+	select {
+	case res := <-done:
+		return save(res)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,146 @@
+package validator
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
+	mockPOW "github.com/prysmaticlabs/prysm/beacon-chain/powchain/testing"
+	beaconstate "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// slowPOWChain blocks eth1 block lookups until the context is canceled.
+type slowPOWChain struct {
+	*mockPOW.POWChain
+}
+
+func (s *slowPOWChain) BlockNumberByTimestamp(ctx context.Context, _ uint64) (*big.Int, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (s *slowPOWChain) BlockExists(ctx context.Context, _ common.Hash) (bool, *big.Int, error) {
+	<-ctx.Done()
+	return false, nil, ctx.Err()
+}
+
+func TestEth1DataWithDeadline_FallsBack(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	slot := uint64(10000)
+
+	p := &mockPOW.POWChain{
+		BlockNumberByHeight: map[uint64]*big.Int{
+			slot * params.BeaconConfig().SecondsPerSlot: big.NewInt(4096),
+		},
+		HashesByHeight: map[int][]byte{
+			4080: []byte("4080"),
+		},
+		Eth1Data: &ethpb.Eth1Data{
+			DepositCount: 55,
+		},
+	}
+	headState, err := beaconstate.InitializeFromProto(&pbp2p.BeaconState{
+		Eth1Data: &ethpb.Eth1Data{DepositCount: 8},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ps := &Server{
+		HeadFetcher:       &mock.ChainService{State: headState},
+		ChainStartFetcher: p,
+		Eth1InfoFetcher:   p,
+		Eth1BlockFetcher:  &slowPOWChain{POWChain: p},
+		DepositFetcher:    depositcache.NewDepositCache(),
+	}
+
+	// Without a vote determined in time, the eth1 data of the head state is used.
+	eth1Data, err := ps.eth1DataWithDeadline(ctx, slot, time.Now().Add(10*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eth1Data.DepositCount != 8 {
+		t.Errorf("Wanted head state eth1 data with deposit count 8, got %d", eth1Data.DepositCount)
+	}
+
+	ps.Eth1BlockFetcher = p
+	eth1Data, err = ps.eth1DataWithDeadline(ctx, slot, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eth1Data.DepositCount != 55 {
+		t.Fatalf("Wanted deposit count 55, got %d", eth1Data.DepositCount)
+	}
+
+	// The last vote determined in time is reused.
+	ps.Eth1BlockFetcher = &slowPOWChain{POWChain: p}
+	eth1Data, err = ps.eth1DataWithDeadline(ctx, slot, time.Now().Add(10*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eth1Data.DepositCount != 55 {
+		t.Errorf("Wanted last known vote with deposit count 55, got %d", eth1Data.DepositCount)
+	}
+}
+
+func TestDepositsWithDeadline_FallsBack(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eth1Data := &ethpb.Eth1Data{DepositRoot: []byte("root"), DepositCount: 2, BlockHash: []byte("hash")}
+	headState, err := beaconstate.InitializeFromProto(&pbp2p.BeaconState{
+		Eth1Data:         eth1Data,
+		Eth1DepositIndex: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &mockPOW.POWChain{}
+	ps := &Server{
+		HeadFetcher:      &mock.ChainService{State: headState},
+		Eth1InfoFetcher:  p,
+		Eth1BlockFetcher: &slowPOWChain{POWChain: p},
+	}
+
+	// All the deposits of the canonical eth1 data were processed, no deposits are needed.
+	deposits, err := ps.depositsWithDeadline(ctx, eth1Data, time.Now().Add(10*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deposits) != 0 {
+		t.Errorf("Wanted no deposits, got %d", len(deposits))
+	}
+
+	// Pending deposits packed in time for the same deposit index and root are reused.
+	eth1Data.DepositCount = 3
+	if err := headState.SetEth1Data(eth1Data); err != nil {
+		t.Fatal(err)
+	}
+	cached := []*ethpb.Deposit{{Data: &ethpb.Deposit_Data{Amount: 32e9}}}
+	ps.proposalFallback.depositIndex = 2
+	ps.proposalFallback.depositRoot = eth1Data.DepositRoot
+	ps.proposalFallback.deposits = cached
+	deposits, err = ps.depositsWithDeadline(ctx, eth1Data, time.Now().Add(10*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(deposits, cached) {
+		t.Errorf("Wanted the last packed deposits, got %v", deposits)
+	}
+
+	// Without reusable deposits, it waits for the deposits to be packed.
+	ps.proposalFallback.depositIndex = 1
+	waitCtx, waitCancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer waitCancel()
+	if _, err := ps.depositsWithDeadline(waitCtx, eth1Data, time.Now().Add(10*time.Millisecond)); err == nil {
+		t.Error("Expected to wait for the deposits until the context is done")
+	}
+}
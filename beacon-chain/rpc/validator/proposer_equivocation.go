@@ -0,0 +1,100 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkProposerEquivocation refuses a block proposal if the beacon node already has another
+// block signed by the same proposer at the same slot, in the canonical chain or orphaned by a
+// reorg. Broadcasting both blocks would get the proposer slashed, which happens when two beacon
+// node and validator client pairs are accidentally run with the same keys.
+func (vs *Server) checkProposerEquivocation(ctx context.Context, blk *ethpb.SignedBeaconBlock, root [32]byte) error {
+	ctx, span := trace.StartSpan(ctx, "ProposerServer.checkProposerEquivocation")
+	defer span.End()
+
+	slot := blk.Block.Slot
+	canonical, err := vs.BeaconDB.Blocks(ctx, filters.NewFilter().SetStartSlot(slot).SetEndSlot(slot))
+	if err != nil {
+		return status.Errorf(codes.Internal, "Could not retrieve blocks at slot %d: %v", slot, err)
+	}
+	orphaned, err := vs.BeaconDB.OrphanedBlocks(ctx, slot)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Could not retrieve orphaned blocks at slot %d: %v", slot, err)
+	}
+	others := make(map[[32]byte]*ethpb.SignedBeaconBlock)
+	for _, other := range append(canonical, orphaned...) {
+		otherRoot, err := ssz.HashTreeRoot(other.Block)
+		if err != nil {
+			return status.Errorf(codes.Internal, "Could not tree hash block: %v", err)
+		}
+		if otherRoot != root {
+			others[otherRoot] = other
+		}
+	}
+	if len(others) == 0 {
+		return nil
+	}
+
+	// Blocks carry no proposer index, so the other blocks are checked against the public key
+	// of the proposer of this block.
+	parentState, err := vs.StateGen.StateByRoot(ctx, bytesutil.ToBytes32(blk.Block.ParentRoot))
+	if err != nil {
+		return status.Errorf(codes.Internal, "Could not retrieve parent state: %v", err)
+	}
+	if parentState == nil {
+		return status.Errorf(codes.FailedPrecondition, "No state for parent root %#x", blk.Block.ParentRoot)
+	}
+	st := parentState.Copy()
+	if st.Slot() < slot {
+		st, err = state.ProcessSlots(ctx, st, slot)
+		if err != nil {
+			return status.Errorf(codes.Internal, "Could not process slots: %v", err)
+		}
+	}
+	proposerIndex, err := helpers.BeaconProposerIndex(st)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Could not compute proposer index: %v", err)
+	}
+	proposerKey, err := st.PubkeyAtIndex(proposerIndex)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Could not get proposer public key: %v", err)
+	}
+	pubKey, err := bls.PublicKeyFromBytes(proposerKey[:])
+	if err != nil {
+		return status.Errorf(codes.Internal, "Could not convert proposer public key: %v", err)
+	}
+	domain, err := helpers.Domain(st.Fork(), helpers.SlotToEpoch(slot), params.BeaconConfig().DomainBeaconProposer)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Could not get proposer domain: %v", err)
+	}
+	for otherRoot, other := range others {
+		sig, err := bls.SignatureFromBytes(other.Signature)
+		if err != nil {
+			continue
+		}
+		if sig.Verify(otherRoot[:], pubKey, domain) {
+			log.WithFields(logrus.Fields{
+				"slot":          slot,
+				"proposerIndex": proposerIndex,
+				"blockRoot":     fmt.Sprintf("%#x", bytesutil.Trunc(root[:])),
+				"existingRoot":  fmt.Sprintf("%#x", bytesutil.Trunc(otherRoot[:])),
+			}).Error("Refusing to propose a second block for the same slot, is another validator client running with the same keys?")
+			return status.Errorf(codes.FailedPrecondition, "Proposer %d already signed block %#x at slot %d", proposerIndex, otherRoot, slot)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	b "github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestProposeBlock_RefusesEquivocation(t *testing.T) {
+	db := dbutil.SetupDB(t)
+	defer dbutil.TeardownDB(t, db)
+	ctx := context.Background()
+
+	beaconState, privKeys := testutil.DeterministicGenesisState(t, 64)
+	stateRoot, err := beaconState.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesis := b.NewGenesisBlock(stateRoot[:])
+	if err := db.SaveBlock(ctx, genesis); err != nil {
+		t.Fatal(err)
+	}
+	genesisRoot, err := ssz.HashTreeRoot(genesis.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, beaconState, genesisRoot); err != nil {
+		t.Fatal(err)
+	}
+
+	st := beaconState.Copy()
+	if err := st.SetSlot(1); err != nil {
+		t.Fatal(err)
+	}
+	proposerIdx, err := helpers.BeaconProposerIndex(st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	domain, err := helpers.Domain(st.Fork(), 0, params.BeaconConfig().DomainBeaconProposer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conflicting := func(graffiti string, signerIdx uint64) *ethpb.SignedBeaconBlock {
+		blk, err := testutil.GenerateFullBlock(beaconState, privKeys, nil, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		blk.Block.Body.Graffiti = []byte(graffiti)
+		root, err := ssz.HashTreeRoot(blk.Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		blk.Signature = privKeys[signerIdx].Sign(root[:], domain).Marshal()
+		return blk
+	}
+
+	c := &mock.ChainService{}
+	proposerServer := &Server{
+		BeaconDB:      db,
+		BlockReceiver: c,
+		HeadFetcher:   c,
+		BlockNotifier: c.BlockNotifier(),
+		StateGen:      stategen.New(db),
+	}
+	blk, err := testutil.GenerateFullBlock(beaconState, privKeys, nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A block at the same slot signed by another validator does not prevent the proposal.
+	other := conflicting("other validator", (proposerIdx+1)%uint64(len(privKeys)))
+	if err := db.SaveBlock(ctx, other); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := proposerServer.ProposeBlock(ctx, blk); err != nil {
+		t.Fatalf("Could not propose block: %v", err)
+	}
+
+	// Proposing the same block again is not an equivocation.
+	if err := db.SaveBlock(ctx, blk); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := proposerServer.ProposeBlock(ctx, proto.Clone(blk).(*ethpb.SignedBeaconBlock)); err != nil {
+		t.Fatalf("Could not propose block again: %v", err)
+	}
+
+	// A second block signed by the proposer at the same slot is refused.
+	_, err = proposerServer.ProposeBlock(ctx, conflicting("second block", proposerIdx))
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Wanted the equivocating block to be refused, received %v", err)
+	}
+}
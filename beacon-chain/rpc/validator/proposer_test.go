@@ -15,6 +15,7 @@ import (
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
 	b "github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
@@ -32,6 +33,8 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
 	"github.com/prysmaticlabs/prysm/shared/trieutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func init() {
@@ -144,6 +147,104 @@ func TestGetBlock_OK(t *testing.T) {
 	}
 }
 
+func TestGetBlockDryRun_OK(t *testing.T) {
+	db := dbutil.SetupDB(t)
+	defer dbutil.TeardownDB(t, db)
+	ctx := context.Background()
+
+	beaconState, _ := testutil.DeterministicGenesisState(t, params.BeaconConfig().MinGenesisActiveValidatorCount)
+	stateRoot, err := beaconState.HashTreeRoot()
+	if err != nil {
+		t.Fatalf("Could not hash genesis state: %v", err)
+	}
+	genesis := b.NewGenesisBlock(stateRoot[:])
+	if err := db.SaveBlock(ctx, genesis); err != nil {
+		t.Fatalf("Could not save genesis block: %v", err)
+	}
+	parentRoot, err := ssz.HashTreeRoot(genesis.Block)
+	if err != nil {
+		t.Fatalf("Could not get signing root %v", err)
+	}
+	if err := db.SaveState(ctx, beaconState, parentRoot); err != nil {
+		t.Fatalf("Could not save genesis state: %v", err)
+	}
+
+	proposerServer := &Server{
+		BeaconDB:           db,
+		HeadFetcher:        &mock.ChainService{State: beaconState, Root: parentRoot[:]},
+		GenesisTimeFetcher: &mock.ChainService{},
+		SyncChecker:        &mockSync.Sync{IsSyncing: false},
+		ChainStartFetcher:  &mockPOW.POWChain{},
+		Eth1InfoFetcher:    &mockPOW.POWChain{},
+		Eth1BlockFetcher:   &mockPOW.POWChain{},
+		MockEth1Votes:      true,
+		AttPool:            attestations.NewPool(),
+		SlashingsPool:      slashings.NewPool(),
+		ExitPool:           voluntaryexits.NewPool(),
+	}
+
+	// An attestation which can not be included in the block must be left in the pool by a dry run.
+	invalidAtt := &ethpb.Attestation{
+		AggregationBits: bitfield.Bitlist{0b11},
+		Data: &ethpb.AttestationData{
+			BeaconBlockRoot: make([]byte, 32),
+			Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Epoch: 100, Root: make([]byte, 32)},
+		},
+		Signature: make([]byte, 96),
+	}
+	if err := proposerServer.AttPool.SaveUnaggregatedAttestation(invalidAtt); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := proposerServer.GetBlockDryRun(ctx, &ProposalDryRunRequest{Slot: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Block.Body.Attestations) != 0 {
+		t.Errorf("Wanted no attestations in the block, got %d", len(res.Block.Body.Attestations))
+	}
+	if count := proposerServer.AttPool.UnaggregatedAttestationCount(); count != 1 {
+		t.Errorf("Wanted the invalid attestation to be kept in the pool, got %d attestations", count)
+	}
+	if res.Block.Slot != 1 {
+		t.Errorf("Wanted block slot 1, got %d", res.Block.Slot)
+	}
+	if !bytes.Equal(res.Block.ParentRoot, parentRoot[:]) {
+		t.Error("Expected block to have correct parent root")
+	}
+	if !bytes.Equal(res.Block.Body.RandaoReveal, make([]byte, 96)) {
+		t.Error("Expected block to have a zero randao reveal")
+	}
+	if bytes.Equal(res.Block.StateRoot, params.BeaconConfig().ZeroHash[:]) {
+		t.Error("Expected state root to be computed")
+	}
+	timings := res.Timings
+	if timings.Total <= 0 {
+		t.Error("Expected total assembly time to be recorded")
+	}
+	if steps := timings.Eth1Data + timings.Deposits + timings.Attestations + timings.Operations + timings.StateRoot; steps > timings.Total {
+		t.Errorf("Sum of step durations %v exceeds total %v", steps, timings.Total)
+	}
+}
+
+func TestGetBlockDryRun_Syncing(t *testing.T) {
+	proposerServer := &Server{SyncChecker: &mockSync.Sync{IsSyncing: true}}
+	if _, err := proposerServer.GetBlockDryRun(context.Background(), &ProposalDryRunRequest{Slot: 1}); status.Code(err) != codes.Unavailable {
+		t.Errorf("Wanted unavailable error, got %v", err)
+	}
+}
+
+func TestGetBlockDryRun_SlotTooFarAhead(t *testing.T) {
+	proposerServer := &Server{
+		SyncChecker:        &mockSync.Sync{IsSyncing: false},
+		GenesisTimeFetcher: &mock.ChainService{},
+	}
+	if _, err := proposerServer.GetBlockDryRun(context.Background(), &ProposalDryRunRequest{Slot: 2}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Wanted invalid argument error, got %v", err)
+	}
+}
+
 func TestGetBlock_AddsUnaggregatedAtts(t *testing.T) {
 	db := dbutil.SetupDB(t)
 	defer dbutil.TeardownDB(t, db)
@@ -1336,6 +1437,140 @@ func TestFilterAttestation_OK(t *testing.T) {
 	}
 }
 
+func TestIsRedundantAttestation(t *testing.T) {
+	st, _ := testutil.DeterministicGenesisState(t, 64)
+	if err := st.SetSlot(params.BeaconConfig().SlotsPerEpoch); err != nil {
+		t.Fatal(err)
+	}
+	rt := [32]byte{'A'}
+	br := st.BlockRoots()
+	br[0] = rt[:]
+	if err := st.SetBlockRoots(br); err != nil {
+		t.Fatal(err)
+	}
+	committee, err := helpers.BeaconCommitteeFromState(st, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := &ethpb.AttestationData{
+		BeaconBlockRoot: rt[:],
+		Source:          &ethpb.Checkpoint{},
+		Target:          &ethpb.Checkpoint{Epoch: 0, Root: rt[:]},
+	}
+	firstHalf := bitfield.NewBitlist(uint64(len(committee)))
+	for i := 0; i < len(committee)/2; i++ {
+		firstHalf.SetBitAt(uint64(i), true)
+	}
+	att := &ethpb.Attestation{Data: data, AggregationBits: firstHalf}
+
+	vp, err := precompute.ValidatorParticipation(context.Background(), st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	redundant, err := isRedundantAttestation(st, vp, att)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if redundant {
+		t.Error("Attestation without any attester on chain should not be redundant")
+	}
+
+	// Once the first half of the committee attested on chain, only attestations of its members
+	// are redundant.
+	if err := st.SetPreviousEpochAttestations([]*pbp2p.PendingAttestation{{Data: data, AggregationBits: firstHalf}}); err != nil {
+		t.Fatal(err)
+	}
+	vp, err = precompute.ValidatorParticipation(context.Background(), st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	redundant, err = isRedundantAttestation(st, vp, att)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !redundant {
+		t.Error("Attestation covered on chain should be redundant")
+	}
+	allBits := bitfield.NewBitlist(uint64(len(committee)))
+	for i := 0; i < len(committee); i++ {
+		allBits.SetBitAt(uint64(i), true)
+	}
+	redundant, err = isRedundantAttestation(st, vp, &ethpb.Attestation{Data: data, AggregationBits: allBits})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if redundant {
+		t.Error("Attestation partially covered on chain should not be redundant")
+	}
+}
+
+func TestIsRedundantAttestation_CurrentEpochHead(t *testing.T) {
+	st, _ := testutil.DeterministicGenesisState(t, 64)
+	epochStart := params.BeaconConfig().SlotsPerEpoch
+	if err := st.SetSlot(epochStart + 2); err != nil {
+		t.Fatal(err)
+	}
+	rt := [32]byte{'A'}
+	br := st.BlockRoots()
+	br[epochStart] = rt[:]
+	if err := st.SetBlockRoots(br); err != nil {
+		t.Fatal(err)
+	}
+	committee, err := helpers.BeaconCommitteeFromState(st, epochStart, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bits := bitfield.NewBitlist(uint64(len(committee)))
+	for i := 0; i < len(committee); i++ {
+		bits.SetBitAt(uint64(i), true)
+	}
+	wrongHead := &ethpb.AttestationData{
+		Slot:            epochStart,
+		BeaconBlockRoot: []byte{'B'},
+		Source:          &ethpb.Checkpoint{},
+		Target:          &ethpb.Checkpoint{Epoch: 1, Root: rt[:]},
+	}
+	rightHead := &ethpb.AttestationData{
+		Slot:            epochStart,
+		BeaconBlockRoot: rt[:],
+		Source:          &ethpb.Checkpoint{},
+		Target:          &ethpb.Checkpoint{Epoch: 1, Root: rt[:]},
+	}
+	att := &ethpb.Attestation{Data: rightHead, AggregationBits: bits}
+
+	// The committee attested to the target on chain, but not to the head, so an attestation to
+	// the head still earns them the head reward.
+	if err := st.SetCurrentEpochAttestations([]*pbp2p.PendingAttestation{{Data: wrongHead, AggregationBits: bits}}); err != nil {
+		t.Fatal(err)
+	}
+	records, err := precompute.RecordsFromState(context.Background(), st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	redundant, err := isRedundantAttestation(st, records.Validators, att)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if redundant {
+		t.Error("Attestation to the head should not be redundant when only the target was attested on chain")
+	}
+
+	if err := st.SetCurrentEpochAttestations([]*pbp2p.PendingAttestation{{Data: rightHead, AggregationBits: bits}}); err != nil {
+		t.Fatal(err)
+	}
+	records, err = precompute.RecordsFromState(context.Background(), st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	redundant, err = isRedundantAttestation(st, records.Validators, att)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !redundant {
+		t.Error("Attestation covered on chain should be redundant")
+	}
+}
+
 func Benchmark_Eth1Data(b *testing.B) {
 	ctx := context.Background()
 
@@ -0,0 +1,137 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RejectionReason identifies the check a signed block proposal failed.
+type RejectionReason string
+
+// Reasons a signed block proposal is rejected before being broadcast.
+const (
+	RejectMalformedBlock    RejectionReason = "malformed_block"
+	RejectUnknownParent     RejectionReason = "unknown_parent"
+	RejectInvalidSlot       RejectionReason = "invalid_slot"
+	RejectFutureSlot        RejectionReason = "future_slot"
+	RejectInvalidSignature  RejectionReason = "invalid_signature"
+	RejectInvalidHeader     RejectionReason = "invalid_block_header"
+	RejectInvalidRandao     RejectionReason = "invalid_randao"
+	RejectInvalidEth1Data   RejectionReason = "invalid_eth1_data"
+	RejectInvalidOperations RejectionReason = "invalid_operations"
+	RejectStateRootMismatch RejectionReason = "state_root_mismatch"
+)
+
+// ProposalRejection explains why a signed block proposal would be invalid.
+type ProposalRejection struct {
+	Reason  RejectionReason `json:"reason"`
+	Message string          `json:"message"`
+}
+
+// ValidatedProposeResponse is the response to a validated block proposal. Either the block
+// was accepted and broadcast, or Rejection holds the first check it failed.
+type ValidatedProposeResponse struct {
+	Accepted  bool               `json:"accepted"`
+	BlockRoot []byte             `json:"block_root,omitempty"`
+	Rejection *ProposalRejection `json:"rejection,omitempty"`
+}
+
+// ProposeBlockValidated fully validates a signed block against the state of its parent,
+// including the proposer signature and the state root, before proposing it like
+// ProposeBlock. Blocks failing validation are not broadcast, and the reason they were
+// rejected is returned so remote signers learn why a proposal would be invalid.
+func (vs *Server) ProposeBlockValidated(ctx context.Context, blk *ethpb.SignedBeaconBlock) (*ValidatedProposeResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "ProposerServer.ProposeBlockValidated")
+	defer span.End()
+
+	rejection, err := vs.validateProposal(ctx, blk)
+	if err != nil {
+		return nil, err
+	}
+	if rejection != nil {
+		log.WithField("reason", rejection.Reason).Debugf("Rejected block proposal: %s", rejection.Message)
+		return &ValidatedProposeResponse{Rejection: rejection}, nil
+	}
+	res, err := vs.ProposeBlock(ctx, blk)
+	if err != nil {
+		return nil, err
+	}
+	return &ValidatedProposeResponse{
+		Accepted:  true,
+		BlockRoot: res.BlockRoot,
+	}, nil
+}
+
+// validateProposal applies the signed block to a copy of its parent state with full
+// verification. It returns the rejection for the first failed check, or an error if the
+// block could not be checked.
+func (vs *Server) validateProposal(ctx context.Context, blk *ethpb.SignedBeaconBlock) (*ProposalRejection, error) {
+	reject := func(reason RejectionReason, err error) (*ProposalRejection, error) {
+		return &ProposalRejection{Reason: reason, Message: err.Error()}, nil
+	}
+	if blk == nil || blk.Block == nil || blk.Block.Body == nil {
+		return reject(RejectMalformedBlock, errors.New("nil block"))
+	}
+	if len(blk.Signature) != 96 {
+		return reject(RejectMalformedBlock, fmt.Errorf("signature length %d, wanted 96", len(blk.Signature)))
+	}
+
+	parentRoot := bytesutil.ToBytes32(blk.Block.ParentRoot)
+	parentState, err := vs.BeaconDB.State(ctx, parentRoot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not retrieve parent state: %v", err)
+	}
+	if parentState == nil {
+		return reject(RejectUnknownParent, fmt.Errorf("no state for parent root %#x", parentRoot))
+	}
+	if blk.Block.Slot <= parentState.Slot() {
+		return reject(RejectInvalidSlot, fmt.Errorf("block slot %d is not after parent slot %d", blk.Block.Slot, parentState.Slot()))
+	}
+	if err := helpers.VerifySlotTime(uint64(vs.GenesisTimeFetcher.GenesisTime().Unix()), blk.Block.Slot); err != nil {
+		return reject(RejectFutureSlot, err)
+	}
+
+	st := parentState.Copy()
+	st, err = state.ProcessSlots(ctx, st, blk.Block.Slot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not process slots: %v", err)
+	}
+	st, err = blocks.ProcessBlockHeader(st, blk)
+	if err == blocks.ErrSigFailedToVerify {
+		return reject(RejectInvalidSignature, err)
+	}
+	if err != nil {
+		return reject(RejectInvalidHeader, err)
+	}
+	st, err = blocks.ProcessRandao(st, blk.Block.Body)
+	if err != nil {
+		return reject(RejectInvalidRandao, err)
+	}
+	st, err = blocks.ProcessEth1DataInBlock(st, blk.Block)
+	if err != nil {
+		return reject(RejectInvalidEth1Data, err)
+	}
+	st, err = state.ProcessOperations(ctx, st, blk.Block.Body)
+	if err != nil {
+		return reject(RejectInvalidOperations, err)
+	}
+	root, err := st.HashTreeRoot()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not hash post-state: %v", err)
+	}
+	if !bytes.Equal(root[:], blk.Block.StateRoot) {
+		return reject(RejectStateRootMismatch, fmt.Errorf("computed state root %#x, block has %#x", root, blk.Block.StateRoot))
+	}
+	return nil, nil
+}
@@ -0,0 +1,159 @@
+package validator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	b "github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestProposeBlockValidated(t *testing.T) {
+	db := dbutil.SetupDB(t)
+	defer dbutil.TeardownDB(t, db)
+	ctx := context.Background()
+
+	beaconState, privKeys := testutil.DeterministicGenesisState(t, 64)
+	stateRoot, err := beaconState.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesis := b.NewGenesisBlock(stateRoot[:])
+	if err := db.SaveBlock(ctx, genesis); err != nil {
+		t.Fatal(err)
+	}
+	genesisRoot, err := ssz.HashTreeRoot(genesis.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, beaconState, genesisRoot); err != nil {
+		t.Fatal(err)
+	}
+
+	genesisTime := time.Now().Add(-time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	c := &mock.ChainService{Genesis: genesisTime}
+	proposerServer := &Server{
+		BeaconDB:           db,
+		GenesisTimeFetcher: c,
+		BlockReceiver:      c,
+		BlockNotifier:      c.BlockNotifier(),
+		AttPool:            attestations.NewPool(),
+	}
+
+	tests := []struct {
+		name   string
+		block  func(t *testing.T) *ethpb.SignedBeaconBlock
+		reason RejectionReason
+	}{
+		{
+			name: "valid block",
+			block: func(t *testing.T) *ethpb.SignedBeaconBlock {
+				blk, err := testutil.GenerateFullBlock(beaconState, privKeys, nil, 1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return blk
+			},
+		},
+		{
+			name: "malformed block",
+			block: func(t *testing.T) *ethpb.SignedBeaconBlock {
+				return &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 1}}
+			},
+			reason: RejectMalformedBlock,
+		},
+		{
+			name: "unknown parent",
+			block: func(t *testing.T) *ethpb.SignedBeaconBlock {
+				blk, err := testutil.GenerateFullBlock(beaconState, privKeys, nil, 1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				blk.Block.ParentRoot = []byte("unknown parent")
+				return blk
+			},
+			reason: RejectUnknownParent,
+		},
+		{
+			name: "future slot",
+			block: func(t *testing.T) *ethpb.SignedBeaconBlock {
+				blk, err := testutil.GenerateFullBlock(beaconState, privKeys, nil, 20)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return blk
+			},
+			reason: RejectFutureSlot,
+		},
+		{
+			name: "invalid signature",
+			block: func(t *testing.T) *ethpb.SignedBeaconBlock {
+				blk, err := testutil.GenerateFullBlock(beaconState, privKeys, nil, 1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				blk.Block.Body.Graffiti = []byte("not what was signed")
+				return blk
+			},
+			reason: RejectInvalidSignature,
+		},
+		{
+			name: "state root mismatch",
+			block: func(t *testing.T) *ethpb.SignedBeaconBlock {
+				blk, err := testutil.GenerateFullBlock(beaconState, privKeys, nil, 1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				blk.Block.StateRoot = make([]byte, 32)
+				// Re-sign the block so only its state root is invalid.
+				st := beaconState.Copy()
+				if err := st.SetSlot(1); err != nil {
+					t.Fatal(err)
+				}
+				proposerIdx, err := helpers.BeaconProposerIndex(st)
+				if err != nil {
+					t.Fatal(err)
+				}
+				domain, err := helpers.Domain(st.Fork(), 0, params.BeaconConfig().DomainBeaconProposer)
+				if err != nil {
+					t.Fatal(err)
+				}
+				root, err := ssz.HashTreeRoot(blk.Block)
+				if err != nil {
+					t.Fatal(err)
+				}
+				blk.Signature = privKeys[proposerIdx].Sign(root[:], domain).Marshal()
+				return blk
+			},
+			reason: RejectStateRootMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := proposerServer.ProposeBlockValidated(ctx, tt.block(t))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.reason == "" {
+				if !res.Accepted || res.Rejection != nil {
+					t.Fatalf("Expected block to be accepted, got rejection %v", res.Rejection)
+				}
+				return
+			}
+			if res.Accepted {
+				t.Fatal("Expected block to be rejected")
+			}
+			if res.Rejection.Reason != tt.reason {
+				t.Errorf("Wanted rejection %s, got %s: %s", tt.reason, res.Rejection.Reason, res.Rejection.Message)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package validator
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
@@ -22,9 +23,11 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/beacon-chain/sync"
 	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/prysmaticlabs/prysm/shared/statusutil"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -44,6 +47,7 @@ type Server struct {
 	Ctx                    context.Context
 	BeaconDB               db.NoHeadAccessDatabase
 	AttestationCache       *cache.AttestationCache
+	DutiesCache            *cache.DutiesCache
 	HeadFetcher            blockchain.HeadFetcher
 	ForkFetcher            blockchain.ForkFetcher
 	FinalizationFetcher    blockchain.FinalizationFetcher
@@ -67,6 +71,8 @@ type Server struct {
 	PendingDepositsFetcher depositcache.PendingDepositsFetcher
 	OperationNotifier      opfeed.Notifier
 	GenesisTime            time.Time
+	StateGen               *stategen.State
+	proposalFallback       proposalFallbackCache
 }
 
 // WaitForActivation checks if a validator public key exists in the active validator registry of the current
@@ -118,7 +124,7 @@ func (vs *Server) ValidatorIndex(ctx context.Context, req *ethpb.ValidatorIndexR
 		return nil, status.Errorf(codes.Internal, "Could not fetch validator index: %v", err)
 	}
 	if !ok {
-		return nil, status.Errorf(codes.Internal, "Could not find validator index for public key %#x not found", req.PublicKey)
+		return nil, status.Errorf(codes.NotFound, "Could not find validator index for public key %#x", req.PublicKey)
 	}
 
 	return &ethpb.ValidatorIndexResponse{Index: index}, nil
@@ -150,12 +156,22 @@ func (vs *Server) ExitedValidators(
 	return resp, nil
 }
 
+// syncingError returns the error of requests which can't be served while the node is syncing,
+// carrying the head slot of the node, if known, and the delay to retry after.
+func (vs *Server) syncingError() error {
+	var headSlot uint64
+	if vs.HeadFetcher != nil {
+		headSlot = vs.HeadFetcher.HeadSlot()
+	}
+	return statusutil.Syncing(headSlot)
+}
+
 // DomainData fetches the current domain version information from the beacon state.
 func (vs *Server) DomainData(ctx context.Context, request *ethpb.DomainRequest) (*ethpb.DomainResponse, error) {
 	fork := vs.ForkFetcher.CurrentFork()
 	dv, err := helpers.Domain(fork, request.Epoch, bytesutil.ToBytes4(request.Domain))
 	if err != nil {
-		return nil, err
+		return nil, status.Errorf(codes.Internal, "Could not compute domain: %v", err)
 	}
 	return &ethpb.DomainResponse{
 		SignatureDomain: dv,
@@ -175,7 +191,10 @@ func (vs *Server) CanonicalHead(ctx context.Context, req *ptypes.Empty) (*ethpb.
 // WaitForChainStart queries the logs of the Deposit Contract in order to verify the beacon chain
 // has started its runtime and validators begin their responsibilities. If it has not, it then
 // subscribes to an event stream triggered by the powchain service whenever the ChainStart log does
-// occur in the Deposit Contract on ETH 1.0.
+// occur in the Deposit Contract on ETH 1.0, so that connected validators learn the genesis time as
+// soon as it is known and start their duties at genesis. The initialized event of the blockchain
+// service is also accepted, in case the chain started between the head state check and the
+// subscription.
 func (vs *Server) WaitForChainStart(req *ptypes.Empty, stream ethpb.BeaconNodeValidator_WaitForChainStartServer) error {
 	head, err := vs.HeadFetcher.HeadState(context.Background())
 	if err != nil {
@@ -195,16 +214,28 @@ func (vs *Server) WaitForChainStart(req *ptypes.Empty, stream ethpb.BeaconNodeVa
 	for {
 		select {
 		case event := <-stateChannel:
-			if event.Type == statefeed.ChainStarted {
+			var startTime time.Time
+			var validatorsRoot []byte
+			switch event.Type {
+			case statefeed.ChainStarted:
 				data := event.Data.(*statefeed.ChainStartedData)
-				log.WithField("starttime", data.StartTime).Debug("Received chain started event")
-				log.Info("Sending genesis time notification to connected validator clients")
-				res := &ethpb.ChainStartResponse{
-					Started:     true,
-					GenesisTime: uint64(data.StartTime.Unix()),
-				}
-				return stream.Send(res)
+				startTime, validatorsRoot = data.StartTime, data.GenesisValidatorsRoot
+			case statefeed.Initialized:
+				data := event.Data.(*statefeed.InitializedData)
+				startTime, validatorsRoot = data.StartTime, data.GenesisValidatorsRoot
+			default:
+				continue
+			}
+			log.WithFields(logrus.Fields{
+				"starttime":             startTime,
+				"genesisValidatorsRoot": fmt.Sprintf("%#x", validatorsRoot),
+			}).Debug("Received chain started event")
+			log.Info("Sending genesis time notification to connected validator clients")
+			res := &ethpb.ChainStartResponse{
+				Started:     true,
+				GenesisTime: uint64(startTime.Unix()),
 			}
+			return stream.Send(res)
 		case <-stateSub.Err():
 			return status.Error(codes.Aborted, "Subscriber closed, exiting goroutine")
 		case <-vs.Ctx.Done():
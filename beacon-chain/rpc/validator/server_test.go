@@ -336,3 +336,48 @@ func TestWaitForChainStart_NotStartedThenLogFired(t *testing.T) {
 	exitRoutine <- true
 	testutil.AssertLogsContain(t, hook, "Sending genesis time")
 }
+
+func TestWaitForChainStart_NotStartedThenInitialized(t *testing.T) {
+	db := dbutil.SetupDB(t)
+	defer dbutil.TeardownDB(t, db)
+
+	chainService := &mockChain.ChainService{}
+	Server := &Server{
+		Ctx: context.Background(),
+		ChainStartFetcher: &mockPOW.FaultyMockPOWChain{
+			ChainFeed: new(event.Feed),
+		},
+		BeaconDB:      db,
+		StateNotifier: chainService.StateNotifier(),
+		HeadFetcher:   chainService,
+	}
+	exitRoutine := make(chan bool)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStream := mockRPC.NewMockBeaconNodeValidator_WaitForChainStartServer(ctrl)
+	mockStream.EXPECT().Send(
+		&ethpb.ChainStartResponse{
+			Started:     true,
+			GenesisTime: 100,
+		},
+	).Return(nil)
+	go func(tt *testing.T) {
+		if err := Server.WaitForChainStart(&ptypes.Empty{}, mockStream); err != nil {
+			tt.Errorf("Could not call RPC method: %v", err)
+		}
+		<-exitRoutine
+	}(t)
+
+	// The chain started event was missed, so the validator is notified once the chain is initialized.
+	for sent := 0; sent == 0; {
+		sent = Server.StateNotifier.StateFeed().Send(&feed.Event{
+			Type: statefeed.Initialized,
+			Data: &statefeed.InitializedData{
+				StartTime:             time.Unix(100, 0),
+				GenesisValidatorsRoot: []byte{'a'},
+			},
+		})
+	}
+
+	exitRoutine <- true
+}
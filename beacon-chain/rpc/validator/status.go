@@ -18,7 +18,9 @@ import (
 
 var errPubkeyDoesNotExist = errors.New("pubkey does not exist")
 
-// ValidatorStatus returns the validator status of the current epoch.
+// ValidatorStatus returns the validator status of the current epoch. For validators in the
+// activation queue, the response includes their position in the queue, see GetActivationEstimate
+// for the epoch they are expected to be activated at.
 // The status response can be one of the following:
 //  DEPOSITED - validator's deposit has been recognized by Ethereum 1, not yet recognized by Ethereum 2.
 //  PENDING - validator is in Ethereum 2's activation queue.
@@ -144,6 +146,68 @@ func (vs *Server) validatorStatus(ctx context.Context, pubKey []byte, headState
 	return resp
 }
 
+// ActivationEstimate is the activation epoch of a validator, next to the epoch it is expected to
+// be activated at while it waits in the activation queue.
+type ActivationEstimate struct {
+	PublicKey []byte                `json:"public_key"`
+	Status    ethpb.ValidatorStatus `json:"status"`
+	// ActivationEpoch is the activation epoch of the validator in the head state, the far future
+	// epoch until the validator is dequeued for activation.
+	ActivationEpoch           uint64 `json:"activation_epoch"`
+	PositionInActivationQueue uint64 `json:"position_in_activation_queue"`
+	// EstimatedActivationEpoch is the activation epoch once the validator is dequeued, and else
+	// estimated from its position in the queue and the validator churn limit. It is the far future
+	// epoch if the validator is not in the queue.
+	EstimatedActivationEpoch uint64 `json:"estimated_activation_epoch"`
+}
+
+// GetActivationEstimate returns the activation epoch of the validator of the public key, and the
+// epoch it is expected to be activated at if it is still in the activation queue. The estimate is
+// kept apart from the ValidatorStatus response, so that its activation epoch is only ever set by
+// the actual activation of the validator.
+func (vs *Server) GetActivationEstimate(ctx context.Context, pubKey []byte) (*ActivationEstimate, error) {
+	headState, err := vs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Could not get head state")
+	}
+	resp := vs.validatorStatus(ctx, pubKey, headState)
+	estimate := &ActivationEstimate{
+		PublicKey:                 pubKey,
+		Status:                    resp.Status,
+		ActivationEpoch:           uint64(resp.ActivationEpoch),
+		PositionInActivationQueue: uint64(resp.PositionInActivationQueue),
+		EstimatedActivationEpoch:  uint64(resp.ActivationEpoch),
+	}
+	// If the validator has not been dequeued for activation yet, estimate its activation
+	// epoch from its position in the queue and the validator churn limit.
+	if estimate.ActivationEpoch == params.BeaconConfig().FarFutureEpoch && estimate.PositionInActivationQueue > 0 {
+		estimate.EstimatedActivationEpoch, err = estimatedActivationEpoch(headState, estimate.PositionInActivationQueue)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not estimate activation epoch: %v", err)
+		}
+	}
+	return estimate, nil
+}
+
+// estimatedActivationEpoch estimates the activation epoch of a validator at the given
+// position in the activation queue. This assumes the queue is processed at the churn
+// limit of the current epoch from now on, so it is a lower bound when the chain is
+// not finalizing or the churn limit changes.
+func estimatedActivationEpoch(beaconState *stateTrie.BeaconState, position uint64) (uint64, error) {
+	currentEpoch := helpers.CurrentEpoch(beaconState)
+	activeValidatorCount, err := helpers.ActiveValidatorCount(beaconState, currentEpoch)
+	if err != nil {
+		return 0, err
+	}
+	churnLimit, err := helpers.ValidatorChurnLimit(activeValidatorCount)
+	if err != nil {
+		return 0, err
+	}
+	// Validators are dequeued churnLimit at a time, starting with the current epoch.
+	dequeueEpoch := currentEpoch + (position-1)/churnLimit
+	return helpers.ActivationExitEpoch(dequeueEpoch), nil
+}
+
 func (vs *Server) retrieveStatusFromState(
 	ctx context.Context,
 	pubKey []byte,
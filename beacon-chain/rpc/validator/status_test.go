@@ -710,6 +710,149 @@ func TestValidatorStatus_CorrectActivationQueue(t *testing.T) {
 	}
 }
 
+func TestValidatorStatus_EstimatedActivationEpoch(t *testing.T) {
+	db := dbutil.SetupDB(t)
+	defer dbutil.TeardownDB(t, db)
+	ctx := context.Background()
+
+	pbKey := pubKey(5)
+	if err := db.SaveValidatorIndex(ctx, pbKey, 5); err != nil {
+		t.Fatalf("Could not save validator index: %v", err)
+	}
+	block := blk.NewGenesisBlock([]byte{})
+	if err := db.SaveBlock(ctx, block); err != nil {
+		t.Fatalf("Could not save genesis block: %v", err)
+	}
+	genesisRoot, err := ssz.HashTreeRoot(block.Block)
+	if err != nil {
+		t.Fatalf("Could not get signing root %v", err)
+	}
+	currentSlot := uint64(5000)
+	currentEpoch := currentSlot / params.BeaconConfig().SlotsPerEpoch
+	validators := make([]*ethpb.Validator, 6)
+	for i := 0; i < len(validators); i++ {
+		validators[i] = &ethpb.Validator{
+			ActivationEpoch:   0,
+			PublicKey:         pubKey(uint64(i)),
+			ExitEpoch:         params.BeaconConfig().FarFutureEpoch,
+			WithdrawableEpoch: params.BeaconConfig().FarFutureEpoch,
+		}
+	}
+	// Index 4 has already been dequeued, index 5 is still waiting in the queue.
+	validators[4].ActivationEpoch = currentEpoch + 1
+	validators[5].ActivationEpoch = params.BeaconConfig().FarFutureEpoch
+	state, err := stateTrie.InitializeFromProtoUnsafe(&pbp2p.BeaconState{
+		Validators: validators,
+		Slot:       currentSlot,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, state, genesisRoot); err != nil {
+		t.Fatalf("could not save state: %v", err)
+	}
+	if err := db.SaveHeadBlockRoot(ctx, genesisRoot); err != nil {
+		t.Fatalf("Could not save genesis state: %v", err)
+	}
+
+	depositTrie, err := trieutil.NewTrie(int(params.BeaconConfig().DepositContractTreeDepth))
+	if err != nil {
+		t.Fatalf("Could not setup deposit trie: %v", err)
+	}
+	depositCache := depositcache.NewDepositCache()
+	for i := 0; i < len(validators); i++ {
+		deposit := &ethpb.Deposit{
+			Data: &ethpb.Deposit_Data{
+				PublicKey:             pubKey(uint64(i)),
+				Signature:             []byte("hi"),
+				WithdrawalCredentials: []byte("hey"),
+			},
+		}
+		depositCache.InsertDeposit(ctx, deposit, 0 /*blockNum*/, 0, depositTrie.Root())
+	}
+
+	height := time.Unix(int64(params.BeaconConfig().Eth1FollowDistance), 0).Unix()
+	p := &mockPOW.POWChain{
+		TimesByHeight: map[int]uint64{
+			0: uint64(height),
+		},
+	}
+	vs := &Server{
+		BeaconDB:          db,
+		ChainStartFetcher: p,
+		BlockFetcher:      p,
+		Eth1InfoFetcher:   p,
+		DepositFetcher:    depositCache,
+		HeadFetcher:       &mockChain.ChainService{State: state, Root: genesisRoot[:]},
+	}
+	resp, err := vs.ValidatorStatus(ctx, &ethpb.ValidatorStatusRequest{PublicKey: pbKey})
+	if err != nil {
+		t.Fatalf("Could not get validator status %v", err)
+	}
+	if resp.Status != ethpb.ValidatorStatus_PENDING {
+		t.Errorf("Wanted %v, got %v", ethpb.ValidatorStatus_PENDING, resp.Status)
+	}
+	if resp.PositionInActivationQueue != 2 {
+		t.Errorf("Expected Position in activation queue of %d but instead got %d", 2, resp.PositionInActivationQueue)
+	}
+	// The activation epoch of the status is the one of the state, never the estimate.
+	if uint64(resp.ActivationEpoch) != params.BeaconConfig().FarFutureEpoch {
+		t.Errorf("Expected activation epoch %d, received %d", params.BeaconConfig().FarFutureEpoch, resp.ActivationEpoch)
+	}
+
+	estimate, err := vs.GetActivationEstimate(ctx, pbKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if estimate.ActivationEpoch != params.BeaconConfig().FarFutureEpoch {
+		t.Errorf("Expected activation epoch %d, received %d", params.BeaconConfig().FarFutureEpoch, estimate.ActivationEpoch)
+	}
+	if estimate.PositionInActivationQueue != 2 {
+		t.Errorf("Expected Position in activation queue of %d but instead got %d", 2, estimate.PositionInActivationQueue)
+	}
+	wanted := helpers.ActivationExitEpoch(currentEpoch)
+	if estimate.EstimatedActivationEpoch != wanted {
+		t.Errorf("Expected estimated activation epoch %d, received %d", wanted, estimate.EstimatedActivationEpoch)
+	}
+}
+
+func TestEstimatedActivationEpoch_ChurnLimit(t *testing.T) {
+	currentSlot := uint64(5000)
+	currentEpoch := currentSlot / params.BeaconConfig().SlotsPerEpoch
+	validators := make([]*ethpb.Validator, 4)
+	for i := 0; i < len(validators); i++ {
+		validators[i] = &ethpb.Validator{
+			ExitEpoch: params.BeaconConfig().FarFutureEpoch,
+		}
+	}
+	state, err := stateTrie.InitializeFromProtoUnsafe(&pbp2p.BeaconState{
+		Validators: validators,
+		Slot:       currentSlot,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	churnLimit := params.BeaconConfig().MinPerEpochChurnLimit
+	tests := []struct {
+		position     uint64
+		dequeueEpoch uint64
+	}{
+		{position: 1, dequeueEpoch: currentEpoch},
+		{position: churnLimit, dequeueEpoch: currentEpoch},
+		{position: churnLimit + 1, dequeueEpoch: currentEpoch + 1},
+		{position: 3*churnLimit + 1, dequeueEpoch: currentEpoch + 3},
+	}
+	for _, tt := range tests {
+		epoch, err := estimatedActivationEpoch(state, tt.position)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if epoch != helpers.ActivationExitEpoch(tt.dequeueEpoch) {
+			t.Errorf("Position %d: wanted epoch %d, received %d", tt.position, helpers.ActivationExitEpoch(tt.dequeueEpoch), epoch)
+		}
+	}
+}
+
 func TestDepositBlockSlotAfterGenesisTime(t *testing.T) {
 	db := dbutil.SetupDB(t)
 	defer dbutil.TeardownDB(t, db)
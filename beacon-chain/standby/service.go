@@ -0,0 +1,221 @@
+// Package standby defines a service which runs the beacon node as a hot standby of a primary
+// beacon node. The standby follows the blocks streamed by the RPC server of the primary in
+// addition to the blocks received over p2p, so its head and caches are as warm as the ones of
+// the primary and validator clients can fail over to it without waiting for it to sync.
+package standby
+
+import (
+	"context"
+	"io"
+	"time"
+
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/plugin/ocgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+var log = logrus.WithField("prefix", "standby")
+
+var (
+	primaryBlocksImported = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "standby_primary_blocks_imported_total",
+		Help: "The number of blocks streamed by the primary beacon node which were processed before being received over p2p.",
+	})
+	primaryHeadLag = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "standby_primary_head_lag_slots",
+		Help: "The number of slots the head of the standby is behind the head of the primary beacon node.",
+	})
+)
+
+// Chain is the chain service the standby processes the blocks of the primary with.
+type Chain interface {
+	blockchain.BlockReceiver
+	blockchain.HeadFetcher
+}
+
+// Service streams the blocks and the chain head of a primary beacon node.
+type Service struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	primary string
+	cert    string
+	conn    *grpc.ClientConn
+	client  ethpb.BeaconChainClient
+	db      db.ReadOnlyDatabase
+	chain   Chain
+}
+
+// Config options for the standby service.
+type Config struct {
+	// Primary is the gRPC endpoint of the primary beacon node.
+	Primary string
+	// Cert is the TLS certificate of the primary beacon node, insecure when empty.
+	Cert  string
+	DB    db.ReadOnlyDatabase
+	Chain Chain
+}
+
+// NewService initializes the service from configuration options.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		ctx:     ctx,
+		cancel:  cancel,
+		primary: cfg.Primary,
+		cert:    cfg.Cert,
+		db:      cfg.DB,
+		chain:   cfg.Chain,
+	}
+}
+
+// Start dials the primary beacon node and follows its blocks and chain head.
+func (s *Service) Start() {
+	dialOpt := grpc.WithInsecure()
+	if s.cert != "" {
+		creds, err := credentials.NewClientTLSFromFile(s.cert, "")
+		if err != nil {
+			log.WithError(err).Error("Could not get valid credentials, not following the primary beacon node")
+			return
+		}
+		dialOpt = grpc.WithTransportCredentials(creds)
+	}
+	conn, err := grpc.DialContext(s.ctx, s.primary, dialOpt, grpc.WithStatsHandler(&ocgrpc.ClientHandler{}))
+	if err != nil {
+		log.WithError(err).Errorf("Could not dial primary beacon node %s", s.primary)
+		return
+	}
+	s.conn = conn
+	s.client = ethpb.NewBeaconChainClient(conn)
+	log.WithField("primary", s.primary).Info("Running as hot standby of primary beacon node")
+	go s.followBlocks()
+	go s.followChainHead()
+}
+
+// Stop the standby service and close the connection to the primary beacon node.
+func (s *Service) Stop() error {
+	s.cancel()
+	if s.conn != nil {
+		return s.conn.Close()
+	}
+	return nil
+}
+
+// Status returns an error if the primary beacon node could not be dialed.
+func (s *Service) Status() error {
+	if s.conn == nil {
+		return errors.New("no connection to the primary beacon node")
+	}
+	return nil
+}
+
+// followBlocks processes the blocks streamed by the primary, resubscribing every slot while the
+// stream is unavailable.
+func (s *Service) followBlocks() {
+	retry := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	for s.ctx.Err() == nil {
+		stream, err := s.client.StreamBlocks(s.ctx, &ptypes.Empty{})
+		if err == nil {
+			for {
+				var blk *ethpb.SignedBeaconBlock
+				blk, err = stream.Recv()
+				if err != nil {
+					break
+				}
+				if err := s.processBlock(s.ctx, blk); err != nil {
+					log.WithError(err).WithField("slot", blk.Block.Slot).Debug("Could not process block of primary beacon node")
+				}
+			}
+		}
+		if s.ctx.Err() != nil {
+			return
+		}
+		if err != io.EOF {
+			log.WithError(err).Warn("Lost blocks stream of primary beacon node, retrying")
+		}
+		select {
+		case <-time.After(retry):
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// processBlock processes a block of the primary unless it is already known, which is the case
+// when the block was received over p2p first. Blocks with an unknown parent are left to the
+// regular sync, which fetches the missing ancestors from peers.
+func (s *Service) processBlock(ctx context.Context, blk *ethpb.SignedBeaconBlock) error {
+	if blk == nil || blk.Block == nil {
+		return errors.New("nil block")
+	}
+	root, err := ssz.HashTreeRoot(blk.Block)
+	if err != nil {
+		return errors.Wrap(err, "could not hash block")
+	}
+	if s.db.HasBlock(ctx, root) {
+		return nil
+	}
+	if !s.db.HasBlock(ctx, bytesutil.ToBytes32(blk.Block.ParentRoot)) {
+		return errors.Errorf("unknown parent %#x", bytesutil.Trunc(blk.Block.ParentRoot))
+	}
+	// The primary already gossiped the block.
+	if err := s.chain.ReceiveBlockNoPubsub(ctx, blk); err != nil {
+		return err
+	}
+	primaryBlocksImported.Inc()
+	return nil
+}
+
+// followChainHead tracks how many slots the head of the standby is behind the head of the
+// primary, resubscribing every slot while the stream is unavailable.
+func (s *Service) followChainHead() {
+	retry := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	for s.ctx.Err() == nil {
+		stream, err := s.client.StreamChainHead(s.ctx, &ptypes.Empty{})
+		if err == nil {
+			for {
+				var head *ethpb.ChainHead
+				head, err = stream.Recv()
+				if err != nil {
+					break
+				}
+				s.updateHeadLag(head)
+			}
+		}
+		if s.ctx.Err() != nil {
+			return
+		}
+		if err != io.EOF {
+			log.WithError(err).Warn("Lost chain head stream of primary beacon node, retrying")
+		}
+		select {
+		case <-time.After(retry):
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *Service) updateHeadLag(head *ethpb.ChainHead) {
+	var lag uint64
+	if headSlot := s.chain.HeadSlot(); head.HeadSlot > headSlot {
+		lag = head.HeadSlot - headSlot
+	}
+	primaryHeadLag.Set(float64(lag))
+	if lag > params.BeaconConfig().SlotsPerEpoch {
+		log.WithFields(logrus.Fields{
+			"primaryHeadSlot": head.HeadSlot,
+			"lag":             lag,
+		}).Warn("Standby head is behind the primary beacon node")
+	}
+}
@@ -0,0 +1,55 @@
+package standby
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+)
+
+type recordingChain struct {
+	mock.ChainService
+	received []*ethpb.SignedBeaconBlock
+}
+
+func (c *recordingChain) ReceiveBlockNoPubsub(_ context.Context, blk *ethpb.SignedBeaconBlock) error {
+	c.received = append(c.received, blk)
+	return nil
+}
+
+func TestProcessBlock(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	defer dbtest.TeardownDB(t, beaconDB)
+	chain := &recordingChain{}
+	s := NewService(ctx, &Config{DB: beaconDB, Chain: chain})
+
+	parent := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 1}}
+	if err := beaconDB.SaveBlock(ctx, parent); err != nil {
+		t.Fatal(err)
+	}
+	parentRoot, err := ssz.HashTreeRoot(parent.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A block already received over p2p is skipped.
+	if err := s.processBlock(ctx, parent); err != nil {
+		t.Fatal(err)
+	}
+	// A block with an unknown parent is left to the regular sync.
+	orphan := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 2, ParentRoot: []byte{'a'}}}
+	if err := s.processBlock(ctx, orphan); err == nil {
+		t.Error("Expected a block with an unknown parent to be rejected")
+	}
+	child := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 2, ParentRoot: parentRoot[:]}}
+	if err := s.processBlock(ctx, child); err != nil {
+		t.Fatal(err)
+	}
+	if len(chain.received) != 1 || chain.received[0] != child {
+		t.Errorf("Wanted only the new block to be processed, received %v", chain.received)
+	}
+}
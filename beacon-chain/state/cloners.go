@@ -265,3 +265,20 @@ func CopySignedVoluntaryExit(exit *ethpb.SignedVoluntaryExit) *ethpb.SignedVolun
 		Signature: bytesutil.SafeCopyBytes(exit.Signature),
 	}
 }
+
+// CopyValidator copies the provided validator.
+func CopyValidator(val *ethpb.Validator) *ethpb.Validator {
+	if val == nil {
+		return nil
+	}
+	return &ethpb.Validator{
+		PublicKey:                  bytesutil.SafeCopyBytes(val.PublicKey),
+		WithdrawalCredentials:      bytesutil.SafeCopyBytes(val.WithdrawalCredentials),
+		EffectiveBalance:           val.EffectiveBalance,
+		Slashed:                    val.Slashed,
+		ActivationEligibilityEpoch: val.ActivationEligibilityEpoch,
+		ActivationEpoch:            val.ActivationEpoch,
+		ExitEpoch:                  val.ExitEpoch,
+		WithdrawableEpoch:          val.WithdrawableEpoch,
+	}
+}
@@ -230,6 +230,26 @@ func (b *BeaconState) BlockRootAtIndex(idx uint64) ([]byte, error) {
 	return root, nil
 }
 
+// BlockRootAtIndex32 retrieves a specific block root based on an
+// input index value, as a fixed size array which is not allocated on
+// the heap and does not alias the state.
+func (b *BeaconState) BlockRootAtIndex32(idx uint64) ([32]byte, error) {
+	if !b.HasInnerState() {
+		return [32]byte{}, ErrNilInnerState
+	}
+	if b.state.BlockRoots == nil {
+		return [32]byte{}, nil
+	}
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if len(b.state.BlockRoots) <= int(idx) {
+		return [32]byte{}, fmt.Errorf("index %d out of range", idx)
+	}
+	return bytesutil.ToBytes32(b.state.BlockRoots[idx]), nil
+}
+
 // StateRoots kept track of in the beacon state.
 func (b *BeaconState) StateRoots() [][]byte {
 	if !b.HasInnerState() {
@@ -437,18 +457,23 @@ func (b *BeaconState) validatorIndexMap() map[[48]byte]uint64 {
 }
 
 // PubkeyAtIndex returns the pubkey at the given
-// validator index.
-func (b *BeaconState) PubkeyAtIndex(idx uint64) [48]byte {
+// validator index. A pubkey which is not 48 bytes long
+// is returned as an error rather than truncated or padded.
+func (b *BeaconState) PubkeyAtIndex(idx uint64) ([48]byte, error) {
 	if !b.HasInnerState() {
-		return [48]byte{}
+		return [48]byte{}, ErrNilInnerState
 	}
 	if idx >= uint64(len(b.state.Validators)) {
-		return [48]byte{}
+		return [48]byte{}, fmt.Errorf("index %d out of range", idx)
 	}
 	b.lock.RLock()
 	defer b.lock.RUnlock()
 
-	return bytesutil.ToBytes48(b.state.Validators[idx].PublicKey)
+	pubkey, err := bytesutil.ToBytes48Checked(b.state.Validators[idx].PublicKey)
+	if err != nil {
+		return [48]byte{}, fmt.Errorf("invalid pubkey of validator %d: %v", idx, err)
+	}
+	return pubkey, nil
 }
 
 // NumValidators returns the size of the validator registry.
@@ -571,6 +596,26 @@ func (b *BeaconState) RandaoMixAtIndex(idx uint64) ([]byte, error) {
 	return root, nil
 }
 
+// RandaoMixAtIndex32 retrieves a specific randao mix based on an
+// input index value, as a fixed size array which is not allocated on
+// the heap and does not alias the state.
+func (b *BeaconState) RandaoMixAtIndex32(idx uint64) ([32]byte, error) {
+	if !b.HasInnerState() {
+		return [32]byte{}, ErrNilInnerState
+	}
+	if b.state.RandaoMixes == nil {
+		return [32]byte{}, nil
+	}
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if len(b.state.RandaoMixes) <= int(idx) {
+		return [32]byte{}, fmt.Errorf("index %d out of range", idx)
+	}
+	return bytesutil.ToBytes32(b.state.RandaoMixes[idx]), nil
+}
+
 // RandaoMixesLength returns the length of the randao mixes slice.
 func (b *BeaconState) RandaoMixesLength() int {
 	if !b.HasInnerState() {
@@ -0,0 +1,36 @@
+package state_test
+
+import (
+	"bytes"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+func TestBeaconState_PubkeyAtIndex(t *testing.T) {
+	pubkey := bytes.Repeat([]byte{1}, 48)
+	st, err := stateTrie.InitializeFromProtoUnsafe(&pb.BeaconState{
+		Validators: []*ethpb.Validator{
+			{PublicKey: pubkey},
+			{PublicKey: pubkey[:47]},
+			{PublicKey: append(pubkey, 1)},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := st.PubkeyAtIndex(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got[:], pubkey) {
+		t.Errorf("Wanted pubkey %#x, received %#x", pubkey, got)
+	}
+	for _, idx := range []uint64{1, 2, 3} {
+		if _, err := st.PubkeyAtIndex(idx); err == nil {
+			t.Errorf("Expected error for pubkey of validator %d", idx)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package state
+
+import (
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/shared/event"
+)
+
+// validatorStatus holds the fields of a validator which define its status,
+// used to detect status changes when validators are modified in place.
+type validatorStatus struct {
+	activationEligibilityEpoch uint64
+	activationEpoch            uint64
+	exitEpoch                  uint64
+	withdrawableEpoch          uint64
+	slashed                    bool
+}
+
+func statusOf(val *ethpb.Validator) validatorStatus {
+	if val == nil {
+		return validatorStatus{}
+	}
+	return validatorStatus{
+		activationEligibilityEpoch: val.ActivationEligibilityEpoch,
+		activationEpoch:            val.ActivationEpoch,
+		exitEpoch:                  val.ExitEpoch,
+		withdrawableEpoch:          val.WithdrawableEpoch,
+		slashed:                    val.Slashed,
+	}
+}
+
+// SetMutationFeed sets an optional feed on which validator balance and status
+// changes made through the state setters are sent. Sending on the feed blocks
+// until all subscribers receive the event, so subscribers should use a buffered
+// subscription. Copies of the state do not inherit the feed, so speculative
+// states derived from this one do not emit events. A nil feed disables events.
+func (b *BeaconState) SetMutationFeed(f *event.Feed) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.mutationFeed = f
+}
+
+// MutationFeed returns the feed set with SetMutationFeed, if any.
+func (b *BeaconState) MutationFeed() *event.Feed {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.mutationFeed
+}
+
+// sendMutationEvents sends the events on the mutation feed. It must be called
+// without holding the state lock, as sending blocks on subscribers.
+func sendMutationEvents(f *event.Feed, events []*feed.Event) {
+	if f == nil {
+		return
+	}
+	for _, e := range events {
+		f.Send(e)
+	}
+}
+
+func balanceChangedEvent(slot uint64, idx uint64, prev uint64, bal uint64) *feed.Event {
+	return &feed.Event{
+		Type: statefeed.ValidatorBalanceChanged,
+		Data: &statefeed.ValidatorBalanceChangedData{
+			Slot:            slot,
+			ValidatorIndex:  idx,
+			PreviousBalance: prev,
+			Balance:         bal,
+		},
+	}
+}
+
+// validatorChangedEvents returns the events for a validator whose status
+// changed from prev to the current validator record.
+func validatorChangedEvents(slot uint64, idx uint64, prev validatorStatus, val *ethpb.Validator) []*feed.Event {
+	cur := statusOf(val)
+	if cur == prev {
+		return nil
+	}
+	data := &statefeed.ValidatorChangedData{
+		Slot:           slot,
+		ValidatorIndex: idx,
+		Validator:      CopyValidator(val),
+	}
+	events := make([]*feed.Event, 0, 2)
+	if cur.exitEpoch != prev.exitEpoch {
+		events = append(events, &feed.Event{Type: statefeed.ValidatorExitInitiated, Data: data})
+	}
+	prev.exitEpoch = cur.exitEpoch
+	if cur != prev {
+		events = append(events, &feed.Event{Type: statefeed.ValidatorStatusChanged, Data: data})
+	}
+	return events
+}
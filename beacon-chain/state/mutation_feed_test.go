@@ -0,0 +1,131 @@
+package state_test
+
+import (
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/event"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func mutationFeedState(t *testing.T) *stateTrie.BeaconState {
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	vals := make([]*ethpb.Validator, 2)
+	for i := range vals {
+		vals[i] = &ethpb.Validator{
+			PublicKey:         []byte{byte(i)},
+			ExitEpoch:         farFuture,
+			WithdrawableEpoch: farFuture,
+		}
+	}
+	st, err := stateTrie.InitializeFromProto(&pb.BeaconState{
+		Slot:       5,
+		Validators: vals,
+		Balances:   []uint64{10, 20},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return st
+}
+
+func subscribe(t *testing.T, st *stateTrie.BeaconState) (chan *feed.Event, event.Subscription) {
+	f := new(event.Feed)
+	ch := make(chan *feed.Event, 10)
+	sub := f.Subscribe(ch)
+	st.SetMutationFeed(f)
+	return ch, sub
+}
+
+func TestMutationFeed_BalanceChanges(t *testing.T) {
+	st := mutationFeedState(t)
+	ch, sub := subscribe(t, st)
+	defer sub.Unsubscribe()
+
+	if err := st.UpdateBalancesAtIndex(1, 25); err != nil {
+		t.Fatal(err)
+	}
+	// Unchanged balances do not emit events.
+	if err := st.SetBalances([]uint64{10, 25}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.SetBalances([]uint64{7, 25}); err != nil {
+		t.Fatal(err)
+	}
+	if len(ch) != 2 {
+		t.Fatalf("Wanted 2 events, received %d", len(ch))
+	}
+	want := []*statefeed.ValidatorBalanceChangedData{
+		{Slot: 5, ValidatorIndex: 1, PreviousBalance: 20, Balance: 25},
+		{Slot: 5, ValidatorIndex: 0, PreviousBalance: 10, Balance: 7},
+	}
+	for _, w := range want {
+		e := <-ch
+		if e.Type != statefeed.ValidatorBalanceChanged {
+			t.Fatalf("Wanted event type %d, received %d", statefeed.ValidatorBalanceChanged, e.Type)
+		}
+		if got := e.Data.(*statefeed.ValidatorBalanceChangedData); *got != *w {
+			t.Errorf("Wanted %+v, received %+v", w, got)
+		}
+	}
+}
+
+func TestMutationFeed_ValidatorChanges(t *testing.T) {
+	st := mutationFeedState(t)
+	ch, sub := subscribe(t, st)
+	defer sub.Unsubscribe()
+
+	val, err := st.ValidatorAtIndex(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	val.ExitEpoch = 10
+	val.WithdrawableEpoch = 20
+	if err := st.UpdateValidatorAtIndex(0, val); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.ApplyToEveryValidator(func(idx int, val *ethpb.Validator) error {
+		if idx == 1 {
+			val.Slashed = true
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	wantTypes := []feed.EventType{statefeed.ValidatorExitInitiated, statefeed.ValidatorStatusChanged, statefeed.ValidatorStatusChanged}
+	wantIndices := []uint64{0, 0, 1}
+	if len(ch) != len(wantTypes) {
+		t.Fatalf("Wanted %d events, received %d", len(wantTypes), len(ch))
+	}
+	for i := range wantTypes {
+		e := <-ch
+		if e.Type != wantTypes[i] {
+			t.Errorf("Event %d: wanted type %d, received %d", i, wantTypes[i], e.Type)
+		}
+		if idx := e.Data.(*statefeed.ValidatorChangedData).ValidatorIndex; idx != wantIndices[i] {
+			t.Errorf("Event %d: wanted validator index %d, received %d", i, wantIndices[i], idx)
+		}
+	}
+}
+
+func TestMutationFeed_NotInheritedByCopy(t *testing.T) {
+	st := mutationFeedState(t)
+	ch, sub := subscribe(t, st)
+	defer sub.Unsubscribe()
+
+	cp := st.Copy()
+	if cp.MutationFeed() != nil {
+		t.Error("Expected copied state to not have a mutation feed")
+	}
+	if err := cp.UpdateBalancesAtIndex(0, 100); err != nil {
+		t.Fatal(err)
+	}
+	if len(ch) != 0 {
+		t.Errorf("Expected no events from copied state, received %d", len(ch))
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
 )
@@ -303,20 +304,28 @@ func (b *BeaconState) ApplyToEveryValidator(f func(idx int, val *ethpb.Validator
 		ref.refs--
 		b.sharedFieldReferences[validators] = &reference{refs: 1}
 	}
+	mutationFeed := b.mutationFeed
+	slot := b.state.Slot
 	b.lock.RUnlock()
 
+	var events []*feed.Event
 	for i, val := range v {
+		prev := statusOf(val)
 		err := f(i, val)
 		if err != nil {
 			return err
 		}
+		if mutationFeed != nil {
+			events = append(events, validatorChangedEvents(slot, uint64(i), prev, val)...)
+		}
 	}
 
 	b.lock.Lock()
-	defer b.lock.Unlock()
-
 	b.state.Validators = v
 	b.markFieldAsDirty(validators)
+	b.lock.Unlock()
+
+	sendMutationEvents(mutationFeed, events)
 	return nil
 }
 
@@ -342,11 +351,17 @@ func (b *BeaconState) UpdateValidatorAtIndex(idx uint64, val *ethpb.Validator) e
 	b.lock.RUnlock()
 
 	b.lock.Lock()
-	defer b.lock.Unlock()
-
+	prev := statusOf(v[idx])
 	v[idx] = val
 	b.state.Validators = v
 	b.markFieldAsDirty(validators)
+	mutationFeed := b.mutationFeed
+	slot := b.state.Slot
+	b.lock.Unlock()
+
+	if mutationFeed != nil {
+		sendMutationEvents(mutationFeed, validatorChangedEvents(slot, idx, prev, val))
+	}
 	return nil
 }
 
@@ -370,13 +385,24 @@ func (b *BeaconState) SetBalances(val []uint64) error {
 		return ErrNilInnerState
 	}
 	b.lock.Lock()
-	defer b.lock.Unlock()
+	var events []*feed.Event
+	if b.mutationFeed != nil {
+		for i := 0; i < len(val) && i < len(b.state.Balances); i++ {
+			if val[i] != b.state.Balances[i] {
+				events = append(events, balanceChangedEvent(b.state.Slot, uint64(i), b.state.Balances[i], val[i]))
+			}
+		}
+	}
+	mutationFeed := b.mutationFeed
 
 	b.sharedFieldReferences[balances].refs--
 	b.sharedFieldReferences[balances] = &reference{refs: 1}
 
 	b.state.Balances = val
 	b.markFieldAsDirty(balances)
+	b.lock.Unlock()
+
+	sendMutationEvents(mutationFeed, events)
 	return nil
 }
 
@@ -400,11 +426,17 @@ func (b *BeaconState) UpdateBalancesAtIndex(idx uint64, val uint64) error {
 	b.lock.RUnlock()
 
 	b.lock.Lock()
-	defer b.lock.Unlock()
-
+	prev := bals[idx]
 	bals[idx] = val
 	b.state.Balances = bals
 	b.markFieldAsDirty(balances)
+	mutationFeed := b.mutationFeed
+	slot := b.state.Slot
+	b.lock.Unlock()
+
+	if mutationFeed != nil && prev != val {
+		sendMutationEvents(mutationFeed, []*feed.Event{balanceChangedEvent(slot, idx, prev, val)})
+	}
 	return nil
 }
 
@@ -0,0 +1,198 @@
+package stategen
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// MigrateToCold saves the states of the archived points between the previous finalized slot and
+// the finalized block as cold states, before the hot states of these slots are deleted. The
+// states are taken from the canonical chain ending at the finalized block root.
+func (s *State) MigrateToCold(ctx context.Context, prevFinalizedSlot uint64, finalizedRoot [32]byte) error {
+	ctx, span := trace.StartSpan(ctx, "stateGen.MigrateToCold")
+	defer span.End()
+
+	finalizedBlock, err := s.beaconDB.Block(ctx, finalizedRoot)
+	if err != nil {
+		return err
+	}
+	if finalizedBlock == nil || finalizedBlock.Block == nil {
+		return errUnknownBlock
+	}
+	finalizedSlot := finalizedBlock.Block.Slot
+
+	// The first archived point after the previous finalized slot.
+	archivedSlot := (prevFinalizedSlot/s.slotsPerArchivedPoint + 1) * s.slotsPerArchivedPoint
+	if archivedSlot > finalizedSlot {
+		return nil
+	}
+	canonicalBlocks, err := s.LoadBlocks(ctx, prevFinalizedSlot, finalizedSlot, finalizedRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not load finalized blocks")
+	}
+
+	for ; archivedSlot <= finalizedSlot; archivedSlot += s.slotsPerArchivedPoint {
+		// The blocks are in slot-descending order, the block of the archived point is the first
+		// one at or before its slot.
+		var blockRoot [32]byte
+		var blockSlot uint64
+		found := false
+		for _, b := range canonicalBlocks {
+			if b.Block.Slot <= archivedSlot {
+				blockRoot, err = ssz.HashTreeRoot(b.Block)
+				if err != nil {
+					return err
+				}
+				blockSlot = b.Block.Slot
+				found = true
+				break
+			}
+		}
+		if !found {
+			blockRoot, blockSlot, err = s.lastSavedBlock(ctx, archivedSlot)
+			if err != nil {
+				return errors.Wrap(err, "could not get block of archived point")
+			}
+		}
+
+		archivedState, err := s.stateAtSlot(ctx, blockRoot, blockSlot, archivedSlot)
+		if err != nil {
+			return errors.Wrapf(err, "could not generate state of archived point at slot %d", archivedSlot)
+		}
+		if err := s.saveColdState(ctx, blockRoot, archivedState); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StateBySlot regenerates the canonical state of a finalized slot, by replaying the canonical
+// blocks from the state of the closest archived point at or before the slot. Archived points
+// saved with a different interval are handled, as the states are looked up by their own slot.
+func (s *State) StateBySlot(ctx context.Context, slot uint64) (*state.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "stateGen.StateBySlot")
+	defer span.End()
+
+	archivedState, err := s.archivedStateBeforeSlot(ctx, slot)
+	if err != nil {
+		return nil, err
+	}
+	if archivedState.Slot() == slot {
+		return archivedState, nil
+	}
+
+	rs, err := s.beaconDB.BlockRoots(ctx, filters.NewFilter().SetStartSlot(archivedState.Slot()+1).SetEndSlot(slot))
+	if err != nil {
+		return nil, err
+	}
+	var blks []*ethpb.SignedBeaconBlock
+	for i := len(rs) - 1; i >= 0; i-- {
+		if !s.beaconDB.IsCanonical(ctx, rs[i]) {
+			continue
+		}
+		b, err := s.beaconDB.Block(ctx, rs[i])
+		if err != nil {
+			return nil, err
+		}
+		if b == nil || b.Block == nil {
+			return nil, errUnknownBlock
+		}
+		blks, err = s.LoadBlocks(ctx, archivedState.Slot()+1, b.Block.Slot, rs[i])
+		if err != nil {
+			return nil, errors.Wrap(err, "could not load blocks to replay")
+		}
+		break
+	}
+	return s.ReplayBlocks(ctx, archivedState, blks, slot)
+}
+
+// This saves the state of an archived point along with the root of its block, the slot of the
+// state has to be a multiple of the slots per archived point.
+func (s *State) saveColdState(ctx context.Context, blockRoot [32]byte, st *state.BeaconState) error {
+	ctx, span := trace.StartSpan(ctx, "stateGen.saveColdState")
+	defer span.End()
+
+	if st.Slot()%s.slotsPerArchivedPoint != 0 {
+		return errSlotNonArchivedPoint
+	}
+	archivedPointIndex := st.Slot() / s.slotsPerArchivedPoint
+	if err := s.beaconDB.SaveArchivedPointState(ctx, st, archivedPointIndex); err != nil {
+		return err
+	}
+	if err := s.beaconDB.SaveArchivedPointRoot(ctx, blockRoot, archivedPointIndex); err != nil {
+		return err
+	}
+	s.lastArchivedSlot = st.Slot()
+
+	log.WithFields(logrus.Fields{
+		"slot":      st.Slot(),
+		"index":     archivedPointIndex,
+		"blockRoot": fmt.Sprintf("%#x", bytesutil.Trunc(blockRoot[:])),
+	}).Debug("Saved cold state")
+	return nil
+}
+
+// This returns the state at the input slot of the chain ending at the input block, using the
+// saved state of the block if there is one, and replaying the blocks from the last saved state
+// otherwise.
+func (s *State) stateAtSlot(ctx context.Context, blockRoot [32]byte, blockSlot uint64, slot uint64) (*state.BeaconState, error) {
+	if s.beaconDB.HasState(ctx, blockRoot) {
+		st, err := s.beaconDB.State(ctx, blockRoot)
+		if err != nil {
+			return nil, err
+		}
+		return s.ReplayBlocks(ctx, st.Copy(), nil, slot)
+	}
+
+	savedRoot, err := s.lastSavedState(ctx, blockSlot)
+	if err != nil {
+		return nil, err
+	}
+	savedState, err := s.beaconDB.State(ctx, savedRoot)
+	if err != nil {
+		return nil, err
+	}
+	if savedState == nil || savedState.Slot() >= blockSlot {
+		return nil, errUnknownState
+	}
+	blks, err := s.LoadBlocks(ctx, savedState.Slot()+1, blockSlot, blockRoot)
+	if err != nil {
+		return nil, err
+	}
+	return s.ReplayBlocks(ctx, savedState, blks, slot)
+}
+
+// This returns the state of the latest archived point at or before the input slot, or the
+// genesis state if there is none.
+func (s *State) archivedStateBeforeSlot(ctx context.Context, slot uint64) (*state.BeaconState, error) {
+	for index := slot / s.slotsPerArchivedPoint; index > 0; index-- {
+		if !s.beaconDB.HasArchivedPoint(ctx, index) {
+			continue
+		}
+		st, err := s.beaconDB.ArchivedPointState(ctx, index)
+		if err != nil {
+			return nil, err
+		}
+		// Archived points saved with a larger interval may be after the slot.
+		if st != nil && st.Slot() <= slot {
+			return st, nil
+		}
+	}
+	st, err := s.beaconDB.GenesisState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if st == nil {
+		return nil, errUnknownArchivedState
+	}
+	return st, nil
+}
@@ -0,0 +1,180 @@
+package stategen
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestSaveColdState_NonArchivedPoint(t *testing.T) {
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+	s := &State{
+		beaconDB:              db,
+		slotsPerArchivedPoint: 3,
+	}
+
+	st, err := stateTrie.InitializeFromProtoUnsafe(&pb.BeaconState{Slot: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.saveColdState(context.Background(), [32]byte{}, st); err != errSlotNonArchivedPoint {
+		t.Errorf("Wanted error %v, received %v", errSlotNonArchivedPoint, err)
+	}
+}
+
+func TestSaveColdState_CanSave(t *testing.T) {
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+	ctx := context.Background()
+	s := &State{
+		beaconDB:              db,
+		slotsPerArchivedPoint: 3,
+	}
+
+	st, err := stateTrie.InitializeFromProtoUnsafe(&pb.BeaconState{Slot: 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := [32]byte{'A'}
+	if err := s.saveColdState(ctx, r, st); err != nil {
+		t.Fatal(err)
+	}
+	if !db.HasArchivedPoint(ctx, 2) {
+		t.Error("Archived point was not saved")
+	}
+	if db.ArchivedPointRoot(ctx, 2) != r {
+		t.Error("Did not get wanted archived point root")
+	}
+	if s.lastArchivedSlot != 6 {
+		t.Errorf("Wanted last archived slot 6, received %d", s.lastArchivedSlot)
+	}
+}
+
+func TestMigrateToCold_SavesArchivedPoints(t *testing.T) {
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+	ctx := context.Background()
+	s := &State{
+		beaconDB:              db,
+		slotsPerArchivedPoint: 2,
+	}
+
+	// A chain of blocks from slot 1 to 5, with the hot state of every block.
+	var roots [][32]byte
+	parentRoot := [32]byte{'G'}
+	for slot := uint64(1); slot <= 5; slot++ {
+		b := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: slot, ParentRoot: parentRoot[:]}}
+		if err := db.SaveBlock(ctx, b); err != nil {
+			t.Fatal(err)
+		}
+		r, err := ssz.HashTreeRoot(b.Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		st, err := stateTrie.InitializeFromProtoUnsafe(&pb.BeaconState{Slot: slot})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := db.SaveState(ctx, st, r); err != nil {
+			t.Fatal(err)
+		}
+		roots = append(roots, r)
+		parentRoot = r
+	}
+
+	if err := s.MigrateToCold(ctx, 0, roots[4]); err != nil {
+		t.Fatal(err)
+	}
+	for index, wanted := range map[uint64][32]byte{1: roots[1], 2: roots[3]} {
+		if db.ArchivedPointRoot(ctx, index) != wanted {
+			t.Errorf("Did not get wanted root of archived point %d", index)
+		}
+		st, err := db.ArchivedPointState(ctx, index)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if st == nil || st.Slot() != index*2 {
+			t.Errorf("Did not get wanted state of archived point %d", index)
+		}
+	}
+	if db.HasArchivedPoint(ctx, 3) {
+		t.Error("Archived point after the finalized block should not be saved")
+	}
+	if s.lastArchivedSlot != 4 {
+		t.Errorf("Wanted last archived slot 4, received %d", s.lastArchivedSlot)
+	}
+}
+
+func TestStateBySlot_ArbitraryInterval(t *testing.T) {
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+	ctx := context.Background()
+
+	genesisState, _ := testutil.DeterministicGenesisState(t, 32)
+	genesisBlock := blocks.NewGenesisBlock([]byte{})
+	bodyRoot, err := ssz.HashTreeRoot(genesisBlock.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesisState.SetLatestBlockHeader(&ethpb.BeaconBlockHeader{
+		Slot:       genesisBlock.Block.Slot,
+		ParentRoot: genesisBlock.Block.ParentRoot,
+		StateRoot:  params.BeaconConfig().ZeroHash[:],
+		BodyRoot:   bodyRoot[:],
+	})
+	genesisRoot, err := ssz.HashTreeRoot(genesisBlock.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveBlock(ctx, genesisBlock); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveGenesisBlockRoot(ctx, genesisRoot); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, genesisState, genesisRoot); err != nil {
+		t.Fatal(err)
+	}
+
+	// Archived points every 3 slots, which is not aligned with epochs.
+	s := &State{
+		beaconDB:              db,
+		slotsPerArchivedPoint: 3,
+	}
+	archivedState, err := s.ReplayBlocks(ctx, genesisState.Copy(), nil, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.saveColdState(ctx, genesisRoot, archivedState); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, slot := range []uint64{2, 3, 5} {
+		st, err := s.StateBySlot(ctx, slot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if st.Slot() != slot {
+			t.Errorf("Wanted state at slot %d, received %d", slot, st.Slot())
+		}
+	}
+
+	// The archived points saved with the previous interval are still used.
+	s.slotsPerArchivedPoint = 2
+	st, err := s.StateBySlot(ctx, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Slot() != 5 {
+		t.Errorf("Wanted state at slot 5, received %d", st.Slot())
+	}
+}
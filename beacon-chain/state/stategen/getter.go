@@ -0,0 +1,28 @@
+package stategen
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"go.opencensus.io/trace"
+)
+
+// StateByRoot returns the post-state of the block root, from the saved state of the block if
+// there is one, and by replaying the blocks from the last saved state otherwise. It returns nil
+// if the block is unknown.
+func (s *State) StateByRoot(ctx context.Context, blockRoot [32]byte) (*state.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "stateGen.StateByRoot")
+	defer span.End()
+
+	if s.beaconDB.HasState(ctx, blockRoot) {
+		return s.beaconDB.State(ctx, blockRoot)
+	}
+	b, err := s.beaconDB.Block(ctx, blockRoot)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil || b.Block == nil {
+		return nil, nil
+	}
+	return s.stateAtSlot(ctx, blockRoot, b.Block.Slot, b.Block.Slot)
+}
@@ -0,0 +1,77 @@
+package stategen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestStateByRoot_ReplaysUnsavedState(t *testing.T) {
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+	ctx := context.Background()
+
+	beaconState, privKeys := testutil.DeterministicGenesisState(t, 32)
+	stateRoot, err := beaconState.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesis := blocks.NewGenesisBlock(stateRoot[:])
+	if err := db.SaveBlock(ctx, genesis); err != nil {
+		t.Fatal(err)
+	}
+	genesisRoot, err := ssz.HashTreeRoot(genesis.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveGenesisBlockRoot(ctx, genesisRoot); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, beaconState, genesisRoot); err != nil {
+		t.Fatal(err)
+	}
+
+	// The state of the block at slot 1 is not saved.
+	blk, err := testutil.GenerateFullBlock(beaconState, privKeys, nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveBlock(ctx, blk); err != nil {
+		t.Fatal(err)
+	}
+	root, err := ssz.HashTreeRoot(blk.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service := New(db)
+	st, err := service.StateByRoot(ctx, genesisRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st == nil || st.Slot() != 0 {
+		t.Errorf("Wanted the saved genesis state, received %v", st)
+	}
+	st, err = service.StateByRoot(ctx, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st == nil || st.Slot() != 1 {
+		t.Fatalf("Wanted the state of slot 1 to be replayed, received %v", st)
+	}
+	if st.LatestBlockHeader().Slot != 1 {
+		t.Errorf("Wanted the block of slot 1 to be processed, latest block header is at slot %d", st.LatestBlockHeader().Slot)
+	}
+
+	st, err = service.StateByRoot(ctx, [32]byte{'A'})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st != nil {
+		t.Errorf("Wanted no state for an unknown block, received %v", st)
+	}
+}
@@ -126,9 +126,9 @@ func executeStateTransitionStateGen(
 	}
 
 	// Execute per block transition.
-	// Given this is for state gen, a node only cares about the post state without proposer
-	// and randao signature verifications.
-	state, err = transition.ProcessBlockForStateRoot(ctx, state, signed)
+	// Given this is for state gen, the blocks were already verified and a node only cares about
+	// the post state, so the signatures are skipped but the roots are still validated.
+	state, err = transition.ExecuteStateTransitionNoVerifySig(ctx, state, signed)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process block")
 	}
@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/shared/params"
 )
 
 // State represents a management object that handles the internal
@@ -11,6 +12,7 @@ import (
 type State struct {
 	beaconDB                db.NoHeadAccessDatabase
 	lastArchivedSlot        uint64
+	slotsPerArchivedPoint   uint64
 	epochBoundarySlotToRoot map[uint64][32]byte
 	epochBoundaryLock       sync.RWMutex
 }
@@ -20,5 +22,6 @@ func New(db db.NoHeadAccessDatabase) *State {
 	return &State{
 		beaconDB:                db,
 		epochBoundarySlotToRoot: make(map[uint64][32]byte),
+		slotsPerArchivedPoint:   params.BeaconConfig().SlotsPerArchivedPoint,
 	}
 }
@@ -0,0 +1,146 @@
+package stateutil
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/htrutils"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+const (
+	blockFieldCount     = 4
+	blockBodyFieldIndex = 3
+	bodyFieldCount      = 8
+	bodyAttestationsIdx = 5
+)
+
+// BlockBodyFieldRoots computes the hash tree roots of each field of a
+// BeaconBlockBody, in the order in which they are merkleized into the body
+// root according to the eth2 Simple Serialize specification.
+func BlockBodyFieldRoots(body *ethpb.BeaconBlockBody) ([][32]byte, error) {
+	if body == nil {
+		return nil, errors.New("nil block body")
+	}
+	fieldRoots := make([][32]byte, bodyFieldCount)
+
+	// The randao reveal is a 96 byte vector, packed into 3 chunks.
+	randao := make([][32]byte, 3)
+	for i := range randao {
+		if len(body.RandaoReveal) > 32*i {
+			copy(randao[i][:], body.RandaoReveal[32*i:])
+		}
+	}
+	randaoRoot, err := htrutils.Merkleize(randao, uint64(len(randao)))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not merkleize randao reveal")
+	}
+	fieldRoots[0] = randaoRoot
+
+	eth1Root, err := Eth1Root(body.Eth1Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute eth1data merkleization")
+	}
+	fieldRoots[1] = eth1Root
+	fieldRoots[2] = bytesutil.ToBytes32(body.Graffiti)
+
+	lists := []struct {
+		length int
+		limit  uint64
+		item   func(i int) interface{}
+	}{
+		{len(body.ProposerSlashings), params.BeaconConfig().MaxProposerSlashings, func(i int) interface{} { return body.ProposerSlashings[i] }},
+		{len(body.AttesterSlashings), params.BeaconConfig().MaxAttesterSlashings, func(i int) interface{} { return body.AttesterSlashings[i] }},
+		{len(body.Attestations), params.BeaconConfig().MaxAttestations, func(i int) interface{} { return body.Attestations[i] }},
+		{len(body.Deposits), params.BeaconConfig().MaxDeposits, func(i int) interface{} { return body.Deposits[i] }},
+		{len(body.VoluntaryExits), params.BeaconConfig().MaxVoluntaryExits, func(i int) interface{} { return body.VoluntaryExits[i] }},
+	}
+	for i, list := range lists {
+		roots := make([][32]byte, list.length)
+		for j := range roots {
+			roots[j], err = ssz.HashTreeRoot(list.item(j))
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not compute root of item %d in body field %d", j, i+3)
+			}
+		}
+		root, err := htrutils.Merkleize(roots, list.limit)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not merkleize body field %d", i+3)
+		}
+		fieldRoots[i+3] = htrutils.MixInLength(root, uint64(list.length))
+	}
+	return fieldRoots, nil
+}
+
+// AttestationInclusionProof returns a merkle proof of the inclusion of the
+// attestation at the given index of the block body in the hash tree root of
+// the block. The proof is ordered from the attestation root up and is
+// verified against the block root using the returned merkle index, for example
+// with trieutil.VerifyMerkleBranch.
+func AttestationInclusionProof(block *ethpb.BeaconBlock, index uint64) ([][32]byte, uint64, error) {
+	if block == nil || block.Body == nil {
+		return nil, 0, errors.New("nil block")
+	}
+	atts := block.Body.Attestations
+	if index >= uint64(len(atts)) {
+		return nil, 0, errors.Errorf("attestation index %d out of range, block has %d attestations", index, len(atts))
+	}
+	limit := params.BeaconConfig().MaxAttestations
+	attRoots := make([][32]byte, len(atts))
+	var err error
+	for i := range atts {
+		attRoots[i], err = ssz.HashTreeRoot(atts[i])
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "could not compute root of attestation %d", i)
+		}
+	}
+	listProof, err := htrutils.MerkleProof(attRoots, limit, index)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "could not compute attestations proof")
+	}
+	var lengthChunk [32]byte
+	binary.LittleEndian.PutUint64(lengthChunk[:8], uint64(len(atts)))
+
+	bodyFields, err := BlockBodyFieldRoots(block.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	bodyProof, err := htrutils.MerkleProof(bodyFields, bodyFieldCount, bodyAttestationsIdx)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "could not compute block body proof")
+	}
+	bodyRoot, err := htrutils.Merkleize(bodyFields, bodyFieldCount)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "could not merkleize block body")
+	}
+
+	blockFields := [][32]byte{
+		Uint64Root(block.Slot),
+		bytesutil.ToBytes32(block.ParentRoot),
+		bytesutil.ToBytes32(block.StateRoot),
+		bodyRoot,
+	}
+	blockProof, err := htrutils.MerkleProof(blockFields, blockFieldCount, blockBodyFieldIndex)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "could not compute block proof")
+	}
+
+	proof := make([][32]byte, 0, len(listProof)+1+len(bodyProof)+len(blockProof))
+	proof = append(proof, listProof...)
+	proof = append(proof, lengthChunk)
+	proof = append(proof, bodyProof...)
+	proof = append(proof, blockProof...)
+
+	// The merkle index is read from the lowest bit up: the attestation index
+	// within the list, a zero bit for the list root on the left of its length,
+	// then the position of the attestations in the body and of the body in the block.
+	listDepth := uint64(htrutils.Depth(limit))
+	bodyDepth := uint64(htrutils.Depth(bodyFieldCount))
+	merkleIndex := index |
+		bodyAttestationsIdx<<(listDepth+1) |
+		blockBodyFieldIndex<<(listDepth+1+bodyDepth)
+	return proof, merkleIndex, nil
+}
@@ -0,0 +1,95 @@
+package stateutil_test
+
+import (
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	"github.com/prysmaticlabs/prysm/shared/htrutils"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
+)
+
+func testBlockWithAttestations(n int) *ethpb.BeaconBlock {
+	atts := make([]*ethpb.Attestation, n)
+	for i := range atts {
+		atts[i] = &ethpb.Attestation{
+			AggregationBits: bitfield.Bitlist{byte(i), 0x01},
+			Data: &ethpb.AttestationData{
+				Slot:            uint64(i),
+				CommitteeIndex:  uint64(i),
+				BeaconBlockRoot: make([]byte, 32),
+				Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+				Target:          &ethpb.Checkpoint{Epoch: 1, Root: make([]byte, 32)},
+			},
+			Signature: make([]byte, 96),
+		}
+	}
+	return &ethpb.BeaconBlock{
+		Slot:       10,
+		ParentRoot: []byte{'A'},
+		StateRoot:  []byte{'B'},
+		Body: &ethpb.BeaconBlockBody{
+			RandaoReveal: []byte{'C', 32: 'D', 95: 'E'},
+			Eth1Data: &ethpb.Eth1Data{
+				DepositRoot:  []byte{'F'},
+				DepositCount: 5,
+				BlockHash:    []byte{'G'},
+			},
+			Graffiti:       []byte{'H'},
+			Attestations:   atts,
+			VoluntaryExits: []*ethpb.SignedVoluntaryExit{{Exit: &ethpb.VoluntaryExit{Epoch: 1, ValidatorIndex: 2}, Signature: make([]byte, 96)}},
+		},
+	}
+}
+
+func TestBlockBodyFieldRoots_MatchesSSZ(t *testing.T) {
+	body := testBlockWithAttestations(3).Body
+	fieldRoots, err := stateutil.BlockBodyFieldRoots(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := htrutils.Merkleize(fieldRoots, uint64(len(fieldRoots)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := ssz.HashTreeRoot(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root != want {
+		t.Errorf("Wanted body root %#x, received %#x", want, root)
+	}
+}
+
+func TestAttestationInclusionProof_VerifiesAgainstBlockRoot(t *testing.T) {
+	block := testBlockWithAttestations(5)
+	blockRoot, err := ssz.HashTreeRoot(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, att := range block.Body.Attestations {
+		proof, merkleIndex, err := stateutil.AttestationInclusionProof(block, uint64(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		attRoot, err := ssz.HashTreeRoot(att)
+		if err != nil {
+			t.Fatal(err)
+		}
+		branch := make([][]byte, len(proof))
+		for j := range proof {
+			branch[j] = proof[j][:]
+		}
+		if !trieutil.VerifyMerkleBranch(blockRoot[:], attRoot[:], int(merkleIndex), branch) {
+			t.Errorf("Inclusion proof of attestation %d did not verify", i)
+		}
+	}
+}
+
+func TestAttestationInclusionProof_IndexOutOfRange(t *testing.T) {
+	if _, _, err := stateutil.AttestationInclusionProof(testBlockWithAttestations(2), 2); err == nil {
+		t.Error("Expected error for attestation index out of range")
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/protolambda/zssz/merkle"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/htrutils"
 )
 
 func bitlistRoot(bfield bitfield.Bitfield, maxCapacity uint64) ([32]byte, error) {
@@ -59,11 +60,7 @@ func bitwiseMerkleizeArrays(chunks [][32]byte, count uint64, limit uint64) ([32]
 	if count > limit {
 		return [32]byte{}, errors.New("merkleizing list that is too large, over limit")
 	}
-	hasher := htr.HashFn(hashutil.CustomSHA256Hasher())
-	leafIndexer := func(i uint64) []byte {
-		return chunks[i][:]
-	}
-	return merkle.Merkleize(hasher, count, limit, leafIndexer), nil
+	return htrutils.Merkleize(chunks[:count], limit)
 }
 
 func pack(serializedItems [][]byte) ([][]byte, error) {
@@ -0,0 +1,87 @@
+package stateutil
+
+import (
+	"github.com/pkg/errors"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/htrutils"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// The indices of the proven fields among the fields of the beacon state.
+const (
+	randaoMixesFieldIndex         = 12
+	finalizedCheckpointFieldIndex = 19
+)
+
+// FinalizedRootIndex is the merkle index of the finalized checkpoint root in the hash tree root
+// of the state, as the right child of the finalized checkpoint field.
+const FinalizedRootIndex = 1 | finalizedCheckpointFieldIndex<<1
+
+// FinalizedRootProof returns a merkle proof of the inclusion of the finalized
+// checkpoint root in the hash tree root of the state. The proof is ordered from
+// the finalized root up and is verified against the state root using the
+// returned merkle index, for example with trieutil.VerifyMerkleBranch.
+func FinalizedRootProof(state *pb.BeaconState) ([][32]byte, uint64, error) {
+	if state == nil || state.FinalizedCheckpoint == nil {
+		return nil, 0, errors.New("nil state or finalized checkpoint")
+	}
+	stateProof, err := stateFieldProof(state, finalizedCheckpointFieldIndex)
+	if err != nil {
+		return nil, 0, err
+	}
+	// The finalized root is the second field of the checkpoint, on the right of its epoch.
+	proof := make([][32]byte, 0, 1+len(stateProof))
+	proof = append(proof, Uint64Root(state.FinalizedCheckpoint.Epoch))
+	proof = append(proof, stateProof...)
+	return proof, FinalizedRootIndex, nil
+}
+
+// RandaoMixProof returns a merkle proof of the inclusion of the randao mix at the
+// given index of the randao mixes vector in the hash tree root of the state. The
+// proof is ordered from the randao mix up and is verified against the state root
+// using the returned merkle index, for example with trieutil.VerifyMerkleBranch.
+func RandaoMixProof(state *pb.BeaconState, index uint64) ([][32]byte, uint64, error) {
+	if state == nil {
+		return nil, 0, errors.New("nil state")
+	}
+	length := params.BeaconConfig().EpochsPerHistoricalVector
+	if index >= uint64(len(state.RandaoMixes)) || index >= length {
+		return nil, 0, errors.Errorf("randao mix index %d out of range, state has %d mixes", index, len(state.RandaoMixes))
+	}
+	mixes := make([][32]byte, len(state.RandaoMixes))
+	for i := range state.RandaoMixes {
+		mixes[i] = bytesutil.ToBytes32(state.RandaoMixes[i])
+	}
+	vectorProof, err := htrutils.MerkleProof(mixes, length, index)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "could not compute randao mixes proof")
+	}
+	stateProof, err := stateFieldProof(state, randaoMixesFieldIndex)
+	if err != nil {
+		return nil, 0, err
+	}
+	proof := make([][32]byte, 0, len(vectorProof)+len(stateProof))
+	proof = append(proof, vectorProof...)
+	proof = append(proof, stateProof...)
+	// The randao mixes are a vector, merkleized without mixing in their length.
+	return proof, index | randaoMixesFieldIndex<<uint64(htrutils.Depth(length)), nil
+}
+
+// stateFieldProof returns a merkle proof of the inclusion of the root of the field at the
+// given index in the hash tree root of the state.
+func stateFieldProof(state *pb.BeaconState, fieldIndex uint64) ([][32]byte, error) {
+	fieldRoots, err := ComputeFieldRoots(state)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute state field roots")
+	}
+	chunks := make([][32]byte, len(fieldRoots))
+	for i := range fieldRoots {
+		chunks[i] = bytesutil.ToBytes32(fieldRoots[i])
+	}
+	proof, err := htrutils.MerkleProof(chunks, uint64(len(chunks)), fieldIndex)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute state proof")
+	}
+	return proof, nil
+}
@@ -0,0 +1,62 @@
+package stateutil_test
+
+import (
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/interop"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
+)
+
+func TestFinalizedRootProof_VerifiesAgainstStateRoot(t *testing.T) {
+	state, _, err := interop.GenerateGenesisState(0, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.FinalizedCheckpoint = &ethpb.Checkpoint{Epoch: 3, Root: []byte{'A'}}
+	stateRoot, err := stateutil.HashTreeRootState(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, merkleIndex, err := stateutil.FinalizedRootProof(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	branch := make([][]byte, len(proof))
+	for i := range proof {
+		branch[i] = proof[i][:]
+	}
+	leaf := bytesutil.ToBytes32([]byte{'A'})
+	if !trieutil.VerifyMerkleBranch(stateRoot[:], leaf[:], int(merkleIndex), branch) {
+		t.Error("Finalized root proof did not verify against the state root")
+	}
+}
+
+func TestRandaoMixProof_VerifiesAgainstStateRoot(t *testing.T) {
+	state, _, err := interop.GenerateGenesisState(0, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mix := bytesutil.ToBytes32([]byte{'A'})
+	state.RandaoMixes[5] = mix[:]
+	stateRoot, err := stateutil.HashTreeRootState(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, merkleIndex, err := stateutil.RandaoMixProof(state, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	branch := make([][]byte, len(proof))
+	for i := range proof {
+		branch[i] = proof[i][:]
+	}
+	if !trieutil.VerifyMerkleBranch(stateRoot[:], mix[:], int(merkleIndex), branch) {
+		t.Error("Randao mix proof did not verify against the state root")
+	}
+	if _, _, err := stateutil.RandaoMixProof(state, uint64(len(state.RandaoMixes))); err == nil {
+		t.Error("Expected error for out of range randao mix index")
+	}
+}
@@ -37,6 +37,21 @@ func TestState_FieldCount(t *testing.T) {
 	}
 }
 
+func TestHashTreeRootState_LargeRegistryMatchesSSZ(t *testing.T) {
+	genesisState := setupGenesisState(t, 2048)
+	want, err := ssz.HashTreeRoot(genesisState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := stateutil.HashTreeRootState(genesisState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("Wanted state root %#x, received %#x", want, got)
+	}
+}
+
 func BenchmarkHashTreeRootState_Custom_512(b *testing.B) {
 	b.StopTimer()
 	genesisState := setupGenesisState(b, 512)
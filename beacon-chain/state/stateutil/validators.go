@@ -3,15 +3,21 @@ package stateutil
 import (
 	"bytes"
 	"encoding/binary"
+	"sync"
 
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/mputil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 )
 
+// minParallelValidators is the registry size from which validator roots are
+// computed in parallel.
+const minParallelValidators = 1024
+
 // ValidatorRegistryRoot computes the HashTreeRoot Merkleization of
 // a list of validator structs according to the eth2
 // Simple Serialize specification.
@@ -61,17 +67,13 @@ func ValidatorBalancesRoot(balances []uint64) ([32]byte, error) {
 
 func (h *stateRootHasher) validatorRegistryRoot(validators []*ethpb.Validator) ([32]byte, error) {
 	hashKeyElements := make([]byte, len(validators)*32)
-	roots := make([][32]byte, len(validators))
 	emptyKey := hashutil.FastSum256(hashKeyElements)
-	bytesProcessed := 0
-	for i := 0; i < len(validators); i++ {
-		val, err := h.validatorRoot(validators[i])
-		if err != nil {
-			return [32]byte{}, errors.Wrap(err, "could not compute validators merkleization")
-		}
-		copy(hashKeyElements[bytesProcessed:bytesProcessed+32], val[:])
-		roots[i] = val
-		bytesProcessed += 32
+	roots, err := h.validatorRoots(validators)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not compute validators merkleization")
+	}
+	for i := 0; i < len(roots); i++ {
+		copy(hashKeyElements[i*32:(i+1)*32], roots[i][:])
 	}
 
 	hashKey := hashutil.FastSum256(hashKeyElements)
@@ -99,6 +101,37 @@ func (h *stateRootHasher) validatorRegistryRoot(validators []*ethpb.Validator) (
 	return res, nil
 }
 
+// validatorRoots computes the hash tree root of each validator in the registry.
+// Large registries are split across multiple goroutines, as hashing each
+// validator is independent of the others.
+func (h *stateRootHasher) validatorRoots(validators []*ethpb.Validator) ([][32]byte, error) {
+	roots := make([][32]byte, len(validators))
+	if len(validators) < minParallelValidators {
+		for i := 0; i < len(validators); i++ {
+			root, err := h.validatorRoot(validators[i])
+			if err != nil {
+				return nil, err
+			}
+			roots[i] = root
+		}
+		return roots, nil
+	}
+	// Workers write to disjoint ranges of the roots slice.
+	if _, err := mputil.Scatter(len(validators), func(offset int, entries int, _ *sync.RWMutex) (interface{}, error) {
+		for i := offset; i < offset+entries; i++ {
+			root, err := h.validatorRoot(validators[i])
+			if err != nil {
+				return nil, err
+			}
+			roots[i] = root
+		}
+		return nil, nil
+	}); err != nil {
+		return nil, err
+	}
+	return roots, nil
+}
+
 func (h *stateRootHasher) validatorRoot(validator *ethpb.Validator) ([32]byte, error) {
 	// Validator marshaling for caching.
 	enc := make([]byte, 122)
@@ -12,6 +12,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
 	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
 	"github.com/prysmaticlabs/prysm/shared/memorypool"
 	"github.com/prysmaticlabs/prysm/shared/params"
@@ -38,6 +39,7 @@ type BeaconState struct {
 	dirtyFields  map[fieldIndex]interface{}
 	valIdxMap    map[[48]byte]uint64
 	merkleLayers [][][]byte
+	mutationFeed *event.Feed
 
 	sharedFieldReferences map[fieldIndex]*reference
 }
@@ -7,7 +7,9 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pkgerrors "github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
 )
 
 func (r *Service) decodePubsubMessage(msg *pubsub.Message) (proto.Message, error) {
@@ -16,12 +18,16 @@ func (r *Service) decodePubsubMessage(msg *pubsub.Message) (proto.Message, error
 	}
 	topic := msg.TopicIDs[0]
 	topic = strings.TrimSuffix(topic, r.p2p.Encoding().ProtocolSuffix())
+	topic = p2p.TopicWithoutForkDigest(topic)
 	base, ok := p2p.GossipTopicMappings[topic]
 	if !ok {
 		return nil, fmt.Errorf("no message mapped for topic %s", topic)
 	}
 	m := proto.Clone(base)
-	if err := r.p2p.Encoding().Decode(msg.Data, m); err != nil {
+	if err := r.p2p.Encoding().DecodeGossip(msg.Data, m, p2p.GossipTopicMaxSize(topic)); err != nil {
+		if pkgerrors.Cause(err) == encoder.ErrExceedsMaxSize {
+			messageOversizedCounter.WithLabelValues(topic).Inc()
+		}
 		return nil, err
 	}
 	return m, nil
@@ -0,0 +1,107 @@
+package sync
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+)
+
+// forkDigestCheckPeriod is the period at which the fork digests of the gossip topics are checked,
+// to subscribe to the topics of an upcoming fork and leave the topics of a past fork in time.
+const forkDigestCheckPeriod = time.Second
+
+// gossipTopic is a gossip topic, without fork digest, along with its subscription for each of the
+// active fork digests.
+type gossipTopic struct {
+	base      proto.Message
+	validator pubsub.Validator
+	handle    subHandler
+	subs      map[[4]byte]*pubsub.Subscription
+}
+
+// gossipTopics tracks the gossip topics registered by the service.
+type gossipTopics struct {
+	lock   sync.Mutex
+	topics map[string]*gossipTopic
+}
+
+// registerGossipTopic registers the topic to be subscribed with each of the active fork digests.
+func (r *Service) registerGossipTopic(base proto.Message, topic string, validator pubsub.Validator, handle subHandler) {
+	r.gossipTopics.lock.Lock()
+	if r.gossipTopics.topics == nil {
+		r.gossipTopics.topics = make(map[string]*gossipTopic)
+	}
+	if _, ok := r.gossipTopics.topics[topic]; ok {
+		r.gossipTopics.lock.Unlock()
+		return
+	}
+	r.gossipTopics.topics[topic] = &gossipTopic{
+		base:      base,
+		validator: validator,
+		handle:    handle,
+		subs:      make(map[[4]byte]*pubsub.Subscription),
+	}
+	r.gossipTopics.lock.Unlock()
+	r.updateForkDigestSubscriptions()
+}
+
+// unregisterGossipTopic cancels the subscriptions of the topic for every fork digest.
+func (r *Service) unregisterGossipTopic(topic string) {
+	r.gossipTopics.lock.Lock()
+	defer r.gossipTopics.lock.Unlock()
+	t, ok := r.gossipTopics.topics[topic]
+	if !ok {
+		return
+	}
+	for digest, sub := range t.subs {
+		r.unsubscribeFromTopic(sub, p2p.ForkDigestTopic(topic, digest))
+	}
+	delete(r.gossipTopics.topics, topic)
+}
+
+// updateForkDigestSubscriptions subscribes the registered topics with the fork digests which became
+// active and cancels the subscriptions of the fork digests which are no longer active. Around fork
+// epochs, topics are subscribed with the digests of the forks on both sides of the fork epoch.
+func (r *Service) updateForkDigestSubscriptions() {
+	digests, err := r.p2p.ActiveForkDigests()
+	if err == p2p.ErrGenesisUnknown {
+		return
+	}
+	if err != nil {
+		log.WithError(err).Error("Could not compute active fork digests")
+		return
+	}
+	active := make(map[[4]byte]bool, len(digests))
+	for _, digest := range digests {
+		active[digest] = true
+	}
+
+	r.gossipTopics.lock.Lock()
+	defer r.gossipTopics.lock.Unlock()
+	for topic, t := range r.gossipTopics.topics {
+		for digest, sub := range t.subs {
+			if !active[digest] {
+				log.WithField("topic", topic).WithField("forkDigest", digest).Debug("Leaving gossip topic of inactive fork digest")
+				r.unsubscribeFromTopic(sub, p2p.ForkDigestTopic(topic, digest))
+				delete(t.subs, digest)
+			}
+		}
+		for _, digest := range digests {
+			if _, ok := t.subs[digest]; !ok {
+				t.subs[digest] = r.subscribeToTopic(t.base, p2p.ForkDigestTopic(topic, digest), t.validator, t.handle)
+			}
+		}
+	}
+}
+
+// unsubscribeFromTopic cancels the subscription and unregisters the validator of the topic with
+// fork digest.
+func (r *Service) unsubscribeFromTopic(sub *pubsub.Subscription, topic string) {
+	sub.Cancel()
+	if err := r.p2p.PubSub().UnregisterTopicValidator(topic + r.p2p.Encoding().ProtocolSuffix()); err != nil {
+		log.WithError(err).WithField("topic", topic).Debug("Failed to unregister validator")
+	}
+}
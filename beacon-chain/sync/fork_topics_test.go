@@ -0,0 +1,54 @@
+package sync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
+)
+
+func TestUpdateForkDigestSubscriptions_MigratesTopics(t *testing.T) {
+	p := p2ptest.NewTestP2P(t)
+	r := Service{
+		ctx:         context.Background(),
+		p2p:         p,
+		initialSync: &mockSync.Sync{IsSyncing: false},
+	}
+	topic := "/eth2/voluntary_exit"
+	r.subscribe(topic, r.noopValidator, func(_ context.Context, _ proto.Message) error {
+		return nil
+	})
+
+	oldTopic := p2p.ForkDigestTopic(topic, p.Digest) + p.Encoding().ProtocolSuffix()
+	if !hasTopic(p.PubSub().GetTopics(), oldTopic) {
+		t.Fatalf("Expected subscription to %s", oldTopic)
+	}
+
+	p.Digest = [4]byte{1, 2, 3, 4}
+	r.updateForkDigestSubscriptions()
+	newTopic := p2p.ForkDigestTopic(topic, p.Digest) + p.Encoding().ProtocolSuffix()
+	if !hasTopic(p.PubSub().GetTopics(), newTopic) {
+		t.Errorf("Expected subscription to %s", newTopic)
+	}
+	subs := r.gossipTopics.topics[topic].subs
+	if _, ok := subs[p.Digest]; !ok || len(subs) != 1 {
+		t.Errorf("Wanted only the subscription of the new fork digest, got %v", subs)
+	}
+
+	r.unregisterGossipTopic(topic)
+	if _, ok := r.gossipTopics.topics[topic]; ok {
+		t.Error("Expected topic to be unregistered")
+	}
+}
+
+func hasTopic(topics []string, topic string) bool {
+	for _, t := range topics {
+		if t == topic {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package initialsync
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	blockfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/block"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/sirupsen/logrus"
+)
+
+// blocksDirFileExtension is the extension of the ssz encoded signed block files imported from the
+// blocks directory.
+const blocksDirFileExtension = ".ssz"
+
+// importBlocksDir processes the ssz encoded signed blocks of the files in the blocks directory, in
+// slot order, before syncing from the network. Blocks which are already known are skipped, as
+// well as blocks whose parent is neither in the database nor in the directory.
+func (s *Service) importBlocksDir(ctx context.Context) error {
+	blks, err := readBlocksDir(s.blocksDir)
+	if err != nil {
+		return err
+	}
+	log.WithFields(logrus.Fields{
+		"dir":    s.blocksDir,
+		"blocks": len(blks),
+	}).Info("Importing blocks from directory")
+
+	var imported, skipped int
+	for _, blk := range blks {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		root, err := ssz.HashTreeRoot(blk.Block)
+		if err != nil {
+			return errors.Wrap(err, "could not hash block")
+		}
+		if s.db.HasBlock(ctx, root) {
+			continue
+		}
+		if !s.db.HasBlock(ctx, bytesutil.ToBytes32(blk.Block.ParentRoot)) {
+			log.Debugf("Skipping block %#x at slot %d without a known parent", root, blk.Block.Slot)
+			skipped++
+			continue
+		}
+		s.blockNotifier.BlockFeed().Send(&feed.Event{
+			Type: blockfeed.ReceivedBlock,
+			Data: &blockfeed.ReceivedBlockData{SignedBlock: blk},
+		})
+		if featureconfig.Get().InitSyncNoVerify {
+			err = s.chain.ReceiveBlockNoVerify(ctx, blk)
+		} else {
+			err = s.chain.ReceiveBlockNoPubsubForkchoice(ctx, blk)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "could not process block %#x at slot %d", root, blk.Block.Slot)
+		}
+		imported++
+	}
+	log.WithFields(logrus.Fields{
+		"imported": imported,
+		"skipped":  skipped,
+		"headSlot": s.chain.HeadSlot(),
+	}).Info("Imported blocks from directory")
+	return nil
+}
+
+// readBlocksDir decodes the signed blocks of the ssz files of a directory, sorted by slot.
+func readBlocksDir(dir string) ([]*eth.SignedBeaconBlock, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read blocks directory")
+	}
+	blks := make([]*eth.SignedBeaconBlock, 0, len(files))
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != blocksDirFileExtension {
+			continue
+		}
+		enc, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read block file %s", f.Name())
+		}
+		blk := &eth.SignedBeaconBlock{}
+		if err := ssz.Unmarshal(enc, blk); err != nil {
+			return nil, errors.Wrapf(err, "could not decode block file %s", f.Name())
+		}
+		if blk.Block == nil {
+			return nil, errors.Errorf("block file %s has no block", f.Name())
+		}
+		blks = append(blks, blk)
+	}
+	sort.SliceStable(blks, func(i, j int) bool {
+		return blks[i].Block.Slot < blks[j].Block.Slot
+	})
+	return blks, nil
+}
@@ -0,0 +1,94 @@
+package initialsync
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+)
+
+func TestImportBlocksDir(t *testing.T) {
+	db := dbtest.SetupDB(t)
+	defer dbtest.TeardownDB(t, db)
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "blocks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	genesis := &eth.SignedBeaconBlock{Block: &eth.BeaconBlock{Slot: 0}}
+	if err := db.SaveBlock(ctx, genesis); err != nil {
+		t.Fatal(err)
+	}
+	genesisRoot, err := ssz.HashTreeRoot(genesis.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeBlock := func(name string, blk *eth.SignedBeaconBlock) {
+		enc, err := ssz.Marshal(blk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, name), enc, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The files are named so that they are not in slot order.
+	parentRoot := genesisRoot
+	for slot := uint64(1); slot <= 3; slot++ {
+		blk := &eth.SignedBeaconBlock{Block: &eth.BeaconBlock{Slot: slot, ParentRoot: parentRoot[:]}}
+		writeBlock(fmt.Sprintf("block_%d.ssz", 10-slot), blk)
+		parentRoot, err = ssz.HashTreeRoot(blk.Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A block without a known parent is skipped.
+	writeBlock("orphan.ssz", &eth.SignedBeaconBlock{Block: &eth.BeaconBlock{Slot: 2, ParentRoot: []byte{'A'}}})
+	// Files without the ssz extension are ignored.
+	if err := ioutil.WriteFile(filepath.Join(dir, "README"), []byte("blocks"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	mc := &mock.ChainService{DB: db, Root: genesisRoot[:]}
+	s := &Service{
+		ctx:           ctx,
+		chain:         mc,
+		db:            db,
+		blockNotifier: mc.BlockNotifier(),
+		blocksDir:     dir,
+	}
+	if err := s.importBlocksDir(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(mc.BlocksReceived) != 3 {
+		t.Fatalf("Wanted 3 imported blocks, received %d", len(mc.BlocksReceived))
+	}
+	for i, blk := range mc.BlocksReceived {
+		if blk.Block.Slot != uint64(i+1) {
+			t.Errorf("Wanted block %d at slot %d, received slot %d", i, i+1, blk.Block.Slot)
+		}
+	}
+	if !db.HasBlock(ctx, bytesutil.ToBytes32(mc.Root)) {
+		t.Error("Expected the last imported block to be saved")
+	}
+
+	// Importing the directory again skips the known blocks.
+	if err := s.importBlocksDir(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(mc.BlocksReceived) != 3 {
+		t.Errorf("Wanted known blocks to be skipped, received %d blocks", len(mc.BlocksReceived))
+	}
+}
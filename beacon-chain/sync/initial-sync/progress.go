@@ -0,0 +1,51 @@
+package initialsync
+
+import (
+	"sync"
+	"time"
+
+	prysmsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
+)
+
+// syncProgress records the starting point and the rate of the running sync.
+type syncProgress struct {
+	lock            sync.RWMutex
+	startingSlot    uint64
+	blocksPerSecond float64
+}
+
+func (p *syncProgress) start(headSlot uint64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.startingSlot = headSlot
+	p.blocksPerSecond = 0
+}
+
+func (p *syncProgress) setBlocksPerSecond(rate float64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.blocksPerSecond = rate
+}
+
+// SyncProgress returns the progress of the node in syncing to the highest head slot reported by
+// its peers. The estimated time remaining assumes the blocks keep being processed at the rate
+// measured over the last seconds of sync.
+func (s *Service) SyncProgress() *prysmsync.Progress {
+	s.progress.lock.RLock()
+	defer s.progress.lock.RUnlock()
+	progress := &prysmsync.Progress{
+		Syncing:      s.Syncing(),
+		StartingSlot: s.progress.startingSlot,
+		CurrentSlot:  s.chain.HeadSlot(),
+		HighestSlot:  s.p2p.Peers().HighestHeadSlot(),
+	}
+	if !progress.Syncing {
+		return progress
+	}
+	progress.BlocksPerSecond = s.progress.blocksPerSecond
+	if progress.BlocksPerSecond > 0 && progress.HighestSlot > progress.CurrentSlot {
+		remaining := float64(progress.HighestSlot-progress.CurrentSlot) / progress.BlocksPerSecond
+		progress.EstimatedTimeRemaining = time.Duration(remaining * float64(time.Second))
+	}
+	return progress
+}
@@ -0,0 +1,47 @@
+package initialsync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	p2pt "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	p2ppb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+func TestService_SyncProgress(t *testing.T) {
+	headState, err := stateTrie.InitializeFromProto(&p2ppb.BeaconState{Slot: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := p2pt.NewTestP2P(t)
+	pid := peer.ID("peer")
+	p.Peers().Add(pid, nil, network.DirOutbound)
+	p.Peers().SetChainState(pid, &p2ppb.Status{HeadSlot: 300})
+	s := &Service{
+		chain: &mock.ChainService{State: headState},
+		p2p:   p,
+	}
+
+	s.progress.start(40)
+	s.progress.setBlocksPerSecond(20)
+	progress := s.SyncProgress()
+	if !progress.Syncing {
+		t.Fatal("Wanted syncing progress")
+	}
+	if progress.StartingSlot != 40 || progress.CurrentSlot != 100 || progress.HighestSlot != 300 {
+		t.Errorf("Unexpected slots %d, %d, %d", progress.StartingSlot, progress.CurrentSlot, progress.HighestSlot)
+	}
+	if progress.EstimatedTimeRemaining != 10*time.Second {
+		t.Errorf("Wanted 10s remaining, got %v", progress.EstimatedTimeRemaining)
+	}
+
+	s.synced = true
+	progress = s.SyncProgress()
+	if progress.Syncing || progress.EstimatedTimeRemaining != 0 {
+		t.Errorf("Wanted no time remaining once synced, got %v", progress.EstimatedTimeRemaining)
+	}
+}
@@ -341,6 +341,7 @@ func (s *Service) highestFinalizedEpoch() uint64 {
 func (s *Service) logSyncStatus(genesis time.Time, blk *eth.BeaconBlock, syncingPeers []peer.ID, counter *ratecounter.RateCounter) {
 	counter.Incr(1)
 	rate := float64(counter.Rate()) / counterSeconds
+	s.progress.setBlocksPerSecond(rate)
 	if rate == 0 {
 		rate = 1
 	}
@@ -42,6 +42,7 @@ type Config struct {
 	Chain         blockchainService
 	StateNotifier statefeed.Notifier
 	BlockNotifier blockfeed.Notifier
+	BlocksDir     string
 }
 
 // Service service.
@@ -55,6 +56,8 @@ type Service struct {
 	stateNotifier     statefeed.Notifier
 	blockNotifier     blockfeed.Notifier
 	blocksRateLimiter *leakybucket.Collector
+	progress          syncProgress
+	blocksDir         string
 }
 
 // NewInitialSync configures the initial sync service responsible for bringing the node up to the
@@ -67,6 +70,7 @@ func NewInitialSync(cfg *Config) *Service {
 		db:                cfg.DB,
 		stateNotifier:     cfg.StateNotifier,
 		blockNotifier:     cfg.BlockNotifier,
+		blocksDir:         cfg.BlocksDir,
 		blocksRateLimiter: leakybucket.NewCollector(allowedBlocksPerSecond, allowedBlocksPerSecond, false /* deleteEmptyBuckets */),
 	}
 }
@@ -112,6 +116,11 @@ func (s *Service) Start() {
 		time.Sleep(roughtime.Until(genesis))
 	}
 	s.chainStarted = true
+	if s.blocksDir != "" {
+		if err := s.importBlocksDir(s.ctx); err != nil {
+			log.WithError(err).Error("Could not import blocks from directory")
+		}
+	}
 	currentSlot := helpers.SlotsSince(genesis)
 	if helpers.SlotToEpoch(currentSlot) == 0 {
 		log.Info("Chain started within the last epoch - not syncing")
@@ -126,6 +135,7 @@ func (s *Service) Start() {
 		return
 	}
 	s.waitForMinimumPeers()
+	s.progress.start(s.chain.HeadSlot())
 	if err := s.roundRobinSync(genesis); err != nil {
 		panic(err)
 	}
@@ -164,6 +174,7 @@ func (s *Service) Resync() error {
 	genesis := time.Unix(int64(headState.GenesisTime()), 0)
 
 	s.waitForMinimumPeers()
+	s.progress.start(s.chain.HeadSlot())
 	err = s.roundRobinSync(genesis)
 	if err != nil {
 		log = log.WithError(err)
@@ -3,6 +3,7 @@ package testing
 // Sync defines a mock for the sync service.
 type Sync struct {
 	IsSyncing bool
+	Resyncs   int
 }
 
 // Syncing --
@@ -17,5 +18,6 @@ func (s *Sync) Status() error {
 
 // Resync --
 func (s *Sync) Resync() error {
+	s.Resyncs++
 	return nil
 }
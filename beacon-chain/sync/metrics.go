@@ -3,6 +3,24 @@ package sync
 import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+	"github.com/prysmaticlabs/prysm/shared/slotutil"
+)
+
+// Types and stages of gossip messages whose arrival is timed relative to the start of their slot.
+const (
+	arrivalBlock       = "block"
+	arrivalAttestation = "attestation"
+	arrivalAggregate   = "aggregate"
+	arrivalReceived    = "received"
+	arrivalProcessed   = "processed"
+)
+
+// Outcomes of the validation of gossip messages.
+const (
+	validationAccepted = "accepted"
+	validationIgnored  = "ignored"
+	validationRejected = "rejected"
 )
 
 var (
@@ -20,6 +38,35 @@ var (
 		},
 		[]string{"topic"},
 	)
+	messageValidationCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "p2p_message_validation_total",
+			Help: "Count of validated messages by outcome: accepted, ignored while syncing, or rejected.",
+		},
+		[]string{"topic", "outcome"},
+	)
+	messageValidationLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "p2p_message_validation_seconds",
+			Help:    "Time taken to validate messages received over gossip.",
+			Buckets: []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5},
+		},
+		[]string{"topic"},
+	)
+	messageOversizedCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "p2p_message_oversized_total",
+			Help: "Count of messages rejected before decoding for exceeding the max size of their topic.",
+		},
+		[]string{"topic"},
+	)
+	attestationOutOfWindowCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "p2p_attestation_out_of_window_total",
+			Help: "Count of attestations rejected for being outside of their propagation window, by reason: slot out of range, target epoch mismatch or target epoch out of range.",
+		},
+		[]string{"reason"},
+	)
 	messageFailedProcessingCounter = promauto.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "p2p_message_failed_processing_total",
@@ -27,6 +74,14 @@ var (
 		},
 		[]string{"topic"},
 	)
+	messageArrivalDelay = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "p2p_message_arrival_delay_seconds",
+			Help:    "Time between the start of the slot of blocks and attestations and their receipt over gossip or successful processing.",
+			Buckets: []float64{0.25, 0.5, 1, 2, 3, 4, 5, 6, 8, 10, 12, 18, 24},
+		},
+		[]string{"type", "stage"},
+	)
 	numberOfTimesResyncedCounter = promauto.NewCounter(
 		prometheus.CounterOpts{
 			Name: "number_of_times_resynced",
@@ -58,3 +113,17 @@ var (
 		},
 	)
 )
+
+// recordArrival records the delay between the start of the slot of a gossip message and now, at
+// the given stage of its handling. Nothing is recorded before the genesis time is known.
+func (r *Service) recordArrival(messageType string, stage string, slot uint64) {
+	if r.chain == nil {
+		return
+	}
+	genesis := r.chain.GenesisTime()
+	if genesis.IsZero() {
+		return
+	}
+	delay := roughtime.Now().Sub(slotutil.SlotStartTime(uint64(genesis.Unix()), slot))
+	messageArrivalDelay.WithLabelValues(messageType, stage).Observe(delay.Seconds())
+}
@@ -0,0 +1,50 @@
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func arrivalHistogram(t *testing.T, messageType string, stage string) *dto.Histogram {
+	metric := &dto.Metric{}
+	if err := messageArrivalDelay.WithLabelValues(messageType, stage).(prometheus.Histogram).Write(metric); err != nil {
+		t.Fatal(err)
+	}
+	return metric.GetHistogram()
+}
+
+func TestRecordArrival(t *testing.T) {
+	slotDuration := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	r := &Service{
+		chain: &mock.ChainService{Genesis: time.Now().Add(-3*slotDuration - 2*time.Second)},
+	}
+	before := arrivalHistogram(t, arrivalBlock, arrivalReceived)
+	r.recordArrival(arrivalBlock, arrivalReceived, 3)
+	after := arrivalHistogram(t, arrivalBlock, arrivalReceived)
+
+	if after.GetSampleCount() != before.GetSampleCount()+1 {
+		t.Fatalf("Wanted 1 recorded arrival, received %d", after.GetSampleCount()-before.GetSampleCount())
+	}
+	delay := after.GetSampleSum() - before.GetSampleSum()
+	if delay < 2 || delay > 3 {
+		t.Errorf("Wanted arrival delay of about 2s, received %fs", delay)
+	}
+}
+
+func TestRecordArrival_NoGenesis(t *testing.T) {
+	r := &Service{
+		chain: &mock.ChainService{},
+	}
+	before := arrivalHistogram(t, arrivalAttestation, arrivalProcessed)
+	r.recordArrival(arrivalAttestation, arrivalProcessed, 3)
+	after := arrivalHistogram(t, arrivalAttestation, arrivalProcessed)
+
+	if after.GetSampleCount() != before.GetSampleCount() {
+		t.Error("Expected no arrival to be recorded before genesis time is known")
+	}
+}
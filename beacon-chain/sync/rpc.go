@@ -7,6 +7,9 @@ import (
 
 	libp2pcore "github.com/libp2p/go-libp2p-core"
 	"github.com/libp2p/go-libp2p-core/network"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/lightclient"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/roughtime"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
@@ -18,11 +21,6 @@ import (
 // they don't receive the first byte within 5 seconds.
 const ttfbTimeout = 5 * time.Second
 
-// maxChunkSize would be the maximum allowed size that a request/response chunk can be.
-// any size beyond that would be rejected and the corresponding stream reset. This would
-// be 1048576 bytes or 1 MiB.
-const maxChunkSize = 1 << 20
-
 // rpcHandler is responsible for handling and responding to any incoming message.
 // This method may return an error to internal monitoring, but the error will
 // not be relayed to the peer.
@@ -50,6 +48,13 @@ func (r *Service) registerRPCHandlers() {
 		[][32]byte{},
 		r.beaconBlocksRootRPCHandler,
 	)
+	if r.lightClient != nil {
+		r.registerRPC(
+			"/eth2/beacon_chain/req/light_client_update/1",
+			&lightclient.UpdateRequest{},
+			r.lightClientUpdateRPCHandler,
+		)
+	}
 }
 
 // registerRPC for a given topic with an expected protobuf message type.
@@ -81,6 +86,9 @@ func (r *Service) registerRPC(topic string, base interface{}, handle rpcHandler)
 		if t.Kind() == reflect.Ptr {
 			msg := reflect.New(t.Elem())
 			if err := r.p2p.Encoding().DecodeWithLength(stream, msg.Interface()); err != nil {
+				if pkgerrors.Cause(err) == encoder.ErrExceedsMaxSize {
+					messageOversizedCounter.WithLabelValues(topic).Inc()
+				}
 				log.WithError(err).Warn("Failed to decode stream message")
 				traceutil.AnnotateError(span, err)
 				return
@@ -95,6 +103,9 @@ func (r *Service) registerRPC(topic string, base interface{}, handle rpcHandler)
 		} else {
 			msg := reflect.New(t)
 			if err := r.p2p.Encoding().DecodeWithLength(stream, msg.Interface()); err != nil {
+				if pkgerrors.Cause(err) == encoder.ErrExceedsMaxSize {
+					messageOversizedCounter.WithLabelValues(topic).Inc()
+				}
 				log.WithError(err).Warn("Failed to decode stream message")
 				traceutil.AnnotateError(span, err)
 				return
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	libp2pcore "github.com/libp2p/go-libp2p-core"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
@@ -13,7 +14,21 @@ import (
 	"go.opencensus.io/trace"
 )
 
+// maxBlocksByRangeSlots is the largest range of slots a blocks by range request can span.
+// TODO(3147): Update this with reasonable constraints.
+const maxBlocksByRangeSlots = 1000
+
+// maxConcurrentBlocksByRangeRequests is the number of blocks by range requests of a peer which
+// are served at the same time.
+const maxConcurrentBlocksByRangeRequests = 2
+
+// blocksByRangeBatchSize is the number of requested slots whose blocks are read from the
+// database at once, so that the whole range is never held in memory.
+const blocksByRangeBatchSize = 64
+
 // beaconBlocksByRangeRPCHandler looks up the request blocks from the database from a given start block.
+// The cost of a request, accounted for in the rate limiter of the peer, is the number of slots it
+// spans, count × step, as every slot of the range is scanned.
 func (r *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interface{}, stream libp2pcore.Stream) error {
 	ctx, span := trace.StartSpan(ctx, "sync.BeaconBlocksByRangeHandler")
 	defer span.End()
@@ -27,7 +42,8 @@ func (r *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interfa
 
 	startSlot := m.StartSlot
 	endSlot := startSlot + (m.Step * (m.Count - 1))
-	remainingBucketCapacity := r.blocksRateLimiter.Remaining(stream.Conn().RemotePeer().String())
+	pid := stream.Conn().RemotePeer()
+	remainingBucketCapacity := r.blocksRateLimiter.Remaining(pid.String())
 
 	span.AddAttributes(
 		trace.Int64Attribute("start", int64(startSlot)),
@@ -38,11 +54,27 @@ func (r *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interfa
 		trace.Int64Attribute("remaining_capacity", remainingBucketCapacity),
 	)
 
-	if m.Count > uint64(remainingBucketCapacity) {
-		r.p2p.Peers().IncrementBadResponses(stream.Conn().RemotePeer())
-		if r.p2p.Peers().IsBad(stream.Conn().RemotePeer()) {
+	if m.Count == 0 || m.Step == 0 || m.Count > maxBlocksByRangeSlots || m.Step > maxBlocksByRangeSlots ||
+		endSlot-startSlot > maxBlocksByRangeSlots {
+		resp, err := r.generateErrorResponse(responseCodeInvalidRequest, "invalid range or step")
+		if err != nil {
+			log.WithError(err).Error("Failed to generate a response error")
+		} else {
+			if _, err := stream.Write(resp); err != nil {
+				log.WithError(err).Errorf("Failed to write to stream")
+			}
+		}
+		err = errors.New("invalid range or step")
+		traceutil.AnnotateError(span, err)
+		return err
+	}
+
+	cost := int64(m.Count * m.Step)
+	if cost > remainingBucketCapacity {
+		r.p2p.Peers().IncrementBadResponses(pid)
+		if r.p2p.Peers().IsBad(pid) {
 			log.Debug("Disconnecting bad peer")
-			defer r.p2p.Disconnect(stream.Conn().RemotePeer())
+			defer r.p2p.Disconnect(pid)
 		}
 		resp, err := r.generateErrorResponse(responseCodeInvalidRequest, rateLimitedError)
 		if err != nil {
@@ -55,11 +87,8 @@ func (r *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interfa
 		return errors.New(rateLimitedError)
 	}
 
-	r.blocksRateLimiter.Add(stream.Conn().RemotePeer().String(), int64(m.Count))
-
-	// TODO(3147): Update this with reasonable constraints.
-	if endSlot-startSlot > 1000 || m.Step == 0 {
-		resp, err := r.generateErrorResponse(responseCodeInvalidRequest, "invalid range or step")
+	if !r.startBlocksByRangeRequest(pid) {
+		resp, err := r.generateErrorResponse(responseCodeInvalidRequest, rateLimitedError)
 		if err != nil {
 			log.WithError(err).Error("Failed to generate a response error")
 		} else {
@@ -67,10 +96,11 @@ func (r *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interfa
 				log.WithError(err).Errorf("Failed to write to stream")
 			}
 		}
-		err = errors.New("invalid range or step")
-		traceutil.AnnotateError(span, err)
-		return err
+		return errors.New(rateLimitedError)
 	}
+	defer r.endBlocksByRangeRequest(pid)
+
+	r.blocksRateLimiter.Add(pid.String(), cost)
 
 	var errResponse = func() {
 		resp, err := r.generateErrorResponse(responseCodeServerError, genericError)
@@ -83,21 +113,6 @@ func (r *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interfa
 		}
 	}
 
-	filter := filters.NewFilter().SetStartSlot(startSlot).SetEndSlot(endSlot).SetSlotStep(m.Step)
-	blks, err := r.db.Blocks(ctx, filter)
-	if err != nil {
-		log.WithError(err).Error("Failed to retrieve blocks")
-		errResponse()
-		traceutil.AnnotateError(span, err)
-		return err
-	}
-	roots, err := r.db.BlockRoots(ctx, filter)
-	if err != nil {
-		log.WithError(err).Error("Failed to retrieve block roots")
-		errResponse()
-		traceutil.AnnotateError(span, err)
-		return err
-	}
 	checkpoint, err := r.db.FinalizedCheckpoint(ctx)
 	if err != nil {
 		log.WithError(err).Error("Failed to retrieve finalized checkpoint")
@@ -105,20 +120,72 @@ func (r *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interfa
 		traceutil.AnnotateError(span, err)
 		return err
 	}
-	for i, b := range blks {
-		if b == nil || b.Block == nil {
-			continue
+
+	// The range is read from the database in batches of slots, each batch being written to the
+	// stream before the next one is read.
+	batchSpan := m.Step * blocksByRangeBatchSize
+	for batchStart := startSlot; batchStart <= endSlot; batchStart += batchSpan {
+		batchEnd := batchStart + batchSpan - 1
+		if batchEnd > endSlot {
+			batchEnd = endSlot
 		}
-		blk := b.Block
-
-		isRequestedSlotStep := (blk.Slot-startSlot)%m.Step == 0
-		isRecentUnfinalizedSlot := blk.Slot >= helpers.StartSlot(checkpoint.Epoch+1) || checkpoint.Epoch == 0
-		if isRequestedSlotStep && (isRecentUnfinalizedSlot || r.db.IsFinalizedBlock(ctx, roots[i])) {
-			if err := r.chunkWriter(stream, b); err != nil {
-				log.WithError(err).Error("Failed to send a chunked response")
-				return err
+		filter := filters.NewFilter().SetStartSlot(batchStart).SetEndSlot(batchEnd).SetSlotStep(m.Step)
+		blks, err := r.db.Blocks(ctx, filter)
+		if err != nil {
+			log.WithError(err).Error("Failed to retrieve blocks")
+			errResponse()
+			traceutil.AnnotateError(span, err)
+			return err
+		}
+		roots, err := r.db.BlockRoots(ctx, filter)
+		if err != nil {
+			log.WithError(err).Error("Failed to retrieve block roots")
+			errResponse()
+			traceutil.AnnotateError(span, err)
+			return err
+		}
+		for i, b := range blks {
+			if b == nil || b.Block == nil {
+				continue
+			}
+			blk := b.Block
+
+			isRequestedSlotStep := (blk.Slot-startSlot)%m.Step == 0
+			isRecentUnfinalizedSlot := blk.Slot >= helpers.StartSlot(checkpoint.Epoch+1) || checkpoint.Epoch == 0
+			if isRequestedSlotStep && (isRecentUnfinalizedSlot || r.db.IsFinalizedBlock(ctx, roots[i])) {
+				if err := r.chunkWriter(stream, b); err != nil {
+					log.WithError(err).Error("Failed to send a chunked response")
+					return err
+				}
 			}
 		}
 	}
-	return err
+	return nil
+}
+
+// startBlocksByRangeRequest counts a blocks by range request of the peer as being served,
+// unless the peer already has the maximum number of concurrent requests being served.
+func (r *Service) startBlocksByRangeRequest(pid peer.ID) bool {
+	r.rangeRequestsLock.Lock()
+	defer r.rangeRequestsLock.Unlock()
+
+	if r.rangeRequests == nil {
+		r.rangeRequests = make(map[peer.ID]int)
+	}
+	if r.rangeRequests[pid] >= maxConcurrentBlocksByRangeRequests {
+		return false
+	}
+	r.rangeRequests[pid]++
+	return true
+}
+
+// endBlocksByRangeRequest counts a blocks by range request of the peer as served.
+func (r *Service) endBlocksByRangeRequest(pid peer.ID) {
+	r.rangeRequestsLock.Lock()
+	defer r.rangeRequestsLock.Unlock()
+
+	r.rangeRequests[pid]--
+	if r.rangeRequests[pid] <= 0 {
+		delete(r.rangeRequests, pid)
+	}
 }
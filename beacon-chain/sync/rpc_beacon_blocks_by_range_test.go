@@ -8,6 +8,7 @@ import (
 
 	"github.com/kevinms/leakybucket-go"
 	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/protocol"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	db "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
@@ -72,3 +73,116 @@ func TestBeaconBlocksRPCHandler_ReturnsBlocks(t *testing.T) {
 		t.Fatal("Did not receive stream within 1 sec")
 	}
 }
+
+func TestBeaconBlocksRPCHandler_ReturnsBlocksOfSeveralBatches(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	d := db.SetupDB(t)
+	defer db.TeardownDB(t, d)
+
+	req := &pb.BeaconBlocksByRangeRequest{
+		StartSlot: 1,
+		Step:      1,
+		Count:     3*blocksByRangeBatchSize + 5,
+	}
+	for i := req.StartSlot; i < req.StartSlot+req.Count; i++ {
+		if err := d.SaveBlock(context.Background(), &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: i}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := &Service{p2p: p1, db: d, blocksRateLimiter: leakybucket.NewCollector(10000, 10000, false)}
+	pcl := protocol.ID("/testing")
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.Host.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		for i := req.StartSlot; i < req.StartSlot+req.Count; i++ {
+			expectSuccess(t, r, stream)
+			res := &ethpb.SignedBeaconBlock{}
+			if err := r.p2p.Encoding().DecodeWithLength(stream, res); err != nil {
+				t.Error(err)
+				return
+			}
+			if res.Block.Slot != i {
+				t.Errorf("Received block slot %d, wanted %d", res.Block.Slot, i)
+				return
+			}
+		}
+	})
+
+	stream1, err := p1.Host.NewStream(context.Background(), p2.Host.ID(), pcl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.beaconBlocksByRangeRPCHandler(context.Background(), req, stream1); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if testutil.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestBeaconBlocksRPCHandler_RateLimitsCost(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	d := db.SetupDB(t)
+	defer db.TeardownDB(t, d)
+
+	// Few blocks are requested, but they span more slots than the capacity of the peer.
+	req := &pb.BeaconBlocksByRangeRequest{
+		StartSlot: 100,
+		Step:      64,
+		Count:     4,
+	}
+	r := &Service{p2p: p1, db: d, blocksRateLimiter: leakybucket.NewCollector(100, 100, false)}
+	pcl := protocol.ID("/testing")
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.Host.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		code, errMsg, err := ReadStatusCode(stream, r.p2p.Encoding())
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != responseCodeInvalidRequest || errMsg != rateLimitedError {
+			t.Errorf("Unexpected response code %d with message %q", code, errMsg)
+		}
+	})
+
+	stream1, err := p1.Host.NewStream(context.Background(), p2.Host.ID(), pcl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.beaconBlocksByRangeRPCHandler(context.Background(), req, stream1); err == nil || err.Error() != rateLimitedError {
+		t.Errorf("Expected rate limited error, received %v", err)
+	}
+	if testutil.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestBlocksByRangeRequest_ConcurrencyLimit(t *testing.T) {
+	r := &Service{}
+	pid := peer.ID("peer")
+	for i := 0; i < maxConcurrentBlocksByRangeRequests; i++ {
+		if !r.startBlocksByRangeRequest(pid) {
+			t.Fatalf("Request %d should be served", i)
+		}
+	}
+	if r.startBlocksByRangeRequest(pid) {
+		t.Error("Request over the concurrency limit should not be served")
+	}
+	if !r.startBlocksByRangeRequest(peer.ID("other")) {
+		t.Error("Request of another peer should be served")
+	}
+	r.endBlocksByRangeRequest(pid)
+	if !r.startBlocksByRangeRequest(pid) {
+		t.Error("Request should be served once another one ended")
+	}
+}
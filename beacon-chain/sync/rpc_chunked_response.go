@@ -8,6 +8,7 @@ import (
 	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
+	"github.com/prysmaticlabs/prysm/shared/params"
 )
 
 // chunkWriter writes the given message as a chunked response to the given network
@@ -24,7 +25,7 @@ func WriteChunk(stream libp2pcore.Stream, encoding encoder.NetworkEncoding, msg
 	if _, err := stream.Write([]byte{responseCodeSuccess}); err != nil {
 		return err
 	}
-	_, err := encoding.EncodeWithMaxLength(stream, msg, maxChunkSize)
+	_, err := encoding.EncodeWithMaxLength(stream, msg, params.BeaconConfig().MaxChunkSize)
 	return err
 }
 
@@ -50,5 +51,5 @@ func readResponseChunk(stream libp2pcore.Stream, p2p p2p.P2P, to interface{}) er
 	if code != 0 {
 		return errors.New(errMsg)
 	}
-	return p2p.Encoding().DecodeWithMaxLength(stream, to, maxChunkSize)
+	return p2p.Encoding().DecodeWithMaxLength(stream, to, params.BeaconConfig().MaxChunkSize)
 }
@@ -0,0 +1,42 @@
+package sync
+
+import (
+	"context"
+	"errors"
+
+	libp2pcore "github.com/libp2p/go-libp2p-core"
+	"github.com/prysmaticlabs/prysm/beacon-chain/lightclient"
+)
+
+const noLightClientUpdateError = "no light client update available"
+
+// lightClientUpdateRPCHandler responds with the light client update for the requested
+// finalized epoch, or the latest update if no epoch is requested.
+func (r *Service) lightClientUpdateRPCHandler(ctx context.Context, msg interface{}, stream libp2pcore.Stream) error {
+	defer stream.Close()
+	setRPCStreamDeadlines(stream)
+	log := log.WithField("handler", "light_client_update")
+
+	req, ok := msg.(*lightclient.UpdateRequest)
+	if !ok {
+		return errors.New("message is not type *lightclient.UpdateRequest")
+	}
+	var update *lightclient.Update
+	if req.FinalizedEpoch == 0 {
+		update = r.lightClient.LatestUpdate()
+	} else {
+		update = r.lightClient.UpdateAtFinalizedEpoch(req.FinalizedEpoch)
+	}
+	if update == nil {
+		resp, err := r.generateErrorResponse(responseCodeInvalidRequest, noLightClientUpdateError)
+		if err != nil {
+			log.WithError(err).Error("Failed to generate a response error")
+		} else {
+			if _, err := stream.Write(resp); err != nil {
+				log.WithError(err).Errorf("Failed to write to stream")
+			}
+		}
+		return errors.New(noLightClientUpdateError)
+	}
+	return r.chunkWriter(stream, update)
+}
@@ -47,7 +47,7 @@ func (r *Service) resyncIfBehind() {
 	// Run sixteen times per epoch.
 	interval := time.Duration(params.BeaconConfig().SecondsPerSlot*params.BeaconConfig().SlotsPerEpoch/16) * time.Second
 	runutil.RunEvery(r.ctx, interval, func() {
-		currentEpoch := uint64(roughtime.Now().Unix()-r.chain.GenesisTime().Unix()) / (params.BeaconConfig().SecondsPerSlot * params.BeaconConfig().SlotsPerEpoch)
+		currentEpoch := helpers.SlotToEpoch(slotutil.CurrentSlot(uint64(r.chain.GenesisTime().Unix())))
 		syncedEpoch := helpers.SlotToEpoch(r.chain.HeadSlot())
 		if r.initialSync != nil && !r.initialSync.Syncing() && syncedEpoch < currentEpoch-1 {
 			_, highestEpoch, _ := r.p2p.Peers().BestFinalized(params.BeaconConfig().MaxPeersToSync, syncedEpoch)
@@ -3,8 +3,10 @@ package sync
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/kevinms/leakybucket-go"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
@@ -13,6 +15,7 @@ import (
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/lightclient"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
@@ -35,6 +38,7 @@ type Config struct {
 	StateNotifier       statefeed.Notifier
 	BlockNotifier       blockfeed.Notifier
 	AttestationNotifier operation.Notifier
+	LightClient         lightclient.UpdateFetcher
 }
 
 // This defines the interface for interacting with block chain service
@@ -65,6 +69,7 @@ func NewRegularSync(cfg *Config) *Service {
 		blkRootToPendingAtts: make(map[[32]byte][]*ethpb.AggregateAttestationAndProof),
 		stateNotifier:        cfg.StateNotifier,
 		blockNotifier:        cfg.BlockNotifier,
+		lightClient:          cfg.LightClient,
 		blocksRateLimiter:    leakybucket.NewCollector(allowedBlocksPerSecond, allowedBlocksBurst, false /* deleteEmptyBuckets */),
 	}
 
@@ -95,7 +100,11 @@ type Service struct {
 	stateNotifier        statefeed.Notifier
 	blockNotifier        blockfeed.Notifier
 	blocksRateLimiter    *leakybucket.Collector
+	rangeRequests        map[peer.ID]int
+	rangeRequestsLock    sync.Mutex
 	attestationNotifier  operation.Notifier
+	lightClient          lightclient.UpdateFetcher
+	gossipTopics         gossipTopics
 }
 
 // Start the regular sync service.
@@ -137,3 +146,22 @@ type Checker interface {
 	Status() error
 	Resync() error
 }
+
+// Progress describes how far along a node is in syncing the chain with its peers.
+type Progress struct {
+	Syncing bool `json:"syncing"`
+	// StartingSlot is the head slot when the node started syncing.
+	StartingSlot uint64 `json:"starting_slot"`
+	// CurrentSlot is the current head slot of the node.
+	CurrentSlot uint64 `json:"current_slot"`
+	// HighestSlot is the highest head slot reported by peers.
+	HighestSlot     uint64  `json:"highest_slot"`
+	BlocksPerSecond float64 `json:"blocks_per_second"`
+	// EstimatedTimeRemaining is zero when not syncing or when no blocks were processed yet.
+	EstimatedTimeRemaining time.Duration `json:"estimated_time_remaining"`
+}
+
+// ProgressFetcher defines a struct which can report the progress of syncing a chain.
+type ProgressFetcher interface {
+	SyncProgress() *Progress
+}
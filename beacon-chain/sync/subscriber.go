@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"runtime/debug"
+	"strings"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -14,6 +15,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/shared/messagehandler"
 	"github.com/prysmaticlabs/prysm/shared/roughtime"
+	"github.com/prysmaticlabs/prysm/shared/runutil"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
 	"go.opencensus.io/trace"
 )
@@ -62,6 +64,7 @@ func (r *Service) registerSubscribers() {
 			}
 		}
 	}()
+	runutil.RunEvery(r.ctx, forkDigestCheckPeriod, r.updateForkDigestSubscriptions)
 	r.subscribe(
 		"/eth2/beacon_block",
 		r.validateBeaconBlockPubSub,
@@ -97,19 +100,21 @@ func (r *Service) registerSubscribers() {
 
 // subscribe to a given topic with a given validator and subscription handler.
 // The base protobuf message is used to initialize new messages for decoding.
-func (r *Service) subscribe(topic string, validator pubsub.Validator, handle subHandler) *pubsub.Subscription {
+// The topic is subscribed with each of the active fork digests.
+func (r *Service) subscribe(topic string, validator pubsub.Validator, handle subHandler) {
 	base := p2p.GossipTopicMappings[topic]
 	if base == nil {
 		panic(fmt.Sprintf("%s is not mapped to any message in GossipTopicMappings", topic))
 	}
-	return r.subscribeWithBase(base, topic, validator, handle)
+	r.registerGossipTopic(base, topic, validator, handle)
 }
 
-func (r *Service) subscribeWithBase(base proto.Message, topic string, validator pubsub.Validator, handle subHandler) *pubsub.Subscription {
+// subscribeToTopic subscribes to the topic with fork digest.
+func (r *Service) subscribeToTopic(base proto.Message, topic string, validator pubsub.Validator, handle subHandler) *pubsub.Subscription {
 	topic += r.p2p.Encoding().ProtocolSuffix()
 	log := log.WithField("topic", topic)
 
-	if err := r.p2p.PubSub().RegisterTopicValidator(wrapAndReportValidation(topic, validator)); err != nil {
+	if err := r.p2p.PubSub().RegisterTopicValidator(r.wrapAndReportValidation(topic, validator)); err != nil {
 		log.WithError(err).Error("Failed to register validator")
 	}
 
@@ -175,16 +180,32 @@ func (r *Service) subscribeWithBase(base proto.Message, topic string, validator
 }
 
 // Wrap the pubsub validator with a metric monitoring function. This function increments the
-// appropriate counter if the particular message fails to validate.
-func wrapAndReportValidation(topic string, v pubsub.Validator) (string, pubsub.Validator) {
+// appropriate counter if the particular message fails to validate, and records the validation
+// result in the gossip score of the peer which delivered the message.
+func (r *Service) wrapAndReportValidation(topic string, v pubsub.Validator) (string, pubsub.Validator) {
+	scoreTopic := strings.TrimSuffix(p2p.TopicWithoutForkDigest(topic), r.p2p.Encoding().ProtocolSuffix())
 	return topic, func(ctx context.Context, pid peer.ID, msg *pubsub.Message) bool {
 		defer messagehandler.HandlePanic(ctx, msg)
 		ctx, _ = context.WithTimeout(ctx, pubsubMessageTimeout)
 		messageReceivedCounter.WithLabelValues(topic).Inc()
+		start := time.Now()
 		b := v(ctx, pid, msg)
+		messageValidationLatency.WithLabelValues(topic).Observe(time.Since(start).Seconds())
 		if !b {
 			messageFailedValidationCounter.WithLabelValues(topic).Inc()
 		}
+		// Messages are not validated during initial sync, and our own messages are not scored.
+		scored := pid != r.p2p.PeerID() && !r.initialSync.Syncing()
+		if scored {
+			r.p2p.Peers().RecordGossipValidation(pid, scoreTopic, b)
+		}
+		outcome := validationAccepted
+		if !b && scored {
+			outcome = validationRejected
+		} else if !b {
+			outcome = validationIgnored
+		}
+		messageValidationCounter.WithLabelValues(topic, outcome).Inc()
 		return b
 	}
 }
@@ -200,7 +221,7 @@ func (r *Service) subscribeDynamic(topicFormat string, determineSubsLen func() i
 		panic(fmt.Sprintf("%s is not mapped to any message in GossipTopicMappings", topicFormat))
 	}
 
-	var subscriptions []*pubsub.Subscription
+	subscribed := 0
 
 	stateChannel := make(chan *feed.Event, 1)
 	stateSub := r.stateNotifier.StateFeed().Subscribe(stateChannel)
@@ -217,19 +238,16 @@ func (r *Service) subscribeDynamic(topicFormat string, determineSubsLen func() i
 				// Update topic count.
 				wantedSubs := determineSubsLen()
 				// Resize as appropriate.
-				if len(subscriptions) > wantedSubs { // Reduce topics
-					var cancelSubs []*pubsub.Subscription
-					subscriptions, cancelSubs = subscriptions[:wantedSubs-1], subscriptions[wantedSubs:]
-					for i, sub := range cancelSubs {
-						sub.Cancel()
-						r.p2p.PubSub().UnregisterTopicValidator(fmt.Sprintf(topicFormat, i+wantedSubs))
+				if subscribed > wantedSubs { // Reduce topics
+					for i := wantedSubs; i < subscribed; i++ {
+						r.unregisterGossipTopic(fmt.Sprintf(topicFormat, i))
 					}
-				} else if len(subscriptions) < wantedSubs { // Increase topics
-					for i := len(subscriptions); i < wantedSubs; i++ {
-						sub := r.subscribeWithBase(base, fmt.Sprintf(topicFormat, i), validate, handle)
-						subscriptions = append(subscriptions, sub)
+				} else if subscribed < wantedSubs { // Increase topics
+					for i := subscribed; i < wantedSubs; i++ {
+						r.registerGossipTopic(base, fmt.Sprintf(topicFormat, i), validate, handle)
 					}
 				}
+				subscribed = wantedSubs
 			}
 		}
 	}()
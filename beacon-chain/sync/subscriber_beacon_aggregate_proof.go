@@ -16,5 +16,9 @@ func (r *Service) beaconAggregateProofSubscriber(ctx context.Context, msg proto.
 		return fmt.Errorf("message was not type *eth.AggregateAttestationAndProof, type=%T", msg)
 	}
 
-	return r.attPool.SaveAggregatedAttestation(a.Aggregate)
+	if err := r.attPool.SaveAggregatedAttestation(a.Aggregate); err != nil {
+		return err
+	}
+	r.recordArrival(arrivalAggregate, arrivalProcessed, a.Aggregate.GetData().GetSlot())
+	return nil
 }
@@ -66,6 +66,8 @@ func (r *Service) beaconBlockSubscriber(ctx context.Context, msg proto.Message)
 	err = r.chain.ReceiveBlockNoPubsub(ctx, signed)
 	if err != nil {
 		interop.WriteBlockToDisk(signed, true /*failed*/)
+	} else {
+		r.recordArrival(arrivalBlock, arrivalProcessed, block.Slot)
 	}
 
 	// Delete attestations from the block in the pool to avoid inclusion in future block.
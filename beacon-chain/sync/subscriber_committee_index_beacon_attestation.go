@@ -30,7 +30,11 @@ func (r *Service) committeeIndexBeaconAttestationSubscriber(ctx context.Context,
 		},
 	})
 
-	return r.attPool.SaveUnaggregatedAttestation(a)
+	if err := r.attPool.SaveUnaggregatedAttestation(a); err != nil {
+		return err
+	}
+	r.recordArrival(arrivalAttestation, arrivalProcessed, a.GetData().GetSlot())
+	return nil
 }
 
 func (r *Service) currentCommitteeIndex() int {
@@ -95,8 +95,9 @@ func TestSubscribe_WaitToSync(t *testing.T) {
 func TestSubscribe_HandlesPanic(t *testing.T) {
 	p := p2ptest.NewTestP2P(t)
 	r := Service{
-		ctx: context.Background(),
-		p2p: p,
+		ctx:         context.Background(),
+		p2p:         p,
+		initialSync: &mockSync.Sync{IsSyncing: false},
 	}
 
 	topic := p2p.GossipTypeMapping[reflect.TypeOf(&pb.SignedVoluntaryExit{})]
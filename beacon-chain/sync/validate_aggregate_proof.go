@@ -11,14 +11,12 @@ import (
 	"github.com/prysmaticlabs/go-ssz"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
-	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/shared/attestationutil"
 	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/params"
-	"github.com/prysmaticlabs/prysm/shared/roughtime"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
 	"go.opencensus.io/trace"
 )
@@ -49,6 +47,7 @@ func (r *Service) validateAggregateAndProof(ctx context.Context, pid peer.ID, ms
 	if !ok {
 		return false
 	}
+	r.recordArrival(arrivalAggregate, arrivalReceived, m.GetAggregate().GetData().GetSlot())
 
 	// Verify aggregate attestation has not already been seen via aggregate gossip, within a block, or through the creation locally.
 	seen, err := r.attPool.HasAggregatedAttestation(m.Aggregate)
@@ -80,31 +79,21 @@ func (r *Service) validateAggregatedAtt(ctx context.Context, a *ethpb.AggregateA
 	ctx, span := trace.StartSpan(ctx, "sync.validateAggregatedAtt")
 	defer span.End()
 
-	attSlot := a.Aggregate.Data.Slot
-
-	// Verify attestation slot is within the last ATTESTATION_PROPAGATION_SLOT_RANGE slots.
-	currentSlot := uint64(roughtime.Now().Unix()-r.chain.GenesisTime().Unix()) / params.BeaconConfig().SecondsPerSlot
-	if attSlot > currentSlot || currentSlot > attSlot+params.BeaconConfig().AttestationPropagationSlotRange {
-		traceutil.AnnotateError(span, fmt.Errorf("attestation slot out of range %d <= %d <= %d", attSlot, currentSlot, attSlot+params.BeaconConfig().AttestationPropagationSlotRange))
+	// Verify attestation slot is within the last ATTESTATION_PROPAGATION_SLOT_RANGE slots and its target is the
+	// epoch of its slot, in the current or previous epoch.
+	if err := validateAttestationWindow(uint64(r.chain.GenesisTime().Unix()), a.Aggregate.Data); err != nil {
+		traceutil.AnnotateError(span, err)
 		return false
-
 	}
 
-	s, err := r.chain.HeadState(ctx)
+	// The target state is shared by the aggregates of the epoch through the checkpoint state cache,
+	// instead of advancing the head state for every aggregate.
+	s, err := r.chain.AttestationTargetState(ctx, a.Aggregate.Data.Target)
 	if err != nil {
 		traceutil.AnnotateError(span, err)
 		return false
 	}
 
-	// Only advance state if different epoch as the committee can only change on an epoch transition.
-	if helpers.SlotToEpoch(attSlot) > helpers.SlotToEpoch(s.Slot()) {
-		s, err = state.ProcessSlots(ctx, s, helpers.StartSlot(helpers.SlotToEpoch(attSlot)))
-		if err != nil {
-			traceutil.AnnotateError(span, err)
-			return false
-		}
-	}
-
 	// Verify validator index is within the aggregate's committee.
 	if err := validateIndexInCommittee(ctx, s, a.Aggregate, a.AggregatorIndex); err != nil {
 		traceutil.AnnotateError(span, errors.Wrapf(err, "Could not validate index in committee"))
@@ -191,7 +180,10 @@ func validateSelection(ctx context.Context, s *stateTrie.BeaconState, data *ethp
 	if err != nil {
 		return err
 	}
-	pubkeyState := s.PubkeyAtIndex(validatorIndex)
+	pubkeyState, err := s.PubkeyAtIndex(validatorIndex)
+	if err != nil {
+		return err
+	}
 	pubKey, err := bls.PublicKeyFromBytes(pubkeyState[:])
 	if err != nil {
 		return err
@@ -0,0 +1,49 @@
+package sync
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/slotutil"
+)
+
+// Reasons for rejecting attestations received over gossip outside of their propagation window.
+const (
+	attSlotOutOfRange        = "slot_out_of_range"
+	attTargetEpochMismatch   = "target_epoch_mismatch"
+	attTargetEpochOutOfRange = "target_epoch_out_of_range"
+)
+
+// validateAttestationWindow verifies an attestation may still be propagated, so that old
+// attestations cannot be replayed over gossip. Per the p2p spec:
+// - attestation.data.slot is within the last ATTESTATION_PROPAGATION_SLOT_RANGE slots.
+// - attestation.data.target.epoch == compute_epoch_at_slot(attestation.data.slot).
+// - attestation.data.target.epoch is the current or previous epoch.
+func validateAttestationWindow(genesis uint64, data *ethpb.AttestationData) error {
+	currentSlot := slotutil.CurrentSlot(genesis)
+	if !slotutil.WithinVotingWindow(genesis, data.Slot) {
+		attestationOutOfWindowCounter.WithLabelValues(attSlotOutOfRange).Inc()
+		return fmt.Errorf("attestation slot out of range %d <= %d <= %d", data.Slot, currentSlot, data.Slot+params.BeaconConfig().AttestationPropagationSlotRange)
+	}
+	if data.Target == nil {
+		attestationOutOfWindowCounter.WithLabelValues(attTargetEpochMismatch).Inc()
+		return errors.New("attestation has no target")
+	}
+	if data.Target.Epoch != helpers.SlotToEpoch(data.Slot) {
+		attestationOutOfWindowCounter.WithLabelValues(attTargetEpochMismatch).Inc()
+		return fmt.Errorf("attestation target epoch %d does not match the epoch of slot %d", data.Target.Epoch, data.Slot)
+	}
+	currentEpoch := helpers.SlotToEpoch(currentSlot)
+	previousEpoch := currentEpoch
+	if currentEpoch > 0 {
+		previousEpoch = currentEpoch - 1
+	}
+	if data.Target.Epoch != currentEpoch && data.Target.Epoch != previousEpoch {
+		attestationOutOfWindowCounter.WithLabelValues(attTargetEpochOutOfRange).Inc()
+		return fmt.Errorf("attestation target epoch %d is not the current epoch %d or previous epoch %d", data.Target.Epoch, currentEpoch, previousEpoch)
+	}
+	return nil
+}
@@ -38,13 +38,14 @@ func (r *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 		return false
 	}
 
-	r.validateBlockLock.Lock()
-	defer r.validateBlockLock.Unlock()
-
 	blk, ok := m.(*ethpb.SignedBeaconBlock)
 	if !ok {
 		return false
 	}
+	r.recordArrival(arrivalBlock, arrivalReceived, blk.GetBlock().GetSlot())
+
+	r.validateBlockLock.Lock()
+	defer r.validateBlockLock.Unlock()
 
 	blockRoot, err := ssz.HashTreeRoot(blk.Block)
 	if err != nil {
@@ -9,11 +9,9 @@ import (
 	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
-	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
-	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
 	"go.opencensus.io/trace"
 )
@@ -23,6 +21,7 @@ import (
 // - The attestation is unaggregated -- that is, it has exactly one participating validator (len([bit for bit in attestation.aggregation_bits if bit == 0b1]) == 1).
 // - The block being voted for (attestation.data.beacon_block_root) passes validation.
 // - attestation.data.slot is within the last ATTESTATION_PROPAGATION_SLOT_RANGE slots (attestation.data.slot + ATTESTATION_PROPAGATION_SLOT_RANGE >= current_slot >= attestation.data.slot).
+// - attestation.data.target.epoch is the epoch of attestation.data.slot, and the current or previous epoch.
 // - The signature of attestation is valid.
 func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, pid peer.ID, msg *pubsub.Message) bool {
 	if pid == s.p2p.PeerID() {
@@ -54,9 +53,10 @@ func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, p
 	if !ok {
 		return false
 	}
+	s.recordArrival(arrivalAttestation, arrivalReceived, att.GetData().GetSlot())
 
 	// The attestation's committee index (attestation.data.index) is for the correct subnet.
-	if !strings.HasPrefix(originalTopic, fmt.Sprintf(format, att.Data.CommitteeIndex)) {
+	if !strings.HasPrefix(p2p.TopicWithoutForkDigest(originalTopic), fmt.Sprintf(format, att.Data.CommitteeIndex)) {
 		return false
 	}
 
@@ -65,11 +65,10 @@ func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, p
 		return false
 	}
 
-	// Attestation's slot is within ATTESTATION_PROPAGATION_SLOT_RANGE.
-	currentSlot := helpers.SlotsSince(s.chain.GenesisTime())
-	upper := att.Data.Slot + params.BeaconConfig().AttestationPropagationSlotRange
-	lower := att.Data.Slot
-	if currentSlot > upper || currentSlot < lower {
+	// Attestation's slot is within ATTESTATION_PROPAGATION_SLOT_RANGE and its target is the epoch of its slot,
+	// in the current or previous epoch.
+	if err := validateAttestationWindow(uint64(s.chain.GenesisTime().Unix()), att.Data); err != nil {
+		traceutil.AnnotateError(span, err)
 		return false
 	}
 
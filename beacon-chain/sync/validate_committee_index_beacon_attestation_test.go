@@ -69,6 +69,7 @@ func TestService_validateCommitteeIndexBeaconAttestation(t *testing.T) {
 					BeaconBlockRoot: validBlockRoot[:],
 					CommitteeIndex:  1,
 					Slot:            63,
+					Target:          &ethpb.Checkpoint{Epoch: 1},
 				},
 			},
 			topic:                     "/eth2/committee_index1_beacon_attestation",
@@ -83,6 +84,7 @@ func TestService_validateCommitteeIndexBeaconAttestation(t *testing.T) {
 					BeaconBlockRoot: validBlockRoot[:],
 					CommitteeIndex:  2,
 					Slot:            63,
+					Target:          &ethpb.Checkpoint{Epoch: 1},
 				},
 			},
 			topic:                     "/eth2/committee_index3_beacon_attestation",
@@ -97,6 +99,7 @@ func TestService_validateCommitteeIndexBeaconAttestation(t *testing.T) {
 					BeaconBlockRoot: validBlockRoot[:],
 					CommitteeIndex:  1,
 					Slot:            63,
+					Target:          &ethpb.Checkpoint{Epoch: 1},
 				},
 			},
 			topic:                     "/eth2/committee_index1_beacon_attestation",
@@ -111,6 +114,37 @@ func TestService_validateCommitteeIndexBeaconAttestation(t *testing.T) {
 					BeaconBlockRoot: []byte("missing"),
 					CommitteeIndex:  1,
 					Slot:            63,
+					Target:          &ethpb.Checkpoint{Epoch: 1},
+				},
+			},
+			topic:                     "/eth2/committee_index1_beacon_attestation",
+			validAttestationSignature: true,
+			want:                      false,
+		},
+		{
+			name: "target epoch mismatch",
+			msg: &ethpb.Attestation{
+				AggregationBits: bitfield.Bitlist{0b1010},
+				Data: &ethpb.AttestationData{
+					BeaconBlockRoot: validBlockRoot[:],
+					CommitteeIndex:  1,
+					Slot:            63,
+					Target:          &ethpb.Checkpoint{Epoch: 0},
+				},
+			},
+			topic:                     "/eth2/committee_index1_beacon_attestation",
+			validAttestationSignature: true,
+			want:                      false,
+		},
+		{
+			name: "slot out of range",
+			msg: &ethpb.Attestation{
+				AggregationBits: bitfield.Bitlist{0b1010},
+				Data: &ethpb.AttestationData{
+					BeaconBlockRoot: validBlockRoot[:],
+					CommitteeIndex:  1,
+					Slot:            16,
+					Target:          &ethpb.Checkpoint{Epoch: 0},
 				},
 			},
 			topic:                     "/eth2/committee_index1_beacon_attestation",
@@ -125,6 +159,7 @@ func TestService_validateCommitteeIndexBeaconAttestation(t *testing.T) {
 					BeaconBlockRoot: validBlockRoot[:],
 					CommitteeIndex:  1,
 					Slot:            63,
+					Target:          &ethpb.Checkpoint{Epoch: 1},
 				},
 			},
 			topic:                     "/eth2/committee_index1_beacon_attestation",
@@ -44,6 +44,7 @@ var appHelpFlagGroups = []flagGroup{
 	{
 		Name: "cmd",
 		Flags: []cli.Flag{
+			cmd.ConfigFileFlag,
 			cmd.NoDiscovery,
 			cmd.BootstrapNode,
 			cmd.RelayNode,
@@ -77,33 +78,65 @@ var appHelpFlagGroups = []flagGroup{
 		Name: "beacon-chain",
 		Flags: []cli.Flag{
 			flags.NoCustomConfigFlag,
+			flags.ChainConfigFileFlag,
 			flags.InteropMockEth1DataVotesFlag,
 			flags.InteropGenesisStateFlag,
 			flags.DepositContractFlag,
+			flags.ForceEth1ConfigFlag,
 			flags.ContractDeploymentBlock,
+			flags.DepositSnapshotFlag,
+			flags.BlocksDirFlag,
 			flags.Web3ProviderFlag,
 			flags.RPCHost,
 			flags.RPCPort,
 			flags.RPCMaxPageSize,
 			flags.CertFlag,
 			flags.KeyFlag,
+			flags.ClientCAFlag,
+			flags.RPCAPIKeysFlag,
+			flags.ReadOnlyFlag,
 			flags.GRPCGatewayPort,
 			flags.HTTPWeb3ProviderFlag,
 			flags.SetGCPercent,
 			flags.UnsafeSync,
+			flags.ShuffledIndicesCacheSize,
+			flags.CommitteeAssignmentsCacheSize,
+			flags.PostStateCacheSize,
+			flags.MaxStateCacheMB,
+			flags.LateBlockParentWeight,
+			flags.OrphanedBlockRetentionEpochs,
+			flags.DBIntegrityCheckFlag,
+			flags.SlotsPerArchivedPoint,
+			flags.WatchdogStuckSlotsFlag,
+			flags.FinalityAlertEpochsFlag,
+			flags.StandbyPrimaryFlag,
+			flags.StandbyPrimaryCertFlag,
+			flags.ValidatorAccountingFlag,
+			flags.EpochSummaryWebhookURLFlag,
+			flags.TransitionDebugDirFlag,
 		},
 	},
 	{
 		Name: "p2p",
 		Flags: []cli.Flag{
 			cmd.P2PIP,
+			cmd.P2PIP6,
 			cmd.P2PHost,
+			cmd.P2PHostIP6,
 			cmd.P2PHostDNS,
 			cmd.P2PMaxPeers,
 			cmd.P2PPrivKey,
 			cmd.P2PWhitelist,
+			cmd.P2PAllowList,
+			cmd.P2PDenyList,
+			cmd.P2PGossipD,
+			cmd.P2PGossipDlo,
+			cmd.P2PGossipDhi,
+			cmd.P2PGossipHeartbeat,
+			cmd.P2POutboundBandwidthBudget,
 			cmd.StaticPeers,
 			cmd.EnableUPnPFlag,
+			cmd.MaxClockDisparityFlag,
 			cmd.P2PEncoding,
 			flags.MinSyncPeers,
 		},
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/beacon-chain/node"
+	"github.com/prysmaticlabs/prysm/beacon-chain/verifychain"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+// verifyChain replays the canonical chain stored in the beacon node database
+// and reports the first block whose computed state root does not match its
+// stored state root.
+func verifyChain(ctx *cli.Context) error {
+	log := logrus.WithField("prefix", "main")
+	if err := configureChainParams(ctx); err != nil {
+		return err
+	}
+
+	dbPath := path.Join(ctx.GlobalString(cmd.DataDirFlag.Name), node.BeaconChainDBName)
+	beaconDB, err := db.NewDB(dbPath)
+	if err != nil {
+		return errors.Wrapf(err, "could not open database at %s", dbPath)
+	}
+	defer func() {
+		if err := beaconDB.Close(); err != nil {
+			log.WithError(err).Error("Failed to close database")
+		}
+	}()
+
+	res, err := verifychain.Verify(context.Background(), beaconDB, ctx.Uint64(flags.VerifyChainStartSlotFlag.Name))
+	if err != nil {
+		return errors.Wrap(err, "could not verify chain")
+	}
+	if res.Divergence != nil {
+		log.WithFields(logrus.Fields{
+			"slot":              res.Divergence.Slot,
+			"blockRoot":         fmt.Sprintf("%#x", res.Divergence.BlockRoot),
+			"expectedStateRoot": fmt.Sprintf("%#x", res.Divergence.Expected),
+			"computedStateRoot": fmt.Sprintf("%#x", res.Divergence.Computed),
+			"blocksVerified":    res.BlocksVerified,
+		}).Error("State root divergence")
+		return errors.New(res.Divergence.String())
+	}
+	log.WithFields(logrus.Fields{
+		"startSlot":      res.StartSlot,
+		"headSlot":       res.HeadSlot,
+		"blocksVerified": res.BlocksVerified,
+	}).Info("Chain verified, all state roots match")
+	return nil
+}
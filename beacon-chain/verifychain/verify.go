@@ -0,0 +1,160 @@
+// Package verifychain replays the canonical chain stored in the beacon
+// database and checks every computed post-state root against the state
+// root committed to by the stored block.
+package verifychain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "verifychain")
+
+// Divergence describes the first block whose computed post-state root does
+// not match the state root stored in the block.
+type Divergence struct {
+	Slot      uint64
+	BlockRoot [32]byte
+	Expected  [32]byte
+	Computed  [32]byte
+}
+
+func (d *Divergence) String() string {
+	return fmt.Sprintf(
+		"state root divergence at slot %d, block root %#x: expected state root %#x, computed %#x",
+		d.Slot,
+		d.BlockRoot,
+		d.Expected,
+		d.Computed,
+	)
+}
+
+// Result of a chain verification. Divergence is nil if every replayed block
+// matched its stored state root.
+type Result struct {
+	// StartSlot is the slot of the stored state the replay started from.
+	StartSlot      uint64
+	HeadSlot       uint64
+	BlocksVerified uint64
+	Divergence     *Divergence
+}
+
+// Verify replays the canonical chain from the stored state of the latest
+// block at or before startSlot, or from the genesis state if no such state is
+// stored, up to the head block. Each block is applied without signature
+// verification and the resulting state root is compared to the state root of
+// the block. The replay stops at the first divergence.
+func Verify(ctx context.Context, beaconDB db.HeadAccessDatabase, startSlot uint64) (*Result, error) {
+	head, err := beaconDB.HeadBlock(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head block")
+	}
+	if head == nil || head.Block == nil {
+		return nil, errors.New("no head block in database")
+	}
+	root, err := ssz.HashTreeRoot(head.Block)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not hash head block")
+	}
+
+	// Walk back from the head until a block with a stored state at or before the
+	// start slot, collecting the blocks to replay.
+	var chain []*ethpb.SignedBeaconBlock
+	var chainRoots [][32]byte
+	var baseState *stateTrie.BeaconState
+	blk := head
+	for {
+		if blk.Block.Slot <= startSlot {
+			baseState, err = baseStateFor(ctx, beaconDB, blk, root)
+			if err != nil {
+				return nil, err
+			}
+			if baseState != nil {
+				break
+			}
+		}
+		if blk.Block.Slot == 0 {
+			return nil, errors.New("no genesis state in database")
+		}
+		chain = append(chain, blk)
+		chainRoots = append(chainRoots, root)
+		root = bytesutil.ToBytes32(blk.Block.ParentRoot)
+		blk, err = beaconDB.Block(ctx, root)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get block %#x", root)
+		}
+		if blk == nil || blk.Block == nil {
+			return nil, fmt.Errorf("missing block %#x in database", root)
+		}
+	}
+
+	result := &Result{
+		StartSlot: baseState.Slot(),
+		HeadSlot:  head.Block.Slot,
+	}
+	log.WithFields(logrus.Fields{
+		"startSlot": result.StartSlot,
+		"headSlot":  result.HeadSlot,
+	}).Info("Verifying chain")
+
+	st := baseState
+	for i := len(chain) - 1; i >= 0; i-- {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		blk := chain[i]
+		st, err = state.ExecuteStateTransitionNoVerifySig(ctx, st, blk)
+		if mismatch, ok := err.(*state.StateRootMismatchError); ok {
+			result.Divergence = &Divergence{
+				Slot:      blk.Block.Slot,
+				BlockRoot: chainRoots[i],
+				Expected:  mismatch.Expected,
+				Computed:  mismatch.Computed,
+			}
+			return result, nil
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not process block at slot %d", blk.Block.Slot)
+		}
+		result.BlocksVerified++
+		if helpers.IsEpochStart(blk.Block.Slot) {
+			log.WithFields(logrus.Fields{
+				"slot":     blk.Block.Slot,
+				"verified": result.BlocksVerified,
+			}).Info("Verified chain up to slot")
+		}
+	}
+	return result, nil
+}
+
+// baseStateFor returns the stored state to start the replay from at the given
+// block, or nil if there is none.
+func baseStateFor(
+	ctx context.Context, beaconDB db.ReadOnlyDatabase, blk *ethpb.SignedBeaconBlock, root [32]byte,
+) (*stateTrie.BeaconState, error) {
+	if blk.Block.Slot == 0 {
+		st, err := beaconDB.GenesisState(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get genesis state")
+		}
+		return st, nil
+	}
+	if !beaconDB.HasState(ctx, root) {
+		return nil, nil
+	}
+	st, err := beaconDB.State(ctx, root)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get state of block %#x", root)
+	}
+	return st, nil
+}
@@ -0,0 +1,173 @@
+package verifychain
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+// setupChain saves a genesis state and a chain of blocks with valid state
+// roots along with their post-states, and returns the blocks with the head last.
+func setupChain(t *testing.T, beaconDB db.Database, numBlocks uint64) []*ethpb.SignedBeaconBlock {
+	ctx := context.Background()
+	st, privs := testutil.DeterministicGenesisState(t, 64)
+	stateRoot, err := st.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesis := blocks.NewGenesisBlock(stateRoot[:])
+	genesisRoot, err := ssz.HashTreeRoot(genesis.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := beaconDB.SaveBlock(ctx, genesis); err != nil {
+		t.Fatal(err)
+	}
+	if err := beaconDB.SaveState(ctx, st, genesisRoot); err != nil {
+		t.Fatal(err)
+	}
+	if err := beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot); err != nil {
+		t.Fatal(err)
+	}
+
+	var chain []*ethpb.SignedBeaconBlock
+	for i := uint64(1); i <= numBlocks; i++ {
+		blk, err := testutil.GenerateFullBlock(st, privs, nil, i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		st, err = state.ProcessSlots(ctx, st, i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		st, err = state.ProcessBlockForStateRoot(ctx, st, blk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		postRoot, err := st.HashTreeRoot()
+		if err != nil {
+			t.Fatal(err)
+		}
+		blk.Block.StateRoot = postRoot[:]
+		if err := beaconDB.SaveBlock(ctx, blk); err != nil {
+			t.Fatal(err)
+		}
+		root, err := ssz.HashTreeRoot(blk.Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := beaconDB.SaveState(ctx, st, root); err != nil {
+			t.Fatal(err)
+		}
+		chain = append(chain, blk)
+	}
+	return chain
+}
+
+func saveHead(t *testing.T, beaconDB db.Database, blk *ethpb.SignedBeaconBlock) {
+	root, err := ssz.HashTreeRoot(blk.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := beaconDB.SaveHeadBlockRoot(context.Background(), root); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerify_NoDivergence(t *testing.T) {
+	beaconDB := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, beaconDB)
+
+	chain := setupChain(t, beaconDB, 3)
+	saveHead(t, beaconDB, chain[len(chain)-1])
+
+	res, err := Verify(context.Background(), beaconDB, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Divergence != nil {
+		t.Fatalf("Unexpected divergence: %v", res.Divergence)
+	}
+	if res.StartSlot != 0 || res.HeadSlot != 3 {
+		t.Errorf("Wanted replay from slot 0 to 3, got %d to %d", res.StartSlot, res.HeadSlot)
+	}
+	if res.BlocksVerified != 3 {
+		t.Errorf("Wanted 3 blocks verified, got %d", res.BlocksVerified)
+	}
+}
+
+func TestVerify_ReportsFirstDivergence(t *testing.T) {
+	beaconDB := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, beaconDB)
+
+	chain := setupChain(t, beaconDB, 3)
+	// Corrupt the stored state root of the block at slot 2 and link the head
+	// to it. Replay should stop at slot 2.
+	chain[1].Block.StateRoot = make([]byte, 32)
+	corruptRoot, err := ssz.HashTreeRoot(chain[1].Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain[2].Block.ParentRoot = corruptRoot[:]
+	if err := beaconDB.SaveBlocks(context.Background(), chain[1:]); err != nil {
+		t.Fatal(err)
+	}
+	headRoot, err := ssz.HashTreeRoot(chain[2].Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	headState, err := beaconDB.GenesisState(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := beaconDB.SaveState(context.Background(), headState, headRoot); err != nil {
+		t.Fatal(err)
+	}
+	saveHead(t, beaconDB, chain[2])
+
+	res, err := Verify(context.Background(), beaconDB, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Divergence == nil {
+		t.Fatal("Expected a divergence")
+	}
+	if res.Divergence.Slot != 2 {
+		t.Errorf("Wanted divergence at slot 2, got %d", res.Divergence.Slot)
+	}
+	if res.BlocksVerified != 1 {
+		t.Errorf("Wanted 1 block verified, got %d", res.BlocksVerified)
+	}
+	if res.Divergence.BlockRoot != corruptRoot {
+		t.Errorf("Wanted divergent block root %#x, got %#x", corruptRoot, res.Divergence.BlockRoot)
+	}
+}
+
+func TestVerify_FromCheckpoint(t *testing.T) {
+	beaconDB := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, beaconDB)
+
+	chain := setupChain(t, beaconDB, 3)
+	saveHead(t, beaconDB, chain[len(chain)-1])
+
+	res, err := Verify(context.Background(), beaconDB, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Divergence != nil {
+		t.Fatalf("Unexpected divergence: %v", res.Divergence)
+	}
+	if res.StartSlot != 2 {
+		t.Errorf("Wanted replay from slot 2, got %d", res.StartSlot)
+	}
+	if res.BlocksVerified != 1 {
+		t.Errorf("Wanted 1 block verified, got %d", res.BlocksVerified)
+	}
+}
@@ -0,0 +1,147 @@
+// Package watchdog defines a service which detects a stuck head, when the node does not
+// update its head while its peers advertise higher head slots, and recovers from it by
+// rotating the peers of the node and resyncing, instead of requiring a manual restart.
+package watchdog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/runutil"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "watchdog")
+
+var stuckHeadResets = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "watchdog_stuck_head_resets_total",
+	Help: "The number of times the peers were rotated and the chain resynced because of a stuck head.",
+})
+
+// Service checks every slot whether the head of the node is stuck.
+type Service struct {
+	ctx            context.Context
+	cancel         context.CancelFunc
+	headFetcher    blockchain.HeadFetcher
+	timeFetcher    blockchain.TimeFetcher
+	p2p            p2p.P2P
+	initialSync    sync.Checker
+	stuckSlots     uint64
+	lastHeadRoot   []byte
+	lastHeadChange uint64
+}
+
+// Config options for the watchdog service.
+type Config struct {
+	HeadFetcher blockchain.HeadFetcher
+	TimeFetcher blockchain.TimeFetcher
+	P2P         p2p.P2P
+	InitialSync sync.Checker
+	// StuckSlots is the number of slots without a new head after which the head is stuck.
+	StuckSlots uint64
+}
+
+// NewService initializes the service from configuration options.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		ctx:         ctx,
+		cancel:      cancel,
+		headFetcher: cfg.HeadFetcher,
+		timeFetcher: cfg.TimeFetcher,
+		p2p:         cfg.P2P,
+		initialSync: cfg.InitialSync,
+		stuckSlots:  cfg.StuckSlots,
+	}
+}
+
+// Start the watchdog service event loop.
+func (s *Service) Start() {
+	runutil.RunEvery(s.ctx, time.Duration(params.BeaconConfig().SecondsPerSlot)*time.Second, s.checkHead)
+}
+
+// Stop the watchdog service event loop.
+func (s *Service) Stop() error {
+	defer s.cancel()
+	return nil
+}
+
+// Status reports the healthy status of the watchdog service. Returning nil
+// means service is correctly running without error.
+func (s *Service) Status() error {
+	return nil
+}
+
+// checkHead tracks the slot at which the head last changed, and resets the peers and the sync of
+// the node once the head did not change for the stuck slots while connected peers advertise
+// higher head slots. A head which is not updated because no peer is ahead is left alone, as the
+// whole network is not producing blocks then.
+func (s *Service) checkHead() {
+	currentSlot := s.timeFetcher.CurrentSlot()
+	headRoot, err := s.headFetcher.HeadRoot(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not get head root")
+		return
+	}
+	if !bytes.Equal(headRoot, s.lastHeadRoot) || s.initialSync.Syncing() {
+		s.lastHeadRoot = headRoot
+		s.lastHeadChange = currentSlot
+		return
+	}
+	if currentSlot < s.lastHeadChange+s.stuckSlots {
+		return
+	}
+
+	headSlot := s.headFetcher.HeadSlot()
+	connected := s.p2p.Peers().Connected()
+	var ahead, behind []peer.ID
+	var highestPeerSlot uint64
+	for _, pid := range connected {
+		chainState, err := s.p2p.Peers().ChainState(pid)
+		if err != nil || chainState == nil || chainState.HeadSlot <= headSlot {
+			behind = append(behind, pid)
+			continue
+		}
+		ahead = append(ahead, pid)
+		if chainState.HeadSlot > highestPeerSlot {
+			highestPeerSlot = chainState.HeadSlot
+		}
+	}
+	if len(ahead) == 0 {
+		return
+	}
+
+	log.WithFields(logrus.Fields{
+		"headSlot":          headSlot,
+		"headRoot":          fmt.Sprintf("%#x", bytesutil.Trunc(headRoot)),
+		"currentSlot":       currentSlot,
+		"slotsSinceNewHead": currentSlot - s.lastHeadChange,
+		"connectedPeers":    len(connected),
+		"peersAhead":        len(ahead),
+		"highestPeerSlot":   highestPeerSlot,
+		"badPeers":          len(s.p2p.Peers().Bad()),
+	}).Warn("Head is stuck while peers are ahead; rotating peers and resyncing")
+	stuckHeadResets.Inc()
+
+	// The peers which are not ahead are disconnected, to make room for peers which can serve the
+	// blocks the node is missing.
+	for _, pid := range behind {
+		if err := s.p2p.Disconnect(pid); err != nil {
+			log.WithError(err).WithField("peer", pid).Debug("Could not disconnect peer")
+		}
+	}
+	if err := s.initialSync.Resync(); err != nil {
+		log.WithError(err).Error("Could not resync chain")
+	}
+	s.lastHeadChange = s.timeFetcher.CurrentSlot()
+}
@@ -0,0 +1,117 @@
+package watchdog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+type mockClock struct {
+	slot uint64
+}
+
+func (c *mockClock) GenesisTime() time.Time {
+	return time.Unix(0, 0)
+}
+
+func (c *mockClock) CurrentSlot() uint64 {
+	return c.slot
+}
+
+func addPeer(t *testing.T, p *peers.Status, id string, headSlot uint64) {
+	pid, err := peer.IDB58Decode(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/13000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Add(pid, addr, network.DirOutbound)
+	p.SetConnectionState(pid, peers.PeerConnected)
+	p.SetChainState(pid, &pb.Status{HeadSlot: headSlot})
+}
+
+func TestCheckHead_ResetsStuckHead(t *testing.T) {
+	st, err := stateTrie.InitializeFromProto(&pb.BeaconState{Slot: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain := &mock.ChainService{State: st, Root: []byte{'a'}}
+	clock := &mockClock{slot: 10}
+	p := p2ptest.NewTestP2P(t)
+	initialSync := &mockSync.Sync{}
+	s := NewService(context.Background(), &Config{
+		HeadFetcher: chain,
+		TimeFetcher: clock,
+		P2P:         p,
+		InitialSync: initialSync,
+		StuckSlots:  4,
+	})
+
+	// No peer is ahead, the whole network is stuck.
+	addPeer(t, p.Peers(), "16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR", 5)
+	s.checkHead()
+	clock.slot = 20
+	s.checkHead()
+	if initialSync.Resyncs != 0 {
+		t.Fatalf("Wanted no resync without peers ahead, received %d", initialSync.Resyncs)
+	}
+
+	addPeer(t, p.Peers(), "16Uiu2HAm4HgJ9N1o222xK61o7LSgToYWoAy1wNTJRkh9gLZapVAy", 50)
+	// A new head resets the stuck slots.
+	chain.Root = []byte{'b'}
+	s.checkHead()
+	clock.slot = 23
+	s.checkHead()
+	if initialSync.Resyncs != 0 {
+		t.Fatalf("Wanted no resync before the stuck slots, received %d", initialSync.Resyncs)
+	}
+	clock.slot = 24
+	s.checkHead()
+	if initialSync.Resyncs != 1 {
+		t.Fatalf("Wanted a resync of the stuck head, received %d", initialSync.Resyncs)
+	}
+	// The next reset waits for the stuck slots again.
+	clock.slot = 25
+	s.checkHead()
+	if initialSync.Resyncs != 1 {
+		t.Errorf("Wanted a single resync, received %d", initialSync.Resyncs)
+	}
+}
+
+func TestCheckHead_IgnoredWhileSyncing(t *testing.T) {
+	st, err := stateTrie.InitializeFromProto(&pb.BeaconState{Slot: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain := &mock.ChainService{State: st, Root: []byte{'a'}}
+	clock := &mockClock{slot: 10}
+	p := p2ptest.NewTestP2P(t)
+	addPeer(t, p.Peers(), "16Uiu2HAm4HgJ9N1o222xK61o7LSgToYWoAy1wNTJRkh9gLZapVAy", 50)
+	initialSync := &mockSync.Sync{IsSyncing: true}
+	s := NewService(context.Background(), &Config{
+		HeadFetcher: chain,
+		TimeFetcher: clock,
+		P2P:         p,
+		InitialSync: initialSync,
+		StuckSlots:  4,
+	})
+
+	s.checkHead()
+	clock.slot = 20
+	s.checkHead()
+	if initialSync.Resyncs != 0 {
+		t.Errorf("Wanted no resync while syncing, received %d", initialSync.Resyncs)
+	}
+}
@@ -24,6 +24,8 @@ func TestEndToEnd_DemoConfig(t *testing.T) {
 			ev.ValidatorsAreActive,
 			ev.ValidatorsParticipating,
 			ev.FinalizationOccurs,
+			ev.ValidatorsAreNotSlashed,
+			ev.NoSlashingsIncluded,
 		},
 	}
 	runEndToEndTest(t, demoConfig)
@@ -0,0 +1,79 @@
+package evaluators
+
+import (
+	"context"
+	"fmt"
+
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// ValidatorsAreNotSlashed ensures no validator has been slashed, as honest
+// validators should never commit a slashable offense.
+var ValidatorsAreNotSlashed = Evaluator{
+	Name:       "validators_not_slashed_epoch_%d",
+	Policy:     afterNthEpoch(0),
+	Evaluation: validatorsAreNotSlashed,
+}
+
+// NoSlashingsIncluded ensures no proposer or attester slashings have been
+// included in blocks of the previous epoch.
+var NoSlashingsIncluded = Evaluator{
+	Name:       "no_slashings_included_epoch_%d",
+	Policy:     afterNthEpoch(1),
+	Evaluation: noSlashingsIncluded,
+}
+
+func validatorsAreNotSlashed(client eth.BeaconChainClient) error {
+	validatorRequest := &eth.ListValidatorsRequest{
+		PageSize: int32(params.BeaconConfig().MinGenesisActiveValidatorCount),
+	}
+	validators, err := client.ListValidators(context.Background(), validatorRequest)
+	if err != nil {
+		return errors.Wrap(err, "failed to get validators")
+	}
+
+	slashedCount := 0
+	for _, item := range validators.ValidatorList {
+		if item.Validator.Slashed {
+			slashedCount++
+		}
+	}
+	if slashedCount > 0 {
+		return fmt.Errorf("%d validators were slashed", slashedCount)
+	}
+	return nil
+}
+
+func noSlashingsIncluded(client eth.BeaconChainClient) error {
+	chainHead, err := client.GetChainHead(context.Background(), &ptypes.Empty{})
+	if err != nil {
+		return errors.Wrap(err, "failed to get chain head")
+	}
+	epoch := chainHead.HeadEpoch - 1
+	blocks, err := client.ListBlocks(context.Background(), &eth.ListBlocksRequest{
+		QueryFilter: &eth.ListBlocksRequest_Epoch{Epoch: epoch},
+		PageSize:    int32(params.BeaconConfig().SlotsPerEpoch),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to get blocks")
+	}
+
+	proposerSlashings := 0
+	attesterSlashings := 0
+	for _, container := range blocks.BlockContainers {
+		proposerSlashings += len(container.Block.Block.Body.ProposerSlashings)
+		attesterSlashings += len(container.Block.Block.Body.AttesterSlashings)
+	}
+	if proposerSlashings > 0 || attesterSlashings > 0 {
+		return fmt.Errorf(
+			"expected no slashings in epoch %d, received %d proposer slashings and %d attester slashings",
+			epoch,
+			proposerSlashings,
+			attesterSlashings,
+		)
+	}
+	return nil
+}
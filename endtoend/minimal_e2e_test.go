@@ -23,6 +23,8 @@ func TestEndToEnd_MinimalConfig(t *testing.T) {
 			ev.ValidatorsAreActive,
 			ev.ValidatorsParticipating,
 			ev.FinalizationOccurs,
+			ev.ValidatorsAreNotSlashed,
+			ev.NoSlashingsIncluded,
 		},
 	}
 	runEndToEndTest(t, minimalConfig)
@@ -3,6 +3,7 @@ package bytesutil
 
 import (
 	"encoding/binary"
+	"fmt"
 )
 
 // ToBytes returns integer x to bytes in little-endian format at the specified length.
@@ -113,6 +114,18 @@ func ToBytes32(x []byte) [32]byte {
 	return y
 }
 
+// ToBytes32Checked converts a byte slice to a fix sized 32 byte array, as
+// ToBytes32 does, but returns an error instead of truncating or padding the
+// input if it is not exactly 32 bytes long.
+func ToBytes32Checked(x []byte) ([32]byte, error) {
+	var y [32]byte
+	if len(x) != len(y) {
+		return y, fmt.Errorf("wanted %d bytes, received %d", len(y), len(x))
+	}
+	copy(y[:], x)
+	return y, nil
+}
+
 // ToBytes96 is a convenience method for converting a byte slice to a fix
 // sized 96 byte array. This method will truncate the input if it is larger
 // than 96 bytes.
@@ -131,6 +144,18 @@ func ToBytes48(x []byte) [48]byte {
 	return y
 }
 
+// ToBytes48Checked converts a byte slice to a fix sized 48 byte array, as
+// ToBytes48 does, but returns an error instead of truncating or padding the
+// input if it is not exactly 48 bytes long.
+func ToBytes48Checked(x []byte) ([48]byte, error) {
+	var y [48]byte
+	if len(x) != len(y) {
+		return y, fmt.Errorf("wanted %d bytes, received %d", len(y), len(x))
+	}
+	copy(y[:], x)
+	return y, nil
+}
+
 // ToBool is a convenience method for converting a byte to a bool.
 // This method will use the first bit of the 0 byte to generate the returned value.
 func ToBool(x byte) bool {
@@ -257,3 +257,45 @@ func TestReverse(t *testing.T) {
 		}
 	}
 }
+
+func TestToBytes32Checked(t *testing.T) {
+	tests := []struct {
+		input   []byte
+		wantErr bool
+	}{
+		{bytes.Repeat([]byte{1}, 32), false},
+		{bytes.Repeat([]byte{1}, 31), true},
+		{bytes.Repeat([]byte{1}, 33), true},
+		{nil, true},
+	}
+	for _, tt := range tests {
+		b, err := bytesutil.ToBytes32Checked(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ToBytes32Checked(%d) error = %v, wantErr = %v", len(tt.input), err, tt.wantErr)
+		}
+		if !tt.wantErr && !bytes.Equal(b[:], tt.input) {
+			t.Errorf("ToBytes32Checked(%d) = %v, want = %v", len(tt.input), b, tt.input)
+		}
+	}
+}
+
+func TestToBytes48Checked(t *testing.T) {
+	tests := []struct {
+		input   []byte
+		wantErr bool
+	}{
+		{bytes.Repeat([]byte{1}, 48), false},
+		{bytes.Repeat([]byte{1}, 47), true},
+		{bytes.Repeat([]byte{1}, 96), true},
+		{nil, true},
+	}
+	for _, tt := range tests {
+		b, err := bytesutil.ToBytes48Checked(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ToBytes48Checked(%d) error = %v, wantErr = %v", len(tt.input), err, tt.wantErr)
+		}
+		if !tt.wantErr && !bytes.Equal(b[:], tt.input) {
+			t.Errorf("ToBytes48Checked(%d) = %v, want = %v", len(tt.input), b, tt.input)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+)
+
+// LoadFlagsFromConfigFile sets the flags found in the YAML file of the --config-file flag, if
+// any, on the global context. Environment variables in the file are expanded before it is
+// parsed. A flag set on the command line or by its environment variable keeps its value, and a
+// key which is not the name of one of the given flags is an error.
+func LoadFlagsFromConfigFile(ctx *cli.Context, flags []cli.Flag) error {
+	configFile := ctx.GlobalString(ConfigFileFlag.Name)
+	if configFile == "" {
+		return nil
+	}
+	enc, err := ioutil.ReadFile(configFile)
+	if err != nil {
+		return errors.Wrapf(err, "could not read config file %s", configFile)
+	}
+	values := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(os.ExpandEnv(string(enc))), &values); err != nil {
+		return errors.Wrapf(err, "could not parse config file %s", configFile)
+	}
+
+	known := make(map[string]bool)
+	for _, f := range flags {
+		for _, name := range flagNames(f) {
+			known[name] = true
+		}
+	}
+	for name, value := range values {
+		if !known[name] {
+			return fmt.Errorf("unknown flag %s in config file %s", name, configFile)
+		}
+		if name == ConfigFileFlag.Name || ctx.GlobalIsSet(name) {
+			continue
+		}
+		items, ok := value.([]interface{})
+		if !ok {
+			items = []interface{}{value}
+		}
+		for _, item := range items {
+			if err := ctx.GlobalSet(name, fmt.Sprint(item)); err != nil {
+				return errors.Wrapf(err, "could not set flag %s from config file %s", name, configFile)
+			}
+		}
+	}
+	return nil
+}
+
+// DumpConfig writes the effective value of every flag as YAML, in the format read by
+// --config-file.
+func DumpConfig(ctx *cli.Context, flags []cli.Flag, w io.Writer) error {
+	config := make(yaml.MapSlice, 0, len(flags))
+	for _, f := range flags {
+		name := flagNames(f)[0]
+		if name == ConfigFileFlag.Name {
+			continue
+		}
+		config = append(config, yaml.MapItem{Key: name, Value: flagValue(ctx, f, name)})
+	}
+	enc, err := yaml.Marshal(config)
+	if err != nil {
+		return errors.Wrap(err, "could not encode config")
+	}
+	_, err = w.Write(enc)
+	return err
+}
+
+// ConfigCommand returns the config command of an app with the given flags, whose dump
+// subcommand prints the effective configuration, with the values of the config file and
+// the command line flags applied.
+func ConfigCommand(flags []cli.Flag) cli.Command {
+	return cli.Command{
+		Name:  "config",
+		Usage: "defines useful functions for the configuration of the node",
+		Subcommands: []cli.Command{
+			{
+				Name:  "dump",
+				Usage: "prints the effective configuration as YAML, which can be used as a --config-file",
+				Action: func(ctx *cli.Context) error {
+					return DumpConfig(ctx, flags, os.Stdout)
+				},
+			},
+		},
+	}
+}
+
+// flagNames returns the names of a flag, the first one being its main name.
+func flagNames(f cli.Flag) []string {
+	parts := strings.Split(f.GetName(), ",")
+	names := make([]string, len(parts))
+	for i, part := range parts {
+		names[i] = strings.TrimSpace(part)
+	}
+	return names
+}
+
+func flagValue(ctx *cli.Context, f cli.Flag, name string) interface{} {
+	switch f.(type) {
+	case cli.BoolFlag:
+		return ctx.GlobalBool(name)
+	case cli.BoolTFlag:
+		return ctx.GlobalBoolT(name)
+	case cli.IntFlag:
+		return ctx.GlobalInt(name)
+	case cli.Int64Flag:
+		return ctx.GlobalInt64(name)
+	case cli.UintFlag:
+		return ctx.GlobalUint(name)
+	case cli.Uint64Flag:
+		return ctx.GlobalUint64(name)
+	case cli.Float64Flag:
+		return ctx.GlobalFloat64(name)
+	case cli.DurationFlag:
+		return ctx.GlobalDuration(name).String()
+	case cli.StringSliceFlag:
+		return ctx.GlobalStringSlice(name)
+	case cli.IntSliceFlag:
+		return ctx.GlobalIntSlice(name)
+	case cli.StringFlag:
+		return ctx.GlobalString(name)
+	default:
+		if value := ctx.GlobalGeneric(name); value != nil {
+			return fmt.Sprint(value)
+		}
+		return nil
+	}
+}
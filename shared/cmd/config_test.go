@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/urfave/cli"
+)
+
+var testConfigFlags = []cli.Flag{
+	ConfigFileFlag,
+	cli.StringFlag{Name: "endpoint"},
+	cli.IntFlag{Name: "port", Value: 4000},
+	cli.BoolFlag{Name: "enable"},
+	cli.DurationFlag{Name: "timeout", Value: time.Second},
+	cli.StringSliceFlag{Name: "peer"},
+}
+
+func writeConfigFile(t *testing.T, content string) string {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := path.Join(dir, "config.yaml")
+	if err := ioutil.WriteFile(file, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func runWithConfig(t *testing.T, args []string, action func(ctx *cli.Context) error) error {
+	app := cli.NewApp()
+	app.Flags = testConfigFlags
+	app.Before = func(ctx *cli.Context) error {
+		return LoadFlagsFromConfigFile(ctx, testConfigFlags)
+	}
+	app.Action = action
+	return app.Run(append([]string{"app"}, args...))
+}
+
+func TestLoadFlagsFromConfigFile(t *testing.T) {
+	if err := os.Setenv("TEST_CONFIG_HOST", "localhost"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := os.Unsetenv("TEST_CONFIG_HOST"); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	file := writeConfigFile(t, `
+endpoint: ${TEST_CONFIG_HOST}:4000
+port: 5000
+enable: true
+timeout: 3s
+peer:
+  - a
+  - b
+`)
+	called := false
+	err := runWithConfig(t, []string{"--config-file", file, "--port", "6000"}, func(ctx *cli.Context) error {
+		called = true
+		if got := ctx.GlobalString("endpoint"); got != "localhost:4000" {
+			t.Errorf("Wanted endpoint localhost:4000, received %s", got)
+		}
+		if got := ctx.GlobalInt("port"); got != 6000 {
+			t.Errorf("Wanted port of command line 6000, received %d", got)
+		}
+		if !ctx.GlobalBool("enable") {
+			t.Error("Wanted enable to be set")
+		}
+		if got := ctx.GlobalDuration("timeout"); got != 3*time.Second {
+			t.Errorf("Wanted timeout 3s, received %v", got)
+		}
+		if got := ctx.GlobalStringSlice("peer"); !reflect.DeepEqual(got, []string{"a", "b"}) {
+			t.Errorf("Wanted peers [a b], received %v", got)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !called {
+		t.Error("Expected action to be called")
+	}
+}
+
+func TestLoadFlagsFromConfigFile_UnknownFlag(t *testing.T) {
+	file := writeConfigFile(t, "unknown: 1\n")
+	err := runWithConfig(t, []string{"--config-file", file}, func(ctx *cli.Context) error {
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "unknown flag unknown") {
+		t.Errorf("Expected unknown flag error, received %v", err)
+	}
+}
+
+func TestDumpConfig(t *testing.T) {
+	file := writeConfigFile(t, "endpoint: localhost:4000\n")
+	var buf bytes.Buffer
+	err := runWithConfig(t, []string{"--config-file", file, "--peer", "a"}, func(ctx *cli.Context) error {
+		return DumpConfig(ctx, testConfigFlags, &buf)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `endpoint: localhost:4000
+port: 4000
+enable: false
+timeout: 1s
+peer:
+- a
+`
+	if buf.String() != want {
+		t.Errorf("Wanted config:\n%s\nreceived:\n%s", want, buf.String())
+	}
+
+	// The dumped config is a valid config file.
+	file = writeConfigFile(t, buf.String())
+	if err := runWithConfig(t, []string{"--config-file", file}, func(ctx *cli.Context) error {
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -2,6 +2,8 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/urfave/cli"
 )
 
@@ -94,12 +96,24 @@ var (
 		Usage: "The local ip address to listen for incoming data.",
 		Value: "",
 	}
+	// P2PIP6 defines the local IPv6 address to be used by libp2p and discovery.
+	P2PIP6 = cli.StringFlag{
+		Name:  "p2p-local-ip6",
+		Usage: "The local ipv6 address to listen for incoming data, next to the ipv4 one. Use :: to listen on all interfaces.",
+		Value: "",
+	}
 	// P2PHost defines the host IP to be used by libp2p.
 	P2PHost = cli.StringFlag{
 		Name:  "p2p-host-ip",
 		Usage: "The IP address advertised by libp2p. This may be used to advertise an external IP.",
 		Value: "",
 	}
+	// P2PHostIP6 defines the host IPv6 address to be used by libp2p and discovery.
+	P2PHostIP6 = cli.StringFlag{
+		Name:  "p2p-host-ip6",
+		Usage: "The ipv6 address advertised by libp2p and in the node record. This may be used to advertise an external ipv6 address.",
+		Value: "",
+	}
 	// P2PHostDNS defines the host DNS to be used by libp2p.
 	P2PHostDNS = cli.StringFlag{
 		Name:  "p2p-host-dns",
@@ -125,6 +139,48 @@ var (
 			"would whitelist connections to peers on your local network only. The default " +
 			"is to accept all connections.",
 	}
+	// P2PAllowList defines CIDR subnets to exclusively allow peer connections with.
+	P2PAllowList = cli.StringSliceFlag{
+		Name: "p2p-allowlist",
+		Usage: "A CIDR subnet peer connections are allowed with, checked when dialing and accepting connections. " +
+			"When set, peers outside of the allowed subnets are never connected. This flag may be used multiple times.",
+	}
+	// P2PDenyList defines CIDR subnets to deny peer connections with.
+	P2PDenyList = cli.StringSliceFlag{
+		Name: "p2p-denylist",
+		Usage: "A CIDR subnet peer connections are denied with, checked when dialing and accepting connections, " +
+			"even within an allowed subnet. This flag may be used multiple times.",
+	}
+	// P2PGossipD defines the target number of peers in the gossip mesh of a topic.
+	P2PGossipD = cli.IntFlag{
+		Name:  "p2p-gossip-d",
+		Usage: "The target number of peers in the gossip mesh of each topic. Fewer peers use less bandwidth but propagate messages slower.",
+		Value: 6,
+	}
+	// P2PGossipDlo defines the number of peers in the gossip mesh of a topic under which peers are grafted.
+	P2PGossipDlo = cli.IntFlag{
+		Name:  "p2p-gossip-dlo",
+		Usage: "The number of peers in the gossip mesh of a topic under which more peers are grafted.",
+		Value: 4,
+	}
+	// P2PGossipDhi defines the number of peers in the gossip mesh of a topic over which peers are pruned.
+	P2PGossipDhi = cli.IntFlag{
+		Name:  "p2p-gossip-dhi",
+		Usage: "The number of peers in the gossip mesh of a topic over which peers are pruned.",
+		Value: 12,
+	}
+	// P2PGossipHeartbeat defines the interval of the gossip mesh maintenance.
+	P2PGossipHeartbeat = cli.DurationFlag{
+		Name:  "p2p-gossip-heartbeat",
+		Usage: "The interval at which the gossip meshes are maintained and message IDs are gossiped.",
+		Value: time.Second,
+	}
+	// P2POutboundBandwidthBudget defines the outbound p2p bandwidth over which no new peers are accepted.
+	P2POutboundBandwidthBudget = cli.Uint64Flag{
+		Name: "p2p-outbound-bandwidth-budget",
+		Usage: "The outbound p2p bandwidth in bytes per second over which no new peer connections are accepted. " +
+			"The default of 0 is unlimited.",
+	}
 	// P2PEncoding defines the encoding format for p2p messages.
 	P2PEncoding = cli.StringFlag{
 		Name:  "p2p-encoding",
@@ -163,4 +219,15 @@ var (
 		Name:  "enable-upnp",
 		Usage: "Enable the service (Beacon chain or Validator) to use UPnP when possible.",
 	}
+	// ConfigFileFlag specifies the filepath to a YAML file of flag values.
+	ConfigFileFlag = cli.StringFlag{
+		Name:  "config-file",
+		Usage: "The filepath to a YAML file with flag values, as flag name: value. Environment variables in the file, as $VAR or ${VAR}, are expanded. Flags set on the command line take precedence",
+	}
+	// MaxClockDisparityFlag defines the maximum clock disparity tolerated when checking slot times.
+	MaxClockDisparityFlag = cli.DurationFlag{
+		Name:  "max-clock-disparity",
+		Usage: "The maximum clock disparity tolerated when checking whether a gossip message is within its voting window",
+		Value: 500 * time.Millisecond,
+	}
 )
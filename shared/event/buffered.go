@@ -0,0 +1,145 @@
+package event
+
+import (
+	"reflect"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	bufferedDelivered = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "event_feed_delivered_total",
+		Help: "The number of values delivered to a buffered feed subscriber.",
+	}, []string{"subscriber"})
+	bufferedDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "event_feed_dropped_total",
+		Help: "The number of values dropped by a buffered feed subscriber with a full buffer.",
+	}, []string{"subscriber"})
+	bufferedBlocked = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "event_feed_blocked_total",
+		Help: "The number of times a buffered feed subscriber with a full buffer blocked the feed.",
+	}, []string{"subscriber"})
+	bufferedLength = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "event_feed_buffered",
+		Help: "The number of values waiting in the buffer of a buffered feed subscriber.",
+	}, []string{"subscriber"})
+)
+
+// OverflowPolicy defines what a buffered subscription does with the values sent to the feed
+// while its buffer is full.
+type OverflowPolicy int
+
+const (
+	// DropOldest drops the oldest buffered value to make room for the new one, so that a slow
+	// subscriber never holds up the feed. Suited to subscribers only caring about the latest values.
+	DropOldest OverflowPolicy = iota
+	// Block stops receiving from the feed until the subscriber makes room in its buffer, so that
+	// every value is delivered. Sends to the feed block in the meantime.
+	Block
+)
+
+// String returns the name of the overflow policy.
+func (p OverflowPolicy) String() string {
+	switch p {
+	case DropOldest:
+		return "drop-oldest"
+	case Block:
+		return "block"
+	default:
+		return "unknown"
+	}
+}
+
+// BufferedOpts defines the buffer of a buffered subscription.
+type BufferedOpts struct {
+	// Name labels the delivery metrics of the subscription.
+	Name string
+	// Size is the number of values held for the subscriber. It must be positive.
+	Size int
+	// Policy applies when a value is sent to the feed while the buffer is full.
+	Policy OverflowPolicy
+}
+
+// SubscribeBuffered adds a channel to the feed, with a buffer of values sitting between the
+// feed and the channel. Values are delivered on the channel in order, and the overflow policy
+// decides whether a subscriber falling behind by more than the buffer size loses values or holds
+// up the feed. The channel must have the element type of the feed.
+func (f *Feed) SubscribeBuffered(channel interface{}, opts *BufferedOpts) Subscription {
+	if opts.Size <= 0 {
+		panic("event: SubscribeBuffered size must be positive")
+	}
+	out := reflect.ValueOf(channel)
+	outTyp := out.Type()
+	if outTyp.Kind() != reflect.Chan || outTyp.ChanDir()&reflect.SendDir == 0 {
+		panic(errBadChannel)
+	}
+	// The feed channel is unbuffered, so that a value is in the buffer once the send returns.
+	in := reflect.MakeChan(reflect.ChanOf(reflect.BothDir, outTyp.Elem()), 0)
+	sub := f.Subscribe(in.Interface())
+
+	delivered := bufferedDelivered.WithLabelValues(opts.Name)
+	dropped := bufferedDropped.WithLabelValues(opts.Name)
+	blocked := bufferedBlocked.WithLabelValues(opts.Name)
+	length := bufferedLength.WithLabelValues(opts.Name)
+	return NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		defer length.Set(0)
+
+		const (
+			quitCase = iota
+			errCase
+			recvCase
+			sendCase
+		)
+		cases := []reflect.SelectCase{
+			quitCase: {Dir: reflect.SelectRecv, Chan: reflect.ValueOf(quit)},
+			errCase:  {Dir: reflect.SelectRecv, Chan: reflect.ValueOf(sub.Err())},
+			recvCase: {Dir: reflect.SelectRecv, Chan: in},
+			sendCase: {Dir: reflect.SelectSend, Chan: out},
+		}
+		buf := make([]reflect.Value, 0, opts.Size)
+		for {
+			full := len(buf) == opts.Size
+			if full && opts.Policy == Block {
+				// Leaving the feed channel unread holds up the sends to the feed.
+				cases[recvCase].Chan = reflect.Value{}
+			} else {
+				cases[recvCase].Chan = in
+			}
+			if len(buf) > 0 {
+				cases[sendCase].Chan = out
+				cases[sendCase].Send = buf[0]
+			} else {
+				cases[sendCase].Chan = reflect.Value{}
+				cases[sendCase].Send = reflect.Value{}
+			}
+
+			chosen, recv, recvOK := reflect.Select(cases)
+			switch chosen {
+			case quitCase:
+				return nil
+			case errCase:
+				if !recvOK {
+					return nil
+				}
+				return recv.Interface().(error)
+			case recvCase:
+				if full {
+					buf[0] = reflect.Value{}
+					buf = buf[1:]
+					dropped.Inc()
+				}
+				buf = append(buf, recv)
+				if len(buf) == opts.Size && opts.Policy == Block {
+					blocked.Inc()
+				}
+			case sendCase:
+				buf[0] = reflect.Value{}
+				buf = buf[1:]
+				delivered.Inc()
+			}
+			length.Set(float64(len(buf)))
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package event
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFeedSubscribeBuffered_DropOldest(t *testing.T) {
+	var feed Feed
+	ch := make(chan int)
+	sub := feed.SubscribeBuffered(ch, &BufferedOpts{Name: "test-drop-oldest", Size: 2, Policy: DropOldest})
+	defer sub.Unsubscribe()
+
+	// Sends never block on a subscriber which does not read.
+	for i := 1; i <= 5; i++ {
+		if nsent := feed.Send(i); nsent != 1 {
+			t.Fatalf("Wanted value sent to 1 subscriber, sent to %d", nsent)
+		}
+	}
+	for _, want := range []int{4, 5} {
+		if got := <-ch; got != want {
+			t.Errorf("Wanted %d, received %d", want, got)
+		}
+	}
+	select {
+	case v := <-ch:
+		t.Errorf("Unexpected value %d, the older values should be dropped", v)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestFeedSubscribeBuffered_Block(t *testing.T) {
+	var feed Feed
+	ch := make(chan int)
+	sub := feed.SubscribeBuffered(ch, &BufferedOpts{Name: "test-block", Size: 2, Policy: Block})
+	defer sub.Unsubscribe()
+
+	feed.Send(1)
+	feed.Send(2)
+	sent := make(chan struct{})
+	go func() {
+		feed.Send(3)
+		close(sent)
+	}()
+	select {
+	case <-sent:
+		t.Fatal("Expected the send to block while the buffer is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if got := <-ch; got != 1 {
+		t.Errorf("Wanted 1, received %d", got)
+	}
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the send to complete once the buffer has room")
+	}
+	for _, want := range []int{2, 3} {
+		if got := <-ch; got != want {
+			t.Errorf("Wanted %d, received %d", want, got)
+		}
+	}
+}
+
+func TestFeedSubscribeBuffered_Unsubscribe(t *testing.T) {
+	var feed Feed
+	ch := make(chan int)
+	sub := feed.SubscribeBuffered(ch, &BufferedOpts{Name: "test-unsubscribe", Size: 1, Policy: Block})
+	feed.Send(1)
+	sub.Unsubscribe()
+
+	if nsent := feed.Send(2); nsent != 0 {
+		t.Errorf("Wanted no subscriber after unsubscribing, sent to %d", nsent)
+	}
+	if _, ok := <-sub.Err(); ok {
+		t.Error("Expected the error channel to be closed")
+	}
+}
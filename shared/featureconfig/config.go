@@ -43,10 +43,19 @@ type Flags struct {
 	DisableUpdateHeadPerAttestation            bool   // DisableUpdateHeadPerAttestation will disabling update head on per attestation basis.
 	EnableByteMempool                          bool   // EnaableByteMempool memory management.
 	EnableDomainDataCache                      bool   // EnableDomainDataCache caches validator calls to DomainData per epoch.
+	EnableRandaoRevealPrecompute               bool   // EnableRandaoRevealPrecompute signs the randao reveals of upcoming proposals as soon as duties are known.
 	EnableStateGenSigVerify                    bool   // EnableStateGenSigVerify verifies proposer and randao signatures during state gen.
 	CheckHeadState                             bool   // CheckHeadState checks the current headstate before retrieving the desired state from the db.
 	EnableNoise                                bool   // EnableNoise enables the beacon node to use NOISE instead of SECIO when performing a handshake with another peer.
 	DontPruneStateStartUp                      bool   // DontPruneStateStartUp disables pruning state upon beacon node start up.
+	EnableStateMutationFeed                    bool   // EnableStateMutationFeed sends validator balance and status changes of processed blocks on a feed.
+	EnableLightClientServer                    bool   // EnableLightClientServer stores and serves finalized header updates for light clients.
+	AttestationAggregationStrategy             string // AttestationAggregationStrategy selects the algorithm aggregating attestations in the pool.
+	EnableEpochBoundaryPrecompute              bool   // EnableEpochBoundaryPrecompute advances the head state to the next epoch boundary ahead of time.
+	EnableTransitionProfiling                  bool   // EnableTransitionProfiling times the stages of block and epoch processing and logs a report every epoch.
+	EnableBalanceDivergenceCheck               bool   // EnableBalanceDivergenceCheck reports the validators whose effective balance changes at the next epoch transition.
+	ForkChoiceProposerBoost                    uint64 // ForkChoiceProposerBoost is the percentage of the committee weight boosting timely blocks in fork choice.
+	ForkChoiceAttestationWeight                uint64 // ForkChoiceAttestationWeight is the percentage of the validator balance counted for attestations in fork choice.
 	// DisableForkChoice disables using LMD-GHOST fork choice to update
 	// the head of the chain based on attestations and instead accepts any valid received block
 	// as the chain head. UNSAFE, use with caution.
@@ -80,11 +89,6 @@ func Init(c *Flags) {
 func ConfigureBeaconChain(ctx *cli.Context) {
 	complainOnDeprecatedFlags(ctx)
 	cfg := &Flags{}
-	delay := ctx.GlobalUint64(customGenesisDelayFlag.Name)
-	if delay != params.BeaconConfig().MinGenesisDelay {
-		log.Warnf("Starting ETH2 with genesis delay of %d seconds", delay)
-	}
-	cfg.CustomGenesisDelay = delay
 	if ctx.GlobalBool(minimalConfigFlag.Name) {
 		log.Warn("Using minimal config")
 		cfg.MinimalConfig = true
@@ -163,6 +167,52 @@ func ConfigureBeaconChain(ctx *cli.Context) {
 		log.Warn("Not enabling state pruning upon start up")
 		cfg.DontPruneStateStartUp = true
 	}
+	if ctx.GlobalBool(enableStateMutationFeed.Name) {
+		log.Warn("Enabling validator balance and status change events from state transitions")
+		cfg.EnableStateMutationFeed = true
+	}
+	if ctx.GlobalBool(enableLightClientServer.Name) {
+		log.Warn("Enabling light client update server")
+		cfg.EnableLightClientServer = true
+	}
+	if ctx.GlobalBool(enableEpochBoundaryPrecompute.Name) {
+		log.Warn("Enabling epoch boundary state pre-computation")
+		cfg.EnableEpochBoundaryPrecompute = true
+	}
+	if ctx.GlobalBool(enableTransitionProfiling.Name) {
+		log.Warn("Enabling state transition profiling")
+		cfg.EnableTransitionProfiling = true
+	}
+	if ctx.GlobalBool(enableBalanceDivergenceCheck.Name) {
+		log.Warn("Enabling effective balance divergence check")
+		cfg.EnableBalanceDivergenceCheck = true
+	}
+	cfg.AttestationAggregationStrategy = ctx.GlobalString(attestationAggregationStrategy.Name)
+	if cfg.AttestationAggregationStrategy != attestationAggregationStrategy.Value {
+		log.WithField("strategy", cfg.AttestationAggregationStrategy).Warn("Using non-default attestation aggregation strategy")
+	}
+	cfg.ForkChoiceProposerBoost = ctx.GlobalUint64(forkChoiceProposerBoostFlag.Name)
+	cfg.ForkChoiceAttestationWeight = ctx.GlobalUint64(forkChoiceAttestationWeightFlag.Name)
+	if cfg.ForkChoiceProposerBoost != 0 || cfg.ForkChoiceAttestationWeight != forkChoiceAttestationWeightFlag.Value {
+		log.WithFields(logrus.Fields{
+			"proposerBoost":     cfg.ForkChoiceProposerBoost,
+			"attestationWeight": cfg.ForkChoiceAttestationWeight,
+		}).Warn("Using experimental fork choice weights")
+	}
+	Init(cfg)
+}
+
+// ConfigureGenesisDelay sets the genesis delay to the --custom-genesis-delay flag if it is set, or
+// to the MIN_GENESIS_DELAY of the chain config otherwise. It must be called once the chain config
+// of the node has been loaded, after ConfigureBeaconChain.
+func ConfigureGenesisDelay(ctx *cli.Context) {
+	cfg := Get()
+	delay := params.BeaconConfig().MinGenesisDelay
+	if ctx.GlobalIsSet(customGenesisDelayFlag.Name) {
+		delay = ctx.GlobalUint64(customGenesisDelayFlag.Name)
+		log.Warnf("Starting ETH2 with genesis delay of %d seconds", delay)
+	}
+	cfg.CustomGenesisDelay = delay
 	Init(cfg)
 }
 
@@ -187,6 +237,10 @@ func ConfigureValidator(ctx *cli.Context) {
 		log.Warn("Enabled domain data cache.")
 		cfg.EnableDomainDataCache = true
 	}
+	if ctx.GlobalBool(enableRandaoRevealPrecomputeFlag.Name) {
+		log.Warn("Enabled randao reveal precomputation.")
+		cfg.EnableRandaoRevealPrecompute = true
+	}
 	Init(cfg)
 }
 
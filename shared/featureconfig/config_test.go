@@ -4,6 +4,7 @@ import (
 	"flag"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/urfave/cli"
 )
 
@@ -27,3 +28,25 @@ func TestConfigureBeaconConfig(t *testing.T) {
 		t.Errorf("MinimalConfig in FeatureFlags incorrect. Wanted true, got false")
 	}
 }
+
+func TestConfigureGenesisDelay(t *testing.T) {
+	defer Init(&Flags{})
+	app := cli.NewApp()
+
+	set := flag.NewFlagSet("test", 0)
+	set.Uint64(customGenesisDelayFlag.Name, 0, "test")
+	ConfigureGenesisDelay(cli.NewContext(app, set, nil))
+	if c := Get(); c.CustomGenesisDelay != params.BeaconConfig().MinGenesisDelay {
+		t.Errorf("Wanted the chain config genesis delay %d, got %d", params.BeaconConfig().MinGenesisDelay, c.CustomGenesisDelay)
+	}
+
+	set = flag.NewFlagSet("test", 0)
+	set.Uint64(customGenesisDelayFlag.Name, 0, "test")
+	if err := set.Set(customGenesisDelayFlag.Name, "15"); err != nil {
+		t.Fatal(err)
+	}
+	ConfigureGenesisDelay(cli.NewContext(app, set, nil))
+	if c := Get(); c.CustomGenesisDelay != 15 {
+		t.Errorf("Wanted the flag genesis delay 15, got %d", c.CustomGenesisDelay)
+	}
+}
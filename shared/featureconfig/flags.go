@@ -1,7 +1,6 @@
 package featureconfig
 
 import (
-	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/urfave/cli"
 )
 
@@ -61,9 +60,8 @@ var (
 	}
 	customGenesisDelayFlag = cli.Uint64Flag{
 		Name: "custom-genesis-delay",
-		Usage: "Start the genesis event with the configured genesis delay in seconds. " +
-			"This flag should be used for local development and testing only.",
-		Value: params.BeaconConfig().MinGenesisDelay,
+		Usage: "Start the genesis event with the configured genesis delay in seconds, instead of the " +
+			"MIN_GENESIS_DELAY of the chain config. This flag should be used for local development and testing only.",
 	}
 	cacheFilteredBlockTreeFlag = cli.BoolFlag{
 		Name: "cache-filtered-block-tree",
@@ -97,6 +95,11 @@ var (
 		Usage: "Enable caching of domain data requests per epoch. This feature reduces the total " +
 			"calls to the beacon node for each assignment.",
 	}
+	enableRandaoRevealPrecomputeFlag = cli.BoolFlag{
+		Name: "enable-randao-reveal-precompute",
+		Usage: "Sign the randao reveal of upcoming block proposals as soon as the duties are known, " +
+			"keeping it in memory until the proposal. This takes the signing of the reveal off the proposal path.",
+	}
 	enableStateGenSigVerify = cli.BoolFlag{
 		Name: "enable-state-gen-sig-verify",
 		Usage: "Enable signature verification for state gen. This feature increases the cost to generate a historical state," +
@@ -115,6 +118,48 @@ var (
 		Name:  "dont-prune-state-start-up",
 		Usage: "Don't prune historical states upon start up",
 	}
+	enableStateMutationFeed = cli.BoolFlag{
+		Name: "enable-state-mutation-feed",
+		Usage: "Send validator balance, status and exit changes made while processing blocks on an event feed, " +
+			"so services can subscribe to them instead of diffing states",
+	}
+	enableLightClientServer = cli.BoolFlag{
+		Name: "enable-light-client-server",
+		Usage: "Store the finalized header updates needed by light clients and serve them over " +
+			"p2p req/resp and the monitoring port",
+	}
+	enableEpochBoundaryPrecompute = cli.BoolFlag{
+		Name: "enable-epoch-boundary-precompute",
+		Usage: "Advance the head state through the epoch transition shortly before the epoch boundary, " +
+			"so the first block of the epoch is processed without the epoch transition latency",
+	}
+	enableTransitionProfiling = cli.BoolFlag{
+		Name: "enable-transition-profiling",
+		Usage: "Time each stage of block and epoch processing, such as randao, attestations and rewards, " +
+			"and log an aggregated report of the timings every epoch",
+	}
+	enableBalanceDivergenceCheck = cli.BoolFlag{
+		Name: "enable-balance-divergence-check",
+		Usage: "Compare the balances of the active validators against their effective balances at every " +
+			"epoch boundary, and report the validators whose effective balance changes at the next epoch transition",
+	}
+	attestationAggregationStrategy = cli.StringFlag{
+		Name: "attestation-aggregation-strategy",
+		Usage: "Algorithm aggregating attestations in the pool: naive (greedy, in arrival order) or " +
+			"max_cover (largest first, better packing at a higher CPU cost)",
+		Value: "naive",
+	}
+	forkChoiceProposerBoostFlag = cli.Uint64Flag{
+		Name: "fork-choice-proposer-boost",
+		Usage: "(Experimental, for testnets) Percentage of the average committee weight added in fork choice to " +
+			"the block received before the attestation deadline of the current slot, 0 disables the boost",
+	}
+	forkChoiceAttestationWeightFlag = cli.Uint64Flag{
+		Name: "fork-choice-attestation-weight",
+		Usage: "(Experimental, for testnets) Percentage of the balance of a validator counted in fork choice " +
+			"for its latest attestation",
+		Value: 100,
+	}
 )
 
 // Deprecated flags list.
@@ -259,6 +304,7 @@ var ValidatorFlags = append(deprecatedFlags, []cli.Flag{
 	protectAttesterFlag,
 	protectProposerFlag,
 	enableDomainDataCacheFlag,
+	enableRandaoRevealPrecomputeFlag,
 }...)
 
 // E2EValidatorFlags contains a list of the validator feature flags to be tested in E2E.
@@ -266,6 +312,7 @@ var E2EValidatorFlags = []string{
 	"--protect-attester",
 	"--protect-proposer",
 	"--enable-domain-data-cache",
+	"--enable-randao-reveal-precompute",
 }
 
 // BeaconChainFlags contains a list of all the feature flags that apply to the beacon-chain client.
@@ -290,6 +337,14 @@ var BeaconChainFlags = append(deprecatedFlags, []cli.Flag{
 	checkHeadState,
 	enableNoiseHandshake,
 	dontPruneStateStartUp,
+	enableStateMutationFeed,
+	enableLightClientServer,
+	enableEpochBoundaryPrecompute,
+	enableTransitionProfiling,
+	enableBalanceDivergenceCheck,
+	attestationAggregationStrategy,
+	forkChoiceProposerBoostFlag,
+	forkChoiceAttestationWeightFlag,
 }...)
 
 // E2EBeaconChainFlags contains a list of the beacon chain feature flags to be tested in E2E.
@@ -0,0 +1,74 @@
+// Package htrutils provides hash tree root helpers for Simple Serialize
+// merkleization. Chunk pairs are hashed with the assembly backed sha256-simd
+// implementation (AVX2, AVX512 and SHA extensions where the CPU supports them),
+// and large tree layers are split across a pool of workers.
+package htrutils
+
+import (
+	"sync"
+
+	"github.com/minio/sha256-simd"
+	"github.com/prysmaticlabs/prysm/shared/mputil"
+)
+
+// parallelThreshold is the minimum number of chunk pairs in a layer before
+// hashing of that layer is spread across multiple goroutines. Below this, the
+// cost of scheduling workers outweighs the gain.
+const parallelThreshold = 4096
+
+// maxDepth is the deepest tree supported, which covers any uint64 limit.
+const maxDepth = 64
+
+// ZeroHashes holds the roots of empty subtrees, where ZeroHashes[i] is the
+// root of a subtree of depth i with only zero chunks as leaves.
+var ZeroHashes [maxDepth + 1][32]byte
+
+func init() {
+	var buf [64]byte
+	for i := 1; i <= maxDepth; i++ {
+		copy(buf[:32], ZeroHashes[i-1][:])
+		copy(buf[32:], ZeroHashes[i-1][:])
+		ZeroHashes[i] = sha256.Sum256(buf[:])
+	}
+}
+
+// HashPair returns the sha256 hash of the concatenation of two chunks.
+func HashPair(a [32]byte, b [32]byte) [32]byte {
+	var buf [64]byte
+	copy(buf[:32], a[:])
+	copy(buf[32:], b[:])
+	return sha256.Sum256(buf[:])
+}
+
+// HashLayer hashes each pair of consecutive chunks in the layer, returning
+// the parent layer. The layer must have an even number of chunks. Large layers
+// are hashed in parallel.
+func HashLayer(layer [][32]byte) [][32]byte {
+	pairs := len(layer) / 2
+	parents := make([][32]byte, pairs)
+	if pairs < parallelThreshold {
+		hashPairs(parents, layer, 0, pairs)
+		return parents
+	}
+	// Each worker writes to a disjoint range of the parents slice, so no
+	// locking or reassembly of the worker results is needed.
+	if _, err := mputil.Scatter(pairs, func(offset int, entries int, _ *sync.RWMutex) (interface{}, error) {
+		hashPairs(parents, layer, offset, entries)
+		return nil, nil
+	}); err != nil {
+		// Scatter only fails on an empty input, which is handled above.
+		hashPairs(parents, layer, 0, pairs)
+	}
+	return parents
+}
+
+// hashPairs hashes the chunk pairs in [offset, offset+entries) of the layer
+// into the matching positions of dst.
+func hashPairs(dst [][32]byte, layer [][32]byte, offset int, entries int) {
+	var buf [64]byte
+	for i := offset; i < offset+entries; i++ {
+		copy(buf[:32], layer[2*i][:])
+		copy(buf[32:], layer[2*i+1][:])
+		dst[i] = sha256.Sum256(buf[:])
+	}
+}
@@ -0,0 +1,37 @@
+package htrutils
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+)
+
+func TestZeroHashes(t *testing.T) {
+	for i := 1; i < len(ZeroHashes); i++ {
+		want := hashutil.Hash(append(ZeroHashes[i-1][:], ZeroHashes[i-1][:]...))
+		if ZeroHashes[i] != want {
+			t.Fatalf("Wrong zero hash at depth %d, wanted %#x, received %#x", i, want, ZeroHashes[i])
+		}
+	}
+}
+
+func TestHashLayer_ParallelMatchesSequential(t *testing.T) {
+	for _, pairs := range []int{1, 7, parallelThreshold - 1, parallelThreshold, 3*parallelThreshold + 5} {
+		layer := make([][32]byte, 2*pairs)
+		for i := range layer {
+			layer[i][0] = byte(i)
+			layer[i][1] = byte(i >> 8)
+			layer[i][2] = byte(i >> 16)
+		}
+		parents := HashLayer(layer)
+		if len(parents) != pairs {
+			t.Fatalf("Wanted %d parents, received %d", pairs, len(parents))
+		}
+		for i := range parents {
+			want := hashutil.Hash(append(layer[2*i][:], layer[2*i+1][:]...))
+			if parents[i] != want {
+				t.Fatalf("Wrong parent at index %d for %d pairs", i, pairs)
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package htrutils
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Depth returns the depth of a merkle tree which holds the given number of
+// leaves, that is, the base 2 logarithm of count rounded up.
+func Depth(count uint64) uint8 {
+	if count <= 1 {
+		return 0
+	}
+	depth := uint8(0)
+	for i := count - 1; i > 0; i >>= 1 {
+		depth++
+	}
+	return depth
+}
+
+// Merkleize computes the root of a merkle tree with limit leaves, where the
+// given chunks fill the leftmost leaves and the remaining leaves are zero
+// chunks. Only the non-zero portion of each layer is hashed; the zero subtrees
+// are filled in from precomputed zero hashes. This returns the same root as
+// the Simple Serialize merkleize(chunks, limit) function.
+func Merkleize(chunks [][32]byte, limit uint64) ([32]byte, error) {
+	count := uint64(len(chunks))
+	if count > limit {
+		return [32]byte{}, errors.New("merkleizing list that is too large, over limit")
+	}
+	depth := Depth(limit)
+	if count == 0 {
+		return ZeroHashes[depth], nil
+	}
+	layer := make([][32]byte, len(chunks), len(chunks)+1)
+	copy(layer, chunks)
+	for i := uint8(0); i < depth; i++ {
+		if len(layer)%2 == 1 {
+			layer = append(layer, ZeroHashes[i])
+		}
+		layer = HashLayer(layer)
+	}
+	return layer[0], nil
+}
+
+// MixInLength mixes the length of a list into its merkle root, as done when
+// computing the hash tree root of variable length lists.
+func MixInLength(root [32]byte, length uint64) [32]byte {
+	var lengthChunk [32]byte
+	binary.LittleEndian.PutUint64(lengthChunk[:8], length)
+	return HashPair(root, lengthChunk)
+}
@@ -0,0 +1,83 @@
+package htrutils
+
+import (
+	"testing"
+
+	"github.com/protolambda/zssz/htr"
+	"github.com/protolambda/zssz/merkle"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+)
+
+func TestDepth(t *testing.T) {
+	tests := []struct {
+		count uint64
+		depth uint8
+	}{
+		{count: 0, depth: 0},
+		{count: 1, depth: 0},
+		{count: 2, depth: 1},
+		{count: 3, depth: 2},
+		{count: 4, depth: 2},
+		{count: 5, depth: 3},
+		{count: 1 << 40, depth: 40},
+		{count: 1<<40 + 1, depth: 41},
+	}
+	for _, tt := range tests {
+		if got := Depth(tt.count); got != tt.depth {
+			t.Errorf("Depth(%d) = %d, wanted %d", tt.count, got, tt.depth)
+		}
+		if got := merkle.GetDepth(tt.count); got != tt.depth {
+			t.Errorf("merkle.GetDepth(%d) = %d, wanted %d", tt.count, got, tt.depth)
+		}
+	}
+}
+
+func TestMerkleize_MatchesZssz(t *testing.T) {
+	hasher := htr.HashFn(hashutil.CustomSHA256Hasher())
+	tests := []struct {
+		count uint64
+		limit uint64
+	}{
+		{count: 0, limit: 0},
+		{count: 0, limit: 16},
+		{count: 1, limit: 1},
+		{count: 1, limit: 1 << 40},
+		{count: 3, limit: 3},
+		{count: 5, limit: 8},
+		{count: 100, limit: 1 << 40},
+		{count: 3*parallelThreshold + 1, limit: 1 << 40},
+	}
+	for _, tt := range tests {
+		chunks := make([][32]byte, tt.count)
+		for i := range chunks {
+			chunks[i][0] = byte(i)
+			chunks[i][31] = byte(i >> 8)
+		}
+		want := merkle.Merkleize(hasher, tt.count, tt.limit, func(i uint64) []byte {
+			return chunks[i][:]
+		})
+		got, err := Merkleize(chunks, tt.limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("Merkleize(%d chunks, limit %d) = %#x, wanted %#x", tt.count, tt.limit, got, want)
+		}
+	}
+}
+
+func TestMerkleize_OverLimit(t *testing.T) {
+	if _, err := Merkleize(make([][32]byte, 3), 2); err == nil {
+		t.Error("Expected error when merkleizing over the limit")
+	}
+}
+
+func TestMixInLength(t *testing.T) {
+	root := [32]byte{'a'}
+	lengthChunk := make([]byte, 32)
+	lengthChunk[0] = 5
+	want := hashutil.Hash(append(root[:], lengthChunk...))
+	if got := MixInLength(root, 5); got != want {
+		t.Errorf("Wanted %#x, received %#x", want, got)
+	}
+}
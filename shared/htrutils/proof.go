@@ -0,0 +1,35 @@
+package htrutils
+
+import (
+	"errors"
+)
+
+// MerkleProof returns the sibling hashes, ordered from the leaf layer up to
+// the layer below the root, which prove the inclusion of the chunk at the
+// given index in the tree whose root is returned by Merkleize(chunks, limit).
+// The proof can be checked with trieutil.VerifyMerkleBranch.
+func MerkleProof(chunks [][32]byte, limit uint64, index uint64) ([][32]byte, error) {
+	if uint64(len(chunks)) > limit {
+		return nil, errors.New("merkleizing list that is too large, over limit")
+	}
+	if index >= limit {
+		return nil, errors.New("merkle proof index out of range")
+	}
+	depth := Depth(limit)
+	proof := make([][32]byte, depth)
+	layer := make([][32]byte, len(chunks), len(chunks)+1)
+	copy(layer, chunks)
+	for i := uint8(0); i < depth; i++ {
+		if sibling := index ^ 1; sibling < uint64(len(layer)) {
+			proof[i] = layer[sibling]
+		} else {
+			proof[i] = ZeroHashes[i]
+		}
+		if len(layer)%2 == 1 {
+			layer = append(layer, ZeroHashes[i])
+		}
+		layer = HashLayer(layer)
+		index >>= 1
+	}
+	return proof, nil
+}
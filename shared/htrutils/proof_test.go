@@ -0,0 +1,80 @@
+package htrutils
+
+import (
+	"testing"
+)
+
+func verifyProof(root [32]byte, leaf [32]byte, index uint64, proof [][32]byte) bool {
+	node := leaf
+	for _, sibling := range proof {
+		if index%2 == 1 {
+			node = HashPair(sibling, node)
+		} else {
+			node = HashPair(node, sibling)
+		}
+		index >>= 1
+	}
+	return node == root
+}
+
+func TestMerkleProof_VerifiesAgainstMerkleize(t *testing.T) {
+	tests := []struct {
+		count uint64
+		limit uint64
+	}{
+		{count: 1, limit: 1},
+		{count: 3, limit: 4},
+		{count: 5, limit: 8},
+		{count: 100, limit: 128},
+		{count: 7, limit: 1 << 40},
+	}
+	for _, tt := range tests {
+		chunks := make([][32]byte, tt.count)
+		for i := range chunks {
+			chunks[i][0] = byte(i + 1)
+		}
+		root, err := Merkleize(chunks, tt.limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := uint64(0); i < tt.count; i++ {
+			proof, err := MerkleProof(chunks, tt.limit, i)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(proof) != int(Depth(tt.limit)) {
+				t.Errorf("Wanted proof length %d, received %d", Depth(tt.limit), len(proof))
+			}
+			if !verifyProof(root, chunks[i], i, proof) {
+				t.Errorf("Proof for chunk %d of %d (limit %d) did not verify", i, tt.count, tt.limit)
+			}
+		}
+	}
+}
+
+func TestMerkleProof_EmptyLeaf(t *testing.T) {
+	chunks := make([][32]byte, 3)
+	for i := range chunks {
+		chunks[i][0] = byte(i + 1)
+	}
+	root, err := Merkleize(chunks, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, err := MerkleProof(chunks, 16, 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !verifyProof(root, [32]byte{}, 9, proof) {
+		t.Error("Proof for zero leaf did not verify")
+	}
+}
+
+func TestMerkleProof_OutOfRange(t *testing.T) {
+	if _, err := MerkleProof(make([][32]byte, 3), 4, 4); err == nil {
+		t.Error("Expected error for index beyond limit")
+	}
+	if _, err := MerkleProof(make([][32]byte, 5), 4, 0); err == nil {
+		t.Error("Expected error for chunks over limit")
+	}
+}
@@ -33,6 +33,32 @@ func GenerateGenesisState(genesisTime, numValidators uint64) (*pb.BeaconState, [
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "could not generate deposit data from keys")
 	}
+	return genesisStateFromDepositData(genesisTime, depositDataItems, depositDataRoots)
+}
+
+// GenerateGenesisStateFromDepositData creates a genesis state from a list of
+// deposit data items, such as the ones produced by a deposit tool for a
+// devnet. If a genesis time of 0 is supplied it is set to the current time.
+func GenerateGenesisStateFromDepositData(genesisTime uint64, depositDataItems []*ethpb.Deposit_Data) (*pb.BeaconState, []*ethpb.Deposit, error) {
+	if len(depositDataItems) == 0 {
+		return nil, nil, errors.New("no deposit data provided")
+	}
+	depositDataRoots := make([][]byte, len(depositDataItems))
+	for i, item := range depositDataItems {
+		root, err := ssz.HashTreeRoot(item)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "could not hash tree root deposit data item %d", i)
+		}
+		depositDataRoots[i] = root[:]
+	}
+	return genesisStateFromDepositData(genesisTime, depositDataItems, depositDataRoots)
+}
+
+func genesisStateFromDepositData(
+	genesisTime uint64,
+	depositDataItems []*ethpb.Deposit_Data,
+	depositDataRoots [][]byte,
+) (*pb.BeaconState, []*ethpb.Deposit, error) {
 	trie, err := trieutil.GenerateTrieFromItems(
 		depositDataRoots,
 		int(params.BeaconConfig().DepositContractTreeDepth),
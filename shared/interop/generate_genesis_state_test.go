@@ -3,6 +3,7 @@ package interop_test
 import (
 	"testing"
 
+	"github.com/gogo/protobuf/proto"
 	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
 	"github.com/prysmaticlabs/prysm/shared/interop"
@@ -50,3 +51,35 @@ func TestGenerateGenesisState(t *testing.T) {
 		t.Errorf("Wanted genesis time 0, received %d", genesisState.GenesisTime())
 	}
 }
+
+func TestGenerateGenesisStateFromDepositData_MatchesInteropState(t *testing.T) {
+	numValidators := uint64(16)
+	privKeys, pubKeys, err := interop.DeterministicallyGenerateKeys(0 /*startIndex*/, numValidators)
+	if err != nil {
+		t.Fatal(err)
+	}
+	depositDataItems, _, err := interop.DepositDataFromKeys(privKeys, pubKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _, err := interop.GenerateGenesisState(1, numValidators)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, deposits, err := interop.GenerateGenesisStateFromDepositData(1, depositDataItems)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uint64(len(deposits)) != numValidators {
+		t.Errorf("Wanted %d deposits, received %d", numValidators, len(deposits))
+	}
+	if !proto.Equal(got, want) {
+		t.Error("Genesis state from deposit data does not match the interop genesis state")
+	}
+}
+
+func TestGenerateGenesisStateFromDepositData_NoDeposits(t *testing.T) {
+	if _, _, err := interop.GenerateGenesisStateFromDepositData(0, nil); err == nil {
+		t.Error("Expected error when no deposit data is provided")
+	}
+}
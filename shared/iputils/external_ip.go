@@ -6,6 +6,22 @@ import (
 
 // ExternalIPv4 returns the first IPv4 available.
 func ExternalIPv4() (string, error) {
+	return externalIP(func(ip net.IP) bool {
+		return ip.To4() != nil
+	}, "127.0.0.1")
+}
+
+// ExternalIPv6 returns the first global unicast IPv6 available. Link-local addresses are skipped,
+// as they cannot be reached by peers outside the link.
+func ExternalIPv6() (string, error) {
+	return externalIP(func(ip net.IP) bool {
+		return ip.To4() == nil && ip.IsGlobalUnicast()
+	}, "::1")
+}
+
+// externalIP returns the first address of the interfaces which are up, not loopback, and
+// matched by the filter. Returns the fallback if no address matches.
+func externalIP(match func(net.IP) bool, fallback string) (string, error) {
 	ifaces, err := net.Interfaces()
 	if err != nil {
 		return "", err
@@ -29,15 +45,14 @@ func ExternalIPv4() (string, error) {
 			case *net.IPAddr:
 				ip = v.IP
 			}
-			if ip == nil || ip.IsLoopback() {
+			if ip == nil || ip.IsLoopback() || !match(ip) {
 				continue
 			}
-			ip = ip.To4()
-			if ip == nil {
-				continue // not an ipv4 address
+			if ip4 := ip.To4(); ip4 != nil {
+				return ip4.String(), nil
 			}
 			return ip.String(), nil
 		}
 	}
-	return "127.0.0.1", nil
+	return fallback, nil
 }
@@ -1,6 +1,7 @@
 package iputils_test
 
 import (
+	"net"
 	"regexp"
 	"testing"
 
@@ -22,3 +23,14 @@ func TestExternalIPv4(t *testing.T) {
 		t.Errorf("Wanted: %v, got: %v", IPv4Format, test)
 	}
 }
+
+func TestExternalIPv6(t *testing.T) {
+	test, err := iputils.ExternalIPv6()
+	if err != nil {
+		t.Errorf("Test check external ipv6 failed with %v", err)
+	}
+	ip := net.ParseIP(test)
+	if ip == nil || ip.To4() != nil {
+		t.Errorf("Wanted an ipv6 address, got: %v", test)
+	}
+}
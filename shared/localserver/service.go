@@ -0,0 +1,62 @@
+// Package localserver serves HTTP handlers which change the state of a node, such as importing
+// keys, on a listener bound to the loopback interface. Unlike the monitoring port, which is often
+// exposed to scrape metrics, these handlers can only be reached from the machine of the node.
+package localserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "localserver")
+
+// Handler represents a path and handler func to serve on the loopback interface.
+type Handler struct {
+	Path    string
+	Handler func(http.ResponseWriter, *http.Request)
+}
+
+// Service serves handlers on a port of the loopback interface.
+type Service struct {
+	server     *http.Server
+	failStatus error
+}
+
+// NewService sets up the handlers to be served at 127.0.0.1 on the given port.
+func NewService(port int64, handlers ...Handler) *Service {
+	mux := http.NewServeMux()
+	for _, h := range handlers {
+		mux.HandleFunc(h.Path, h.Handler)
+	}
+	return &Service{
+		server: &http.Server{Addr: fmt.Sprintf("127.0.0.1:%d", port), Handler: mux},
+	}
+}
+
+// Start serving the handlers.
+func (s *Service) Start() {
+	log.WithField("endpoint", s.server.Addr).Info("Serving local API")
+	go func() {
+		err := s.server.ListenAndServe()
+		if err != nil && err != http.ErrServerClosed {
+			log.Errorf("Could not listen to host:port %s: %v", s.server.Addr, err)
+			s.failStatus = err
+		}
+	}()
+}
+
+// Stop the service gracefully.
+func (s *Service) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return s.server.Shutdown(ctx)
+}
+
+// Status checks for any service failure conditions.
+func (s *Service) Status() error {
+	return s.failStatus
+}
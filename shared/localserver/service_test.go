@@ -0,0 +1,60 @@
+package localserver
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestLifecycle(t *testing.T) {
+	hook := logTest.NewGlobal()
+	s := NewService(7599, Handler{
+		Path: "/ping",
+		Handler: func(w http.ResponseWriter, _ *http.Request) {
+			// #nosec G104
+			w.Write([]byte("pong"))
+		},
+	})
+	if s.server.Addr != "127.0.0.1:7599" {
+		t.Errorf("Wanted the service to listen on the loopback interface, received %s", s.server.Addr)
+	}
+	s.Start()
+	defer func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	}()
+	testutil.AssertLogsContain(t, hook, "Serving local API")
+
+	var resp *http.Response
+	var err error
+	for i := 0; i < 10; i++ {
+		resp, err = http.Get("http://127.0.0.1:7599/ping")
+		if err == nil {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "pong" {
+		t.Errorf("Wanted pong, received %s", body)
+	}
+	if err := s.Status(); err != nil {
+		t.Errorf("Wanted a healthy status, received %v", err)
+	}
+}
@@ -79,6 +79,11 @@ type BeaconChainConfig struct {
 	DomainDeposit        [4]byte `yaml:"DOMAIN_DEPOSIT"`         // DomainDeposit defines the BLS signature domain for deposit verification.
 	DomainVoluntaryExit  [4]byte `yaml:"DOMAIN_VOLUNTARY_EXIT"`  // DomainVoluntaryExit defines the BLS signature domain for exit verification.
 
+	// Deposit contract values.
+	DepositChainID          uint64   `yaml:"DEPOSIT_CHAIN_ID"`           // DepositChainID is the chain ID of the eth1 chain of the deposit contract, not checked if zero.
+	DepositNetworkID        uint64   `yaml:"DEPOSIT_NETWORK_ID"`         // DepositNetworkID is the network ID of the eth1 chain of the deposit contract, not checked if zero.
+	DepositContractCodeHash [32]byte `yaml:"DEPOSIT_CONTRACT_CODE_HASH"` // DepositContractCodeHash is the keccak256 hash of the code of the deposit contract, not checked if zero.
+
 	// Prysm constants.
 	GweiPerEth                uint64        // GweiPerEth is the amount of gwei corresponding to 1 eth.
 	LogBlockDelay             int64         // Number of blocks to wait from the current head before processing logs from the deposit contract.
@@ -94,6 +99,15 @@ type BeaconChainConfig struct {
 	EmptySignature            [96]byte      // EmptySignature is used to represent a zeroed out BLS Signature.
 	DefaultPageSize           int           // DefaultPageSize defines the default page size for RPC server request.
 	MaxPeersToSync            int           // MaxPeersToSync describes the limit for number of peers in round robin sync.
+	SlotsPerArchivedPoint     uint64        // SlotsPerArchivedPoint defines the number of slots between the states saved as archived points.
+
+	// Networking constants.
+	GossipMaxSize uint64 `yaml:"GOSSIP_MAX_SIZE"` // GossipMaxSize is the maximum allowed size of uncompressed gossip messages.
+	MaxChunkSize  uint64 `yaml:"MAX_CHUNK_SIZE"`  // MaxChunkSize is the maximum allowed size of uncompressed req/resp chunked responses.
+
+	// Fork related values.
+	ForkVersionSchedule        map[uint64][]byte `yaml:"FORK_VERSION_SCHEDULE"`         // ForkVersionSchedule maps the epochs of scheduled forks to their fork versions.
+	ForkDigestTransitionEpochs uint64            `yaml:"FORK_DIGEST_TRANSITION_EPOCHS"` // ForkDigestTransitionEpochs is the number of epochs around a fork during which the gossip topics of both fork digests are subscribed.
 
 	// Slasher constants.
 	WeakSubjectivityPeriod    uint64 // WeakSubjectivityPeriod defines the time period expressed in number of epochs were proof of stake network should validate block headers and attestations for slashable events.
@@ -170,6 +184,10 @@ var defaultBeaconConfig = &BeaconChainConfig{
 	DomainDeposit:        bytesutil.ToBytes4(bytesutil.Bytes4(3)),
 	DomainVoluntaryExit:  bytesutil.ToBytes4(bytesutil.Bytes4(4)),
 
+	// Deposit contract values, unchecked by default and set by network specific chain config files.
+	DepositChainID:   0,
+	DepositNetworkID: 0,
+
 	// Prysm constants.
 	GweiPerEth:                1000000000,
 	LogBlockDelay:             4,
@@ -185,6 +203,15 @@ var defaultBeaconConfig = &BeaconChainConfig{
 	EmptySignature:            [96]byte{},
 	DefaultPageSize:           250,
 	MaxPeersToSync:            15,
+	SlotsPerArchivedPoint:     2048,
+
+	// Networking constants.
+	GossipMaxSize: 1 << 20, // 1 MiB
+	MaxChunkSize:  1 << 20, // 1 MiB
+
+	// Fork related values.
+	ForkVersionSchedule:        map[uint64][]byte{},
+	ForkDigestTransitionEpochs: 2,
 
 	// Slasher related values.
 	WeakSubjectivityPeriod:    54000,
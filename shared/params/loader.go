@@ -0,0 +1,76 @@
+package params
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// LoadChainConfigFile overrides the active beacon chain config with the values found in the given
+// YAML file, using the keys of the spec configs such as MIN_GENESIS_TIME or MIN_GENESIS_DELAY.
+// Values missing from the file keep their current value, so a testnet config only has to list
+// the parameters it changes. Unknown keys are ignored.
+func LoadChainConfigFile(chainConfigFileName string) error {
+	enc, err := ioutil.ReadFile(chainConfigFileName)
+	if err != nil {
+		return err
+	}
+	conf, err := chainConfigFromYAML(enc, BeaconConfig())
+	if err != nil {
+		return fmt.Errorf("could not parse chain config file %s: %v", chainConfigFileName, err)
+	}
+	OverrideBeaconConfig(conf)
+	return nil
+}
+
+// chainConfigFromYAML returns a copy of the base config with the values of the YAML document applied.
+func chainConfigFromYAML(enc []byte, base *BeaconChainConfig) (*BeaconChainConfig, error) {
+	conf := *base
+	// The struct is copied shallowly, so fresh byte fields must not alias the base config.
+	conf.GenesisForkVersion = nil
+	conf.ForkVersionSchedule = nil
+	lines, err := hexValuesToYAMLSequences(strings.Split(string(enc), "\n"))
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal([]byte(strings.Join(lines, "\n")), &conf); err != nil {
+		return nil, err
+	}
+	if conf.GenesisForkVersion == nil {
+		conf.GenesisForkVersion = base.GenesisForkVersion
+	}
+	if conf.ForkVersionSchedule == nil {
+		conf.ForkVersionSchedule = base.ForkVersionSchedule
+	}
+	return &conf, nil
+}
+
+// hexValuesToYAMLSequences rewrites hex values such as `GENESIS_FORK_VERSION: 0x00000001` into
+// sequences of bytes, which YAML can decode into the byte slices and arrays of the config.
+func hexValuesToYAMLSequences(lines []string) ([]string, error) {
+	res := make([]string, len(lines))
+	for i, line := range lines {
+		res[i] = line
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		value := strings.Trim(strings.TrimSpace(parts[1]), `'"`)
+		if !strings.HasPrefix(value, "0x") {
+			continue
+		}
+		b, err := hex.DecodeString(value[2:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex value for %s: %v", parts[0], err)
+		}
+		values := make([]string, len(b))
+		for j, v := range b {
+			values[j] = fmt.Sprintf("%d", v)
+		}
+		res[i] = fmt.Sprintf("%s: [%s]", parts[0], strings.Join(values, ", "))
+	}
+	return res, nil
+}
@@ -0,0 +1,81 @@
+package params_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestLoadChainConfigFile(t *testing.T) {
+	defer params.OverrideBeaconConfig(params.MainnetConfig())
+	params.OverrideBeaconConfig(params.MinimalSpecConfig())
+
+	dir, err := ioutil.TempDir("", "chain-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	file := filepath.Join(dir, "config.yaml")
+	enc := []byte(`# Testnet overrides
+MIN_GENESIS_TIME: 1578009600
+MIN_GENESIS_DELAY: 60
+MIN_GENESIS_ACTIVE_VALIDATOR_COUNT: 8
+GENESIS_FORK_VERSION: 0x00000001
+DOMAIN_RANDAO: 0x02000000
+UNKNOWN_PARAMETER: 5
+`)
+	if err := ioutil.WriteFile(file, enc, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := params.LoadChainConfigFile(file); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := params.BeaconConfig()
+	if cfg.MinGenesisTime != 1578009600 {
+		t.Errorf("Wanted MinGenesisTime 1578009600, got %d", cfg.MinGenesisTime)
+	}
+	if cfg.MinGenesisDelay != 60 {
+		t.Errorf("Wanted MinGenesisDelay 60, got %d", cfg.MinGenesisDelay)
+	}
+	if cfg.MinGenesisActiveValidatorCount != 8 {
+		t.Errorf("Wanted MinGenesisActiveValidatorCount 8, got %d", cfg.MinGenesisActiveValidatorCount)
+	}
+	if !bytes.Equal(cfg.GenesisForkVersion, []byte{0, 0, 0, 1}) {
+		t.Errorf("Wanted GenesisForkVersion 0x00000001, got %#x", cfg.GenesisForkVersion)
+	}
+	if cfg.DomainRandao != [4]byte{2, 0, 0, 0} {
+		t.Errorf("Wanted DomainRandao 0x02000000, got %#x", cfg.DomainRandao)
+	}
+	// Parameters missing from the file keep the values of the config being overridden.
+	if cfg.SlotsPerEpoch != params.MinimalSpecConfig().SlotsPerEpoch {
+		t.Errorf("Wanted SlotsPerEpoch %d, got %d", params.MinimalSpecConfig().SlotsPerEpoch, cfg.SlotsPerEpoch)
+	}
+}
+
+func TestLoadChainConfigFile_InvalidHex(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chain-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	file := filepath.Join(dir, "config.yaml")
+	if err := ioutil.WriteFile(file, []byte("GENESIS_FORK_VERSION: 0xzz\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := params.LoadChainConfigFile(file); err == nil {
+		t.Error("Expected an error loading a config with an invalid hex value")
+	}
+}
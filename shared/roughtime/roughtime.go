@@ -2,6 +2,7 @@
 package roughtime
 
 import (
+	"sync/atomic"
 	"time"
 
 	rt "github.com/cloudflare/roughtime"
@@ -9,12 +10,20 @@ import (
 )
 
 // offset is the difference between the system time and the time returned by
-// the roughtime server
-var offset time.Duration
+// the roughtime server, stored as an int64 duration so it can be updated
+// while the clock is being read.
+var offset int64
 
 var log = logrus.WithField("prefix", "roughtime")
 
 func init() {
+	Recalibrate()
+}
+
+// Recalibrate queries the roughtime servers and updates the offset between the
+// system time and the roughtime response. The previous offset is kept if the
+// servers could not be reached.
+func Recalibrate() {
 	t0 := time.Now()
 
 	results := rt.Do(rt.Ecosystem, rt.DefaultQueryAttempts, rt.DefaultQueryTimeout, nil)
@@ -22,11 +31,18 @@ func init() {
 	// Compute the average difference between the system's time and the
 	// Roughtime responses from the servers, rejecting responses whose radii
 	// are larger than 2 seconds.
-	var err error
-	offset, err = rt.AvgDeltaWithRadiusThresh(results, t0, 2*time.Second)
+	delta, err := rt.AvgDeltaWithRadiusThresh(results, t0, 2*time.Second)
 	if err != nil {
 		log.WithError(err).Error("Failed to calculate roughtime offset")
+		return
 	}
+	atomic.StoreInt64(&offset, int64(delta))
+}
+
+// Offset returns the difference between the system time and the roughtime
+// response. A large offset means the system clock is drifting.
+func Offset() time.Duration {
+	return time.Duration(atomic.LoadInt64(&offset))
 }
 
 // Since returns the duration since t, based on the roughtime response
@@ -41,5 +57,5 @@ func Until(t time.Time) time.Duration {
 
 // Now returns the current local time given the roughtime offset.
 func Now() time.Time {
-	return time.Now().Add(offset)
+	return time.Now().Add(Offset())
 }
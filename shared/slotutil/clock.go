@@ -0,0 +1,129 @@
+package slotutil
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultMaxClockDisparity is the maximum clock disparity tolerated by default,
+// MAXIMUM_GOSSIP_CLOCK_DISPARITY in the networking specification.
+const DefaultMaxClockDisparity = 500 * time.Millisecond
+
+// driftCheckInterval is how often the system clock is checked against roughtime.
+const driftCheckInterval = 10 * time.Minute
+
+var log = logrus.WithField("prefix", "slotutil")
+
+var maxClockDisparity = int64(DefaultMaxClockDisparity)
+
+// SetMaxClockDisparity configures the maximum clock disparity tolerated when checking
+// whether a slot has started or is still within its voting window.
+func SetMaxClockDisparity(d time.Duration) {
+	atomic.StoreInt64(&maxClockDisparity, int64(d))
+}
+
+// MaxClockDisparity returns the configured maximum clock disparity.
+func MaxClockDisparity() time.Duration {
+	return time.Duration(atomic.LoadInt64(&maxClockDisparity))
+}
+
+// CurrentSlot returns the slot at the current roughtime for the given genesis
+// time, or 0 before genesis.
+func CurrentSlot(genesis uint64) uint64 {
+	now := roughtime.Now().Unix()
+	if now < int64(genesis) {
+		return 0
+	}
+	return uint64(now-int64(genesis)) / params.BeaconConfig().SecondsPerSlot
+}
+
+// VerifySlotTime returns an error if the slot has not started yet, allowing for
+// slots starting up to tolerance in the future.
+func VerifySlotTime(genesis uint64, slot uint64, tolerance time.Duration) error {
+	slotTime := SlotStartTime(genesis, slot)
+	now := roughtime.Now()
+	if slotTime.After(now.Add(tolerance)) {
+		return fmt.Errorf("could not process slot from the future, slot time %d > current time %d", slotTime.Unix(), now.Unix())
+	}
+	return nil
+}
+
+// WithinVotingWindow returns true if messages of the given slot may still be
+// propagated at the current time, that is if the current slot is between slot
+// and slot + ATTESTATION_PROPAGATION_SLOT_RANGE, allowing for the maximum clock
+// disparity on both ends.
+func WithinVotingWindow(genesis uint64, slot uint64) bool {
+	now := roughtime.Now()
+	disparity := MaxClockDisparity()
+	start := SlotStartTime(genesis, slot)
+	end := SlotStartTime(genesis, slot+params.BeaconConfig().AttestationPropagationSlotRange+1)
+	return !now.Add(disparity).Before(start) && now.Add(-disparity).Before(end)
+}
+
+// ClockService periodically recalibrates the roughtime clock and warns when the
+// system clock drifted further than the maximum clock disparity, in which case
+// blocks and attestations may be rejected by peers.
+type ClockService struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewClockService configures the maximum clock disparity and creates a clock
+// service to monitor the drift of the system clock.
+func NewClockService(ctx context.Context, maxDisparity time.Duration) *ClockService {
+	SetMaxClockDisparity(maxDisparity)
+	ctx, cancel := context.WithCancel(ctx)
+	return &ClockService{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Start the clock drift monitoring.
+func (s *ClockService) Start() {
+	if err := s.Status(); err != nil {
+		log.WithError(err).Warn("Please check your NTP configuration")
+	}
+	go s.run()
+}
+
+// Stop the clock drift monitoring.
+func (s *ClockService) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status returns an error if the system clock drifted further than the maximum
+// clock disparity.
+func (s *ClockService) Status() error {
+	drift := roughtime.Offset()
+	if drift < 0 {
+		drift = -drift
+	}
+	if drift > MaxClockDisparity() {
+		return fmt.Errorf("system clock drift %v exceeds maximum clock disparity %v", drift, MaxClockDisparity())
+	}
+	return nil
+}
+
+func (s *ClockService) run() {
+	ticker := time.NewTicker(driftCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			roughtime.Recalibrate()
+			if err := s.Status(); err != nil {
+				log.WithError(err).Warn("Please check your NTP configuration")
+			}
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package slotutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// genesisSlotsAgo returns a genesis time such that the current time is in the
+// middle of the given slot.
+func genesisSlotsAgo(slot uint64) uint64 {
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	return uint64(time.Now().Unix()) - slot*secondsPerSlot - secondsPerSlot/2
+}
+
+func TestCurrentSlot(t *testing.T) {
+	if slot := CurrentSlot(genesisSlotsAgo(10)); slot != 10 {
+		t.Errorf("Wanted slot 10, got %d", slot)
+	}
+	if slot := CurrentSlot(uint64(time.Now().Add(time.Hour).Unix())); slot != 0 {
+		t.Errorf("Wanted slot 0 before genesis, got %d", slot)
+	}
+}
+
+func TestVerifySlotTime(t *testing.T) {
+	genesis := genesisSlotsAgo(10)
+	if err := VerifySlotTime(genesis, 10, DefaultMaxClockDisparity); err != nil {
+		t.Errorf("Unexpected error for current slot: %v", err)
+	}
+	if err := VerifySlotTime(genesis, 12, DefaultMaxClockDisparity); err == nil {
+		t.Error("Expected error for slot from the future")
+	}
+}
+
+func TestVerifySlotTime_Tolerance(t *testing.T) {
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	// Slot 5 starts in 2 seconds.
+	genesis := uint64(time.Now().Unix()) + 2 - 5*secondsPerSlot
+	if err := VerifySlotTime(genesis, 5, time.Second); err == nil {
+		t.Error("Expected error for slot starting after the tolerance")
+	}
+	if err := VerifySlotTime(genesis, 5, 3*time.Second); err != nil {
+		t.Errorf("Unexpected error for slot starting within the tolerance: %v", err)
+	}
+}
+
+func TestWithinVotingWindow(t *testing.T) {
+	rangeSlots := params.BeaconConfig().AttestationPropagationSlotRange
+	genesis := genesisSlotsAgo(rangeSlots + 10)
+	current := rangeSlots + 10
+
+	tests := []struct {
+		name string
+		slot uint64
+		want bool
+	}{
+		{name: "current slot", slot: current, want: true},
+		{name: "oldest slot in range", slot: current - rangeSlots, want: true},
+		{name: "slot too old", slot: current - rangeSlots - 1, want: false},
+		{name: "future slot", slot: current + 1, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WithinVotingWindow(genesis, tt.slot); got != tt.want {
+				t.Errorf("WithinVotingWindow(%d) = %v, want %v", tt.slot, got, tt.want)
+			}
+		})
+	}
+}
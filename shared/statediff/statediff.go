@@ -0,0 +1,99 @@
+// Package statediff compares two beacon states field by field, which helps locating the cause of a
+// consensus split against another client.
+package statediff
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Difference is a field, or an element of a list field, holding different values in two states.
+type Difference struct {
+	// Path of the field, such as Validators[12].EffectiveBalance.
+	Path string
+	A    string
+	B    string
+}
+
+// String returns the difference as "path: a != b".
+func (d *Difference) String() string {
+	return fmt.Sprintf("%s: %s != %s", d.Path, d.A, d.B)
+}
+
+// Diff compares two objects of the same type, typically *pb.BeaconState, and returns the fields
+// which differ. Struct fields and list elements are compared recursively, so a differing validator
+// is reported with the index and field which differ. Lists of different lengths are reported by
+// their length, along with the differing elements of their common prefix.
+func Diff(a, b interface{}) ([]*Difference, error) {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Type() != vb.Type() {
+		return nil, fmt.Errorf("cannot compare a %s to a %s", va.Type(), vb.Type())
+	}
+	var diffs []*Difference
+	diff(&diffs, "", va, vb)
+	return diffs, nil
+}
+
+func diff(diffs *[]*Difference, path string, a, b reflect.Value) {
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				*diffs = append(*diffs, &Difference{Path: path, A: format(a), B: format(b)})
+			}
+			return
+		}
+		diff(diffs, path, a.Elem(), b.Elem())
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			field := a.Type().Field(i)
+			// Skip unexported and protobuf internal fields.
+			if field.PkgPath != "" || strings.HasPrefix(field.Name, "XXX_") {
+				continue
+			}
+			fieldPath := field.Name
+			if path != "" {
+				fieldPath = path + "." + field.Name
+			}
+			diff(diffs, fieldPath, a.Field(i), b.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		// Byte slices such as roots and bitfields are compared as a whole.
+		if a.Type().Elem().Kind() == reflect.Uint8 {
+			if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+				*diffs = append(*diffs, &Difference{Path: path, A: format(a), B: format(b)})
+			}
+			return
+		}
+		if a.Len() != b.Len() {
+			*diffs = append(*diffs, &Difference{
+				Path: path + ".length",
+				A:    fmt.Sprintf("%d", a.Len()),
+				B:    fmt.Sprintf("%d", b.Len()),
+			})
+		}
+		for i := 0; i < a.Len() && i < b.Len(); i++ {
+			diff(diffs, fmt.Sprintf("%s[%d]", path, i), a.Index(i), b.Index(i))
+		}
+	default:
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			*diffs = append(*diffs, &Difference{Path: path, A: format(a), B: format(b)})
+		}
+	}
+}
+
+func format(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return "nil"
+		}
+		return fmt.Sprintf("%+v", v.Interface())
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return fmt.Sprintf("%#x", v.Interface())
+		}
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
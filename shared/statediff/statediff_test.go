@@ -0,0 +1,55 @@
+package statediff
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+func TestDiff(t *testing.T) {
+	a := &pb.BeaconState{
+		Slot:       10,
+		BlockRoots: [][]byte{{1}, {2}, {3}},
+		Validators: []*ethpb.Validator{
+			{EffectiveBalance: 32},
+			{EffectiveBalance: 32, Slashed: false},
+		},
+		Balances: []uint64{32, 32},
+		Fork:     &pb.Fork{Epoch: 1},
+	}
+	b := proto.Clone(a).(*pb.BeaconState)
+	if diffs, err := Diff(a, b); err != nil || len(diffs) != 0 {
+		t.Fatalf("Wanted no differences between equal states, received %v, %v", diffs, err)
+	}
+
+	b.Slot = 11
+	b.BlockRoots[1] = []byte{4}
+	b.Validators[1].Slashed = true
+	b.Balances = append(b.Balances, 1)
+	b.Fork = nil
+	diffs, err := Diff(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, d := range diffs {
+		paths = append(paths, d.Path)
+	}
+	wanted := []string{"Slot", "Fork", "BlockRoots[1]", "Validators[1].Slashed", "Balances.length"}
+	if !reflect.DeepEqual(paths, wanted) {
+		t.Fatalf("Wanted differences %v, received %v", wanted, paths)
+	}
+	if diffs[2].String() != "BlockRoots[1]: 0x02 != 0x04" {
+		t.Errorf("Unexpected difference %s", diffs[2])
+	}
+	if diffs[4].String() != "Balances.length: 2 != 3" {
+		t.Errorf("Unexpected difference %s", diffs[4])
+	}
+
+	if _, err := Diff(a, &pb.Fork{}); err == nil {
+		t.Error("Expected comparing different types to fail")
+	}
+}
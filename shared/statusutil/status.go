@@ -0,0 +1,119 @@
+// Package statusutil builds the gRPC status errors of the beacon node RPC server, carrying the
+// head slot or current epoch of the node and a retry delay as structured details, and reads them
+// back on clients.
+package statusutil
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// HeadSlotViolation is the type of the precondition failure violation whose subject is the
+	// head slot of the node at the time of the error.
+	HeadSlotViolation = "HEAD_SLOT"
+	// CurrentEpochViolation is the type of the precondition failure violation whose subject is
+	// the current epoch of the node at the time of the error.
+	CurrentEpochViolation = "CURRENT_EPOCH"
+)
+
+// Syncing returns the error of a request which can't be served while the node is syncing, with
+// a retry delay of a slot.
+func Syncing(headSlot uint64) error {
+	return withDetails(
+		codes.Unavailable,
+		"Syncing to latest head, not ready to respond",
+		HeadSlotViolation,
+		headSlot,
+		params.BeaconConfig().SecondsPerSlot,
+	)
+}
+
+// FutureEpoch returns the error of a request for an epoch after the current epoch of the node,
+// with a retry delay until the start of the requested epoch.
+func FutureEpoch(currentEpoch uint64, requestedEpoch uint64) error {
+	msg := fmt.Sprintf(
+		"Cannot retrieve information about an epoch in the future, current epoch %d, requesting %d",
+		currentEpoch,
+		requestedEpoch,
+	)
+	retry := (requestedEpoch - currentEpoch) * params.BeaconConfig().SlotsPerEpoch * params.BeaconConfig().SecondsPerSlot
+	return withDetails(codes.OutOfRange, msg, CurrentEpochViolation, currentEpoch, retry)
+}
+
+// FutureSlot returns the error of a request for a slot after the head slot of the node, with a
+// retry delay until the requested slot.
+func FutureSlot(headSlot uint64, requestedSlot uint64) error {
+	msg := fmt.Sprintf(
+		"Cannot retrieve information about a slot in the future, current slot %d, requesting %d",
+		headSlot,
+		requestedSlot,
+	)
+	retry := (requestedSlot - headSlot) * params.BeaconConfig().SecondsPerSlot
+	return withDetails(codes.OutOfRange, msg, HeadSlotViolation, headSlot, retry)
+}
+
+func withDetails(code codes.Code, msg string, violation string, value uint64, retrySeconds uint64) error {
+	st, err := status.New(code, msg).WithDetails(
+		&errdetails.PreconditionFailure{
+			Violations: []*errdetails.PreconditionFailure_Violation{{
+				Type:    violation,
+				Subject: strconv.FormatUint(value, 10),
+			}},
+		},
+		&errdetails.RetryInfo{
+			RetryDelay: &duration.Duration{Seconds: int64(retrySeconds)},
+		},
+	)
+	if err != nil {
+		return status.Error(code, msg)
+	}
+	return st.Err()
+}
+
+// HeadSlot returns the head slot of the node carried by the error, if any.
+func HeadSlot(err error) (uint64, bool) {
+	return violationValue(err, HeadSlotViolation)
+}
+
+// CurrentEpoch returns the current epoch of the node carried by the error, if any.
+func CurrentEpoch(err error) (uint64, bool) {
+	return violationValue(err, CurrentEpochViolation)
+}
+
+func violationValue(err error, violation string) (uint64, bool) {
+	for _, detail := range status.Convert(err).Details() {
+		failure, ok := detail.(*errdetails.PreconditionFailure)
+		if !ok {
+			continue
+		}
+		for _, v := range failure.Violations {
+			if v.Type != violation {
+				continue
+			}
+			value, err := strconv.ParseUint(v.Subject, 10, 64)
+			if err != nil {
+				return 0, false
+			}
+			return value, true
+		}
+	}
+	return 0, false
+}
+
+// RetryDelaySeconds returns the delay after which the request can be retried carried by the
+// error, if any.
+func RetryDelaySeconds(err error) (int64, bool) {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && info.RetryDelay != nil {
+			return info.RetryDelay.Seconds, true
+		}
+	}
+	return 0, false
+}
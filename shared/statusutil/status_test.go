@@ -0,0 +1,40 @@
+package statusutil
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFutureEpoch(t *testing.T) {
+	err := FutureEpoch(2, 4)
+	if status.Code(err) != codes.OutOfRange {
+		t.Errorf("Wanted out of range code, received %v", status.Code(err))
+	}
+	if epoch, ok := CurrentEpoch(err); !ok || epoch != 2 {
+		t.Errorf("Unexpected current epoch %d", epoch)
+	}
+	if _, ok := HeadSlot(err); ok {
+		t.Error("Expected no head slot in a future epoch error")
+	}
+	delay, ok := RetryDelaySeconds(err)
+	want := int64(2 * params.BeaconConfig().SlotsPerEpoch * params.BeaconConfig().SecondsPerSlot)
+	if !ok || delay != want {
+		t.Errorf("Wanted retry delay %d, received %d", want, delay)
+	}
+}
+
+func TestSyncing(t *testing.T) {
+	err := Syncing(10)
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("Wanted unavailable code, received %v", status.Code(err))
+	}
+	if headSlot, ok := HeadSlot(err); !ok || headSlot != 10 {
+		t.Errorf("Unexpected head slot %d", headSlot)
+	}
+	if _, ok := HeadSlot(status.Error(codes.Internal, "internal")); ok {
+		t.Error("Expected no head slot in an error without details")
+	}
+}
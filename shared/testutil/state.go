@@ -0,0 +1,126 @@
+package testutil
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// DeterministicFilledState returns a deterministic state of the deterministic validators at the
+// last slot of the epoch, as used by state and epoch processing benchmarks. The block roots,
+// state roots and randao mixes are filled, the balances vary between validators, and every
+// committee of the previous and current epoch has a pending attestation from the participation
+// rate, between 0 and 1, of its members.
+func DeterministicFilledState(t testing.TB, numValidators uint64, epoch uint64, participation float64) (*stateTrie.BeaconState, []*bls.SecretKey) {
+	genesis, privKeys := DeterministicGenesisState(t, numValidators)
+	st, err := filledState(genesis.CloneInnerState(), epoch, participation)
+	if err != nil {
+		t.Fatal(errors.Wrapf(err, "failed to fill state of %d validators", numValidators))
+	}
+	return st, privKeys
+}
+
+func filledState(st *pb.BeaconState, epoch uint64, participation float64) (*stateTrie.BeaconState, error) {
+	cfg := params.BeaconConfig()
+	st.Slot = helpers.StartSlot(epoch+1) - 1
+	for i := range st.BlockRoots {
+		st.BlockRoots[i] = deterministicRoot("block", uint64(i))
+		st.StateRoots[i] = deterministicRoot("state", uint64(i))
+	}
+	for i := range st.RandaoMixes {
+		st.RandaoMixes[i] = deterministicRoot("randao", uint64(i))
+	}
+	for i := range st.Balances {
+		st.Balances[i] = cfg.MaxEffectiveBalance - uint64(i%64)*cfg.EffectiveBalanceIncrement/8
+	}
+	if epoch > 1 {
+		justified := &ethpb.Checkpoint{Epoch: epoch - 1, Root: st.BlockRoots[helpers.StartSlot(epoch-1)%cfg.SlotsPerHistoricalRoot]}
+		st.PreviousJustifiedCheckpoint = justified
+		st.CurrentJustifiedCheckpoint = justified
+		st.FinalizedCheckpoint = &ethpb.Checkpoint{Epoch: epoch - 2, Root: st.BlockRoots[helpers.StartSlot(epoch-2)%cfg.SlotsPerHistoricalRoot]}
+		st.JustificationBits = bitfield.Bitvector4{0x03}
+	}
+
+	filled, err := stateTrie.InitializeFromProtoUnsafe(st)
+	if err != nil {
+		return nil, err
+	}
+	current, err := epochAttestations(filled, epoch, participation, filled.CurrentJustifiedCheckpoint())
+	if err != nil {
+		return nil, err
+	}
+	st.CurrentEpochAttestations = current
+	if epoch > 0 {
+		previous, err := epochAttestations(filled, epoch-1, participation, filled.PreviousJustifiedCheckpoint())
+		if err != nil {
+			return nil, err
+		}
+		st.PreviousEpochAttestations = previous
+	}
+	return stateTrie.InitializeFromProto(st)
+}
+
+// epochAttestations returns a pending attestation of every committee of the epoch up to the slot
+// of the state, with the bits of the first members of the committee set by the participation rate.
+func epochAttestations(st *stateTrie.BeaconState, epoch uint64, participation float64, source *ethpb.Checkpoint) ([]*pb.PendingAttestation, error) {
+	activeCount, err := helpers.ActiveValidatorCount(st, epoch)
+	if err != nil {
+		return nil, err
+	}
+	targetRoot, err := helpers.BlockRoot(st, epoch)
+	if err != nil {
+		return nil, err
+	}
+	committeeCount := helpers.SlotCommitteeCount(activeCount)
+	end := helpers.StartSlot(epoch + 1)
+	if end > st.Slot() {
+		end = st.Slot()
+	}
+	var atts []*pb.PendingAttestation
+	for slot := helpers.StartSlot(epoch); slot < end; slot++ {
+		blockRoot, err := helpers.BlockRootAtSlot(st, slot)
+		if err != nil {
+			return nil, err
+		}
+		for i := uint64(0); i < committeeCount; i++ {
+			committee, err := helpers.BeaconCommitteeFromState(st, slot, i)
+			if err != nil {
+				return nil, err
+			}
+			bits := bitfield.NewBitlist(uint64(len(committee)))
+			for j := 0; j < int(participation*float64(len(committee))); j++ {
+				bits.SetBitAt(uint64(j), true)
+			}
+			atts = append(atts, &pb.PendingAttestation{
+				AggregationBits: bits,
+				Data: &ethpb.AttestationData{
+					Slot:            slot,
+					CommitteeIndex:  i,
+					BeaconBlockRoot: blockRoot,
+					Source:          source,
+					Target:          &ethpb.Checkpoint{Epoch: epoch, Root: targetRoot},
+				},
+				InclusionDelay: 1,
+				ProposerIndex:  committee[0],
+			})
+		}
+	}
+	return atts, nil
+}
+
+func deterministicRoot(kind string, i uint64) []byte {
+	b := make([]byte, len(kind)+8)
+	copy(b, kind)
+	binary.LittleEndian.PutUint64(b[len(kind):], i)
+	h := hashutil.Hash(b)
+	return h[:]
+}
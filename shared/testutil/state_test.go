@@ -0,0 +1,54 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestDeterministicFilledState(t *testing.T) {
+	st, keys := DeterministicFilledState(t, 256, 3, 0.5)
+	if len(keys) != 256 {
+		t.Fatalf("Wanted 256 keys, received %d", len(keys))
+	}
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	if st.Slot() != 4*slotsPerEpoch-1 {
+		t.Errorf("Wanted the last slot of epoch 3, received %d", st.Slot())
+	}
+	previous := st.PreviousEpochAttestations()
+	current := st.CurrentEpochAttestations()
+	if uint64(len(previous)) != slotsPerEpoch || uint64(len(current)) != slotsPerEpoch-1 {
+		t.Errorf("Wanted an attestation per committee, received %d and %d", len(previous), len(current))
+	}
+	atts := append(append([]*pb.PendingAttestation{}, previous...), current...)
+	for _, att := range atts {
+		if att.AggregationBits.Count() != att.AggregationBits.Len()/2 {
+			t.Errorf("Wanted half of the committee to attest, received %d of %d", att.AggregationBits.Count(), att.AggregationBits.Len())
+		}
+		if helpers.SlotToEpoch(att.Data.Slot) != att.Data.Target.Epoch {
+			t.Errorf("Attestation of slot %d has target epoch %d", att.Data.Slot, att.Data.Target.Epoch)
+		}
+	}
+
+	// The same state is generated again.
+	again, _ := DeterministicFilledState(t, 256, 3, 0.5)
+	root, err := st.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	againRoot, err := again.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root != againRoot {
+		t.Error("Expected the state to be deterministic")
+	}
+
+	if _, err := state.ProcessEpochPrecompute(context.Background(), st); err != nil {
+		t.Fatalf("Could not process epoch of the filled state: %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+// Package tlsutil loads TLS certificates for gRPC connections, reloading them when their
+// files change so that certificates can be rotated without restarting the process.
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "tlsutil")
+
+// CertReloader serves a certificate and key pair from files, reloading them whenever
+// either file is modified.
+type CertReloader struct {
+	certPath string
+	keyPath  string
+	lock     sync.Mutex
+	cert     *tls.Certificate
+	certMod  time.Time
+	keyMod   time.Time
+}
+
+// NewCertReloader loads the certificate and key pair of the given PEM files.
+func NewCertReloader(certPath string, keyPath string) (*CertReloader, error) {
+	r := &CertReloader{certPath: certPath, keyPath: keyPath}
+	if _, err := r.Certificate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Certificate returns the certificate, reloading it first if its files were modified. The
+// previous certificate is kept if the new files can not be loaded, as they may be observed
+// halfway through a rotation.
+func (r *CertReloader) Certificate() (*tls.Certificate, error) {
+	certInfo, err := os.Stat(r.certPath)
+	if err != nil {
+		return r.fallback(errors.Wrap(err, "could not stat certificate"))
+	}
+	keyInfo, err := os.Stat(r.keyPath)
+	if err != nil {
+		return r.fallback(errors.Wrap(err, "could not stat key"))
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.cert != nil && certInfo.ModTime().Equal(r.certMod) && keyInfo.ModTime().Equal(r.keyMod) {
+		return r.cert, nil
+	}
+	cert, err := tls.LoadX509KeyPair(r.certPath, r.keyPath)
+	if err != nil {
+		if r.cert == nil {
+			return nil, errors.Wrap(err, "could not load TLS certificate")
+		}
+		log.WithError(err).Warn("Could not reload TLS certificate, keeping the previous certificate")
+		return r.cert, nil
+	}
+	if r.cert != nil {
+		log.WithField("path", r.certPath).Info("Reloaded TLS certificate")
+	}
+	r.cert = &cert
+	r.certMod = certInfo.ModTime()
+	r.keyMod = keyInfo.ModTime()
+	return r.cert, nil
+}
+
+// GetCertificate can be used as the GetCertificate function of a server tls.Config.
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return r.Certificate()
+}
+
+// GetClientCertificate can be used as the GetClientCertificate function of a client
+// tls.Config.
+func (r *CertReloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return r.Certificate()
+}
+
+func (r *CertReloader) fallback(err error) (*tls.Certificate, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.cert == nil {
+		return nil, err
+	}
+	log.WithError(err).Warn("Could not reload TLS certificate, keeping the previous certificate")
+	return r.cert, nil
+}
+
+// LoadCertPool reads a pool of PEM encoded certificate authorities from a file.
+func LoadCertPool(path string) (*x509.CertPool, error) {
+	enc, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read certificate authority")
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(enc) {
+		return nil, errors.Errorf("no certificates found in %s", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,122 @@
+package tlsutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCert writes a self-signed certificate for the common name, and its key, with the given
+// modification time.
+func writeCert(t *testing.T, certPath string, keyPath string, commonName string, modTime time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+		KeyUsage:     x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	if err := ioutil.WriteFile(certPath, certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{certPath, keyPath} {
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func commonName(t *testing.T, r *CertReloader) string {
+	cert, err := r.Certificate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed.Subject.CommonName
+}
+
+func TestCertReloader_Rotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tlsutil")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+	writeCert(t, certPath, keyPath, "first", time.Now().Add(-time.Hour))
+
+	r, err := NewCertReloader(certPath, keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := commonName(t, r); name != "first" {
+		t.Errorf("Wanted certificate %q, received %q", "first", name)
+	}
+
+	writeCert(t, certPath, keyPath, "second", time.Now())
+	if name := commonName(t, r); name != "second" {
+		t.Errorf("Wanted rotated certificate %q, received %q", "second", name)
+	}
+
+	// A certificate which does not match its key yet keeps the previous certificate.
+	if err := ioutil.WriteFile(keyPath, []byte("invalid"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if name := commonName(t, r); name != "second" {
+		t.Errorf("Wanted previous certificate %q to be kept, received %q", "second", name)
+	}
+}
+
+func TestNewCertReloader_MissingFiles(t *testing.T) {
+	if _, err := NewCertReloader("/does/not/exist.pem", "/does/not/exist.key"); err == nil {
+		t.Error("Expected missing certificate to fail to load")
+	}
+}
+
+func TestLoadCertPool(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tlsutil")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	certPath := filepath.Join(dir, "ca.pem")
+	keyPath := filepath.Join(dir, "ca.key")
+	writeCert(t, certPath, keyPath, "ca", time.Now())
+
+	if _, err := LoadCertPool(certPath); err != nil {
+		t.Error(err)
+	}
+	if _, err := LoadCertPool(keyPath); err == nil {
+		t.Error("Expected a file without certificates to be rejected")
+	}
+}
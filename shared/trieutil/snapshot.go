@@ -0,0 +1,140 @@
+package trieutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+)
+
+// DepositTreeSnapshot is a compact representation of the first deposits of a deposit
+// trie, following the format of EIP-4881. It only contains the roots of the largest
+// complete subtrees covering the deposits, which is enough to keep inserting deposits
+// and to compute proofs for the deposits after them.
+type DepositTreeSnapshot struct {
+	// Finalized are the roots of the complete subtrees, from the largest to the smallest.
+	Finalized            [][]byte `json:"finalized"`
+	DepositRoot          []byte   `json:"deposit_root"`
+	DepositCount         uint64   `json:"deposit_count"`
+	ExecutionBlockHash   []byte   `json:"execution_block_hash"`
+	ExecutionBlockHeight uint64   `json:"execution_block_height"`
+}
+
+// Snapshot returns a snapshot of the first count items of the trie. The execution block of
+// the snapshot is left for the caller to fill in.
+func (m *SparseMerkleTrie) Snapshot(count uint64) (*DepositTreeSnapshot, error) {
+	if count > uint64(len(m.originalItems)) {
+		return nil, fmt.Errorf("snapshot of %d items is larger than the trie of %d items", count, len(m.originalItems))
+	}
+	if count < m.PrunedCount() {
+		return nil, fmt.Errorf("snapshot of %d items is smaller than the %d pruned items", count, m.PrunedCount())
+	}
+	finalized := make([][]byte, 0, m.depth)
+	for i := int(m.depth) - 1; i >= 0; i-- {
+		if (count>>uint(i))&1 == 1 {
+			node := make([]byte, 32)
+			copy(node, m.branches[i][(count>>uint(i))-1])
+			finalized = append(finalized, node)
+		}
+	}
+	root := snapshotRoot(finalized, count, m.depth)
+	return &DepositTreeSnapshot{
+		Finalized:    finalized,
+		DepositRoot:  root[:],
+		DepositCount: count,
+	}, nil
+}
+
+// TrieFromSnapshot creates a trie containing the deposits of the snapshot. The items of
+// the snapshot are pruned from the trie, so that merkle proofs can only be computed for
+// the items inserted after them.
+func TrieFromSnapshot(snapshot *DepositTreeSnapshot, depth int) (*SparseMerkleTrie, error) {
+	if snapshot.DepositCount == 0 {
+		return NewTrie(depth)
+	}
+	if depth <= 0 || snapshot.DepositCount >= 1<<uint(depth) {
+		return nil, fmt.Errorf("snapshot of %d items does not fit a trie of depth %d", snapshot.DepositCount, depth)
+	}
+	count := snapshot.DepositCount
+	finalized := 0
+	layers := make([][][]byte, depth+1)
+	for i := depth - 1; i >= 0; i-- {
+		layers[i] = make([][]byte, count>>uint(i))
+		if (count>>uint(i))&1 == 1 {
+			if finalized == len(snapshot.Finalized) {
+				return nil, errors.New("snapshot has fewer finalized roots than its deposit count requires")
+			}
+			layers[i][len(layers[i])-1] = snapshot.Finalized[finalized]
+			finalized++
+		}
+	}
+	if finalized != len(snapshot.Finalized) {
+		return nil, errors.New("snapshot has more finalized roots than its deposit count requires")
+	}
+	root := snapshotRoot(snapshot.Finalized, count, uint(depth))
+	if !bytes.Equal(root[:], snapshot.DepositRoot) {
+		return nil, fmt.Errorf("snapshot deposit root %#x does not match its computed root %#x", snapshot.DepositRoot, root)
+	}
+	layers[depth] = [][]byte{snapshotNode(snapshot.Finalized, count, uint(depth))}
+	return &SparseMerkleTrie{
+		branches:      layers,
+		originalItems: make([][]byte, count),
+		depth:         uint(depth),
+	}, nil
+}
+
+// TrieFromSnapshotAndItems creates a trie containing the deposits of the snapshot followed by the
+// items, or only the items when the snapshot is nil.
+func TrieFromSnapshotAndItems(snapshot *DepositTreeSnapshot, items [][]byte, depth int) (*SparseMerkleTrie, error) {
+	if snapshot == nil {
+		return GenerateTrieFromItems(items, depth)
+	}
+	trie, err := TrieFromSnapshot(snapshot, depth)
+	if err != nil {
+		return nil, err
+	}
+	for i, item := range items {
+		trie.Insert(item, int(snapshot.DepositCount)+i)
+	}
+	return trie, nil
+}
+
+// PrunedCount returns the number of items at the start of the trie which were pruned when
+// creating it from a snapshot.
+func (m *SparseMerkleTrie) PrunedCount() uint64 {
+	return uint64(sort.Search(len(m.originalItems), func(i int) bool {
+		return len(m.originalItems[i]) > 0
+	}))
+}
+
+// snapshotNode computes the top node of the trie containing only the items of a snapshot.
+func snapshotNode(finalized [][]byte, count uint64, depth uint) []byte {
+	node := zeroHashes[0]
+	next := len(finalized) - 1
+	for i := uint(0); i < depth; i++ {
+		if (count>>i)&1 == 1 {
+			h := hashutil.Hash(append(append([]byte{}, finalized[next]...), node...))
+			node = h[:]
+			next--
+		} else {
+			h := hashutil.Hash(append(append([]byte{}, node...), zeroHashes[i]...))
+			node = h[:]
+		}
+	}
+	return node
+}
+
+// snapshotRoot computes the deposit root of the trie containing only the items of a snapshot,
+// as defined in the deposit contract.
+func snapshotRoot(finalized [][]byte, count uint64, depth uint) [32]byte {
+	var zeroBytes [32]byte
+	node := snapshotNode(finalized, count, depth)
+	enc := make([]byte, 32)
+	copy(enc, node)
+	enc = append(enc, bytesutil.Bytes8(count)...)
+	enc = append(enc, zeroBytes[:24]...)
+	return hashutil.Hash(enc)
+}
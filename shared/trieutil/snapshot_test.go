@@ -0,0 +1,95 @@
+package trieutil
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+)
+
+func TestTrieFromSnapshot_MatchesFullTrie(t *testing.T) {
+	depth := 32
+	items := make([][]byte, 23)
+	for i := range items {
+		h := hashutil.Hash([]byte{byte(i)})
+		items[i] = h[:]
+	}
+	full, err := GenerateTrieFromItems(items, depth)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, count := range []int{1, 8, 13, len(items)} {
+		snapshot, err := full.Snapshot(uint64(count))
+		if err != nil {
+			t.Fatal(err)
+		}
+		partial, err := GenerateTrieFromItems(items[:count], depth)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if root := partial.HashTreeRoot(); !bytes.Equal(snapshot.DepositRoot, root[:]) {
+			t.Errorf("Wanted deposit root %#x for %d items, received %#x", root, count, snapshot.DepositRoot)
+		}
+
+		trie, err := TrieFromSnapshot(snapshot, depth)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if trie.PrunedCount() != uint64(count) {
+			t.Errorf("Wanted %d pruned items, received %d", count, trie.PrunedCount())
+		}
+		for i := count; i < len(items); i++ {
+			trie.Insert(items[i], i)
+		}
+		if trie.HashTreeRoot() != full.HashTreeRoot() {
+			t.Errorf("Wanted root %#x after inserting from %d items, received %#x", full.HashTreeRoot(), count, trie.HashTreeRoot())
+		}
+		for i := count; i < len(items); i++ {
+			proof, err := trie.MerkleProof(i)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wanted, err := full.MerkleProof(i)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(proof, wanted) {
+				t.Errorf("Wanted proof of item %d to match the full trie", i)
+			}
+		}
+		if _, err := trie.MerkleProof(count - 1); err == nil {
+			t.Errorf("Expected proof of pruned item %d to fail", count-1)
+		}
+
+		// Snapshots can be taken again from a trie created from a snapshot.
+		again, err := trie.Snapshot(uint64(count))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(again.Finalized, snapshot.Finalized) {
+			t.Errorf("Wanted the same finalized roots when taking a snapshot of %d items again", count)
+		}
+	}
+}
+
+func TestTrieFromSnapshot_InvalidRoot(t *testing.T) {
+	items := [][]byte{{'A'}, {'B'}, {'C'}}
+	full, err := GenerateTrieFromItems(items, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot, err := full.Snapshot(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot.DepositRoot = make([]byte, 32)
+	if _, err := TrieFromSnapshot(snapshot, 32); err == nil {
+		t.Error("Expected snapshot with an invalid deposit root to be rejected")
+	}
+	snapshot.Finalized = snapshot.Finalized[1:]
+	if _, err := TrieFromSnapshot(snapshot, 32); err == nil {
+		t.Error("Expected snapshot with missing finalized roots to be rejected")
+	}
+}
@@ -132,6 +132,9 @@ func (m *SparseMerkleTrie) MerkleProof(index int) ([][]byte, error) {
 	if index >= len(leaves) {
 		return nil, fmt.Errorf("merkle index out of range in trie, max range: %d, received: %d", len(leaves), index)
 	}
+	if index < len(m.originalItems) && len(m.originalItems[index]) == 0 {
+		return nil, fmt.Errorf("merkle index %d was pruned from the trie", index)
+	}
 	proof := make([][]byte, m.depth+1)
 	for i := uint(0); i < m.depth; i++ {
 		subIndex := (merkleIndex / (1 << i)) ^ 1
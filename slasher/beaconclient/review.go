@@ -0,0 +1,206 @@
+package beaconclient
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/slasher/db/types"
+	"github.com/sirupsen/logrus"
+)
+
+// errSlashingNotPending is returned when reviewing a slashing which is not queued for review.
+var errSlashingNotPending = errors.New("no slashing pending review with this root")
+
+// PendingSlashing is a detected slashing queued for operator review, identified by the hash of
+// its encoding. Only one of the proposer and attester slashings is set.
+type PendingSlashing struct {
+	Root             string                  `json:"root"`
+	ProposerSlashing *ethpb.ProposerSlashing `json:"proposer_slashing,omitempty"`
+	AttesterSlashing *ethpb.AttesterSlashing `json:"attester_slashing,omitempty"`
+}
+
+// queueProposerSlashing saves a detected proposer slashing as pending review, unless it was
+// already queued, approved or rejected.
+func (bs *Service) queueProposerSlashing(ctx context.Context, slashing *ethpb.ProposerSlashing) error {
+	found, _, err := bs.slasherDB.HasProposerSlashing(ctx, slashing)
+	if err != nil {
+		return err
+	}
+	if found {
+		return nil
+	}
+	root, err := hashutil.HashProto(slashing)
+	if err != nil {
+		return err
+	}
+	if err := bs.slasherDB.SaveProposerSlashing(ctx, types.PendingReview, slashing); err != nil {
+		return err
+	}
+	log.WithFields(logrus.Fields{
+		"root":          fmt.Sprintf("%#x", root),
+		"proposerIndex": slashing.ProposerIndex,
+	}).Warn("Proposer slashing detected, queued for review")
+	return nil
+}
+
+// queueAttesterSlashing saves a detected attester slashing as pending review, unless it was
+// already queued, approved or rejected.
+func (bs *Service) queueAttesterSlashing(ctx context.Context, slashing *ethpb.AttesterSlashing) error {
+	found, _, err := bs.slasherDB.HasAttesterSlashing(ctx, slashing)
+	if err != nil {
+		return err
+	}
+	if found {
+		return nil
+	}
+	root, err := hashutil.HashProto(slashing)
+	if err != nil {
+		return err
+	}
+	if err := bs.slasherDB.SaveAttesterSlashing(ctx, types.PendingReview, slashing); err != nil {
+		return err
+	}
+	log.WithField("root", fmt.Sprintf("%#x", root)).Warn("Attester slashing detected, queued for review")
+	return nil
+}
+
+// PendingSlashings returns the detected slashings queued for operator review.
+func (bs *Service) PendingSlashings(ctx context.Context) ([]*PendingSlashing, error) {
+	proposerSlashings, err := bs.slasherDB.ProposalSlashingsByStatus(ctx, types.PendingReview)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve proposer slashings")
+	}
+	attesterSlashings, err := bs.slasherDB.AttesterSlashings(ctx, types.PendingReview)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve attester slashings")
+	}
+	pending := make([]*PendingSlashing, 0, len(proposerSlashings)+len(attesterSlashings))
+	for _, s := range proposerSlashings {
+		root, err := hashutil.HashProto(s)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, &PendingSlashing{Root: fmt.Sprintf("%#x", root), ProposerSlashing: s})
+	}
+	for _, s := range attesterSlashings {
+		root, err := hashutil.HashProto(s)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, &PendingSlashing{Root: fmt.Sprintf("%#x", root), AttesterSlashing: s})
+	}
+	return pending, nil
+}
+
+// pendingSlashing returns the slashing pending review with the root.
+func (bs *Service) pendingSlashing(ctx context.Context, root [32]byte) (*PendingSlashing, error) {
+	pending, err := bs.PendingSlashings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pending {
+		if p.Root == fmt.Sprintf("%#x", root) {
+			return p, nil
+		}
+	}
+	return nil, errSlashingNotPending
+}
+
+// ApproveSlashing submits the slashing pending review with the root to the beacon node, and
+// marks it as active.
+func (bs *Service) ApproveSlashing(ctx context.Context, root [32]byte) error {
+	p, err := bs.pendingSlashing(ctx, root)
+	if err != nil {
+		return err
+	}
+	if p.ProposerSlashing != nil {
+		if _, err := bs.beaconClient.SubmitProposerSlashing(ctx, p.ProposerSlashing); err != nil {
+			return errors.Wrap(err, "could not submit proposer slashing")
+		}
+		err = bs.slasherDB.SaveProposerSlashing(ctx, types.Active, p.ProposerSlashing)
+	} else {
+		if _, err := bs.beaconClient.SubmitAttesterSlashing(ctx, p.AttesterSlashing); err != nil {
+			return errors.Wrap(err, "could not submit attester slashing")
+		}
+		err = bs.slasherDB.SaveAttesterSlashing(ctx, types.Active, p.AttesterSlashing)
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not save approved slashing")
+	}
+	log.WithField("root", p.Root).Info("Approved slashing submitted to beacon node")
+	return nil
+}
+
+// RejectSlashing marks the slashing pending review with the root as rejected, so it is never
+// submitted nor queued again.
+func (bs *Service) RejectSlashing(ctx context.Context, root [32]byte) error {
+	p, err := bs.pendingSlashing(ctx, root)
+	if err != nil {
+		return err
+	}
+	if p.ProposerSlashing != nil {
+		err = bs.slasherDB.SaveProposerSlashing(ctx, types.Rejected, p.ProposerSlashing)
+	} else {
+		err = bs.slasherDB.SaveAttesterSlashing(ctx, types.Rejected, p.AttesterSlashing)
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not save rejected slashing")
+	}
+	log.WithField("root", p.Root).Info("Rejected slashing")
+	return nil
+}
+
+// ReviewSlashingsHandler is a handler to serve the /slashings/review page. As it is not
+// authenticated, it must only be served on the loopback interface. A GET
+// request lists the slashings pending review as JSON, and a POST request approves or rejects
+// the slashing of the hex encoded root query parameter, with the action query parameter set to
+// approve or reject.
+func (bs *Service) ReviewSlashingsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		pending, err := bs.PendingSlashings(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(pending); err != nil {
+			log.WithError(err).Error("Failed to write pending slashings")
+		}
+	case http.MethodPost:
+		enc, err := hex.DecodeString(strings.TrimPrefix(r.URL.Query().Get("root"), "0x"))
+		if err != nil || len(enc) != 32 {
+			http.Error(w, "Invalid root parameter", http.StatusBadRequest)
+			return
+		}
+		root := bytesutil.ToBytes32(enc)
+		switch action := r.URL.Query().Get("action"); action {
+		case "approve":
+			err = bs.ApproveSlashing(r.Context(), root)
+		case "reject":
+			err = bs.RejectSlashing(r.Context(), root)
+		default:
+			http.Error(w, fmt.Sprintf("Unknown action %s, wanted approve or reject", action), http.StatusBadRequest)
+			return
+		}
+		if err == errSlashingNotPending {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
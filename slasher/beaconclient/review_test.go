@@ -0,0 +1,104 @@
+package beaconclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/mock"
+	testDB "github.com/prysmaticlabs/prysm/slasher/db/testing"
+	"github.com/prysmaticlabs/prysm/slasher/db/types"
+)
+
+func TestService_ReviewSlashings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconChainClient(ctrl)
+	db := testDB.SetupSlasherDB(t, false)
+	defer testDB.TeardownSlasherDB(t, db)
+	ctx := context.Background()
+
+	bs := Service{
+		beaconClient:    client,
+		slasherDB:       db,
+		reviewSlashings: true,
+	}
+	proposerSlashing := &ethpb.ProposerSlashing{
+		ProposerIndex: 5,
+		Header_1: &ethpb.SignedBeaconBlockHeader{
+			Header:    &ethpb.BeaconBlockHeader{Slot: 5},
+			Signature: make([]byte, 96),
+		},
+		Header_2: &ethpb.SignedBeaconBlockHeader{
+			Header:    &ethpb.BeaconBlockHeader{Slot: 5, StateRoot: []byte{1}},
+			Signature: make([]byte, 96),
+		},
+	}
+	attesterSlashing := &ethpb.AttesterSlashing{
+		Attestation_1: &ethpb.IndexedAttestation{
+			AttestingIndices: []uint64{1, 2},
+			Data:             &ethpb.AttestationData{Slot: 1, Target: &ethpb.Checkpoint{Epoch: 1}},
+		},
+		Attestation_2: &ethpb.IndexedAttestation{
+			AttestingIndices: []uint64{2, 3},
+			Data:             &ethpb.AttestationData{Slot: 2, Target: &ethpb.Checkpoint{Epoch: 1}},
+		},
+	}
+	if err := bs.queueProposerSlashing(ctx, proposerSlashing); err != nil {
+		t.Fatal(err)
+	}
+	if err := bs.queueAttesterSlashing(ctx, attesterSlashing); err != nil {
+		t.Fatal(err)
+	}
+	pending, err := bs.PendingSlashings(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 2 {
+		t.Fatalf("Wanted 2 slashings pending review, got %d", len(pending))
+	}
+
+	// Approving submits the slashing to the beacon node.
+	client.EXPECT().SubmitProposerSlashing(gomock.Any(), proposerSlashing)
+	req := httptest.NewRequest(http.MethodPost, "/slashings/review?action=approve&root="+pending[0].Root, nil)
+	rec := httptest.NewRecorder()
+	bs.ReviewSlashingsHandler(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Wanted status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if _, status, err := db.HasProposerSlashing(ctx, proposerSlashing); err != nil || status != types.Active {
+		t.Errorf("Wanted approved slashing to be active, got %v: %v", status, err)
+	}
+
+	// Rejecting never submits the slashing, even when detected again.
+	req = httptest.NewRequest(http.MethodPost, "/slashings/review?action=reject&root="+pending[1].Root, nil)
+	rec = httptest.NewRecorder()
+	bs.ReviewSlashingsHandler(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Wanted status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if err := bs.queueAttesterSlashing(ctx, attesterSlashing); err != nil {
+		t.Fatal(err)
+	}
+	if _, status, err := db.HasAttesterSlashing(ctx, attesterSlashing); err != nil || status != types.Rejected {
+		t.Errorf("Wanted rejected slashing to stay rejected, got %v: %v", status, err)
+	}
+	pending, err = bs.PendingSlashings(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("Wanted no slashing pending review, got %d", len(pending))
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/slashings/review?action=approve&root=0x"+strings.Repeat("00", 32), nil)
+	rec = httptest.NewRecorder()
+	bs.ReviewSlashingsHandler(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Wanted status %d for an unknown slashing, got %d", http.StatusNotFound, rec.Code)
+	}
+}
@@ -54,6 +54,7 @@ type Service struct {
 	attesterSlashingsChan chan *ethpb.AttesterSlashing
 	attesterSlashingsFeed *event.Feed
 	proposerSlashingsFeed *event.Feed
+	reviewSlashings       bool
 }
 
 // Config options for the beaconclient service.
@@ -63,6 +64,8 @@ type Config struct {
 	SlasherDB             db.Database
 	ProposerSlashingsFeed *event.Feed
 	AttesterSlashingsFeed *event.Feed
+	// ReviewSlashings queues detected slashings for operator review instead of submitting them.
+	ReviewSlashings bool
 }
 
 // NewBeaconClientService instantiation.
@@ -81,6 +84,7 @@ func NewBeaconClientService(ctx context.Context, cfg *Config) *Service {
 		attesterSlashingsChan: make(chan *ethpb.AttesterSlashing, 1),
 		attesterSlashingsFeed: cfg.AttesterSlashingsFeed,
 		proposerSlashingsFeed: cfg.ProposerSlashingsFeed,
+		reviewSlashings:       cfg.ReviewSlashings,
 	}
 }
 
@@ -19,6 +19,12 @@ func (bs *Service) subscribeDetectedProposerSlashings(ctx context.Context, ch ch
 	for {
 		select {
 		case slashing := <-ch:
+			if bs.reviewSlashings {
+				if err := bs.queueProposerSlashing(ctx, slashing); err != nil {
+					log.WithError(err).Error("Could not queue proposer slashing for review")
+				}
+				continue
+			}
 			if _, err := bs.beaconClient.SubmitProposerSlashing(ctx, slashing); err != nil {
 				log.Error(err)
 			}
@@ -44,6 +50,12 @@ func (bs *Service) subscribeDetectedAttesterSlashings(ctx context.Context, ch ch
 	for {
 		select {
 		case slashing := <-ch:
+			if bs.reviewSlashings {
+				if err := bs.queueAttesterSlashing(ctx, slashing); err != nil {
+					log.WithError(err).Error("Could not queue attester slashing for review")
+				}
+				continue
+			}
 			if _, err := bs.beaconClient.SubmitAttesterSlashing(ctx, slashing); err != nil {
 				log.Error(err)
 			}
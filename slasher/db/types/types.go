@@ -12,6 +12,10 @@ const (
 	Included
 	// Reverted slashing proof that has been reverted and therefore is relevant again.
 	Reverted //relevant again
+	// PendingReview slashing proof that is queued for operator review before being submitted.
+	PendingReview
+	// Rejected slashing proof that has been rejected by the operator and is never submitted.
+	Rejected
 )
 
 func (status SlashingStatus) String() string {
@@ -19,9 +23,11 @@ func (status SlashingStatus) String() string {
 		"Unknown",
 		"Active",
 		"Included",
-		"Reverted"}
+		"Reverted",
+		"PendingReview",
+		"Rejected"}
 
-	if status < Active || status > Reverted {
+	if status < Active || status > Rejected {
 		return "Unknown"
 	}
 	// return the name of a SlashingStatus
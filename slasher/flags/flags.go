@@ -37,6 +37,18 @@ var (
 		Name:  "span-map-cache",
 		Usage: "Enable span map cache",
 	}
+	// ReviewSlashingsFlag queues detected slashings for operator review instead of submitting them.
+	ReviewSlashingsFlag = cli.BoolFlag{
+		Name: "review-slashings",
+		Usage: "Queue detected slashings for operator review instead of submitting them to the beacon node. " +
+			"Queued slashings are listed, approved and rejected at /slashings/review on 127.0.0.1 at the review port",
+	}
+	// ReviewPortFlag defines the port of the loopback interface the review of slashings is served on.
+	ReviewPortFlag = cli.Int64Flag{
+		Name:  "review-port",
+		Usage: "Port of 127.0.0.1 the review of slashings is served on. It is never served on other interfaces",
+		Value: 8282,
+	}
 	// RebuildSpanMapsFlag iterate through all indexed attestations in db and update all validators span maps from scratch.
 	RebuildSpanMapsFlag = cli.BoolFlag{
 		Name:  "rebuild-span-maps",
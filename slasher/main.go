@@ -57,6 +57,8 @@ var appFlags = []cli.Flag{
 	flags.KeyFlag,
 	flags.UseSpanCacheFlag,
 	flags.RebuildSpanMapsFlag,
+	flags.ReviewSlashingsFlag,
+	flags.ReviewPortFlag,
 	flags.BeaconCertFlag,
 	flags.BeaconRPCProviderFlag,
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/cmd"
 	"github.com/prysmaticlabs/prysm/shared/debug"
 	"github.com/prysmaticlabs/prysm/shared/event"
+	"github.com/prysmaticlabs/prysm/shared/localserver"
 	"github.com/prysmaticlabs/prysm/shared/tracing"
 	"github.com/prysmaticlabs/prysm/slasher/beaconclient"
 	"github.com/prysmaticlabs/prysm/slasher/db"
@@ -73,6 +74,12 @@ func NewSlasherNode(ctx *cli.Context) (*SlasherNode, error) {
 		return nil, err
 	}
 
+	if ctx.GlobalBool(flags.ReviewSlashingsFlag.Name) {
+		if err := slasher.registerReviewService(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	return slasher, nil
 }
 
@@ -165,6 +172,7 @@ func (s *SlasherNode) registerBeaconClientService(ctx *cli.Context) error {
 		BeaconProvider:        beaconProvider,
 		AttesterSlashingsFeed: s.attesterSlashingsFeed,
 		ProposerSlashingsFeed: s.proposerSlashingsFeed,
+		ReviewSlashings:       ctx.GlobalBool(flags.ReviewSlashingsFlag.Name),
 	})
 	return s.services.RegisterService(bs)
 }
@@ -184,3 +192,17 @@ func (s *SlasherNode) registerDetectionService() error {
 	})
 	return s.services.RegisterService(ds)
 }
+
+// registerReviewService serves the review of the detected slashings on the loopback interface
+// only, as the slashings are approved and rejected without authentication.
+func (s *SlasherNode) registerReviewService(ctx *cli.Context) error {
+	var bs *beaconclient.Service
+	if err := s.services.FetchService(&bs); err != nil {
+		return err
+	}
+	service := localserver.NewService(
+		ctx.GlobalInt64(flags.ReviewPortFlag.Name),
+		localserver.Handler{Path: "/slashings/review", Handler: bs.ReviewSlashingsHandler},
+	)
+	return s.services.RegisterService(service)
+}
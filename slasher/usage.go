@@ -76,6 +76,8 @@ var appHelpFlagGroups = []flagGroup{
 			flags.RPCPort,
 			flags.UseSpanCacheFlag,
 			flags.RebuildSpanMapsFlag,
+			flags.ReviewSlashingsFlag,
+			flags.ReviewPortFlag,
 			flags.BeaconRPCProviderFlag,
 		},
 	},
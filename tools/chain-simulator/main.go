@@ -0,0 +1,85 @@
+// Chain simulator runs the state transition over many epochs with simulated validators whose
+// behavior is described by profiles, and writes the finality and reward statistics of every
+// epoch as JSON, e.g.
+//
+//	chain-simulator --validators=1024 --epochs=20 --profiles=profiles.yaml
+//
+// with a profiles file such as
+//
+//   - name: honest
+//     fraction: 0.8
+//     inclusion_delays: [0.9, 0.08, 0.02]
+//   - name: offline
+//     fraction: 0.2
+//     offline_rate: 1
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/tools/chain-simulator/simulator"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	validators   = flag.Uint64("validators", 256, "Number of validators at genesis")
+	epochs       = flag.Uint64("epochs", 10, "Number of epochs to simulate")
+	profilesFile = flag.String("profiles", "", "YAML file of the validator behavior profiles, every validator is honest if empty")
+	offlineRate  = flag.Float64("offline-rate", 0, "Probability of a validator missing a duty, when no profiles file is given")
+	seed         = flag.Int64("seed", 0, "Seed of the random behavior of the validators")
+	minimal      = flag.Bool("minimal-config", false, "Use the minimal config instead of the mainnet config")
+	output       = flag.String("output", "", "File to write the JSON statistics to, stdout if empty")
+)
+
+func main() {
+	flag.Parse()
+	if *minimal {
+		params.UseMinimalConfig()
+	}
+
+	profiles := []*simulator.Profile{{Name: "default", Fraction: 1, OfflineRate: *offlineRate}}
+	if *profilesFile != "" {
+		enc, err := ioutil.ReadFile(*profilesFile)
+		if err != nil {
+			log.Fatalf("Could not read profiles file: %v", err)
+		}
+		profiles = nil
+		if err := yaml.UnmarshalStrict(enc, &profiles); err != nil {
+			log.Fatalf("Could not decode profiles file: %v", err)
+		}
+	}
+
+	stats, err := simulator.Run(context.Background(), &simulator.Config{
+		Validators: *validators,
+		Epochs:     *epochs,
+		Profiles:   profiles,
+		Seed:       *seed,
+	})
+	if err != nil {
+		log.Fatalf("Could not run simulation: %v", err)
+	}
+	for _, s := range stats {
+		log.Printf("Epoch %d: participation %.3f, justified epoch %d, finalized epoch %d, missed blocks %d",
+			s.Epoch, s.Participation, s.JustifiedEpoch, s.FinalizedEpoch, s.MissedBlocks)
+	}
+
+	enc, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *output == "" {
+		if _, err := os.Stdout.Write(append(enc, '\n')); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if err := ioutil.WriteFile(*output, enc, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
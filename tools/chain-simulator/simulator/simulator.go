@@ -0,0 +1,346 @@
+// Package simulator drives the state transition slot by slot with simulated validators, whose
+// behavior is configured by profiles, and reports the rewards and finality of every epoch. It
+// lets the economics of the protocol be studied without running a network. Signatures are
+// neither produced nor verified.
+package simulator
+
+import (
+	"context"
+	"math"
+	"math/rand"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/interop"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// Profile describes the behavior of a share of the validators.
+type Profile struct {
+	Name string `yaml:"name" json:"name"`
+	// Fraction is the share of the validators following the profile.
+	Fraction float64 `yaml:"fraction" json:"fraction"`
+	// OfflineRate is the probability of a validator missing each of its attestations and
+	// proposals.
+	OfflineRate float64 `yaml:"offline_rate" json:"offline_rate"`
+	// InclusionDelays are the relative weights of the attestations of the profile being
+	// included 0, 1, 2... slots later than the earliest possible slot, modeling their latency.
+	// Attestations are included as early as possible if empty.
+	InclusionDelays []float64 `yaml:"inclusion_delays" json:"inclusion_delays"`
+}
+
+// Config of a simulation.
+type Config struct {
+	Validators uint64
+	Epochs     uint64
+	Profiles   []*Profile
+	Seed       int64
+}
+
+// EpochStats are the statistics of an epoch, taken after its epoch transition.
+type EpochStats struct {
+	Epoch          uint64 `json:"epoch"`
+	JustifiedEpoch uint64 `json:"justified_epoch"`
+	FinalizedEpoch uint64 `json:"finalized_epoch"`
+	// Participation is the ratio of the active balance which attested to the target of the
+	// epoch, as counted for its justification.
+	Participation float64         `json:"participation"`
+	MissedBlocks  uint64          `json:"missed_blocks"`
+	Profiles      []*ProfileStats `json:"profiles"`
+}
+
+// ProfileStats are the balances of the validators of a profile at the end of an epoch.
+type ProfileStats struct {
+	Name        string  `json:"name"`
+	Validators  uint64  `json:"validators"`
+	MeanBalance float64 `json:"mean_balance"`
+	// MeanReward is the mean change of balance over the epoch, in Gwei.
+	MeanReward float64 `json:"mean_reward"`
+}
+
+// pendingAttestation is an aggregate of the attestations of a committee, which will be
+// included in the first block from includeAt.
+type pendingAttestation struct {
+	att       *ethpb.Attestation
+	includeAt uint64
+}
+
+type simulation struct {
+	cfg      *Config
+	rng      *rand.Rand
+	profiles []int // profile index of each validator
+	pending  []*pendingAttestation
+	missed   uint64
+	balances []uint64 // balances at the start of the epoch
+	stats    []*EpochStats
+}
+
+// Run simulates the configured number of epochs from a genesis state, returning the
+// statistics of every epoch.
+func Run(ctx context.Context, cfg *Config) ([]*EpochStats, error) {
+	if err := validateConfig(cfg); err != nil {
+		return nil, err
+	}
+	genesis, _, err := interop.GenerateGenesisState(0, cfg.Validators)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not generate genesis state")
+	}
+	st, err := stateTrie.InitializeFromProtoUnsafe(genesis)
+	if err != nil {
+		return nil, err
+	}
+	s := &simulation{
+		cfg:      cfg,
+		rng:      rand.New(rand.NewSource(cfg.Seed)),
+		balances: st.Balances(),
+	}
+	s.assignProfiles()
+
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	for slot := uint64(1); slot <= cfg.Epochs*slotsPerEpoch; slot++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if slot%slotsPerEpoch == 0 {
+			// The state of the last slot of the epoch holds the participation used by the
+			// epoch transition.
+			bp, err := precompute.Participation(ctx, st)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not compute participation")
+			}
+			if st, err = state.ProcessSlots(ctx, st, slot); err != nil {
+				return nil, errors.Wrapf(err, "could not process slot %d", slot)
+			}
+			s.recordEpoch(st, slot/slotsPerEpoch-1, bp.CurrentEpochParticipationRate())
+		} else if st, err = state.ProcessSlots(ctx, st, slot); err != nil {
+			return nil, errors.Wrapf(err, "could not process slot %d", slot)
+		}
+		if st, err = s.propose(ctx, st); err != nil {
+			return nil, errors.Wrapf(err, "could not propose block at slot %d", slot)
+		}
+		if err := s.attest(st); err != nil {
+			return nil, errors.Wrapf(err, "could not attest at slot %d", slot)
+		}
+	}
+	return s.stats, nil
+}
+
+func validateConfig(cfg *Config) error {
+	if cfg.Validators == 0 || cfg.Epochs == 0 {
+		return errors.New("number of validators and epochs must be positive")
+	}
+	if len(cfg.Profiles) == 0 {
+		return errors.New("no validator profiles")
+	}
+	var total float64
+	for _, p := range cfg.Profiles {
+		if p.Fraction < 0 || p.OfflineRate < 0 || p.OfflineRate > 1 {
+			return errors.Errorf("invalid fraction or offline rate of profile %q", p.Name)
+		}
+		for _, w := range p.InclusionDelays {
+			if w < 0 {
+				return errors.Errorf("invalid inclusion delay weight of profile %q", p.Name)
+			}
+		}
+		total += p.Fraction
+	}
+	if math.Abs(total-1) > 1e-6 {
+		return errors.Errorf("fractions of the profiles sum to %f instead of 1", total)
+	}
+	return nil
+}
+
+// assignProfiles assigns the validators to the profiles in a random order, according to the
+// fractions of the profiles.
+func (s *simulation) assignProfiles() {
+	s.profiles = make([]int, s.cfg.Validators)
+	perm := s.rng.Perm(int(s.cfg.Validators))
+	var start int
+	for i, p := range s.cfg.Profiles {
+		end := int(math.Round(float64(start) + p.Fraction*float64(s.cfg.Validators)))
+		if i == len(s.cfg.Profiles)-1 || end > len(perm) {
+			end = len(perm)
+		}
+		for _, v := range perm[start:end] {
+			s.profiles[v] = i
+		}
+		start = end
+	}
+}
+
+func (s *simulation) offline(validator uint64) bool {
+	return s.rng.Float64() < s.cfg.Profiles[s.profiles[validator]].OfflineRate
+}
+
+// inclusionDelay samples the extra inclusion delay of an attestation of the validator.
+func (s *simulation) inclusionDelay(validator uint64) uint64 {
+	weights := s.cfg.Profiles[s.profiles[validator]].InclusionDelays
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+	if total == 0 {
+		return 0
+	}
+	r := s.rng.Float64() * total
+	for i, w := range weights {
+		if r < w {
+			return uint64(i)
+		}
+		r -= w
+	}
+	return uint64(len(weights) - 1)
+}
+
+// propose processes a block including the pending attestations due at the slot of the state,
+// unless the proposer is offline.
+func (s *simulation) propose(ctx context.Context, st *stateTrie.BeaconState) (*stateTrie.BeaconState, error) {
+	proposer, err := helpers.BeaconProposerIndex(st)
+	if err != nil {
+		return nil, err
+	}
+	if s.offline(proposer) {
+		s.missed++
+		return st, nil
+	}
+	parentRoot, err := ssz.HashTreeRoot(st.LatestBlockHeader())
+	if err != nil {
+		return nil, err
+	}
+	reveal := make([]byte, 96)
+	s.rng.Read(reveal)
+
+	var atts []*ethpb.Attestation
+	var remaining []*pendingAttestation
+	for _, p := range s.pending {
+		if p.att.Data.Slot+params.BeaconConfig().SlotsPerEpoch < st.Slot() {
+			// Too old to be included anymore.
+			continue
+		}
+		if p.includeAt <= st.Slot() && uint64(len(atts)) < params.BeaconConfig().MaxAttestations {
+			atts = append(atts, p.att)
+			continue
+		}
+		remaining = append(remaining, p)
+	}
+	s.pending = remaining
+
+	block := &ethpb.SignedBeaconBlock{
+		Block: &ethpb.BeaconBlock{
+			Slot:       st.Slot(),
+			ParentRoot: parentRoot[:],
+			Body: &ethpb.BeaconBlockBody{
+				RandaoReveal: reveal,
+				Eth1Data:     st.Eth1Data(),
+				Attestations: atts,
+			},
+		},
+		Signature: make([]byte, 96),
+	}
+	return state.ProcessBlockForStateRoot(ctx, st, block)
+}
+
+// attest creates the attestations of the online validators of every committee at the slot of
+// the state, voting for the latest block.
+func (s *simulation) attest(st *stateTrie.BeaconState) error {
+	header := st.LatestBlockHeader()
+	if bytesutil.ToBytes32(header.StateRoot) == [32]byte{} {
+		// The state root of a block processed at this slot is only filled in at the next slot.
+		root, err := st.HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		header.StateRoot = root[:]
+	}
+	headRoot, err := ssz.HashTreeRoot(header)
+	if err != nil {
+		return err
+	}
+	slot := st.Slot()
+	epoch := helpers.SlotToEpoch(slot)
+	targetRoot := headRoot[:]
+	if slot != helpers.StartSlot(epoch) {
+		if targetRoot, err = helpers.BlockRoot(st, epoch); err != nil {
+			return err
+		}
+	}
+	activeCount, err := helpers.ActiveValidatorCount(st, epoch)
+	if err != nil {
+		return err
+	}
+	for c := uint64(0); c < helpers.SlotCommitteeCount(activeCount); c++ {
+		committee, err := helpers.BeaconCommitteeFromState(st, slot, c)
+		if err != nil {
+			return err
+		}
+		data := &ethpb.AttestationData{
+			Slot:            slot,
+			CommitteeIndex:  c,
+			BeaconBlockRoot: headRoot[:],
+			Source:          st.CurrentJustifiedCheckpoint(),
+			Target:          &ethpb.Checkpoint{Epoch: epoch, Root: targetRoot},
+		}
+		// Attestations included at the same slot are aggregated.
+		aggregates := make(map[uint64]*pendingAttestation)
+		for i, validator := range committee {
+			if s.offline(validator) {
+				continue
+			}
+			includeAt := slot + params.BeaconConfig().MinAttestationInclusionDelay + s.inclusionDelay(validator)
+			p, ok := aggregates[includeAt]
+			if !ok {
+				p = &pendingAttestation{
+					att: &ethpb.Attestation{
+						Data:            data,
+						AggregationBits: bitfield.NewBitlist(uint64(len(committee))),
+						Signature:       make([]byte, 96),
+					},
+					includeAt: includeAt,
+				}
+				aggregates[includeAt] = p
+				s.pending = append(s.pending, p)
+			}
+			p.att.AggregationBits.SetBitAt(uint64(i), true)
+		}
+	}
+	return nil
+}
+
+// recordEpoch records the statistics of the epoch, with the state right after its epoch
+// transition.
+func (s *simulation) recordEpoch(st *stateTrie.BeaconState, epoch uint64, participation float64) {
+	stats := &EpochStats{
+		Epoch:          epoch,
+		JustifiedEpoch: st.CurrentJustifiedCheckpoint().Epoch,
+		FinalizedEpoch: st.FinalizedCheckpoint().Epoch,
+		Participation:  participation,
+		MissedBlocks:   s.missed,
+	}
+	balances := st.Balances()
+	totals := make([]float64, len(s.cfg.Profiles))
+	rewards := make([]float64, len(s.cfg.Profiles))
+	counts := make([]uint64, len(s.cfg.Profiles))
+	for v, p := range s.profiles {
+		totals[p] += float64(balances[v])
+		rewards[p] += float64(balances[v]) - float64(s.balances[v])
+		counts[p]++
+	}
+	for i, p := range s.cfg.Profiles {
+		ps := &ProfileStats{Name: p.Name, Validators: counts[i]}
+		if counts[i] > 0 {
+			ps.MeanBalance = totals[i] / float64(counts[i])
+			ps.MeanReward = rewards[i] / float64(counts[i])
+		}
+		stats.Profiles = append(stats.Profiles, ps)
+	}
+	s.stats = append(s.stats, stats)
+	s.balances = balances
+	s.missed = 0
+}
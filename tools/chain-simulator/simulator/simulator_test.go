@@ -0,0 +1,77 @@
+package simulator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestRun_FullParticipationFinalizes(t *testing.T) {
+	params.UseMinimalConfig()
+	defer params.UseMainnetConfig()
+
+	stats, err := Run(context.Background(), &Config{
+		Validators: 64,
+		Epochs:     5,
+		Profiles:   []*Profile{{Name: "honest", Fraction: 1}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 5 {
+		t.Fatalf("Wanted stats of 5 epochs, received %d", len(stats))
+	}
+	last := stats[len(stats)-1]
+	if last.FinalizedEpoch == 0 {
+		t.Errorf("Expected the chain to finalize with full participation, justified epoch %d", last.JustifiedEpoch)
+	}
+	if last.MissedBlocks != 0 {
+		t.Errorf("Expected no missed blocks, received %d", last.MissedBlocks)
+	}
+	if last.Profiles[0].MeanReward <= 0 {
+		t.Errorf("Expected honest validators to be rewarded, received mean reward %f", last.Profiles[0].MeanReward)
+	}
+}
+
+func TestRun_OfflineValidators(t *testing.T) {
+	params.UseMinimalConfig()
+	defer params.UseMainnetConfig()
+
+	stats, err := Run(context.Background(), &Config{
+		Validators: 64,
+		Epochs:     5,
+		Profiles: []*Profile{
+			{Name: "online", Fraction: 0.5, InclusionDelays: []float64{1, 1}},
+			{Name: "offline", Fraction: 0.5, OfflineRate: 1},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := stats[len(stats)-1]
+	if last.FinalizedEpoch != 0 || last.JustifiedEpoch != 0 {
+		t.Errorf("Expected no justification with half of the validators offline, received justified epoch %d", last.JustifiedEpoch)
+	}
+	if last.Participation > 0.5 {
+		t.Errorf("Expected participation of at most half of the balance, received %f", last.Participation)
+	}
+	online, offline := last.Profiles[0], last.Profiles[1]
+	if offline.MeanReward >= 0 {
+		t.Errorf("Expected offline validators to be penalized, received mean reward %f", offline.MeanReward)
+	}
+	if online.MeanBalance <= offline.MeanBalance {
+		t.Errorf("Expected online validators to end with a higher balance than offline validators, %f <= %f", online.MeanBalance, offline.MeanBalance)
+	}
+}
+
+func TestRun_InvalidProfiles(t *testing.T) {
+	_, err := Run(context.Background(), &Config{
+		Validators: 64,
+		Epochs:     1,
+		Profiles:   []*Profile{{Name: "honest", Fraction: 0.5}},
+	})
+	if err == nil {
+		t.Error("Expected profiles not covering every validator to be rejected")
+	}
+}
@@ -1,19 +1,34 @@
 package main
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"flag"
 	"io/ioutil"
 	"log"
+	"strings"
 
 	"github.com/ghodss/yaml"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/interop"
 	"github.com/prysmaticlabs/prysm/shared/params"
 )
 
+// depositDataJSON is the deposit data format written by deposit tools, where
+// every byte field is hex encoded.
+type depositDataJSON struct {
+	PubKey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                uint64 `json:"amount"`
+	Signature             string `json:"signature"`
+}
+
 var (
 	numValidators    = flag.Int("num-validators", 0, "Number of validators to deterministically include in the generated genesis state")
+	depositJSONFile  = flag.String("deposit-json-file", "", "Path to a JSON file of deposit data items to include in the generated genesis state, instead of deterministic interop keys")
+	forkVersion      = flag.String("fork-version", "", "Hex encoded 4 byte genesis fork version, such as 0x00000001 (defaults to the selected config)")
 	useMainnetConfig = flag.Bool("mainnet-config", false, "Select whether genesis state should be generated with mainnet or minimal (default) params")
 	genesisTime      = flag.Uint64("genesis-time", 0, "Unix timestamp used as the genesis time in the generated genesis state (defaults to now)")
 	sszOutputFile    = flag.String("output-ssz", "", "Output filename of the SSZ marshaling of the generated genesis state")
@@ -23,8 +38,11 @@ var (
 
 func main() {
 	flag.Parse()
-	if *numValidators == 0 {
-		log.Fatal("Expected --num-validators to have been provided, received 0")
+	if *numValidators == 0 && *depositJSONFile == "" {
+		log.Fatal("Expected --num-validators or --deposit-json-file to have been provided, received neither")
+	}
+	if *numValidators != 0 && *depositJSONFile != "" {
+		log.Fatal("Only one of --num-validators or --deposit-json-file may be provided")
 	}
 	if *genesisTime == 0 {
 		log.Print("No --genesis-time specified, defaulting to now")
@@ -35,10 +53,35 @@ func main() {
 	if !*useMainnetConfig {
 		params.OverrideBeaconConfig(params.MinimalSpecConfig())
 	}
+	if *forkVersion != "" {
+		version, err := decodeHex(*forkVersion)
+		if err != nil {
+			log.Fatalf("Could not decode --fork-version: %v", err)
+		}
+		if len(version) != 4 {
+			log.Fatalf("Expected --fork-version to be 4 bytes, received %d", len(version))
+		}
+		cfg := params.BeaconConfig()
+		cfg.GenesisForkVersion = version
+		params.OverrideBeaconConfig(cfg)
+	}
 
-	genesisState, _, err := interop.GenerateGenesisState(*genesisTime, uint64(*numValidators))
-	if err != nil {
-		log.Fatalf("Could not generate genesis beacon state: %v", err)
+	var genesisState *pb.BeaconState
+	var err error
+	if *depositJSONFile != "" {
+		depositDataItems, err := depositDataFromJSONFile(*depositJSONFile)
+		if err != nil {
+			log.Fatalf("Could not read deposit data from %s: %v", *depositJSONFile, err)
+		}
+		genesisState, _, err = interop.GenerateGenesisStateFromDepositData(*genesisTime, depositDataItems)
+		if err != nil {
+			log.Fatalf("Could not generate genesis beacon state: %v", err)
+		}
+	} else {
+		genesisState, _, err = interop.GenerateGenesisState(*genesisTime, uint64(*numValidators))
+		if err != nil {
+			log.Fatalf("Could not generate genesis beacon state: %v", err)
+		}
 	}
 	if *sszOutputFile != "" {
 		encodedState, err := ssz.Marshal(genesisState)
@@ -71,3 +114,42 @@ func main() {
 		log.Printf("Done writing to %s", *jsonOutputFile)
 	}
 }
+
+// depositDataFromJSONFile reads a list of hex encoded deposit data items
+// from a JSON file.
+func depositDataFromJSONFile(path string) ([]*ethpb.Deposit_Data, error) {
+	enc, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var items []*depositDataJSON
+	if err := json.Unmarshal(enc, &items); err != nil {
+		return nil, err
+	}
+	depositDataItems := make([]*ethpb.Deposit_Data, len(items))
+	for i, item := range items {
+		pubKey, err := decodeHex(item.PubKey)
+		if err != nil {
+			return nil, err
+		}
+		withdrawalCredentials, err := decodeHex(item.WithdrawalCredentials)
+		if err != nil {
+			return nil, err
+		}
+		signature, err := decodeHex(item.Signature)
+		if err != nil {
+			return nil, err
+		}
+		depositDataItems[i] = &ethpb.Deposit_Data{
+			PublicKey:             pubKey,
+			WithdrawalCredentials: withdrawalCredentials,
+			Amount:                item.Amount,
+			Signature:             signature,
+		}
+	}
+	return depositDataItems, nil
+}
+
+func decodeHex(s string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(s, "0x"))
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/urfave/cli"
+)
+
+var checkpointOutputFlag = cli.StringFlag{
+	Name:  "output",
+	Usage: "Output filename of the SSZ encoded finalized state",
+}
+
+var checkpointCommand = cli.Command{
+	Name: "checkpoint",
+	Usage: "prints the finalized checkpoint of a stopped beacon node as root:epoch, and writes the " +
+		"finalized state, to bootstrap or verify other nodes",
+	Flags:  []cli.Flag{cmd.DataDirFlag, checkpointOutputFlag},
+	Action: generateCheckpoint,
+}
+
+func generateCheckpoint(ctx *cli.Context) error {
+	beaconDB, err := openDB(ctx)
+	if err != nil {
+		return err
+	}
+	defer beaconDB.Close()
+	cp, err := beaconDB.FinalizedCheckpoint(context.Background())
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	root := bytesutil.ToBytes32(cp.Root)
+	fmt.Printf("%#x:%d\n", root, cp.Epoch)
+
+	output := ctx.String(checkpointOutputFlag.Name)
+	if output == "" {
+		return nil
+	}
+	st, err := beaconDB.State(context.Background(), root)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized state")
+	}
+	if st == nil {
+		return fmt.Errorf("no state found for finalized root %#x", root)
+	}
+	enc, err := ssz.Marshal(st.InnerStateUnsafe())
+	if err != nil {
+		return errors.Wrap(err, "could not ssz marshal the finalized state")
+	}
+	if err := ioutil.WriteFile(output, enc, 0644); err != nil {
+		return errors.Wrap(err, "could not write finalized state")
+	}
+	log.WithField("slot", st.Slot()).Infof("Done writing finalized state to %s", output)
+	return nil
+}
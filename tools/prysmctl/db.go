@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/urfave/cli"
+)
+
+// The directory and file name of the beacon node database within its data directory.
+const (
+	beaconChainDBName = "beaconchaindata"
+	databaseFileName  = "beaconchain.db"
+)
+
+var (
+	rootFlag = cli.StringFlag{
+		Name:  "root",
+		Usage: "Hex encoded block root",
+	}
+	slotFlag = cli.Uint64Flag{
+		Name:  "slot",
+		Usage: "Slot of the block, instead of its root",
+	}
+	typeFlag = cli.StringFlag{
+		Name:  "type",
+		Usage: "The type of object to query, block or state",
+		Value: "block",
+	}
+	sszOutputFlag = cli.StringFlag{
+		Name:  "output-ssz",
+		Usage: "Output filename of the SSZ encoded object, instead of printing it as JSON",
+	}
+)
+
+var dbCommand = cli.Command{
+	Name:  "db",
+	Usage: "inspects the database of a stopped beacon node",
+	Subcommands: cli.Commands{
+		{
+			Name:   "buckets",
+			Usage:  "lists the buckets of the database with their number of keys and size",
+			Flags:  []cli.Flag{cmd.DataDirFlag},
+			Action: listBuckets,
+		},
+		{
+			Name: "query",
+			Usage: "prints the block or state with the given root, or of the block at the given slot, as JSON " +
+				"or writes it SSZ encoded",
+			Flags:  []cli.Flag{cmd.DataDirFlag, rootFlag, slotFlag, typeFlag, sszOutputFlag},
+			Action: query,
+		},
+	},
+}
+
+// listBuckets opens the bolt database read only, so it can be inspected without the beacon
+// node creating any missing bucket.
+func listBuckets(ctx *cli.Context) error {
+	dbPath := path.Join(ctx.String(cmd.DataDirFlag.Name), beaconChainDBName, databaseFileName)
+	boltDB, err := bolt.Open(dbPath, 0600, &bolt.Options{Timeout: time.Second, ReadOnly: true})
+	if err != nil {
+		if err == bolt.ErrTimeout {
+			return errors.New("cannot obtain database lock, the beacon node may still be running")
+		}
+		return errors.Wrapf(err, "could not open database %s", dbPath)
+	}
+	defer boltDB.Close()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "BUCKET\tKEYS\tBYTES")
+	var totalKeys, totalBytes int
+	if err := boltDB.View(func(tx *bolt.Tx) error {
+		fmt.Printf("Database %s is %d bytes\n", dbPath, tx.Size())
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			keys, bytes := 0, 0
+			if err := b.ForEach(func(k []byte, v []byte) error {
+				keys++
+				bytes += len(k) + len(v)
+				return nil
+			}); err != nil {
+				return err
+			}
+			totalKeys += keys
+			totalBytes += bytes
+			fmt.Fprintf(w, "%s\t%d\t%d\n", name, keys, bytes)
+			return nil
+		})
+	}); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "total\t%d\t%d\n", totalKeys, totalBytes)
+	return w.Flush()
+}
+
+// query looks up the block or state of the requested root or slot. A slot with several blocks,
+// from competing forks, is rejected with their roots so one can be selected with --root.
+func query(ctx *cli.Context) error {
+	if ctx.IsSet(rootFlag.Name) == ctx.IsSet(slotFlag.Name) {
+		return fmt.Errorf("expected exactly one of --%s or --%s", rootFlag.Name, slotFlag.Name)
+	}
+	objType := ctx.String(typeFlag.Name)
+	if objType != "block" && objType != "state" {
+		return fmt.Errorf("unknown type %s, expected block or state", objType)
+	}
+	beaconDB, err := openDB(ctx)
+	if err != nil {
+		return err
+	}
+	defer beaconDB.Close()
+	c := context.Background()
+
+	var root [32]byte
+	if ctx.IsSet(rootFlag.Name) {
+		root, err = blockRoot(ctx)
+		if err != nil {
+			return err
+		}
+	} else {
+		slot := ctx.Uint64(slotFlag.Name)
+		roots, err := beaconDB.BlockRoots(c, filters.NewFilter().SetStartSlot(slot).SetEndSlot(slot))
+		if err != nil {
+			return errors.Wrap(err, "could not get block roots")
+		}
+		switch len(roots) {
+		case 0:
+			return fmt.Errorf("no block found at slot %d", slot)
+		case 1:
+			root = roots[0]
+		default:
+			return fmt.Errorf("found %d blocks at slot %d, select one with --%s: %#x", len(roots), slot, rootFlag.Name, roots)
+		}
+	}
+
+	var obj interface{}
+	if objType == "block" {
+		blk, err := beaconDB.Block(c, root)
+		if err != nil {
+			return err
+		}
+		if blk == nil {
+			return fmt.Errorf("no block found with root %#x", root)
+		}
+		obj = blk
+	} else {
+		st, err := beaconDB.State(c, root)
+		if err != nil {
+			return err
+		}
+		if st == nil {
+			return fmt.Errorf("no state found for block root %#x", root)
+		}
+		obj = st.InnerStateUnsafe()
+	}
+
+	output := ctx.String(sszOutputFlag.Name)
+	if output == "" {
+		return printJSON(obj)
+	}
+	enc, err := ssz.Marshal(obj)
+	if err != nil {
+		return errors.Wrapf(err, "could not ssz marshal the %s", objType)
+	}
+	if err := ioutil.WriteFile(output, enc, 0644); err != nil {
+		return errors.Wrapf(err, "could not write the %s", objType)
+	}
+	log.WithField("root", fmt.Sprintf("%#x", root)).Infof("Done writing %s to %s", objType, output)
+	return nil
+}
+
+// openDB opens the beacon node database in the data directory.
+func openDB(ctx *cli.Context) (db.Database, error) {
+	beaconDB, err := db.NewDB(path.Join(ctx.String(cmd.DataDirFlag.Name), beaconChainDBName))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open database")
+	}
+	return beaconDB, nil
+}
+
+func blockRoot(ctx *cli.Context) ([32]byte, error) {
+	root, err := decodeHex(ctx.String(rootFlag.Name))
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not decode root")
+	}
+	if len(root) != 32 {
+		return [32]byte{}, fmt.Errorf("expected --%s to be 32 bytes, received %d", rootFlag.Name, len(root))
+	}
+	var r [32]byte
+	copy(r[:], root)
+	return r, nil
+}
+
+func printJSON(v interface{}) error {
+	enc, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(enc))
+	return nil
+}
+
+func decodeHex(s string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(s, "0x"))
+}
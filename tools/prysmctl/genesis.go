@@ -0,0 +1,56 @@
+package main
+
+import (
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/shared/interop"
+	"github.com/urfave/cli"
+)
+
+var (
+	numValidatorsFlag = cli.Uint64Flag{
+		Name:  "num-validators",
+		Usage: "Number of validators to deterministically include in the generated genesis state",
+	}
+	genesisTimeFlag = cli.Uint64Flag{
+		Name:  "genesis-time",
+		Usage: "Unix timestamp used as the genesis time in the generated genesis state (defaults to now)",
+	}
+	genesisOutputFlag = cli.StringFlag{
+		Name:  "output",
+		Usage: "Output filename of the SSZ encoded genesis state",
+	}
+)
+
+var genesisCommand = cli.Command{
+	Name:   "genesis",
+	Usage:  "generates a genesis state with deterministic interop validator keys",
+	Flags:  []cli.Flag{numValidatorsFlag, genesisTimeFlag, genesisOutputFlag},
+	Action: generateGenesis,
+}
+
+func generateGenesis(ctx *cli.Context) error {
+	numValidators := ctx.Uint64(numValidatorsFlag.Name)
+	if numValidators == 0 {
+		return errors.New("expected --num-validators to have been provided")
+	}
+	output := ctx.String(genesisOutputFlag.Name)
+	if output == "" {
+		return errors.New("expected --output to have been provided")
+	}
+	genesisState, _, err := interop.GenerateGenesisState(ctx.Uint64(genesisTimeFlag.Name), numValidators)
+	if err != nil {
+		return errors.Wrap(err, "could not generate genesis state")
+	}
+	enc, err := ssz.Marshal(genesisState)
+	if err != nil {
+		return errors.Wrap(err, "could not ssz marshal the genesis state")
+	}
+	if err := ioutil.WriteFile(output, enc, 0644); err != nil {
+		return errors.Wrap(err, "could not write genesis state")
+	}
+	log.WithField("genesisTime", genesisState.GenesisTime).Infof("Done writing genesis state to %s", output)
+	return nil
+}
@@ -0,0 +1,46 @@
+// Package main implements prysmctl, a command line utility consolidating the operational
+// tooling of Prysm: inspecting the database of a stopped beacon node, generating checkpoint and
+// genesis states, diagnosing peers, and comparing states.
+//
+// Usage: bazel run //tools/prysmctl -- <command> [subcommand] [flags]
+package main
+
+import (
+	"os"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/version"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var log = logrus.WithField("prefix", "prysmctl")
+
+var minimalConfigFlag = cli.BoolFlag{
+	Name:  "minimal-config",
+	Usage: "Use the minimal beacon chain config instead of the mainnet config",
+}
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "prysmctl"
+	app.Usage = "operational tooling for Prysm beacon nodes"
+	app.Version = version.GetVersion()
+	app.Flags = []cli.Flag{minimalConfigFlag}
+	app.Before = func(ctx *cli.Context) error {
+		if ctx.GlobalBool(minimalConfigFlag.Name) {
+			params.UseMinimalConfig()
+		}
+		return nil
+	}
+	app.Commands = []cli.Command{
+		dbCommand,
+		checkpointCommand,
+		genesisCommand,
+		p2pCommand,
+		stateCommand,
+	}
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
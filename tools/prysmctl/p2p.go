@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	noise "github.com/libp2p/go-libp2p-noise"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/urfave/cli"
+)
+
+const statusProtocol = "/eth2/beacon_chain/req/status/1"
+
+var (
+	peerFlag = cli.StringFlag{
+		Name:  "peer",
+		Usage: "Multiaddress of the peer, such as /ip4/127.0.0.1/tcp/13000/p2p/16Uiu2...",
+	}
+	encodingFlag = cli.StringFlag{
+		Name:  "encoding",
+		Usage: "The encoding of the peer, ssz or ssz-snappy",
+		Value: encoder.SSZ,
+	}
+	noiseFlag = cli.BoolFlag{
+		Name:  "noise",
+		Usage: "Secure the connection with noise, for peers running with --enable-noise",
+	}
+	timeoutFlag = cli.DurationFlag{
+		Name:  "timeout",
+		Usage: "Timeout of dialing the peer and of the status handshake",
+		Value: 10 * time.Second,
+	}
+)
+
+var p2pCommand = cli.Command{
+	Name:  "p2p",
+	Usage: "diagnoses the connectivity to a peer",
+	Subcommands: cli.Commands{
+		{
+			Name:   "dial",
+			Usage:  "dials the peer and prints its protocols",
+			Flags:  []cli.Flag{peerFlag, noiseFlag, timeoutFlag},
+			Action: dialPeer,
+		},
+		{
+			Name:   "status",
+			Usage:  "dials the peer and prints its response to a status handshake as JSON",
+			Flags:  []cli.Flag{peerFlag, encodingFlag, noiseFlag, timeoutFlag},
+			Action: peerStatus,
+		},
+	},
+}
+
+func dialPeer(ctx *cli.Context) error {
+	c, cancel := context.WithTimeout(context.Background(), ctx.Duration(timeoutFlag.Name))
+	defer cancel()
+	h, info, err := connect(c, ctx)
+	if err != nil {
+		return err
+	}
+	defer h.Close()
+	protocols, err := h.Peerstore().GetProtocols(info.ID)
+	if err != nil {
+		return errors.Wrap(err, "could not get protocols of peer")
+	}
+	log.WithField("peer", info.ID.Pretty()).Info("Connected to peer")
+	for _, p := range protocols {
+		fmt.Println(p)
+	}
+	return nil
+}
+
+// peerStatus sends a status message of the genesis fork to the peer, and prints the status it
+// responds with.
+func peerStatus(ctx *cli.Context) error {
+	var enc encoder.NetworkEncoding
+	switch ctx.String(encodingFlag.Name) {
+	case encoder.SSZ:
+		enc = &encoder.SszNetworkEncoder{}
+	case encoder.SSZSnappy:
+		enc = &encoder.SszNetworkEncoder{UseSnappyCompression: true}
+	default:
+		return fmt.Errorf("unknown encoding %s", ctx.String(encodingFlag.Name))
+	}
+	c, cancel := context.WithTimeout(context.Background(), ctx.Duration(timeoutFlag.Name))
+	defer cancel()
+	h, info, err := connect(c, ctx)
+	if err != nil {
+		return err
+	}
+	defer h.Close()
+
+	stream, err := h.NewStream(c, info.ID, protocol.ID(statusProtocol+enc.ProtocolSuffix()))
+	if err != nil {
+		return errors.Wrap(err, "could not open status stream")
+	}
+	defer stream.Close()
+	if err := stream.SetDeadline(time.Now().Add(ctx.Duration(timeoutFlag.Name))); err != nil {
+		return err
+	}
+	req := &pb.Status{
+		HeadForkVersion: params.BeaconConfig().GenesisForkVersion,
+		FinalizedRoot:   params.BeaconConfig().ZeroHash[:],
+		HeadRoot:        params.BeaconConfig().ZeroHash[:],
+	}
+	if _, err := enc.EncodeWithLength(stream, req); err != nil {
+		return errors.Wrap(err, "could not send status")
+	}
+	code := make([]byte, 1)
+	if _, err := stream.Read(code); err != nil {
+		return errors.Wrap(err, "could not read response code")
+	}
+	if code[0] != 0 {
+		msg := make([]byte, 0)
+		if err := enc.DecodeWithLength(stream, &msg); err != nil {
+			return errors.Wrapf(err, "could not decode error message of response code %d", code[0])
+		}
+		return fmt.Errorf("peer responded with code %d: %s", code[0], msg)
+	}
+	resp := &pb.Status{}
+	if err := enc.DecodeWithLength(stream, resp); err != nil {
+		return errors.Wrap(err, "could not decode status")
+	}
+	return printJSON(resp)
+}
+
+// connect creates a libp2p host without listen addresses and connects it to the peer.
+func connect(c context.Context, ctx *cli.Context) (host.Host, *peerstore.PeerInfo, error) {
+	info, err := p2p.MakePeer(ctx.String(peerFlag.Name))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not parse peer address")
+	}
+	options := []libp2p.Option{libp2p.NoListenAddrs}
+	if ctx.Bool(noiseFlag.Name) {
+		options = append(options, libp2p.Security(noise.ID, noise.New))
+	}
+	h, err := libp2p.New(c, options...)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not create libp2p host")
+	}
+	if err := h.Connect(c, *info); err != nil {
+		h.Close()
+		return nil, nil, errors.Wrapf(err, "could not connect to peer %s", info.ID.Pretty())
+	}
+	return h, info, nil
+}
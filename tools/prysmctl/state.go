@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-ssz"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/prysmaticlabs/prysm/shared/statediff"
+	"github.com/urfave/cli"
+)
+
+var stateCommand = cli.Command{
+	Name:  "state",
+	Usage: "inspects beacon states",
+	Subcommands: cli.Commands{
+		{
+			Name: "diff",
+			Usage: "compares two states field by field and prints the fields and list indices which differ. Each " +
+				"state is either the path of an SSZ encoded state, or a 0x prefixed block root whose state is read " +
+				"from the database of a stopped beacon node in --datadir",
+			ArgsUsage: "<state-a> <state-b>",
+			Flags:     []cli.Flag{cmd.DataDirFlag},
+			Action:    diffStates,
+		},
+	},
+}
+
+func diffStates(ctx *cli.Context) error {
+	if ctx.NArg() != 2 {
+		return errors.New("expected two states to compare")
+	}
+	a, err := loadState(ctx, ctx.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	b, err := loadState(ctx, ctx.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	diffs, err := statediff.Diff(a, b)
+	if err != nil {
+		return err
+	}
+	for _, d := range diffs {
+		fmt.Println(d)
+	}
+	fmt.Printf("%d differences\n", len(diffs))
+	return nil
+}
+
+// loadState reads the state of a block root from the database, or an SSZ encoded state file.
+func loadState(ctx *cli.Context, arg string) (*pb.BeaconState, error) {
+	if !strings.HasPrefix(arg, "0x") {
+		// #nosec G304
+		enc, err := ioutil.ReadFile(arg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read state %s", arg)
+		}
+		st := &pb.BeaconState{}
+		if err := ssz.Unmarshal(enc, st); err != nil {
+			return nil, errors.Wrapf(err, "could not ssz unmarshal state %s", arg)
+		}
+		return st, nil
+	}
+	root, err := decodeHex(arg)
+	if err != nil || len(root) != 32 {
+		return nil, fmt.Errorf("invalid block root %s", arg)
+	}
+	beaconDB, err := openDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer beaconDB.Close()
+	var r [32]byte
+	copy(r[:], root)
+	st, err := beaconDB.State(context.Background(), r)
+	if err != nil {
+		return nil, err
+	}
+	if st == nil {
+		return nil, fmt.Errorf("no state found for block root %s", arg)
+	}
+	return st.InnerStateUnsafe(), nil
+}
@@ -3,9 +3,12 @@ package accounts
 import (
 	"bufio"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -30,6 +33,40 @@ func DecryptKeysFromKeystore(directory string, password string) (map[string]*key
 	return validatorKeys, nil
 }
 
+// DeleteKeyFromKeystore removes the files of a keystore directory holding the validator key of
+// the given public key. Returns false if no file holds the key.
+func DeleteKeyFromKeystore(directory string, pubKey []byte) (bool, error) {
+	validatorPrefix := strings.TrimPrefix(params.BeaconConfig().ValidatorPrivkeyFileName, "/")
+	files, err := ioutil.ReadDir(directory)
+	if err != nil {
+		return false, err
+	}
+	deleted := false
+	for _, f := range files {
+		if !f.Mode().IsRegular() || !strings.Contains(f.Name(), validatorPrefix) {
+			continue
+		}
+		path := filepath.Join(directory, f.Name())
+		// #nosec G304
+		enc, err := ioutil.ReadFile(path)
+		if err != nil {
+			return deleted, err
+		}
+		// The public key of a key file is stored in clear next to the encrypted secret key.
+		var keyFile struct {
+			PublicKey string `json:"publickey"`
+		}
+		if err := json.Unmarshal(enc, &keyFile); err != nil || keyFile.PublicKey != hex.EncodeToString(pubKey) {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return deleted, err
+		}
+		deleted = true
+	}
+	return deleted, nil
+}
+
 // VerifyAccountNotExists checks if a validator has not yet created an account
 // and keystore in the provided directory string.
 func VerifyAccountNotExists(directory string, password string) error {
@@ -0,0 +1,404 @@
+package accounts
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/shared/keystore"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/validator/db"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	backupKeystoresDir          = "keystores/"
+	backupSlashingProtectionKey = "slashing-protection.json"
+	backupSaltLength            = 32
+	backupScryptN               = 1 << 18
+	backupScryptR               = 8
+	backupScryptP               = 1
+)
+
+// BackupOpts defines the accounts to back up and where to write the backup.
+type BackupOpts struct {
+	// KeystorePath is the keystore directory of the validator client.
+	KeystorePath string
+	// Password of the validator client keystore.
+	Password string
+	// PubKeys to back up. All the keys of the keystore are backed up if empty.
+	PubKeys [][]byte
+	// DataDir is the data directory of the validator client holding the slashing protection
+	// history of the keys. The history is left out of the backup if empty.
+	DataDir string
+	// BackupDir is the directory the backup file is written to.
+	BackupDir string
+	// BackupPassword encrypts the backup archive. Defaults to the keystore password.
+	BackupPassword string
+}
+
+// RestoreOpts defines the backup to restore and where to restore it.
+type RestoreOpts struct {
+	// BackupFile is the encrypted backup archive written by BackupAccounts.
+	BackupFile string
+	// BackupPassword decrypts the backup archive. Defaults to the keystore password.
+	BackupPassword string
+	// KeystorePath is the keystore directory of the validator client the keys are restored into.
+	KeystorePath string
+	// Password of the validator client keystore, which must be the password the keys were
+	// encrypted with on the machine they were backed up from.
+	Password string
+	// DataDir is the data directory of the validator client the slashing protection history is
+	// restored into. The history is not restored if empty.
+	DataDir string
+}
+
+// slashingProtection is the slashing protection history of a key in the backup archive.
+type slashingProtection struct {
+	PubKey             string         `json:"pubkey"`
+	ProposalHistory    []byte         `json:"proposal_history"`
+	AttestationHistory []byte         `json:"attestation_history"`
+	Watermarks         *db.Watermarks `json:"watermarks"`
+}
+
+// BackupAccounts writes the keys of the keystore along with their slashing protection history
+// into a zip archive encrypted with the backup password, so that validators can be moved to
+// another machine without signing anything their history forbids. Returns the path of the
+// backup file.
+func BackupAccounts(ctx context.Context, opts *BackupOpts) (string, error) {
+	if opts.KeystorePath == "" || opts.Password == "" || opts.BackupDir == "" {
+		return "", errors.New("expected a path to the validator keystore, password and backup directory to be provided")
+	}
+	keys, err := DecryptKeysFromKeystore(opts.KeystorePath, opts.Password)
+	if err != nil {
+		return "", errors.Wrap(err, "could not decrypt the keys of the validator keystore")
+	}
+	selected := make(map[string]*keystore.Key)
+	if len(opts.PubKeys) == 0 {
+		selected = keys
+	}
+	for _, pubKey := range opts.PubKeys {
+		key, ok := keys[hex.EncodeToString(pubKey)]
+		if !ok {
+			return "", errors.Errorf("no key with public key %#x in the validator keystore", pubKey)
+		}
+		selected[hex.EncodeToString(pubKey)] = key
+	}
+
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	var history []*slashingProtection
+	var valDB *db.Store
+	if opts.DataDir != "" {
+		valDB, err = db.NewKVStore(opts.DataDir, nil)
+		if err != nil {
+			return "", errors.Wrap(err, "could not open the validator database")
+		}
+		defer func() {
+			if err := valDB.Close(); err != nil {
+				log.WithError(err).Error("Could not close the validator database")
+			}
+		}()
+	}
+	for pubKeyHex, key := range selected {
+		enc, err := keystore.EncryptKey(key, opts.Password, keystore.StandardScryptN, keystore.StandardScryptP)
+		if err != nil {
+			return "", errors.Wrapf(err, "could not encrypt key 0x%s", pubKeyHex)
+		}
+		name := backupKeystoresDir + strings.TrimPrefix(params.BeaconConfig().ValidatorPrivkeyFileName, "/") + pubKeyHex[:12]
+		if err := writeZipFile(w, name, enc); err != nil {
+			return "", err
+		}
+		if valDB == nil {
+			continue
+		}
+		protection, err := keySlashingProtection(ctx, valDB, key.PublicKey.Marshal())
+		if err != nil {
+			return "", errors.Wrapf(err, "could not read slashing protection history of key 0x%s", pubKeyHex)
+		}
+		history = append(history, protection)
+	}
+	if valDB != nil {
+		enc, err := json.Marshal(history)
+		if err != nil {
+			return "", err
+		}
+		if err := writeZipFile(w, backupSlashingProtectionKey, enc); err != nil {
+			return "", err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	backupPassword := opts.BackupPassword
+	if backupPassword == "" {
+		backupPassword = opts.Password
+	}
+	enc, err := encryptBackup(buf.Bytes(), backupPassword)
+	if err != nil {
+		return "", errors.Wrap(err, "could not encrypt backup")
+	}
+	if err := os.MkdirAll(opts.BackupDir, 0700); err != nil {
+		return "", err
+	}
+	backupFile := filepath.Join(opts.BackupDir, fmt.Sprintf("validator-backup-%d.zip.enc", time.Now().Unix()))
+	if err := ioutil.WriteFile(backupFile, enc, 0600); err != nil {
+		return "", err
+	}
+	log.WithFields(logrus.Fields{
+		"path": backupFile,
+		"keys": len(selected),
+	}).Info("Wrote encrypted accounts backup")
+	return backupFile, nil
+}
+
+// RestoreAccounts restores the keys and the slashing protection history of a backup written by
+// BackupAccounts. Keys already in the keystore are left untouched. The slashing protection
+// history of a key is only replaced if the backup holds a more recent history, and watermarks
+// are only ever raised.
+func RestoreAccounts(ctx context.Context, opts *RestoreOpts) error {
+	if opts.BackupFile == "" || opts.KeystorePath == "" || opts.Password == "" {
+		return errors.New("expected a backup file, a path to the validator keystore and password to be provided")
+	}
+	// #nosec G304
+	enc, err := ioutil.ReadFile(opts.BackupFile)
+	if err != nil {
+		return err
+	}
+	backupPassword := opts.BackupPassword
+	if backupPassword == "" {
+		backupPassword = opts.Password
+	}
+	archive, err := decryptBackup(enc, backupPassword)
+	if err != nil {
+		return errors.Wrap(err, "could not decrypt backup")
+	}
+	r, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return errors.Wrap(err, "could not open backup archive")
+	}
+
+	existing := make(map[string]bool)
+	exists, err := Exists(opts.KeystorePath)
+	if err != nil {
+		return err
+	}
+	if exists {
+		keys, err := DecryptKeysFromKeystore(opts.KeystorePath, opts.Password)
+		if err != nil {
+			return errors.Wrap(err, "could not decrypt the keys of the validator keystore")
+		}
+		for pubKey := range keys {
+			existing[pubKey] = true
+		}
+	}
+
+	ks := keystore.NewKeystore(opts.KeystorePath)
+	var history []*slashingProtection
+	for _, f := range r.File {
+		content, err := readZipFile(f)
+		if err != nil {
+			return err
+		}
+		if f.Name == backupSlashingProtectionKey {
+			if err := json.Unmarshal(content, &history); err != nil {
+				return errors.Wrap(err, "could not decode slashing protection history")
+			}
+			continue
+		}
+		if !strings.HasPrefix(f.Name, backupKeystoresDir) {
+			continue
+		}
+		key, err := keystore.DecryptKey(content, opts.Password)
+		if err != nil {
+			return errors.Wrapf(err, "could not decrypt key %s", f.Name)
+		}
+		pubKeyHex := hex.EncodeToString(key.PublicKey.Marshal())
+		if existing[pubKeyHex] {
+			log.WithField("publicKey", "0x"+pubKeyHex).Warn("Skipping key which is already in the validator keystore")
+			continue
+		}
+		keyFile := opts.KeystorePath + params.BeaconConfig().ValidatorPrivkeyFileName + pubKeyHex[:12]
+		if err := ks.StoreKey(keyFile, key, opts.Password); err != nil {
+			return errors.Wrap(err, "unable to store key")
+		}
+		log.WithField("publicKey", "0x"+pubKeyHex).Info("Restored validator key")
+	}
+
+	if opts.DataDir == "" || len(history) == 0 {
+		return nil
+	}
+	valDB, err := db.NewKVStore(opts.DataDir, nil)
+	if err != nil {
+		return errors.Wrap(err, "could not open the validator database")
+	}
+	defer func() {
+		if err := valDB.Close(); err != nil {
+			log.WithError(err).Error("Could not close the validator database")
+		}
+	}()
+	for _, protection := range history {
+		if err := restoreSlashingProtection(ctx, valDB, protection); err != nil {
+			return errors.Wrapf(err, "could not restore slashing protection history of key %s", protection.PubKey)
+		}
+	}
+	return nil
+}
+
+func keySlashingProtection(ctx context.Context, valDB *db.Store, pubKey []byte) (*slashingProtection, error) {
+	protection := &slashingProtection{PubKey: hex.EncodeToString(pubKey)}
+	proposals, err := valDB.ProposalHistory(ctx, pubKey)
+	if err != nil {
+		return nil, err
+	}
+	if proposals != nil {
+		if protection.ProposalHistory, err = proto.Marshal(proposals); err != nil {
+			return nil, err
+		}
+	}
+	attestations, err := valDB.AttestationHistory(ctx, pubKey)
+	if err != nil {
+		return nil, err
+	}
+	if attestations != nil {
+		if protection.AttestationHistory, err = proto.Marshal(attestations); err != nil {
+			return nil, err
+		}
+	}
+	if protection.Watermarks, err = valDB.Watermarks(ctx, pubKey); err != nil {
+		return nil, err
+	}
+	return protection, nil
+}
+
+func restoreSlashingProtection(ctx context.Context, valDB *db.Store, protection *slashingProtection) error {
+	pubKey, err := hex.DecodeString(protection.PubKey)
+	if err != nil {
+		return err
+	}
+	if protection.ProposalHistory != nil {
+		backup := &slashpb.ProposalHistory{}
+		if err := proto.Unmarshal(protection.ProposalHistory, backup); err != nil {
+			return err
+		}
+		local, err := valDB.ProposalHistory(ctx, pubKey)
+		if err != nil {
+			return err
+		}
+		if local == nil || backup.LatestEpochWritten > local.LatestEpochWritten {
+			if err := valDB.SaveProposalHistory(ctx, pubKey, backup); err != nil {
+				return err
+			}
+		} else {
+			log.WithField("publicKey", "0x"+protection.PubKey).Warn("Keeping more recent local proposal history")
+		}
+	}
+	if protection.AttestationHistory != nil {
+		backup := &slashpb.AttestationHistory{}
+		if err := proto.Unmarshal(protection.AttestationHistory, backup); err != nil {
+			return err
+		}
+		local, err := valDB.AttestationHistory(ctx, pubKey)
+		if err != nil {
+			return err
+		}
+		if local == nil || backup.LatestEpochWritten > local.LatestEpochWritten {
+			if err := valDB.SaveAttestationHistory(ctx, pubKey, backup); err != nil {
+				return err
+			}
+		} else {
+			log.WithField("publicKey", "0x"+protection.PubKey).Warn("Keeping more recent local attestation history")
+		}
+	}
+	if protection.Watermarks != nil {
+		return valDB.RaiseWatermarks(ctx, pubKey, protection.Watermarks)
+	}
+	return nil
+}
+
+// encryptBackup encrypts the archive with AES-256-GCM, using a key derived from the password
+// with scrypt. The salt and nonce are prepended to the ciphertext.
+func encryptBackup(archive []byte, password string) ([]byte, error) {
+	salt := make([]byte, backupSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	gcm, err := backupCipher(password, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	enc := append(salt, nonce...)
+	return gcm.Seal(enc, nonce, archive, nil), nil
+}
+
+func decryptBackup(enc []byte, password string) ([]byte, error) {
+	if len(enc) < backupSaltLength {
+		return nil, errors.New("backup is too short")
+	}
+	gcm, err := backupCipher(password, enc[:backupSaltLength])
+	if err != nil {
+		return nil, err
+	}
+	enc = enc[backupSaltLength:]
+	if len(enc) < gcm.NonceSize() {
+		return nil, errors.New("backup is too short")
+	}
+	archive, err := gcm.Open(nil, enc[:gcm.NonceSize()], enc[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.New("wrong backup password or corrupted backup")
+	}
+	return archive, nil
+}
+
+func backupCipher(password string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(password), salt, backupScryptN, backupScryptR, backupScryptP, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func writeZipFile(w *zip.Writer, name string, content []byte) error {
+	f, err := w.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(content)
+	return err
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	content, err := ioutil.ReadAll(rc)
+	if closeErr := rc.Close(); err == nil {
+		err = closeErr
+	}
+	return content, err
+}
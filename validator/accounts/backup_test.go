@@ -0,0 +1,107 @@
+package accounts
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prysmaticlabs/go-bitfield"
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/shared/keystore"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/validator/db"
+)
+
+func TestBackupAccounts_RestoresSelectedKeysAndHistory(t *testing.T) {
+	ctx := context.Background()
+	dir := testutil.TempDir() + "/backup"
+	defer os.RemoveAll(dir)
+	keystorePath := filepath.Join(dir, "keystore")
+	dataDir := filepath.Join(dir, "data")
+
+	ks := keystore.NewKeystore(keystorePath)
+	keys := make([]*keystore.Key, 2)
+	for i := range keys {
+		key, err := keystore.NewKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[i] = key
+		keyFile := keystorePath + params.BeaconConfig().ValidatorPrivkeyFileName + hex.EncodeToString(key.PublicKey.Marshal())[:12]
+		if err := ks.StoreKey(keyFile, key, "password"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pubKey := keys[0].PublicKey.Marshal()
+	history := &slashpb.ProposalHistory{
+		EpochBits:          bitfield.NewBitlist(params.BeaconConfig().WeakSubjectivityPeriod),
+		LatestEpochWritten: 5,
+	}
+	history.EpochBits.SetBitAt(5, true)
+	valDB, err := db.NewKVStore(dataDir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := valDB.SaveProposalHistory(ctx, pubKey, history); err != nil {
+		t.Fatal(err)
+	}
+	if err := valDB.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	backupFile, err := BackupAccounts(ctx, &BackupOpts{
+		KeystorePath:   keystorePath,
+		Password:       "password",
+		PubKeys:        [][]byte{pubKey},
+		DataDir:        dataDir,
+		BackupDir:      filepath.Join(dir, "backups"),
+		BackupPassword: "backup",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restoredKeystore := filepath.Join(dir, "restored-keystore")
+	restoredDataDir := filepath.Join(dir, "restored-data")
+	opts := &RestoreOpts{
+		BackupFile:     backupFile,
+		BackupPassword: "wrong",
+		KeystorePath:   restoredKeystore,
+		Password:       "password",
+		DataDir:        restoredDataDir,
+	}
+	if err := RestoreAccounts(ctx, opts); err == nil {
+		t.Fatal("Expected restoring with the wrong backup password to fail")
+	}
+	opts.BackupPassword = "backup"
+	if err := RestoreAccounts(ctx, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := DecryptKeysFromKeystore(restoredKeystore, "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(restored) != 1 {
+		t.Fatalf("Wanted 1 restored key, received %d", len(restored))
+	}
+	if _, ok := restored[hex.EncodeToString(pubKey)]; !ok {
+		t.Errorf("Key %#x was not restored", pubKey)
+	}
+	restoredDB, err := db.NewKVStore(restoredDataDir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer restoredDB.Close()
+	restoredHistory, err := restoredDB.ProposalHistory(ctx, pubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(restoredHistory, history) {
+		t.Errorf("Wanted proposal history %v, received %v", history, restoredHistory)
+	}
+}
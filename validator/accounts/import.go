@@ -0,0 +1,279 @@
+package accounts
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/keystore"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/validator/db"
+	"github.com/sirupsen/logrus"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+// ImportOpts defines the keystores to import and where to import them.
+type ImportOpts struct {
+	// KeysDir is a directory, or a zip archive such as the one produced by the deposit launchpad,
+	// holding EIP-2335 keystores. Directories are searched recursively, so the validator directories
+	// of Lighthouse and the key directories of Teku can be imported as is.
+	KeysDir string
+	// KeystorePath is the keystore directory of the validator client the keys are imported into.
+	KeystorePath string
+	// Password of the validator client keystore, also used to decrypt the imported keystores which
+	// have no password file.
+	Password string
+	// PasswordsDir is an optional directory of password files, named after the public key of each
+	// keystore as Lighthouse does, or after the keystore file name with a .txt extension as Teku
+	// does.
+	PasswordsDir string
+	// DryRun decrypts the keystores and reports conflicts without importing any key.
+	DryRun bool
+	// DataDir is the data directory of the validator client the watermarks are saved into.
+	DataDir string
+	// Watermarks are optional slashing protection low watermarks to raise for the imported keys,
+	// so that they never sign below the messages they signed on the machine they come from.
+	Watermarks *db.Watermarks
+}
+
+// ImportResult lists the public keys imported, and the public keys skipped because they are
+// already in the validator client keystore or appear more than once in the imported keystores.
+type ImportResult struct {
+	Imported  [][]byte
+	Conflicts [][]byte
+}
+
+// eip2335Keystore is the JSON encoding of a keystore defined by EIP-2335.
+type eip2335Keystore struct {
+	Crypto  map[string]interface{} `json:"crypto"`
+	Pubkey  string                 `json:"pubkey"`
+	Path    string                 `json:"path"`
+	UUID    string                 `json:"uuid"`
+	Version uint                   `json:"version"`
+}
+
+type keystoreFile struct {
+	name     string
+	keystore *eip2335Keystore
+}
+
+// ImportKeystores imports the EIP-2335 keystores written by other clients and by the deposit CLI
+// into the keystore of the validator client, encrypted with the validator client password.
+func ImportKeystores(opts *ImportOpts) (*ImportResult, error) {
+	if opts.KeysDir == "" || opts.KeystorePath == "" || opts.Password == "" {
+		return nil, errors.New("expected a keys directory, a path to the validator keystore and password to be provided")
+	}
+	if opts.Watermarks != nil && opts.DataDir == "" {
+		return nil, errors.New("expected a data directory to save the watermarks of the imported keys")
+	}
+	files, err := readKeystoreFiles(opts.KeysDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read keystores in %s", opts.KeysDir)
+	}
+	if len(files) == 0 {
+		return nil, errors.Errorf("no keystores found in %s", opts.KeysDir)
+	}
+
+	existing := make(map[string]bool)
+	exists, err := Exists(opts.KeystorePath)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		keys, err := DecryptKeysFromKeystore(opts.KeystorePath, opts.Password)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decrypt the keys of the validator keystore")
+		}
+		for pubKey := range keys {
+			existing[pubKey] = true
+		}
+	}
+
+	ks := keystore.NewKeystore(opts.KeystorePath)
+	encryptor := keystorev4.New()
+	res := &ImportResult{}
+	for _, f := range files {
+		password, err := keystorePassword(opts, f)
+		if err != nil {
+			return nil, err
+		}
+		key, err := decryptKeystore(encryptor, f.keystore, password)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decrypt keystore %s", f.name)
+		}
+		pubKey := key.PublicKey.Marshal()
+		pubKeyHex := hex.EncodeToString(pubKey)
+		if existing[pubKeyHex] {
+			log.WithFields(logrus.Fields{
+				"publicKey": "0x" + pubKeyHex,
+				"keystore":  f.name,
+			}).Warn("Skipping key which is already in the validator keystore")
+			res.Conflicts = append(res.Conflicts, pubKey)
+			continue
+		}
+		existing[pubKeyHex] = true
+		if !opts.DryRun {
+			keyFile := opts.KeystorePath + params.BeaconConfig().ValidatorPrivkeyFileName + pubKeyHex[:12]
+			if err := ks.StoreKey(keyFile, key, opts.Password); err != nil {
+				return nil, errors.Wrap(err, "unable to store key")
+			}
+		}
+		log.WithFields(logrus.Fields{
+			"publicKey": "0x" + pubKeyHex,
+			"keystore":  f.name,
+			"dryRun":    opts.DryRun,
+		}).Info("Imported validator key")
+		res.Imported = append(res.Imported, pubKey)
+	}
+	if opts.DryRun || opts.Watermarks == nil || len(res.Imported) == 0 {
+		return res, nil
+	}
+	if err := raiseWatermarks(context.Background(), opts.DataDir, res.Imported, opts.Watermarks); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// raiseWatermarks raises the slashing protection low watermarks of the public keys in the
+// validator database of the data directory.
+func raiseWatermarks(ctx context.Context, dataDir string, pubKeys [][]byte, watermarks *db.Watermarks) error {
+	valDB, err := db.NewKVStore(dataDir, nil)
+	if err != nil {
+		return errors.Wrap(err, "could not open the validator database")
+	}
+	defer func() {
+		if err := valDB.Close(); err != nil {
+			log.WithError(err).Error("Could not close the validator database")
+		}
+	}()
+	for _, pubKey := range pubKeys {
+		if err := valDB.RaiseWatermarks(ctx, pubKey, watermarks); err != nil {
+			return errors.Wrapf(err, "could not raise the watermarks of key %#x", pubKey)
+		}
+	}
+	log.WithFields(logrus.Fields{
+		"minSourceEpoch": watermarks.MinSourceEpoch,
+		"minTargetEpoch": watermarks.MinTargetEpoch,
+		"minBlockSlot":   watermarks.MinBlockSlot,
+	}).Info("Raised slashing protection low watermarks of the imported keys")
+	return nil
+}
+
+// DecryptKeystore decrypts the validator key of a JSON encoded EIP-2335 keystore.
+func DecryptKeystore(enc []byte, password string) (*keystore.Key, error) {
+	ks := &eip2335Keystore{}
+	if err := json.Unmarshal(enc, ks); err != nil {
+		return nil, errors.Wrap(err, "could not decode keystore")
+	}
+	if ks.Crypto == nil {
+		return nil, errors.New("not an EIP-2335 keystore")
+	}
+	return decryptKeystore(keystorev4.New(), ks, password)
+}
+
+func decryptKeystore(encryptor *keystorev4.Encryptor, ks *eip2335Keystore, password string) (*keystore.Key, error) {
+	secret, err := encryptor.Decrypt(ks.Crypto, password)
+	if err != nil {
+		return nil, err
+	}
+	secretKey, err := bls.SecretKeyFromBytes(secret)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid secret key")
+	}
+	key, err := keystore.NewKeyFromBLS(secretKey)
+	if err != nil {
+		return nil, err
+	}
+	if declared := strings.TrimPrefix(ks.Pubkey, "0x"); declared != "" && declared != hex.EncodeToString(key.PublicKey.Marshal()) {
+		return nil, errors.New("public key of keystore does not match its secret key")
+	}
+	return key, nil
+}
+
+// readKeystoreFiles reads the EIP-2335 keystores of a directory and its subdirectories, or of a
+// zip archive. JSON files which are not keystores, such as deposit data files, are skipped.
+func readKeystoreFiles(path string) ([]*keystoreFile, error) {
+	var files []*keystoreFile
+	addFile := func(name string, enc []byte) {
+		ks := &eip2335Keystore{}
+		if err := json.Unmarshal(enc, ks); err != nil || ks.Crypto == nil {
+			log.WithField("file", name).Debug("Skipping file which is not an EIP-2335 keystore")
+			return
+		}
+		files = append(files, &keystoreFile{name: name, keystore: ks})
+	}
+
+	if strings.HasSuffix(path, ".zip") {
+		r, err := zip.OpenReader(path)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err := r.Close(); err != nil {
+				log.WithError(err).Error("Could not close zip archive")
+			}
+		}()
+		for _, f := range r.File {
+			if f.FileInfo().IsDir() || filepath.Ext(f.Name) != ".json" {
+				continue
+			}
+			enc, err := readZipFile(f)
+			if err != nil {
+				return nil, err
+			}
+			addFile(f.Name, enc)
+		}
+		return files, nil
+	}
+
+	err := filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(p) != ".json" {
+			return nil
+		}
+		// #nosec G304
+		enc, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		addFile(p, enc)
+		return nil
+	})
+	return files, err
+}
+
+// keystorePassword finds the password file of a keystore in the passwords directory, falling
+// back to the validator client password.
+func keystorePassword(opts *ImportOpts, f *keystoreFile) (string, error) {
+	if opts.PasswordsDir == "" {
+		return opts.Password, nil
+	}
+	pubKey := strings.TrimPrefix(f.keystore.Pubkey, "0x")
+	base := strings.TrimSuffix(filepath.Base(f.name), filepath.Ext(f.name))
+	var candidates []string
+	if pubKey != "" {
+		candidates = append(candidates, "0x"+pubKey, pubKey)
+	}
+	candidates = append(candidates, base+".txt", base)
+	for _, name := range candidates {
+		// #nosec G304
+		enc, err := ioutil.ReadFile(filepath.Join(opts.PasswordsDir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", errors.Wrapf(err, "could not read password file of keystore %s", f.name)
+		}
+		return strings.TrimRight(string(enc), "\r\n"), nil
+	}
+	return opts.Password, nil
+}
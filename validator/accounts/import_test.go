@@ -0,0 +1,174 @@
+package accounts
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/validator/db"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+func encryptedKeystore(t *testing.T, secretKey *bls.SecretKey, password string) []byte {
+	crypto, err := keystorev4.New().Encrypt(secretKey.Marshal(), password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc, err := json.Marshal(map[string]interface{}{
+		"crypto":  crypto,
+		"pubkey":  hex.EncodeToString(secretKey.PublicKey().Marshal()),
+		"path":    "m/12381/3600/0/0/0",
+		"uuid":    "5e5a0e5c-b3f4-4a36-9e6e-6b5c0b1f3c4d",
+		"version": 4,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return enc
+}
+
+func TestImportKeystores_LighthouseDirectory(t *testing.T) {
+	dir := testutil.TempDir() + "/import"
+	defer os.RemoveAll(dir)
+	keysDir := filepath.Join(dir, "validators")
+	secretsDir := filepath.Join(dir, "secrets")
+	keystorePath := filepath.Join(dir, "keystore")
+	if err := os.MkdirAll(secretsDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each keystore is in a directory named after its public key, along with a password file
+	// of the same name in the secrets directory.
+	secretKeys := []*bls.SecretKey{bls.RandKey(), bls.RandKey()}
+	for i, secretKey := range secretKeys {
+		name := "0x" + hex.EncodeToString(secretKey.PublicKey().Marshal())
+		password := "password" + strconv.Itoa(i)
+		if err := os.MkdirAll(filepath.Join(keysDir, name), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(keysDir, name, "voting-keystore.json"), encryptedKeystore(t, secretKey, password), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(secretsDir, name), []byte(password+"\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	opts := &ImportOpts{
+		KeysDir:      keysDir,
+		KeystorePath: keystorePath,
+		Password:     "prysm",
+		PasswordsDir: secretsDir,
+		DryRun:       true,
+	}
+	res, err := ImportKeystores(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Imported) != 2 || len(res.Conflicts) != 0 {
+		t.Fatalf("Wanted 2 keys to import without conflicts, received %d and %d conflicts", len(res.Imported), len(res.Conflicts))
+	}
+	if exists, _ := Exists(keystorePath); exists {
+		t.Fatal("Dry run imported keys")
+	}
+
+	opts.DryRun = false
+	opts.DataDir = filepath.Join(dir, "data")
+	opts.Watermarks = &db.Watermarks{MinSourceEpoch: 10, MinTargetEpoch: 11, MinBlockSlot: 352}
+	if _, err := ImportKeystores(opts); err != nil {
+		t.Fatal(err)
+	}
+	valDB, err := db.NewKVStore(opts.DataDir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secretKey := range secretKeys {
+		watermarks, err := valDB.Watermarks(context.Background(), secretKey.PublicKey().Marshal())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if watermarks == nil || *watermarks != *opts.Watermarks {
+			t.Errorf("Wanted watermarks %v for key %#x, received %v", opts.Watermarks, secretKey.PublicKey().Marshal(), watermarks)
+		}
+	}
+	if err := valDB.Close(); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := DecryptKeysFromKeystore(keystorePath, "prysm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secretKey := range secretKeys {
+		key, ok := keys[hex.EncodeToString(secretKey.PublicKey().Marshal())]
+		if !ok {
+			t.Fatalf("Key %#x was not imported", secretKey.PublicKey().Marshal())
+		}
+		if !bytes.Equal(key.SecretKey.Marshal(), secretKey.Marshal()) {
+			t.Errorf("Wanted secret key %#x, received %#x", secretKey.Marshal(), key.SecretKey.Marshal())
+		}
+	}
+
+	// Importing the keys again reports the conflicts with the existing keys.
+	opts.DryRun = true
+	res, err = ImportKeystores(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Imported) != 0 || len(res.Conflicts) != 2 {
+		t.Errorf("Wanted 2 conflicts, received %d imported keys and %d conflicts", len(res.Imported), len(res.Conflicts))
+	}
+}
+
+func TestImportKeystores_LaunchpadZip(t *testing.T) {
+	dir := testutil.TempDir() + "/import-zip"
+	defer os.RemoveAll(dir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	zipPath := filepath.Join(dir, "validator_keys.zip")
+	keystorePath := filepath.Join(dir, "keystore")
+
+	secretKey := bls.RandKey()
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	files := map[string][]byte{
+		"validator_keys/keystore-m_12381_3600_0_0_0-1596485378.json": encryptedKeystore(t, secretKey, "launchpad"),
+		"validator_keys/deposit_data-1596485378.json":                []byte(`[{"pubkey": "00"}]`),
+	}
+	for name, enc := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write(enc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(zipPath, buf.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := ImportKeystores(&ImportOpts{
+		KeysDir:      zipPath,
+		KeystorePath: keystorePath,
+		Password:     "launchpad",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Imported) != 1 || !bytes.Equal(res.Imported[0], secretKey.PublicKey().Marshal()) {
+		t.Errorf("Wanted key %#x to be imported, received %#x", secretKey.PublicKey().Marshal(), res.Imported)
+	}
+}
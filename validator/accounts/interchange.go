@@ -0,0 +1,178 @@
+package accounts
+
+import (
+	"context"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/validator/db"
+)
+
+// InterchangeFormatVersion is the version of the EIP-3076 slashing protection interchange format.
+const InterchangeFormatVersion = "5"
+
+// Interchange is the slashing protection history of keys in the interchange format of EIP-3076,
+// used to move validators between clients.
+type Interchange struct {
+	Metadata *InterchangeMetadata `json:"metadata"`
+	Data     []*InterchangeData   `json:"data"`
+}
+
+// InterchangeMetadata describes the network of an interchange.
+type InterchangeMetadata struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+	GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+}
+
+// InterchangeData is the slashing protection history of a key.
+type InterchangeData struct {
+	Pubkey             string                    `json:"pubkey"`
+	SignedBlocks       []*InterchangeBlock       `json:"signed_blocks"`
+	SignedAttestations []*InterchangeAttestation `json:"signed_attestations"`
+}
+
+// InterchangeBlock is a block signed by a key.
+type InterchangeBlock struct {
+	Slot        string `json:"slot"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// InterchangeAttestation is an attestation signed by a key.
+type InterchangeAttestation struct {
+	SourceEpoch string `json:"source_epoch"`
+	TargetEpoch string `json:"target_epoch"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// ExportSlashingProtection exports the slashing protection history of the keys in the interchange
+// format. The proposal history only records the epochs blocks were signed in, so every signed block
+// is exported at the last slot of its epoch, and the low watermarks of the keys are exported as a
+// block and an attestation just below them. The genesis validators root is left empty as the
+// validator client does not track it.
+func ExportSlashingProtection(ctx context.Context, valDB *db.Store, pubKeys [][]byte) (*Interchange, error) {
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	wsPeriod := params.BeaconConfig().WeakSubjectivityPeriod
+	interchange := &Interchange{
+		Metadata: &InterchangeMetadata{InterchangeFormatVersion: InterchangeFormatVersion},
+		Data:     make([]*InterchangeData, 0, len(pubKeys)),
+	}
+	for _, pubKey := range pubKeys {
+		data := &InterchangeData{
+			Pubkey:             "0x" + hex.EncodeToString(pubKey),
+			SignedBlocks:       []*InterchangeBlock{},
+			SignedAttestations: []*InterchangeAttestation{},
+		}
+		proposals, err := valDB.ProposalHistory(ctx, pubKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read proposal history of key %#x", pubKey)
+		}
+		if proposals != nil && proposals.EpochBits != nil {
+			first := uint64(0)
+			if proposals.LatestEpochWritten >= wsPeriod {
+				first = proposals.LatestEpochWritten - wsPeriod + 1
+			}
+			for epoch := first; epoch <= proposals.LatestEpochWritten; epoch++ {
+				if proposals.EpochBits.BitAt(epoch % wsPeriod) {
+					data.SignedBlocks = append(data.SignedBlocks, &InterchangeBlock{
+						Slot: strconv.FormatUint((epoch+1)*slotsPerEpoch-1, 10),
+					})
+				}
+			}
+		}
+		attestations, err := valDB.AttestationHistory(ctx, pubKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read attestation history of key %#x", pubKey)
+		}
+		if attestations != nil {
+			targets := make([]uint64, 0, len(attestations.TargetToSource))
+			for target, source := range attestations.TargetToSource {
+				// Epochs without attestations are marked with a far future source.
+				if source != params.BeaconConfig().FarFutureEpoch {
+					targets = append(targets, target)
+				}
+			}
+			sort.Slice(targets, func(i, j int) bool { return targets[i] < targets[j] })
+			for _, target := range targets {
+				data.SignedAttestations = append(data.SignedAttestations, &InterchangeAttestation{
+					SourceEpoch: strconv.FormatUint(attestations.TargetToSource[target], 10),
+					TargetEpoch: strconv.FormatUint(target, 10),
+				})
+			}
+		}
+		watermarks, err := valDB.Watermarks(ctx, pubKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read watermarks of key %#x", pubKey)
+		}
+		if watermarks != nil {
+			if watermarks.MinBlockSlot > 0 {
+				data.SignedBlocks = append(data.SignedBlocks, &InterchangeBlock{
+					Slot: strconv.FormatUint(watermarks.MinBlockSlot-1, 10),
+				})
+			}
+			if watermarks.MinTargetEpoch > 0 {
+				target := watermarks.MinTargetEpoch - 1
+				source := watermarks.MinSourceEpoch
+				if source > target {
+					source = target
+				}
+				data.SignedAttestations = append(data.SignedAttestations, &InterchangeAttestation{
+					SourceEpoch: strconv.FormatUint(source, 10),
+					TargetEpoch: strconv.FormatUint(target, 10),
+				})
+			}
+		}
+		interchange.Data = append(interchange.Data, data)
+	}
+	return interchange, nil
+}
+
+// ImportSlashingProtection imports the slashing protection history of an interchange by raising the
+// low watermarks of each key above the blocks and attestations it signed: blocks may only be signed
+// after the highest signed slot, and attestations may not have a source lower than the highest
+// signed source nor a target lower or equal to the highest signed target. This is the minimal
+// import strategy of EIP-3076.
+func ImportSlashingProtection(ctx context.Context, valDB *db.Store, interchange *Interchange) error {
+	if interchange.Metadata != nil && interchange.Metadata.InterchangeFormatVersion != InterchangeFormatVersion {
+		return errors.Errorf("unsupported interchange format version %q, wanted %q", interchange.Metadata.InterchangeFormatVersion, InterchangeFormatVersion)
+	}
+	for _, data := range interchange.Data {
+		pubKey, err := hex.DecodeString(strings.TrimPrefix(data.Pubkey, "0x"))
+		if err != nil || len(pubKey) != params.BeaconConfig().BLSPubkeyLength {
+			return errors.Errorf("invalid public key %q", data.Pubkey)
+		}
+		watermarks := &db.Watermarks{}
+		for _, blk := range data.SignedBlocks {
+			slot, err := strconv.ParseUint(blk.Slot, 10, 64)
+			if err != nil {
+				return errors.Wrapf(err, "invalid block slot of key %s", data.Pubkey)
+			}
+			if slot+1 > watermarks.MinBlockSlot {
+				watermarks.MinBlockSlot = slot + 1
+			}
+		}
+		for _, att := range data.SignedAttestations {
+			source, err := strconv.ParseUint(att.SourceEpoch, 10, 64)
+			if err != nil {
+				return errors.Wrapf(err, "invalid attestation source epoch of key %s", data.Pubkey)
+			}
+			target, err := strconv.ParseUint(att.TargetEpoch, 10, 64)
+			if err != nil {
+				return errors.Wrapf(err, "invalid attestation target epoch of key %s", data.Pubkey)
+			}
+			if source > watermarks.MinSourceEpoch {
+				watermarks.MinSourceEpoch = source
+			}
+			if target+1 > watermarks.MinTargetEpoch {
+				watermarks.MinTargetEpoch = target + 1
+			}
+		}
+		if err := valDB.RaiseWatermarks(ctx, pubKey, watermarks); err != nil {
+			return errors.Wrapf(err, "could not raise watermarks of key %s", data.Pubkey)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/tlsutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// eth1WithdrawalPrefixByte prefixes the withdrawal credentials of validators withdrawing to an
+// eth1 address, which is held in the last 20 bytes of the credentials.
+const eth1WithdrawalPrefixByte = byte(1)
+
+// ListOpts defines the accounts to list and the beacon node their status is queried from.
+type ListOpts struct {
+	// KeystorePath is the keystore directory of the validator client.
+	KeystorePath string
+	// Password of the validator client keystore.
+	Password string
+	// Detailed queries the beacon node for the status and withdrawal credentials of the accounts.
+	Detailed bool
+	// BeaconRPCProvider is the beacon node endpoint, or a comma separated list of endpoints of
+	// which the first one is used.
+	BeaconRPCProvider string
+	// Cert is the certificate authority of the beacon node. The connection is insecure if empty.
+	Cert string
+	// ExpectedWithdrawalCredentials is the prefix the withdrawal credentials of the accounts are
+	// expected to start with. Defaults to the BLS withdrawal prefix.
+	ExpectedWithdrawalCredentials []byte
+}
+
+// AccountStatus is the on-chain status and withdrawal credentials of an account.
+type AccountStatus struct {
+	PublicKey []byte
+	Status    ethpb.ValidatorStatus
+	// WithdrawalCredentials are empty if the deposit of the account is not processed yet.
+	WithdrawalCredentials []byte
+	// WithdrawalType is bls, eth1 or unknown, depending on the prefix of the withdrawal credentials.
+	WithdrawalType string
+	// WithdrawalTarget is the hash of the BLS withdrawal key or the eth1 withdrawal address.
+	WithdrawalTarget []byte
+	// UnexpectedWithdrawal is true if the withdrawal credentials do not start with the expected prefix.
+	UnexpectedWithdrawal bool
+}
+
+// ListAccounts writes the public keys of the accounts of the keystore to stdout, along with
+// their status and withdrawal credentials in detailed mode.
+func ListAccounts(ctx context.Context, opts *ListOpts) error {
+	if opts.KeystorePath == "" || opts.Password == "" {
+		return errors.New("expected a path to the validator keystore and password to be provided")
+	}
+	pubKeys, err := keystorePubKeys(opts.KeystorePath, opts.Password)
+	if err != nil {
+		return err
+	}
+	if !opts.Detailed {
+		for _, pubKey := range pubKeys {
+			fmt.Printf("%#x\n", pubKey)
+		}
+		return nil
+	}
+
+	conn, err := dialBeaconNode(ctx, opts)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.WithError(err).Error("Could not close the connection to the beacon node")
+		}
+	}()
+	expected := opts.ExpectedWithdrawalCredentials
+	if len(expected) == 0 {
+		expected = []byte{params.BeaconConfig().BLSWithdrawalPrefixByte}
+	}
+	statuses, err := AccountStatuses(ctx, pubKeys, ethpb.NewBeaconNodeValidatorClient(conn), ethpb.NewBeaconChainClient(conn), expected)
+	if err != nil {
+		return err
+	}
+	return writeAccountStatuses(os.Stdout, statuses)
+}
+
+// AccountStatuses queries the beacon node for the status and withdrawal credentials of the
+// public keys, and flags the keys whose withdrawal credentials do not start with the expected
+// prefix.
+func AccountStatuses(
+	ctx context.Context,
+	pubKeys [][]byte,
+	validatorClient ethpb.BeaconNodeValidatorClient,
+	beaconClient ethpb.BeaconChainClient,
+	expectedPrefix []byte,
+) ([]*AccountStatus, error) {
+	statuses := make([]*AccountStatus, 0, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		res, err := validatorClient.ValidatorStatus(ctx, &ethpb.ValidatorStatusRequest{PublicKey: pubKey})
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get the status of validator %#x", pubKey)
+		}
+		accountStatus := &AccountStatus{
+			PublicKey: pubKey,
+			Status:    res.Status,
+		}
+		statuses = append(statuses, accountStatus)
+
+		val, err := beaconClient.GetValidator(ctx, &ethpb.GetValidatorRequest{
+			QueryFilter: &ethpb.GetValidatorRequest_PublicKey{PublicKey: pubKey},
+		})
+		if err != nil {
+			if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+				continue
+			}
+			return nil, errors.Wrapf(err, "could not get validator %#x", pubKey)
+		}
+		accountStatus.WithdrawalCredentials = val.WithdrawalCredentials
+		accountStatus.WithdrawalType, accountStatus.WithdrawalTarget = withdrawalTarget(val.WithdrawalCredentials)
+		accountStatus.UnexpectedWithdrawal = !bytes.HasPrefix(val.WithdrawalCredentials, expectedPrefix)
+	}
+	return statuses, nil
+}
+
+// withdrawalTarget returns the type of the withdrawal credentials and what they withdraw to.
+func withdrawalTarget(creds []byte) (string, []byte) {
+	if len(creds) != 32 {
+		return "unknown", nil
+	}
+	switch creds[0] {
+	case params.BeaconConfig().BLSWithdrawalPrefixByte:
+		return "bls", creds[1:]
+	case eth1WithdrawalPrefixByte:
+		return "eth1", creds[12:]
+	default:
+		return "unknown", creds[1:]
+	}
+}
+
+// writeAccountStatuses writes the account statuses as a table, with a warning for the accounts
+// whose withdrawal credentials are unexpected.
+func writeAccountStatuses(out io.Writer, statuses []*AccountStatus) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "PUBLIC KEY\tSTATUS\tWITHDRAWAL TYPE\tWITHDRAWAL TARGET\t")
+	unexpected := 0
+	for _, s := range statuses {
+		withdrawalType, target, flag := "-", "-", ""
+		if len(s.WithdrawalCredentials) > 0 {
+			withdrawalType = s.WithdrawalType
+			target = "0x" + hex.EncodeToString(s.WithdrawalTarget)
+		}
+		if s.UnexpectedWithdrawal {
+			flag = "UNEXPECTED"
+			unexpected++
+		}
+		fmt.Fprintf(w, "%#x\t%s\t%s\t%s\t%s\n", s.PublicKey, s.Status, withdrawalType, target, flag)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if unexpected > 0 {
+		log.WithField("accounts", unexpected).Warn("Accounts have withdrawal credentials which do not match the expected prefix")
+	}
+	return nil
+}
+
+// keystorePubKeys returns the sorted public keys of the accounts of the keystore.
+func keystorePubKeys(keystorePath string, password string) ([][]byte, error) {
+	keys, err := DecryptKeysFromKeystore(keystorePath, password)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decrypt the keys of the validator keystore")
+	}
+	pubKeys := make([][]byte, 0, len(keys))
+	for _, key := range keys {
+		pubKeys = append(pubKeys, key.PublicKey.Marshal())
+	}
+	sort.Slice(pubKeys, func(i, j int) bool {
+		return bytes.Compare(pubKeys[i], pubKeys[j]) < 0
+	})
+	return pubKeys, nil
+}
+
+// dialBeaconNode connects to the first beacon node endpoint of the options.
+func dialBeaconNode(ctx context.Context, opts *ListOpts) (*grpc.ClientConn, error) {
+	endpoint := strings.TrimSpace(strings.Split(opts.BeaconRPCProvider, ",")[0])
+	if endpoint == "" {
+		return nil, errors.New("no beacon node endpoint configured")
+	}
+	dialOpt := grpc.WithInsecure()
+	if opts.Cert != "" {
+		pool, err := tlsutil.LoadCertPool(opts.Cert)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not load the beacon node certificate")
+		}
+		dialOpt = grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+			RootCAs:    pool,
+			MinVersion: tls.VersionTLS12,
+		}))
+	}
+	conn, err := grpc.DialContext(ctx, endpoint, dialOpt)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not dial endpoint %s", endpoint)
+	}
+	return conn, nil
+}
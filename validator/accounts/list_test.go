@@ -0,0 +1,77 @@
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/mock"
+	"github.com/prysmaticlabs/prysm/validator/internal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAccountStatuses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	validatorClient := internal.NewMockBeaconNodeValidatorClient(ctrl)
+	beaconClient := mock.NewMockBeaconChainClient(ctrl)
+
+	blsKey := bytes.Repeat([]byte{1}, 48)
+	eth1Key := bytes.Repeat([]byte{2}, 48)
+	depositedKey := bytes.Repeat([]byte{3}, 48)
+	blsCreds := append([]byte{0}, bytes.Repeat([]byte{0xaa}, 31)...)
+	address := bytes.Repeat([]byte{0xbb}, 20)
+	eth1Creds := append(append([]byte{1}, make([]byte, 11)...), address...)
+
+	validatorClient.EXPECT().ValidatorStatus(gomock.Any(), &ethpb.ValidatorStatusRequest{PublicKey: blsKey}).
+		Return(&ethpb.ValidatorStatusResponse{Status: ethpb.ValidatorStatus_ACTIVE}, nil)
+	validatorClient.EXPECT().ValidatorStatus(gomock.Any(), &ethpb.ValidatorStatusRequest{PublicKey: eth1Key}).
+		Return(&ethpb.ValidatorStatusResponse{Status: ethpb.ValidatorStatus_EXITED}, nil)
+	validatorClient.EXPECT().ValidatorStatus(gomock.Any(), &ethpb.ValidatorStatusRequest{PublicKey: depositedKey}).
+		Return(&ethpb.ValidatorStatusResponse{Status: ethpb.ValidatorStatus_DEPOSITED}, nil)
+	beaconClient.EXPECT().GetValidator(gomock.Any(), &ethpb.GetValidatorRequest{
+		QueryFilter: &ethpb.GetValidatorRequest_PublicKey{PublicKey: blsKey},
+	}).Return(&ethpb.Validator{PublicKey: blsKey, WithdrawalCredentials: blsCreds}, nil)
+	beaconClient.EXPECT().GetValidator(gomock.Any(), &ethpb.GetValidatorRequest{
+		QueryFilter: &ethpb.GetValidatorRequest_PublicKey{PublicKey: eth1Key},
+	}).Return(&ethpb.Validator{PublicKey: eth1Key, WithdrawalCredentials: eth1Creds}, nil)
+	beaconClient.EXPECT().GetValidator(gomock.Any(), &ethpb.GetValidatorRequest{
+		QueryFilter: &ethpb.GetValidatorRequest_PublicKey{PublicKey: depositedKey},
+	}).Return(nil, status.Error(codes.NotFound, "validator not found"))
+
+	statuses, err := AccountStatuses(
+		context.Background(),
+		[][]byte{blsKey, eth1Key, depositedKey},
+		validatorClient,
+		beaconClient,
+		[]byte{0},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 3 {
+		t.Fatalf("Wanted 3 statuses, received %d", len(statuses))
+	}
+
+	bls := statuses[0]
+	if bls.Status != ethpb.ValidatorStatus_ACTIVE || bls.WithdrawalType != "bls" || bls.UnexpectedWithdrawal {
+		t.Errorf("Unexpected status of the BLS withdrawal key: %+v", bls)
+	}
+	if !bytes.Equal(bls.WithdrawalTarget, blsCreds[1:]) {
+		t.Errorf("Wanted target %#x, received %#x", blsCreds[1:], bls.WithdrawalTarget)
+	}
+	eth1 := statuses[1]
+	if eth1.WithdrawalType != "eth1" || !eth1.UnexpectedWithdrawal {
+		t.Errorf("Unexpected status of the eth1 withdrawal key: %+v", eth1)
+	}
+	if !bytes.Equal(eth1.WithdrawalTarget, address) {
+		t.Errorf("Wanted target %#x, received %#x", address, eth1.WithdrawalTarget)
+	}
+	deposited := statuses[2]
+	if deposited.Status != ethpb.ValidatorStatus_DEPOSITED || len(deposited.WithdrawalCredentials) != 0 || deposited.UnexpectedWithdrawal {
+		t.Errorf("Unexpected status of the deposited key: %+v", deposited)
+	}
+}
@@ -0,0 +1,223 @@
+// Package alerts notifies an external webhook, such as the PagerDuty events API, when a
+// validator keeps missing attestations or losing balance, so that operators learn about a
+// degraded validator without watching its logs or metrics.
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/version"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// MissedAttestations is the kind of alert fired when a validator misses too many
+	// attestations within the configured window of epochs.
+	MissedAttestations = "missed_attestations"
+	// BalanceLoss is the kind of alert fired when the balance of a validator decreased for
+	// too many consecutive epochs.
+	BalanceLoss = "balance_loss"
+)
+
+// requestTimeout bounds how long a single post to the alert URL may take.
+const requestTimeout = 10 * time.Second
+
+// Config for the alerter. A window or number of balance loss epochs of 0 disables the
+// corresponding alert.
+type Config struct {
+	// URL alerts are posted to as JSON.
+	URL string
+	// PagerDutyRoutingKey formats the alerts as PagerDuty events API v2 events, routed with
+	// the key, instead of the plain JSON alert.
+	PagerDutyRoutingKey string
+	// MissedAttestations is the number of attestations a validator may miss within
+	// MissedAttestationsWindow epochs before an alert is fired.
+	MissedAttestations       uint64
+	MissedAttestationsWindow uint64
+	// BalanceLossEpochs is the number of consecutive epochs the balance of a validator has
+	// to decrease for an alert to be fired.
+	BalanceLossEpochs uint64
+}
+
+// Performance is the performance of a validator in an epoch, as reported by the beacon node.
+type Performance struct {
+	PublicKey     [48]byte
+	Included      bool
+	BalanceBefore uint64
+	BalanceAfter  uint64
+}
+
+// Alert is the JSON document posted to the alert URL when a validator starts or stops
+// meeting the conditions of an alert.
+type Alert struct {
+	Kind      string `json:"kind"`
+	PublicKey string `json:"public_key"`
+	Epoch     uint64 `json:"epoch"`
+	Message   string `json:"message"`
+	// Resolved is true if the validator no longer meets the conditions of the alert.
+	Resolved bool `json:"resolved"`
+}
+
+// pagerDutyEvent is an event of the PagerDuty events API v2.
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary  string `json:"summary"`
+	Source   string `json:"source"`
+	Severity string `json:"severity"`
+}
+
+// validatorState is the recent performance of a validator.
+type validatorState struct {
+	// missed holds whether the attestation of each of the last epochs of the window was
+	// missed, as a ring buffer indexed by epoch.
+	missed            []bool
+	consecutiveLosses uint64
+	missedFiring      bool
+	lossFiring        bool
+}
+
+// Alerter tracks the performance of the validators every epoch, and posts an alert when a
+// validator starts or stops meeting the conditions of an alert.
+type Alerter struct {
+	cfg        *Config
+	client     *http.Client
+	lock       sync.Mutex
+	validators map[[48]byte]*validatorState
+}
+
+// NewAlerter creates a new alerter.
+func NewAlerter(cfg *Config) *Alerter {
+	return &Alerter{
+		cfg:        cfg,
+		client:     &http.Client{Timeout: requestTimeout},
+		validators: make(map[[48]byte]*validatorState),
+	}
+}
+
+// Observe records the performance of the validators in the epoch and returns the alerts fired
+// or resolved by it.
+func (a *Alerter) Observe(epoch uint64, performances []*Performance) []*Alert {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	var alerts []*Alert
+	for _, p := range performances {
+		s, ok := a.validators[p.PublicKey]
+		if !ok {
+			s = &validatorState{missed: make([]bool, a.cfg.MissedAttestationsWindow)}
+			a.validators[p.PublicKey] = s
+		}
+		pubKey := fmt.Sprintf("%#x", p.PublicKey)
+
+		if a.cfg.MissedAttestationsWindow > 0 {
+			s.missed[epoch%a.cfg.MissedAttestationsWindow] = !p.Included
+			missed := uint64(0)
+			for _, m := range s.missed {
+				if m {
+					missed++
+				}
+			}
+			if firing := missed > a.cfg.MissedAttestations; firing != s.missedFiring {
+				s.missedFiring = firing
+				alerts = append(alerts, &Alert{
+					Kind:      MissedAttestations,
+					PublicKey: pubKey,
+					Epoch:     epoch,
+					Message: fmt.Sprintf("Validator %s missed %d attestations in the last %d epochs",
+						pubKey, missed, a.cfg.MissedAttestationsWindow),
+					Resolved: !firing,
+				})
+			}
+		}
+
+		if a.cfg.BalanceLossEpochs > 0 {
+			if p.BalanceAfter < p.BalanceBefore {
+				s.consecutiveLosses++
+			} else {
+				s.consecutiveLosses = 0
+			}
+			if firing := s.consecutiveLosses >= a.cfg.BalanceLossEpochs; firing != s.lossFiring {
+				s.lossFiring = firing
+				alerts = append(alerts, &Alert{
+					Kind:      BalanceLoss,
+					PublicKey: pubKey,
+					Epoch:     epoch,
+					Message: fmt.Sprintf("Validator %s lost balance for %d consecutive epochs",
+						pubKey, s.consecutiveLosses),
+					Resolved: !firing,
+				})
+			}
+		}
+	}
+	return alerts
+}
+
+// Send posts the alerts to the alert URL, one request per alert.
+func (a *Alerter) Send(ctx context.Context, alerts []*Alert) error {
+	for _, alert := range alerts {
+		if err := a.post(ctx, alert); err != nil {
+			return err
+		}
+		log.WithFields(logrus.Fields{
+			"kind":     alert.Kind,
+			"pubKey":   alert.PublicKey,
+			"resolved": alert.Resolved,
+		}).Info("Sent validator alert")
+	}
+	return nil
+}
+
+func (a *Alerter) post(ctx context.Context, alert *Alert) error {
+	var body interface{} = alert
+	if a.cfg.PagerDutyRoutingKey != "" {
+		event := &pagerDutyEvent{
+			RoutingKey:  a.cfg.PagerDutyRoutingKey,
+			EventAction: "trigger",
+			DedupKey:    alert.Kind + "-" + alert.PublicKey,
+			Payload: &pagerDutyPayload{
+				Summary:  alert.Message,
+				Source:   "prysm-validator " + version.GetVersion(),
+				Severity: "warning",
+			},
+		}
+		if alert.Resolved {
+			event.EventAction = "resolve"
+			event.Payload = nil
+		}
+		body = event
+	}
+	enc, err := json.Marshal(body)
+	if err != nil {
+		return errors.Wrap(err, "could not encode alert")
+	}
+	req, err := http.NewRequest(http.MethodPost, a.cfg.URL, bytes.NewReader(enc))
+	if err != nil {
+		return errors.Wrap(err, "could not create request")
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "could not post alert")
+	}
+	if err := resp.Body.Close(); err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("alert URL responded with status %s", resp.Status)
+	}
+	return nil
+}
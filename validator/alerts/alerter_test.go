@@ -0,0 +1,87 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAlerter_MissedAttestations(t *testing.T) {
+	a := NewAlerter(&Config{MissedAttestations: 1, MissedAttestationsWindow: 3})
+	pk := [48]byte{1}
+	observe := func(epoch uint64, included bool) []*Alert {
+		return a.Observe(epoch, []*Performance{{PublicKey: pk, Included: included}})
+	}
+
+	if alerts := observe(1, false); len(alerts) != 0 {
+		t.Fatalf("Expected no alert after a single missed attestation, received %v", alerts)
+	}
+	alerts := observe(2, false)
+	if len(alerts) != 1 || alerts[0].Kind != MissedAttestations || alerts[0].Resolved {
+		t.Fatalf("Expected a missed attestations alert, received %v", alerts)
+	}
+	if alerts := observe(3, true); len(alerts) != 0 {
+		t.Fatalf("Expected the alert to keep firing without a new alert, received %v", alerts)
+	}
+	// The miss of epoch 1 leaves the window.
+	alerts = observe(4, true)
+	if len(alerts) != 1 || !alerts[0].Resolved {
+		t.Fatalf("Expected the missed attestations alert to be resolved, received %v", alerts)
+	}
+}
+
+func TestAlerter_BalanceLoss(t *testing.T) {
+	a := NewAlerter(&Config{BalanceLossEpochs: 2})
+	pk := [48]byte{1}
+	observe := func(epoch uint64, before uint64, after uint64) []*Alert {
+		return a.Observe(epoch, []*Performance{{PublicKey: pk, BalanceBefore: before, BalanceAfter: after}})
+	}
+
+	if alerts := observe(1, 32, 31); len(alerts) != 0 {
+		t.Fatalf("Expected no alert after a single loss, received %v", alerts)
+	}
+	alerts := observe(2, 31, 30)
+	if len(alerts) != 1 || alerts[0].Kind != BalanceLoss || alerts[0].Resolved {
+		t.Fatalf("Expected a balance loss alert, received %v", alerts)
+	}
+	alerts = observe(3, 30, 30)
+	if len(alerts) != 1 || !alerts[0].Resolved {
+		t.Fatalf("Expected the balance loss alert to be resolved, received %v", alerts)
+	}
+}
+
+func TestAlerter_SendPagerDuty(t *testing.T) {
+	received := make(chan *pagerDutyEvent, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event := &pagerDutyEvent{}
+		if err := json.NewDecoder(r.Body).Decode(event); err != nil {
+			t.Error(err)
+		}
+		received <- event
+	}))
+	defer srv.Close()
+
+	a := NewAlerter(&Config{URL: srv.URL, PagerDutyRoutingKey: "key"})
+	alert := &Alert{Kind: BalanceLoss, PublicKey: "0x01", Message: "lost balance"}
+	if err := a.Send(context.Background(), []*Alert{alert}); err != nil {
+		t.Fatal(err)
+	}
+	event := <-received
+	if event.RoutingKey != "key" || event.EventAction != "trigger" || event.Payload.Summary != "lost balance" {
+		t.Errorf("Unexpected event %+v", event)
+	}
+}
+
+func TestAlerter_SendFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	a := NewAlerter(&Config{URL: srv.URL})
+	if err := a.Send(context.Background(), []*Alert{{Kind: BalanceLoss}}); err == nil {
+		t.Error("Expected send to fail when the alert URL is unavailable")
+	}
+}
@@ -0,0 +1,7 @@
+package alerts
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "alerts")
@@ -0,0 +1,166 @@
+// Package audit keeps an append-only log of every object signed by the validator client, along
+// with the signed object itself, so that institutional stakers can account for each signature
+// made with their keys.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// Block is the type of the records of signed beacon blocks.
+	Block = "block"
+	// Attestation is the type of the records of signed attestation data.
+	Attestation = "attestation"
+	// RandaoReveal is the type of the records of signed randao reveals.
+	RandaoReveal = "randao_reveal"
+	// SelectionProof is the type of the records of signed aggregator selection proofs.
+	SelectionProof = "selection_proof"
+)
+
+// maxRecordSize bounds the size of a line of the audit log read back on export.
+const maxRecordSize = 1 << 20
+
+// Record is an object signed by the validator client. The public key, signing root, signature
+// and object are hex encoded with a 0x prefix, the object being SSZ encoded.
+type Record struct {
+	Type        string    `json:"type"`
+	Slot        uint64    `json:"slot"`
+	PublicKey   string    `json:"public_key"`
+	SigningRoot string    `json:"signing_root"`
+	Domain      uint64    `json:"domain"`
+	Signature   string    `json:"signature"`
+	Object      string    `json:"object"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// Filter selects the records to export. An empty public key selects all the keys, and an end
+// slot of 0 has no upper bound.
+type Filter struct {
+	PublicKey string
+	StartSlot uint64
+	EndSlot   uint64
+}
+
+func (f *Filter) matches(r *Record) bool {
+	if f.PublicKey != "" && !strings.EqualFold(f.PublicKey, r.PublicKey) {
+		return false
+	}
+	return r.Slot >= f.StartSlot && (f.EndSlot == 0 || r.Slot <= f.EndSlot)
+}
+
+// Log is an append-only file of records, one JSON document per line. Records are synced to
+// disk before the signature is used, so no signed object is missing from the log after a crash.
+type Log struct {
+	path string
+	file *os.File
+	lock sync.Mutex
+}
+
+// NewLog opens the audit log at the path, creating it if it does not exist.
+func NewLog(path string) (*Log, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open audit log")
+	}
+	return &Log{path: path, file: f}, nil
+}
+
+// Record appends the record to the log.
+func (l *Log) Record(r *Record) error {
+	enc, err := json.Marshal(r)
+	if err != nil {
+		return errors.Wrap(err, "could not encode audit record")
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.file == nil {
+		return errors.New("audit log is closed")
+	}
+	if _, err := l.file.Write(append(enc, '\n')); err != nil {
+		return errors.Wrap(err, "could not write audit record")
+	}
+	return l.file.Sync()
+}
+
+// Export writes the records of the log matching the filter to the writer, one JSON document
+// per line.
+func (l *Log) Export(w io.Writer, filter *Filter) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	f, err := os.Open(l.path)
+	if err != nil {
+		return errors.Wrap(err, "could not open audit log")
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Error("Could not close audit log")
+		}
+	}()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordSize)
+	for scanner.Scan() {
+		r := &Record{}
+		if err := json.Unmarshal(scanner.Bytes(), r); err != nil {
+			return errors.Wrap(err, "could not decode audit record")
+		}
+		if !filter.matches(r) {
+			continue
+		}
+		if _, err := w.Write(append(scanner.Bytes(), '\n')); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// ExportHandler serves the records of the log as JSON lines, filtered by the optional
+// public_key, start_slot and end_slot query parameters.
+func (l *Log) ExportHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	filter := &Filter{PublicKey: query.Get("public_key")}
+	var err error
+	if start := query.Get("start_slot"); start != "" {
+		if filter.StartSlot, err = strconv.ParseUint(start, 10, 64); err != nil {
+			http.Error(w, "Invalid start_slot parameter", http.StatusBadRequest)
+			return
+		}
+	}
+	if end := query.Get("end_slot"); end != "" {
+		if filter.EndSlot, err = strconv.ParseUint(end, 10, 64); err != nil {
+			http.Error(w, "Invalid end_slot parameter", http.StatusBadRequest)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if err := l.Export(w, filter); err != nil {
+		log.WithError(err).Error("Could not export audit log")
+	}
+}
+
+// Close closes the log, records can no longer be appended.
+func (l *Log) Close() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
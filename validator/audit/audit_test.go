@@ -0,0 +1,91 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLog_RecordAndExport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+
+	l, err := NewLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := []*Record{
+		{Type: Block, Slot: 1, PublicKey: "0xaa"},
+		{Type: Attestation, Slot: 2, PublicKey: "0xbb"},
+		{Type: Attestation, Slot: 3, PublicKey: "0xaa"},
+	}
+	for _, r := range records {
+		if err := l.Record(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reopening the log appends to the existing records.
+	l, err = NewLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	if err := l.Record(&Record{Type: RandaoReveal, Slot: 4, PublicKey: "0xaa"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		filter *Filter
+		slots  []uint64
+	}{
+		{filter: &Filter{}, slots: []uint64{1, 2, 3, 4}},
+		{filter: &Filter{PublicKey: "0xAA"}, slots: []uint64{1, 3, 4}},
+		{filter: &Filter{StartSlot: 2, EndSlot: 3}, slots: []uint64{2, 3}},
+	}
+	for _, tt := range tests {
+		buf := new(bytes.Buffer)
+		if err := l.Export(buf, tt.filter); err != nil {
+			t.Fatal(err)
+		}
+		var slots []uint64
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			r := &Record{}
+			if err := json.Unmarshal([]byte(line), r); err != nil {
+				t.Fatal(err)
+			}
+			slots = append(slots, r.Slot)
+		}
+		if len(slots) != len(tt.slots) {
+			t.Fatalf("Wanted slots %v for filter %+v, received %v", tt.slots, tt.filter, slots)
+		}
+		for i := range slots {
+			if slots[i] != tt.slots[i] {
+				t.Errorf("Wanted slots %v for filter %+v, received %v", tt.slots, tt.filter, slots)
+				break
+			}
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	l.ExportHandler(rec, httptest.NewRequest(http.MethodGet, "/audit/export?start_slot=4", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Wanted status %d, received %d", http.StatusOK, rec.Code)
+	}
+	if lines := strings.Count(rec.Body.String(), "\n"); lines != 1 {
+		t.Errorf("Wanted 1 exported record, received %d", lines)
+	}
+}
@@ -0,0 +1,7 @@
+package audit
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "audit")
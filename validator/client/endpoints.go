@@ -0,0 +1,252 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// endpointScoreDecay is the weight of the latest sample in the moving averages of the latency
+	// and error rate of an endpoint.
+	endpointScoreDecay = 0.2
+	// endpointErrorPenalty is added to the score of an endpoint for an error rate of 1.
+	endpointErrorPenalty = 10 * time.Second
+	// endpointSyncingPenalty is added to the score of an endpoint reporting it is syncing.
+	endpointSyncingPenalty = time.Minute
+)
+
+var beaconEndpointScore = promauto.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: "validator",
+		Name:      "beacon_endpoint_score_seconds",
+		Help:      "The score of a beacon node endpoint, lower is better.",
+	},
+	[]string{"endpoint"},
+)
+
+// pinnedEndpointKey marks the context of a request which must be sent over the connection it is
+// made on, such as the polling of an endpoint, instead of being routed.
+type pinnedEndpointKey struct{}
+
+// beaconEndpoint is a beacon node endpoint with the measurements it is scored by.
+type beaconEndpoint struct {
+	address   string
+	conn      *grpc.ClientConn
+	headSlot  uint64
+	syncing   bool
+	errorRate float64
+	latency   time.Duration
+	// methodLatency is the latency of the endpoint per gRPC method.
+	methodLatency map[string]time.Duration
+}
+
+// endpointRouter scores the configured beacon node endpoints by their sync distance, RPC latency
+// and error rate, and routes every request to the best endpoint for its method, instead of
+// always using the first endpoint.
+type endpointRouter struct {
+	endpoints []*beaconEndpoint
+	lock      sync.RWMutex
+}
+
+// newEndpointRouter creates a router over the endpoint addresses, whose connections are set once
+// dialed.
+func newEndpointRouter(addresses []string) *endpointRouter {
+	endpoints := make([]*beaconEndpoint, len(addresses))
+	for i, address := range addresses {
+		endpoints[i] = &beaconEndpoint{
+			address:       address,
+			methodLatency: make(map[string]time.Duration),
+		}
+	}
+	return &endpointRouter{endpoints: endpoints}
+}
+
+// score returns the score of the endpoint for the method, lower is better. Every slot of sync
+// distance to the most synced endpoint costs a slot duration.
+func (r *endpointRouter) score(e *beaconEndpoint, method string, maxHeadSlot uint64) time.Duration {
+	latency, ok := e.methodLatency[method]
+	if !ok {
+		latency = e.latency
+	}
+	score := latency + time.Duration(e.errorRate*float64(endpointErrorPenalty))
+	score += time.Duration(maxHeadSlot-e.headSlot) * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	if e.syncing {
+		score += endpointSyncingPenalty
+	}
+	return score
+}
+
+// best returns the endpoint with the lowest score for the method, the first endpoint on ties.
+func (r *endpointRouter) best(method string) *beaconEndpoint {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	maxHeadSlot := r.maxHeadSlot()
+	best := r.endpoints[0]
+	bestScore := r.score(best, method, maxHeadSlot)
+	for _, e := range r.endpoints[1:] {
+		if s := r.score(e, method, maxHeadSlot); s < bestScore {
+			best, bestScore = e, s
+		}
+	}
+	return best
+}
+
+func (r *endpointRouter) maxHeadSlot() uint64 {
+	var maxHeadSlot uint64
+	for _, e := range r.endpoints {
+		if e.headSlot > maxHeadSlot {
+			maxHeadSlot = e.headSlot
+		}
+	}
+	return maxHeadSlot
+}
+
+// record updates the latency and error rate of the endpoint with the result of a request. Only
+// errors of the endpoint itself count, not the rejection of invalid requests.
+func (r *endpointRouter) record(e *beaconEndpoint, method string, latency time.Duration, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var failed float64
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal, codes.Unknown:
+		failed = 1
+	}
+	e.errorRate += endpointScoreDecay * (failed - e.errorRate)
+	if failed == 1 {
+		return
+	}
+	e.latency = movingAverage(e.latency, latency)
+	if method != "" {
+		if previous, ok := e.methodLatency[method]; ok {
+			latency = movingAverage(previous, latency)
+		}
+		e.methodLatency[method] = latency
+	}
+}
+
+func movingAverage(average time.Duration, sample time.Duration) time.Duration {
+	if average == 0 {
+		return sample
+	}
+	return average + time.Duration(endpointScoreDecay*float64(sample-average))
+}
+
+// unaryInterceptor sends the request to the best endpoint for its method.
+func (r *endpointRouter) unaryInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	if ctx.Value(pinnedEndpointKey{}) != nil {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	e := r.best(method)
+	start := time.Now()
+	var err error
+	if e.conn == cc {
+		err = invoker(ctx, method, req, reply, cc, opts...)
+	} else {
+		err = e.conn.Invoke(ctx, method, req, reply, opts...)
+	}
+	r.record(e, method, time.Since(start), err)
+	return err
+}
+
+// streamInterceptor opens the stream on the best endpoint for its method.
+func (r *endpointRouter) streamInterceptor(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	cc *grpc.ClientConn,
+	method string,
+	streamer grpc.Streamer,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	e := r.best(method)
+	start := time.Now()
+	var stream grpc.ClientStream
+	var err error
+	if e.conn == cc {
+		stream, err = streamer(ctx, desc, cc, method, opts...)
+	} else {
+		stream, err = e.conn.NewStream(ctx, desc, method, opts...)
+	}
+	r.record(e, method, time.Since(start), err)
+	return stream, err
+}
+
+// run polls the head slot and sync status of every endpoint once per slot.
+func (r *endpointRouter) run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	defer ticker.Stop()
+	for {
+		r.poll(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *endpointRouter) poll(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, e := range r.endpoints {
+		wg.Add(1)
+		go func(e *beaconEndpoint) {
+			defer wg.Done()
+			r.pollEndpoint(ctx, e)
+		}(e)
+	}
+	wg.Wait()
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	maxHeadSlot := r.maxHeadSlot()
+	for _, e := range r.endpoints {
+		beaconEndpointScore.WithLabelValues(e.address).Set(r.score(e, "", maxHeadSlot).Seconds())
+	}
+}
+
+func (r *endpointRouter) pollEndpoint(ctx context.Context, e *beaconEndpoint) {
+	ctx = context.WithValue(ctx, pinnedEndpointKey{}, true)
+	start := time.Now()
+	head, err := ethpb.NewBeaconChainClient(e.conn).GetChainHead(ctx, &ptypes.Empty{})
+	if err != nil {
+		r.record(e, "", time.Since(start), err)
+		log.WithError(err).WithField("endpoint", e.address).Debug("Could not get chain head of beacon node")
+		return
+	}
+	r.record(e, "", time.Since(start), nil)
+	syncStatus, err := ethpb.NewNodeClient(e.conn).GetSyncStatus(ctx, &ptypes.Empty{})
+	if err != nil {
+		log.WithError(err).WithField("endpoint", e.address).Debug("Could not get sync status of beacon node")
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	e.headSlot = head.HeadSlot
+	if syncStatus != nil {
+		e.syncing = syncStatus.Syncing
+	}
+	log.WithFields(logrus.Fields{
+		"endpoint":  e.address,
+		"headSlot":  e.headSlot,
+		"syncing":   e.syncing,
+		"latency":   e.latency,
+		"errorRate": e.errorRate,
+	}).Debug("Polled beacon node")
+}
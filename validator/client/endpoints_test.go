@@ -0,0 +1,53 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestEndpointRouter_Best(t *testing.T) {
+	r := newEndpointRouter([]string{"a", "b"})
+	a, b := r.endpoints[0], r.endpoints[1]
+	if r.best("/m") != a {
+		t.Error("Expected the first endpoint on ties")
+	}
+
+	// The faster endpoint is preferred for the method it is faster for.
+	r.record(a, "/m", 100*time.Millisecond, nil)
+	r.record(b, "/m", 10*time.Millisecond, nil)
+	r.record(a, "/n", 10*time.Millisecond, nil)
+	r.record(b, "/n", 100*time.Millisecond, nil)
+	if r.best("/m") != b || r.best("/n") != a {
+		t.Error("Expected every method to be routed to its fastest endpoint")
+	}
+
+	// Rejected requests don't count as errors, unavailable endpoints do.
+	r.record(b, "/m", time.Millisecond, status.Error(codes.InvalidArgument, "invalid"))
+	if b.errorRate != 0 {
+		t.Errorf("Expected no error rate, received %f", b.errorRate)
+	}
+	for i := 0; i < 5; i++ {
+		r.record(b, "/m", time.Millisecond, status.Error(codes.Unavailable, "unavailable"))
+	}
+	if r.best("/m") != a {
+		t.Error("Expected the failing endpoint to be avoided")
+	}
+
+	// An endpoint behind the others is avoided.
+	b.errorRate = 0
+	a.headSlot = 10
+	b.headSlot = 12
+	if r.best("/n") != b {
+		t.Error("Expected the endpoint behind to be avoided")
+	}
+}
+
+func TestParseEndpoints(t *testing.T) {
+	endpoints := parseEndpoints("localhost:4000, localhost:4001,")
+	if len(endpoints) != 2 || endpoints[0] != "localhost:4000" || endpoints[1] != "localhost:4001" {
+		t.Errorf("Unexpected endpoints %v", endpoints)
+	}
+}
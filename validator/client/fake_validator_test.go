@@ -28,6 +28,7 @@ type fakeValidator struct {
 	ProposeBlockCalled               bool
 	ProposeBlockArg1                 uint64
 	LogValidatorGainsAndLossesCalled bool
+	CheckPendingActivationsCalled    bool
 	SlotDeadlineCalled               bool
 	PublicKey                        string
 }
@@ -77,6 +78,10 @@ func (fv *fakeValidator) LogValidatorGainsAndLosses(_ context.Context, slot uint
 	return nil
 }
 
+func (fv *fakeValidator) CheckPendingActivations(_ context.Context, slot uint64) {
+	fv.CheckPendingActivationsCalled = true
+}
+
 func (fv *fakeValidator) RolesAt(_ context.Context, slot uint64) (map[[48]byte][]pb.ValidatorRole, error) {
 	fv.RoleAtCalled = true
 	fv.RoleAtArg1 = slot
@@ -0,0 +1,295 @@
+package client
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/validator/accounts"
+	"github.com/prysmaticlabs/prysm/validator/db"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+)
+
+// Statuses of keystores imported or deleted through the keymanager API.
+const (
+	keystoreImported  = "imported"
+	keystoreDuplicate = "duplicate"
+	keystoreDeleted   = "deleted"
+	keystoreNotActive = "not_active"
+	keystoreNotFound  = "not_found"
+	keystoreError     = "error"
+)
+
+// errNotStarted is returned when keys are imported or deleted before the validator client opened
+// its database.
+var errNotStarted = errors.New("validator client is not started")
+
+// KeystoreInfo is a key the validator client validates with.
+type KeystoreInfo struct {
+	ValidatingPubkey string `json:"validating_pubkey"`
+	DerivationPath   string `json:"derivation_path,omitempty"`
+	Readonly         bool   `json:"readonly"`
+}
+
+// ListKeystoresResponse lists the keys the validator client validates with.
+type ListKeystoresResponse struct {
+	Data []*KeystoreInfo `json:"data"`
+}
+
+// ImportKeystoresRequest holds EIP-2335 keystores encoded as JSON strings, their passwords, an
+// optional EIP-3076 slashing protection interchange encoded as a JSON string, and optional
+// slashing protection low watermarks for the imported keys.
+type ImportKeystoresRequest struct {
+	Keystores          []string       `json:"keystores"`
+	Passwords          []string       `json:"passwords"`
+	SlashingProtection string         `json:"slashing_protection,omitempty"`
+	Watermarks         *db.Watermarks `json:"watermarks,omitempty"`
+}
+
+// KeystoreStatus is the outcome of importing or deleting a keystore.
+type KeystoreStatus struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// ImportKeystoresResponse holds the status of each imported keystore, in the order of the request.
+type ImportKeystoresResponse struct {
+	Data []*KeystoreStatus `json:"data"`
+}
+
+// DeleteKeystoresRequest holds the public keys to delete.
+type DeleteKeystoresRequest struct {
+	Pubkeys []string `json:"pubkeys"`
+}
+
+// DeleteKeystoresResponse holds the status of each deleted key, in the order of the request, along
+// with the slashing protection interchange of the deleted keys encoded as a JSON string.
+type DeleteKeystoresResponse struct {
+	Data               []*KeystoreStatus `json:"data"`
+	SlashingProtection string            `json:"slashing_protection"`
+}
+
+// validatorDB returns the database of the validator client, or nil if the validator client is not
+// started yet.
+func (v *ValidatorService) validatorDB() *db.Store {
+	v.dbLock.RLock()
+	defer v.dbLock.RUnlock()
+	return v.db
+}
+
+// ListKeystores lists the keys the validator client validates with. Keys are read only unless the
+// key manager supports importing and deleting keys.
+func (v *ValidatorService) ListKeystores() (*ListKeystoresResponse, error) {
+	pubKeys, err := v.keyManager.FetchValidatingKeys()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not fetch validating keys")
+	}
+	_, mutable := v.keyManager.(keymanager.MutableKeyManager)
+	res := &ListKeystoresResponse{Data: make([]*KeystoreInfo, len(pubKeys))}
+	for i, pubKey := range pubKeys {
+		res.Data[i] = &KeystoreInfo{
+			ValidatingPubkey: "0x" + hex.EncodeToString(pubKey[:]),
+			Readonly:         !mutable,
+		}
+	}
+	return res, nil
+}
+
+// ImportKeystores imports the slashing protection history of the request, then starts validating
+// with the keys of the keystores. The low watermarks of the request and those set by flag are
+// raised for the imported keys. Keys the validator client already validates with are reported as
+// duplicates.
+func (v *ValidatorService) ImportKeystores(ctx context.Context, req *ImportKeystoresRequest) (*ImportKeystoresResponse, error) {
+	km, ok := v.keyManager.(keymanager.MutableKeyManager)
+	if !ok {
+		return nil, errors.New("the key manager does not support importing keys")
+	}
+	if len(req.Keystores) != len(req.Passwords) {
+		return nil, errors.Errorf("received %d keystores and %d passwords", len(req.Keystores), len(req.Passwords))
+	}
+	valDB := v.validatorDB()
+	if valDB == nil {
+		return nil, errNotStarted
+	}
+	if req.SlashingProtection != "" {
+		interchange := &accounts.Interchange{}
+		if err := json.Unmarshal([]byte(req.SlashingProtection), interchange); err != nil {
+			return nil, errors.Wrap(err, "could not decode slashing protection interchange")
+		}
+		if err := accounts.ImportSlashingProtection(ctx, valDB, interchange); err != nil {
+			return nil, errors.Wrap(err, "could not import slashing protection history")
+		}
+	}
+
+	pubKeys, err := km.FetchValidatingKeys()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not fetch validating keys")
+	}
+	existing := make(map[[48]byte]bool, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		existing[pubKey] = true
+	}
+	res := &ImportKeystoresResponse{Data: make([]*KeystoreStatus, len(req.Keystores))}
+	for i, enc := range req.Keystores {
+		key, err := accounts.DecryptKeystore([]byte(enc), req.Passwords[i])
+		if err != nil {
+			res.Data[i] = &KeystoreStatus{Status: keystoreError, Message: err.Error()}
+			continue
+		}
+		pubKey := bytesutil.ToBytes48(key.PublicKey.Marshal())
+		if existing[pubKey] {
+			res.Data[i] = &KeystoreStatus{Status: keystoreDuplicate}
+			continue
+		}
+		// The slashing protection history must exist before the key signs anything.
+		if err := valDB.InitializeHistory(ctx, [][48]byte{pubKey}); err != nil {
+			res.Data[i] = &KeystoreStatus{Status: keystoreError, Message: err.Error()}
+			continue
+		}
+		if err := v.raiseImportWatermarks(ctx, valDB, pubKey, req.Watermarks); err != nil {
+			res.Data[i] = &KeystoreStatus{Status: keystoreError, Message: err.Error()}
+			continue
+		}
+		if err := km.ImportKey(key); err != nil {
+			res.Data[i] = &KeystoreStatus{Status: keystoreError, Message: err.Error()}
+			continue
+		}
+		existing[pubKey] = true
+		log.WithField("publicKey", "0x"+hex.EncodeToString(pubKey[:])).Info("Imported validator key through the keymanager API")
+		res.Data[i] = &KeystoreStatus{Status: keystoreImported}
+	}
+	return res, nil
+}
+
+// raiseImportWatermarks raises the low watermarks of an imported key to those set by flag and
+// those of the import request.
+func (v *ValidatorService) raiseImportWatermarks(ctx context.Context, valDB *db.Store, pubKey [48]byte, watermarks *db.Watermarks) error {
+	for _, w := range []*db.Watermarks{v.watermarks, watermarks} {
+		if w == nil {
+			continue
+		}
+		if err := valDB.RaiseWatermarks(ctx, pubKey[:], w); err != nil {
+			return errors.Wrap(err, "could not raise low watermarks")
+		}
+	}
+	return nil
+}
+
+// DeleteKeystores stops validating with the keys and deletes them, returning the slashing
+// protection history of the deleted keys so they can safely validate from another client. Keys
+// which are not validated with but have a slashing protection history are reported as not active.
+func (v *ValidatorService) DeleteKeystores(ctx context.Context, req *DeleteKeystoresRequest) (*DeleteKeystoresResponse, error) {
+	km, ok := v.keyManager.(keymanager.MutableKeyManager)
+	if !ok {
+		return nil, errors.New("the key manager does not support deleting keys")
+	}
+	valDB := v.validatorDB()
+	if valDB == nil {
+		return nil, errNotStarted
+	}
+	res := &DeleteKeystoresResponse{Data: make([]*KeystoreStatus, len(req.Pubkeys))}
+	var exported [][]byte
+	seen := make(map[[48]byte]bool)
+	export := func(pubKey []byte) {
+		if !seen[bytesutil.ToBytes48(pubKey)] {
+			seen[bytesutil.ToBytes48(pubKey)] = true
+			exported = append(exported, pubKey)
+		}
+	}
+	for i, pubKeyHex := range req.Pubkeys {
+		pubKey, err := hex.DecodeString(strings.TrimPrefix(pubKeyHex, "0x"))
+		if err != nil || len(pubKey) != params.BeaconConfig().BLSPubkeyLength {
+			res.Data[i] = &KeystoreStatus{Status: keystoreError, Message: "invalid public key"}
+			continue
+		}
+		deleted, err := km.DeleteKey(bytesutil.ToBytes48(pubKey))
+		if err != nil {
+			res.Data[i] = &KeystoreStatus{Status: keystoreError, Message: err.Error()}
+			continue
+		}
+		if deleted {
+			log.WithField("publicKey", "0x"+hex.EncodeToString(pubKey)).Info("Deleted validator key through the keymanager API")
+			res.Data[i] = &KeystoreStatus{Status: keystoreDeleted}
+			export(pubKey)
+			continue
+		}
+		history, err := valDB.ProposalHistory(ctx, pubKey)
+		if err != nil {
+			res.Data[i] = &KeystoreStatus{Status: keystoreError, Message: err.Error()}
+			continue
+		}
+		if history == nil {
+			res.Data[i] = &KeystoreStatus{Status: keystoreNotFound}
+			continue
+		}
+		res.Data[i] = &KeystoreStatus{Status: keystoreNotActive}
+		export(pubKey)
+	}
+	interchange, err := accounts.ExportSlashingProtection(ctx, valDB, exported)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not export slashing protection history")
+	}
+	enc, err := json.Marshal(interchange)
+	if err != nil {
+		return nil, err
+	}
+	res.SlashingProtection = string(enc)
+	return res, nil
+}
+
+// KeystoresHandler serves the standard keymanager API to list, import and delete keys. Requests
+// must hold the keymanager API token as a bearer token. As keystores are imported along with their
+// passwords, the handler must only be served on the loopback interface.
+func (v *ValidatorService) KeystoresHandler(w http.ResponseWriter, r *http.Request) {
+	auth := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if auth == "" || auth == r.Header.Get("Authorization") {
+		http.Error(w, "Missing bearer token", http.StatusUnauthorized)
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(auth), []byte(v.keymanagerAPIToken)) != 1 {
+		http.Error(w, "Invalid bearer token", http.StatusForbidden)
+		return
+	}
+	if v.validatorDB() == nil {
+		http.Error(w, "Validator client is not started", http.StatusServiceUnavailable)
+		return
+	}
+
+	var res interface{}
+	var err error
+	switch r.Method {
+	case http.MethodGet:
+		res, err = v.ListKeystores()
+	case http.MethodPost:
+		req := &ImportKeystoresRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		res, err = v.ImportKeystores(r.Context(), req)
+	case http.MethodDelete:
+		req := &DeleteKeystoresRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		res, err = v.DeleteKeystores(r.Context(), req)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		log.WithError(err).Error("Could not write keymanager API response")
+	}
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/validator/accounts"
+	"github.com/prysmaticlabs/prysm/validator/db"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+func TestKeymanagerAPI_ImportListDelete(t *testing.T) {
+	ctx := context.Background()
+	dir := testutil.TempDir() + "/keymanager-api"
+	defer os.RemoveAll(dir)
+	km, _, err := keymanager.NewKeystore(fmt.Sprintf(`{"path":%q,"passphrase":"password"}`, dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	valDB := db.SetupDB(t, nil)
+	defer db.TeardownDB(t, valDB)
+	v := &ValidatorService{keyManager: km, db: valDB, watermarks: &db.Watermarks{MinSourceEpoch: 4}}
+
+	list, err := v.ListKeystores()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Data) != 1 || list.Data[0].Readonly {
+		t.Fatalf("Wanted the created account key to be listed as writable, received %v", list.Data)
+	}
+
+	secretKey := bls.RandKey()
+	pubKey := secretKey.PublicKey().Marshal()
+	pubKeyHex := "0x" + hex.EncodeToString(pubKey)
+	crypto, err := keystorev4.New().Encrypt(secretKey.Marshal(), "keystore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ks, err := json.Marshal(map[string]interface{}{"crypto": crypto, "pubkey": pubKeyHex, "version": 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	protection := fmt.Sprintf(`{"metadata":{"interchange_format_version":"5"},"data":[{"pubkey":%q,`+
+		`"signed_blocks":[{"slot":"100"}],"signed_attestations":[{"source_epoch":"3","target_epoch":"4"}]}]}`, pubKeyHex)
+	imported, err := v.ImportKeystores(ctx, &ImportKeystoresRequest{
+		Keystores:          []string{string(ks), string(ks), string(ks)},
+		Passwords:          []string{"keystore", "keystore", "wrong"},
+		SlashingProtection: protection,
+		Watermarks:         &db.Watermarks{MinBlockSlot: 200},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, status := range []string{keystoreImported, keystoreDuplicate, keystoreError} {
+		if imported.Data[i].Status != status {
+			t.Errorf("Wanted keystore %d to be %s, received %s", i, status, imported.Data[i].Status)
+		}
+	}
+	watermarks, err := valDB.Watermarks(ctx, pubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The watermarks of the interchange, of the flags and of the request are all applied.
+	if watermarks == nil || watermarks.MinBlockSlot != 200 || watermarks.MinSourceEpoch != 4 || watermarks.MinTargetEpoch != 5 {
+		t.Errorf("Wanted the import to raise the watermarks, received %v", watermarks)
+	}
+	keys, err := km.FetchValidatingKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 {
+		t.Errorf("Wanted 2 validating keys after import, received %d", len(keys))
+	}
+
+	unknown := "0x" + hex.EncodeToString(bls.RandKey().PublicKey().Marshal())
+	deleted, err := v.DeleteKeystores(ctx, &DeleteKeystoresRequest{Pubkeys: []string{pubKeyHex, pubKeyHex, unknown}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, status := range []string{keystoreDeleted, keystoreNotActive, keystoreNotFound} {
+		if deleted.Data[i].Status != status {
+			t.Errorf("Wanted key %d to be %s, received %s", i, status, deleted.Data[i].Status)
+		}
+	}
+	interchange := &accounts.Interchange{}
+	if err := json.Unmarshal([]byte(deleted.SlashingProtection), interchange); err != nil {
+		t.Fatal(err)
+	}
+	if len(interchange.Data) != 1 || interchange.Data[0].Pubkey != pubKeyHex {
+		t.Fatalf("Wanted the slashing protection of the deleted key, received %v", interchange.Data)
+	}
+	blocks := interchange.Data[0].SignedBlocks
+	if len(blocks) != 1 || blocks[0].Slot != "100" {
+		t.Errorf("Wanted the signed block at slot 100 to be exported, received %v", blocks)
+	}
+	keyMap, err := accounts.DecryptKeysFromKeystore(dir, "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := keyMap[hex.EncodeToString(pubKey)]; ok || len(keyMap) != 1 {
+		t.Errorf("Wanted the deleted key to be removed from the keystore, received %d keys", len(keyMap))
+	}
+}
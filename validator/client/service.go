@@ -2,6 +2,10 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
+	"net/http"
+	"strings"
+	"sync"
 
 	"github.com/dgraph-io/ristretto"
 	middleware "github.com/grpc-ecosystem/go-grpc-middleware"
@@ -10,6 +14,9 @@ import (
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/tlsutil"
+	"github.com/prysmaticlabs/prysm/validator/alerts"
+	"github.com/prysmaticlabs/prysm/validator/audit"
 	"github.com/prysmaticlabs/prysm/validator/db"
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
 	"github.com/sirupsen/logrus"
@@ -29,13 +36,24 @@ type ValidatorService struct {
 	graffiti             []byte
 	conn                 *grpc.ClientConn
 	endpoint             string
+	router               *endpointRouter
 	withCert             string
+	withClientCert       string
+	withClientKey        string
+	headers              map[string]string
 	dataDir              string
 	keyManager           keymanager.KeyManager
 	logValidatorBalances bool
 	emitAccountMetrics   bool
+	dryRun               bool
+	alerter              *alerts.Alerter
+	watermarks           *db.Watermarks
 	maxCallRecvMsgSize   int
 	grpcRetries          uint
+	db                   *db.Store
+	dbLock               sync.RWMutex
+	keymanagerAPIToken   string
+	auditLog             *audit.Log
 }
 
 // Config for the validator service.
@@ -43,30 +61,54 @@ type Config struct {
 	Endpoint                   string
 	DataDir                    string
 	CertFlag                   string
+	ClientCertFlag             string
+	ClientKeyFlag              string
+	APIKey                     string
 	GraffitiFlag               string
 	KeyManager                 keymanager.KeyManager
 	LogValidatorBalances       bool
 	EmitAccountMetrics         bool
+	DryRun                     bool
+	Alerter                    *alerts.Alerter
+	Watermarks                 *db.Watermarks
 	GrpcMaxCallRecvMsgSizeFlag int
 	GrpcRetriesFlag            uint
+	GrpcHeadersFlag            string
+	KeymanagerAPIToken         string
+	AuditLog                   *audit.Log
 }
 
 // NewValidatorService creates a new validator service for the service
 // registry.
 func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, error) {
+	headers, err := parseGrpcHeaders(cfg.GrpcHeadersFlag)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.APIKey != "" {
+		headers["x-api-key"] = cfg.APIKey
+	}
 	ctx, cancel := context.WithCancel(ctx)
 	return &ValidatorService{
 		ctx:                  ctx,
 		cancel:               cancel,
 		endpoint:             cfg.Endpoint,
 		withCert:             cfg.CertFlag,
+		withClientCert:       cfg.ClientCertFlag,
+		withClientKey:        cfg.ClientKeyFlag,
+		headers:              headers,
 		dataDir:              cfg.DataDir,
 		graffiti:             []byte(cfg.GraffitiFlag),
 		keyManager:           cfg.KeyManager,
 		logValidatorBalances: cfg.LogValidatorBalances,
 		emitAccountMetrics:   cfg.EmitAccountMetrics,
+		dryRun:               cfg.DryRun,
+		alerter:              cfg.Alerter,
+		watermarks:           cfg.Watermarks,
 		maxCallRecvMsgSize:   cfg.GrpcMaxCallRecvMsgSizeFlag,
 		grpcRetries:          cfg.GrpcRetriesFlag,
+		keymanagerAPIToken:   cfg.KeymanagerAPIToken,
+		auditLog:             cfg.AuditLog,
 	}, nil
 }
 
@@ -77,7 +119,7 @@ func (v *ValidatorService) Start() {
 	var maxCallRecvMsgSize int
 
 	if v.withCert != "" {
-		creds, err := credentials.NewClientTLSFromFile(v.withCert, "")
+		creds, err := v.transportCredentials()
 		if err != nil {
 			log.Errorf("Could not get valid credentials: %v", err)
 			return
@@ -94,30 +136,68 @@ func (v *ValidatorService) Start() {
 		maxCallRecvMsgSize = 10 * 5 << 20 // Default 50Mb
 	}
 
-	opts := []grpc.DialOption{
-		dialOpt,
-		grpc.WithDefaultCallOptions(
-			grpc.MaxCallRecvMsgSize(maxCallRecvMsgSize),
-			grpc_retry.WithMax(v.grpcRetries),
-		),
-		grpc.WithStatsHandler(&ocgrpc.ClientHandler{}),
-		grpc.WithStreamInterceptor(middleware.ChainStreamClient(
-			grpc_opentracing.StreamClientInterceptor(),
-			grpc_prometheus.StreamClientInterceptor,
-			grpc_retry.StreamClientInterceptor(),
-		)),
-		grpc.WithUnaryInterceptor(middleware.ChainUnaryClient(
-			grpc_opentracing.UnaryClientInterceptor(),
-			grpc_prometheus.UnaryClientInterceptor,
-			grpc_retry.UnaryClientInterceptor(),
-			logDebugRequestInfoUnaryInterceptor,
-		)),
-	}
-	conn, err := grpc.DialContext(v.ctx, v.endpoint, opts...)
-	if err != nil {
-		log.Errorf("Could not dial endpoint: %s, %v", v.endpoint, err)
+	dialOpts := func(streamInterceptors []grpc.StreamClientInterceptor, unaryInterceptors []grpc.UnaryClientInterceptor) []grpc.DialOption {
+		opts := []grpc.DialOption{
+			dialOpt,
+			grpc.WithDefaultCallOptions(
+				grpc.MaxCallRecvMsgSize(maxCallRecvMsgSize),
+				grpc_retry.WithMax(v.grpcRetries),
+			),
+			grpc.WithStatsHandler(&ocgrpc.ClientHandler{}),
+			grpc.WithStreamInterceptor(middleware.ChainStreamClient(append(
+				streamInterceptors,
+				grpc_opentracing.StreamClientInterceptor(),
+				grpc_prometheus.StreamClientInterceptor,
+				grpc_retry.StreamClientInterceptor(),
+			)...)),
+			grpc.WithUnaryInterceptor(middleware.ChainUnaryClient(append(
+				unaryInterceptors,
+				grpc_opentracing.UnaryClientInterceptor(),
+				grpc_prometheus.UnaryClientInterceptor,
+				grpc_retry.UnaryClientInterceptor(),
+				logDebugRequestInfoUnaryInterceptor,
+			)...)),
+		}
+		if len(v.headers) > 0 {
+			opts = append(opts, grpc.WithPerRPCCredentials(headerCredentials(v.headers)))
+		}
+		return opts
+	}
+
+	// With several endpoints, the requests made over the connection of the first endpoint are
+	// routed to the best scored endpoint.
+	endpoints := parseEndpoints(v.endpoint)
+	if len(endpoints) == 0 {
+		log.Error("No beacon node endpoint configured")
 		return
 	}
+	if len(endpoints) > 1 {
+		v.router = newEndpointRouter(endpoints)
+	}
+	var conn *grpc.ClientConn
+	for i, endpoint := range endpoints {
+		var streamInterceptors []grpc.StreamClientInterceptor
+		var unaryInterceptors []grpc.UnaryClientInterceptor
+		if i == 0 && v.router != nil {
+			streamInterceptors = []grpc.StreamClientInterceptor{v.router.streamInterceptor}
+			unaryInterceptors = []grpc.UnaryClientInterceptor{v.router.unaryInterceptor}
+		}
+		c, err := grpc.DialContext(v.ctx, endpoint, dialOpts(streamInterceptors, unaryInterceptors)...)
+		if err != nil {
+			log.Errorf("Could not dial endpoint: %s, %v", endpoint, err)
+			return
+		}
+		if i == 0 {
+			conn = c
+		}
+		if v.router != nil {
+			v.router.endpoints[i].conn = c
+		}
+	}
+	if v.router != nil {
+		go v.router.run(v.ctx)
+		log.WithField("endpoints", endpoints).Info("Routing requests to the best scored beacon node")
+	}
 	log.Info("Successfully started gRPC connection")
 
 	pubkeys, err := v.keyManager.FetchValidatingKeys()
@@ -131,8 +211,24 @@ func (v *ValidatorService) Start() {
 		log.Errorf("Could not initialize db: %v", err)
 		return
 	}
+	if v.watermarks != nil {
+		for _, pubkey := range pubkeys {
+			if err := valDB.RaiseWatermarks(v.ctx, pubkey[:], v.watermarks); err != nil {
+				log.Errorf("Could not set low watermarks: %v", err)
+				return
+			}
+		}
+		log.WithFields(logrus.Fields{
+			"minSourceEpoch": v.watermarks.MinSourceEpoch,
+			"minTargetEpoch": v.watermarks.MinTargetEpoch,
+			"minBlockSlot":   v.watermarks.MinBlockSlot,
+		}).Info("Raised slashing protection low watermarks")
+	}
 
 	v.conn = conn
+	v.dbLock.Lock()
+	v.db = valDB
+	v.dbLock.Unlock()
 	cache, err := ristretto.NewCache(&ristretto.Config{
 		NumCounters: 1280, // number of keys to track.
 		MaxCost:     128,  // maximum cost of cache, 1 item = 1 cost.
@@ -151,9 +247,15 @@ func (v *ValidatorService) Start() {
 		graffiti:             v.graffiti,
 		logValidatorBalances: v.logValidatorBalances,
 		emitAccountMetrics:   v.emitAccountMetrics,
+		dryRun:               v.dryRun,
+		alerter:              v.alerter,
 		prevBalance:          make(map[[48]byte]uint64),
 		attLogs:              make(map[[32]byte]*attSubmitted),
 		domainDataCache:      cache,
+		auditLog:             v.auditLog,
+	}
+	if v.dryRun {
+		log.Warn("Running in dry run mode, duties are performed and signed but never submitted to the beacon node")
 	}
 	go run(v.ctx, v.validator)
 }
@@ -162,12 +264,37 @@ func (v *ValidatorService) Start() {
 func (v *ValidatorService) Stop() error {
 	v.cancel()
 	log.Info("Stopping service")
+	if v.router != nil {
+		for _, e := range v.router.endpoints[1:] {
+			if e.conn == nil {
+				continue
+			}
+			if err := e.conn.Close(); err != nil {
+				log.WithError(err).WithField("endpoint", e.address).Error("Could not close connection")
+			}
+		}
+	}
+	if v.auditLog != nil {
+		if err := v.auditLog.Close(); err != nil {
+			log.WithError(err).Error("Could not close audit log")
+		}
+	}
 	if v.conn != nil {
 		return v.conn.Close()
 	}
 	return nil
 }
 
+// AuditLogHandler serves the records of the audit log of signed objects, see
+// audit.Log.ExportHandler.
+func (v *ValidatorService) AuditLogHandler(w http.ResponseWriter, r *http.Request) {
+	if v.auditLog == nil {
+		http.Error(w, "Audit log is not enabled", http.StatusServiceUnavailable)
+		return
+	}
+	v.auditLog.ExportHandler(w, r)
+}
+
 // Status ...
 //
 // WIP - not done.
@@ -177,3 +304,65 @@ func (v *ValidatorService) Status() error {
 	}
 	return nil
 }
+
+// transportCredentials returns the TLS credentials used to connect to the beacon node, which
+// is trusted if its certificate is signed by the certificate authority of the cert flag. The
+// client certificate, if any, is reloaded when its files change.
+func (v *ValidatorService) transportCredentials() (credentials.TransportCredentials, error) {
+	pool, err := tlsutil.LoadCertPool(v.withCert)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}
+	if v.withClientCert != "" && v.withClientKey != "" {
+		reloader, err := tlsutil.NewCertReloader(v.withClientCert, v.withClientKey)
+		if err != nil {
+			return nil, err
+		}
+		cfg.GetClientCertificate = reloader.GetClientCertificate
+	}
+	return credentials.NewTLS(cfg), nil
+}
+
+// parseEndpoints parses a comma separated list of beacon node endpoints.
+func parseEndpoints(flag string) []string {
+	var endpoints []string
+	for _, endpoint := range strings.Split(flag, ",") {
+		if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+// parseGrpcHeaders parses a comma separated list of key=value pairs.
+func parseGrpcHeaders(flag string) (map[string]string, error) {
+	headers := make(map[string]string)
+	if flag == "" {
+		return headers, nil
+	}
+	for _, pair := range strings.Split(flag, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, errors.Errorf("incorrect gRPC header flag format, wanted key=value: %q", pair)
+		}
+		headers[strings.ToLower(strings.TrimSpace(kv[0]))] = kv[1]
+	}
+	return headers, nil
+}
+
+// headerCredentials sends metadata along with every request to the beacon node.
+type headerCredentials map[string]string
+
+// GetRequestMetadata returns the metadata of a request.
+func (h headerCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return h, nil
+}
+
+// RequireTransportSecurity returns false, as the metadata may be sent over insecure connections.
+func (h headerCredentials) RequireTransportSecurity() bool {
+	return false
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -117,3 +118,17 @@ func TestStatus_NoConnectionError(t *testing.T) {
 		t.Errorf("Expected status check to fail if no connection is found, received: %v", err)
 	}
 }
+
+func TestParseGrpcHeaders(t *testing.T) {
+	headers, err := parseGrpcHeaders("Authorization=Bearer abc=,x-tenant=validators")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wanted := map[string]string{"authorization": "Bearer abc=", "x-tenant": "validators"}
+	if !reflect.DeepEqual(headers, wanted) {
+		t.Errorf("Wanted headers %v, received %v", wanted, headers)
+	}
+	if _, err := parseGrpcHeaders("authorization"); err == nil {
+		t.Error("Expected header without a value to be rejected")
+	}
+}
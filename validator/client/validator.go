@@ -24,6 +24,8 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/slotutil"
+	"github.com/prysmaticlabs/prysm/validator/alerts"
+	"github.com/prysmaticlabs/prysm/validator/audit"
 	"github.com/prysmaticlabs/prysm/validator/db"
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
 	"github.com/sirupsen/logrus"
@@ -43,10 +45,20 @@ type validator struct {
 	prevBalance          map[[48]byte]uint64
 	logValidatorBalances bool
 	emitAccountMetrics   bool
+	dryRun               bool
+	alerter              *alerts.Alerter
 	attLogs              map[[32]byte]*attSubmitted
 	attLogsLock          sync.Mutex
 	domainDataLock       sync.Mutex
 	domainDataCache      *ristretto.Cache
+	randaoReveals        map[randaoRevealKey][]byte
+	randaoRevealsLock    sync.Mutex
+	selectionProofs      map[selectionProofKey]*selectionProof
+	selectionProofsLock  sync.Mutex
+	// pendingActivations are the last known statuses of the keys not active yet.
+	pendingActivations     map[[48]byte]*ethpb.ValidatorStatusResponse
+	pendingActivationsLock sync.Mutex
+	auditLog               *audit.Log
 }
 
 // Done cleans up the validator.
@@ -108,6 +120,7 @@ func (v *validator) WaitForActivation(ctx context.Context) error {
 		return errors.Wrap(err, "could not setup validator WaitForActivation streaming client")
 	}
 	var validatorActivatedRecords [][]byte
+	var statuses []*ethpb.ValidatorActivationResponse_Status
 	for {
 		res, err := stream.Recv()
 		// If the stream is closed, we stop the loop.
@@ -123,6 +136,7 @@ func (v *validator) WaitForActivation(ctx context.Context) error {
 		}
 		log.Info("Waiting for validator to be activated in the beacon chain")
 		activatedKeys := v.checkAndLogValidatorStatus(res.Statuses)
+		statuses = res.Statuses
 
 		if len(activatedKeys) > 0 {
 			validatorActivatedRecords = activatedKeys
@@ -132,6 +146,7 @@ func (v *validator) WaitForActivation(ctx context.Context) error {
 	for _, pubKey := range validatorActivatedRecords {
 		log.WithField("pubKey", fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:]))).Info("Validator activated")
 	}
+	v.trackPendingActivations(statuses)
 	v.ticker = slotutil.GetSlotTicker(time.Unix(int64(v.genesisTime), 0), params.BeaconConfig().SecondsPerSlot)
 
 	return nil
@@ -223,8 +238,7 @@ func (v *validator) NextSlot() <-chan uint64 {
 
 // SlotDeadline is the start time of the next slot.
 func (v *validator) SlotDeadline(slot uint64) time.Time {
-	secs := (slot + 1) * params.BeaconConfig().SecondsPerSlot
-	return time.Unix(int64(v.genesisTime), 0 /*ns*/).Add(time.Duration(secs) * time.Second)
+	return slotutil.SlotStartTime(v.genesisTime, slot+1)
 }
 
 // UpdateDuties checks the slot number to determine if the validator's
@@ -258,6 +272,9 @@ func (v *validator) UpdateDuties(ctx context.Context, slot uint64) error {
 	}
 
 	v.duties = resp
+	if featureconfig.Get().EnableRandaoRevealPrecompute {
+		go v.precomputeRandaoReveals(context.Background(), slot/params.BeaconConfig().SlotsPerEpoch, resp.Duties)
+	}
 	// Only log the full assignments output on epoch start to be less verbose.
 	if slot%params.BeaconConfig().SlotsPerEpoch == 0 {
 		for _, duty := range v.duties.Duties {
@@ -300,7 +317,7 @@ func (v *validator) RolesAt(ctx context.Context, slot uint64) (map[[48]byte][]pb
 		if duty.AttesterSlot == slot {
 			roles = append(roles, pb.ValidatorRole_ATTESTER)
 
-			aggregator, err := v.isAggregator(ctx, duty.Committee, slot, bytesutil.ToBytes48(duty.PublicKey))
+			aggregator, err := v.isAggregator(ctx, duty.Committee, duty.CommitteeIndex, slot, bytesutil.ToBytes48(duty.PublicKey))
 			if err != nil {
 				return nil, errors.Wrap(err, "could not check if a validator is an aggregator")
 			}
@@ -322,20 +339,25 @@ func (v *validator) RolesAt(ctx context.Context, slot uint64) (map[[48]byte][]pb
 
 // isAggregator checks if a validator is an aggregator of a given slot, it uses the selection algorithm outlined in:
 // https://github.com/ethereum/eth2.0-specs/blob/v0.9.3/specs/validator/0_beacon-chain-validator.md#aggregation-selection
-func (v *validator) isAggregator(ctx context.Context, committee []uint64, slot uint64, pubKey [48]byte) (bool, error) {
-	modulo := uint64(1)
-	if len(committee)/int(params.BeaconConfig().TargetAggregatorsPerCommittee) > 1 {
-		modulo = uint64(len(committee)) / params.BeaconConfig().TargetAggregatorsPerCommittee
-	}
-
-	slotSig, err := v.signSlot(ctx, pubKey, slot)
+func (v *validator) isAggregator(ctx context.Context, committee []uint64, committeeIndex uint64, slot uint64, pubKey [48]byte) (bool, error) {
+	proof, err := v.selectionProof(ctx, pubKey, slot, committeeIndex, committee)
 	if err != nil {
 		return false, err
 	}
+	return proof.aggregator, nil
+}
+
+// isAggregatorSignature returns whether the slot signature selects its validator as an
+// aggregator of a committee of the given size.
+func isAggregatorSignature(slotSig []byte, committeeSize int) bool {
+	modulo := uint64(1)
+	if committeeSize/int(params.BeaconConfig().TargetAggregatorsPerCommittee) > 1 {
+		modulo = uint64(committeeSize) / params.BeaconConfig().TargetAggregatorsPerCommittee
+	}
 
 	b := hashutil.Hash(slotSig)
 
-	return binary.LittleEndian.Uint64(b[:8])%modulo == 0, nil
+	return binary.LittleEndian.Uint64(b[:8])%modulo == 0
 }
 
 // UpdateDomainDataCaches by making calls for all of the possible domain data. These can change when
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// isPendingActivation returns true for the statuses of keys which may still be activated.
+func isPendingActivation(status ethpb.ValidatorStatus) bool {
+	switch status {
+	case ethpb.ValidatorStatus_UNKNOWN_STATUS, ethpb.ValidatorStatus_DEPOSITED, ethpb.ValidatorStatus_PENDING:
+		return true
+	}
+	return false
+}
+
+// trackPendingActivations records the keys which were not yet active when the validator client
+// started its duties, so their activation is detected without restarting the client.
+func (v *validator) trackPendingActivations(statuses []*ethpb.ValidatorActivationResponse_Status) {
+	v.pendingActivationsLock.Lock()
+	defer v.pendingActivationsLock.Unlock()
+	if v.pendingActivations == nil {
+		v.pendingActivations = make(map[[48]byte]*ethpb.ValidatorStatusResponse)
+	}
+	for _, s := range statuses {
+		if s.Status == nil || !isPendingActivation(s.Status.Status) {
+			continue
+		}
+		v.pendingActivations[bytesutil.ToBytes48(s.PublicKey)] = s.Status
+	}
+}
+
+// CheckPendingActivations queries the status of the keys which are not active yet at the start
+// of every epoch, and logs the deposits being processed, the activation epochs being scheduled
+// and the activations. Duties are updated for all keys at every epoch, so activated keys begin
+// their duties the epoch they are activated.
+func (v *validator) CheckPendingActivations(ctx context.Context, slot uint64) {
+	if !helpers.IsEpochStart(slot) {
+		return
+	}
+	ctx, span := trace.StartSpan(ctx, "validator.CheckPendingActivations")
+	defer span.End()
+
+	v.pendingActivationsLock.Lock()
+	defer v.pendingActivationsLock.Unlock()
+	epoch := helpers.SlotToEpoch(slot)
+	for pubKey, previous := range v.pendingActivations {
+		status, err := v.validatorClient.ValidatorStatus(ctx, &ethpb.ValidatorStatusRequest{PublicKey: pubKey[:]})
+		if err != nil {
+			log.WithError(err).WithField("pubKey", fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:]))).Debug(
+				"Could not get status of validator pending activation")
+			continue
+		}
+		if v.logActivationStatus(pubKey, previous, status, epoch) {
+			delete(v.pendingActivations, pubKey)
+			continue
+		}
+		v.pendingActivations[pubKey] = status
+	}
+}
+
+// logActivationStatus logs the changes of the status of a key pending activation, and returns
+// true once the key is no longer pending activation.
+func (v *validator) logActivationStatus(pubKey [48]byte, previous *ethpb.ValidatorStatusResponse, status *ethpb.ValidatorStatusResponse, epoch uint64) bool {
+	log := log.WithFields(logrus.Fields{
+		"pubKey": fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:])),
+		"status": status.Status.String(),
+	})
+	activationEpoch := uint64(status.ActivationEpoch)
+	activationScheduled := activationEpoch != params.BeaconConfig().FarFutureEpoch && activationEpoch != 0
+	switch {
+	// Only the status tells an activation apart, the activation epoch is scheduled ahead of it.
+	case status.Status == ethpb.ValidatorStatus_ACTIVE:
+		log.WithField("epoch", epoch).Info("Validator activated")
+		return true
+	case !isPendingActivation(status.Status):
+		log.Info("Validator is no longer pending activation")
+		return true
+	case activationScheduled && (previous == nil || previous.ActivationEpoch != status.ActivationEpoch):
+		log.WithField("activationEpoch", status.ActivationEpoch).Info("Validator activation scheduled")
+	case status.Status == ethpb.ValidatorStatus_DEPOSITED && (previous == nil || previous.Status != status.Status):
+		log.WithField("expectedInclusionSlot", status.DepositInclusionSlot).Info(
+			"Deposit for validator received but not processed into state")
+	case status.Status == ethpb.ValidatorStatus_PENDING && (previous == nil || previous.Status != status.Status):
+		log.WithField("positionInActivationQueue", status.PositionInActivationQueue).Info(
+			"Deposit for validator processed, waiting to be activated")
+	}
+	return false
+}
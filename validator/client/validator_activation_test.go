@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/validator/internal"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestCheckPendingActivations_LogsActivation(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := internal.NewMockBeaconNodeValidatorClient(ctrl)
+
+	v := validator{
+		keyManager:      testKeyManager,
+		validatorClient: client,
+	}
+	pubKeys := publicKeys(v.keyManager)
+	v.trackPendingActivations([]*ethpb.ValidatorActivationResponse_Status{
+		{PublicKey: pubKeys[0], Status: &ethpb.ValidatorStatusResponse{Status: ethpb.ValidatorStatus_DEPOSITED}},
+	})
+
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	req := &ethpb.ValidatorStatusRequest{PublicKey: pubKeys[0]}
+	gomock.InOrder(
+		client.EXPECT().ValidatorStatus(gomock.Any(), req).Return(&ethpb.ValidatorStatusResponse{
+			Status:          ethpb.ValidatorStatus_PENDING,
+			ActivationEpoch: 2,
+		}, nil),
+		client.EXPECT().ValidatorStatus(gomock.Any(), req).Return(&ethpb.ValidatorStatusResponse{
+			Status:          ethpb.ValidatorStatus_ACTIVE,
+			ActivationEpoch: 2,
+		}, nil),
+	)
+
+	v.CheckPendingActivations(context.Background(), slotsPerEpoch)
+	testutil.AssertLogsContain(t, hook, "Validator activation scheduled")
+	testutil.AssertLogsDoNotContain(t, hook, "Validator activated")
+	// Statuses are only checked at the start of an epoch.
+	v.CheckPendingActivations(context.Background(), slotsPerEpoch+1)
+	v.CheckPendingActivations(context.Background(), 2*slotsPerEpoch)
+	testutil.AssertLogsContain(t, hook, "Validator activated")
+	if len(v.pendingActivations) != 0 {
+		t.Errorf("Wanted no key pending activation, got %d", len(v.pendingActivations))
+	}
+}
+
+func TestTrackPendingActivations_SkipsActiveKeys(t *testing.T) {
+	v := validator{}
+	v.trackPendingActivations([]*ethpb.ValidatorActivationResponse_Status{
+		{PublicKey: []byte{1}, Status: &ethpb.ValidatorStatusResponse{Status: ethpb.ValidatorStatus_ACTIVE}},
+		{PublicKey: []byte{2}, Status: &ethpb.ValidatorStatusResponse{Status: ethpb.ValidatorStatus_PENDING}},
+		{PublicKey: []byte{3}, Status: &ethpb.ValidatorStatusResponse{Status: ethpb.ValidatorStatus_EXITED}},
+	})
+	if len(v.pendingActivations) != 1 {
+		t.Fatalf("Wanted 1 key pending activation, got %d", len(v.pendingActivations))
+	}
+	if _, ok := v.pendingActivations[[48]byte{2}]; !ok {
+		t.Error("Wanted the pending key to be tracked")
+	}
+}
+
+func TestLogActivationStatus_ScheduledEpochIsNotActivation(t *testing.T) {
+	hook := logTest.NewGlobal()
+	v := validator{}
+	status := &ethpb.ValidatorStatusResponse{
+		Status:          ethpb.ValidatorStatus_PENDING,
+		ActivationEpoch: 2,
+	}
+	if v.logActivationStatus([48]byte{1}, status, status, 3) {
+		t.Error("Wanted the pending key to remain pending activation")
+	}
+	testutil.AssertLogsDoNotContain(t, hook, "Validator activated")
+}
@@ -10,9 +10,12 @@ import (
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/roughtime"
 	"github.com/prysmaticlabs/prysm/shared/slotutil"
+	"github.com/prysmaticlabs/prysm/validator/audit"
+	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
 
@@ -59,7 +62,8 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot uint64, pu
 		return
 	}
 
-	slotSig, err := v.signSlot(ctx, pubKey, slot)
+	signStart := time.Now()
+	proof, err := v.selectionProof(ctx, pubKey, slot, duty.CommitteeIndex, duty.Committee)
 	if err != nil {
 		log.Errorf("Could not sign slot: %v", err)
 		if v.emitAccountMetrics {
@@ -68,6 +72,15 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot uint64, pu
 		return
 	}
 
+	if v.dryRun {
+		log.WithFields(logrus.Fields{
+			"slot":        slot,
+			"pubKey":      fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:])),
+			"signingTime": time.Since(signStart),
+		}).Info("Dry run, not submitting aggregate and proof")
+		return
+	}
+
 	// As specified in spec, an aggregator should wait until two thirds of the way through slot
 	// to broadcast the best aggregate to the global aggregate channel.
 	// https://github.com/ethereum/eth2.0-specs/blob/v0.9.3/specs/validator/0_beacon-chain-validator.md#broadcast-aggregate
@@ -77,7 +90,7 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot uint64, pu
 		Slot:           slot,
 		CommitteeIndex: duty.CommitteeIndex,
 		PublicKey:      pubKey[:],
-		SlotSignature:  slotSig,
+		SlotSignature:  proof.signature,
 	})
 	if err != nil {
 		log.Errorf("Could not submit slot signature to beacon node: %v", err)
@@ -117,6 +130,7 @@ func (v *validator) signSlot(ctx context.Context, pubKey [48]byte, slot uint64)
 	if err != nil {
 		return nil, err
 	}
+	v.auditSignature(audit.SelectionProof, pubKey, slot, slotRoot, domain.SignatureDomain, sig, slot)
 
 	return sig.Marshal(), nil
 }
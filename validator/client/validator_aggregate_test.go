@@ -1,6 +1,7 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"testing"
 	"time"
@@ -47,6 +48,48 @@ func TestSubmitAggregateAndProof_Ok(t *testing.T) {
 	validator.SubmitAggregateAndProof(context.Background(), 0, validatorPubKey)
 }
 
+func TestSubmitAggregateAndProof_ReusesSelectionProof(t *testing.T) {
+	validator, m, finish := setup(t)
+	defer finish()
+	validator.duties = &ethpb.DutiesResponse{
+		Duties: []*ethpb.DutiesResponse_Duty{
+			{
+				CommitteeIndex: 1,
+				AttesterSlot:   0,
+				PublicKey:      validatorKey.PublicKey.Marshal(),
+			},
+		},
+	}
+
+	// The slot is only signed once, when the roles are computed.
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{}, nil /*err*/).Times(1)
+
+	var slotSig []byte
+	m.validatorClient.EXPECT().SubmitAggregateAndProof(
+		gomock.Any(), // ctx
+		gomock.AssignableToTypeOf(&ethpb.AggregationRequest{}),
+	).DoAndReturn(func(_ context.Context, req *ethpb.AggregationRequest) (*ethpb.AggregationResponse, error) {
+		slotSig = req.SlotSignature
+		return &ethpb.AggregationResponse{}, nil
+	})
+
+	if _, err := validator.RolesAt(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+	validator.SubmitAggregateAndProof(context.Background(), 0, validatorPubKey)
+
+	proof, ok := validator.selectionProofs[selectionProofKey{pubKey: validatorPubKey, slot: 0, committeeIndex: 1}]
+	if !ok {
+		t.Fatal("Expected the selection proof to be cached")
+	}
+	if !bytes.Equal(slotSig, proof.signature) {
+		t.Errorf("Wanted slot signature %#x, received %#x", proof.signature, slotSig)
+	}
+}
+
 func TestWaitForSlotTwoThird_WaitCorrectly(t *testing.T) {
 	validator, _, finish := setup(t)
 	defer finish()
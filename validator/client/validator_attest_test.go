@@ -17,6 +17,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/roughtime"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/validator/db"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 )
 
@@ -125,6 +126,38 @@ func TestAttestToBlockHead_AttestsCorrectly(t *testing.T) {
 	}
 }
 
+func TestAttestToBlockHead_DryRunDoesNotSubmit(t *testing.T) {
+	hook := logTest.NewGlobal()
+	validator, m, finish := setup(t)
+	defer finish()
+	validator.dryRun = true
+	validatorIndex := uint64(7)
+	committee := []uint64{0, 3, 4, 2, validatorIndex, 6, 8, 9, 10}
+	validator.duties = &ethpb.DutiesResponse{Duties: []*ethpb.DutiesResponse_Duty{
+		{
+			PublicKey:      validatorKey.PublicKey.Marshal(),
+			CommitteeIndex: 5,
+			Committee:      committee,
+			ValidatorIndex: validatorIndex,
+		}}}
+	m.validatorClient.EXPECT().GetAttestationData(
+		gomock.Any(), // ctx
+		gomock.AssignableToTypeOf(&ethpb.AttestationDataRequest{}),
+	).Return(&ethpb.AttestationData{
+		BeaconBlockRoot: []byte("A"),
+		Target:          &ethpb.Checkpoint{Root: []byte("B")},
+		Source:          &ethpb.Checkpoint{Root: []byte("C"), Epoch: 3},
+	}, nil)
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{}, nil /*err*/)
+
+	validator.SubmitAttestation(context.Background(), 30, validatorPubKey)
+	testutil.AssertLogsContain(t, hook, "Dry run, not submitting attestation")
+}
+
 func TestAttestToBlockHead_BlocksDoubleAtt(t *testing.T) {
 	config := &featureconfig.Flags{
 		ProtectAttester: true,
@@ -268,6 +301,36 @@ func TestAttestToBlockHead_BlocksSurroundedAtt(t *testing.T) {
 	testutil.AssertLogsContain(t, hook, "Attempted to make a slashable attestation, rejected")
 }
 
+func TestAttestToBlockHead_BlocksBelowWatermark(t *testing.T) {
+	featureconfig.Init(&featureconfig.Flags{})
+	hook := logTest.NewGlobal()
+	validator, m, finish := setup(t)
+	defer finish()
+	if err := validator.db.RaiseWatermarks(context.Background(), validatorPubKey[:], &db.Watermarks{MinTargetEpoch: 5}); err != nil {
+		t.Fatal(err)
+	}
+	validatorIndex := uint64(7)
+	committee := []uint64{0, 3, 4, 2, validatorIndex, 6, 8, 9, 10}
+	validator.duties = &ethpb.DutiesResponse{Duties: []*ethpb.DutiesResponse_Duty{
+		{
+			PublicKey:      validatorKey.PublicKey.Marshal(),
+			CommitteeIndex: 5,
+			Committee:      committee,
+			ValidatorIndex: validatorIndex,
+		}}}
+	m.validatorClient.EXPECT().GetAttestationData(
+		gomock.Any(), // ctx
+		gomock.AssignableToTypeOf(&ethpb.AttestationDataRequest{}),
+	).Return(&ethpb.AttestationData{
+		BeaconBlockRoot: []byte("A"),
+		Target:          &ethpb.Checkpoint{Root: []byte("B"), Epoch: 4},
+		Source:          &ethpb.Checkpoint{Root: []byte("C"), Epoch: 3},
+	}, nil)
+
+	validator.SubmitAttestation(context.Background(), 30, validatorPubKey)
+	testutil.AssertLogsContain(t, hook, "Refusing to sign attestation")
+}
+
 func TestAttestToBlockHead_DoesNotAttestBeforeDelay(t *testing.T) {
 	validator, m, finish := setup(t)
 	defer finish()
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+	"github.com/prysmaticlabs/prysm/validator/audit"
+	"github.com/sirupsen/logrus"
+)
+
+// auditSignature records the signed object in the audit log, if it is enabled. The object is
+// archived SSZ encoded.
+func (v *validator) auditSignature(kind string, pubKey [48]byte, slot uint64, root [32]byte, domain uint64, sig *bls.Signature, object interface{}) {
+	if v.auditLog == nil {
+		return
+	}
+	enc, err := ssz.Marshal(object)
+	if err != nil {
+		log.WithError(err).Error("Could not encode signed object for the audit log")
+		return
+	}
+	if err := v.auditLog.Record(&audit.Record{
+		Type:        kind,
+		Slot:        slot,
+		PublicKey:   fmt.Sprintf("%#x", pubKey),
+		SigningRoot: fmt.Sprintf("%#x", root),
+		Domain:      domain,
+		Signature:   fmt.Sprintf("%#x", sig.Marshal()),
+		Object:      fmt.Sprintf("%#x", enc),
+		Timestamp:   roughtime.Now().UTC().Truncate(time.Millisecond),
+	}); err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"type": kind,
+			"slot": slot,
+		}).Error("Could not record signature in the audit log")
+	}
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/validator/audit"
+)
+
+func TestAuditSignature_RecordsSignedObject(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	auditLog, err := audit.NewLog(filepath.Join(dir, "audit.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer auditLog.Close()
+	v := &validator{auditLog: auditLog}
+
+	secretKey := bls.RandKey()
+	var pubKey [48]byte
+	copy(pubKey[:], secretKey.PublicKey().Marshal())
+	data := &ethpb.AttestationData{
+		Slot:            5,
+		BeaconBlockRoot: make([]byte, 32),
+		Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+		Target:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+	}
+	root, err := ssz.HashTreeRoot(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := secretKey.Sign(root[:], 7)
+	v.auditSignature(audit.Attestation, pubKey, data.Slot, root, 7, sig, data)
+
+	buf := new(bytes.Buffer)
+	if err := auditLog.Export(buf, &audit.Filter{}); err != nil {
+		t.Fatal(err)
+	}
+	record := &audit.Record{}
+	if err := json.Unmarshal(buf.Bytes(), record); err != nil {
+		t.Fatal(err)
+	}
+	enc, err := ssz.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Type != audit.Attestation || record.Slot != 5 || record.Domain != 7 {
+		t.Errorf("Unexpected record %+v", record)
+	}
+	if record.PublicKey != fmt.Sprintf("%#x", pubKey) {
+		t.Errorf("Wanted public key %#x, received %s", pubKey, record.PublicKey)
+	}
+	if record.SigningRoot != fmt.Sprintf("%#x", root) {
+		t.Errorf("Wanted signing root %#x, received %s", root, record.SigningRoot)
+	}
+	if record.Signature != fmt.Sprintf("%#x", sig.Marshal()) {
+		t.Error("Recorded signature does not match")
+	}
+	if record.Object != fmt.Sprintf("%#x", enc) {
+		t.Error("Recorded object does not match the SSZ encoded attestation data")
+	}
+	if record.Timestamp.IsZero() {
+		t.Error("Expected a timestamp")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/validator/alerts"
 	"github.com/sirupsen/logrus"
 )
 
@@ -27,13 +28,14 @@ var validatorBalancesGaugeVec = promauto.NewGaugeVec(
 // LogValidatorGainsAndLosses logs important metrics related to this validator client's
 // responsibilities throughout the beacon chain's lifecycle. It logs absolute accrued rewards
 // and penalties over time, percentage gain/loss, and gives the end user a better idea
-// of how the validator performs with respect to the rest.
+// of how the validator performs with respect to the rest. The performance is also passed to the
+// alerter, if configured.
 func (v *validator) LogValidatorGainsAndLosses(ctx context.Context, slot uint64) error {
 	if slot%params.BeaconConfig().SlotsPerEpoch != 0 || slot <= params.BeaconConfig().SlotsPerEpoch {
 		// Do nothing unless we are at the start of the epoch, and not in the first epoch.
 		return nil
 	}
-	if !v.logValidatorBalances {
+	if !v.logValidatorBalances && v.alerter == nil {
 		return nil
 	}
 
@@ -62,6 +64,7 @@ func (v *validator) LogValidatorGainsAndLosses(ctx context.Context, slot uint64)
 	votedHead := 0
 
 	reported := 0
+	var performances []*alerts.Performance
 	for _, pkey := range pubKeys {
 		pubKey := fmt.Sprintf("%#x", pkey[:8])
 		log := log.WithField("pubKey", pubKey)
@@ -77,7 +80,7 @@ func (v *validator) LogValidatorGainsAndLosses(ctx context.Context, slot uint64)
 			v.prevBalance[bytesutil.ToBytes48(pkey)] = params.BeaconConfig().MaxEffectiveBalance
 		}
 
-		if v.prevBalance[bytesutil.ToBytes48(pkey)] > 0 && len(resp.BalancesAfterEpochTransition) > reported {
+		if v.logValidatorBalances && v.prevBalance[bytesutil.ToBytes48(pkey)] > 0 && len(resp.BalancesAfterEpochTransition) > reported {
 			newBalance := float64(resp.BalancesAfterEpochTransition[reported]) / float64(params.BeaconConfig().GweiPerEth)
 			prevBalance := float64(resp.BalancesBeforeEpochTransition[reported]) / float64(params.BeaconConfig().GweiPerEth)
 			percentNet := (newBalance - prevBalance) / prevBalance
@@ -112,10 +115,32 @@ func (v *validator) LogValidatorGainsAndLosses(ctx context.Context, slot uint64)
 		if reported < len(resp.BalancesAfterEpochTransition) {
 			v.prevBalance[bytesutil.ToBytes48(pkey)] = resp.BalancesBeforeEpochTransition[reported]
 		}
+		if reported < len(resp.InclusionSlots) && reported < len(resp.BalancesAfterEpochTransition) {
+			performances = append(performances, &alerts.Performance{
+				PublicKey:     bytesutil.ToBytes48(pkey),
+				Included:      resp.InclusionSlots[reported] != ^uint64(0),
+				BalanceBefore: resp.BalancesBeforeEpochTransition[reported],
+				BalanceAfter:  resp.BalancesAfterEpochTransition[reported],
+			})
+		}
 
 		reported++
 	}
 
+	if v.alerter != nil {
+		if fired := v.alerter.Observe(slot/params.BeaconConfig().SlotsPerEpoch-1, performances); len(fired) > 0 {
+			// Post the alerts in the background so a slow alert URL doesn't delay the duties of the slot.
+			go func() {
+				if err := v.alerter.Send(context.Background(), fired); err != nil {
+					log.WithError(err).Error("Could not send validator alerts")
+				}
+			}()
+		}
+	}
+	if !v.logValidatorBalances {
+		return nil
+	}
+
 	log.WithFields(logrus.Fields{
 		"epoch":                          (slot / params.BeaconConfig().SlotsPerEpoch) - 1,
 		"attestationInclusionPercentage": fmt.Sprintf("%.2f", float64(included)/float64(len(resp.InclusionSlots))),
@@ -5,17 +5,20 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
 	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/validator/audit"
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
@@ -63,7 +66,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot uint64, pubKey [48]by
 
 	// Sign randao reveal, it's used to request block from beacon node
 	epoch := slot / params.BeaconConfig().SlotsPerEpoch
-	randaoReveal, err := v.signRandaoReveal(ctx, pubKey, epoch)
+	randaoReveal, err := v.randaoReveal(ctx, pubKey, epoch)
 	if err != nil {
 		log.WithError(err).Error("Failed to sign randao reveal")
 		if v.emitAccountMetrics {
@@ -86,6 +89,14 @@ func (v *validator) ProposeBlock(ctx context.Context, slot uint64, pubKey [48]by
 		return
 	}
 
+	if err := v.checkBlockWatermark(ctx, pubKey, slot); err != nil {
+		log.WithError(err).Error("Refusing to sign block")
+		if v.emitAccountMetrics {
+			validatorProposeFailVec.WithLabelValues(fmtKey).Inc()
+		}
+		return
+	}
+
 	if featureconfig.Get().ProtectProposer {
 		history, err := v.db.ProposalHistory(ctx, pubKey[:])
 		if err != nil {
@@ -106,6 +117,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot uint64, pubKey [48]by
 	}
 
 	// Sign returned block from beacon node
+	signStart := time.Now()
 	sig, err := v.signBlock(ctx, pubKey, epoch, b)
 	if err != nil {
 		log.WithError(err).Error("Failed to sign block")
@@ -119,6 +131,15 @@ func (v *validator) ProposeBlock(ctx context.Context, slot uint64, pubKey [48]by
 		Signature: sig,
 	}
 
+	if v.dryRun {
+		log.WithFields(logrus.Fields{
+			"slot":            b.Slot,
+			"numAttestations": len(b.Body.Attestations),
+			"signingTime":     time.Since(signStart),
+		}).Info("Dry run, not proposing block")
+		return
+	}
+
 	// Propose and broadcast block via beacon node
 	blkResp, err := v.validatorClient.ProposeBlock(ctx, blk)
 	if err != nil {
@@ -185,6 +206,7 @@ func (v *validator) signRandaoReveal(ctx context.Context, pubKey [48]byte, epoch
 	if err != nil {
 		return nil, errors.Wrap(err, "could not sign reveal")
 	}
+	v.auditSignature(audit.RandaoReveal, pubKey, helpers.StartSlot(epoch), buf, domain.SignatureDomain, randaoReveal, epoch)
 	return randaoReveal.Marshal(), nil
 }
 
@@ -207,6 +229,7 @@ func (v *validator) signBlock(ctx context.Context, pubKey [48]byte, epoch uint64
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get signing root")
 	}
+	v.auditSignature(audit.Block, pubKey, b.Slot, root, domain.SignatureDomain, sig, b)
 	return sig.Marshal(), nil
 }
 
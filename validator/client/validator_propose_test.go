@@ -80,6 +80,37 @@ func TestProposeBlock_RequestBlockFailed(t *testing.T) {
 	testutil.AssertLogsContain(t, hook, "Failed to request block from beacon node")
 }
 
+func TestProposeBlock_UsesPrecomputedRandaoReveal(t *testing.T) {
+	hook := logTest.NewGlobal()
+	validator, m, finish := setup(t)
+	defer finish()
+
+	// Domain data is only requested once, when precomputing the reveal.
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{}, nil /*err*/)
+
+	validator.precomputeRandaoReveals(context.Background(), 0, []*ethpb.DutiesResponse_Duty{
+		{
+			PublicKey:    validatorPubKey[:],
+			ProposerSlot: 1,
+			Status:       ethpb.ValidatorStatus_ACTIVE,
+		},
+	})
+	m.validatorClient.EXPECT().GetBlock(
+		gomock.Any(), // ctx
+		gomock.Any(), // block request
+	).Return(nil /*response*/, errors.New("uh oh"))
+
+	validator.ProposeBlock(context.Background(), 1, validatorPubKey)
+	testutil.AssertLogsDoNotContain(t, hook, "Failed to sign randao reveal")
+	testutil.AssertLogsContain(t, hook, "Failed to request block from beacon node")
+	if len(validator.randaoReveals) != 0 {
+		t.Errorf("Expected the precomputed reveal to be used once, %d reveals left", len(validator.randaoReveals))
+	}
+}
+
 func TestProposeBlock_ProposeBlockFailed(t *testing.T) {
 	hook := logTest.NewGlobal()
 	validator, m, finish := setup(t)
@@ -223,6 +254,29 @@ func TestProposeBlock_AllowsPastProposals(t *testing.T) {
 	testutil.AssertLogsDoNotContain(t, hook, "Tried to sign a double proposal")
 }
 
+func TestProposeBlock_BlocksBelowWatermark(t *testing.T) {
+	hook := logTest.NewGlobal()
+	validator, m, finish := setup(t)
+	defer finish()
+	defer db.TeardownDB(t, validator.db)
+	if err := validator.db.RaiseWatermarks(context.Background(), validatorPubKey[:], &db.Watermarks{MinBlockSlot: 100}); err != nil {
+		t.Fatal(err)
+	}
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), //epoch
+	).Return(&ethpb.DomainResponse{}, nil /*err*/)
+
+	m.validatorClient.EXPECT().GetBlock(
+		gomock.Any(), // ctx
+		gomock.Any(),
+	).Return(&ethpb.BeaconBlock{Body: &ethpb.BeaconBlockBody{}}, nil /*err*/)
+
+	validator.ProposeBlock(context.Background(), 99, validatorPubKey)
+	testutil.AssertLogsContain(t, hook, "Refusing to sign block")
+}
+
 func TestProposeBlock_BroadcastsBlock(t *testing.T) {
 	validator, m, finish := setup(t)
 	defer finish()
@@ -250,6 +304,31 @@ func TestProposeBlock_BroadcastsBlock(t *testing.T) {
 	validator.ProposeBlock(context.Background(), 1, validatorPubKey)
 }
 
+func TestProposeBlock_DryRunDoesNotBroadcast(t *testing.T) {
+	hook := logTest.NewGlobal()
+	validator, m, finish := setup(t)
+	defer finish()
+	validator.dryRun = true
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), //epoch
+	).Return(&ethpb.DomainResponse{}, nil /*err*/)
+
+	m.validatorClient.EXPECT().GetBlock(
+		gomock.Any(), // ctx
+		gomock.Any(),
+	).Return(&ethpb.BeaconBlock{Body: &ethpb.BeaconBlockBody{}}, nil /*err*/)
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), //epoch
+	).Return(&ethpb.DomainResponse{}, nil /*err*/)
+
+	validator.ProposeBlock(context.Background(), 1, validatorPubKey)
+	testutil.AssertLogsContain(t, hook, "Dry run, not proposing block")
+}
+
 func TestProposeBlock_BroadcastsBlock_WithGraffiti(t *testing.T) {
 	validator, m, finish := setup(t)
 	defer finish()
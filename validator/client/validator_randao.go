@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// randaoRevealKey identifies the randao reveal of a validator for an epoch.
+type randaoRevealKey struct {
+	pubKey [48]byte
+	epoch  uint64
+}
+
+// precomputeRandaoReveals signs the randao reveals of the upcoming proposals in the duties, so
+// that proposing does not wait on the signer. The reveals are only kept in memory, and those of
+// epochs before the given epoch are dropped.
+func (v *validator) precomputeRandaoReveals(ctx context.Context, epoch uint64, duties []*ethpb.DutiesResponse_Duty) {
+	v.randaoRevealsLock.Lock()
+	if v.randaoReveals == nil {
+		v.randaoReveals = make(map[randaoRevealKey][]byte)
+	}
+	for k := range v.randaoReveals {
+		if k.epoch < epoch {
+			delete(v.randaoReveals, k)
+		}
+	}
+	v.randaoRevealsLock.Unlock()
+
+	for _, duty := range duties {
+		if duty == nil || duty.Status != ethpb.ValidatorStatus_ACTIVE || duty.ProposerSlot == 0 {
+			continue
+		}
+		key := randaoRevealKey{
+			pubKey: bytesutil.ToBytes48(duty.PublicKey),
+			epoch:  duty.ProposerSlot / params.BeaconConfig().SlotsPerEpoch,
+		}
+		v.randaoRevealsLock.Lock()
+		_, ok := v.randaoReveals[key]
+		v.randaoRevealsLock.Unlock()
+		if ok {
+			continue
+		}
+		reveal, err := v.signRandaoReveal(ctx, key.pubKey, key.epoch)
+		if err != nil {
+			log.WithError(err).WithField(
+				"pubKey", fmt.Sprintf("%#x", bytesutil.Trunc(duty.PublicKey)),
+			).Warn("Failed to precompute randao reveal")
+			continue
+		}
+		v.randaoRevealsLock.Lock()
+		v.randaoReveals[key] = reveal
+		v.randaoRevealsLock.Unlock()
+	}
+}
+
+// randaoReveal returns the randao reveal of the validator for the epoch, using the precomputed
+// reveal if there is one and signing it otherwise.
+func (v *validator) randaoReveal(ctx context.Context, pubKey [48]byte, epoch uint64) ([]byte, error) {
+	key := randaoRevealKey{pubKey: pubKey, epoch: epoch}
+	v.randaoRevealsLock.Lock()
+	reveal, ok := v.randaoReveals[key]
+	delete(v.randaoReveals, key)
+	v.randaoRevealsLock.Unlock()
+	if ok {
+		return reveal, nil
+	}
+	return v.signRandaoReveal(ctx, pubKey, epoch)
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// selectionProofKey identifies the selection proof of a validator for a committee at a slot.
+type selectionProofKey struct {
+	pubKey         [48]byte
+	slot           uint64
+	committeeIndex uint64
+}
+
+// selectionProof is the signed slot of a validator, and whether it selects the validator as an
+// aggregator of its committee.
+type selectionProof struct {
+	signature  []byte
+	aggregator bool
+}
+
+// selectionProof returns the selection proof of the validator for the committee at the slot.
+// Proofs are kept in memory, so retrying a failed aggregation or recomputing the roles after
+// reconnecting to a beacon node does not sign the slot again. Proofs of slots older than an
+// epoch before the slot are dropped.
+func (v *validator) selectionProof(ctx context.Context, pubKey [48]byte, slot uint64, committeeIndex uint64, committee []uint64) (*selectionProof, error) {
+	key := selectionProofKey{pubKey: pubKey, slot: slot, committeeIndex: committeeIndex}
+	v.selectionProofsLock.Lock()
+	proof, ok := v.selectionProofs[key]
+	v.selectionProofsLock.Unlock()
+	if ok {
+		return proof, nil
+	}
+
+	slotSig, err := v.signSlot(ctx, pubKey, slot)
+	if err != nil {
+		return nil, err
+	}
+	proof = &selectionProof{
+		signature:  slotSig,
+		aggregator: isAggregatorSignature(slotSig, len(committee)),
+	}
+
+	v.selectionProofsLock.Lock()
+	defer v.selectionProofsLock.Unlock()
+	if v.selectionProofs == nil {
+		v.selectionProofs = make(map[selectionProofKey]*selectionProof)
+	}
+	for k := range v.selectionProofs {
+		if k.slot+params.BeaconConfig().SlotsPerEpoch < slot {
+			delete(v.selectionProofs, k)
+		}
+	}
+	v.selectionProofs[key] = proof
+	return proof, nil
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+// errBelowWatermark is returned when a validator is asked to sign a message below its low watermarks.
+var errBelowWatermark = errors.New("below the slashing protection low watermark")
+
+// checkAttestationWatermarks returns an error if the source or target epoch of the attestation is
+// below the low watermarks of the validator.
+func (v *validator) checkAttestationWatermarks(ctx context.Context, pubKey [48]byte, data *ethpb.AttestationData) error {
+	watermarks, err := v.db.Watermarks(ctx, pubKey[:])
+	if err != nil {
+		return errors.Wrap(err, "could not get low watermarks from DB")
+	}
+	if watermarks == nil {
+		return nil
+	}
+	if data.Source.Epoch < watermarks.MinSourceEpoch {
+		return errors.Wrapf(errBelowWatermark, "source epoch %d is lower than %d", data.Source.Epoch, watermarks.MinSourceEpoch)
+	}
+	if data.Target.Epoch < watermarks.MinTargetEpoch {
+		return errors.Wrapf(errBelowWatermark, "target epoch %d is lower than %d", data.Target.Epoch, watermarks.MinTargetEpoch)
+	}
+	return nil
+}
+
+// checkBlockWatermark returns an error if the block slot is below the low watermark of the validator.
+func (v *validator) checkBlockWatermark(ctx context.Context, pubKey [48]byte, slot uint64) error {
+	watermarks, err := v.db.Watermarks(ctx, pubKey[:])
+	if err != nil {
+		return errors.Wrap(err, "could not get low watermarks from DB")
+	}
+	if watermarks != nil && slot < watermarks.MinBlockSlot {
+		return errors.Wrapf(errBelowWatermark, "block slot %d is lower than %d", slot, watermarks.MinBlockSlot)
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ type ValidatorDB interface {
 	io.Closer
 	DatabasePath() string
 	ClearDB() error
+	InitializeHistory(ctx context.Context, pubkeys [][48]byte) error
 	// Proposer protection related methods.
 	ProposalHistory(ctx context.Context, publicKey []byte) (*slashpb.ProposalHistory, error)
 	SaveProposalHistory(ctx context.Context, publicKey []byte, history *slashpb.ProposalHistory) error
@@ -21,4 +22,14 @@ type ValidatorDB interface {
 	AttestationHistory(ctx context.Context, publicKey []byte) (*slashpb.AttestationHistory, error)
 	SaveAttestationHistory(ctx context.Context, publicKey []byte, history *slashpb.AttestationHistory) error
 	DeleteAttestationHistory(ctx context.Context, publicKey []byte) error
+	// Low watermark related methods.
+	Watermarks(ctx context.Context, publicKey []byte) (*Watermarks, error)
+	RaiseWatermarks(ctx context.Context, publicKey []byte, watermarks *Watermarks) error
+}
+
+// Watermarks -- See github.com/prysmaticlabs/prysm/validator/db.Watermarks
+type Watermarks struct {
+	MinSourceEpoch uint64
+	MinTargetEpoch uint64
+	MinBlockSlot   uint64
 }
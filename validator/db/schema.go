@@ -5,4 +5,6 @@ var (
 	historicProposalsBucket = []byte("proposal-history-bucket")
 	// Validator slashing protection from slashable attestations.
 	historicAttestationsBucket = []byte("attestation-history-bucket")
+	// Validator slashing protection from signing below the configured low watermarks.
+	watermarksBucket = []byte("watermarks-bucket")
 )
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/boltdb/bolt"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/validator/db/iface"
+	"go.opencensus.io/trace"
+)
+
+// Watermarks are the lowest attestation source epoch, attestation target epoch and block slot
+// a validator is allowed to sign.
+type Watermarks = iface.Watermarks
+
+// Watermarks returns the low watermarks of the validator public key. Returns nil if no watermarks
+// were set for the validator.
+func (db *Store) Watermarks(ctx context.Context, publicKey []byte) (*Watermarks, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.Watermarks")
+	defer span.End()
+
+	var watermarks *Watermarks
+	err := db.view(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(watermarksBucket).Get(publicKey)
+		if enc == nil {
+			return nil
+		}
+		var err error
+		watermarks, err = unmarshalWatermarks(enc)
+		return err
+	})
+	return watermarks, err
+}
+
+// RaiseWatermarks sets the low watermarks of the validator public key to the given values, keeping
+// any stored watermark which is already higher. Watermarks can never be lowered, so that restoring
+// an old configuration can't re-allow signing below them.
+func (db *Store) RaiseWatermarks(ctx context.Context, publicKey []byte, watermarks *Watermarks) error {
+	ctx, span := trace.StartSpan(ctx, "Validator.RaiseWatermarks")
+	defer span.End()
+
+	return db.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(watermarksBucket)
+		raised := *watermarks
+		if enc := bucket.Get(publicKey); enc != nil {
+			stored, err := unmarshalWatermarks(enc)
+			if err != nil {
+				return err
+			}
+			raised.MinSourceEpoch = maxUint64(raised.MinSourceEpoch, stored.MinSourceEpoch)
+			raised.MinTargetEpoch = maxUint64(raised.MinTargetEpoch, stored.MinTargetEpoch)
+			raised.MinBlockSlot = maxUint64(raised.MinBlockSlot, stored.MinBlockSlot)
+		}
+		return bucket.Put(publicKey, marshalWatermarks(&raised))
+	})
+}
+
+func marshalWatermarks(watermarks *Watermarks) []byte {
+	enc := make([]byte, 24)
+	binary.BigEndian.PutUint64(enc[:8], watermarks.MinSourceEpoch)
+	binary.BigEndian.PutUint64(enc[8:16], watermarks.MinTargetEpoch)
+	binary.BigEndian.PutUint64(enc[16:], watermarks.MinBlockSlot)
+	return enc
+}
+
+func unmarshalWatermarks(enc []byte) (*Watermarks, error) {
+	if len(enc) != 24 {
+		return nil, errors.Errorf("invalid watermarks encoding of length %d", len(enc))
+	}
+	return &Watermarks{
+		MinSourceEpoch: binary.BigEndian.Uint64(enc[:8]),
+		MinTargetEpoch: binary.BigEndian.Uint64(enc[8:16]),
+		MinBlockSlot:   binary.BigEndian.Uint64(enc[16:]),
+	}, nil
+}
+
+func maxUint64(a uint64, b uint64) uint64 {
+	if a > b {
+		return a
+	}
+	return b
+}
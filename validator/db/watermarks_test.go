@@ -0,0 +1,42 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestWatermarks_NilDB(t *testing.T) {
+	db := SetupDB(t, [][48]byte{})
+	defer TeardownDB(t, db)
+
+	watermarks, err := db.Watermarks(context.Background(), []byte{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if watermarks != nil {
+		t.Errorf("Expected no watermarks, received %v", watermarks)
+	}
+}
+
+func TestRaiseWatermarks_NeverLowers(t *testing.T) {
+	db := SetupDB(t, [][48]byte{})
+	defer TeardownDB(t, db)
+	ctx := context.Background()
+	pubKey := []byte{1, 2, 3}
+
+	if err := db.RaiseWatermarks(ctx, pubKey, &Watermarks{MinSourceEpoch: 5, MinTargetEpoch: 6, MinBlockSlot: 100}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.RaiseWatermarks(ctx, pubKey, &Watermarks{MinSourceEpoch: 2, MinTargetEpoch: 10, MinBlockSlot: 50}); err != nil {
+		t.Fatal(err)
+	}
+	watermarks, err := db.Watermarks(ctx, pubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Watermarks{MinSourceEpoch: 5, MinTargetEpoch: 10, MinBlockSlot: 100}
+	if !reflect.DeepEqual(watermarks, want) {
+		t.Errorf("Wanted watermarks %v, received %v", want, watermarks)
+	}
+}
@@ -1,6 +1,8 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/prysmaticlabs/prysm/shared/cmd"
 	"github.com/urfave/cli"
 )
@@ -11,10 +13,10 @@ var (
 		Name:  "no-custom-config",
 		Usage: "Run the beacon chain with the real parameters from phase 0.",
 	}
-	// BeaconRPCProviderFlag defines a beacon node RPC endpoint.
+	// BeaconRPCProviderFlag defines the beacon node RPC endpoints.
 	BeaconRPCProviderFlag = cli.StringFlag{
 		Name:  "beacon-rpc-provider",
-		Usage: "Beacon node RPC provider endpoint",
+		Usage: "Beacon node RPC provider endpoint, or a comma separated list of endpoints scored by sync distance, latency and error rate, each request being sent to the best one",
 		Value: "localhost:4000",
 	}
 	// CertFlag defines a flag for the node's TLS certificate.
@@ -22,6 +24,21 @@ var (
 		Name:  "tls-cert",
 		Usage: "Certificate for secure gRPC. Pass this and the tls-key flag in order to use gRPC securely.",
 	}
+	// ClientCertFlag defines a flag for the TLS certificate the validator authenticates with.
+	ClientCertFlag = cli.StringFlag{
+		Name:  "tls-client-cert",
+		Usage: "Certificate to authenticate with to a beacon node requiring mutual TLS. Pass this and the tls-client-key flag.",
+	}
+	// ClientKeyFlag defines a flag for the key of the TLS certificate the validator authenticates with.
+	ClientKeyFlag = cli.StringFlag{
+		Name:  "tls-client-key",
+		Usage: "Key to authenticate with to a beacon node requiring mutual TLS. Pass this and the tls-client-cert flag.",
+	}
+	// BeaconRPCAPIKeyFlag defines the API key sent to the beacon node, when its RPC server requires one.
+	BeaconRPCAPIKeyFlag = cli.StringFlag{
+		Name:  "beacon-rpc-api-key",
+		Usage: "API key sent to the beacon node RPC server in the x-api-key metadata",
+	}
 	// KeystorePathFlag defines the location of the keystore directory for a validator's account.
 	KeystorePathFlag = cmd.DirectoryFlag{
 		Name:  "keystore-path",
@@ -52,6 +69,53 @@ var (
 		Name:  "password",
 		Usage: "String value of the password for your validator private keys",
 	}
+	// KeysDirFlag defines the directory or zip archive of EIP-2335 keystores to import.
+	KeysDirFlag = cli.StringFlag{
+		Name:  "keys-dir",
+		Usage: "Path to a directory or zip archive of EIP-2335 keystores, such as the validator keys of Lighthouse, Teku or the deposit launchpad",
+	}
+	// PasswordsDirFlag defines the directory of password files of the keystores to import.
+	PasswordsDirFlag = cli.StringFlag{
+		Name: "passwords-dir",
+		Usage: "Path to a directory of password files for the imported keystores, named after the public key or the " +
+			"keystore file name of each keystore. Keystores without a password file are decrypted with --password",
+	}
+	// DryRunFlag reports the keys which would be imported without importing them.
+	DryRunFlag = cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Decrypt the keystores and report the keys already in the validator keystore without importing any key",
+	}
+	// PubKeysFlag defines the public keys of the accounts to back up.
+	PubKeysFlag = cli.StringFlag{
+		Name:  "pubkeys",
+		Usage: "Comma separated list of the hex encoded public keys of the accounts to back up. All accounts are backed up if empty",
+	}
+	// BackupDirFlag defines the directory the accounts backup is written to.
+	BackupDirFlag = cli.StringFlag{
+		Name:  "backup-dir",
+		Usage: "Path to the directory the encrypted accounts backup is written to",
+	}
+	// BackupFileFlag defines the accounts backup to restore.
+	BackupFileFlag = cli.StringFlag{
+		Name:  "backup-file",
+		Usage: "Path to the encrypted accounts backup to restore",
+	}
+	// BackupPasswordFlag defines the password of the accounts backup.
+	BackupPasswordFlag = cli.StringFlag{
+		Name:  "backup-password",
+		Usage: "Password encrypting the accounts backup. Defaults to --password",
+	}
+	// DetailedFlag lists the on-chain status and withdrawal credentials of the accounts.
+	DetailedFlag = cli.BoolFlag{
+		Name:  "detailed",
+		Usage: "Query the beacon node for the status and withdrawal credentials of each account",
+	}
+	// ExpectedWithdrawalCredentialsFlag defines the prefix the withdrawal credentials of the accounts are expected to have.
+	ExpectedWithdrawalCredentialsFlag = cli.StringFlag{
+		Name: "expected-withdrawal-credentials",
+		Usage: "Hex encoded prefix the withdrawal credentials of the accounts are expected to start with, such as 0x01 " +
+			"followed by the padded withdrawal address. Defaults to the BLS withdrawal prefix",
+	}
 	// DisablePenaltyRewardLogFlag defines the ability to not log reward/penalty information during deployment
 	DisablePenaltyRewardLogFlag = cli.BoolFlag{
 		Name:  "disable-rewards-penalties-logging",
@@ -67,6 +131,11 @@ var (
 		Name:  "grpc-max-msg-size",
 		Usage: "Integer to define max recieve message call size (default: 52428800 (for 50Mb)).",
 	}
+	// GrpcHeadersFlag defines the metadata sent along with every gRPC request to the beacon node.
+	GrpcHeadersFlag = cli.StringFlag{
+		Name:  "grpc-headers",
+		Usage: "Comma separated list of key=value pairs sent as metadata with every gRPC request, e.g. authorization=Bearer abc",
+	}
 	// GrpcRetriesFlag defines the number of times to retry a failed gRPC request.
 	GrpcRetriesFlag = cli.UintFlag{
 		Name:  "grpc-retries",
@@ -78,4 +147,90 @@ var (
 		Name:  "enable-account-metrics",
 		Usage: "Enable prometheus metrics for validator accounts",
 	}
+	// StatsPushURLFlag defines the URL a JSON summary of the validator client is periodically posted to.
+	StatsPushURLFlag = cli.StringFlag{
+		Name: "stats-push-url",
+		Usage: "URL to periodically POST a JSON summary of the validator balances, duties performed and host " +
+			"metrics to, for monitoring services which can't scrape the metrics. Enables account metrics.",
+	}
+	// StatsPushIntervalFlag defines how often the summary of the validator client is posted.
+	StatsPushIntervalFlag = cli.DurationFlag{
+		Name:  "stats-push-interval",
+		Usage: "Interval between posts of the validator client summary to the stats push URL",
+		Value: time.Minute,
+	}
+	// MinAttestationSourceEpochFlag defines the lowest attestation source epoch the validators may sign.
+	MinAttestationSourceEpochFlag = cli.Uint64Flag{
+		Name: "min-attestation-source-epoch",
+		Usage: "Refuse to sign attestations with a source epoch lower than this value. The watermark is saved " +
+			"for every validator key in the database and can only be raised, guarding against restored stale backups",
+	}
+	// MinAttestationTargetEpochFlag defines the lowest attestation target epoch the validators may sign.
+	MinAttestationTargetEpochFlag = cli.Uint64Flag{
+		Name: "min-attestation-target-epoch",
+		Usage: "Refuse to sign attestations with a target epoch lower than this value. The watermark is saved " +
+			"for every validator key in the database and can only be raised, guarding against restored stale backups",
+	}
+	// MinBlockSlotFlag defines the lowest block slot the validators may sign.
+	MinBlockSlotFlag = cli.Uint64Flag{
+		Name: "min-block-slot",
+		Usage: "Refuse to sign blocks with a slot lower than this value. The watermark is saved for every " +
+			"validator key in the database and can only be raised, guarding against restored stale backups",
+	}
+	// AlertURLFlag defines the URL alerts about missed attestations and balance losses are posted to.
+	AlertURLFlag = cli.StringFlag{
+		Name: "alert-url",
+		Usage: "URL to POST a JSON alert to when a validator misses too many attestations or keeps losing " +
+			"balance, such as a webhook or https://events.pagerduty.com/v2/enqueue",
+	}
+	// AlertPagerDutyRoutingKeyFlag defines the PagerDuty routing key of the alerts.
+	AlertPagerDutyRoutingKeyFlag = cli.StringFlag{
+		Name:  "alert-pagerduty-routing-key",
+		Usage: "Send the alerts as PagerDuty events API v2 events with the routing key",
+	}
+	// AlertMissedAttestationsFlag defines how many attestations a validator may miss before an alert is sent.
+	AlertMissedAttestationsFlag = cli.Uint64Flag{
+		Name:  "alert-missed-attestations",
+		Usage: "Alert when a validator misses more than this many attestations within the alert window, 0 to alert on any miss",
+		Value: 2,
+	}
+	// AlertMissedAttestationsEpochsFlag defines the window of epochs missed attestations are counted in.
+	AlertMissedAttestationsEpochsFlag = cli.Uint64Flag{
+		Name:  "alert-missed-attestations-epochs",
+		Usage: "Number of recent epochs missed attestations are counted in, 0 to disable missed attestation alerts",
+		Value: 10,
+	}
+	// AlertBalanceLossEpochsFlag defines after how many consecutive epochs of balance loss an alert is sent.
+	AlertBalanceLossEpochsFlag = cli.Uint64Flag{
+		Name:  "alert-balance-loss-epochs",
+		Usage: "Alert when the balance of a validator decreases for this many consecutive epochs, 0 to disable balance loss alerts",
+		Value: 3,
+	}
+	// DutiesDryRunFlag defines whether the validator client performs its duties without submitting them.
+	DutiesDryRunFlag = cli.BoolFlag{
+		Name: "dry-run",
+		Usage: "Perform all duties, including the state queries and signing, but never submit blocks, attestations " +
+			"or aggregates to the beacon node. Used to check the beacon node connectivity and signing latency " +
+			"before going live, slashing protection history is not updated",
+	}
+	// AuditLogFlag defines the file of the audit log of signed objects.
+	AuditLogFlag = cli.StringFlag{
+		Name: "audit-log",
+		Usage: "Append a record of every object signed by the validator client, with its type, slot, signing root, " +
+			"public key, timestamp and the SSZ encoded object, to this file. The records can be exported at " +
+			"/audit/export on the monitoring port",
+	}
+	// KeymanagerAPITokenFileFlag defines the file holding the bearer token of the keymanager API.
+	KeymanagerAPITokenFileFlag = cli.StringFlag{
+		Name: "keymanager-api-token-file",
+		Usage: "Serve the standard keymanager API to list, import and delete keys at /eth/v1/keystores on " +
+			"127.0.0.1 at the keymanager API port, authenticated with the bearer token held in this file. Keys " +
+			"can only be imported and deleted with the keystore key manager",
+	}
+	// KeymanagerAPIPortFlag defines the port of the loopback interface the keymanager API is served on.
+	KeymanagerAPIPortFlag = cli.Int64Flag{
+		Name:  "keymanager-api-port",
+		Usage: "Port of 127.0.0.1 the keymanager API is served on. It is never served on other interfaces",
+		Value: 7500,
+	}
 )
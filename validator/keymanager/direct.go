@@ -1,12 +1,15 @@
 package keymanager
 
 import (
+	"sync"
+
 	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 )
 
 // Direct is a key manager that holds all secret keys directly.
 type Direct struct {
+	lock sync.RWMutex
 	// Key to the map is the bytes of the public key.
 	publicKeys map[[48]byte]*bls.PublicKey
 	// Key to the map is the bytes of the public key.
@@ -30,6 +33,8 @@ func NewDirect(sks []*bls.SecretKey) *Direct {
 
 // FetchValidatingKeys fetches the list of public keys that should be used to validate with.
 func (km *Direct) FetchValidatingKeys() ([][48]byte, error) {
+	km.lock.RLock()
+	defer km.lock.RUnlock()
 	keys := make([][48]byte, 0, len(km.publicKeys))
 	for key := range km.publicKeys {
 		keys = append(keys, key)
@@ -39,8 +44,32 @@ func (km *Direct) FetchValidatingKeys() ([][48]byte, error) {
 
 // Sign signs a message for the validator to broadcast.
 func (km *Direct) Sign(pubKey [48]byte, root [32]byte, domain uint64) (*bls.Signature, error) {
+	km.lock.RLock()
+	defer km.lock.RUnlock()
 	if secretKey, exists := km.secretKeys[pubKey]; exists {
 		return secretKey.Sign(root[:], domain), nil
 	}
 	return nil, ErrNoSuchKey
 }
+
+// addKey starts validating with the secret key.
+func (km *Direct) addKey(sk *bls.SecretKey) {
+	km.lock.Lock()
+	defer km.lock.Unlock()
+	publicKey := sk.PublicKey()
+	pubKey := bytesutil.ToBytes48(publicKey.Marshal())
+	km.publicKeys[pubKey] = publicKey
+	km.secretKeys[pubKey] = sk
+}
+
+// removeKey stops validating with the key of the public key. Returns false if the key is unknown.
+func (km *Direct) removeKey(pubKey [48]byte) bool {
+	km.lock.Lock()
+	defer km.lock.Unlock()
+	if _, ok := km.secretKeys[pubKey]; !ok {
+		return false
+	}
+	delete(km.publicKeys, pubKey)
+	delete(km.secretKeys, pubKey)
+	return true
+}
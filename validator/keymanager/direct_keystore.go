@@ -1,6 +1,7 @@
 package keymanager
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"os"
 	"os/user"
@@ -8,15 +9,21 @@ import (
 	"runtime"
 	"strings"
 
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/keystore"
+	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/validator/accounts"
 	"golang.org/x/crypto/ssh/terminal"
 )
 
-// Keystore is a key manager that loads keys from a standard keystore.
+// Keystore is a key manager that loads keys from a standard keystore. Keys can be imported into and
+// deleted from the keystore while the validator runs.
 type Keystore struct {
 	*Direct
+	path       string
+	passphrase string
 }
 
 type keystoreOpts struct {
@@ -80,11 +87,13 @@ func NewKeystore(input string) (KeyManager, string, error) {
 		return nil, keystoreOptsHelp, err
 	}
 
-	km := &Unencrypted{
+	km := &Keystore{
 		Direct: &Direct{
 			publicKeys: make(map[[48]byte]*bls.PublicKey),
 			secretKeys: make(map[[48]byte]*bls.SecretKey),
 		},
+		path:       opts.Path,
+		passphrase: opts.Passphrase,
 	}
 	for _, key := range keyMap {
 		pubKey := bytesutil.ToBytes48(key.PublicKey.Marshal())
@@ -94,6 +103,30 @@ func NewKeystore(input string) (KeyManager, string, error) {
 	return km, "", nil
 }
 
+// ImportKey stores the key in the keystore, encrypted with the keystore passphrase, and starts
+// validating with it.
+func (km *Keystore) ImportKey(key *keystore.Key) error {
+	pubKeyHex := hex.EncodeToString(key.PublicKey.Marshal())
+	keyFile := km.path + params.BeaconConfig().ValidatorPrivkeyFileName + pubKeyHex[:12]
+	if err := keystore.NewKeystore(km.path).StoreKey(keyFile, key, km.passphrase); err != nil {
+		return errors.Wrap(err, "unable to store key")
+	}
+	km.addKey(key.SecretKey)
+	return nil
+}
+
+// DeleteKey stops validating with the key and removes it from the keystore. Returns false if the
+// key is unknown.
+func (km *Keystore) DeleteKey(pubKey [48]byte) (bool, error) {
+	if !km.removeKey(pubKey) {
+		return false, nil
+	}
+	if _, err := accounts.DeleteKeyFromKeystore(km.path, pubKey[:]); err != nil {
+		return true, errors.Wrap(err, "could not delete key from keystore")
+	}
+	return true, nil
+}
+
 func homeDir() string {
 	if home := os.Getenv("HOME"); home != "" {
 		return home
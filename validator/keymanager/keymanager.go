@@ -5,6 +5,7 @@ import (
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/keystore"
 )
 
 // ErrNoSuchKey is returned whenever a request is made for a key of which a key manager is unaware.
@@ -24,6 +25,16 @@ type KeyManager interface {
 	Sign(pubKey [48]byte, root [32]byte, domain uint64) (*bls.Signature, error)
 }
 
+// MutableKeyManager provides access to a key manager whose keys can be imported and deleted while the
+// validator runs.
+type MutableKeyManager interface {
+	KeyManager
+	// ImportKey stores the key and starts validating with it.
+	ImportKey(key *keystore.Key) error
+	// DeleteKey stops validating with the key and removes it from storage. Returns false if the key is unknown.
+	DeleteKey(pubKey [48]byte) (bool, error)
+}
+
 // ProtectingKeyManager provides access to a keymanager that protects its clients from slashing events.
 type ProtectingKeyManager interface {
 	// SignProposal signs a block proposal for the validator to broadcast.
@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"runtime"
 	runtimeDebug "runtime/debug"
+	"strings"
 
 	joonix "github.com/joonix/log"
 	"github.com/prysmaticlabs/prysm/shared/cmd"
@@ -34,9 +37,13 @@ func startNode(ctx *cli.Context) error {
 }
 
 var appFlags = []cli.Flag{
+	cmd.ConfigFileFlag,
 	flags.NoCustomConfigFlag,
 	flags.BeaconRPCProviderFlag,
 	flags.CertFlag,
+	flags.ClientCertFlag,
+	flags.ClientKeyFlag,
+	flags.BeaconRPCAPIKeyFlag,
 	flags.GraffitiFlag,
 	flags.KeystorePathFlag,
 	flags.PasswordFlag,
@@ -46,9 +53,24 @@ var appFlags = []cli.Flag{
 	flags.InteropNumValidators,
 	flags.GrpcMaxCallRecvMsgSizeFlag,
 	flags.GrpcRetriesFlag,
+	flags.GrpcHeadersFlag,
 	flags.KeyManager,
 	flags.KeyManagerOpts,
 	flags.AccountMetricsFlag,
+	flags.StatsPushURLFlag,
+	flags.StatsPushIntervalFlag,
+	flags.AlertURLFlag,
+	flags.AlertPagerDutyRoutingKeyFlag,
+	flags.AlertMissedAttestationsFlag,
+	flags.AlertMissedAttestationsEpochsFlag,
+	flags.AlertBalanceLossEpochsFlag,
+	flags.MinAttestationSourceEpochFlag,
+	flags.MinAttestationTargetEpochFlag,
+	flags.MinBlockSlotFlag,
+	flags.KeymanagerAPITokenFileFlag,
+	flags.KeymanagerAPIPortFlag,
+	flags.DutiesDryRunFlag,
+	flags.AuditLogFlag,
 	cmd.VerbosityFlag,
 	cmd.DataDirFlag,
 	cmd.ClearDB,
@@ -68,6 +90,7 @@ var appFlags = []cli.Flag{
 	debug.TraceFlag,
 	cmd.LogFileName,
 	cmd.EnableUPnPFlag,
+	cmd.MaxClockDisparityFlag,
 }
 
 func init() {
@@ -115,6 +138,93 @@ contract in order to activate the validator client`,
 						}
 					},
 				},
+				cli.Command{
+					Name: "import",
+					Description: `imports the EIP-2335 keystores of other clients and of the deposit launchpad into the
+validator client keystore, encrypted with the validator client password. The slashing protection low
+watermarks set by flag are saved for the imported keys`,
+					Flags: []cli.Flag{
+						flags.KeysDirFlag,
+						flags.KeystorePathFlag,
+						flags.PasswordFlag,
+						flags.PasswordsDirFlag,
+						flags.DryRunFlag,
+					},
+					Action: func(ctx *cli.Context) {
+						res, err := accounts.ImportKeystores(&accounts.ImportOpts{
+							KeysDir:      ctx.String(flags.KeysDirFlag.Name),
+							KeystorePath: ctx.String(flags.KeystorePathFlag.Name),
+							Password:     ctx.String(flags.PasswordFlag.Name),
+							PasswordsDir: ctx.String(flags.PasswordsDirFlag.Name),
+							DryRun:       ctx.Bool(flags.DryRunFlag.Name),
+							DataDir:      ctx.GlobalString(cmd.DataDirFlag.Name),
+							Watermarks:   node.Watermarks(ctx),
+						})
+						if err != nil {
+							log.WithError(err).Fatal("Could not import keystores")
+						}
+						log.WithFields(logrus.Fields{
+							"imported":  len(res.Imported),
+							"conflicts": len(res.Conflicts),
+						}).Info("Keystore import complete")
+					},
+				},
+				cli.Command{
+					Name: "backup",
+					Description: `writes the selected accounts along with their slashing protection history into a zip
+archive encrypted with the backup password, to move validators between machines`,
+					Flags: []cli.Flag{
+						flags.KeystorePathFlag,
+						flags.PasswordFlag,
+						flags.PubKeysFlag,
+						flags.BackupDirFlag,
+						flags.BackupPasswordFlag,
+					},
+					Action: func(ctx *cli.Context) {
+						var pubKeys [][]byte
+						for _, pubKey := range strings.Split(ctx.String(flags.PubKeysFlag.Name), ",") {
+							if pubKey = strings.TrimSpace(pubKey); pubKey == "" {
+								continue
+							}
+							b, err := hex.DecodeString(strings.TrimPrefix(pubKey, "0x"))
+							if err != nil {
+								log.WithError(err).Fatalf("Invalid public key %s", pubKey)
+							}
+							pubKeys = append(pubKeys, b)
+						}
+						if _, err := accounts.BackupAccounts(context.Background(), &accounts.BackupOpts{
+							KeystorePath:   ctx.String(flags.KeystorePathFlag.Name),
+							Password:       ctx.String(flags.PasswordFlag.Name),
+							PubKeys:        pubKeys,
+							DataDir:        ctx.GlobalString(cmd.DataDirFlag.Name),
+							BackupDir:      ctx.String(flags.BackupDirFlag.Name),
+							BackupPassword: ctx.String(flags.BackupPasswordFlag.Name),
+						}); err != nil {
+							log.WithError(err).Fatal("Could not back up accounts")
+						}
+					},
+				},
+				cli.Command{
+					Name:        "restore",
+					Description: `restores the accounts and slashing protection history of an encrypted accounts backup`,
+					Flags: []cli.Flag{
+						flags.BackupFileFlag,
+						flags.BackupPasswordFlag,
+						flags.KeystorePathFlag,
+						flags.PasswordFlag,
+					},
+					Action: func(ctx *cli.Context) {
+						if err := accounts.RestoreAccounts(context.Background(), &accounts.RestoreOpts{
+							BackupFile:     ctx.String(flags.BackupFileFlag.Name),
+							BackupPassword: ctx.String(flags.BackupPasswordFlag.Name),
+							KeystorePath:   ctx.String(flags.KeystorePathFlag.Name),
+							Password:       ctx.String(flags.PasswordFlag.Name),
+							DataDir:        ctx.GlobalString(cmd.DataDirFlag.Name),
+						}); err != nil {
+							log.WithError(err).Fatal("Could not restore accounts")
+						}
+					},
+				},
 				cli.Command{
 					Name:        "keys",
 					Description: `lists the private keys for 'keystore' keymanager keys`,
@@ -138,12 +248,44 @@ contract in order to activate the validator client`,
 						}
 					},
 				},
+				cli.Command{
+					Name:        "list",
+					Description: `lists the public keys of the accounts, along with their on-chain status and withdrawal credentials with --detailed`,
+					Flags: []cli.Flag{
+						flags.KeystorePathFlag,
+						flags.PasswordFlag,
+						flags.DetailedFlag,
+						flags.BeaconRPCProviderFlag,
+						flags.CertFlag,
+						flags.ExpectedWithdrawalCredentialsFlag,
+					},
+					Action: func(ctx *cli.Context) {
+						expected, err := hex.DecodeString(strings.TrimPrefix(ctx.String(flags.ExpectedWithdrawalCredentialsFlag.Name), "0x"))
+						if err != nil {
+							log.WithError(err).Fatalf("Could not decode %s", flags.ExpectedWithdrawalCredentialsFlag.Name)
+						}
+						if err := accounts.ListAccounts(context.Background(), &accounts.ListOpts{
+							KeystorePath:                  ctx.String(flags.KeystorePathFlag.Name),
+							Password:                      ctx.String(flags.PasswordFlag.Name),
+							Detailed:                      ctx.Bool(flags.DetailedFlag.Name),
+							BeaconRPCProvider:             ctx.String(flags.BeaconRPCProviderFlag.Name),
+							Cert:                          ctx.String(flags.CertFlag.Name),
+							ExpectedWithdrawalCredentials: expected,
+						}); err != nil {
+							log.WithError(err).Fatal("Could not list accounts")
+						}
+					},
+				},
 			},
 		},
+		cmd.ConfigCommand(appFlags),
 	}
 	app.Flags = appFlags
 
 	app.Before = func(ctx *cli.Context) error {
+		if err := cmd.LoadFlagsFromConfigFile(ctx, appFlags); err != nil {
+			return err
+		}
 		format := ctx.GlobalString(cmd.LogFormat.Name)
 		switch format {
 		case "text":
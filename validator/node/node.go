@@ -17,14 +17,19 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/cmd"
 	"github.com/prysmaticlabs/prysm/shared/debug"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/localserver"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/prometheus"
+	"github.com/prysmaticlabs/prysm/shared/slotutil"
 	"github.com/prysmaticlabs/prysm/shared/tracing"
 	"github.com/prysmaticlabs/prysm/shared/version"
+	"github.com/prysmaticlabs/prysm/validator/alerts"
+	"github.com/prysmaticlabs/prysm/validator/audit"
 	"github.com/prysmaticlabs/prysm/validator/client"
 	"github.com/prysmaticlabs/prysm/validator/db"
 	"github.com/prysmaticlabs/prysm/validator/flags"
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/validator/stats"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
 )
@@ -112,7 +117,11 @@ func NewValidatorClient(ctx *cli.Context) (*ValidatorClient, error) {
 		}
 	}
 
-	if err := ValidatorClient.registerPrometheusService(ctx); err != nil {
+	if err := ValidatorClient.registerClockService(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := ValidatorClient.registerStatsService(ctx); err != nil {
 		return nil, err
 	}
 
@@ -120,6 +129,14 @@ func NewValidatorClient(ctx *cli.Context) (*ValidatorClient, error) {
 		return nil, err
 	}
 
+	if err := ValidatorClient.registerPrometheusService(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := ValidatorClient.registerKeymanagerAPIService(ctx); err != nil {
+		return nil, err
+	}
+
 	return ValidatorClient, nil
 }
 
@@ -168,34 +185,118 @@ func (s *ValidatorClient) Close() {
 	close(s.stop)
 }
 
+func (s *ValidatorClient) registerClockService(ctx *cli.Context) error {
+	svc := slotutil.NewClockService(context.Background(), ctx.GlobalDuration(cmd.MaxClockDisparityFlag.Name))
+	return s.services.RegisterService(svc)
+}
+
 func (s *ValidatorClient) registerPrometheusService(ctx *cli.Context) error {
+	var additionalHandlers []prometheus.Handler
+	if ctx.GlobalString(flags.AuditLogFlag.Name) != "" {
+		var v *client.ValidatorService
+		if err := s.services.FetchService(&v); err != nil {
+			return err
+		}
+		additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/audit/export", Handler: v.AuditLogHandler})
+	}
 	service := prometheus.NewPrometheusService(
 		fmt.Sprintf(":%d", ctx.GlobalInt64(cmd.MonitoringPortFlag.Name)),
 		s.services,
+		additionalHandlers...,
 	)
 	logrus.AddHook(prometheus.NewLogrusCollector())
 	return s.services.RegisterService(service)
 }
 
+// registerKeymanagerAPIService serves the keymanager API on the loopback interface only, as it
+// receives keystores and their passwords over plain HTTP.
+func (s *ValidatorClient) registerKeymanagerAPIService(ctx *cli.Context) error {
+	if ctx.GlobalString(flags.KeymanagerAPITokenFileFlag.Name) == "" {
+		return nil
+	}
+	var v *client.ValidatorService
+	if err := s.services.FetchService(&v); err != nil {
+		return err
+	}
+	service := localserver.NewService(
+		ctx.GlobalInt64(flags.KeymanagerAPIPortFlag.Name),
+		localserver.Handler{Path: "/eth/v1/keystores", Handler: v.KeystoresHandler},
+	)
+	return s.services.RegisterService(service)
+}
+
+func (s *ValidatorClient) registerStatsService(ctx *cli.Context) error {
+	url := ctx.GlobalString(flags.StatsPushURLFlag.Name)
+	if url == "" {
+		return nil
+	}
+	period := ctx.GlobalDuration(flags.StatsPushIntervalFlag.Name)
+	if period <= 0 {
+		return errors.Errorf("invalid stats push interval %v", period)
+	}
+	svc := stats.NewService(context.Background(), &stats.Config{
+		URL:    url,
+		Period: period,
+	})
+	return s.services.RegisterService(svc)
+}
+
 func (s *ValidatorClient) registerClientService(ctx *cli.Context, keyManager keymanager.KeyManager) error {
 	endpoint := ctx.GlobalString(flags.BeaconRPCProviderFlag.Name)
 	dataDir := ctx.GlobalString(cmd.DataDirFlag.Name)
 	logValidatorBalances := !ctx.GlobalBool(flags.DisablePenaltyRewardLogFlag.Name)
-	emitAccountMetrics := ctx.GlobalBool(flags.AccountMetricsFlag.Name)
+	// The pushed stats are gathered from the account metrics.
+	emitAccountMetrics := ctx.GlobalBool(flags.AccountMetricsFlag.Name) || ctx.GlobalString(flags.StatsPushURLFlag.Name) != ""
 	cert := ctx.GlobalString(flags.CertFlag.Name)
+	clientCert := ctx.GlobalString(flags.ClientCertFlag.Name)
+	clientKey := ctx.GlobalString(flags.ClientKeyFlag.Name)
+	apiKey := ctx.GlobalString(flags.BeaconRPCAPIKeyFlag.Name)
 	graffiti := ctx.GlobalString(flags.GraffitiFlag.Name)
 	maxCallRecvMsgSize := ctx.GlobalInt(flags.GrpcMaxCallRecvMsgSizeFlag.Name)
 	grpcRetries := ctx.GlobalUint(flags.GrpcRetriesFlag.Name)
+	grpcHeaders := ctx.GlobalString(flags.GrpcHeadersFlag.Name)
+	var keymanagerAPIToken string
+	if tokenFile := ctx.GlobalString(flags.KeymanagerAPITokenFileFlag.Name); tokenFile != "" {
+		enc, err := ioutil.ReadFile(tokenFile)
+		if err != nil {
+			return errors.Wrap(err, "could not read keymanager API token file")
+		}
+		keymanagerAPIToken = strings.TrimSpace(string(enc))
+		if keymanagerAPIToken == "" {
+			return errors.New("keymanager API token file is empty")
+		}
+	}
+	alerter, err := newAlerter(ctx)
+	if err != nil {
+		return err
+	}
+	var auditLog *audit.Log
+	if path := ctx.GlobalString(flags.AuditLogFlag.Name); path != "" {
+		auditLog, err = audit.NewLog(path)
+		if err != nil {
+			return err
+		}
+		log.WithField("path", path).Info("Recording signed objects in the audit log")
+	}
 	v, err := client.NewValidatorService(context.Background(), &client.Config{
 		Endpoint:                   endpoint,
 		DataDir:                    dataDir,
 		KeyManager:                 keyManager,
 		LogValidatorBalances:       logValidatorBalances,
 		EmitAccountMetrics:         emitAccountMetrics,
+		DryRun:                     ctx.GlobalBool(flags.DutiesDryRunFlag.Name),
+		Alerter:                    alerter,
+		Watermarks:                 Watermarks(ctx),
 		CertFlag:                   cert,
+		ClientCertFlag:             clientCert,
+		ClientKeyFlag:              clientKey,
+		APIKey:                     apiKey,
 		GraffitiFlag:               graffiti,
 		GrpcMaxCallRecvMsgSizeFlag: maxCallRecvMsgSize,
 		GrpcRetriesFlag:            grpcRetries,
+		GrpcHeadersFlag:            grpcHeaders,
+		KeymanagerAPIToken:         keymanagerAPIToken,
+		AuditLog:                   auditLog,
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not initialize client service")
@@ -203,6 +304,26 @@ func (s *ValidatorClient) registerClientService(ctx *cli.Context, keyManager key
 	return s.services.RegisterService(v)
 }
 
+// newAlerter creates the alerter of the validator performance, if an alert URL is configured.
+func newAlerter(ctx *cli.Context) (*alerts.Alerter, error) {
+	url := ctx.GlobalString(flags.AlertURLFlag.Name)
+	if url == "" {
+		return nil, nil
+	}
+	missed := ctx.GlobalUint64(flags.AlertMissedAttestationsFlag.Name)
+	window := ctx.GlobalUint64(flags.AlertMissedAttestationsEpochsFlag.Name)
+	if window > 0 && missed >= window {
+		return nil, errors.Errorf("alert missed attestations %d must be lower than the %d epochs they are counted in", missed, window)
+	}
+	return alerts.NewAlerter(&alerts.Config{
+		URL:                      url,
+		PagerDutyRoutingKey:      ctx.GlobalString(flags.AlertPagerDutyRoutingKeyFlag.Name),
+		MissedAttestations:       missed,
+		MissedAttestationsWindow: window,
+		BalanceLossEpochs:        ctx.GlobalUint64(flags.AlertBalanceLossEpochsFlag.Name),
+	}), nil
+}
+
 // selectKeyManager selects the key manager depending on the options provided by the user.
 func selectKeyManager(ctx *cli.Context) (keymanager.KeyManager, error) {
 	manager := strings.ToLower(ctx.String(flags.KeyManager.Name))
@@ -295,3 +416,17 @@ func clearDB(dataDir string, pubkeys [][48]byte, force bool) error {
 
 	return nil
 }
+
+// Watermarks returns the slashing protection low watermarks set by flag, or nil if none is set.
+func Watermarks(ctx *cli.Context) *db.Watermarks {
+	if !ctx.GlobalIsSet(flags.MinAttestationSourceEpochFlag.Name) &&
+		!ctx.GlobalIsSet(flags.MinAttestationTargetEpochFlag.Name) &&
+		!ctx.GlobalIsSet(flags.MinBlockSlotFlag.Name) {
+		return nil
+	}
+	return &db.Watermarks{
+		MinSourceEpoch: ctx.GlobalUint64(flags.MinAttestationSourceEpochFlag.Name),
+		MinTargetEpoch: ctx.GlobalUint64(flags.MinAttestationTargetEpochFlag.Name),
+		MinBlockSlot:   ctx.GlobalUint64(flags.MinBlockSlotFlag.Name),
+	}
+}
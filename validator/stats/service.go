@@ -0,0 +1,196 @@
+// Package stats periodically pushes a JSON summary of the validator client to an external
+// monitoring service, for setups where the Prometheus metrics of the client can't be scraped.
+package stats
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prysmaticlabs/prysm/shared/runutil"
+	"github.com/prysmaticlabs/prysm/shared/version"
+)
+
+// Summary is the JSON document pushed to the monitoring service.
+type Summary struct {
+	Timestamp  int64                        `json:"timestamp"`
+	Version    string                       `json:"version"`
+	Validators map[string]*ValidatorSummary `json:"validators"`
+	Host       *HostSummary                 `json:"host"`
+}
+
+// ValidatorSummary is the balance and duties performed by a validator, keyed by public key
+// in the summary. Duty counts are totals since the client started.
+type ValidatorSummary struct {
+	Balance                float64 `json:"balance"`
+	SuccessfulAttestations uint64  `json:"successful_attestations"`
+	FailedAttestations     uint64  `json:"failed_attestations"`
+	SuccessfulProposals    uint64  `json:"successful_proposals"`
+	FailedProposals        uint64  `json:"failed_proposals"`
+	SuccessfulAggregations uint64  `json:"successful_aggregations"`
+	FailedAggregations     uint64  `json:"failed_aggregations"`
+}
+
+// HostSummary is the resource usage of the validator client process.
+type HostSummary struct {
+	CPUSeconds          float64 `json:"cpu_seconds"`
+	ResidentMemoryBytes uint64  `json:"resident_memory_bytes"`
+	Goroutines          uint64  `json:"goroutines"`
+	UptimeSeconds       uint64  `json:"uptime_seconds"`
+}
+
+// Config for the stats service.
+type Config struct {
+	URL      string
+	Period   time.Duration
+	Gatherer prometheus.Gatherer
+}
+
+// Service pushes the summary of the validator client to a URL every period.
+type Service struct {
+	ctx        context.Context
+	cancel     context.CancelFunc
+	url        string
+	period     time.Duration
+	gatherer   prometheus.Gatherer
+	client     *http.Client
+	lock       sync.RWMutex
+	failStatus error
+}
+
+// NewService creates a new stats service. Metrics are gathered from the Prometheus default
+// gatherer unless another one is configured.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	gatherer := cfg.Gatherer
+	if gatherer == nil {
+		gatherer = prometheus.DefaultGatherer
+	}
+	return &Service{
+		ctx:      ctx,
+		cancel:   cancel,
+		url:      cfg.URL,
+		period:   cfg.Period,
+		gatherer: gatherer,
+		client:   &http.Client{Timeout: cfg.Period},
+	}
+}
+
+// Start pushing the summary every period.
+func (s *Service) Start() {
+	log.WithField("url", s.url).Info("Pushing validator client stats")
+	runutil.RunEvery(s.ctx, s.period, func() {
+		err := s.push()
+		if err != nil {
+			log.WithError(err).Warn("Could not push validator client stats")
+		}
+		s.lock.Lock()
+		s.failStatus = err
+		s.lock.Unlock()
+	})
+}
+
+// Stop pushing the summary.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status returns the error of the last push, if it failed.
+func (s *Service) Status() error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.failStatus
+}
+
+func (s *Service) push() error {
+	summary, err := s.summary(time.Now())
+	if err != nil {
+		return err
+	}
+	enc, err := json.Marshal(summary)
+	if err != nil {
+		return errors.Wrap(err, "could not encode summary")
+	}
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(enc))
+	if err != nil {
+		return errors.Wrap(err, "could not create request")
+	}
+	req = req.WithContext(s.ctx)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "could not post summary")
+	}
+	if err := resp.Body.Close(); err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("monitoring service responded with status %s", resp.Status)
+	}
+	return nil
+}
+
+// summary builds the summary from the validator and process metrics of the gatherer.
+func (s *Service) summary(now time.Time) (*Summary, error) {
+	families, err := s.gatherer.Gather()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not gather metrics")
+	}
+	summary := &Summary{
+		Timestamp:  now.Unix(),
+		Version:    version.GetVersion(),
+		Validators: make(map[string]*ValidatorSummary),
+		Host:       &HostSummary{},
+	}
+	validator := func(m *dto.Metric) *ValidatorSummary {
+		var pubKey string
+		for _, l := range m.GetLabel() {
+			if l.GetName() == "pkey" {
+				pubKey = l.GetValue()
+			}
+		}
+		if summary.Validators[pubKey] == nil {
+			summary.Validators[pubKey] = &ValidatorSummary{}
+		}
+		return summary.Validators[pubKey]
+	}
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			switch f.GetName() {
+			case "validator_balance":
+				validator(m).Balance = m.GetGauge().GetValue()
+			case "validator_successful_attestations":
+				validator(m).SuccessfulAttestations = uint64(m.GetCounter().GetValue())
+			case "validator_failed_attestations":
+				validator(m).FailedAttestations = uint64(m.GetCounter().GetValue())
+			case "validator_successful_proposals":
+				validator(m).SuccessfulProposals = uint64(m.GetCounter().GetValue())
+			case "validator_failed_proposals":
+				validator(m).FailedProposals = uint64(m.GetCounter().GetValue())
+			case "validator_successful_aggregations":
+				validator(m).SuccessfulAggregations = uint64(m.GetCounter().GetValue())
+			case "validator_failed_aggregations":
+				validator(m).FailedAggregations = uint64(m.GetCounter().GetValue())
+			case "process_cpu_seconds_total":
+				summary.Host.CPUSeconds = m.GetCounter().GetValue()
+			case "process_resident_memory_bytes":
+				summary.Host.ResidentMemoryBytes = uint64(m.GetGauge().GetValue())
+			case "process_start_time_seconds":
+				if start := int64(m.GetGauge().GetValue()); start > 0 && start <= now.Unix() {
+					summary.Host.UptimeSeconds = uint64(now.Unix() - start)
+				}
+			case "go_goroutines":
+				summary.Host.Goroutines = uint64(m.GetGauge().GetValue())
+			}
+		}
+	}
+	return summary, nil
+}
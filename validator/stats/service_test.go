@@ -0,0 +1,84 @@
+package stats
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func testRegistry(t *testing.T) *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	balances := prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "validator", Name: "balance"}, []string{"pkey"})
+	attestations := prometheus.NewCounterVec(prometheus.CounterOpts{Namespace: "validator", Name: "successful_attestations"}, []string{"pkey"})
+	proposals := prometheus.NewCounterVec(prometheus.CounterOpts{Namespace: "validator", Name: "failed_proposals"}, []string{"pkey"})
+	goroutines := prometheus.NewGauge(prometheus.GaugeOpts{Name: "go_goroutines"})
+	for _, c := range []prometheus.Collector{balances, attestations, proposals, goroutines} {
+		if err := registry.Register(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	balances.WithLabelValues("0xaa").Set(32.5)
+	balances.WithLabelValues("0xbb").Set(31)
+	attestations.WithLabelValues("0xaa").Add(7)
+	proposals.WithLabelValues("0xbb").Inc()
+	goroutines.Set(42)
+	return registry
+}
+
+func TestService_Summary(t *testing.T) {
+	s := NewService(context.Background(), &Config{Period: time.Second, Gatherer: testRegistry(t)})
+	summary, err := s.summary(time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summary.Validators) != 2 {
+		t.Fatalf("Wanted 2 validators, received %d", len(summary.Validators))
+	}
+	if v := summary.Validators["0xaa"]; v.Balance != 32.5 || v.SuccessfulAttestations != 7 || v.FailedProposals != 0 {
+		t.Errorf("Unexpected summary of validator 0xaa: %+v", v)
+	}
+	if v := summary.Validators["0xbb"]; v.Balance != 31 || v.FailedProposals != 1 {
+		t.Errorf("Unexpected summary of validator 0xbb: %+v", v)
+	}
+	if summary.Host.Goroutines != 42 {
+		t.Errorf("Wanted 42 goroutines, received %d", summary.Host.Goroutines)
+	}
+}
+
+func TestService_Push(t *testing.T) {
+	received := make(chan *Summary, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		summary := &Summary{}
+		if err := json.NewDecoder(r.Body).Decode(summary); err != nil {
+			t.Error(err)
+		}
+		received <- summary
+	}))
+	defer srv.Close()
+
+	s := NewService(context.Background(), &Config{URL: srv.URL, Period: time.Second, Gatherer: testRegistry(t)})
+	if err := s.push(); err != nil {
+		t.Fatal(err)
+	}
+	summary := <-received
+	if summary.Validators["0xaa"].SuccessfulAttestations != 7 {
+		t.Errorf("Wanted pushed summary to contain the attestations of validator 0xaa, received %+v", summary.Validators["0xaa"])
+	}
+}
+
+func TestService_PushFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	s := NewService(context.Background(), &Config{URL: srv.URL, Period: time.Second, Gatherer: testRegistry(t)})
+	if err := s.push(); err == nil {
+		t.Error("Expected push to fail when the monitoring service is unavailable")
+	}
+}
@@ -44,6 +44,7 @@ var appHelpFlagGroups = []flagGroup{
 	{
 		Name: "cmd",
 		Flags: []cli.Flag{
+			cmd.ConfigFileFlag,
 			cmd.VerbosityFlag,
 			cmd.DataDirFlag,
 			cmd.ClearDB,
@@ -57,6 +58,7 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.LogFormat,
 			cmd.LogFileName,
 			cmd.EnableUPnPFlag,
+			cmd.MaxClockDisparityFlag,
 		},
 	},
 	{
@@ -76,6 +78,9 @@ var appHelpFlagGroups = []flagGroup{
 			flags.NoCustomConfigFlag,
 			flags.BeaconRPCProviderFlag,
 			flags.CertFlag,
+			flags.ClientCertFlag,
+			flags.ClientKeyFlag,
+			flags.BeaconRPCAPIKeyFlag,
 			flags.KeyManager,
 			flags.KeyManagerOpts,
 			flags.KeystorePathFlag,
@@ -85,7 +90,22 @@ var appHelpFlagGroups = []flagGroup{
 			flags.GraffitiFlag,
 			flags.GrpcMaxCallRecvMsgSizeFlag,
 			flags.GrpcRetriesFlag,
+			flags.GrpcHeadersFlag,
 			flags.AccountMetricsFlag,
+			flags.StatsPushURLFlag,
+			flags.StatsPushIntervalFlag,
+			flags.AlertURLFlag,
+			flags.AlertPagerDutyRoutingKeyFlag,
+			flags.AlertMissedAttestationsFlag,
+			flags.AlertMissedAttestationsEpochsFlag,
+			flags.AlertBalanceLossEpochsFlag,
+			flags.MinAttestationSourceEpochFlag,
+			flags.MinAttestationTargetEpochFlag,
+			flags.MinBlockSlotFlag,
+			flags.KeymanagerAPITokenFileFlag,
+			flags.KeymanagerAPIPortFlag,
+			flags.DutiesDryRunFlag,
+			flags.AuditLogFlag,
 		},
 	},
 	{